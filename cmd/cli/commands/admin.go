@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// AdminCmd groups operator commands that talk to the registry directly.
+// They read the same storage and database settings as serve.
+var AdminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Registry administration commands.",
+}
+
+var lifecycleCmd = &cobra.Command{
+	Use:   "lifecycle",
+	Short: "Manage bucket lifecycle rules.",
+}
+
+var lifecycleSyncCmd = &cobra.Command{
+	Use:           "sync",
+	Short:         "Translate retention and tiering settings into S3 lifecycle rules",
+	Example:       "aether admin lifecycle sync --ingress-expiry-days 7 --curated-transition-days 30 --dry-run",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runLifecycleSync,
+}
+
+var runJobCmd = &cobra.Command{
+	Use:   "run-job [name]",
+	Short: "Run a maintenance job once",
+	Long: "Run a single maintenance job once against the configured database and storage, for operators who schedule jobs with cron instead of serve.\n" +
+		"Without a name the available jobs are listed: partitions (partition upkeep and log retention), deletion, gc, metering, enrich-<name> (metadata backfill), digest-<algorithm> (digests besides sha256), replicate-<peer> and storage-events.\n" +
+		"With --dry-run the destructive jobs (partitions, deletion and gc) list what they would remove and the bytes it holds instead of running.",
+	Example:       "aether admin run-job\naether admin run-job gc\naether admin run-job gc --dry-run\naether admin run-job enrich-media --timeout 6h",
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runJob,
+}
+
+var migrateTagsCmd = &cobra.Command{
+	Use:   "migrate-tags",
+	Short: "Move key:value tag names to structured tags",
+	Long: "Parse tag names following the key:value convention into structured tags, renaming tags to their normalized name and merging duplicates with their asset associations in one transaction.\n" +
+		"Names with several separators, empty or invalid parts, or a merge target in another tag group are left untouched and listed as ambiguous for manual review.",
+	Example:       "aether admin migrate-tags --dry-run\naether admin migrate-tags",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runMigrateTags,
+}
+
+func init() {
+	AdminCmd.AddCommand(lifecycleCmd, runJobCmd, migrateTagsCmd)
+	lifecycleCmd.AddCommand(lifecycleSyncCmd)
+
+	lifecycleSyncCmd.Flags().Int32("ingress-expiry-days", registry.DEFAULT_INGRESS_EXPIRY_DAYS, "Expire uploads that were never promoted after this many days, 0 disables.")
+	lifecycleSyncCmd.Flags().Int32("abort-upload-days", registry.DEFAULT_ABORT_UPLOAD_DAYS, "Abort incomplete multipart uploads after this many days, 0 disables.")
+	lifecycleSyncCmd.Flags().Int32("curated-transition-days", 0, "Move curated objects to the curated storage class after this many days, 0 disables.")
+	lifecycleSyncCmd.Flags().String("curated-storage-class", "STANDARD_IA", "Storage class curated objects transition to.")
+	lifecycleSyncCmd.Flags().Bool("dry-run", false, "Print the rules without writing them")
+	runJobCmd.Flags().Bool("dry-run", false, "List what the job would remove without running it")
+	migrateTagsCmd.Flags().Bool("dry-run", false, "Report the migration without changing any tag")
+
+	viper.BindPFlag("server.storage.lifecycle.ingress_expiry_days", lifecycleSyncCmd.Flags().Lookup("ingress-expiry-days"))
+	viper.BindPFlag("server.storage.lifecycle.abort_upload_days", lifecycleSyncCmd.Flags().Lookup("abort-upload-days"))
+	viper.BindPFlag("server.storage.lifecycle.curated_transition_days", lifecycleSyncCmd.Flags().Lookup("curated-transition-days"))
+	viper.BindPFlag("server.storage.lifecycle.curated_storage_class", lifecycleSyncCmd.Flags().Lookup("curated-storage-class"))
+}
+
+func runLifecycleSync(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	policy := registry.LifecyclePolicy{
+		IngressExpiryDays:     viper.GetInt32("server.storage.lifecycle.ingress_expiry_days"),
+		AbortUploadDays:       viper.GetInt32("server.storage.lifecycle.abort_upload_days"),
+		CuratedTransitionDays: viper.GetInt32("server.storage.lifecycle.curated_transition_days"),
+		CuratedStorageClass:   strings.ToUpper(viper.GetString("server.storage.lifecycle.curated_storage_class")),
+	}
+
+	engine, err := initRegistry()
+	if err != nil {
+		return err
+	}
+
+	plans, err := engine.SyncLifecycle(cmd.Context(), policy, dryRun)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BUCKET\tRULE\tPREFIX\tACTION\tAPPLIED")
+	for _, plan := range plans {
+		for _, rule := range plan.Rules {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n",
+				plan.Bucket, aws.ToString(rule.ID), aws.ToString(rule.Filter.Prefix), describeLifecycleRule(rule), plan.Applied)
+		}
+		if plan.Foreign > 0 {
+			fmt.Fprintf(w, "%s\t(%d foreign rules kept)\t\t\t%t\n", plan.Bucket, plan.Foreign, plan.Applied)
+		}
+	}
+	w.Flush()
+
+	return err
+}
+
+func describeLifecycleRule(rule registry.LifecycleRule) string {
+	var actions []string
+	if rule.Expiration != nil {
+		actions = append(actions, fmt.Sprintf("expire after %dd", aws.ToInt32(rule.Expiration.Days)))
+	}
+	if rule.AbortIncompleteMultipartUpload != nil {
+		actions = append(actions, fmt.Sprintf("abort uploads after %dd", aws.ToInt32(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation)))
+	}
+	for _, t := range rule.Transitions {
+		actions = append(actions, fmt.Sprintf("%s after %dd", t.StorageClass, aws.ToInt32(t.Days)))
+	}
+	return strings.Join(actions, ", ")
+}
+
+func runJob(cmd *cobra.Command, args []string) error {
+	engine, err := initRegistry()
+	if err != nil {
+		return err
+	}
+
+	replicas, err := replicationJobs(engine)
+	if err != nil {
+		return err
+	}
+	events, err := storageEventJobs(cmd.Context(), data.NewService(engine))
+	if err != nil {
+		return err
+	}
+	jobs := append(append(engine.Jobs(), replicas...), events...)
+
+	if len(args) == 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "JOB\tINTERVAL")
+		for _, job := range jobs {
+			fmt.Fprintf(w, "%s\t%s\n", job.Name, job.Interval)
+		}
+		return w.Flush()
+	}
+
+	var names []string
+	for _, job := range jobs {
+		if job.Name != args[0] {
+			names = append(names, job.Name)
+			continue
+		}
+
+		ctx, cancel := commandContext(cmd)
+		defer cancel()
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			if job.Plan == nil {
+				return fmt.Errorf("job %s does not support dry runs", job.Name)
+			}
+			plan, err := job.Plan(ctx)
+			if err != nil {
+				return fmt.Errorf("job %s dry run failed: %w", job.Name, err)
+			}
+			return printPlan(plan)
+		}
+
+		slog.Info("running job", "job", job.Name)
+		started := time.Now()
+		if err := job.Run(ctx); err != nil {
+			return fmt.Errorf("job %s failed: %w", job.Name, err)
+		}
+		slog.Info("job completed", "job", job.Name, "duration", time.Since(started).Round(time.Millisecond).String())
+		return nil
+	}
+	return fmt.Errorf("unknown job %q, available jobs: %s", args[0], strings.Join(names, ", "))
+}
+
+// printPlan prints the candidates of a job dry run and their byte total
+func printPlan(plan *registry.DryRunReport) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tBYTES\tREASON")
+	for _, c := range plan.Candidates {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", c.Kind, c.Name, c.Bytes, c.Reason)
+	}
+	fmt.Fprintf(w, "TOTAL\t%d\t%d\t\n", len(plan.Candidates), plan.Bytes)
+	return w.Flush()
+}
+
+func runMigrateTags(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	engine, err := initRegistry()
+	if err != nil {
+		return err
+	}
+
+	report, err := engine.MigrateTagNames(dryRun)
+	if err != nil {
+		return err
+	}
+
+	var ambiguous int
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tTAG\tACTION\tTARGET\tASSETS\tREASON")
+	for _, m := range report {
+		if m.Action == registry.TagMigrationAmbiguous {
+			ambiguous++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", m.Project, m.Name, m.Action, m.Target, m.Assets, m.Reason)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if ambiguous > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d tags are ambiguous and need manual review\n", ambiguous, len(report))
+	}
+	return nil
+}
@@ -1,16 +1,19 @@
 package commands
 
 import (
-	"context"
-	"time"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
 
 	"github.com/UnivocalX/aether/pkg/client"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
-const (
-	DefaultTimeoutSeconds = 3600
-)
+// UserAgent identifies the CLI to the server, set by the root command
+var UserAgent = "aether-cli"
 
 // AssetsCmd represents the assets command
 var AssetsCmd = &cobra.Command{
@@ -23,7 +26,7 @@ var loadCmd = &cobra.Command{
 	Use:           "load <path>",
 	Short:         "Load assets",
 	Long:          "Load assets to the Aether platform",
-	Example:       "aether assets load data/",
+	Example:       "aether assets load data/\naether assets load 'data/*.jpg' --ci --report report.json",
 	Args:          cobra.MinimumNArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -33,33 +36,168 @@ var loadCmd = &cobra.Command{
 	RunE: runLoadAssets,
 }
 
+// deleteCmd represents the assets delete command
+var deleteCmd = &cobra.Command{
+	Use:           "delete <checksum...>",
+	Short:         "Move assets to the trash",
+	Example:       "aether assets delete <sha256>\naether assets delete <sha256> --purge",
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runDeleteAssets,
+}
+
+// streamCmd represents the assets stream command
+var streamCmd = &cobra.Command{
+	Use:           "stream <display>",
+	Short:         "Load an asset from stdin",
+	Long:          "Upload stdin as a single asset, hashing it while it streams so the data is only read once",
+	Example:       "tar -c logs/ | aether assets stream logs.tar\ngenerate-samples | aether assets stream samples.jsonl --mime-type application/jsonl",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runStreamAsset,
+}
+
 func init() {
-	AssetsCmd.AddCommand(loadCmd)
+	AssetsCmd.AddCommand(loadCmd, deleteCmd, streamCmd)
+	streamCmd.Flags().String("mime-type", "", "Asset mime type (detected from the content when unset)")
+	streamCmd.Flags().Int64("size", -1, "Stream length in bytes, required by S3 storage")
+	streamCmd.Flags().Duration("url-ttl", 0, "Requested upload url expiry (server default when unset)")
+	deleteCmd.Flags().Bool("purge", false, "Also remove the stored objects")
+	loadCmd.Flags().Duration("url-ttl", 0, "Requested upload url expiry (server default when unset)")
+	addReportFlag(loadCmd)
+	addFailOnFlag(loadCmd, deleteCmd)
 	AssetsCmd.PersistentFlags().Bool("ci", false, "Disable user interaction and progress bars")
-	AssetsCmd.PersistentFlags().Int("timeout", DefaultTimeoutSeconds, "Command timeout in seconds")
 }
 
 func runLoadAssets(cmd *cobra.Command, args []string) error {
-	ci, _ := cmd.Flags().GetBool("ci")
-	timeout, _ := cmd.Flags().GetInt("timeout")
-	host, _ := cmd.Flags().GetString("host")
+	ttl, _ := cmd.Flags().GetDuration("url-ttl")
+	report := commandReport(cmd, "load")
 
-	// create aether client
-	aether, err := client.New(
-		client.WithDurable(!ci),
-		client.WithHost(host),
-	)
+	policy, err := failurePolicy(cmd)
+	if err != nil {
+		return err
+	}
 
+	// create aether client
+	aether, err := newClient(cmd, client.WithPresignTTL(ttl), client.WithReport(report), client.WithFailurePolicy(policy))
 	if err != nil {
 		return err
 	}
 
 	// run analyze
-	ctx, cancel := context.WithTimeout(
-		context.Background(),
-		time.Duration(timeout)*time.Second,
-	)
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	err = writeReport(cmd, report, aether.LoadAssets(ctx, args[0]))
+	return applyFailurePolicy(policy, err)
+}
+
+func runStreamAsset(cmd *cobra.Command, args []string) error {
+	mimeType, _ := cmd.Flags().GetString("mime-type")
+	size, _ := cmd.Flags().GetInt64("size")
+	ttl, _ := cmd.Flags().GetDuration("url-ttl")
+
+	aether, err := newClient(cmd, client.WithPresignTTL(ttl))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(cmd)
 	defer cancel()
 
-	return aether.LoadAssets(ctx, args[0])
+	asset := v1.AssetPayload{Display: args[0], MimeType: mimeType}
+	response, err := aether.StreamAsset(ctx, os.Stdin, size, asset)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(response.Checksum)
+	return nil
+}
+
+func runDeleteAssets(cmd *cobra.Command, args []string) error {
+	purge, _ := cmd.Flags().GetBool("purge")
+
+	policy, err := failurePolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	aether, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	partial := &client.PartialFailureError{Operation: "delete", Total: len(args)}
+	for _, checksum := range args {
+		deletion, err := aether.DeleteAsset(ctx, checksum, purge)
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			slog.Error("failed to delete asset", "checksum", checksum, "error", err)
+			partial.Failed++
+			if partial.Err == nil {
+				partial.Err = fmt.Errorf("delete asset %s: %w", checksum, err)
+			}
+			continue
+		}
+		if deletion != nil {
+			slog.Info("asset is referenced by datasets, deletion scheduled", "checksum", checksum, "purge", purge, "after", deletion.PurgeAfter)
+			continue
+		}
+		slog.Info("deleted asset", "checksum", checksum, "purge", purge)
+	}
+
+	if partial.Failed > 0 {
+		return applyFailurePolicy(policy, partial)
+	}
+	return nil
+}
+
+// newClient creates an aether client from the command flags
+func newClient(cmd *cobra.Command, opts ...client.Option) (*client.Client, error) {
+	ci, _ := cmd.Flags().GetBool("ci")
+	host, _ := cmd.Flags().GetString("host")
+	headers, _ := cmd.Flags().GetStringArray("header")
+
+	// comma separated hosts enable failover between api nodes
+	hosts := strings.Split(host, ",")
+	for i := range hosts {
+		hosts[i] = strings.TrimSpace(hosts[i])
+	}
+
+	base := []client.Option{
+		client.WithDurable(!ci),
+		client.WithEndpoints(hosts...),
+		client.WithUserAgent(UserAgent),
+	}
+	base = append(base, deadlineOptions(cmd)...)
+	if provider := clientTracing(); provider != nil {
+		base = append(base, client.WithTracing(provider))
+	}
+	if key := viper.GetString("api-key"); key != "" {
+		base = append(base, client.WithAPIKey(key))
+	}
+	if project := viper.GetString("project"); project != "" {
+		base = append(base, client.WithProject(project))
+	}
+	if ttl := viper.GetDuration("cache-ttl"); ttl > 0 {
+		base = append(base, client.WithCache(ttl, viper.GetInt("cache-size")))
+	}
+
+	for _, header := range headers {
+		key, value, ok := strings.Cut(header, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q, expected key=value", header)
+		}
+		base = append(base, client.WithHeader(strings.TrimSpace(key), strings.TrimSpace(value)))
+	}
+
+	return client.New(append(base, opts...)...)
 }
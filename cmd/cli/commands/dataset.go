@@ -0,0 +1,263 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// DatasetsCmd represents the datasets command
+var DatasetsCmd = &cobra.Command{
+	Use:   "datasets",
+	Short: "Manage and interact with datasets.",
+}
+
+// bundleCmd represents the datasets bundle command
+var bundleCmd = &cobra.Command{
+	Use:           "bundle <dataset> <version> <target>",
+	Short:         "Export a published dataset version for offline transfer",
+	Long:          "Package the manifest and every asset object of a published dataset version into a directory, or a tar archive when the target ends with .tar",
+	Example:       "aether datasets bundle images 3 images-v3/\naether datasets bundle images 3 images-v3.tar",
+	Args:          cobra.ExactArgs(3),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runBundle,
+}
+
+// importBundleCmd represents the datasets import-bundle command
+var importBundleCmd = &cobra.Command{
+	Use:           "import-bundle <source>",
+	Short:         "Import a dataset bundle",
+	Long:          "Register and upload every asset of a bundle, then recreate its dataset version as a draft. Rerunning an interrupted import only uploads what is missing",
+	Example:       "aether datasets import-bundle images-v3.tar\naether datasets import-bundle images-v3/ --dataset images-offline",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runImportBundle,
+}
+
+// pullCmd represents the datasets pull command
+var pullCmd = &cobra.Command{
+	Use:           "pull <dataset> <version> <dir>",
+	Short:         "Materialize a published dataset version",
+	Long:          "Download the assets of a published dataset version into a directory, filters make the server compute the slice to pull",
+	Example:       "aether datasets pull images 3 data/\naether datasets pull images 3 data/ --tag train --mime-type image/* --prefix cam1_\naether datasets pull images 3 data/ --max-bytes 10000000000",
+	Args:          cobra.ExactArgs(3),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runPull,
+}
+
+// statusCmd represents the datasets status command
+var statusCmd = &cobra.Command{
+	Use:           "status [dir]",
+	Short:         "Show local changes of a pulled dataset version",
+	Long:          "Compare a directory materialized by pull against the dataset version it was pulled from, listing modified, missing and untracked files",
+	Example:       "aether datasets status\naether datasets status data/",
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runStatus,
+}
+
+// readmeCmd represents the datasets readme command
+var readmeCmd = &cobra.Command{
+	Use:           "readme <dataset>",
+	Short:         "Show or replace a dataset readme",
+	Long:          "Print the markdown readme of a dataset, or replace it with the content of a file. An empty file removes the readme",
+	Example:       "aether datasets readme images\naether datasets readme images --set README.md",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runReadme,
+}
+
+func init() {
+	DatasetsCmd.AddCommand(bundleCmd, importBundleCmd, pullCmd, statusCmd, readmeCmd)
+	pullCmd.Flags().StringArray("tag", nil, "Only pull assets carrying this tag, repeatable")
+	pullCmd.Flags().StringArray("mime-type", nil, "Only pull assets of this mime type, e.g. image/*, repeatable")
+	pullCmd.Flags().String("prefix", "", "Only pull assets whose display name starts with this prefix")
+	pullCmd.Flags().Int64("max-bytes", 0, "Stop before the asset that would exceed this many bytes, the workspace is marked partial")
+	importBundleCmd.Flags().String("dataset", "", "Import into this dataset instead of the bundled dataset name")
+	readmeCmd.Flags().String("set", "", "Replace the readme with the content of this markdown file, - reads stdin")
+	addReportFlag(bundleCmd, importBundleCmd, pullCmd)
+	addFailOnFlag(pullCmd)
+	DatasetsCmd.PersistentFlags().Bool("ci", false, "Disable user interaction and progress bars")
+}
+
+func runBundle(cmd *cobra.Command, args []string) error {
+	version, err := parseVersion(args[1])
+	if err != nil {
+		return err
+	}
+
+	report := commandReport(cmd, "bundle")
+	aether, err := newClient(cmd, client.WithReport(report))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	manifest, err := aether.ExportBundle(ctx, args[0], version, args[2])
+	if err := writeReport(cmd, report, err); err != nil {
+		return err
+	}
+
+	slog.Info("bundle ready", "target", args[2], "digest", manifest.Digest)
+	return nil
+}
+
+func runPull(cmd *cobra.Command, args []string) error {
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	mimeTypes, _ := cmd.Flags().GetStringArray("mime-type")
+	prefix, _ := cmd.Flags().GetString("prefix")
+	maxBytes, _ := cmd.Flags().GetInt64("max-bytes")
+
+	version, err := parseVersion(args[1])
+	if err != nil {
+		return err
+	}
+	if maxBytes < 0 {
+		return fmt.Errorf("invalid max bytes %d, expected a positive number", maxBytes)
+	}
+
+	policy, err := failurePolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	report := commandReport(cmd, "pull")
+	aether, err := newClient(cmd, client.WithReport(report))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	filter := &registry.ManifestFilter{Tags: tags, MimeTypes: mimeTypes, Prefix: prefix}
+	manifest, err := aether.PullDataset(ctx, args[0], version, args[2], filter, maxBytes)
+	if err := writeReport(cmd, report, err); err != nil {
+		return applyFailurePolicy(policy, err)
+	}
+
+	slog.Info("dataset ready", "dir", args[2], "dataset", manifest.Dataset, "version", manifest.Version)
+	return nil
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	ws, err := client.LoadWorkspace(dir)
+	if err != nil {
+		return err
+	}
+
+	drift, err := ws.Drift(dir)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("workspace", "dataset", ws.Dataset, "version", ws.Version, "partial", ws.Partial, "pulled_at", ws.PulledAt, "files", len(ws.Files))
+	if drift.Clean() {
+		slog.Info("no local changes")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tPATH")
+	for _, name := range drift.Modified {
+		fmt.Fprintf(w, "modified\t%s\n", name)
+	}
+	for _, name := range drift.Missing {
+		fmt.Fprintf(w, "missing\t%s\n", name)
+	}
+	for _, name := range drift.Untracked {
+		fmt.Fprintf(w, "untracked\t%s\n", name)
+	}
+	w.Flush()
+
+	slog.Info("local changes", "modified", len(drift.Modified), "missing", len(drift.Missing), "untracked", len(drift.Untracked))
+	return nil
+}
+
+func runReadme(cmd *cobra.Command, args []string) error {
+	source, _ := cmd.Flags().GetString("set")
+
+	aether, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	if source == "" {
+		response, err := aether.GetDatasetReadme(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Print(response.Readme)
+		return nil
+	}
+
+	var readme []byte
+	if source == "-" {
+		readme, err = io.ReadAll(os.Stdin)
+	} else {
+		readme, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return fmt.Errorf("read readme: %w", err)
+	}
+
+	response, err := aether.SetDatasetReadme(ctx, args[0], string(readme))
+	if err != nil {
+		return err
+	}
+
+	slog.Info("readme updated", "dataset", response.Dataset, "bytes", len(response.Readme))
+	return nil
+}
+
+func runImportBundle(cmd *cobra.Command, args []string) error {
+	dataset, _ := cmd.Flags().GetString("dataset")
+
+	report := commandReport(cmd, "import-bundle")
+	aether, err := newClient(cmd, client.WithReport(report))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	dsv, err := aether.ImportBundle(ctx, args[0], dataset)
+	if err := writeReport(cmd, report, err); err != nil {
+		return err
+	}
+
+	slog.Info("bundle imported as a draft version, submit it for review to publish", "dataset", dsv.Dataset, "version", dsv.Version)
+	return nil
+}
+
+// parseVersion reads a dataset version number argument
+func parseVersion(arg string) (int, error) {
+	version, err := strconv.Atoi(arg)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid version %q, expected a positive number", arg)
+	}
+	return version, nil
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// Process exit codes
+const (
+	ExitOK = 0
+	// ExitFailure is any error that stopped the command
+	ExitFailure = 1
+	// ExitPartialFailure means the command went through but more items failed than its --fail-on policy tolerates
+	ExitPartialFailure = 2
+)
+
+// ExitError is a command error with a specific exit code
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string { return e.Err.Error() }
+func (e *ExitError) Unwrap() error { return e.Err }
+
+// ExitCode maps a command error to the process exit code
+func ExitCode(err error) int {
+	var exitErr *ExitError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &exitErr):
+		return exitErr.Code
+	default:
+		return ExitFailure
+	}
+}
+
+// addFailOnFlag lets a batch command choose how many failed items fail the job
+func addFailOnFlag(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		cmd.Flags().String("fail-on", client.FailOnAny, "When failed items fail the command with exit code 2: any, none or threshold=<percent>, e.g. threshold=5%")
+	}
+}
+
+// failurePolicy parses the fail-on flag of a command
+func failurePolicy(cmd *cobra.Command) (client.FailurePolicy, error) {
+	raw, _ := cmd.Flags().GetString("fail-on")
+	return client.ParseFailurePolicy(raw)
+}
+
+// applyFailurePolicy turns a partial failure into a success when the policy tolerates it,
+// or into an ExitPartialFailure error when it doesn't. Other errors are returned as is.
+func applyFailurePolicy(policy client.FailurePolicy, err error) error {
+	var partial *client.PartialFailureError
+	if !errors.As(err, &partial) {
+		return err
+	}
+
+	if policy.Tolerates(partial.Failed, partial.Total) {
+		slog.Warn("some items failed, tolerated by the failure policy", "operation", partial.Operation, "failed", partial.Failed, "total", partial.Total, "fail_on", policy.String())
+		return nil
+	}
+	return &ExitError{Code: ExitPartialFailure, Err: err}
+}
@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// KeysCmd manages api keys. Like admin it talks to the registry directly,
+// so the first key can be issued before the server requires one.
+var KeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Issue, list and revoke api keys.",
+}
+
+var keysCreateCmd = &cobra.Command{
+	Use:           "create <name>",
+	Short:         "Issue an api key, the key is printed once",
+	Example:       "aether keys create ci --scopes read,write\naether keys create ops --scopes admin --roles confidential --expires 720h",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runKeysCreate,
+}
+
+var keysListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List api keys, revoked ones included",
+	Example:       "aether keys list",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runKeysList,
+}
+
+var keysRevokeCmd = &cobra.Command{
+	Use:           "revoke <name>",
+	Short:         "Revoke an api key",
+	Example:       "aether keys revoke ci",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runKeysRevoke,
+}
+
+func init() {
+	KeysCmd.AddCommand(keysCreateCmd, keysListCmd, keysRevokeCmd)
+
+	keysCreateCmd.Flags().String("scopes", registry.ScopeRead, "Comma separated scopes: read, write (includes read) or admin (includes both).")
+	keysCreateCmd.Flags().String("roles", "", "Comma separated roles of the key, e.g. confidential.")
+	keysCreateCmd.Flags().String("project", "", "Project the key works in, the default project when empty.")
+	keysCreateCmd.Flags().Duration("expires", 0, "Expire the key after this long, 0 never expires.")
+}
+
+func runKeysCreate(cmd *cobra.Command, args []string) error {
+	scopes, _ := cmd.Flags().GetString("scopes")
+	roles, _ := cmd.Flags().GetString("roles")
+	project, _ := cmd.Flags().GetString("project")
+	expires, _ := cmd.Flags().GetDuration("expires")
+
+	svc, err := keysService()
+	if err != nil {
+		return err
+	}
+
+	key := &registry.APIKey{
+		Name:    args[0],
+		Scopes:  splitList(scopes),
+		Roles:   splitList(roles),
+		Project: project,
+	}
+	if expires > 0 {
+		at := time.Now().Add(expires)
+		key.ExpiresAt = &at
+	}
+
+	secret, err := svc.CreateAPIKey(keysContext(cmd), key)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "created api key %s with scopes %s, store it now, it is not shown again\n", key.Name, strings.Join(key.Scopes, ","))
+	fmt.Println(secret.Value())
+	return nil
+}
+
+func runKeysList(cmd *cobra.Command, args []string) error {
+	svc, err := keysService()
+	if err != nil {
+		return err
+	}
+
+	keys, err := svc.ListAPIKeys(keysContext(cmd))
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPREFIX\tSCOPES\tROLES\tLAST USED\tSTATE")
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			key.Name, key.Prefix, strings.Join(key.Scopes, ","), strings.Join(key.Roles, ","), formatTime(key.LastUsedAt), keyState(key))
+	}
+	return w.Flush()
+}
+
+func runKeysRevoke(cmd *cobra.Command, args []string) error {
+	svc, err := keysService()
+	if err != nil {
+		return err
+	}
+
+	key, err := svc.RevokeAPIKey(keysContext(cmd), args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("revoked api key %s\n", key.Name)
+	return nil
+}
+
+func keysService() (*data.Service, error) {
+	engine, err := initRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return data.NewService(engine), nil
+}
+
+// keysContext attributes key changes to the cli
+func keysContext(cmd *cobra.Command) context.Context {
+	return data.WithPrincipal(cmd.Context(), data.Principal{ID: "cli", UserAgent: UserAgent})
+}
+
+func keyState(key *registry.APIKey) string {
+	switch {
+	case key.RevokedAt != nil:
+		return "revoked"
+	case !key.Active(time.Now()):
+		return "expired"
+	case key.ExpiresAt != nil:
+		return "expires " + key.ExpiresAt.Format(time.RFC3339)
+	default:
+		return "active"
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/UnivocalX/aether/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var loadtestCmd = &cobra.Command{
+	Use:   "loadtest",
+	Short: "Send synthetic traffic to a server and report latency percentiles",
+	Long: "Send synthetic asset registrations, ingress presigns and existence lookups to the --host server at a fixed rate and report the latency percentiles of each operation.\n" +
+		"Registrations create real pending assets with random checksums, point it at a test registry.\n" +
+		"Requests are not retried so server throttling shows up as errors.",
+	Example:       "aether admin loadtest --rate 50 --duration 1m\naether admin loadtest --host staging:8080 --rate 200 --concurrency 32 --mix register=1,presign=2,lookup=5",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runLoadtest,
+}
+
+func init() {
+	AdminCmd.AddCommand(loadtestCmd)
+
+	loadtestCmd.Flags().Float64("rate", 10, "Operations started per second")
+	loadtestCmd.Flags().Duration("duration", 30*time.Second, "How long to send traffic")
+	loadtestCmd.Flags().Int("concurrency", 8, "Number of concurrent workers")
+	loadtestCmd.Flags().StringToInt("mix", map[string]int{client.LoadRegister: 1, client.LoadPresign: 1, client.LoadLookup: 2}, "Relative weight of the register, presign and lookup operations")
+}
+
+func runLoadtest(cmd *cobra.Command, args []string) error {
+	rate, _ := cmd.Flags().GetFloat64("rate")
+	duration, _ := cmd.Flags().GetDuration("duration")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	mix, _ := cmd.Flags().GetStringToInt("mix")
+
+	// retries would hide the latency and throttling the test is measuring
+	aether, err := newClient(cmd, client.WithDurable(false))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	result, err := aether.LoadTest(ctx, client.LoadTestConfig{
+		Rate:        rate,
+		Duration:    duration,
+		Concurrency: concurrency,
+		Mix:         mix,
+	})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "OPERATION\tCOUNT\tERRORS\tMEAN\tP50\tP90\tP99\tMAX")
+	for _, op := range result.Operations {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			op.Operation, op.Count, op.Errors, roundLatency(op.Mean), roundLatency(op.P50), roundLatency(op.P90), roundLatency(op.P99), roundLatency(op.Max))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%.1f ops/s over %s (target %.1f ops/s)\n", result.Throughput, result.Elapsed.Round(time.Millisecond), rate)
+	return nil
+}
+
+func roundLatency(d time.Duration) string {
+	return d.Round(100 * time.Microsecond).String()
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// addReportFlag lets a long running command write a JSON summary for CI artifacts
+func addReportFlag(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		cmd.Flags().String("report", "", "Write a JSON summary of every file outcome to this path")
+	}
+}
+
+// commandReport returns the report of a command, nil when the report flag is unset
+func commandReport(cmd *cobra.Command, operation string) *client.Report {
+	if path, _ := cmd.Flags().GetString("report"); path == "" {
+		return nil
+	}
+	return client.NewReport(operation)
+}
+
+// writeReport writes the report with the outcome of the command, the command error takes precedence
+func writeReport(cmd *cobra.Command, report *client.Report, err error) error {
+	if report == nil {
+		return err
+	}
+
+	path, _ := cmd.Flags().GetString("report")
+	if writeErr := report.Write(path, err); writeErr != nil {
+		slog.Error("failed to write report", "path", path, "error", writeErr)
+		if err == nil {
+			return writeErr
+		}
+		return err
+	}
+
+	slog.Info("report written", "path", path)
+	return err
+}
@@ -1,8 +1,13 @@
 package commands
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -10,6 +15,9 @@ import (
 	"github.com/UnivocalX/aether/internal/logging"
 	"github.com/UnivocalX/aether/pkg/web"
 	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/replication"
+	"github.com/UnivocalX/aether/pkg/ingest"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
 )
 
 // ServeCmd represents the serve command
@@ -24,22 +32,106 @@ var ServeCmd = &cobra.Command{
 func init() {
 	// Storage
 	ServeCmd.Flags().Int("port", 8080, "Port to run the server on")
+	ServeCmd.Flags().Int("grpc-port", 0, "Port to serve the gRPC api on, 0 disables it.")
+	ServeCmd.Flags().String("tls-cert", "", "PEM certificate the server terminates TLS with, plain http when empty.")
+	ServeCmd.Flags().String("tls-key", "", "PEM private key of the TLS certificate.")
+	ServeCmd.Flags().String("tls-client-ca", "", "PEM CA bundle client certificates must be signed by, requests without one are refused except health probes.")
+	ServeCmd.Flags().String("trusted-proxies", "", "Comma separated proxy addresses or CIDRs whose X-Forwarded-For and X-Real-IP headers name the client, none when empty.")
+	ServeCmd.Flags().Duration("drain-timeout", web.DEFAULT_DRAIN_TIMEOUT, "How long shutdown waits for in-flight requests before closing their connections.")
+	ServeCmd.Flags().Duration("startup-wait", time.Minute, "How long startup retries connecting to the database and storage before exiting, 0 exits on the first failure.")
+	ServeCmd.Flags().Duration("startup-backoff", registry.DEFAULT_STARTUP_BACKOFF, "Delay before the first startup retry, doubled after each attempt.")
+	ServeCmd.Flags().String("storage-backend", registry.STORAGE_S3, "Storage backend, s3 or filesystem.")
 	ServeCmd.Flags().String("s3endpoint", "", "S3 endpoint")
 	ServeCmd.Flags().String("bucket", "", "S3 bucket.")
 	ServeCmd.Flags().String("prefix", "aether", "S3 prefix.")
+	ServeCmd.Flags().String("storage-path", "", "Directory of the filesystem storage backend.")
+	ServeCmd.Flags().String("storage-url", "", "Public api url filesystem storage urls point at, e.g. http://aether:8080/api.")
+	ServeCmd.Flags().String("storage-signing-key", "", "Key signing filesystem storage urls, shared by every replica. Random when empty.")
+	ServeCmd.Flags().Int64("storage-max-object-size", registry.MAX_COPY_SIZE, "Largest upload in bytes filesystem storage accepts when its size is unknown, e.g. to an unsized signed url.")
+	ServeCmd.Flags().Duration("presign-ttl", registry.DEFAULT_PRESIGN_TTL, "Default presigned url expiry, within the presign min and max ttl.")
+	ServeCmd.Flags().Duration("presign-min-ttl", registry.DEFAULT_PRESIGN_MIN, "Minimum presigned url expiry clients may request.")
+	ServeCmd.Flags().Duration("presign-max-ttl", registry.DEFAULT_PRESIGN_MAX, "Maximum presigned url expiry clients may request.")
+	ServeCmd.Flags().Duration("presign-skew", registry.DEFAULT_PRESIGN_SKEW, "Extra validity of presigned urls before signing and after expiry, tolerates skewed clocks.")
+	ServeCmd.Flags().Duration("gc-interval", registry.DEFAULT_GC_INTERVAL, "How often orphaned ingress objects are collected, 0 disables.")
+	ServeCmd.Flags().Duration("gc-pending-ttl", registry.DEFAULT_GC_PENDING_TTL, "How long pending assets keep their uploaded ingress object, 0 keeps it forever.")
+	ServeCmd.Flags().Duration("gc-deletion-grace", registry.DEFAULT_DELETION_GRACE, "How long deletions of assets referenced by datasets wait for objections.")
+	ServeCmd.Flags().String("storage-events-token", "", "Token bucket notification webhooks (MinIO) authorize with at /api/v1/storage-events, disabled when empty.")
+	ServeCmd.Flags().String("storage-events-queue", "", "SQS queue url receiving the bucket ObjectCreated notifications, uploads are finalized from it. Disabled when empty.")
+	ServeCmd.Flags().Duration("storage-events-interval", ingest.DEFAULT_INTERVAL, "How often the storage events queue is drained.")
+	ServeCmd.Flags().Int("storage-breaker-threshold", registry.DEFAULT_BREAKER_THRESHOLD, "Consecutive storage failures after which storage calls fail fast with 503 while metadata stays readable, 0 disables.")
+	ServeCmd.Flags().Duration("storage-breaker-cooldown", registry.DEFAULT_BREAKER_COOLDOWN, "How long storage calls fail fast before a trial call checks the storage again.")
 
 	// Database
+	ServeCmd.Flags().String("db-driver", registry.DEFAULT_DATABASE_DRIVER, "Database driver, postgres or sqlite.")
 	ServeCmd.Flags().String("db-endpoint", "localhost:5432", "Database port.")
 	ServeCmd.Flags().String("db-user", "postgres", "")
 	ServeCmd.Flags().String("db-password", "changeme", "Port to run the server on")
-	ServeCmd.Flags().String("db-name", "postgres", "Database name.")
+	ServeCmd.Flags().String("db-name", "postgres", "Database name, the database file with sqlite.")
 	ServeCmd.Flags().Bool("ssl", false, "Database SSL.")
+	ServeCmd.Flags().Bool("db-prepare-statements", false, "Prepare each distinct statement once per connection and reuse it.")
+	ServeCmd.Flags().Int("db-statement-cache-size", 1000, "Prepared statements cached per replica, 0 is unbounded.")
+	ServeCmd.Flags().Duration("db-statement-cache-ttl", time.Hour, "How long an unused prepared statement stays cached, 0 is a day.")
+	ServeCmd.Flags().Bool("db-simple-protocol", false, "Send statements unprepared, for poolers in transaction mode such as pgbouncer.")
+	ServeCmd.Flags().Duration("metering-interval", registry.DEFAULT_METERING_INTERVAL, "How often project usage of the running month is metered, 0 disables.")
+	ServeCmd.Flags().Duration("event-retention", registry.DEFAULT_EVENT_RETENTION, "How long event partitions are kept, 0 keeps them forever.")
+	ServeCmd.Flags().Duration("access-log-retention", registry.DEFAULT_ACCESS_LOG_RETENTION, "How long presign access log partitions are kept, 0 keeps them forever.")
+	ServeCmd.Flags().Duration("audit-retention", registry.DEFAULT_AUDIT_RETENTION, "How long audit log partitions are kept, 0 keeps them forever.")
+	ServeCmd.Flags().Duration("idempotency-ttl", registry.DEFAULT_IDEMPOTENCY_TTL, "How long responses to requests with an Idempotency-Key are replayed to retries.")
+	ServeCmd.Flags().Duration("slow-query-threshold", 0, "Log the query plan of list and search queries slower than this at warn level, 0 disables.")
 	ServeCmd.Flags().Bool("production", false, "Run in production mode (enables JSON logging)")
+	ServeCmd.Flags().String("page-token-key", "", "Key signing list page tokens, shared by every replica. Random when empty.")
 
-	bindServeFlags()
+	// Enumeration protection
+	ServeCmd.Flags().Int("probe-limit", 0, "Lookups of unknown assets allowed per principal within the probe window, 0 disables.")
+	ServeCmd.Flags().Duration("probe-window", time.Minute, "Window for the unknown asset lookup quota.")
+	ServeCmd.Flags().Bool("hide-missing-assets", false, "Report unknown assets as forbidden instead of not found.")
+
+	// Presign rate
+	ServeCmd.Flags().Int("presign-warn-rate", 0, "Presigned urls per principal and operation within the rate window before a warning event, 0 disables.")
+	ServeCmd.Flags().Int("presign-max-rate", 0, "Presigned urls per principal and operation within the rate window before throttling, 0 disables.")
+	ServeCmd.Flags().Duration("presign-rate-window", time.Minute, "Window for the presign issuance rate.")
+	ServeCmd.Flags().Int("rate-limit", 0, "Requests per second each api key, or client address without one, may make before 429 responses, 0 disables.")
+	ServeCmd.Flags().Int("rate-burst", 20, "Requests a caller may make at once above the rate limit.")
+
+	// Authentication
+	ServeCmd.Flags().Bool("require-api-keys", false, "Reject api requests without an api key, keys are issued with aether keys create. Keys sent are always checked.")
+	ServeCmd.Flags().Bool("authorize", false, "Check every api request against the principal roles: reader, contributor, curator or admin. Roles come from api keys and aether role assignments.")
+	ServeCmd.Flags().String("default-role", "", "Role of principals without an authorization role when --authorize is set, none when empty.")
+
+	// Export controls
+	ServeCmd.Flags().String("confidential-roles", "", "Comma separated roles allowed to download confidential assets, defaults to the confidential role.")
+	ServeCmd.Flags().String("pii-roles", "", "Comma separated roles allowed to download pii assets, defaults to the pii role.")
+
+	// Enrichment
+	ServeCmd.Flags().Bool("suggest-tags", false, "Suggest tags for new assets from their display path and extension.")
+	ServeCmd.Flags().Duration("media-interval", registry.DEFAULT_MEDIA_INTERVAL, "How often metadata of ready media assets is extracted, 0 disables.")
+	ServeCmd.Flags().Duration("preview-interval", 0, "How often previews of ready images are generated for ?expand=previews listings, 0 disables.")
+	ServeCmd.Flags().Int("preview-size", registry.DEFAULT_PREVIEW_SIZE, "Longest side of generated previews in pixels.")
+	ServeCmd.Flags().String("ffprobe", "", "Path of the ffprobe binary extracting audio and video metadata, disabled when empty.")
+	ServeCmd.Flags().Duration("content-interval", 0, "How often text of ready document assets is indexed for content search, 0 disables.")
+	ServeCmd.Flags().String("pdftotext", "", "Path of the pdftotext binary extracting pdf text, disabled when empty.")
+	ServeCmd.Flags().String("enrichers", "", "Comma separated external enrichers as name=path or name@mime|mime=path, e.g. faces@image/*=/opt/bin/faces.")
+	ServeCmd.Flags().Duration("enrich-interval", registry.DEFAULT_ENRICH_INTERVAL, "How often external enrichers run over ready assets, 0 disables.")
+	ServeCmd.Flags().String("digest-algorithms", "", "Comma separated digest algorithms computed for ready assets besides sha256, e.g. sha512,sha3-256.")
+	ServeCmd.Flags().Duration("digest-interval", registry.DEFAULT_DIGEST_INTERVAL, "How often digests of ready assets are computed, 0 disables.")
+
+	// Replication
+	ServeCmd.Flags().String("replication-name", "", "Name this instance signs replication requests with.")
+	ServeCmd.Flags().String("replication-key", "", "Key signing replication requests, shared by the peer instances. Replication is disabled when empty.")
+	ServeCmd.Flags().String("replication-peers", "", "Comma separated peers to pull from as name=url or name@replica=url, e.g. eu=https://aether-eu:8080/api. Peers are sources of truth unless marked replica.")
+	ServeCmd.Flags().Duration("replication-interval", registry.DEFAULT_REPLICATION_INTERVAL, "How often changes of the replication peers are pulled, 0 disables.")
+
+	// Tracing
+	ServeCmd.Flags().String("otlp-endpoint", "", "OpenTelemetry collector receiving request traces over OTLP/HTTP, e.g. http://otel-collector:4318. Disabled when empty.")
+
+	bindServeSettings()
 }
 
 func startServer(cmd *cobra.Command, args []string) error {
+	if err := validateServeEnv(); err != nil {
+		return fmt.Errorf("invalid environment: %w", err)
+	}
+
 	// Setup server logging
 	slog.Debug("changing logging mode to server mode")
 	prod := viper.GetBool("server.production")
@@ -47,16 +139,102 @@ func startServer(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	logServeSettings(cmd)
+
+	// Trace requests through the database and storage calls they make
+	provider, err := newTracing(viper.GetString("server.tracing.otlp_endpoint"), "aether")
+	if err != nil {
+		return err
+	}
+	engineOpts := []registry.Option{
+		registry.WithStartupWait(viper.GetDuration("server.startup.wait"), viper.GetDuration("server.startup.backoff")),
+	}
+	if provider != nil {
+		defer provider.Shutdown(context.Background())
+		engineOpts = append(engineOpts, registry.WithTracing(provider))
+	}
+
 	// Create Core Engine(registry engine)
 	slog.Info("Initializing registry engine")
-	engine, err := initRegistry()
+	engine, err := initRegistry(engineOpts...)
 	if err != nil {
 		return err
 	}
 
 	// Run server
 	port := viper.GetString("server.port")
-	server := web.NewServer(prod, engine)
+	guard := data.NewProbeGuard(
+		viper.GetInt("server.probes.limit"),
+		viper.GetDuration("server.probes.window"),
+		viper.GetBool("server.probes.hide_missing"),
+	)
+	limits := data.PresignLimits{
+		Warn:   viper.GetInt("server.presign_rate.warn"),
+		Limit:  viper.GetInt("server.presign_rate.max"),
+		Window: viper.GetDuration("server.presign_rate.window"),
+	}
+	serviceOpts := []data.ServiceOption{
+		data.WithProbeGuard(guard),
+		data.WithPresignLimits(limits),
+		data.WithEgressRoles(registry.ClassConfidential, splitList(viper.GetString("server.egress.confidential_roles"))...),
+		data.WithEgressRoles(registry.ClassPII, splitList(viper.GetString("server.egress.pii_roles"))...),
+	}
+	if rps := viper.GetInt("server.rate_limit.rps"); rps > 0 {
+		serviceOpts = append(serviceOpts, data.WithRateLimiter(data.NewRateLimiter(float64(rps), viper.GetInt("server.rate_limit.burst"))))
+	}
+	if viper.GetBool("server.auth.require_api_keys") {
+		serviceOpts = append(serviceOpts, data.WithRequiredAPIKeys())
+	}
+	if viper.GetBool("server.auth.authorize") {
+		serviceOpts = append(serviceOpts, data.WithAuthorization(viper.GetString("server.auth.default_role")))
+	}
+	if viper.GetBool("server.enrichment.suggest_tags") {
+		serviceOpts = append(serviceOpts, data.WithTagSuggesters(registry.PathTagSuggester{}))
+	}
+	if key := viper.GetString("server.replication.key"); key != "" {
+		serviceOpts = append(serviceOpts, data.WithReplicationKey(registry.Secret(key)))
+	}
+	if key := viper.GetString("server.page_token_key"); key != "" {
+		serviceOpts = append(serviceOpts, data.WithPageTokenKey(registry.Secret(key)))
+	}
+	if token := viper.GetString("server.storage.events.token"); token != "" {
+		serviceOpts = append(serviceOpts, data.WithStorageEventToken(registry.Secret(token)))
+	}
+	server := web.NewServer(prod, engine, serviceOpts...)
+	if timeout := viper.GetDuration("server.drain_timeout"); timeout > 0 {
+		server.DrainTimeout = timeout
+	}
+	if server.TLS, err = serverTLS(); err != nil {
+		return err
+	}
+	if err := server.TrustProxies(splitList(viper.GetString("server.trusted_proxies"))...); err != nil {
+		return fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	if grpcPort := viper.GetInt("server.grpc_port"); grpcPort > 0 {
+		server.GRPCPort = strconv.Itoa(grpcPort)
+	}
+
+	// Run maintenance jobs while serving, on the elected replica only
+	jobs, err := replicationJobs(engine)
+	if err != nil {
+		return err
+	}
+	events, err := storageEventJobs(cmd.Context(), server.DataSvc)
+	if err != nil {
+		return err
+	}
+	jobs = append(jobs, events...)
+	server.Go(func(ctx context.Context) {
+		elector := engine.NewElector(registry.DEFAULT_LEADER_LOCK, registry.DEFAULT_LEADER_INTERVAL)
+		elector.Start(ctx)
+		scheduler := registry.NewScheduler(append(engine.MaintenanceJobs(), jobs...)...).RequireLeader(elector)
+		scheduler.Start(ctx)
+
+		<-ctx.Done()
+		scheduler.Wait()
+		elector.Wait()
+	})
+
 	return server.Run(port)
 }
 
@@ -71,8 +249,8 @@ func updateLogging(prod bool) error {
 	return nil
 }
 
-func initRegistry() (*registry.Engine, error) {
-	opts := getRegistryOptions()
+func initRegistry(extra ...registry.Option) (*registry.Engine, error) {
+	opts := append(getRegistryOptions(), extra...)
 	engine, err := registry.New(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize registry: %w", err)
@@ -81,9 +259,7 @@ func initRegistry() (*registry.Engine, error) {
 }
 
 func getRegistryOptions() []registry.Option {
-	opts := []registry.Option{
-		registry.WithBucket(viper.GetString("server.storage.bucket")),
-	}
+	var opts []registry.Option
 
 	addIfSet := func(key string, optFunc func(string) registry.Option) {
 		if val := viper.GetString(key); val != "" {
@@ -91,34 +267,182 @@ func getRegistryOptions() []registry.Option {
 		}
 	}
 
+	addIfSet("server.storage.backend", registry.WithStorageBackend)
+	addIfSet("server.storage.bucket", registry.WithBucket)
 	addIfSet("server.storage.s3endpoint", registry.WithStorageEndpoint)
 	addIfSet("server.storage.prefix", registry.WithBucketPrefix)
+	addIfSet("server.storage.path", registry.WithStoragePath)
+	addIfSet("server.storage.url", registry.WithStorageURL)
+	addIfSet("server.storage.signing_key", registry.WithStorageSigningKey)
+	addIfSet("server.database.driver", registry.WithDatabaseDriver)
 	addIfSet("server.database.endpoint", registry.WithDatabaseEndpoint)
 	addIfSet("server.database.user", registry.WithDatabaseUser)
 	addIfSet("server.database.password", registry.WithDatabasePassword)
 	addIfSet("server.database.name", registry.WithDatabaseName)
 
+	opts = append(opts,
+		registry.WithPresignBounds(
+			viper.GetDuration("server.storage.presign.min"),
+			viper.GetDuration("server.storage.presign.max"),
+		),
+		registry.WithPresignTTL(viper.GetDuration("server.storage.presign.ttl")),
+		registry.WithPresignSkew(viper.GetDuration("server.storage.presign.skew")),
+		registry.WithStorageMaxObjectSize(viper.GetInt64("server.storage.max_object_size")),
+		registry.WithStorageBreaker(viper.GetInt("server.storage.breaker.threshold"), viper.GetDuration("server.storage.breaker.cooldown")),
+		registry.WithGarbageCollection(viper.GetDuration("server.storage.gc.interval")),
+		registry.WithGCPendingTTL(viper.GetDuration("server.storage.gc.pending_ttl")),
+		registry.WithDeletionGrace(viper.GetDuration("server.storage.gc.deletion_grace")),
+		registry.WithUsageMetering(viper.GetDuration("server.database.metering_interval")),
+		registry.WithIdempotencyTTL(viper.GetDuration("server.database.idempotency_ttl")),
+		registry.WithSlowQueryPlans(viper.GetDuration("server.database.slow_query_threshold")),
+		registry.WithMediaExtraction(viper.GetDuration("server.enrichment.media_interval"), mediaExtractors()...),
+		registry.WithPreviews(viper.GetDuration("server.enrichment.preview_interval"), viper.GetInt("server.enrichment.preview_size")),
+		registry.WithContentIndexing(viper.GetDuration("server.enrichment.content_interval"), textExtractors()...),
+	)
+	opts = append(opts, commandEnrichers(viper.GetDuration("server.enrichment.interval"))...)
+	if algorithms := splitList(viper.GetString("server.enrichment.digest_algorithms")); len(algorithms) > 0 {
+		opts = append(opts, registry.WithDigests(viper.GetDuration("server.enrichment.digest_interval"), algorithms...))
+	}
+
+	opts = append(opts,
+		registry.WithAccessLogRetention(viper.GetDuration("server.database.access_log_retention")),
+		registry.WithEventRetention(viper.GetDuration("server.database.event_retention")),
+		registry.WithAuditRetention(viper.GetDuration("server.database.audit_retention")),
+	)
+
 	if viper.GetBool("server.database.ssl") {
 		opts = append(opts, registry.WithSslMode())
 	}
+	if viper.GetBool("server.database.prepare_statements") {
+		opts = append(opts, registry.WithPreparedStatements(
+			viper.GetInt("server.database.statement_cache.size"),
+			viper.GetDuration("server.database.statement_cache.ttl"),
+		))
+	}
+	if viper.GetBool("server.database.simple_protocol") {
+		opts = append(opts, registry.WithSimpleProtocol())
+	}
 
 	return opts
 }
 
-func bindServeFlags() {
-	// Server settings
-	viper.BindPFlag("server.port", ServeCmd.Flags().Lookup("port"))
-	viper.BindPFlag("server.production", ServeCmd.Flags().Lookup("production"))
-
-	// Storage settings
-	viper.BindPFlag("server.storage.s3endpoint", ServeCmd.Flags().Lookup("s3endpoint"))
-	viper.BindPFlag("server.storage.bucket", ServeCmd.Flags().Lookup("bucket"))
-	viper.BindPFlag("server.storage.prefix", ServeCmd.Flags().Lookup("prefix"))
-
-	// Database settings
-	viper.BindPFlag("server.database.endpoint", ServeCmd.Flags().Lookup("db-endpoint"))
-	viper.BindPFlag("server.database.user", ServeCmd.Flags().Lookup("db-user"))
-	viper.BindPFlag("server.database.password", ServeCmd.Flags().Lookup("db-password"))
-	viper.BindPFlag("server.database.name", ServeCmd.Flags().Lookup("db-name"))
-	viper.BindPFlag("server.database.ssl", ServeCmd.Flags().Lookup("ssl"))
+// mediaExtractors returns the configured media metadata extractors, images are always handled
+func mediaExtractors() []registry.MediaExtractor {
+	extractors := []registry.MediaExtractor{registry.ImageExtractor{}}
+	if path := viper.GetString("server.enrichment.ffprobe"); path != "" {
+		extractors = append(extractors, registry.FFprobeExtractor{Path: path})
+	}
+	return extractors
+}
+
+// textExtractors returns the configured document text extractors, plain text is always handled
+func textExtractors() []registry.TextExtractor {
+	extractors := []registry.TextExtractor{registry.PlainTextExtractor{}}
+	if path := viper.GetString("server.enrichment.pdftotext"); path != "" {
+		extractors = append(extractors, registry.PDFTextExtractor{Path: path})
+	}
+	return extractors
+}
+
+// commandEnrichers registers the configured external enrichers, a malformed entry fails the registry setup
+func commandEnrichers(interval time.Duration) []registry.Option {
+	var opts []registry.Option
+	for _, spec := range splitList(viper.GetString("server.enrichment.enrichers")) {
+		name, path, ok := strings.Cut(spec, "=")
+		if !ok || path == "" {
+			opts = append(opts, func(*registry.Engine) error {
+				return fmt.Errorf("invalid enricher %q, expected name=path", spec)
+			})
+			continue
+		}
+
+		enricher := registry.CommandEnricher{EnricherName: name, Path: path}
+		if name, types, ok := strings.Cut(name, "@"); ok {
+			enricher.EnricherName, enricher.Types = name, strings.Split(types, "|")
+		}
+		opts = append(opts, registry.WithEnricher(interval, enricher))
+	}
+	return opts
+}
+
+// storageEventJobs returns the job finalizing uploads from the configured bucket notification queue
+func storageEventJobs(ctx context.Context, svc *data.Service) ([]registry.Job, error) {
+	queue := viper.GetString("server.storage.events.queue")
+	if queue == "" {
+		return nil, nil
+	}
+
+	consumer, err := ingest.NewSQSConsumer(ctx, queue, func(ctx context.Context, notifications []registry.ObjectNotification) error {
+		_, err := svc.IngestObjectNotifications(ctx, notifications)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []registry.Job{consumer.Job(viper.GetDuration("server.storage.events.interval"))}, nil
+}
+
+// replicationJobs registers the configured replication peers and returns a pull job per peer
+func replicationJobs(engine *registry.Engine) ([]registry.Job, error) {
+	specs := splitList(viper.GetString("server.replication.peers"))
+	interval := viper.GetDuration("server.replication.interval")
+	if len(specs) == 0 || interval <= 0 {
+		return nil, nil
+	}
+
+	name, key := viper.GetString("server.replication.name"), registry.Secret(viper.GetString("server.replication.key"))
+	if name == "" || key.Value() == "" {
+		return nil, fmt.Errorf("replication peers need a replication name and key")
+	}
+
+	var jobs []registry.Job
+	for _, spec := range specs {
+		peerName, url, ok := strings.Cut(spec, "=")
+		if !ok || url == "" {
+			return nil, fmt.Errorf("invalid replication peer %q, expected name=url", spec)
+		}
+
+		peerType := registry.PeerSource
+		if n, t, ok := strings.Cut(peerName, "@"); ok {
+			if t != registry.PeerSource && t != registry.PeerReplica {
+				return nil, fmt.Errorf("invalid replication peer %q, type must be %s or %s", spec, registry.PeerSource, registry.PeerReplica)
+			}
+			peerName, peerType = n, t
+		}
+
+		peer, err := engine.EnsurePeerRecord(peerName, peerType, url)
+		if err != nil {
+			return nil, err
+		}
+
+		puller, err := replication.NewPuller(engine, peer, name, key)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, puller.Job(interval))
+	}
+	return jobs, nil
+}
+
+// serverTLS loads the configured TLS certificate, nil when TLS is off
+func serverTLS() (*tls.Config, error) {
+	cert, key, clientCA := viper.GetString("server.tls.cert"), viper.GetString("server.tls.key"), viper.GetString("server.tls.client_ca")
+	switch {
+	case cert == "" && key == "" && clientCA == "":
+		return nil, nil
+	case cert == "" || key == "":
+		return nil, fmt.Errorf("tls needs both a certificate and a key")
+	}
+	return web.NewTLSConfig(cert, key, clientCA)
+}
+
+// splitList parses a comma separated setting, dropping blank entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
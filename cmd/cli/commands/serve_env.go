@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type settingKind int
+
+const (
+	kindString settingKind = iota
+	kindInt
+	kindBool
+	kindDuration
+)
+
+// setting ties a serve configuration key to its flag and environment variable
+type setting struct {
+	Key    string
+	Flag   string
+	Env    string
+	Kind   settingKind
+	Secret bool
+}
+
+// serveSettings is the complete serve configuration schema.
+// Values resolve from flag, then environment, then config file, then the flag default.
+var serveSettings = []setting{
+	// Server
+	{Key: "server.port", Flag: "port", Env: "AETHER_PORT", Kind: kindInt},
+	{Key: "server.grpc_port", Flag: "grpc-port", Env: "AETHER_GRPC_PORT", Kind: kindInt},
+	{Key: "server.production", Flag: "production", Env: "AETHER_PRODUCTION", Kind: kindBool},
+	{Key: "server.tls.cert", Flag: "tls-cert", Env: "AETHER_TLS_CERT"},
+	{Key: "server.tls.key", Flag: "tls-key", Env: "AETHER_TLS_KEY"},
+	{Key: "server.tls.client_ca", Flag: "tls-client-ca", Env: "AETHER_TLS_CLIENT_CA"},
+	{Key: "server.trusted_proxies", Flag: "trusted-proxies", Env: "AETHER_TRUSTED_PROXIES"},
+	{Key: "server.drain_timeout", Flag: "drain-timeout", Env: "AETHER_DRAIN_TIMEOUT", Kind: kindDuration},
+	{Key: "server.startup.wait", Flag: "startup-wait", Env: "AETHER_STARTUP_WAIT", Kind: kindDuration},
+	{Key: "server.startup.backoff", Flag: "startup-backoff", Env: "AETHER_STARTUP_BACKOFF", Kind: kindDuration},
+	{Key: "server.page_token_key", Flag: "page-token-key", Env: "AETHER_PAGE_TOKEN_KEY", Secret: true},
+
+	// Storage
+	{Key: "server.storage.backend", Flag: "storage-backend", Env: "AETHER_STORAGE_BACKEND"},
+	{Key: "server.storage.s3endpoint", Flag: "s3endpoint", Env: "AETHER_STORAGE_ENDPOINT"},
+	{Key: "server.storage.bucket", Flag: "bucket", Env: "AETHER_STORAGE_BUCKET"},
+	{Key: "server.storage.prefix", Flag: "prefix", Env: "AETHER_STORAGE_PREFIX"},
+	{Key: "server.storage.path", Flag: "storage-path", Env: "AETHER_STORAGE_PATH"},
+	{Key: "server.storage.url", Flag: "storage-url", Env: "AETHER_STORAGE_URL"},
+	{Key: "server.storage.signing_key", Flag: "storage-signing-key", Env: "AETHER_STORAGE_SIGNING_KEY", Secret: true},
+	{Key: "server.storage.max_object_size", Flag: "storage-max-object-size", Env: "AETHER_STORAGE_MAX_OBJECT_SIZE", Kind: kindInt},
+	{Key: "server.storage.presign.ttl", Flag: "presign-ttl", Env: "AETHER_STORAGE_PRESIGN_TTL", Kind: kindDuration},
+	{Key: "server.storage.presign.min", Flag: "presign-min-ttl", Env: "AETHER_STORAGE_PRESIGN_MIN_TTL", Kind: kindDuration},
+	{Key: "server.storage.presign.max", Flag: "presign-max-ttl", Env: "AETHER_STORAGE_PRESIGN_MAX_TTL", Kind: kindDuration},
+	{Key: "server.storage.presign.skew", Flag: "presign-skew", Env: "AETHER_STORAGE_PRESIGN_SKEW", Kind: kindDuration},
+	{Key: "server.storage.gc.interval", Flag: "gc-interval", Env: "AETHER_STORAGE_GC_INTERVAL", Kind: kindDuration},
+	{Key: "server.storage.gc.pending_ttl", Flag: "gc-pending-ttl", Env: "AETHER_STORAGE_GC_PENDING_TTL", Kind: kindDuration},
+	{Key: "server.storage.gc.deletion_grace", Flag: "gc-deletion-grace", Env: "AETHER_STORAGE_GC_DELETION_GRACE", Kind: kindDuration},
+	{Key: "server.storage.events.token", Flag: "storage-events-token", Env: "AETHER_STORAGE_EVENTS_TOKEN", Secret: true},
+	{Key: "server.storage.events.queue", Flag: "storage-events-queue", Env: "AETHER_STORAGE_EVENTS_QUEUE"},
+	{Key: "server.storage.events.interval", Flag: "storage-events-interval", Env: "AETHER_STORAGE_EVENTS_INTERVAL", Kind: kindDuration},
+	{Key: "server.storage.breaker.threshold", Flag: "storage-breaker-threshold", Env: "AETHER_STORAGE_BREAKER_THRESHOLD", Kind: kindInt},
+	{Key: "server.storage.breaker.cooldown", Flag: "storage-breaker-cooldown", Env: "AETHER_STORAGE_BREAKER_COOLDOWN", Kind: kindDuration},
+
+	// Database
+	{Key: "server.database.driver", Flag: "db-driver", Env: "AETHER_DB_DRIVER"},
+	{Key: "server.database.endpoint", Flag: "db-endpoint", Env: "AETHER_DB_ENDPOINT"},
+	{Key: "server.database.user", Flag: "db-user", Env: "AETHER_DB_USER"},
+	{Key: "server.database.password", Flag: "db-password", Env: "AETHER_DB_PASSWORD", Secret: true},
+	{Key: "server.database.name", Flag: "db-name", Env: "AETHER_DB_NAME"},
+	{Key: "server.database.ssl", Flag: "ssl", Env: "AETHER_DB_SSL", Kind: kindBool},
+	{Key: "server.database.prepare_statements", Flag: "db-prepare-statements", Env: "AETHER_DB_PREPARE_STATEMENTS", Kind: kindBool},
+	{Key: "server.database.statement_cache.size", Flag: "db-statement-cache-size", Env: "AETHER_DB_STATEMENT_CACHE_SIZE", Kind: kindInt},
+	{Key: "server.database.statement_cache.ttl", Flag: "db-statement-cache-ttl", Env: "AETHER_DB_STATEMENT_CACHE_TTL", Kind: kindDuration},
+	{Key: "server.database.simple_protocol", Flag: "db-simple-protocol", Env: "AETHER_DB_SIMPLE_PROTOCOL", Kind: kindBool},
+	{Key: "server.database.metering_interval", Flag: "metering-interval", Env: "AETHER_DB_METERING_INTERVAL", Kind: kindDuration},
+	{Key: "server.database.event_retention", Flag: "event-retention", Env: "AETHER_DB_EVENT_RETENTION", Kind: kindDuration},
+	{Key: "server.database.access_log_retention", Flag: "access-log-retention", Env: "AETHER_DB_ACCESS_LOG_RETENTION", Kind: kindDuration},
+	{Key: "server.database.audit_retention", Flag: "audit-retention", Env: "AETHER_DB_AUDIT_RETENTION", Kind: kindDuration},
+	{Key: "server.database.idempotency_ttl", Flag: "idempotency-ttl", Env: "AETHER_DB_IDEMPOTENCY_TTL", Kind: kindDuration},
+	{Key: "server.database.slow_query_threshold", Flag: "slow-query-threshold", Env: "AETHER_DB_SLOW_QUERY_THRESHOLD", Kind: kindDuration},
+
+	// Auth
+	{Key: "server.probes.limit", Flag: "probe-limit", Env: "AETHER_AUTH_PROBE_LIMIT", Kind: kindInt},
+	{Key: "server.probes.window", Flag: "probe-window", Env: "AETHER_AUTH_PROBE_WINDOW", Kind: kindDuration},
+	{Key: "server.probes.hide_missing", Flag: "hide-missing-assets", Env: "AETHER_AUTH_HIDE_MISSING_ASSETS", Kind: kindBool},
+	{Key: "server.presign_rate.warn", Flag: "presign-warn-rate", Env: "AETHER_AUTH_PRESIGN_WARN_RATE", Kind: kindInt},
+	{Key: "server.presign_rate.max", Flag: "presign-max-rate", Env: "AETHER_AUTH_PRESIGN_MAX_RATE", Kind: kindInt},
+	{Key: "server.presign_rate.window", Flag: "presign-rate-window", Env: "AETHER_AUTH_PRESIGN_RATE_WINDOW", Kind: kindDuration},
+	{Key: "server.rate_limit.rps", Flag: "rate-limit", Env: "AETHER_AUTH_RATE_LIMIT", Kind: kindInt},
+	{Key: "server.rate_limit.burst", Flag: "rate-burst", Env: "AETHER_AUTH_RATE_BURST", Kind: kindInt},
+	{Key: "server.auth.require_api_keys", Flag: "require-api-keys", Env: "AETHER_AUTH_REQUIRE_API_KEYS", Kind: kindBool},
+	{Key: "server.auth.authorize", Flag: "authorize", Env: "AETHER_AUTH_AUTHORIZE", Kind: kindBool},
+	{Key: "server.auth.default_role", Flag: "default-role", Env: "AETHER_AUTH_DEFAULT_ROLE"},
+	{Key: "server.egress.confidential_roles", Flag: "confidential-roles", Env: "AETHER_AUTH_CONFIDENTIAL_ROLES"},
+	{Key: "server.egress.pii_roles", Flag: "pii-roles", Env: "AETHER_AUTH_PII_ROLES"},
+
+	// Enrichment
+	{Key: "server.enrichment.suggest_tags", Flag: "suggest-tags", Env: "AETHER_ENRICHMENT_SUGGEST_TAGS", Kind: kindBool},
+	{Key: "server.enrichment.media_interval", Flag: "media-interval", Env: "AETHER_ENRICHMENT_MEDIA_INTERVAL", Kind: kindDuration},
+	{Key: "server.enrichment.preview_interval", Flag: "preview-interval", Env: "AETHER_ENRICHMENT_PREVIEW_INTERVAL", Kind: kindDuration},
+	{Key: "server.enrichment.preview_size", Flag: "preview-size", Env: "AETHER_ENRICHMENT_PREVIEW_SIZE", Kind: kindInt},
+	{Key: "server.enrichment.ffprobe", Flag: "ffprobe", Env: "AETHER_ENRICHMENT_FFPROBE"},
+	{Key: "server.enrichment.content_interval", Flag: "content-interval", Env: "AETHER_ENRICHMENT_CONTENT_INTERVAL", Kind: kindDuration},
+	{Key: "server.enrichment.pdftotext", Flag: "pdftotext", Env: "AETHER_ENRICHMENT_PDFTOTEXT"},
+	{Key: "server.enrichment.enrichers", Flag: "enrichers", Env: "AETHER_ENRICHMENT_ENRICHERS"},
+	{Key: "server.enrichment.interval", Flag: "enrich-interval", Env: "AETHER_ENRICHMENT_INTERVAL", Kind: kindDuration},
+	{Key: "server.enrichment.digest_algorithms", Flag: "digest-algorithms", Env: "AETHER_ENRICHMENT_DIGEST_ALGORITHMS"},
+	{Key: "server.enrichment.digest_interval", Flag: "digest-interval", Env: "AETHER_ENRICHMENT_DIGEST_INTERVAL", Kind: kindDuration},
+
+	// Tracing
+	{Key: "server.tracing.otlp_endpoint", Flag: "otlp-endpoint", Env: "AETHER_OTLP_ENDPOINT"},
+
+	// Replication
+	{Key: "server.replication.name", Flag: "replication-name", Env: "AETHER_REPLICATION_NAME"},
+	{Key: "server.replication.key", Flag: "replication-key", Env: "AETHER_REPLICATION_KEY", Secret: true},
+	{Key: "server.replication.peers", Flag: "replication-peers", Env: "AETHER_REPLICATION_PEERS"},
+	{Key: "server.replication.interval", Flag: "replication-interval", Env: "AETHER_REPLICATION_INTERVAL", Kind: kindDuration},
+}
+
+// bindServeSettings binds every serve setting to its flag and environment variable
+func bindServeSettings() {
+	for _, s := range serveSettings {
+		viper.BindPFlag(s.Key, ServeCmd.Flags().Lookup(s.Flag))
+		viper.BindEnv(s.Key, s.Env)
+	}
+}
+
+// validateServeEnv parses every set serve environment variable, so typos fail at startup
+// instead of silently falling back to defaults
+func validateServeEnv() error {
+	var errs []error
+
+	for _, s := range serveSettings {
+		raw, ok := os.LookupEnv(s.Env)
+		if !ok {
+			continue
+		}
+
+		var err error
+		switch s.Kind {
+		case kindInt:
+			_, err = strconv.Atoi(raw)
+		case kindBool:
+			_, err = strconv.ParseBool(raw)
+		case kindDuration:
+			_, err = time.ParseDuration(raw)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid value %q", s.Env, raw))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// settingSource tells where the effective value of a setting comes from
+func settingSource(cmd *cobra.Command, s setting) string {
+	switch {
+	case cmd.Flags().Changed(s.Flag):
+		return "flag"
+	case os.Getenv(s.Env) != "":
+		return "env"
+	case viper.InConfig(s.Key):
+		return "config"
+	default:
+		return "default"
+	}
+}
+
+// logServeSettings dumps the effective serve configuration, secrets are redacted
+func logServeSettings(cmd *cobra.Command) {
+	attrs := make([]any, 0, len(serveSettings))
+
+	for _, s := range serveSettings {
+		value := viper.Get(s.Key)
+		if s.Secret && viper.GetString(s.Key) != "" {
+			value = "[REDACTED]"
+		}
+
+		attrs = append(attrs, s.Key, fmt.Sprintf("%v (%s)", value, settingSource(cmd, s)))
+	}
+
+	slog.Info("Effective configuration", attrs...)
+}
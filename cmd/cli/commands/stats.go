@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+	"github.com/spf13/cobra"
+)
+
+// StatsCmd represents the stats command
+var StatsCmd = &cobra.Command{
+	Use:           "stats",
+	Short:         "Show registry statistics",
+	Long:          "Show asset counts and bytes in total and broken down by state, mime type and tag",
+	Example:       "aether stats",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runStats,
+}
+
+func init() {
+	StatsCmd.Flags().Bool("ci", false, "Disable user interaction and progress bars")
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	aether, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	stats, err := aether.GetStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "assets\t%d\n", stats.TotalAssets)
+	fmt.Fprintf(w, "bytes\t%d\n", stats.TotalBytes)
+	fmt.Fprintf(w, "stored bytes\t%d\n", stats.StoredBytes)
+	printStatsGroups(w, "STATE", stats.ByState)
+	printStatsGroups(w, "MIME TYPE", stats.ByMimeType)
+	printStatsGroups(w, "TAG", stats.ByTag)
+	return w.Flush()
+}
+
+func printStatsGroups(w *tabwriter.Writer, title string, groups []*v1.StatsGroupDetails) {
+	if len(groups) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s\tASSETS\tBYTES\n", title)
+	for _, g := range groups {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", g.Key, g.Count, g.Bytes)
+	}
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/UnivocalX/aether/pkg/client"
+	"github.com/UnivocalX/aether/pkg/tracing"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Deadline defaults, the run deadline covers the whole command and the
+// request and file timeouts bound its individual api calls and transfers.
+const (
+	DefaultTimeout        = time.Hour
+	DefaultRequestTimeout = client.DefaultRequestTimeout
+	DefaultFileTimeout    = time.Duration(0)
+)
+
+// AddDeadlineFlags registers the run, request and file deadline flags on flags
+func AddDeadlineFlags(flags *pflag.FlagSet) {
+	timeout := timeoutValue(DefaultTimeout)
+	flags.Var(&timeout, "timeout", "deadline for the whole command run, e.g. 90m (plain numbers are seconds, 0 disables).")
+	flags.Duration("request-timeout", DefaultRequestTimeout, "timeout of a single API request.")
+	flags.Duration("file-timeout", DefaultFileTimeout, "timeout of a single file upload or download, a file that runs over fails on its own (0 disables).")
+}
+
+// timeoutValue is a duration flag that still accepts the former plain seconds form
+type timeoutValue time.Duration
+
+func (t *timeoutValue) Set(s string) error {
+	if secs, err := strconv.Atoi(s); err == nil {
+		*t = timeoutValue(time.Duration(secs) * time.Second)
+		return nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid timeout %q, expected a duration like 90m or seconds", s)
+	}
+	*t = timeoutValue(d)
+	return nil
+}
+
+func (t *timeoutValue) String() string { return time.Duration(*t).String() }
+func (t *timeoutValue) Type() string   { return "duration" }
+
+// deadlineOptions configures the client request and file timeouts from the command flags
+func deadlineOptions(cmd *cobra.Command) []client.Option {
+	var opts []client.Option
+	if requestTimeout, err := cmd.Flags().GetDuration("request-timeout"); err == nil {
+		opts = append(opts, client.WithRequestTimeout(requestTimeout))
+	}
+	if fileTimeout, err := cmd.Flags().GetDuration("file-timeout"); err == nil {
+		opts = append(opts, client.WithFileTimeout(fileTimeout))
+	}
+	return opts
+}
+
+// commandContext creates a context bound to the command run deadline.
+// With tracing it carries the command span, the parent of every api request the command sends.
+func commandContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	ctx, span := tracing.Tracer(clientTracing()).Start(context.Background(), cmd.CommandPath())
+
+	cancel := context.CancelFunc(func() {})
+	if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	return ctx, func() {
+		cancel()
+		span.End()
+	}
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/viper"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/UnivocalX/aether/pkg/tracing"
+)
+
+// TracingShutdownTimeout bounds exporting the spans left when a command exits
+const TracingShutdownTimeout = 5 * time.Second
+
+// cliTracing exports the spans of the running command, started by the first command span or client
+var cliTracing struct {
+	provider *sdktrace.TracerProvider
+	started  bool
+}
+
+// newTracing exports the spans of service to the OTLP/HTTP collector at endpoint, nil when endpoint is empty
+func newTracing(endpoint string, service string) (*sdktrace.TracerProvider, error) {
+	if endpoint == "" {
+		return nil, nil
+	}
+	return tracing.NewProvider(endpoint, service)
+}
+
+// clientTracing returns the provider of the CLI spans configured by --otlp-endpoint, nil when disabled.
+// A malformed endpoint is logged once and leaves the command untraced.
+func clientTracing() trace.TracerProvider {
+	if !cliTracing.started {
+		cliTracing.started = true
+
+		provider, err := newTracing(viper.GetString("otlp-endpoint"), "aether-cli")
+		if err != nil {
+			slog.Warn("tracing disabled", "error", err)
+		}
+		cliTracing.provider = provider
+	}
+	if cliTracing.provider == nil {
+		return nil
+	}
+	return cliTracing.provider
+}
+
+// ShutdownTracing exports the spans the command left, call it before the process exits
+func ShutdownTracing() {
+	if cliTracing.provider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TracingShutdownTimeout)
+	defer cancel()
+
+	if err := cliTracing.provider.Shutdown(ctx); err != nil {
+		slog.Warn("failed to export spans", "error", err)
+	}
+}
@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/UnivocalX/aether/pkg/client"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+	"github.com/spf13/cobra"
+)
+
+// trashCmd represents the assets trash command
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Review, restore and purge deleted assets.",
+}
+
+var trashListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List deleted assets",
+	Example:       "aether assets trash list --limit 50",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runTrashList,
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:           "restore [checksum...]",
+	Short:         "Restore deleted assets",
+	Example:       "aether assets trash restore <sha256> <sha256>\naether assets trash restore --all",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runTrashRestore,
+}
+
+var trashPurgeCmd = &cobra.Command{
+	Use:           "purge [checksum...]",
+	Short:         "Permanently remove deleted assets and their stored objects",
+	Example:       "aether assets trash purge <sha256>\naether assets trash purge --all --yes\naether assets trash purge --all --dry-run",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runTrashPurge,
+}
+
+func init() {
+	AssetsCmd.AddCommand(trashCmd)
+	trashCmd.AddCommand(trashListCmd, trashRestoreCmd, trashPurgeCmd)
+
+	trashListCmd.Flags().String("page-token", "", "Continue a listing with the page token it printed")
+	trashListCmd.Flags().Uint("limit", 100, "Maximum number of assets to list")
+
+	trashRestoreCmd.Flags().Bool("all", false, "Restore every asset in the trash")
+	trashPurgeCmd.Flags().Bool("all", false, "Purge every asset in the trash")
+	trashPurgeCmd.Flags().Bool("yes", false, "Skip the confirmation prompt")
+	trashPurgeCmd.Flags().Bool("dry-run", false, "List the assets and bytes a purge would remove without purging")
+}
+
+func runTrashList(cmd *cobra.Command, args []string) error {
+	pageToken, _ := cmd.Flags().GetString("page-token")
+	limit, _ := cmd.Flags().GetUint("limit")
+
+	aether, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	page, err := aether.ListTrash(ctx, pageToken, limit)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCHECKSUM\tDISPLAY\tSIZE")
+	for _, asset := range page.Assets {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\n", asset.ID, asset.Checksum, asset.Display, asset.SizeBytes)
+	}
+	w.Flush()
+
+	if page.NextPageToken != "" {
+		slog.Info("more deleted assets available", "page_token", page.NextPageToken)
+	}
+	return nil
+}
+
+func runTrashRestore(cmd *cobra.Command, args []string) error {
+	aether, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	checksums, err := trashTargets(ctx, cmd, aether, args)
+	if err != nil || len(checksums) == 0 {
+		return err
+	}
+
+	response, err := aether.RestoreTrash(ctx, checksums...)
+	if err != nil {
+		return err
+	}
+
+	logTrashResult("restored assets", response)
+	return nil
+}
+
+func runTrashPurge(cmd *cobra.Command, args []string) error {
+	yes, _ := cmd.Flags().GetBool("yes")
+	ci, _ := cmd.Flags().GetBool("ci")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	aether, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	checksums, err := trashTargets(ctx, cmd, aether, args)
+	if err != nil || len(checksums) == 0 {
+		return err
+	}
+
+	if dryRun {
+		plan, err := aether.PlanPurgeTrash(ctx, checksums...)
+		if err != nil {
+			return err
+		}
+		return printDryRun(plan)
+	}
+
+	if !yes {
+		prompt := fmt.Sprintf("permanently remove %d asset(s)? this cannot be undone", len(checksums))
+		approved, err := client.Input(ctx, prompt, !ci)
+		if err != nil {
+			return err
+		}
+		if !approved {
+			return fmt.Errorf("purge aborted, use --yes to skip confirmation")
+		}
+	}
+
+	response, err := aether.PurgeTrash(ctx, checksums...)
+	if err != nil {
+		return err
+	}
+
+	logTrashResult("purged assets", response)
+	return nil
+}
+
+// trashTargets resolves the checksums a trash command operates on, listing the trash within ctx for --all
+func trashTargets(ctx context.Context, cmd *cobra.Command, aether *client.Client, args []string) ([]string, error) {
+	all, _ := cmd.Flags().GetBool("all")
+
+	switch {
+	case all && len(args) > 0:
+		return nil, fmt.Errorf("either pass checksums or --all, not both")
+	case all:
+		checksums, err := aether.ListAllTrash(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(checksums) == 0 {
+			slog.Info("trash is empty")
+		}
+		return checksums, nil
+	case len(args) == 0:
+		return nil, fmt.Errorf("at least one checksum or --all is required")
+	default:
+		return args, nil
+	}
+}
+
+// printDryRun prints the candidates of a dry run and their byte total
+func printDryRun(plan *v1.DryRunResponse) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tBYTES\tREASON")
+	for _, c := range plan.Candidates {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", c.Kind, c.Name, c.Bytes, c.Reason)
+	}
+	fmt.Fprintf(w, "TOTAL\t%d\t%d\t\n", plan.Total, plan.Bytes)
+	return w.Flush()
+}
+
+func logTrashResult(msg string, response *v1.TrashAssetsResponse) {
+	for _, asset := range response.Assets {
+		slog.Debug(msg, "checksum", asset.Checksum, "state", asset.State)
+	}
+	slog.Info(msg, "total", response.Total)
+}
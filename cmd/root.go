@@ -39,24 +39,38 @@ var Log = logging.NewLog()
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	commands.ShutdownTracing()
+	if err != nil {
 		slog.Error(err.Error())
-		os.Exit(1)
+		os.Exit(commands.ExitCode(err))
 	}
 }
 
 func init() {
 	Log.Apply()
+	commands.UserAgent = "aether-cli/" + version
 
 	// Add subcommands
 	rootCmd.AddCommand(commands.AssetsCmd)
 	rootCmd.AddCommand(commands.TagsCmd)
+	rootCmd.AddCommand(commands.DatasetsCmd)
+	rootCmd.AddCommand(commands.StatsCmd)
 	rootCmd.AddCommand(commands.ServeCmd)
+	rootCmd.AddCommand(commands.AdminCmd)
+	rootCmd.AddCommand(commands.KeysCmd)
 
 	// Define persistent flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.aether/config.yaml)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "level", "info", "log level (debug, info, warn, error)")
-	rootCmd.PersistentFlags().StringVar(&host, "host", "localhost:8080", "aether API host.")
+	rootCmd.PersistentFlags().StringVar(&host, "host", "localhost:8080", "aether API host, comma separated hosts enable failover.")
+	rootCmd.PersistentFlags().StringArray("header", nil, "extra header sent with every API request (key=value), repeatable.")
+	rootCmd.PersistentFlags().String("api-key", "", "aether API key sent as bearer token, also read from AETHER_API_KEY.")
+	rootCmd.PersistentFlags().String("project", "", "aether project of the request records, also read from AETHER_PROJECT (default project when empty).")
+	rootCmd.PersistentFlags().Duration("cache-ttl", 0, "serve repeated lookups such as tags and server info from an in-memory cache for this long, then revalidate them by ETag, also read from AETHER_CACHE_TTL (disabled when 0).")
+	rootCmd.PersistentFlags().Int("cache-size", 1000, "responses kept by the lookup cache, also read from AETHER_CACHE_SIZE.")
+	rootCmd.PersistentFlags().String("otlp-endpoint", "", "OpenTelemetry collector receiving command traces over OTLP/HTTP, also read from AETHER_OTLP_ENDPOINT (disabled when empty).")
+	commands.AddDeadlineFlags(rootCmd.PersistentFlags())
 
 	// Set bash completion for log level
 	if err := rootCmd.PersistentFlags().SetAnnotation("level", cobra.BashCompOneRequiredFlag, []string{"debug", "info", "warn", "error"}); err != nil {
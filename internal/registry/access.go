@@ -0,0 +1,175 @@
+package registry
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	AccessLogDefaultLimit = 200
+	AccessLogMaxLimit     = 5000
+)
+
+// AccessLog records a presigned url handed out by the registry.
+// Rows are append only, they back security audits of data egress.
+// The table is partitioned by month, see partition.go.
+type AccessLog struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `gorm:"primaryKey;not null"`
+	Principal string    `gorm:"index;not null;size:200"`
+	Project   string    `gorm:"index;size:63"`
+	ClientIP  string    `gorm:"size:100"`
+	UserAgent string    `gorm:"size:300"`
+	Checksum  string    `gorm:"index;size:64"`
+	Operation string    `gorm:"index;not null;size:10"`
+	Bucket    string    `gorm:"size:63"`
+	Key       string    `gorm:"size:1024"`
+	ExpiresAt time.Time `gorm:"not null"`
+	ExpiresIn int64     `gorm:"not null"`
+}
+
+// AccessLogQuery filters access log records, newest first
+type AccessLogQuery struct {
+	Cursor    uint
+	Limit     uint
+	Principal string
+	Project   string
+	Checksum  string
+	Operation string
+	Since     time.Time
+	Until     time.Time
+}
+
+type AccessLogOption func(*AccessLogQuery) error
+
+func WithAccessCursor(cursor uint) AccessLogOption {
+	return func(q *AccessLogQuery) error {
+		q.Cursor = cursor
+		return nil
+	}
+}
+
+func WithAccessLimit(limit uint) AccessLogOption {
+	return func(q *AccessLogQuery) error {
+		if limit == 0 {
+			return fmt.Errorf("limit must be greater than 0")
+		}
+		if limit > AccessLogMaxLimit {
+			return fmt.Errorf("limit cannot exceed %d", AccessLogMaxLimit)
+		}
+		q.Limit = limit
+		return nil
+	}
+}
+
+func WithAccessPrincipal(principal string) AccessLogOption {
+	return func(q *AccessLogQuery) error {
+		q.Principal = principal
+		return nil
+	}
+}
+
+func WithAccessProject(project string) AccessLogOption {
+	return func(q *AccessLogQuery) error {
+		q.Project = NormalizeString(project)
+		return nil
+	}
+}
+
+func WithAccessChecksum(checksum string) AccessLogOption {
+	return func(q *AccessLogQuery) error {
+		q.Checksum = NormalizeString(checksum)
+		return nil
+	}
+}
+
+func WithAccessOperation(operation string) AccessLogOption {
+	return func(q *AccessLogQuery) error {
+		q.Operation = NormalizeString(operation)
+		return nil
+	}
+}
+
+// WithAccessWindow keeps records issued within [since, until), zero values are open ended
+func WithAccessWindow(since time.Time, until time.Time) AccessLogOption {
+	return func(q *AccessLogQuery) error {
+		if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+			return fmt.Errorf("since must be before until")
+		}
+		q.Since = since
+		q.Until = until
+		return nil
+	}
+}
+
+// NewAccessLogEntries builds access log records for issued urls
+func NewAccessLogEntries(principal string, clientIP string, userAgent string, urls ...*PresignedUrl) []*AccessLog {
+	entries := make([]*AccessLog, 0, len(urls))
+	for _, url := range urls {
+		entries = append(entries, &AccessLog{
+			Principal: principal,
+			ClientIP:  clientIP,
+			UserAgent: userAgent,
+			Checksum:  url.Checksum,
+			Operation: url.Operation,
+			Bucket:    url.Bucket,
+			Key:       url.Key,
+			ExpiresAt: url.ExpiresAt,
+			ExpiresIn: int64(url.ExpiresIn.Seconds()),
+		})
+	}
+	return entries
+}
+
+func (engine *Engine) CreateAccessLogRecords(entries ...*AccessLog) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	slog.Debug("Creating access log records", "total", len(entries))
+	if err := engine.DatabaseClient.Create(entries).Error; err != nil {
+		return fmt.Errorf("create access log records: %w", err)
+	}
+	return nil
+}
+
+func (engine *Engine) ListAccessLogRecords(opts ...AccessLogOption) ([]*AccessLog, error) {
+	query := &AccessLogQuery{Limit: AccessLogDefaultLimit}
+	for _, opt := range opts {
+		if err := opt(query); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+
+	slog.Debug("Listing access log records", "query", fmt.Sprintf("%+v", *query))
+
+	db := engine.DatabaseClient.Model(&AccessLog{})
+	if query.Cursor > 0 {
+		db = db.Where("id < ?", query.Cursor)
+	}
+	if query.Principal != "" {
+		db = db.Where("principal = ?", query.Principal)
+	}
+	if query.Project != "" {
+		db = db.Where("project = ?", query.Project)
+	}
+	if query.Checksum != "" {
+		db = db.Where("checksum = ?", query.Checksum)
+	}
+	if query.Operation != "" {
+		db = db.Where("operation = ?", query.Operation)
+	}
+	if !query.Since.IsZero() {
+		db = db.Where("created_at >= ?", query.Since)
+	}
+	if !query.Until.IsZero() {
+		db = db.Where("created_at < ?", query.Until)
+	}
+
+	var entries []*AccessLog
+	if err := db.Order("id DESC").Limit(int(query.Limit)).Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("list access log: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,213 @@
+package registry
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+const (
+	// API_KEY_PREFIX starts every key, so leaked keys are easy to spot by secret scanners
+	API_KEY_PREFIX       = "aek_"
+	API_KEY_ID_BYTES     = 6
+	API_KEY_SECRET_BYTES = 32
+
+	// API_KEY_TOUCH_INTERVAL throttles last used updates, so busy keys don't write on every request
+	API_KEY_TOUCH_INTERVAL = time.Minute
+)
+
+// API key scopes, each scope includes the ones before it
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+)
+
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// APIKeyScopes lists the scopes a key can hold, from the narrowest
+func APIKeyScopes() []string {
+	return []string{ScopeRead, ScopeWrite, ScopeAdmin}
+}
+
+// APIKey authenticates api requests as a bearer token. Only the SHA256 of the key is stored,
+// Prefix is the public part the key is looked up by.
+type APIKey struct {
+	gorm.Model
+	Name   string                      `gorm:"uniqueIndex;not null;size:100"`
+	Prefix string                      `gorm:"uniqueIndex;not null;size:20"`
+	Hash   string                      `gorm:"not null;size:64"`
+	Scopes datatypes.JSONSlice[string] `gorm:"type:jsonb"`
+
+	// Roles and Project become those of the request principal
+	Roles   datatypes.JSONSlice[string] `gorm:"type:jsonb"`
+	Project string                      `gorm:"size:63"`
+
+	CreatedBy  string `gorm:"size:200"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// Allows reports whether the key holds scope or a wider one
+func (k *APIKey) Allows(scope string) bool {
+	required := slices.Index(APIKeyScopes(), scope)
+	if required < 0 {
+		return false
+	}
+	for _, s := range k.Scopes {
+		if i := slices.Index(APIKeyScopes(), s); i >= required {
+			return true
+		}
+	}
+	return false
+}
+
+// Active reports whether the key is neither revoked nor expired at now
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// hashAPIKey is the stored form of a key
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// newAPIKeySecret generates a key as aek_<id>_<secret>, the id is its lookup prefix
+func newAPIKeySecret() (prefix string, key Secret, err error) {
+	id := make([]byte, API_KEY_ID_BYTES)
+	secret := make([]byte, API_KEY_SECRET_BYTES)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", fmt.Errorf("generate api key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("generate api key: %w", err)
+	}
+
+	prefix = API_KEY_PREFIX + hex.EncodeToString(id)
+	return prefix, Secret(prefix + "_" + hex.EncodeToString(secret)), nil
+}
+
+// ValidateAPIKey checks the name, scopes and expiry of a key
+func ValidateAPIKey(k *APIKey) error {
+	k.Name = NormalizeString(k.Name)
+	if !ValidateString(k.Name) {
+		return fmt.Errorf("%w: api key name contains invalid characters", ErrValidation)
+	}
+
+	if len(k.Scopes) == 0 {
+		return fmt.Errorf("%w: api key needs at least one scope, expected any of %v", ErrValidation, APIKeyScopes())
+	}
+	for _, scope := range k.Scopes {
+		if !slices.Contains(APIKeyScopes(), scope) {
+			return fmt.Errorf("%w: unknown api key scope %q, expected one of %v", ErrValidation, scope, APIKeyScopes())
+		}
+	}
+
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("%w: api key expiry %s is in the past", ErrValidation, k.ExpiresAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// CreateAPIKeyRecord generates and stores a key, the returned secret is the only copy of it
+func (engine *Engine) CreateAPIKeyRecord(k *APIKey) (Secret, error) {
+	slog.Debug("Creating api key", "name", k.Name, "scopes", k.Scopes)
+
+	if err := ValidateAPIKey(k); err != nil {
+		return "", err
+	}
+
+	prefix, key, err := newAPIKeySecret()
+	if err != nil {
+		return "", err
+	}
+	k.Prefix, k.Hash = prefix, hashAPIKey(key.Value())
+
+	if err := engine.DatabaseClient.Create(k).Error; err != nil {
+		return "", fmt.Errorf("create api key %q: %w", k.Name, err)
+	}
+	return key, nil
+}
+
+// GetAPIKeyRecord returns the key named name
+func (engine *Engine) GetAPIKeyRecord(name string) (*APIKey, error) {
+	var k APIKey
+	if err := engine.DatabaseClient.Where("name = ?", NormalizeString(name)).First(&k).Error; err != nil {
+		return nil, fmt.Errorf("get api key %q: %w", name, err)
+	}
+	return &k, nil
+}
+
+// ListAPIKeyRecords returns every key ordered by name, revoked ones included
+func (engine *Engine) ListAPIKeyRecords() ([]*APIKey, error) {
+	var keys []*APIKey
+	if err := engine.DatabaseClient.Order("name ASC").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKeyRecord stops a key from authenticating, the record is kept for auditing
+func (engine *Engine) RevokeAPIKeyRecord(k *APIKey) error {
+	slog.Debug("Revoking api key", "name", k.Name)
+
+	if k.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	if err := engine.DatabaseClient.Model(k).Update("revoked_at", now).Error; err != nil {
+		return fmt.Errorf("revoke api key %q: %w", k.Name, err)
+	}
+	k.RevokedAt = &now
+	return nil
+}
+
+// AuthenticateAPIKey returns the active key matching a bearer token, ErrInvalidAPIKey otherwise
+func (engine *Engine) AuthenticateAPIKey(token string) (*APIKey, error) {
+	rest, ok := strings.CutPrefix(token, API_KEY_PREFIX)
+	id, _, found := strings.Cut(rest, "_")
+	if !ok || !found {
+		return nil, ErrInvalidAPIKey
+	}
+
+	var k APIKey
+	err := engine.DatabaseClient.Where("prefix = ?", API_KEY_PREFIX+id).First(&k).Error
+	if errors.Is(err, registryerrors.ErrNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get api key: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(token)), []byte(k.Hash)) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if !k.Active(now) {
+		return nil, fmt.Errorf("%w: %s is revoked or expired", ErrInvalidAPIKey, k.Name)
+	}
+
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) > API_KEY_TOUCH_INTERVAL {
+		if err := engine.DatabaseClient.Model(&k).UpdateColumn("last_used_at", now).Error; err != nil {
+			slog.Warn("Failed to record api key use", "name", k.Name, "error", err)
+		}
+		k.LastUsedAt = &now
+	}
+	return &k, nil
+}
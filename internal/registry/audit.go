@@ -0,0 +1,501 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	AuditDefaultLimit       = 200
+	AuditMaxLimit           = 5000
+	DEFAULT_AUDIT_RETENTION = 365 * 24 * time.Hour
+
+	// AuditSystemActor is the actor of writes made outside of a request, e.g. by background jobs
+	AuditSystemActor = "system"
+
+	// auditMaxRows caps the rows a single statement records, bulk writes beyond it are logged instead
+	auditMaxRows = 10000
+)
+
+// Audit actions
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+)
+
+// auditedTables are the tables whose writes are recorded. Logs, deliveries, metering,
+// enrichment results and replication watermarks are bookkeeping and left out.
+var auditedTables = map[string]bool{
+	"assets":                    true,
+	"asset_tags":                true,
+	"asset_deletions":           true,
+	"tags":                      true,
+	"tag_groups":                true,
+	"datasets":                  true,
+	"dataset_versions":          true,
+	"dataset_version_assets":    true,
+	"dataset_version_approvals": true,
+	"dataset_channels":          true,
+	"dataset_schemas":           true,
+	"content_policies":          true,
+	"license_restrictions":      true,
+	"storage_mappings":          true,
+	"webhooks":                  true,
+	"api_keys":                  true,
+	"role_assignments":          true,
+	"retag_jobs":                true,
+}
+
+// auditRedacted are columns whose values never reach the audit log
+var auditRedacted = map[string][]string{
+	"api_keys": {"hash"},
+	"webhooks": {"secret"},
+}
+
+// AuditEntry records one row written by a mutating operation with its state before and after.
+// The table is partitioned by month, see partition.go.
+type AuditEntry struct {
+	ID        uint           `gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `gorm:"primaryKey;not null"`
+	Actor     string         `gorm:"not null;size:200"`
+	Project   string         `gorm:"size:63"`
+	RequestID string         `gorm:"size:100"`
+	Action    string         `gorm:"not null;size:10"`
+	Entity    string         `gorm:"not null;size:100"`
+	EntityID  string         `gorm:"size:100"`
+	Before    datatypes.JSON `gorm:"type:jsonb"`
+	After     datatypes.JSON `gorm:"type:jsonb"`
+}
+
+// AuditActor identifies who a write is attributed to
+type AuditActor struct {
+	Principal string
+	Project   string
+	RequestID string
+}
+
+type auditActorKey struct{}
+
+// WithAuditActor attributes the writes made with ctx to actor, see Engine.WithContext
+func WithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditActorFrom returns the actor attached to ctx, the system actor when none was attached
+func AuditActorFrom(ctx context.Context) AuditActor {
+	actor, _ := ctx.Value(auditActorKey{}).(AuditActor)
+	if actor.Principal == "" {
+		actor.Principal = AuditSystemActor
+	}
+	return actor
+}
+
+// WithContext returns an engine whose database calls carry ctx, and with it the audit actor.
+// When ctx is scoped to a project the engine only sees its records and storage namespace.
+func (engine *Engine) WithContext(ctx context.Context) *Engine {
+	scoped := engine.WithTx(engine.DatabaseClient.WithContext(ctx))
+
+	project := ProjectFrom(ctx)
+	if project == "" || (scoped.namespace != nil && scoped.namespace.project == project) {
+		return scoped
+	}
+
+	ns, err := scoped.Namespace(project)
+	if err != nil {
+		// refuse every key rather than falling back to the keys of another project
+		slog.Error("Failed to resolve project namespace", "project", project, "error", err)
+		ns = &Namespace{engine: scoped, bucket: scoped.defaultBucket(), project: project, closed: true}
+	}
+	scoped.namespace = ns
+	return scoped
+}
+
+// registerAuditCallbacks records creates, updates and deletes of the audited tables in the
+// transaction of the write. Raw statements bypass the callbacks and are not recorded.
+func registerAuditCallbacks(db *gorm.DB) error {
+	cb := db.Callback()
+	err := errors.Join(
+		cb.Create().After("gorm:create").Register("audit:create", auditCreated),
+		cb.Update().Before("gorm:update").Register("audit:before_update", auditSnapshot),
+		cb.Update().After("gorm:update").Register("audit:update", auditUpdated),
+		cb.Delete().Before("gorm:delete").Register("audit:before_delete", auditSnapshot),
+		cb.Delete().After("gorm:delete").Register("audit:delete", auditDeleted),
+	)
+	if err != nil {
+		return fmt.Errorf("register audit callbacks: %w", err)
+	}
+	return nil
+}
+
+// audited reports whether the statement writes an audited table
+func audited(db *gorm.DB) bool {
+	return db.Error == nil && !db.DryRun && db.Statement.Schema != nil && auditedTables[db.Statement.Table]
+}
+
+// auditSnapshot keeps the rows an update or delete is about to change
+func auditSnapshot(db *gorm.DB) {
+	if !audited(db) {
+		return
+	}
+
+	stmt := db.Statement
+	query := db.Session(&gorm.Session{NewDB: true}).Table(stmt.Table)
+	conditions := false
+
+	if where, ok := stmt.Clauses["WHERE"]; ok && where.Expression != nil {
+		query = query.Clauses(where.Expression)
+		conditions = true
+	}
+	if stmt.ReflectValue.IsValid() && (stmt.ReflectValue.Kind() == reflect.Struct || stmt.ReflectValue.Kind() == reflect.Slice) {
+		_, values := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmt.Schema.PrimaryFields)
+		if column, values := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, values); len(values) > 0 {
+			query = query.Where(clause.IN{Column: column, Values: values})
+			conditions = true
+		}
+	}
+	// global writes are refused by gorm anyway
+	if !conditions {
+		return
+	}
+
+	rows, err := auditRows(query)
+	if err != nil {
+		db.AddError(fmt.Errorf("audit snapshot %q: %w", stmt.Table, err))
+		return
+	}
+	db.InstanceSet("audit:before", rows)
+}
+
+// auditRows reads up to auditMaxRows rows of a query
+func auditRows(query *gorm.DB) ([]map[string]any, error) {
+	var rows []map[string]any
+	if err := query.Limit(auditMaxRows + 1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) > auditMaxRows {
+		slog.Warn("Audit log truncated a bulk write", "table", query.Statement.Table, "rows", auditMaxRows)
+		rows = rows[:auditMaxRows]
+	}
+	return rows, nil
+}
+
+// beforeRows returns the rows kept by auditSnapshot
+func beforeRows(db *gorm.DB) []map[string]any {
+	rows, _ := db.InstanceGet("audit:before")
+	before, _ := rows.([]map[string]any)
+	return before
+}
+
+func auditCreated(db *gorm.DB) {
+	if !audited(db) || db.Statement.RowsAffected == 0 {
+		return
+	}
+
+	stmt := db.Statement
+	var rows []map[string]any
+	appendRow := func(value reflect.Value) {
+		row := map[string]any{}
+		for _, name := range stmt.Schema.DBNames {
+			if field := stmt.Schema.LookUpField(name); field != nil {
+				row[name], _ = field.ValueOf(stmt.Context, value)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	switch value := reflect.Indirect(stmt.ReflectValue); value.Kind() {
+	case reflect.Struct:
+		appendRow(value)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len() && i < auditMaxRows; i++ {
+			appendRow(reflect.Indirect(value.Index(i)))
+		}
+	default:
+		// creates from maps carry no schema values to record
+		return
+	}
+
+	writeAuditEntries(db, AuditCreate, nil, rows)
+}
+
+func auditUpdated(db *gorm.DB) {
+	if !audited(db) || db.Statement.RowsAffected == 0 {
+		return
+	}
+
+	before := beforeRows(db)
+	if len(before) == 0 {
+		return
+	}
+
+	stmt := db.Statement
+	keys := make([][]any, len(before))
+	for i, row := range before {
+		for _, name := range stmt.Schema.PrimaryFieldDBNames {
+			keys[i] = append(keys[i], row[name])
+		}
+	}
+
+	column, values := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, keys)
+	after, err := auditRows(db.Session(&gorm.Session{NewDB: true}).Table(stmt.Table).Where(clause.IN{Column: column, Values: values}))
+	if err != nil {
+		db.AddError(fmt.Errorf("audit update %q: %w", stmt.Table, err))
+		return
+	}
+
+	writeAuditEntries(db, AuditUpdate, before, after)
+}
+
+func auditDeleted(db *gorm.DB) {
+	if !audited(db) || db.Statement.RowsAffected == 0 {
+		return
+	}
+
+	writeAuditEntries(db, AuditDelete, beforeRows(db), nil)
+}
+
+// writeAuditEntries pairs the before and after rows by primary key and records one entry per row
+func writeAuditEntries(db *gorm.DB, action string, before []map[string]any, after []map[string]any) {
+	stmt := db.Statement
+	actor := AuditActorFrom(stmt.Context)
+
+	type pair struct{ before, after map[string]any }
+	var order []string
+	pairs := map[string]*pair{}
+	add := func(row map[string]any, set func(*pair)) {
+		id := auditEntityID(stmt.Schema, row)
+		if pairs[id] == nil {
+			pairs[id] = &pair{}
+			order = append(order, id)
+		}
+		set(pairs[id])
+	}
+	for _, row := range before {
+		add(row, func(p *pair) { p.before = row })
+	}
+	for _, row := range after {
+		add(row, func(p *pair) { p.after = row })
+	}
+
+	var entries []*AuditEntry
+	for _, id := range order {
+		p := pairs[id]
+		entry := &AuditEntry{
+			Actor:     actor.Principal,
+			Project:   actor.Project,
+			RequestID: actor.RequestID,
+			Action:    action,
+			Entity:    stmt.Table,
+			EntityID:  id,
+		}
+
+		var err error
+		if entry.Before, err = auditSnapshotJSON(stmt.Schema, p.before); err == nil {
+			entry.After, err = auditSnapshotJSON(stmt.Schema, p.after)
+		}
+		if err != nil {
+			db.AddError(fmt.Errorf("audit %s %q: %w", action, stmt.Table, err))
+			return
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return
+	}
+	if err := db.Session(&gorm.Session{NewDB: true}).Create(&entries).Error; err != nil {
+		db.AddError(fmt.Errorf("audit %s %q: %w", action, stmt.Table, err))
+	}
+}
+
+// auditEntityID joins the primary key values of a row, e.g. 12 or 3,7 for join tables
+func auditEntityID(s *schema.Schema, row map[string]any) string {
+	id := ""
+	for i, name := range s.PrimaryFieldDBNames {
+		if i > 0 {
+			id += ","
+		}
+		id += fmt.Sprint(row[name])
+	}
+	return id
+}
+
+// auditSnapshotJSON encodes the schema columns of a row with the redacted ones blanked
+func auditSnapshotJSON(s *schema.Schema, row map[string]any) (datatypes.JSON, error) {
+	if row == nil {
+		return nil, nil
+	}
+
+	snapshot := make(map[string]any, len(s.DBNames))
+	for _, name := range s.DBNames {
+		value, ok := row[name]
+		if !ok {
+			continue
+		}
+		// text columns of some drivers scan into bytes
+		if raw, ok := value.([]byte); ok {
+			value = string(raw)
+		}
+		snapshot[name] = value
+	}
+	for _, name := range auditRedacted[s.Table] {
+		if _, ok := snapshot[name]; ok {
+			snapshot[name] = Secret("").String()
+		}
+	}
+
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return datatypes.JSON(raw), nil
+}
+
+// AuditQuery filters audit entries, newest first
+type AuditQuery struct {
+	Cursor    uint
+	Limit     uint
+	Actor     string
+	Project   string
+	RequestID string
+	Action    string
+	Entity    string
+	EntityID  string
+	Since     time.Time
+	Until     time.Time
+}
+
+type AuditOption func(*AuditQuery) error
+
+func WithAuditCursor(cursor uint) AuditOption {
+	return func(q *AuditQuery) error {
+		q.Cursor = cursor
+		return nil
+	}
+}
+
+func WithAuditLimit(limit uint) AuditOption {
+	return func(q *AuditQuery) error {
+		if limit == 0 {
+			return fmt.Errorf("limit must be greater than 0")
+		}
+		if limit > AuditMaxLimit {
+			return fmt.Errorf("limit cannot exceed %d", AuditMaxLimit)
+		}
+		q.Limit = limit
+		return nil
+	}
+}
+
+func WithAuditActorFilter(actor string) AuditOption {
+	return func(q *AuditQuery) error {
+		q.Actor = actor
+		return nil
+	}
+}
+
+func WithAuditProject(project string) AuditOption {
+	return func(q *AuditQuery) error {
+		q.Project = project
+		return nil
+	}
+}
+
+func WithAuditRequestID(requestID string) AuditOption {
+	return func(q *AuditQuery) error {
+		q.RequestID = requestID
+		return nil
+	}
+}
+
+func WithAuditAction(action string) AuditOption {
+	return func(q *AuditQuery) error {
+		switch action {
+		case "", AuditCreate, AuditUpdate, AuditDelete:
+		default:
+			return fmt.Errorf("%w: unknown audit action %q", ErrValidation, action)
+		}
+		q.Action = action
+		return nil
+	}
+}
+
+// WithAuditEntity filters by table, and by row when id is set
+func WithAuditEntity(entity string, id string) AuditOption {
+	return func(q *AuditQuery) error {
+		q.Entity = entity
+		q.EntityID = id
+		return nil
+	}
+}
+
+func WithAuditSince(since time.Time) AuditOption {
+	return func(q *AuditQuery) error {
+		q.Since = since
+		return nil
+	}
+}
+
+func WithAuditUntil(until time.Time) AuditOption {
+	return func(q *AuditQuery) error {
+		q.Until = until
+		return nil
+	}
+}
+
+func (engine *Engine) ListAuditEntryRecords(opts ...AuditOption) ([]*AuditEntry, error) {
+	query := &AuditQuery{Limit: AuditDefaultLimit}
+	for _, opt := range opts {
+		if err := opt(query); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+
+	slog.Debug("Listing audit entries", "query", fmt.Sprintf("%+v", *query))
+
+	db := engine.DatabaseClient.Model(&AuditEntry{})
+	if query.Cursor > 0 {
+		db = db.Where("id < ?", query.Cursor)
+	}
+	if query.Actor != "" {
+		db = db.Where("actor = ?", query.Actor)
+	}
+	if query.Project != "" {
+		db = db.Where("project = ?", query.Project)
+	}
+	if query.RequestID != "" {
+		db = db.Where("request_id = ?", query.RequestID)
+	}
+	if query.Action != "" {
+		db = db.Where("action = ?", query.Action)
+	}
+	if query.Entity != "" {
+		db = db.Where("entity = ?", query.Entity)
+	}
+	if query.EntityID != "" {
+		db = db.Where("entity_id = ?", query.EntityID)
+	}
+	if !query.Since.IsZero() {
+		db = db.Where("created_at >= ?", query.Since)
+	}
+	if !query.Until.IsZero() {
+		db = db.Where("created_at < ?", query.Until)
+	}
+
+	var entries []*AuditEntry
+	if err := db.Order("id DESC").Limit(int(query.Limit)).Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("list audit entries: %w", err)
+	}
+	return entries, nil
+}
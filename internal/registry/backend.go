@@ -0,0 +1,90 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	STORAGE_S3         = "s3"
+	STORAGE_FILESYSTEM = "filesystem"
+)
+
+var ErrStorageUnsupported = errors.New("operation not supported by the storage backend")
+
+// Storage is the object store behind the registry. Keys are slash separated and already
+// scoped by the caller namespace, implementations only store and sign them.
+type Storage interface {
+	// Name identifies the store in logs and presigned urls, the bucket for S3
+	Name() string
+
+	// Ping verifies the store is reachable
+	Ping(ctx context.Context) error
+
+	// IngressURL signs an upload of key, the upload must match the SHA256 when set and the size when positive
+	IngressURL(ctx context.Context, key string, sha256 string, expire time.Duration, size int64) (string, error)
+
+	// CuratedURL signs a download of key
+	CuratedURL(ctx context.Context, key string, expire time.Duration) (string, error)
+
+	// Promote copies src to dst, keeping the stored checksum
+	Promote(ctx context.Context, src string, dst string) error
+
+	// Put stores size bytes of r under key, for objects the server generates itself, e.g. previews
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Delete removes keys, missing keys are ignored
+	Delete(ctx context.Context, keys ...string) error
+
+	// Get streams the object content, ErrObjectNotFound when the key is missing
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Stat returns the object metadata with its hex SHA256 when known, nil when the key is missing
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+
+	// List calls fn with pages of at most pageSize objects under prefix
+	List(ctx context.Context, prefix string, pageSize int32, fn func([]ObjectInfo) error) error
+}
+
+// Backends lists the storage backend names the engine can create
+func Backends() []string {
+	return []string{STORAGE_S3, STORAGE_FILESYSTEM}
+}
+
+// createStorage builds the configured storage backend, unless one was injected with WithStorage
+func (engine *Engine) createStorage() error {
+	if engine.store != nil {
+		return nil
+	}
+
+	switch strings.ToLower(engine.backend) {
+	case "", STORAGE_S3:
+		if engine.bucket == "" {
+			return fmt.Errorf("storage bucket value required")
+		}
+		return engine.createS3Client()
+
+	case STORAGE_FILESYSTEM:
+		store, err := NewFilesystemStorage(engine.storagePath, engine.storageURL, engine.storageKey)
+		if err != nil {
+			return err
+		}
+		if engine.storageMaxSize > 0 {
+			store.maxSize = engine.storageMaxSize
+		}
+		engine.store = store
+		return nil
+
+	default:
+		return fmt.Errorf("unknown storage backend %q, expected one of %v", engine.backend, Backends())
+	}
+}
+
+// Storage returns the storage backend of the default project, without its circuit breaker
+func (engine *Engine) Storage() Storage {
+	return unwrapStorage(engine.store)
+}
@@ -0,0 +1,205 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+)
+
+const (
+	DEFAULT_BREAKER_THRESHOLD = 5
+	DEFAULT_BREAKER_COOLDOWN  = 30 * time.Second
+)
+
+var ErrStorageUnavailable = errors.New("storage unavailable")
+
+// StorageUnavailableError is returned without calling the store while its circuit breaker is open,
+// metadata stays readable while only the object store is down
+type StorageUnavailableError struct {
+	Store      string
+	RetryAfter time.Duration
+}
+
+func (e *StorageUnavailableError) Error() string {
+	return fmt.Sprintf("%s after repeated failures, retry in %s", ErrStorageUnavailable, e.RetryAfter.Round(time.Second))
+}
+
+func (e *StorageUnavailableError) Unwrap() error {
+	return ErrStorageUnavailable
+}
+
+// breakerStorage fails calls fast once the store failed threshold times in a row.
+// After the cooldown one call goes through as a trial: success closes the circuit, failure opens it again.
+// Presigning signs locally, so it is refused while open but its successes prove nothing about the store.
+type breakerStorage struct {
+	Storage
+
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+// withBreaker wraps store in a circuit breaker, a zero threshold leaves the store unwrapped
+func (engine *Engine) withBreaker(store Storage) Storage {
+	if engine.breakerThreshold <= 0 {
+		return store
+	}
+	return &breakerStorage{Storage: store, threshold: engine.breakerThreshold, cooldown: engine.breakerCooldown}
+}
+
+// unwrapStorage returns the backend behind a circuit breaker
+func unwrapStorage(store Storage) Storage {
+	if b, ok := store.(*breakerStorage); ok {
+		return b.Storage
+	}
+	return store
+}
+
+// allow reports whether a call may reach the store, a trial call is let through once the cooldown passed
+func (b *breakerStorage) allow(probe bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+
+	now := time.Now()
+	if now.Before(b.openUntil) || (b.trial && probe) {
+		return &StorageUnavailableError{Store: b.Name(), RetryAfter: max(b.openUntil.Sub(now), time.Second)}
+	}
+	if probe {
+		b.trial = true
+	}
+	return nil
+}
+
+// record counts the outcome of a call, only probes close the circuit
+func (b *breakerStorage) record(ctx context.Context, probe bool, err error) {
+	failed := storageFailed(ctx, err)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.trial = false
+	}
+	switch {
+	case failed:
+		b.failures++
+		if b.failures >= b.threshold {
+			if b.failures == b.threshold || probe {
+				slog.Warn("Storage circuit opened, failing calls fast", "store", b.Name(), "cooldown", b.cooldown, "error", err)
+			}
+			b.openUntil = time.Now().Add(b.cooldown)
+		}
+	case probe && err == nil:
+		if b.failures >= b.threshold {
+			slog.Info("Storage circuit closed, store is reachable again", "store", b.Name())
+		}
+		b.failures = 0
+	}
+}
+
+// storageFailed tells whether err means the store is unhealthy, answers like a missing object don't
+func storageFailed(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, ErrObjectNotFound) || errors.Is(err, ErrValidation) || errors.Is(err, ErrStorageUnsupported) {
+		return false
+	}
+
+	var response *awshttp.ResponseError
+	if errors.As(err, &response) && response.HTTPStatusCode() < 500 {
+		return false
+	}
+	return true
+}
+
+// call runs fn through the breaker, probe calls reach the store and tell whether it is healthy
+func (b *breakerStorage) call(ctx context.Context, probe bool, fn func() error) error {
+	if err := b.allow(probe); err != nil {
+		return err
+	}
+	err := fn()
+	b.record(ctx, probe, err)
+	return err
+}
+
+func (b *breakerStorage) Ping(ctx context.Context) error {
+	return b.call(ctx, true, func() error { return b.Storage.Ping(ctx) })
+}
+
+func (b *breakerStorage) IngressURL(ctx context.Context, key string, sha256 string, expire time.Duration, size int64) (url string, err error) {
+	err = b.call(ctx, false, func() error {
+		url, err = b.Storage.IngressURL(ctx, key, sha256, expire, size)
+		return err
+	})
+	return url, err
+}
+
+func (b *breakerStorage) CuratedURL(ctx context.Context, key string, expire time.Duration) (url string, err error) {
+	err = b.call(ctx, false, func() error {
+		url, err = b.Storage.CuratedURL(ctx, key, expire)
+		return err
+	})
+	return url, err
+}
+
+func (b *breakerStorage) Promote(ctx context.Context, src string, dst string) error {
+	return b.call(ctx, true, func() error { return b.Storage.Promote(ctx, src, dst) })
+}
+
+func (b *breakerStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	return b.call(ctx, true, func() error { return b.Storage.Put(ctx, key, r, size, contentType) })
+}
+
+func (b *breakerStorage) Delete(ctx context.Context, keys ...string) error {
+	return b.call(ctx, true, func() error { return b.Storage.Delete(ctx, keys...) })
+}
+
+func (b *breakerStorage) Get(ctx context.Context, key string) (r io.ReadCloser, err error) {
+	err = b.call(ctx, true, func() error {
+		r, err = b.Storage.Get(ctx, key)
+		return err
+	})
+	return r, err
+}
+
+func (b *breakerStorage) Stat(ctx context.Context, key string) (info *ObjectInfo, err error) {
+	err = b.call(ctx, true, func() error {
+		info, err = b.Storage.Stat(ctx, key)
+		return err
+	})
+	return info, err
+}
+
+func (b *breakerStorage) List(ctx context.Context, prefix string, pageSize int32, fn func([]ObjectInfo) error) error {
+	// errors of fn are the caller's, only the listing itself tells about the store
+	var callbackErr error
+	err := b.call(ctx, true, func() error {
+		err := b.Storage.List(ctx, prefix, pageSize, func(objects []ObjectInfo) error {
+			callbackErr = fn(objects)
+			return callbackErr
+		})
+		if err != nil && errors.Is(err, callbackErr) {
+			return nil
+		}
+		return err
+	})
+	if err == nil {
+		return callbackErr
+	}
+	return err
+}
@@ -0,0 +1,141 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+// bucketCache keeps clients of mapped buckets, keyed by mapping revision
+type bucketCache struct {
+	mu      sync.Mutex
+	buckets map[string]Storage
+}
+
+func newBucketCache() *bucketCache {
+	return &bucketCache{buckets: make(map[string]Storage)}
+}
+
+// defaultBucket is the storage configured on the engine
+func (engine *Engine) defaultBucket() Storage {
+	return engine.store
+}
+
+// newS3Client builds an S3 client, optionally for a custom endpoint, region and shared config profile
+func newS3Client(ctx context.Context, endpoint Endpoint, region string, profile string, opts ...func(*s3.Options)) (*s3.Client, error) {
+	var cfgOpts []func(*config.LoadOptions) error
+	if region != "" {
+		cfgOpts = append(cfgOpts, config.WithRegion(region))
+	}
+	if profile != "" {
+		cfgOpts = append(cfgOpts, config.WithSharedConfigProfile(profile))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx, cfgOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("aws config: %w", err)
+	}
+
+	s3Opts := []func(*s3.Options){}
+	if endpoint != "" {
+		s3Opts = append(s3Opts, func(o *s3.Options) {
+			o.BaseEndpoint = aws.String(string(endpoint))
+			o.UsePathStyle = true
+		})
+	}
+
+	return s3.NewFromConfig(awsCfg, append(s3Opts, opts...)...), nil
+}
+
+// mappedBucket returns the clients of a mapped bucket, built once per mapping revision
+func (engine *Engine) mappedBucket(mapping *StorageMapping) (Storage, error) {
+	revision := fmt.Sprintf("%d@%d", mapping.ID, mapping.UpdatedAt.UnixNano())
+
+	engine.buckets.mu.Lock()
+	defer engine.buckets.mu.Unlock()
+
+	if b, ok := engine.buckets.buckets[revision]; ok {
+		return b, nil
+	}
+
+	client, err := newS3Client(context.TODO(), Endpoint(mapping.Endpoint), mapping.Region, mapping.Profile, engine.s3Metrics, engine.s3Tracing)
+	if err != nil {
+		return nil, fmt.Errorf("storage mapping %q: %w", mapping.Project, err)
+	}
+
+	// drop older revisions of the same mapping
+	for key := range engine.buckets.buckets {
+		if strings.HasPrefix(key, fmt.Sprintf("%d@", mapping.ID)) {
+			delete(engine.buckets.buckets, key)
+		}
+	}
+
+	b := engine.withBreaker(engine.newS3Storage(client, mapping.Bucket))
+	engine.buckets.buckets[revision] = b
+
+	slog.Debug("Created storage mapping client", "project", mapping.Project, "bucket", mapping.Bucket)
+	return b, nil
+}
+
+// GetStorageMappingRecord returns the bucket mapping of a project, nil when the project uses the shared bucket
+func (engine *Engine) GetStorageMappingRecord(project string) (*StorageMapping, error) {
+	var mapping StorageMapping
+	err := engine.DatabaseClient.Where("project = ?", NormalizeString(project)).First(&mapping).Error
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get storage mapping %q: %w", project, err)
+	}
+	return &mapping, nil
+}
+
+// SaveStorageMappingRecord creates or replaces the bucket mapping of a project
+func (engine *Engine) SaveStorageMappingRecord(mapping *StorageMapping) error {
+	slog.Debug("Saving storage mapping", "project", mapping.Project, "bucket", mapping.Bucket)
+
+	mapping.Project = NormalizeString(mapping.Project)
+	if !projectNamePattern.MatchString(mapping.Project) {
+		return fmt.Errorf("%w: %q", ErrInvalidProject, mapping.Project)
+	}
+
+	mapping.Prefix = strings.Trim(strings.TrimSpace(mapping.Prefix), "/")
+	if mapping.Prefix != "" {
+		mapping.Prefix = path.Clean(mapping.Prefix)
+	}
+
+	existing, err := engine.GetStorageMappingRecord(mapping.Project)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		mapping.ID = existing.ID
+		mapping.CreatedAt = existing.CreatedAt
+	}
+
+	if err := engine.DatabaseClient.Save(mapping).Error; err != nil {
+		return fmt.Errorf("save storage mapping %q: %w", mapping.Project, err)
+	}
+	return nil
+}
+
+// DeleteStorageMappingRecord moves a project back to the shared bucket
+func (engine *Engine) DeleteStorageMappingRecord(project string) error {
+	slog.Debug("Deleting storage mapping", "project", project)
+
+	err := engine.DatabaseClient.Unscoped().Where("project = ?", NormalizeString(project)).Delete(&StorageMapping{}).Error
+	if err != nil {
+		return fmt.Errorf("delete storage mapping %q: %w", project, err)
+	}
+	return nil
+}
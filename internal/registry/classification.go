@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// Classification is the sensitivity of an asset content
+type Classification string
+
+const (
+	ClassPublic       Classification = "public"
+	ClassInternal     Classification = "internal"
+	ClassConfidential Classification = "confidential"
+	ClassPII          Classification = "pii"
+)
+
+var ErrClassificationRestricted = errors.New("asset classification restricts access")
+
+// Classifications lists every classification, least sensitive first
+func Classifications() []Classification {
+	return []Classification{ClassPublic, ClassInternal, ClassConfidential, ClassPII}
+}
+
+// ParseClassification validates a classification name
+func ParseClassification(value string) (Classification, error) {
+	c := Classification(NormalizeString(value))
+	if !slices.Contains(Classifications(), c) {
+		return "", fmt.Errorf("%w: unknown classification %q, expected one of %v", ErrValidation, value, Classifications())
+	}
+	return c, nil
+}
+
+// Sensitive reports whether downloads of the class must be authorized and logged
+func (c Classification) Sensitive() bool {
+	return c == ClassConfidential || c == ClassPII
+}
+
+// Rank orders classifications by sensitivity, unknown ones rank lowest
+func (c Classification) Rank() int {
+	return slices.Index(Classifications(), c)
+}
+
+// Classifier inspects an asset once its upload is promoted, e.g. a PII scanner.
+// An empty classification leaves the asset alone.
+type Classifier interface {
+	Name() string
+	Classify(ctx context.Context, asset *Asset) (Classification, error)
+}
+
+// SetAssetClassificationRecord stores the classification of an asset and who set it
+func (engine *Engine) SetAssetClassificationRecord(asset *Asset, class Classification, by string) error {
+	slog.Debug("Setting asset classification", "checksum", asset.Checksum, "classification", class, "by", by)
+
+	if _, err := ParseClassification(string(class)); err != nil {
+		return err
+	}
+
+	err := engine.DatabaseClient.Model(asset).Updates(map[string]any{
+		"classification": class,
+		"classified_by":  by,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("set asset %q classification: %w", asset.Checksum, err)
+	}
+
+	asset.Classification = class
+	asset.ClassifiedBy = by
+	return nil
+}
@@ -0,0 +1,203 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CONTENT_MAX_BYTES caps the indexed text of an asset, postgres tsvectors are limited to 1MB
+const CONTENT_MAX_BYTES = 256 << 10
+
+// AssetContent is the text of an asset indexed for content search, written by its enricher
+type AssetContent struct {
+	AssetID   uint `gorm:"primaryKey"`
+	Text      string
+	Truncated bool
+	Enricher  string `gorm:"size:50"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// contentVectorDDL indexes extracted text like the display name search vectors
+const contentVectorDDL = `
+	ALTER TABLE asset_contents ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (to_tsvector('simple', coalesce(text, ''))) STORED;
+	CREATE INDEX IF NOT EXISTS idx_asset_contents_search_vector ON asset_contents USING GIN (search_vector);
+`
+
+// TextExtractor reads the text out of a stored document
+type TextExtractor interface {
+	Name() string
+	MimeTypes() []string
+	Extract(ctx context.Context, asset *Asset, r io.Reader) (string, error)
+}
+
+// PlainTextExtractor indexes text documents like txt, markdown and csv as they are
+type PlainTextExtractor struct{}
+
+func (PlainTextExtractor) Name() string {
+	return "text"
+}
+
+func (PlainTextExtractor) MimeTypes() []string {
+	return []string{"text/*"}
+}
+
+func (PlainTextExtractor) Extract(ctx context.Context, asset *Asset, r io.Reader) (string, error) {
+	raw, err := io.ReadAll(io.LimitReader(r, CONTENT_MAX_BYTES+1))
+	if err != nil {
+		return "", fmt.Errorf("read text: %w", err)
+	}
+	return string(raw), nil
+}
+
+// PDFTextExtractor indexes pdf documents with the pdftotext binary of poppler
+type PDFTextExtractor struct {
+	Path string
+}
+
+func (PDFTextExtractor) Name() string {
+	return "pdftotext"
+}
+
+func (PDFTextExtractor) MimeTypes() []string {
+	return []string{"application/pdf"}
+}
+
+func (p PDFTextExtractor) Extract(ctx context.Context, asset *Asset, r io.Reader) (string, error) {
+	// pdftotext needs a seekable file
+	tmp, err := os.CreateTemp("", "aether-pdf-*")
+	if err != nil {
+		return "", fmt.Errorf("stage pdf: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		return "", fmt.Errorf("stage pdf: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, p.Path, "-enc", "UTF-8", "-q", tmp.Name(), "-")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("pdftotext: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// newAssetContent cleans extracted text for storage, postgres text can't hold NUL bytes
+func newAssetContent(asset *Asset, enricher string, text string) *AssetContent {
+	content := &AssetContent{AssetID: asset.ID, Enricher: enricher}
+	if len(text) > CONTENT_MAX_BYTES {
+		text, content.Truncated = text[:CONTENT_MAX_BYTES], true
+	}
+	text = strings.ToValidUTF8(text, "")
+	content.Text = strings.ReplaceAll(text, "\x00", "")
+	return content
+}
+
+// ContentEnricher indexes the text of its first accepting extractor for content search
+type ContentEnricher struct {
+	Extractors []TextExtractor
+}
+
+func (ContentEnricher) Name() string {
+	return "content"
+}
+
+func (c ContentEnricher) MimeTypes() []string {
+	var types []string
+	for _, e := range c.Extractors {
+		types = append(types, e.MimeTypes()...)
+	}
+	return types
+}
+
+func (c ContentEnricher) Enrich(ctx context.Context, asset *Asset, r io.Reader) (*Enrichment, error) {
+	for _, e := range c.Extractors {
+		if !MatchMimeType(e.MimeTypes(), asset.MimeType) {
+			continue
+		}
+
+		text, err := e.Extract(ctx, asset, r)
+		if err != nil {
+			return nil, err
+		}
+		return &Enrichment{Text: text}, nil
+	}
+	return nil, nil
+}
+
+// SearchContent finds assets whose extracted text matches every word of query, best match first
+func (engine *Engine) SearchContent(ctx context.Context, query string, limit uint) ([]*AssetMatch, error) {
+	slog.Debug("Searching asset contents", "query", query, "limit", limit)
+
+	terms, err := searchTerms(query)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = SearchDefaultLimit
+	}
+	limit = min(limit, SearchMaxLimit)
+
+	var hits []searchHit
+	if engine.isSQLite() {
+		err = engine.searchContentSubstring(ctx, terms, limit).Scan(&hits).Error
+	} else {
+		err = engine.searchContentVector(ctx, terms, limit).Scan(&hits).Error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("search contents %q: %w", query, err)
+	}
+
+	return engine.loadMatches(ctx, hits)
+}
+
+// searchContentVector ranks assets by their indexed text
+func (engine *Engine) searchContentVector(ctx context.Context, terms []string, limit uint) *gorm.DB {
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	project := ProjectFrom(ctx)
+
+	return engine.DatabaseClient.WithContext(ctx).Raw(`
+		SELECT a.id, ts_rank(c.search_vector, q) AS rank
+		FROM asset_contents c
+		JOIN assets a ON a.id = c.asset_id
+		CROSS JOIN to_tsquery('simple', ?) q
+		WHERE a.deleted_at IS NULL AND (?::text = '' OR a.project = ?) AND c.search_vector @@ q
+		ORDER BY rank DESC, a.id ASC
+		LIMIT ?`, strings.Join(terms, " & "), project, project, limit)
+}
+
+// searchContentSubstring matches every word as a substring of the indexed text
+func (engine *Engine) searchContentSubstring(ctx context.Context, terms []string, limit uint) *gorm.DB {
+	db := engine.DatabaseClient.WithContext(ctx).
+		Table("asset_contents AS c").
+		Joins("JOIN assets a ON a.id = c.asset_id").
+		Where("a.deleted_at IS NULL")
+	db = scopeAlias(db, ctx, "a")
+
+	for _, term := range terms {
+		db = db.Where("lower(c.text) LIKE ?", "%"+term+"%")
+	}
+
+	return db.
+		Select("a.id, 1.0 AS rank").
+		Order("a.id ASC").
+		Limit(int(limit))
+}
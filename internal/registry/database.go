@@ -1,10 +1,16 @@
 package registry
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
 )
 
 func (e *Engine) WithTx(tx *gorm.DB) *Engine {
@@ -18,6 +24,29 @@ func (e *Engine) WithTx(tx *gorm.DB) *Engine {
 	return &ne
 }
 
+// PingDatabase verifies the database connection
+func (engine *Engine) PingDatabase(ctx context.Context) error {
+	db, err := engine.DatabaseClient.DB()
+	if err != nil {
+		return fmt.Errorf("get database connection: %w", err)
+	}
+	return db.PingContext(ctx)
+}
+
+// Close closes the database connection pool, the engine can't query afterwards
+func (engine *Engine) Close() error {
+	slog.Debug("Closing database connections")
+
+	db, err := engine.DatabaseClient.DB()
+	if err != nil {
+		return fmt.Errorf("get database connection: %w", err)
+	}
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("close database connections: %w", err)
+	}
+	return nil
+}
+
 func (engine *Engine) GetAssetRecord(sha256 string) (*Asset, error) {
 	slog.Debug("Getting asset", "checksum", sha256)
 	normalizedSha256 := NormalizeString(sha256)
@@ -46,34 +75,314 @@ func (engine *Engine) GetAssetRecordTags(sha256 string) ([]*Tag, error) {
 	return tags, nil
 }
 
+// GetTagsForAssets returns the tags of many assets in one query, keyed by asset id and ordered by name.
+// Assets without tags are missing from the map.
+func (engine *Engine) GetTagsForAssets(ids []uint) (map[uint][]Tag, error) {
+	slog.Debug("Getting tags of assets", "total", len(ids))
+
+	byAsset := make(map[uint][]Tag)
+	if len(ids) == 0 {
+		return byAsset, nil
+	}
+
+	var rows []struct {
+		AssetID uint
+		Tag     `gorm:"embedded"`
+	}
+	err := engine.DatabaseClient.Table("tags").
+		Select("asset_tags.asset_id, tags.*").
+		Joins("JOIN asset_tags ON asset_tags.tag_id = tags.id").
+		Where("asset_tags.asset_id IN ? AND tags.deleted_at IS NULL", ids).
+		Order("tags.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("get tags of %d assets: %w", len(ids), err)
+	}
+
+	for _, row := range rows {
+		byAsset[row.AssetID] = append(byAsset[row.AssetID], row.Tag)
+	}
+	return byAsset, nil
+}
+
+// loadTags sets the tags of a collection of assets with GetTagsForAssets instead of a query per asset
+func (engine *Engine) loadTags(assets []*Asset) error {
+	ids := make([]uint, len(assets))
+	for i, asset := range assets {
+		ids[i] = asset.ID
+	}
+
+	byAsset, err := engine.GetTagsForAssets(ids)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range assets {
+		asset.Tags = byAsset[asset.ID]
+	}
+	return nil
+}
+
 func (engine *Engine) CreateAssetRecord(asset *Asset) error {
 	slog.Debug("Creating asset record", "display", asset.Display, "checksum", asset.Checksum)
 
+	if err := engine.applyProjectDefaults(asset); err != nil {
+		return err
+	}
 	if err := engine.DatabaseClient.Create(asset).Error; err != nil {
 		return fmt.Errorf("create asset %q: %w", asset.Checksum, err)
 	}
 
+	engine.notifyAssets(WebhookAssetCreated, asset)
 	return nil
 }
 
-func (engine *Engine) AttachTags(asset *Asset, tags []*Tag) error {
+// ErrAssetStateChanged is returned when another writer moved an asset to a different state since it was read
+var ErrAssetStateChanged = registryerrors.New(registryerrors.ErrConflict, "asset state changed concurrently")
+
+// UpdateAssetState moves an asset from the state it was read in to state, with the project usage in the same transaction.
+// An asset another writer already moved to state is left alone, so the usage counts it once.
+func (engine *Engine) UpdateAssetState(asset *Asset, state Status) error {
+	slog.Debug("Updating asset state", "checksum", asset.Checksum, "from", asset.State, "to", state)
+
+	from := asset.State
+	changed := false
+	err := engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(asset).Where("state = ?", from).Update("state", state)
+		if result.Error != nil {
+			return fmt.Errorf("update asset %q state: %w", asset.Checksum, result.Error)
+		}
+		if result.RowsAffected != 1 {
+			return engine.WithTx(tx).checkConcurrentState(asset, from, state)
+		}
+		changed = true
+
+		// only ready assets count towards the project usage
+		switch {
+		case from == StatusReady && state != StatusReady:
+			return engine.WithTx(tx).addUsage(asset.Project, -asset.SizeBytes, -1)
+		case from != StatusReady && state == StatusReady:
+			return engine.WithTx(tx).addUsage(asset.Project, asset.SizeBytes, 1)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	asset.State = state
+	if changed && state == StatusDeleted {
+		engine.notifyAssets(WebhookAssetDeleted, asset)
+	}
+	return nil
+}
+
+// CompleteAssetRecord marks an uploaded asset ready and records its stored size,
+// an asset registered without a mime type takes the one stored with the object.
+// Concurrent completions, e.g. a client finalize racing a bucket notification, count the asset once.
+func (engine *Engine) CompleteAssetRecord(asset *Asset, info *ObjectInfo) error {
+	slog.Debug("Completing asset", "checksum", asset.Checksum, "size", info.Size)
+
+	from := asset.State
+
+	updates := map[string]any{
+		"state":      StatusReady,
+		"size_bytes": info.Size,
+	}
+	mimeType := asset.MimeType
+	if mimeType == "" && info.ContentType != "" {
+		mimeType = NormalizeString(info.ContentType)
+		updates["mime_type"] = mimeType
+	}
+
+	changed := false
+	err := engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(asset).Where("state = ?", from).Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("complete asset %q: %w", asset.Checksum, result.Error)
+		}
+		if result.RowsAffected != 1 {
+			return engine.WithTx(tx).checkConcurrentState(asset, from, StatusReady)
+		}
+		changed = true
+
+		if from == StatusReady {
+			return nil
+		}
+		return engine.WithTx(tx).addUsage(asset.Project, info.Size, 1)
+	})
+	if err != nil {
+		return err
+	}
+
+	asset.State = StatusReady
+	if !changed {
+		return nil
+	}
+	asset.SizeBytes = info.Size
+	asset.MimeType = mimeType
+
+	engine.notifyAssets(WebhookAssetReady, asset)
+	return nil
+}
+
+// checkConcurrentState explains a conditional state update of asset that matched no row:
+// nil when another writer already moved it to state, ErrAssetStateChanged otherwise
+func (engine *Engine) checkConcurrentState(asset *Asset, from Status, state Status) error {
+	var current Asset
+	if err := engine.DatabaseClient.Select("id", "state", "size_bytes").Where("id = ?", asset.ID).First(&current).Error; err != nil {
+		return fmt.Errorf("get asset %q state: %w", asset.Checksum, err)
+	}
+	if current.State != state {
+		asset.State = from
+		return fmt.Errorf("%w: %s is %s, expected %s", ErrAssetStateChanged, asset.Checksum, current.State, from)
+	}
+
+	slog.Debug("Asset state already changed by another writer", "checksum", asset.Checksum, "state", state)
+	asset.SizeBytes = current.SizeBytes
+	return nil
+}
+
+// AssetPatch edits asset metadata, nil fields are kept and Extra is merged as a JSON merge patch
+type AssetPatch struct {
+	Display  *string
+	MimeType *string
+	Owner    *string
+	Extra    map[string]any
+}
+
+// PatchAssetRecord applies a patch to an asset, only the columns whose value changes are written.
+// Extra is merged against the stored document, so concurrent enrichment results are kept.
+func (engine *Engine) PatchAssetRecord(asset *Asset, patch AssetPatch) error {
+	slog.Debug("Patching asset record", "checksum", asset.Checksum)
+
+	return engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]any{}
+
+		if patch.Display != nil && *patch.Display != asset.Display {
+			updates["display"] = *patch.Display
+		}
+		if patch.MimeType != nil && NormalizeString(*patch.MimeType) != asset.MimeType {
+			updates["mime_type"] = NormalizeString(*patch.MimeType)
+		}
+		if patch.Owner != nil && strings.TrimSpace(*patch.Owner) != asset.Owner {
+			owner := strings.TrimSpace(*patch.Owner)
+			if err := ValidateOwner(owner); err != nil {
+				return err
+			}
+			updates["owner"] = owner
+		}
+
+		var extra datatypes.JSON
+		if len(patch.Extra) > 0 {
+			var current Asset
+			if err := tx.Select("id", "extra").First(&current, asset.ID).Error; err != nil {
+				return fmt.Errorf("get asset %q extra: %w", asset.Checksum, err)
+			}
+
+			merged, err := MergeExtra(current.Extra, patch.Extra)
+			if err != nil {
+				return fmt.Errorf("patch asset %q extra: %w", asset.Checksum, err)
+			}
+
+			// compare re-encoded documents, the database may format the stored one differently
+			stored, err := MergeExtra(current.Extra, nil)
+			if err != nil {
+				return fmt.Errorf("patch asset %q extra: %w", asset.Checksum, err)
+			}
+
+			extra = merged
+			if !bytes.Equal(merged, stored) {
+				updates["extra"] = merged
+			}
+		}
+
+		if len(updates) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(asset).Updates(updates).Error; err != nil {
+			return fmt.Errorf("patch asset %q: %w", asset.Checksum, err)
+		}
+
+		if v, ok := updates["display"]; ok {
+			asset.Display = v.(string)
+		}
+		if v, ok := updates["mime_type"]; ok {
+			asset.MimeType = v.(string)
+		}
+		if v, ok := updates["owner"]; ok {
+			asset.Owner = v.(string)
+		}
+		if extra != nil {
+			asset.Extra = extra
+		}
+		return nil
+	})
+}
+
+// DeleteAssetRecords permanently removes assets and their associations
+func (engine *Engine) DeleteAssetRecords(assets ...*Asset) error {
+	slog.Debug("Deleting asset records", "total", len(assets))
+	if len(assets) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(assets))
+	for i, a := range assets {
+		ids[i] = a.ID
+	}
+
+	// Clear join tables first so no dangling references remain
+	for _, table := range []string{"asset_tags", "asset_dataset_versions", "dataset_version_assets", "asset_peers", "asset_contents", "asset_enrichments", "asset_digests", "asset_deletions"} {
+		if err := engine.DatabaseClient.Exec("DELETE FROM "+table+" WHERE asset_id IN ?", ids).Error; err != nil {
+			return fmt.Errorf("delete assets from %s: %w", table, err)
+		}
+	}
+
+	if err := engine.DatabaseClient.Unscoped().Delete(&Asset{}, ids).Error; err != nil {
+		return fmt.Errorf("delete assets: %w", err)
+	}
+
+	return nil
+}
+
+// AttachTags attaches tags to an asset, detaching the other tags of their groups in the same transaction.
+// It returns the detached tags.
+func (engine *Engine) AttachTags(asset *Asset, tags []*Tag) ([]*TagSwap, error) {
 	slog.Debug("Attempting to attach tags to asset", "AssetID", asset.ID, "tagCount", len(tags))
 
 	if len(tags) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	// Append all tags at once
-	if err := engine.DatabaseClient.Model(asset).Association("Tags").Append(tags); err != nil {
-		return fmt.Errorf("attach tags %q: %w", asset.Checksum, err)
+	if err := engine.checkExclusiveTags(tags); err != nil {
+		return nil, err
+	}
+
+	var swaps []*TagSwap
+	err := engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if swaps, err = engine.WithTx(tx).detachGroupSiblings([]uint{asset.ID}, tags); err != nil {
+			return err
+		}
+
+		// Append all tags at once
+		if err := tx.Model(asset).Association("Tags").Append(tags); err != nil {
+			return fmt.Errorf("attach tags %q: %w", asset.Checksum, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Single reload for all tags
 	if err := engine.DatabaseClient.Preload("Tags").First(asset, asset.ID).Error; err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return swaps, nil
 }
 
 func (engine *Engine) DetachTags(asset *Asset, tags []*Tag) error {
@@ -96,15 +405,144 @@ func (engine *Engine) DetachTags(asset *Asset, tags []*Tag) error {
 	return nil
 }
 
-func (engine *Engine) CreateTagRecord(name string) (*Tag, error) {
-	slog.Debug("creating a new tag", "name", name)
+// AttachTagToChecksums tags the assets of checksums in the project of the tag in a single bulk insert,
+// assets in the trash are left out.
+// Assets already carrying the tag are skipped, it returns how many assets were newly tagged
+// and the other tags of its group detached in the same transaction.
+func (engine *Engine) AttachTagToChecksums(tag *Tag, checksums []string) (int64, []*TagSwap, error) {
+	slog.Debug("Attaching tag to assets", "tag", tag.Name, "total", len(checksums))
+
+	if len(checksums) == 0 {
+		return 0, nil, nil
+	}
+
+	var attached int64
+	var swaps []*TagSwap
+	err := engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		if tag.TagGroupID != nil {
+			var ids []uint
+			err := tx.Model(&Asset{}).
+				Where("project = ? AND checksum IN ? AND state <> ?", tag.Project, checksums, StatusDeleted).
+				Pluck("id", &ids).Error
+			if err != nil {
+				return fmt.Errorf("attach tag %q: %w", tag.Name, err)
+			}
+			if swaps, err = engine.WithTx(tx).detachGroupSiblings(ids, []*Tag{tag}); err != nil {
+				return err
+			}
+		}
+
+		result := tx.Exec(`
+			INSERT INTO asset_tags (asset_id, tag_id)
+			SELECT id, ? FROM assets
+			WHERE project = ? AND checksum IN ? AND state <> ? AND deleted_at IS NULL
+			ON CONFLICT DO NOTHING`, tag.ID, tag.Project, checksums, StatusDeleted)
+		if result.Error != nil {
+			return fmt.Errorf("attach tag %q: %w", tag.Name, result.Error)
+		}
+		attached = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return attached, swaps, nil
+}
+
+// DetachTagFromChecksums removes the tag from the assets of checksums in its project in a single delete,
+// it returns how many assets lost the tag
+func (engine *Engine) DetachTagFromChecksums(tag *Tag, checksums []string) (int64, error) {
+	slog.Debug("Detaching tag from assets", "tag", tag.Name, "total", len(checksums))
+
+	if len(checksums) == 0 {
+		return 0, nil
+	}
+
+	result := engine.DatabaseClient.Exec(`
+		DELETE FROM asset_tags
+		WHERE tag_id = ? AND asset_id IN (SELECT id FROM assets WHERE project = ? AND checksum IN ?)`, tag.ID, tag.Project, checksums)
+	if result.Error != nil {
+		return 0, fmt.Errorf("detach tag %q: %w", tag.Name, result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// ActiveChecksums returns which of checksums belong to assets outside the trash
+func (engine *Engine) ActiveChecksums(checksums []string) ([]string, error) {
+	var active []string
+	if len(checksums) == 0 {
+		return active, nil
+	}
+
+	err := engine.DatabaseClient.Model(&Asset{}).
+		Where("checksum IN ? AND state <> ?", checksums, StatusDeleted).
+		Pluck("checksum", &active).Error
+	if err != nil {
+		return nil, fmt.Errorf("find active checksums: %w", err)
+	}
+
+	return active, nil
+}
+
+// FindAssetRecords looks up the assets of checksums in a single query, unknown checksums are left out.
+// Only the checksum, state, size and classification columns are loaded.
+func (engine *Engine) FindAssetRecords(checksums []string) ([]*Asset, error) {
+	slog.Debug("Finding asset records", "total", len(checksums))
+
+	var assets []*Asset
+	if len(checksums) == 0 {
+		return assets, nil
+	}
+
+	normalized := make([]string, len(checksums))
+	for i, checksum := range checksums {
+		normalized[i] = NormalizeString(checksum)
+	}
+
+	err := engine.DatabaseClient.Model(&Asset{}).
+		Select("id", "checksum", "state", "size_bytes", "classification").
+		Where("checksum IN ?", normalized).
+		Find(&assets).Error
+	if err != nil {
+		return nil, fmt.Errorf("find assets: %w", err)
+	}
+
+	return assets, nil
+}
+
+// ClearTags detaches every tag from the asset
+func (engine *Engine) ClearTags(asset *Asset) error {
+	slog.Debug("Attempting to clear asset tags", "AssetID", asset.ID)
+
+	if err := engine.DatabaseClient.Model(asset).Association("Tags").Clear(); err != nil {
+		return fmt.Errorf("clear tags %q: %w", asset.Checksum, err)
+	}
+
+	asset.Tags = nil
+	return nil
+}
+
+func (engine *Engine) CreateTagRecord(tag *Tag) error {
+	slog.Debug("creating a new tag", "name", tag.Name)
 
-	tag := &Tag{Name: name}
 	if err := engine.DatabaseClient.Create(tag).Error; err != nil {
-		return nil, fmt.Errorf("create tag %q: %w", name, err)
+		return fmt.Errorf("create tag %q: %w", tag.Name, err)
+	}
+
+	return nil
+}
+
+// UpdateTagRecord stores the editable tag metadata, its description and color
+func (engine *Engine) UpdateTagRecord(tag *Tag) error {
+	slog.Debug("updating tag", "name", tag.Name)
+
+	if err := engine.DatabaseClient.Model(tag).Select("description", "color").Updates(tag).Error; err != nil {
+		return fmt.Errorf("update tag %q: %w", tag.Name, err)
 	}
 
-	return tag, nil
+	return nil
 }
 
 func (engine *Engine) GetTagRecord(name string) (*Tag, error) {
@@ -184,8 +622,82 @@ func (engine *Engine) ListAssetsRecords(opts ...SearchAssetsOption) ([]*Asset, e
 	}
 	slog.Debug("created new query", "query", query)
 
+	return engine.SearchAssetsRecords(query)
+}
+
+// CountAssetsRecords counts the assets matching the filters of query, its page is ignored
+func (engine *Engine) CountAssetsRecords(query *SearchAssetsQuery) (int64, error) {
+	tx, err := engine.filterAssets(query)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("count assets: %w", err)
+	}
+	return total, nil
+}
+
+// SearchAssetsRecords returns the page of assets matching a built query
+func (engine *Engine) SearchAssetsRecords(query *SearchAssetsQuery) ([]*Asset, error) {
+	tx, err := engine.filterAssets(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pagination, the cursor is the id of the last asset of the previous page
+	direction, after := "ASC", ">"
+	if query.Descending {
+		direction, after = "DESC", "<"
+	}
+
+	if query.Cursor > 0 {
+		if query.OrderBy == "id" {
+			tx = tx.Where("id "+after+" ?", query.Cursor)
+		} else {
+			// keyset on (column, id), soft deleted cursor assets keep their sort value, purged ones don't
+			var found int64
+			if err := engine.DatabaseClient.Unscoped().Model(&Asset{}).Where("id = ?", query.Cursor).Count(&found).Error; err != nil {
+				return nil, fmt.Errorf("find page cursor: %w", err)
+			}
+			if found == 0 {
+				return nil, fmt.Errorf("%w: %d", ErrCursorNotFound, query.Cursor)
+			}
+			last := engine.DatabaseClient.Unscoped().Model(&Asset{}).Select(query.OrderBy).Where("id = ?", query.Cursor)
+			tx = tx.Where(
+				fmt.Sprintf("(%[1]s %[2]s (?) OR (%[1]s = (?) AND id %[2]s ?))", query.OrderBy, after),
+				last, last, query.Cursor,
+			)
+		}
+	}
+	tx = tx.Limit(int(query.Limit))
+
+	// OrderBy comes from the sortable columns allow-list
+	order := "id " + direction
+	if query.OrderBy != "id" {
+		order = query.OrderBy + " " + direction + ", " + order
+	}
+
+	// Execute query, then load the tags of the page at once
+	var assets []*Asset
+	if err := tx.Order(order).Find(&assets).Error; err != nil {
+		return nil, err
+	}
+
+	if err := engine.loadTags(assets); err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+// filterAssets starts an assets query with the filters of query
+func (engine *Engine) filterAssets(query *SearchAssetsQuery) (*gorm.DB, error) {
+	var err error
+
 	// Start query with base filters
-	tx := engine.DatabaseClient.Model(&Asset{}).Where(&Asset{
+	tx := engine.explainSlow(engine.DatabaseClient, "list assets").Model(&Asset{}).Where(&Asset{
 		MimeType: query.MimeType,
 		State:    query.State,
 	})
@@ -195,6 +707,43 @@ func (engine *Engine) ListAssetsRecords(opts ...SearchAssetsOption) ([]*Asset, e
 		tx = tx.Where("checksum IN ?", query.CheckSums)
 	}
 
+	// Filter by license
+	if len(query.Licenses) > 0 {
+		tx = tx.Where("license IN ?", query.Licenses)
+	}
+
+	// Filter by owner
+	if len(query.Owners) > 0 {
+		tx = tx.Where("owner IN ?", query.Owners)
+	}
+	if query.Ownerless {
+		tx = tx.Where("owner = '' OR owner IS NULL")
+	}
+
+	// Filter by time and size ranges
+	if query.CreatedFrom != nil {
+		tx = tx.Where("created_at >= ?", *query.CreatedFrom)
+	}
+	if query.CreatedTo != nil {
+		tx = tx.Where("created_at < ?", *query.CreatedTo)
+	}
+	if query.UpdatedAfter != nil {
+		tx = tx.Where("updated_at > ?", *query.UpdatedAfter)
+	}
+	if query.MinSize != nil {
+		tx = tx.Where("size_bytes >= ?", *query.MinSize)
+	}
+	if query.MaxSize != nil {
+		tx = tx.Where("size_bytes <= ?", *query.MaxSize)
+	}
+
+	// Filter by extra metadata
+	if len(query.Extra) > 0 {
+		if tx, err = engine.applyExtraFilters(tx, query.Extra); err != nil {
+			return nil, err
+		}
+	}
+
 	// IncludedTags: Filter assets that have ALL specified tags (AND logic)
 	if len(query.IncludedTags) > 0 {
 		subQuery := engine.DatabaseClient.
@@ -221,41 +770,28 @@ func (engine *Engine) ListAssetsRecords(opts ...SearchAssetsOption) ([]*Asset, e
 		tx = tx.Where("id NOT IN (?)", subQuery)
 	}
 
-	// Pagination
-	if query.Cursor > 0 {
-		tx = tx.Where("id > ?", query.Cursor)
-	}
-	tx = tx.Limit(int(query.Limit))
-
-	// Execute query with preloaded tags
-	var assets []*Asset
-	if err := tx.Preload("Tags").Order("id ASC").Find(&assets).Error; err != nil {
-		return nil, err
-	}
-
-	return assets, nil
+	return tx, nil
 }
 
-func (engine *Engine) CreateDatasetRecord(name string, description string) (*Dataset, error) {
-	slog.Debug("creating a new dataset", "name", name)
+func (engine *Engine) CreateDatasetRecord(ds *Dataset) error {
+	slog.Debug("creating a new dataset", "name", ds.Name)
 
-	ds := &Dataset{Name: name, Description: description}
 	if err := engine.DatabaseClient.Create(ds).Error; err != nil {
-		return nil, fmt.Errorf("create dataset %q: %w", name, err)
+		return fmt.Errorf("create dataset %q: %w", ds.Name, err)
 	}
 
-	return ds, nil
+	return nil
 }
 
 func (engine *Engine) GetDatasetRecord(name string) (*Dataset, error) {
 	slog.Debug("getting dataset", "dataset", name)
-	
-	var ds *Dataset
-	if err := engine.DatabaseClient.Where(&Dataset{Name: name}).First(ds).Error; err != nil {
+
+	var ds Dataset
+	if err := engine.DatabaseClient.Where(&Dataset{Name: NormalizeString(name)}).First(&ds).Error; err != nil {
 		return nil, fmt.Errorf("get dataset %q: %w", name, err)
 	}
 
-	return ds, nil
+	return &ds, nil
 }
 
 func (engine *Engine) CreateDatasetVersionRecord(datasetName string, description string) (*DatasetVersion, error) {
@@ -283,9 +819,13 @@ func (engine *Engine) CreateDatasetVersionRecord(datasetName string, description
 
 func (engine *Engine) CreateAssetRecords(assets ...*Asset) error {
 	slog.Debug("creating new assets", "total", len(assets))
+	if err := engine.applyProjectDefaults(assets...); err != nil {
+		return err
+	}
 	if err := engine.DatabaseClient.Create(assets).Error; err != nil {
 		return fmt.Errorf("create assets: %w", err)
 	}
 
+	engine.notifyAssets(WebhookAssetCreated, assets...)
 	return nil
 }
@@ -0,0 +1,261 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// countQueries counts the select statements run by engine from now on
+func countQueries(t testing.TB, engine *Engine) *atomic.Int64 {
+	t.Helper()
+
+	var n atomic.Int64
+	count := func(*gorm.DB) { n.Add(1) }
+	err := engine.DatabaseClient.Callback().Query().After("gorm:query").Register("test:count_queries", count)
+	if err == nil {
+		err = engine.DatabaseClient.Callback().Row().After("gorm:row").Register("test:count_rows", count)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &n
+}
+
+// seedTaggedAssets creates n ready assets carrying two tags each, all members of dsv
+func seedTaggedAssets(t testing.TB, engine *Engine, dsv *DatasetVersion, from int, n int) {
+	t.Helper()
+
+	red, blue := &Tag{Name: "red"}, &Tag{Name: "blue"}
+	for _, tag := range []*Tag{red, blue} {
+		if err := engine.DatabaseClient.Where(Tag{Name: tag.Name}).FirstOrCreate(tag).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assets := make([]*Asset, n)
+	checksums := make([]string, n)
+	for i := range assets {
+		checksums[i] = fmt.Sprintf("%064x", from+i)
+		assets[i] = &Asset{Checksum: checksums[i], Display: fmt.Sprintf("photo %d", from+i), State: StatusReady}
+		if err := engine.CreateAssetRecord(assets[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tag := range []*Tag{red, blue} {
+		if _, _, err := engine.AttachTagToChecksums(tag, checksums); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := engine.AddAssetsToVersion(dsv, assets...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestAssetListsLoadTagsInOneQuery checks listing assets costs the same number of queries for any page size
+func TestAssetListsLoadTagsInOneQuery(t *testing.T) {
+	engine := newTestEngine(t)
+
+	if err := engine.CreateDatasetRecord(&Dataset{Name: "photos"}); err != nil {
+		t.Fatal(err)
+	}
+	dsv, err := engine.CreateDatasetVersionRecord("photos", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lists := []struct {
+		name string
+		// want is the number of queries of a page, the page itself and the tags of its assets
+		want int64
+		list func() ([]*Asset, error)
+	}{
+		{name: "assets", want: 2, list: func() ([]*Asset, error) {
+			return engine.ListAssetsRecords()
+		}},
+		{name: "search", want: 3, list: func() ([]*Asset, error) {
+			matches, err := engine.Search(context.Background(), "photo", 0)
+			assets := make([]*Asset, len(matches))
+			for i, m := range matches {
+				assets[i] = m.Asset
+			}
+			return assets, err
+		}},
+		{name: "dataset version", want: 2, list: func() ([]*Asset, error) {
+			return engine.ListVersionAssets(dsv, 0, 0)
+		}},
+		{name: "ownerless", want: 2, list: func() ([]*Asset, error) {
+			return engine.ListOwnerlessAssetRecords(0, 0)
+		}},
+		{name: "replication", want: 2, list: func() ([]*Asset, error) {
+			changes, err := engine.ListReplicationChanges(Watermark{}, 0)
+			assets := make([]*Asset, len(changes))
+			for i, c := range changes {
+				assets[i] = &Asset{Checksum: c.Checksum}
+				for _, name := range c.Tags {
+					assets[i].Tags = append(assets[i].Tags, Tag{Name: name})
+				}
+			}
+			return assets, err
+		}},
+	}
+
+	queries := countQueries(t, engine)
+	for _, size := range []int{1, 10} {
+		seedTaggedAssets(t, engine, dsv, 100*size, size)
+
+		for _, l := range lists {
+			t.Run(fmt.Sprintf("%s of %d", l.name, size), func(t *testing.T) {
+				before := queries.Load()
+				assets, err := l.list()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := queries.Load() - before; got != l.want {
+					t.Errorf("%d queries, want %d", got, l.want)
+				}
+
+				for _, a := range assets {
+					names := make([]string, len(a.Tags))
+					for i, tag := range a.Tags {
+						names[i] = tag.Name
+					}
+					if got := strings.Join(names, ","); got != "blue,red" {
+						t.Errorf("asset %s tags = %q, want blue,red", a.Checksum, got)
+					}
+				}
+			})
+		}
+	}
+}
+
+// benchmarkLookups runs the hot lookups of the api, an asset by checksum and a tag by name, on an engine built with opts
+func benchmarkLookups(b *testing.B, opts ...Option) {
+	engine := newTestEngine(b, opts...)
+
+	checksum := fmt.Sprintf("%064x", 1)
+	if err := engine.CreateAssetRecord(&Asset{Checksum: checksum, State: StatusReady}); err != nil {
+		b.Fatal(err)
+	}
+	if err := engine.CreateTagRecord(&Tag{Name: "red"}); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("asset by checksum", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := engine.GetAssetRecord(checksum); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("tag by name", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := engine.GetTagRecord("red"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkLookups(b *testing.B) {
+	benchmarkLookups(b)
+}
+
+func BenchmarkLookupsPreparedStatements(b *testing.B) {
+	benchmarkLookups(b, WithPreparedStatements(100, 0))
+}
+
+// TestSortedPageAfterPurgedCursor checks a sorted page continuing after a purged asset fails instead of coming back empty
+func TestSortedPageAfterPurgedCursor(t *testing.T) {
+	engine := newTestEngine(t)
+
+	assets := make([]*Asset, 3)
+	for i := range assets {
+		assets[i] = &Asset{Checksum: fmt.Sprintf("%064x", i), Display: fmt.Sprintf("photo %d", i), State: StatusReady}
+		if err := engine.CreateAssetRecord(assets[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, err := engine.ListAssetsRecords(WithOrderBy("display", SortAscending), WithCursor(assets[0].ID))
+	if err != nil || len(page) != 2 {
+		t.Fatalf("page after %d got %d assets, %v, want 2", assets[0].ID, len(page), err)
+	}
+
+	if err := engine.DatabaseClient.Unscoped().Delete(assets[0]).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.ListAssetsRecords(WithOrderBy("display", SortAscending), WithCursor(assets[0].ID)); !errors.Is(err, ErrCursorNotFound) {
+		t.Errorf("page after purged cursor got %v, want %v", err, ErrCursorNotFound)
+	}
+}
+
+// TestConcurrentStateChangesCountUsageOnce applies the same state change from two stale copies of an asset,
+// like a client finalize racing a bucket notification, or two deletes, and checks the usage counts it once
+func TestConcurrentStateChangesCountUsageOnce(t *testing.T) {
+	ctx := context.Background()
+	engine := newTestEngine(t)
+
+	peer, err := engine.EnsurePeerRecord("origin", PeerSource, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usage := func(want int64) {
+		t.Helper()
+		u, err := engine.GetUsage(DEFAULT_PROJECT)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.StoredObjects != want || u.StoredBytes != want*10 {
+			t.Errorf("usage is %d objects of %d bytes, want %d of %d", u.StoredObjects, u.StoredBytes, want, want*10)
+		}
+	}
+
+	assets := make([]*Asset, 2)
+	for i := range assets {
+		assets[i] = &Asset{Checksum: fmt.Sprintf("%064x", i+100), State: StatusPending}
+		if err := engine.CreateAssetRecord(assets[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, asset := range assets {
+		first, second := *asset, *asset
+		for _, copy := range []*Asset{&first, &second} {
+			if err := engine.CompleteAssetRecord(copy, &ObjectInfo{Size: 10}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	usage(2)
+
+	first, second := *assets[0], *assets[0]
+	first.State, second.State = StatusReady, StatusReady
+	first.SizeBytes, second.SizeBytes = 10, 10
+	for _, copy := range []*Asset{&first, &second} {
+		if err := engine.UpdateAssetState(copy, StatusDeleted); err != nil {
+			t.Fatal(err)
+		}
+	}
+	usage(1)
+
+	// a copy read before another writer moved the asset elsewhere is refused
+	stale := first
+	stale.State = StatusReady
+	if err := engine.UpdateAssetState(&stale, StatusPending); !errors.Is(err, ErrAssetStateChanged) {
+		t.Errorf("stale state update got %v, want %v", err, ErrAssetStateChanged)
+	}
+
+	// replicated deletions leave the usage too
+	change := &ReplicationChange{Project: DEFAULT_PROJECT, Checksum: assets[1].Checksum, State: StatusDeleted}
+	if _, err := engine.ApplyReplicationChange(ctx, peer, change); err != nil {
+		t.Fatal(err)
+	}
+	usage(0)
+}
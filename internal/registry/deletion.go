@@ -0,0 +1,376 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+const (
+	DEFAULT_DELETION_GRACE    = 7 * 24 * time.Hour
+	DEFAULT_DELETION_INTERVAL = time.Hour
+	DEFAULT_DELETION_LOCK     = "aether:deletion"
+
+	// EventAssetDeletion notifies dataset owners about deletions of assets their versions reference
+	EventAssetDeletion = "asset.deletion"
+)
+
+var (
+	ErrDeletionPending = registryerrors.New(registryerrors.ErrConflict, "asset deletion already pending")
+	ErrDeletionStatus  = registryerrors.New(registryerrors.ErrConflict, "invalid asset deletion status transition")
+)
+
+// DeletionStatus is the stage of a two-phase asset deletion
+type DeletionStatus string
+
+const (
+	DeletionPending   DeletionStatus = "pending"
+	DeletionObjected  DeletionStatus = "objected"
+	DeletionCancelled DeletionStatus = "cancelled"
+	DeletionCompleted DeletionStatus = "completed"
+)
+
+// AssetDeletion is a deletion request of a referenced asset. It stays pending for the grace period,
+// an objection or cancellation closes it, otherwise the deletion job completes it.
+type AssetDeletion struct {
+	gorm.Model
+	AssetID     uint `gorm:"not null;index"`
+	Asset       Asset
+	Status      DeletionStatus `gorm:"not null;size:20;default:'pending';index"`
+	Purge       bool
+	RequestedBy string    `gorm:"size:200"`
+	PurgeAfter  time.Time `gorm:"not null;index"`
+	ObjectedBy  string    `gorm:"size:200"`
+	Objection   string
+	ClosedAt    *time.Time
+}
+
+// RequestAssetDeletion opens a deletion of an asset that completes after the grace period
+func (engine *Engine) RequestAssetDeletion(asset *Asset, principal string, purge bool) (*AssetDeletion, error) {
+	slog.Debug("Requesting asset deletion", "checksum", asset.Checksum, "purge", purge, "grace", engine.deletionGrace)
+
+	deletion := &AssetDeletion{
+		AssetID:     asset.ID,
+		Asset:       *asset,
+		Status:      DeletionPending,
+		Purge:       purge,
+		RequestedBy: principal,
+		PurgeAfter:  time.Now().Add(engine.deletionGrace),
+	}
+
+	err := engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		var open int64
+		err := tx.Model(&AssetDeletion{}).
+			Where("asset_id = ? AND status = ?", asset.ID, DeletionPending).
+			Count(&open).Error
+		if err != nil {
+			return err
+		}
+		if open > 0 {
+			return fmt.Errorf("%w: %s", ErrDeletionPending, asset.Checksum)
+		}
+
+		return tx.Omit("Asset").Create(deletion).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("request deletion of asset %q: %w", asset.Checksum, err)
+	}
+	return deletion, nil
+}
+
+// GetAssetDeletionRecord returns the latest deletion request of an asset
+func (engine *Engine) GetAssetDeletionRecord(asset *Asset) (*AssetDeletion, error) {
+	var deletion AssetDeletion
+	err := engine.DatabaseClient.
+		Where("asset_id = ?", asset.ID).
+		Order("id DESC").
+		First(&deletion).Error
+	if err != nil {
+		return nil, err
+	}
+
+	deletion.Asset = *asset
+	return &deletion, nil
+}
+
+// ListAssetDeletionRecords returns a page of deletions in status, ordered by id after cursor
+func (engine *Engine) ListAssetDeletionRecords(status DeletionStatus, cursor uint, limit uint) ([]*AssetDeletion, error) {
+	if limit == 0 || limit > SearchMaxLimit {
+		limit = SearchDefaultLimit
+	}
+
+	var deletions []*AssetDeletion
+	db := engine.DatabaseClient.Preload("Asset").Where("id > ?", cursor)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	if err := db.Order("id ASC").Limit(int(limit)).Find(&deletions).Error; err != nil {
+		return nil, fmt.Errorf("list asset deletions: %w", err)
+	}
+	return deletions, nil
+}
+
+// ObjectToAssetDeletion stops a pending deletion, the asset is kept
+func (engine *Engine) ObjectToAssetDeletion(deletion *AssetDeletion, principal string, reason string) error {
+	return engine.closeAssetDeletion(deletion, DeletionObjected, map[string]any{
+		"objected_by": principal,
+		"objection":   reason,
+	})
+}
+
+// CancelAssetDeletion withdraws a pending deletion
+func (engine *Engine) CancelAssetDeletion(deletion *AssetDeletion) error {
+	return engine.closeAssetDeletion(deletion, DeletionCancelled, nil)
+}
+
+// closeAssetDeletion moves a pending deletion to a final status, guarding against concurrent transitions
+func (engine *Engine) closeAssetDeletion(deletion *AssetDeletion, status DeletionStatus, updates map[string]any) error {
+	slog.Debug("Closing asset deletion", "deletion", deletion.ID, "from", deletion.Status, "to", status)
+
+	if deletion.Status != DeletionPending {
+		return fmt.Errorf("%w: deletion is %s, only pending deletions can be %s", ErrDeletionStatus, deletion.Status, status)
+	}
+
+	now := time.Now()
+	if updates == nil {
+		updates = map[string]any{}
+	}
+	updates["status"] = status
+	updates["closed_at"] = now
+
+	result := engine.DatabaseClient.Model(&AssetDeletion{}).
+		Where("id = ? AND status = ?", deletion.ID, DeletionPending).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("close asset deletion %d: %w", deletion.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: deletion %d is no longer pending", ErrDeletionStatus, deletion.ID)
+	}
+
+	deletion.Status, deletion.ClosedAt = status, &now
+	if v, ok := updates["objected_by"].(string); ok {
+		deletion.ObjectedBy = v
+	}
+	if v, ok := updates["objection"].(string); ok {
+		deletion.Objection = v
+	}
+	return nil
+}
+
+// CompleteAssetDeletion detaches the asset from draft versions and moves it to the trash.
+// With purge the stored objects are removed too, unless locked versions still reference the asset.
+func (engine *Engine) CompleteAssetDeletion(ctx context.Context, deletion *AssetDeletion) (purged bool, err error) {
+	slog.Debug("Completing asset deletion", "deletion", deletion.ID, "checksum", deletion.Asset.Checksum)
+
+	asset := &deletion.Asset
+	err = engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		engine := engine.WithTx(tx)
+
+		var drafts []uint
+		err := tx.Model(&DatasetVersion{}).
+			Joins("JOIN dataset_version_assets ON dataset_version_assets.dataset_version_id = dataset_versions.id").
+			Where("dataset_version_assets.asset_id = ? AND dataset_versions.status = ?", asset.ID, VersionDraft).
+			Pluck("dataset_versions.id", &drafts).Error
+		if err != nil {
+			return fmt.Errorf("find draft versions of asset %q: %w", asset.Checksum, err)
+		}
+
+		if len(drafts) > 0 {
+			members := make([]DatasetVersionAsset, len(drafts))
+			for i, id := range drafts {
+				members[i] = DatasetVersionAsset{DatasetVersionID: id, AssetID: asset.ID}
+			}
+			if err := tx.Delete(&members).Error; err != nil {
+				return fmt.Errorf("remove asset %q from draft versions: %w", asset.Checksum, err)
+			}
+		}
+
+		if err := engine.ClearTags(asset); err != nil {
+			return err
+		}
+		if err := engine.UpdateAssetState(asset, StatusDeleted); err != nil {
+			return err
+		}
+
+		result := tx.Model(&AssetDeletion{}).
+			Where("id = ? AND status = ?", deletion.ID, DeletionPending).
+			Updates(map[string]any{"status": DeletionCompleted, "closed_at": time.Now()})
+		if result.Error != nil {
+			return fmt.Errorf("complete asset deletion %d: %w", deletion.ID, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: deletion %d is no longer pending", ErrDeletionStatus, deletion.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	deletion.Status = DeletionCompleted
+
+	if !deletion.Purge {
+		return false, nil
+	}
+
+	// objects go after the state change, like a regular purge
+	if err := engine.CheckUnreferenced(asset); err != nil {
+		if errors.Is(err, ErrAssetReferenced) {
+			return false, nil
+		}
+		return false, err
+	}
+	scoped := engine.ForProject(ctx, asset.Project)
+	if err := scoped.DeleteObjects(ctx, scoped.AssetKeys(asset.Checksum)...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeletionWorker completes pending deletions whose grace period passed
+type DeletionWorker struct {
+	engine *Engine
+	batch  int
+}
+
+// NewDeletionWorker creates a worker for the default project deletions
+func (engine *Engine) NewDeletionWorker() *DeletionWorker {
+	return &DeletionWorker{engine: engine, batch: SearchDefaultLimit}
+}
+
+// Job wraps the worker as a scheduled job
+func (w *DeletionWorker) Job(interval time.Duration) Job {
+	return Job{
+		Name:     "deletion",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := w.Run(ctx)
+			return err
+		},
+		Plan: w.Plan,
+	}
+}
+
+// Run completes every due deletion and returns how many were completed
+func (w *DeletionWorker) Run(ctx context.Context) (int, error) {
+	lock, err := w.engine.TryLock(ctx, DEFAULT_DELETION_LOCK)
+	if err != nil || lock == nil {
+		return 0, err
+	}
+	defer lock.Release()
+
+	completed, cursor, now := 0, uint(0), time.Now()
+	for {
+		due, err := w.due(now, cursor)
+		if err != nil {
+			return completed, err
+		}
+
+		for _, deletion := range due {
+			if err := ctx.Err(); err != nil {
+				return completed, err
+			}
+
+			purged, err := w.engine.CompleteAssetDeletion(ctx, deletion)
+			if err != nil {
+				// a failed deletion stays due and is retried next run
+				slog.Error("Failed to complete asset deletion", "deletion", deletion.ID, "checksum", deletion.Asset.Checksum, "error", err)
+				continue
+			}
+			completed++
+
+			message := "asset deletion completed"
+			if deletion.Purge && !purged {
+				message = "asset deletion completed, objects kept for locked dataset versions"
+			}
+			w.engine.recordDeletionEvent(deletion, SeverityInfo, message, map[string]any{"action": "completed", "purged": purged})
+		}
+
+		if len(due) < w.batch {
+			break
+		}
+		cursor = due[len(due)-1].ID
+	}
+
+	if completed > 0 {
+		slog.Info("Completed asset deletions", "deletions", completed)
+	}
+	return completed, nil
+}
+
+// Plan lists the due deletions Run would complete, purges carry the bytes of the objects they would remove
+func (w *DeletionWorker) Plan(ctx context.Context) (*DryRunReport, error) {
+	plan := &DryRunReport{Operation: "deletion"}
+
+	cursor, now := uint(0), time.Now()
+	for {
+		due, err := w.due(now, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, deletion := range due {
+			candidate := Candidate{Kind: CandidateAsset, Name: deletion.Asset.Checksum, Reason: "trash"}
+			if deletion.Purge {
+				candidate.Reason = "purge"
+				switch err := w.engine.CheckUnreferenced(&deletion.Asset); {
+				case errors.Is(err, ErrAssetReferenced):
+					candidate.Reason = "trash, objects kept for locked dataset versions"
+				case err != nil:
+					return nil, err
+				default:
+					if candidate.Bytes, err = w.engine.ForProject(ctx, deletion.Asset.Project).StoredBytes(ctx, deletion.Asset.Checksum); err != nil {
+						return nil, err
+					}
+				}
+			}
+			plan.Add(candidate)
+		}
+
+		if len(due) < w.batch {
+			return plan, nil
+		}
+		cursor = due[len(due)-1].ID
+	}
+}
+
+// due lists a batch of pending deletions past their grace period, after the cursor id
+func (w *DeletionWorker) due(now time.Time, cursor uint) ([]*AssetDeletion, error) {
+	var due []*AssetDeletion
+	err := w.engine.DatabaseClient.
+		Preload("Asset").
+		Where("status = ? AND purge_after <= ? AND id > ?", DeletionPending, now, cursor).
+		Order("id ASC").
+		Limit(w.batch).
+		Find(&due).Error
+	if err != nil {
+		return nil, fmt.Errorf("list due asset deletions: %w", err)
+	}
+	return due, nil
+}
+
+// recordDeletionEvent stores a deletion event attributed to the system, failures are only logged
+func (engine *Engine) recordDeletionEvent(deletion *AssetDeletion, severity Severity, message string, data map[string]any) {
+	if data == nil {
+		data = map[string]any{}
+	}
+	data["deletion"] = deletion.ID
+	data["checksum"] = deletion.Asset.Checksum
+	data["status"] = deletion.Status
+
+	event, err := NewEvent(EventAssetDeletion, severity, message, data)
+	if err != nil {
+		slog.Error("Failed to build deletion event", "error", err)
+		return
+	}
+	if err := engine.CreateEventRecord(event); err != nil {
+		slog.Error("Failed to record deletion event", "error", err)
+	}
+}
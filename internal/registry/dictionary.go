@@ -0,0 +1,126 @@
+package registry
+
+import (
+	"fmt"
+	"log/slog"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const DatasetSchemaMaxColumns = 1000
+
+// SchemaColumn documents a single column of a dataset
+type SchemaColumn struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Unit        string `json:"unit,omitempty"`
+	Nullable    bool   `json:"nullable"`
+	Description string `json:"description,omitempty"`
+}
+
+// DatasetSchema is one revision of a dataset data dictionary.
+// Revisions are append only, the highest revision is the current schema.
+type DatasetSchema struct {
+	gorm.Model
+	DatasetID   uint `gorm:"not null;uniqueIndex:idx_dataset_schema_revision"`
+	Revision    int  `gorm:"not null;uniqueIndex:idx_dataset_schema_revision"`
+	Description string
+	Columns     datatypes.JSONSlice[SchemaColumn] `gorm:"type:jsonb;not null"`
+	CreatedBy   string                            `gorm:"size:200"`
+}
+
+// Validate checks the columns are named, typed and unique
+func (s *DatasetSchema) Validate() error {
+	if len(s.Columns) > DatasetSchemaMaxColumns {
+		return fmt.Errorf("%w: schema has %d columns, at most %d are allowed", ErrValidation, len(s.Columns), DatasetSchemaMaxColumns)
+	}
+
+	seen := make(map[string]bool, len(s.Columns))
+	for i, c := range s.Columns {
+		if c.Name == "" || c.Type == "" {
+			return fmt.Errorf("%w: schema column %d needs a name and a type", ErrValidation, i)
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("%w: schema column %q is defined twice", ErrValidation, c.Name)
+		}
+		seen[c.Name] = true
+	}
+	return nil
+}
+
+// BeforeCreate hook for DatasetSchema - validate and auto-increment revision
+func (s *DatasetSchema) BeforeCreate(tx *gorm.DB) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	var maxRevision int
+	err := tx.Model(&DatasetSchema{}).
+		Where("dataset_id = ?", s.DatasetID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&maxRevision).Error
+
+	if err != nil {
+		return err
+	}
+
+	s.Revision = maxRevision + 1
+	return nil
+}
+
+// CreateDatasetSchemaRecord stores a new schema revision for the dataset
+func (engine *Engine) CreateDatasetSchemaRecord(datasetName string, schema *DatasetSchema) error {
+	slog.Debug("creating dataset schema revision", "dataset", datasetName, "columns", len(schema.Columns))
+
+	ds, err := engine.GetDatasetRecord(datasetName)
+	if err != nil {
+		return err
+	}
+
+	schema.DatasetID = ds.ID
+	if err := engine.DatabaseClient.Create(schema).Error; err != nil {
+		return fmt.Errorf("create dataset %q schema: %w", datasetName, err)
+	}
+
+	return nil
+}
+
+// GetDatasetSchemaRecord returns a schema revision of the dataset, revision 0 returns the current one
+func (engine *Engine) GetDatasetSchemaRecord(datasetName string, revision int) (*DatasetSchema, error) {
+	slog.Debug("getting dataset schema", "dataset", datasetName, "revision", revision)
+
+	ds, err := engine.GetDatasetRecord(datasetName)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := engine.DatabaseClient.Where("dataset_id = ?", ds.ID)
+	if revision > 0 {
+		tx = tx.Where("revision = ?", revision)
+	}
+
+	var schema DatasetSchema
+	if err := tx.Order("revision DESC").First(&schema).Error; err != nil {
+		return nil, fmt.Errorf("get dataset %q schema: %w", datasetName, err)
+	}
+
+	return &schema, nil
+}
+
+// ListDatasetSchemaRecords returns every schema revision of the dataset, newest first
+func (engine *Engine) ListDatasetSchemaRecords(datasetName string) ([]*DatasetSchema, error) {
+	slog.Debug("listing dataset schema revisions", "dataset", datasetName)
+
+	ds, err := engine.GetDatasetRecord(datasetName)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []*DatasetSchema
+	if err := engine.DatabaseClient.Where("dataset_id = ?", ds.ID).Order("revision DESC").Find(&schemas).Error; err != nil {
+		return nil, fmt.Errorf("list dataset %q schemas: %w", datasetName, err)
+	}
+
+	return schemas, nil
+}
@@ -0,0 +1,256 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+)
+
+const DEFAULT_DIGEST_INTERVAL = 5 * time.Minute
+
+var (
+	ErrUnknownDigestAlgorithm = errors.New("unknown digest algorithm")
+
+	digestAlgorithmRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,19}$`)
+)
+
+// digestAlgorithms are the algorithms digest jobs can compute, sha256 is the asset checksum itself
+var digestAlgorithms = struct {
+	sync.RWMutex
+	hashes map[string]func() hash.Hash
+}{hashes: map[string]func() hash.Hash{
+	"sha384":   sha512.New384,
+	"sha512":   sha512.New,
+	"sha3-256": func() hash.Hash { return sha3.New256() },
+	"sha3-512": func() hash.Hash { return sha3.New512() },
+}}
+
+// RegisterDigestAlgorithm makes a new algorithm available to digest jobs, e.g. one a client is moving to.
+// Register algorithms before creating the engine, names are lowercase and registered once.
+func RegisterDigestAlgorithm(name string, newHash func() hash.Hash) error {
+	if !digestAlgorithmRe.MatchString(name) || name == "sha256" {
+		return fmt.Errorf("%w: invalid digest algorithm name %q", ErrValidation, name)
+	}
+
+	digestAlgorithms.Lock()
+	defer digestAlgorithms.Unlock()
+	if _, ok := digestAlgorithms.hashes[name]; ok {
+		return fmt.Errorf("digest algorithm %q registered twice", name)
+	}
+	digestAlgorithms.hashes[name] = newHash
+	return nil
+}
+
+// DigestAlgorithms lists the registered algorithm names in order
+func DigestAlgorithms() []string {
+	digestAlgorithms.RLock()
+	defer digestAlgorithms.RUnlock()
+
+	names := make([]string, 0, len(digestAlgorithms.hashes))
+	for name := range digestAlgorithms.hashes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func newDigestHash(algorithm string) (hash.Hash, error) {
+	digestAlgorithms.RLock()
+	newHash, ok := digestAlgorithms.hashes[algorithm]
+	digestAlgorithms.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q, expected one of %v", ErrUnknownDigestAlgorithm, algorithm, DigestAlgorithms())
+	}
+	return newHash(), nil
+}
+
+// AssetDigest is the hex digest of an asset object under another algorithm than its sha256 checksum.
+// Digest jobs compute it from the stored object, so clients can move to a new algorithm gradually
+// while assets stay keyed by checksum. A failed computation keeps its error and an empty digest,
+// so the asset isn't read again on every run.
+type AssetDigest struct {
+	AssetID   uint   `gorm:"primaryKey"`
+	Algorithm string `gorm:"primaryKey;size:20;index:idx_asset_digests_lookup,priority:1"`
+	Digest    string `gorm:"size:128;index:idx_asset_digests_lookup,priority:2"`
+	Error     string
+	CreatedAt time.Time
+}
+
+// ListAssetDigestRecords returns the digests computed for an asset, ordered by algorithm
+func (engine *Engine) ListAssetDigestRecords(asset *Asset) ([]*AssetDigest, error) {
+	var digests []*AssetDigest
+	err := engine.DatabaseClient.
+		Where("asset_id = ? AND digest <> ''", asset.ID).
+		Order("algorithm ASC").
+		Find(&digests).Error
+	if err != nil {
+		return nil, fmt.Errorf("list asset %q digests: %w", asset.Checksum, err)
+	}
+	return digests, nil
+}
+
+// GetAssetByDigestRecord returns the asset whose object has digest under algorithm
+func (engine *Engine) GetAssetByDigestRecord(algorithm string, digest string) (*Asset, error) {
+	slog.Debug("Getting asset by digest", "algorithm", algorithm, "digest", digest)
+
+	algorithm = NormalizeString(algorithm)
+	if _, err := newDigestHash(algorithm); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	var asset Asset
+	err := engine.DatabaseClient.
+		Joins("JOIN asset_digests ON asset_digests.asset_id = assets.id").
+		Where("asset_digests.algorithm = ? AND asset_digests.digest = ?", algorithm, NormalizeString(digest)).
+		First(&asset).Error
+	if err != nil {
+		return nil, fmt.Errorf("get asset by %s digest %q: %w", algorithm, digest, err)
+	}
+	return &asset, nil
+}
+
+// DigestWorker computes one digest algorithm for the ready assets that miss it
+type DigestWorker struct {
+	engine    *Engine
+	algorithm string
+	batch     int
+}
+
+// NewDigestWorker creates a worker computing algorithm, which must be registered
+func (engine *Engine) NewDigestWorker(algorithm string) (*DigestWorker, error) {
+	if _, err := newDigestHash(algorithm); err != nil {
+		return nil, err
+	}
+	return &DigestWorker{engine: engine, algorithm: algorithm, batch: SearchDefaultLimit}, nil
+}
+
+// Job wraps the worker as a scheduled job
+func (w *DigestWorker) Job(interval time.Duration) Job {
+	return Job{
+		Name:     "digest-" + w.algorithm,
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := w.Run(ctx)
+			return err
+		},
+	}
+}
+
+// digestJobs returns a digest job per algorithm, the algorithms were validated when registered
+func (engine *Engine) digestJobs(algorithms []string, interval time.Duration) []Job {
+	jobs := make([]Job, 0, len(algorithms))
+	for _, algorithm := range algorithms {
+		if w, err := engine.NewDigestWorker(algorithm); err == nil {
+			jobs = append(jobs, w.Job(interval))
+		}
+	}
+	return jobs
+}
+
+// Run digests every waiting asset and returns how many were handled
+func (w *DigestWorker) Run(ctx context.Context) (int, error) {
+	lock, err := w.engine.TryLock(ctx, "aether:digest:"+w.algorithm)
+	if err != nil || lock == nil {
+		return 0, err
+	}
+	defer lock.Release()
+
+	handled, cursor := 0, uint(0)
+	for {
+		assets, err := w.engine.listDigestCandidates(w.algorithm, cursor, w.batch)
+		if err != nil {
+			return handled, err
+		}
+
+		for _, asset := range assets {
+			if err := ctx.Err(); err != nil {
+				return handled, err
+			}
+			if w.digest(ctx, asset) {
+				handled++
+			}
+		}
+
+		if len(assets) < w.batch {
+			break
+		}
+		cursor = assets[len(assets)-1].ID
+	}
+
+	if handled > 0 {
+		slog.Info("Computed asset digests", "algorithm", w.algorithm, "assets", handled)
+	}
+	return handled, nil
+}
+
+// digest computes and stores the digest of an asset, failures are stored with their error
+func (w *DigestWorker) digest(ctx context.Context, asset *Asset) bool {
+	record := &AssetDigest{AssetID: asset.ID, Algorithm: w.algorithm}
+
+	digest, err := w.read(ctx, asset)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		slog.Warn("Digest failed", "algorithm", w.algorithm, "checksum", asset.Checksum, "error", err)
+		record.Error = err.Error()
+	}
+	record.Digest = digest
+
+	if err := w.engine.DatabaseClient.Save(record).Error; err != nil {
+		slog.Error("Failed to record digest", "algorithm", w.algorithm, "checksum", asset.Checksum, "error", err)
+		return false
+	}
+	return true
+}
+
+// read streams the stored object through the new algorithm and sha256,
+// the digest is only kept when the object still matches the asset checksum
+func (w *DigestWorker) read(ctx context.Context, asset *Asset) (string, error) {
+	h, err := newDigestHash(w.algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	ns := w.engine.ForProject(ctx, asset.Project).defaultNamespace()
+	r, err := ns.bucket.Get(ctx, ns.CuratedKey(asset.Checksum))
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	checksum := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(h, checksum), r); err != nil {
+		return "", fmt.Errorf("read asset %q: %w", asset.Checksum, err)
+	}
+	if sum := hex.EncodeToString(checksum.Sum(nil)); sum != asset.Checksum {
+		return "", fmt.Errorf("%w: stored object hashes to %s", ErrChecksumMismatch, sum)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// listDigestCandidates returns ready assets without a digest record of algorithm
+func (engine *Engine) listDigestCandidates(algorithm string, cursor uint, limit int) ([]*Asset, error) {
+	var assets []*Asset
+	err := engine.DatabaseClient.
+		Where("state = ? AND id > ?", StatusReady, cursor).
+		Where("NOT EXISTS (SELECT 1 FROM asset_digests WHERE asset_digests.asset_id = assets.id AND asset_digests.algorithm = ?)", algorithm).
+		Order("id ASC").
+		Limit(limit).
+		Find(&assets).Error
+	if err != nil {
+		return nil, fmt.Errorf("list %s digest candidates: %w", algorithm, err)
+	}
+	return assets, nil
+}
@@ -0,0 +1,30 @@
+package registry
+
+// Candidate kinds
+const (
+	CandidateObject    = "object"
+	CandidateAsset     = "asset"
+	CandidatePartition = "partition"
+	CandidateRows      = "rows"
+)
+
+// Candidate is an object, record or partition a destructive operation would remove
+type Candidate struct {
+	Kind   string
+	Name   string
+	Bytes  int64
+	Reason string
+}
+
+// DryRunReport lists what a destructive operation would remove, nothing is changed to build it
+type DryRunReport struct {
+	Operation  string
+	Candidates []Candidate
+	Bytes      int64
+}
+
+// Add lists a candidate and counts its bytes
+func (r *DryRunReport) Add(c Candidate) {
+	r.Candidates = append(r.Candidates, c)
+	r.Bytes += c.Bytes
+}
@@ -6,39 +6,120 @@ import (
 	"log/slog"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 
 	slogGorm "github.com/orandin/slog-gorm"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
 const (
 	DEFAULT_DATABASE_NAME = "postgres"
 	DEFAULT_PRESIGN_TTL   = 15 * time.Minute
+	DEFAULT_PRESIGN_MIN   = 1 * time.Minute
+	DEFAULT_PRESIGN_MAX   = 12 * time.Hour
+	DEFAULT_PRESIGN_SKEW  = 30 * time.Second
+	DEFAULT_LIST_RATE     = 10
+	DEFAULT_LIST_PAGE     = 1000
 	DEFAULT_TIME_ZONE     = "UTC"
 	DEFAULT_DATABASE      = "localhost:5432"
 )
 
 type Engine struct {
 	// storage
-	storage Endpoint
-	bucket  string
-	prefix  string
+	backend     string
+	storage     Endpoint
+	bucket      string
+	prefix      string
+	storagePath string
+	storageURL  string
+	storageKey  Secret
+	// storageMaxSize caps filesystem storage writes of unknown size, see WithStorageMaxObjectSize
+	storageMaxSize int64
+
+	// presign
+	presignTTL time.Duration
+	presignMin time.Duration
+	presignMax time.Duration
+	// presignSkew pads signed urls for clocks that drift from ours, see WithPresignSkew
+	presignSkew time.Duration
+
+	// listing
+	listRate int
+	listPage int32
 
 	// database
+	databaseDriver   string
 	database         Endpoint
 	databaseUser     string
 	databasePassword Secret
 	databaseName     string
 	databaseSslMode  bool
 
+	// prepared statements cached per connection, see WithPreparedStatements
+	prepareStmt   bool
+	stmtCacheSize int
+	stmtCacheTTL  time.Duration
+	// postgres statements are sent unprepared, see WithSimpleProtocol
+	simpleProtocol bool
+
 	// global
 	timeZone string
 
-	// clients
+	// retention
+	accessLogRetention time.Duration
+	eventRetention     time.Duration
+	auditRetention     time.Duration
+
+	// garbage collection
+	gcInterval   time.Duration
+	gcPendingTTL time.Duration
+
+	// usage metering
+	meteringInterval time.Duration
+
+	// two-phase deletion of referenced assets
+	deletionGrace time.Duration
+
+	// how long responses to requests with an idempotency key are replayed
+	idempotencyTTL time.Duration
+
+	// asset enrichment jobs
+	enrichers []scheduledEnricher
+
+	// digest algorithms computed for ready assets, see WithDigests
+	digestAlgorithms []string
+	digestInterval   time.Duration
+
+	// mapped bucket clients
+	buckets *bucketCache
+
+	// namespace of the project the engine is scoped to, see WithContext
+	namespace *Namespace
+
+	// metrics
+	metricsRegistry prometheus.Registerer
+	metrics         *engineMetrics
+
+	// tracing, nil when disabled
+	tracing trace.TracerProvider
+
+	// list and search queries running longer log their plan, 0 disables it, see WithSlowQueryPlans
+	slowQueryThreshold time.Duration
+
+	// startup retries of the database and storage connections, see WithStartupWait
+	startupWait    time.Duration
+	startupBackoff time.Duration
+
+	// storage circuit breaker, see WithStorageBreaker
+	breakerThreshold int
+	breakerCooldown  time.Duration
+
+	// clients, S3Client and PresignClient are only set for the S3 backend
+	store          Storage
 	S3Client       *s3.Client
 	PresignClient  *s3.PresignClient
 	DatabaseClient *gorm.DB
@@ -47,9 +128,35 @@ type Engine struct {
 // New creates new core engine
 func New(opts ...Option) (*Engine, error) {
 	engine := &Engine{
-		database:     DEFAULT_DATABASE,
-		databaseName: DEFAULT_DATABASE_NAME,
-		timeZone:     DEFAULT_TIME_ZONE,
+		databaseDriver: DEFAULT_DATABASE_DRIVER,
+		database:       DEFAULT_DATABASE,
+		databaseName:   DEFAULT_DATABASE_NAME,
+		timeZone:       DEFAULT_TIME_ZONE,
+		presignTTL:     DEFAULT_PRESIGN_TTL,
+		presignMin:     DEFAULT_PRESIGN_MIN,
+		presignMax:     DEFAULT_PRESIGN_MAX,
+		presignSkew:    DEFAULT_PRESIGN_SKEW,
+		listRate:       DEFAULT_LIST_RATE,
+		listPage:       DEFAULT_LIST_PAGE,
+		buckets:        newBucketCache(),
+
+		accessLogRetention: DEFAULT_ACCESS_LOG_RETENTION,
+		eventRetention:     DEFAULT_EVENT_RETENTION,
+		auditRetention:     DEFAULT_AUDIT_RETENTION,
+
+		gcInterval:   DEFAULT_GC_INTERVAL,
+		gcPendingTTL: DEFAULT_GC_PENDING_TTL,
+
+		meteringInterval: DEFAULT_METERING_INTERVAL,
+
+		deletionGrace: DEFAULT_DELETION_GRACE,
+
+		idempotencyTTL: DEFAULT_IDEMPOTENCY_TTL,
+
+		startupBackoff: DEFAULT_STARTUP_BACKOFF,
+
+		breakerThreshold: DEFAULT_BREAKER_THRESHOLD,
+		breakerCooldown:  DEFAULT_BREAKER_COOLDOWN,
 	}
 
 	// Apply all options
@@ -59,11 +166,34 @@ func New(opts ...Option) (*Engine, error) {
 		}
 	}
 
-	// Create S3 Client
-	if err := engine.createS3Client(); err != nil {
+	if engine.prepareStmt && engine.simpleProtocol {
+		return nil, fmt.Errorf("prepared statements need the extended protocol, disable one of them")
+	}
+
+	if engine.presignMin > engine.presignMax {
+		return nil, fmt.Errorf("presign min ttl %s exceeds max ttl %s", engine.presignMin, engine.presignMax)
+	}
+	if engine.presignTTL < engine.presignMin || engine.presignTTL > engine.presignMax {
+		return nil, fmt.Errorf("presign ttl %s is outside its bounds %s to %s", engine.presignTTL, engine.presignMin, engine.presignMax)
+	}
+
+	if err := engine.createMetrics(); err != nil {
 		return nil, err
 	}
 
+	// Create storage backend
+	if err := engine.createStorage(); err != nil {
+		return nil, err
+	}
+
+	// Wait for the storage to come up, it is only checked when waiting
+	if engine.startupWait > 0 {
+		if err := engine.waitFor("storage", engine.store.Ping); err != nil {
+			return nil, err
+		}
+	}
+	engine.store = engine.withBreaker(engine.store)
+
 	// Create DB Client
 	if err := engine.createDatabaseClient(); err != nil {
 		return nil, err
@@ -72,7 +202,20 @@ func New(opts ...Option) (*Engine, error) {
 	return engine, nil
 }
 
+// PresignTTL clamps a requested presign expiry into the configured bounds.
+// A zero request resolves to the default ttl.
+func (engine *Engine) PresignTTL(requested time.Duration) time.Duration {
+	if requested <= 0 {
+		return engine.presignTTL
+	}
+	return min(max(requested, engine.presignMin), engine.presignMax)
+}
+
 func (engine *Engine) dsn() DSN {
+	if engine.isSQLite() {
+		return engine.sqliteDSN()
+	}
+
 	sslMode := "disable"
 	if engine.databaseSslMode {
 		sslMode = "require"
@@ -89,21 +232,14 @@ func (engine *Engine) dsn() DSN {
 
 func (engine *Engine) createS3Client() error {
 	// AWS Client
-	awsCfg, err := config.LoadDefaultConfig(context.TODO())
+	client, err := newS3Client(context.TODO(), engine.storage, "", "", engine.s3Metrics, engine.s3Tracing)
 	if err != nil {
-		return fmt.Errorf("aws config: %w", err)
-	}
-
-	s3Opts := []func(*s3.Options){}
-	if engine.storage != "" {
-		s3Opts = append(s3Opts, func(o *s3.Options) {
-			o.BaseEndpoint = aws.String(string(engine.storage))
-			o.UsePathStyle = true
-		})
+		return err
 	}
 
-	engine.S3Client = s3.NewFromConfig(awsCfg, s3Opts...)
+	engine.S3Client = client
 	engine.PresignClient = s3.NewPresignClient(engine.S3Client)
+	engine.store = engine.newS3Storage(client, engine.bucket)
 	return nil
 }
 
@@ -111,11 +247,25 @@ func (engine *Engine) createDatabaseClient() error {
 	dsn := engine.dsn()
 	slog.Debug("Database connection details", "dsn", dsn)
 
-	gormLogger := slogGorm.New() // use slog.Default() by default
-	db, err := gorm.Open(
-		postgres.Open(dsn.Value()),
-		&gorm.Config{Logger: gormLogger, CreateBatchSize: 1000})
+	dialector := postgres.New(postgres.Config{DSN: dsn.Value(), PreferSimpleProtocol: engine.simpleProtocol})
+	if engine.isSQLite() {
+		dialector = sqlite.Open(dsn.Value())
+	}
 
+	gormLogger := slogGorm.New() // use slog.Default() by default
+	var db *gorm.DB
+	err := engine.waitFor("database", func(ctx context.Context) (err error) {
+		db, err = gorm.Open(
+			dialector,
+			&gorm.Config{
+				Logger:             gormLogger,
+				CreateBatchSize:    1000,
+				PrepareStmt:        engine.prepareStmt,
+				PrepareStmtMaxSize: engine.stmtCacheSize,
+				PrepareStmtTTL:     engine.stmtCacheTTL,
+			})
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -126,5 +276,35 @@ func (engine *Engine) createDatabaseClient() error {
 		return fmt.Errorf("migration failed: %w", err)
 	}
 
+	// Scope requests to their project, before the audit log snapshots the rows they change
+	if err := registerProjectCallbacks(db); err != nil {
+		return err
+	}
+
+	// Give statement errors their registry kind, see registryerrors
+	if err := registerErrorCallbacks(db); err != nil {
+		return err
+	}
+
+	// Record mutating operations, migrations are not audited
+	if err := registerAuditCallbacks(db); err != nil {
+		return err
+	}
+
+	// Time statements, see engineMetrics
+	if err := engine.registerMetricsCallbacks(db); err != nil {
+		return err
+	}
+
+	// Trace statements of traced requests
+	if err := engine.registerTracingCallbacks(db); err != nil {
+		return err
+	}
+
+	// Log the plan of slow list and search queries
+	if err := engine.registerExplainCallbacks(db); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,26 @@
+package registry
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPresignTTLOutsideBounds checks a default presign ttl outside the bounds clients are held to is rejected, not clamped
+func TestPresignTTLOutsideBounds(t *testing.T) {
+	for _, ttl := range []time.Duration{30 * time.Second, 2 * time.Hour} {
+		_, err := New(
+			WithDatabaseDriver(DRIVER_SQLITE),
+			WithDatabaseName(t.TempDir()+"/aether.db"),
+			WithPresignBounds(time.Minute, time.Hour),
+			WithPresignTTL(ttl),
+		)
+		if err == nil {
+			t.Errorf("presign ttl %s outside bounds 1m to 1h was accepted", ttl)
+		}
+	}
+
+	engine := newTestEngine(t, WithPresignBounds(time.Minute, time.Hour), WithPresignTTL(time.Hour))
+	if got := engine.PresignTTL(0); got != time.Hour {
+		t.Errorf("default presign ttl is %s, want 1h", got)
+	}
+}
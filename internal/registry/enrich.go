@@ -0,0 +1,364 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	// EXTRA_RESERVED_PREFIX marks top level Extra keys written by the server only.
+	// Every enricher owns the namespace of its name, e.g. _media.
+	EXTRA_RESERVED_PREFIX = "_"
+
+	DEFAULT_ENRICH_INTERVAL = 5 * time.Minute
+)
+
+var (
+	ErrReservedExtraKey = errors.New("extra key is reserved")
+
+	enricherNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+)
+
+// Enricher derives metadata, tags and searchable text from a stored asset.
+// Every ready asset of an accepted mime type is enriched once by every registered enricher.
+type Enricher interface {
+	Name() string
+	// MimeTypes lists the accepted types, "family/*" accepts a whole family and "*/*" everything
+	MimeTypes() []string
+	Enrich(ctx context.Context, asset *Asset, r io.Reader) (*Enrichment, error)
+}
+
+// Enrichment is the patch an enricher applies to an asset, a nil enrichment changes nothing
+type Enrichment struct {
+	// Metadata is stored under the Extra namespace of the enricher
+	Metadata map[string]any `json:"metadata,omitempty"`
+	// Tags are attached to the asset, names are normalized and invalid ones dropped
+	Tags []string `json:"tags,omitempty"`
+	// Text is indexed for content search, replacing earlier text of the asset
+	Text string `json:"text,omitempty"`
+}
+
+// AssetEnrichment records that an enricher handled an asset, failures keep their error
+// so an asset isn't retried on every run.
+type AssetEnrichment struct {
+	AssetID   uint   `gorm:"primaryKey"`
+	Enricher  string `gorm:"primaryKey;size:50"`
+	Error     string
+	CreatedAt time.Time
+}
+
+// scheduledEnricher is an enricher registered with the maintenance jobs
+type scheduledEnricher struct {
+	enricher Enricher
+	interval time.Duration
+}
+
+// EnrichmentKey is the Extra namespace of an enricher
+func EnrichmentKey(name string) string {
+	return EXTRA_RESERVED_PREFIX + name
+}
+
+// IsReservedExtraKey reports whether a top level Extra key is written by the server only
+func IsReservedExtraKey(key string) bool {
+	return strings.HasPrefix(key, EXTRA_RESERVED_PREFIX)
+}
+
+// ValidateEnricherName checks an enricher name is usable as Extra namespace and job name
+func ValidateEnricherName(name string) error {
+	if !enricherNameRe.MatchString(name) {
+		return fmt.Errorf("%w: invalid enricher name %q", ErrValidation, name)
+	}
+	return nil
+}
+
+// MatchMimeType reports whether mimeType matches any of the patterns
+func MatchMimeType(patterns []string, mimeType string) bool {
+	for _, p := range patterns {
+		if p == "*/*" {
+			return true
+		}
+		if family, ok := strings.CutSuffix(p, "/*"); ok {
+			if strings.HasPrefix(mimeType, family+"/") {
+				return true
+			}
+		} else if p == mimeType {
+			return true
+		}
+	}
+	return false
+}
+
+// EnrichmentWorker runs one enricher over the ready assets it hasn't handled yet
+type EnrichmentWorker struct {
+	engine   *Engine
+	enricher Enricher
+	batch    int
+}
+
+// NewEnrichmentWorker creates a worker for the default project assets
+func (engine *Engine) NewEnrichmentWorker(enricher Enricher) *EnrichmentWorker {
+	return &EnrichmentWorker{engine: engine, enricher: enricher, batch: SearchDefaultLimit}
+}
+
+// Job wraps the worker as a scheduled job
+func (w *EnrichmentWorker) Job(interval time.Duration) Job {
+	return Job{
+		Name:     "enrich-" + w.enricher.Name(),
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := w.Run(ctx)
+			return err
+		},
+	}
+}
+
+// Run enriches every waiting asset and returns how many were handled
+func (w *EnrichmentWorker) Run(ctx context.Context) (int, error) {
+	lock, err := w.engine.TryLock(ctx, "aether:enrich:"+w.enricher.Name())
+	if err != nil || lock == nil {
+		return 0, err
+	}
+	defer lock.Release()
+
+	handled, cursor := 0, uint(0)
+	for {
+		assets, err := w.engine.listEnrichmentCandidates(w.enricher, cursor, w.batch)
+		if err != nil {
+			return handled, err
+		}
+
+		for _, asset := range assets {
+			if err := ctx.Err(); err != nil {
+				return handled, err
+			}
+			if w.enrich(ctx, asset) {
+				handled++
+			}
+		}
+
+		if len(assets) < w.batch {
+			break
+		}
+		cursor = assets[len(assets)-1].ID
+	}
+
+	if handled > 0 {
+		slog.Info("Enriched assets", "enricher", w.enricher.Name(), "assets", handled)
+	}
+	return handled, nil
+}
+
+// enrich runs the enricher on an asset and applies its result
+func (w *EnrichmentWorker) enrich(ctx context.Context, asset *Asset) bool {
+	name := w.enricher.Name()
+
+	enrichment, err := w.read(ctx, asset)
+	if err == nil {
+		err = w.engine.ApplyEnrichment(asset, name, enrichment)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		slog.Warn("Enrichment failed", "enricher", name, "checksum", asset.Checksum, "error", err)
+
+		record := &AssetEnrichment{AssetID: asset.ID, Enricher: name, Error: err.Error()}
+		if err := w.engine.DatabaseClient.Save(record).Error; err != nil {
+			slog.Error("Failed to record enrichment", "enricher", name, "checksum", asset.Checksum, "error", err)
+			return false
+		}
+	}
+	return true
+}
+
+func (w *EnrichmentWorker) read(ctx context.Context, asset *Asset) (*Enrichment, error) {
+	ns := w.engine.ForProject(ctx, asset.Project).defaultNamespace()
+	r, err := ns.bucket.Get(ctx, ns.CuratedKey(asset.Checksum))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return w.enricher.Enrich(ctx, asset, r)
+}
+
+// listEnrichmentCandidates returns ready assets of the enricher mime types it hasn't handled
+func (engine *Engine) listEnrichmentCandidates(enricher Enricher, cursor uint, limit int) ([]*Asset, error) {
+	types := engine.DatabaseClient.Where("1 = 0")
+	for _, p := range enricher.MimeTypes() {
+		if p == "*/*" {
+			types = engine.DatabaseClient.Where("1 = 1")
+			break
+		}
+		if family, ok := strings.CutSuffix(p, "/*"); ok {
+			types = types.Or("mime_type LIKE ?", family+"/%")
+		} else {
+			types = types.Or("mime_type = ?", p)
+		}
+	}
+
+	var assets []*Asset
+	err := engine.DatabaseClient.
+		Where("state = ? AND id > ?", StatusReady, cursor).
+		Where(types).
+		Where("NOT EXISTS (SELECT 1 FROM asset_enrichments WHERE asset_enrichments.asset_id = assets.id AND asset_enrichments.enricher = ?)", enricher.Name()).
+		Order("id ASC").
+		Limit(limit).
+		Find(&assets).Error
+	if err != nil {
+		return nil, fmt.Errorf("list %s enrichment candidates: %w", enricher.Name(), err)
+	}
+	return assets, nil
+}
+
+// ApplyEnrichment stores the result of an enricher on an asset and records it as handled
+func (engine *Engine) ApplyEnrichment(asset *Asset, enricher string, enrichment *Enrichment) error {
+	if enrichment == nil {
+		enrichment = &Enrichment{}
+	}
+
+	var names []string
+	for _, t := range enrichment.Tags {
+		if name := SuggestedTagName(t); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	// tags are shared, create them before locking the asset
+	tags, err := engine.EnsureTagRecords(names)
+	if err != nil {
+		return err
+	}
+	if err := engine.checkExclusiveTags(tags); err != nil {
+		return err
+	}
+
+	return engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		if enrichment.Metadata != nil {
+			if err := setAssetExtraKey(tx, asset, EnrichmentKey(enricher), enrichment.Metadata); err != nil {
+				return err
+			}
+		}
+
+		if len(tags) > 0 {
+			swaps, err := engine.WithTx(tx).detachGroupSiblings([]uint{asset.ID}, tags)
+			if err != nil {
+				return err
+			}
+			for _, swap := range swaps {
+				slog.Debug("Detached grouped tag", "checksum", asset.Checksum, "group", swap.Group, "detached", swap.Detached, "attached", swap.Attached)
+			}
+
+			if err := tx.Model(asset).Omit("Tags.*").Association("Tags").Append(tags); err != nil {
+				return fmt.Errorf("attach tags %q: %w", asset.Checksum, err)
+			}
+		}
+
+		if enrichment.Text != "" {
+			if err := tx.Save(newAssetContent(asset, enricher, enrichment.Text)).Error; err != nil {
+				return fmt.Errorf("store asset %q content: %w", asset.Checksum, err)
+			}
+		}
+
+		if err := tx.Save(&AssetEnrichment{AssetID: asset.ID, Enricher: enricher}).Error; err != nil {
+			return fmt.Errorf("record asset %q enrichment: %w", asset.Checksum, err)
+		}
+		return nil
+	})
+}
+
+// SetAssetExtraKey stores value under a top level Extra key, keeping the other keys
+func (engine *Engine) SetAssetExtraKey(asset *Asset, key string, value any) error {
+	return engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		return setAssetExtraKey(tx, asset, key, value)
+	})
+}
+
+func setAssetExtraKey(tx *gorm.DB, asset *Asset, key string, value any) error {
+	var current Asset
+	if err := tx.Select("id", "extra").First(&current, asset.ID).Error; err != nil {
+		return fmt.Errorf("get asset %q extra: %w", asset.Checksum, err)
+	}
+
+	extra := map[string]any{}
+	if len(current.Extra) > 0 {
+		if err := json.Unmarshal(current.Extra, &extra); err != nil {
+			return fmt.Errorf("decode asset %q extra: %w", asset.Checksum, err)
+		}
+	}
+	extra[key] = value
+
+	raw, err := json.Marshal(extra)
+	if err != nil {
+		return fmt.Errorf("encode asset %q extra: %w", asset.Checksum, err)
+	}
+
+	if err := tx.Model(asset).Update("extra", datatypes.JSON(raw)).Error; err != nil {
+		return fmt.Errorf("set asset %q extra: %w", asset.Checksum, err)
+	}
+
+	asset.Extra = datatypes.JSON(raw)
+	return nil
+}
+
+// CommandEnricher runs an external program per asset, so deployments can add enrichment without a custom build.
+// The object is piped to its stdin and the asset described in AETHER_ASSET_* variables,
+// it prints an Enrichment as JSON, e.g. {"metadata": {"faces": 2}, "tags": ["people"]}.
+type CommandEnricher struct {
+	EnricherName string
+	Path         string
+	// Types are the accepted mime types, all types when empty
+	Types []string
+}
+
+func (c CommandEnricher) Name() string {
+	return c.EnricherName
+}
+
+func (c CommandEnricher) MimeTypes() []string {
+	if len(c.Types) == 0 {
+		return []string{"*/*"}
+	}
+	return c.Types
+}
+
+func (c CommandEnricher) Enrich(ctx context.Context, asset *Asset, r io.Reader) (*Enrichment, error) {
+	cmd := exec.CommandContext(ctx, c.Path)
+	cmd.Stdin = r
+	cmd.Env = append(os.Environ(),
+		"AETHER_ASSET_CHECKSUM="+asset.Checksum,
+		"AETHER_ASSET_DISPLAY="+asset.Display,
+		"AETHER_ASSET_MIME_TYPE="+asset.MimeType,
+		fmt.Sprintf("AETHER_ASSET_SIZE=%d", asset.SizeBytes),
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", c.EnricherName, err, strings.TrimSpace(stderr.String()))
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+
+	var enrichment Enrichment
+	if err := json.Unmarshal(out, &enrichment); err != nil {
+		return nil, fmt.Errorf("%s output: %w", c.EnricherName, err)
+	}
+	return &enrichment, nil
+}
@@ -0,0 +1,32 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+// registerErrorCallbacks gives the errors of every statement their kind, see registryerrors.FromDatabase,
+// so callers tell missing records and duplicates apart without knowing the database driver
+func registerErrorCallbacks(db *gorm.DB) error {
+	translate := func(tx *gorm.DB) {
+		tx.Error = registryerrors.FromDatabase(tx.Error)
+	}
+
+	cb := db.Callback()
+	err := errors.Join(
+		cb.Create().After("*").Register("registry:create_errors", translate),
+		cb.Query().After("*").Register("registry:query_errors", translate),
+		cb.Update().After("*").Register("registry:update_errors", translate),
+		cb.Delete().After("*").Register("registry:delete_errors", translate),
+		cb.Row().After("*").Register("registry:row_errors", translate),
+		cb.Raw().After("*").Register("registry:raw_errors", translate),
+	)
+	if err != nil {
+		return fmt.Errorf("register error callbacks: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+const (
+	EventsDefaultLimit = 200
+	EventsMaxLimit     = 5000
+)
+
+// Severity ranks events
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Event is a notable registry occurrence kept for operators, e.g. a principal exceeding a soft limit.
+// The table is partitioned by month, see partition.go.
+type Event struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `gorm:"primaryKey;not null"`
+	Kind      string    `gorm:"not null;size:100"`
+	Severity  Severity  `gorm:"not null;size:10"`
+	Principal string    `gorm:"size:200"`
+	Project   string    `gorm:"size:63"`
+	Message   string
+	Data      datatypes.JSON `gorm:"type:jsonb"`
+}
+
+// NewEvent builds an event, data is stored as json
+func NewEvent(kind string, severity Severity, message string, data map[string]any) (*Event, error) {
+	event := &Event{Kind: kind, Severity: severity, Message: message}
+
+	if len(data) > 0 {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event data: %w", err)
+		}
+		event.Data = datatypes.JSON(raw)
+	}
+
+	return event, nil
+}
+
+// EventQuery filters events, newest first
+type EventQuery struct {
+	Cursor    uint
+	Limit     uint
+	Kind      string
+	Severity  Severity
+	Principal string
+	Since     time.Time
+}
+
+type EventOption func(*EventQuery) error
+
+func WithEventCursor(cursor uint) EventOption {
+	return func(q *EventQuery) error {
+		q.Cursor = cursor
+		return nil
+	}
+}
+
+func WithEventLimit(limit uint) EventOption {
+	return func(q *EventQuery) error {
+		if limit == 0 {
+			return fmt.Errorf("limit must be greater than 0")
+		}
+		if limit > EventsMaxLimit {
+			return fmt.Errorf("limit cannot exceed %d", EventsMaxLimit)
+		}
+		q.Limit = limit
+		return nil
+	}
+}
+
+func WithEventKind(kind string) EventOption {
+	return func(q *EventQuery) error {
+		q.Kind = kind
+		return nil
+	}
+}
+
+func WithEventSeverity(severity Severity) EventOption {
+	return func(q *EventQuery) error {
+		q.Severity = severity
+		return nil
+	}
+}
+
+func WithEventPrincipal(principal string) EventOption {
+	return func(q *EventQuery) error {
+		q.Principal = principal
+		return nil
+	}
+}
+
+func WithEventSince(since time.Time) EventOption {
+	return func(q *EventQuery) error {
+		q.Since = since
+		return nil
+	}
+}
+
+func (engine *Engine) CreateEventRecord(event *Event) error {
+	slog.Debug("Creating event record", "kind", event.Kind, "severity", event.Severity)
+
+	if err := engine.DatabaseClient.Create(event).Error; err != nil {
+		return fmt.Errorf("create event %q: %w", event.Kind, err)
+	}
+	return nil
+}
+
+func (engine *Engine) ListEventRecords(opts ...EventOption) ([]*Event, error) {
+	query := &EventQuery{Limit: EventsDefaultLimit}
+	for _, opt := range opts {
+		if err := opt(query); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+
+	db := engine.DatabaseClient.Model(&Event{})
+	if query.Cursor > 0 {
+		db = db.Where("id < ?", query.Cursor)
+	}
+	if query.Kind != "" {
+		db = db.Where("kind = ?", query.Kind)
+	}
+	if query.Severity != "" {
+		db = db.Where("severity = ?", query.Severity)
+	}
+	if query.Principal != "" {
+		db = db.Where("principal = ?", query.Principal)
+	}
+	if !query.Since.IsZero() {
+		db = db.Where("created_at >= ?", query.Since)
+	}
+
+	var events []*Event
+	if err := db.Order("id DESC").Limit(int(query.Limit)).Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	return events, nil
+}
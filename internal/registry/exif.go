@@ -0,0 +1,138 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+)
+
+const (
+	exifTagMake             = 0x010f
+	exifTagModel            = 0x0110
+	exifTagOrientation      = 0x0112
+	exifTagDateTime         = 0x0132
+	exifTagExifIFD          = 0x8769
+	exifTagDateTimeOriginal = 0x9003
+	exifTagLensModel        = 0xa434
+
+	exifTypeShort = 3
+	exifTypeLong  = 4
+	exifTypeASCII = 2
+)
+
+// exifTagNames are the EXIF tags copied into the media metadata
+var exifTagNames = map[uint16]string{
+	exifTagMake:             "make",
+	exifTagModel:            "model",
+	exifTagOrientation:      "orientation",
+	exifTagDateTime:         "date_time",
+	exifTagDateTimeOriginal: "date_time_original",
+	exifTagLensModel:        "lens_model",
+}
+
+// parseJPEGExif reads a few well known tags out of the EXIF block of a jpeg header.
+// Anything it doesn't understand is skipped, a missing or broken block returns nil.
+func parseJPEGExif(data []byte) map[string]any {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return nil
+	}
+
+	// walk the segments up to the APP1 Exif segment
+	for pos := 2; pos+4 <= len(data); {
+		if data[pos] != 0xff {
+			return nil
+		}
+		marker := data[pos+1]
+		size := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if size < 2 || pos+2+size > len(data) {
+			return nil
+		}
+		segment := data[pos+4 : pos+2+size]
+
+		switch {
+		case marker == 0xe1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")):
+			return parseTIFF(segment[6:])
+		case marker == 0xda || marker == 0xd9:
+			// image data starts, no EXIF block
+			return nil
+		}
+		pos += 2 + size
+	}
+	return nil
+}
+
+// parseTIFF reads IFD0 and the Exif sub IFD of a TIFF structure
+func parseTIFF(tiff []byte) map[string]any {
+	if len(tiff) < 8 {
+		return nil
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil
+	}
+
+	tags := map[string]any{}
+	sub := readIFD(tiff, order, order.Uint32(tiff[4:]), tags)
+	if sub > 0 {
+		readIFD(tiff, order, sub, tags)
+	}
+	return tags
+}
+
+// readIFD copies known tags of one IFD into tags, returning the Exif sub IFD offset when present
+func readIFD(tiff []byte, order binary.ByteOrder, offset uint32, tags map[string]any) uint32 {
+	if int(offset)+2 > len(tiff) {
+		return 0
+	}
+
+	var sub uint32
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := int(offset) + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+
+		tag := order.Uint16(tiff[entry:])
+		typ := order.Uint16(tiff[entry+2:])
+		n := order.Uint32(tiff[entry+4:])
+		value := tiff[entry+8 : entry+12]
+
+		if tag == exifTagExifIFD && typ == exifTypeLong {
+			sub = order.Uint32(value)
+			continue
+		}
+
+		name, ok := exifTagNames[tag]
+		if !ok {
+			continue
+		}
+
+		switch typ {
+		case exifTypeShort:
+			tags[name] = int(order.Uint16(value))
+		case exifTypeLong:
+			tags[name] = int(order.Uint32(value))
+		case exifTypeASCII:
+			// strings longer than 4 bytes live at an offset
+			raw := value
+			if n > 4 {
+				start := order.Uint32(value)
+				if int(start)+int(n) > len(tiff) {
+					continue
+				}
+				raw = tiff[start : start+n]
+			}
+			if s := strings.TrimSpace(strings.TrimRight(string(raw[:min(int(n), len(raw))]), "\x00")); s != "" {
+				tags[name] = s
+			}
+		}
+	}
+	return sub
+}
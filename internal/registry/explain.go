@@ -0,0 +1,120 @@
+package registry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// slowQueryKey names the list and search statements whose plan is logged when they run slow, see explainSlow
+const slowQueryKey = "explain:query"
+
+// explainSlow marks the statement of db as a list or search query called name,
+// its plan is logged when it runs over the threshold of WithSlowQueryPlans
+func (engine *Engine) explainSlow(db *gorm.DB, name string) *gorm.DB {
+	if engine.slowQueryThreshold <= 0 {
+		return db
+	}
+	return db.Set(slowQueryKey, name)
+}
+
+// registerExplainCallbacks logs the plan of marked statements running over the slow query threshold.
+// The statement is explained again without running it, the plan may differ from the one that ran slow.
+func (engine *Engine) registerExplainCallbacks(db *gorm.DB) error {
+	if engine.slowQueryThreshold <= 0 {
+		return nil
+	}
+	const startKey = "explain:start"
+
+	start := func(tx *gorm.DB) {
+		if _, ok := tx.Get(slowQueryKey); ok {
+			tx.InstanceSet(startKey, time.Now())
+		}
+	}
+	explain := func(tx *gorm.DB) {
+		name, marked := tx.Get(slowQueryKey)
+		started, timed := tx.InstanceGet(startKey)
+		if !marked || !timed || tx.Error != nil {
+			return
+		}
+
+		elapsed := time.Since(started.(time.Time))
+		if elapsed < engine.slowQueryThreshold {
+			return
+		}
+
+		ctx := tx.Statement.Context
+		statement := tx.Statement.SQL.String()
+		plan, err := engine.explain(ctx, statement, tx.Statement.Vars)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to explain slow query", "query", name, "duration", elapsed.String(), "error", err)
+			return
+		}
+		slog.WarnContext(ctx, "slow query plan",
+			"query", name,
+			"duration", elapsed.String(),
+			"threshold", engine.slowQueryThreshold.String(),
+			"statement", statement,
+			"plan", plan,
+		)
+	}
+
+	cb := db.Callback()
+	err := errors.Join(
+		cb.Query().Before("*").Register("explain:before_query", start),
+		cb.Query().After("*").Register("explain:after_query", explain),
+		cb.Row().Before("*").Register("explain:before_row", start),
+		cb.Row().After("*").Register("explain:after_row", explain),
+	)
+	if err != nil {
+		return fmt.Errorf("register explain callbacks: %w", err)
+	}
+	return nil
+}
+
+// explain returns the plan of a built statement, one line per plan node. It goes to the connection pool
+// directly, the statement is already built with the placeholders of the driver.
+func (engine *Engine) explain(ctx context.Context, statement string, vars []any) (string, error) {
+	prefix := "EXPLAIN (ANALYZE off) "
+	if engine.isSQLite() {
+		prefix = "EXPLAIN QUERY PLAN "
+	}
+
+	pool, err := engine.DatabaseClient.DB()
+	if err != nil {
+		return "", err
+	}
+	rows, err := pool.QueryContext(ctx, prefix+statement, vars...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	// postgres plans have a single column, sqlite describes the node in its last one
+	var lines []string
+	for rows.Next() {
+		var detail sql.NullString
+		dest := make([]any, len(columns))
+		for i := range dest {
+			dest[i] = new(any)
+		}
+		dest[len(dest)-1] = &detail
+
+		if err := rows.Scan(dest...); err != nil {
+			return "", err
+		}
+		lines = append(lines, detail.String)
+	}
+	return strings.Join(lines, "\n"), rows.Err()
+}
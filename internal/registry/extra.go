@@ -0,0 +1,210 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	EXTRA_EQUALS   = "eq"
+	EXTRA_CONTAINS = "contains"
+	EXTRA_EXISTS   = "exists"
+
+	ExtraMaxFilters = 20
+	ExtraMaxDepth   = 8
+)
+
+// extraKeyRe limits path segments to plain keys, sqlite paths are not quoted
+var extraKeyRe = regexp.MustCompile(`^[A-Za-z0-9_\-]{1,100}$`)
+
+// ExtraFilter matches assets on their Extra metadata
+type ExtraFilter struct {
+	Op    string
+	Path  []string
+	Value any
+}
+
+func (f ExtraFilter) String() string {
+	return fmt.Sprintf("%s %s %v", strings.Join(f.Path, "."), f.Op, f.Value)
+}
+
+// ParseExtraPath splits a dotted path like "camera.model" into validated keys
+func ParseExtraPath(path string) ([]string, error) {
+	keys := strings.Split(path, ".")
+	if len(keys) > ExtraMaxDepth {
+		return nil, fmt.Errorf("%w: extra path %q is deeper than %d", ErrValidation, path, ExtraMaxDepth)
+	}
+	for _, key := range keys {
+		if !extraKeyRe.MatchString(key) {
+			return nil, fmt.Errorf("%w: invalid extra path %q", ErrValidation, path)
+		}
+	}
+	return keys, nil
+}
+
+// isScalar reports whether v is a JSON string, number or boolean
+func isScalar(v any) bool {
+	switch v.(type) {
+	case string, bool, float64, float32, int, int32, int64, uint, uint32, uint64, json.Number:
+		return true
+	}
+	return false
+}
+
+// WithExtraFilter keeps assets whose Extra value at path equals value
+func WithExtraFilter(path string, value any) SearchAssetsOption {
+	return func(q *SearchAssetsQuery) error {
+		keys, err := ParseExtraPath(path)
+		if err != nil {
+			return err
+		}
+		if !isScalar(value) {
+			return fmt.Errorf("%w: extra %q must be compared to a string, number or boolean", ErrValidation, path)
+		}
+		return q.addExtraFilter(ExtraFilter{Op: EXTRA_EQUALS, Path: keys, Value: value})
+	}
+}
+
+// WithExtraContains keeps assets whose Extra contains the document, like the postgres @> operator
+func WithExtraContains(doc map[string]any) SearchAssetsOption {
+	return func(q *SearchAssetsQuery) error {
+		if len(doc) == 0 {
+			return fmt.Errorf("%w: extra containment document cannot be empty", ErrValidation)
+		}
+		return q.addExtraFilter(ExtraFilter{Op: EXTRA_CONTAINS, Value: doc})
+	}
+}
+
+// WithExtraExists keeps assets whose Extra has a value at path
+func WithExtraExists(path string) SearchAssetsOption {
+	return func(q *SearchAssetsQuery) error {
+		keys, err := ParseExtraPath(path)
+		if err != nil {
+			return err
+		}
+		return q.addExtraFilter(ExtraFilter{Op: EXTRA_EXISTS, Path: keys})
+	}
+}
+
+func (q *SearchAssetsQuery) addExtraFilter(f ExtraFilter) error {
+	if len(q.Extra) >= ExtraMaxFilters {
+		return fmt.Errorf("%w: at most %d extra filters are allowed", ErrValidation, ExtraMaxFilters)
+	}
+	q.Extra = append(q.Extra, f)
+	return nil
+}
+
+// applyExtraFilters adds the Extra conditions to an assets query
+func (engine *Engine) applyExtraFilters(tx *gorm.DB, filters []ExtraFilter) (*gorm.DB, error) {
+	for _, f := range filters {
+		switch f.Op {
+		case EXTRA_EQUALS:
+			tx = tx.Where(datatypes.JSONQuery("extra").Equals(f.Value, f.Path...))
+
+		case EXTRA_EXISTS:
+			tx = tx.Where(datatypes.JSONQuery("extra").HasKey(f.Path...))
+
+		case EXTRA_CONTAINS:
+			doc := f.Value.(map[string]any)
+			if !engine.isSQLite() {
+				raw, err := json.Marshal(doc)
+				if err != nil {
+					return nil, fmt.Errorf("%w: extra containment document: %w", ErrValidation, err)
+				}
+				tx = tx.Where("extra @> ?::jsonb", string(raw))
+				continue
+			}
+
+			// sqlite has no containment operator, match every leaf instead
+			leaves, err := extraLeaves(nil, doc)
+			if err != nil {
+				return nil, err
+			}
+			for _, leaf := range leaves {
+				tx = tx.Where(datatypes.JSONQuery("extra").Equals(leaf.Value, leaf.Path...))
+			}
+
+		default:
+			return nil, fmt.Errorf("%w: unknown extra operator %q", ErrValidation, f.Op)
+		}
+	}
+	return tx, nil
+}
+
+// extraLeaves flattens a containment document into equality filters
+func extraLeaves(path []string, doc map[string]any) ([]ExtraFilter, error) {
+	var leaves []ExtraFilter
+	for key, value := range doc {
+		if !extraKeyRe.MatchString(key) {
+			return nil, fmt.Errorf("%w: invalid extra key %q", ErrValidation, key)
+		}
+
+		keys := append(slices.Clone(path), key)
+		if len(keys) > ExtraMaxDepth {
+			return nil, fmt.Errorf("%w: extra document is deeper than %d", ErrValidation, ExtraMaxDepth)
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			nested, err := extraLeaves(keys, v)
+			if err != nil {
+				return nil, err
+			}
+			leaves = append(leaves, nested...)
+		default:
+			if !isScalar(v) {
+				return nil, fmt.Errorf("%w: extra key %q, only objects and scalars can be matched on sqlite", ErrValidation, strings.Join(keys, "."))
+			}
+			leaves = append(leaves, ExtraFilter{Op: EXTRA_EQUALS, Path: keys, Value: v})
+		}
+	}
+	return leaves, nil
+}
+
+// MergeExtra applies a JSON merge patch (RFC 7386) to an Extra document:
+// objects are merged key by key, null removes a key and any other value replaces it.
+// Reserved top level keys are written by the server only, a patch can't set or remove them.
+func MergeExtra(current datatypes.JSON, patch map[string]any) (datatypes.JSON, error) {
+	for key := range patch {
+		if IsReservedExtraKey(key) {
+			return nil, fmt.Errorf("%w: %w: %q", ErrValidation, ErrReservedExtraKey, key)
+		}
+	}
+
+	doc := map[string]any{}
+	if len(current) > 0 {
+		if err := json.Unmarshal(current, &doc); err != nil {
+			return nil, fmt.Errorf("decode extra: %w", err)
+		}
+	}
+
+	raw, err := json.Marshal(mergePatch(doc, patch))
+	if err != nil {
+		return nil, fmt.Errorf("encode extra: %w", err)
+	}
+	return datatypes.JSON(raw), nil
+}
+
+func mergePatch(target map[string]any, patch map[string]any) map[string]any {
+	for key, value := range patch {
+		switch v := value.(type) {
+		case nil:
+			delete(target, key)
+		case map[string]any:
+			nested, ok := target[key].(map[string]any)
+			if !ok {
+				nested = map[string]any{}
+			}
+			target[key] = mergePatch(nested, v)
+		default:
+			target[key] = v
+		}
+	}
+	return target
+}
@@ -0,0 +1,172 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+const FullTextMaxTerms = 10
+
+// AssetMatch is an asset found by full-text search, higher ranks match better
+type AssetMatch struct {
+	Asset *Asset
+	Rank  float64
+}
+
+// searchVectorDDL adds generated tsvector columns with GIN indexes for full-text search.
+// Punctuation common in file names is folded to spaces so "cat_photo.jpg" matches "photo".
+const searchVectorDDL = `
+	ALTER TABLE assets ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (to_tsvector('simple', translate(coalesce(display, ''), '._-/', '    '))) STORED;
+	CREATE INDEX IF NOT EXISTS idx_assets_search_vector ON assets USING GIN (search_vector);
+
+	ALTER TABLE tags ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (to_tsvector('simple', translate(name, '._-/:', '     '))) STORED;
+	CREATE INDEX IF NOT EXISTS idx_tags_search_vector ON tags USING GIN (search_vector);
+`
+
+// createSearchVectors creates the full-text search columns, sqlite searches by substring instead
+func (engine *Engine) createSearchVectors() error {
+	if engine.isSQLite() {
+		return nil
+	}
+	if err := engine.DatabaseClient.Exec(searchVectorDDL).Error; err != nil {
+		return fmt.Errorf("create search vectors: %w", err)
+	}
+	if err := engine.DatabaseClient.Exec(contentVectorDDL).Error; err != nil {
+		return fmt.Errorf("create content search vector: %w", err)
+	}
+	return nil
+}
+
+// searchTerms splits a free text query into lowercase words
+func searchTerms(query string) ([]string, error) {
+	terms := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: search query needs at least one word", ErrValidation)
+	}
+	if len(terms) > FullTextMaxTerms {
+		return nil, fmt.Errorf("%w: search query has more than %d words", ErrValidation, FullTextMaxTerms)
+	}
+	return terms, nil
+}
+
+// Search finds assets whose display name or tags match every word of query, best match first.
+// Words match as prefixes, "phot" finds "photo".
+func (engine *Engine) Search(ctx context.Context, query string, limit uint) ([]*AssetMatch, error) {
+	slog.Debug("Searching assets", "query", query, "limit", limit)
+
+	terms, err := searchTerms(query)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = SearchDefaultLimit
+	}
+	limit = min(limit, SearchMaxLimit)
+
+	var hits []searchHit
+	if engine.isSQLite() {
+		err = engine.searchSubstring(ctx, terms, limit).Scan(&hits).Error
+	} else {
+		err = engine.searchVector(ctx, terms, limit).Scan(&hits).Error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("search %q: %w", query, err)
+	}
+
+	return engine.loadMatches(ctx, hits)
+}
+
+// searchHit is a matching asset id with its rank
+type searchHit struct {
+	ID   uint
+	Rank float64
+}
+
+// loadMatches loads the assets of ranked hits with their tags, keeping the hit order
+func (engine *Engine) loadMatches(ctx context.Context, hits []searchHit) ([]*AssetMatch, error) {
+	if len(hits) == 0 {
+		return []*AssetMatch{}, nil
+	}
+
+	ids := make([]uint, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.ID)
+	}
+
+	var assets []*Asset
+	if err := engine.DatabaseClient.WithContext(ctx).Where("id IN ?", ids).Find(&assets).Error; err != nil {
+		return nil, fmt.Errorf("load search results: %w", err)
+	}
+	if err := engine.loadTags(assets); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]*Asset, len(assets))
+	for _, a := range assets {
+		byID[a.ID] = a
+	}
+
+	matches := make([]*AssetMatch, 0, len(hits))
+	for _, h := range hits {
+		if a, ok := byID[h.ID]; ok {
+			matches = append(matches, &AssetMatch{Asset: a, Rank: h.Rank})
+		}
+	}
+	return matches, nil
+}
+
+// searchVector ranks assets by their display name and best matching tag
+func (engine *Engine) searchVector(ctx context.Context, terms []string, limit uint) *gorm.DB {
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	project := ProjectFrom(ctx)
+
+	return engine.explainSlow(engine.DatabaseClient.WithContext(ctx), "search").Raw(`
+		SELECT a.id, ts_rank(a.search_vector, q) + COALESCE(t.rank, 0) AS rank
+		FROM assets a
+		CROSS JOIN to_tsquery('simple', ?) q
+		LEFT JOIN LATERAL (
+			SELECT MAX(ts_rank(tags.search_vector, q)) AS rank
+			FROM asset_tags JOIN tags ON tags.id = asset_tags.tag_id
+			WHERE asset_tags.asset_id = a.id AND tags.deleted_at IS NULL AND tags.search_vector @@ q
+		) t ON true
+		WHERE a.deleted_at IS NULL AND (?::text = '' OR a.project = ?) AND (a.search_vector @@ q OR t.rank IS NOT NULL)
+		ORDER BY rank DESC, a.id ASC
+		LIMIT ?`, strings.Join(terms, " & "), project, project, limit)
+}
+
+// searchSubstring matches every word as a substring of the display name or a tag name.
+// Display name matches rank above tag matches.
+func (engine *Engine) searchSubstring(ctx context.Context, terms []string, limit uint) *gorm.DB {
+	db := engine.explainSlow(engine.DatabaseClient.WithContext(ctx), "search").Table("assets AS a").Where("a.deleted_at IS NULL")
+	db = scopeAlias(db, ctx, "a")
+
+	rank := make([]string, 0, len(terms))
+	var args []any
+	for _, term := range terms {
+		pattern := "%" + term + "%"
+		tagged := `EXISTS (
+			SELECT 1 FROM asset_tags JOIN tags ON tags.id = asset_tags.tag_id
+			WHERE asset_tags.asset_id = a.id AND tags.deleted_at IS NULL AND tags.name LIKE ?)`
+
+		db = db.Where("(lower(a.display) LIKE ? OR "+tagged+")", pattern, pattern)
+		rank = append(rank, "(CASE WHEN lower(a.display) LIKE ? THEN 1.0 ELSE 0.5 END)")
+		args = append(args, pattern)
+	}
+
+	return db.
+		Select("a.id, "+strings.Join(rank, " + ")+" AS rank", args...).
+		Order("rank DESC, a.id ASC").
+		Limit(int(limit))
+}
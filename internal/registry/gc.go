@@ -0,0 +1,275 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+const (
+	DEFAULT_GC_INTERVAL    = 6 * time.Hour
+	DEFAULT_GC_PENDING_TTL = 7 * 24 * time.Hour
+	DEFAULT_GC_LOCK        = "aether:gc"
+
+	// GC_MIN_AGE keeps the collector away from objects that were just written
+	GC_MIN_AGE = time.Hour
+)
+
+var ErrGarbageCollectionRunning = registryerrors.New(registryerrors.ErrConflict, "garbage collection already running")
+
+// GarbageCollector removes ingress objects that no asset will ever promote:
+// objects without an asset record, objects of assets pending longer than the pending ttl,
+// and leftovers of assets that are already ready. Trashed assets keep their objects,
+// so do expired assets still referenced by a dataset version.
+type GarbageCollector struct {
+	engine     *Engine
+	pendingTTL time.Duration
+	batch      int
+}
+
+// GarbageCollectionReport summarizes a collector run
+type GarbageCollectionReport struct {
+	Scanned  int
+	Orphaned int
+	Expired  int
+	Promoted int
+	// Referenced counts expired objects kept because their asset is still referenced
+	Referenced int
+	Deleted    int
+	Bytes      int64
+	Started    time.Time
+	Duration   time.Duration
+}
+
+// NewGarbageCollector creates a collector for the ingress areas of every project,
+// or of the project of a scoped engine, see Engine.WithContext
+func (engine *Engine) NewGarbageCollector() *GarbageCollector {
+	return &GarbageCollector{
+		engine:     engine,
+		pendingTTL: engine.gcPendingTTL,
+		batch:      SearchMaxLimit,
+	}
+}
+
+// Run scans the ingress area once. Runs are serialized across replicas with an advisory lock,
+// ErrGarbageCollectionRunning is returned when another run holds it.
+func (gc *GarbageCollector) Run(ctx context.Context) (*GarbageCollectionReport, error) {
+	return gc.run(ctx, nil)
+}
+
+// Plan scans the ingress area like Run and lists the objects it would delete instead of deleting them
+func (gc *GarbageCollector) Plan(ctx context.Context) (*DryRunReport, error) {
+	plan := &DryRunReport{Operation: "gc"}
+	if _, err := gc.run(ctx, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// run collects the ingress area, listing the garbage in plan instead when it is set
+func (gc *GarbageCollector) run(ctx context.Context, plan *DryRunReport) (*GarbageCollectionReport, error) {
+	lock, err := gc.engine.TryLock(ctx, DEFAULT_GC_LOCK)
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil {
+		return nil, ErrGarbageCollectionRunning
+	}
+	defer lock.Release()
+
+	report := &GarbageCollectionReport{Started: time.Now()}
+
+	namespaces, err := gc.namespaces(ctx)
+	if err == nil {
+		for _, ns := range namespaces {
+			if err = gc.sweep(ctx, ns, report, plan); err != nil {
+				break
+			}
+		}
+	}
+
+	report.Duration = time.Since(report.Started)
+	if err != nil {
+		return report, fmt.Errorf("garbage collection: %w", err)
+	}
+
+	if plan != nil {
+		slog.Info("Planned ingress garbage collection", "candidates", len(plan.Candidates), "bytes", plan.Bytes)
+		return report, nil
+	}
+
+	slog.Info("Completed ingress garbage collection",
+		"scanned", report.Scanned,
+		"deleted", report.Deleted,
+		"orphaned", report.Orphaned,
+		"expired", report.Expired,
+		"promoted", report.Promoted,
+		"referenced", report.Referenced,
+		"bytes", report.Bytes,
+		"duration", report.Duration)
+
+	return report, nil
+}
+
+// namespaces lists the namespaces whose ingress area is collected: the one of a scoped engine,
+// otherwise those of every project, in the shared bucket or in their mapped bucket
+func (gc *GarbageCollector) namespaces(ctx context.Context) ([]*Namespace, error) {
+	if gc.engine.namespace != nil {
+		return []*Namespace{gc.engine.namespace}, nil
+	}
+
+	projects, err := gc.engine.WithContext(ctx).ListProjectRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]*Namespace, 0, len(projects))
+	for _, p := range projects {
+		ns, err := gc.engine.Namespace(p.Name)
+		if err != nil {
+			return nil, err
+		}
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces, nil
+}
+
+// sweep collects the ingress area of a namespace in batches
+func (gc *GarbageCollector) sweep(ctx context.Context, ns *Namespace, report *GarbageCollectionReport, plan *DryRunReport) error {
+	// the ingress objects of the namespace belong to the assets of its project only
+	scoped := *gc
+	scoped.engine = gc.engine.ForProject(ctx, ns.Project())
+
+	slog.Info("Starting ingress garbage collection", "project", ns.Project(), "bucket", ns.Bucket(), "root", ns.Root(), "pendingTTL", gc.pendingTTL)
+
+	var pending []ObjectInfo
+	flush := func() error {
+		err := scoped.collect(ctx, ns, pending, report, plan)
+		pending = pending[:0]
+		return err
+	}
+
+	err := gc.engine.listObjects(ctx, ns.bucket, path.Join(ns.root, "ingress"), func(obj ObjectInfo) error {
+		report.Scanned++
+		if report.Started.Sub(obj.LastModified) < GC_MIN_AGE {
+			return nil
+		}
+
+		pending = append(pending, obj)
+		if len(pending) >= gc.batch {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+// collect deletes the garbage among a batch of listed ingress objects, or adds it to plan when set
+func (gc *GarbageCollector) collect(ctx context.Context, ns *Namespace, objects []ObjectInfo, report *GarbageCollectionReport, plan *DryRunReport) error {
+	if len(objects) == 0 {
+		return nil
+	}
+
+	checksums := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		if ValidateSHA256(path.Base(obj.Key)) == nil {
+			checksums = append(checksums, path.Base(obj.Key))
+		}
+	}
+
+	assets := make(map[string]*Asset, len(checksums))
+	if len(checksums) > 0 {
+		records, err := gc.engine.ListAssetsRecords(WithChecksums(checksums...), WithLimit(SearchMaxLimit))
+		if err != nil {
+			return err
+		}
+		for _, r := range records {
+			assets[r.Checksum] = r
+		}
+	}
+
+	refs, err := gc.engine.AssetReferences(gc.expired(assets, report.Started)...)
+	if err != nil {
+		return err
+	}
+
+	var keys []string
+	var bytes int64
+	for _, obj := range objects {
+		asset, ok := assets[path.Base(obj.Key)]
+
+		var reason string
+		switch {
+		case !ok:
+			report.Orphaned++
+			reason = "orphaned"
+		case asset.State == StatusReady:
+			report.Promoted++
+			reason = "promoted"
+		case len(refs[asset.ID]) > 0:
+			report.Referenced++
+			continue
+		case gc.isExpired(asset, report.Started):
+			report.Expired++
+			reason = "expired"
+		default:
+			continue
+		}
+
+		if plan != nil {
+			plan.Add(Candidate{Kind: CandidateObject, Name: obj.Key, Bytes: obj.Size, Reason: reason})
+			continue
+		}
+		keys = append(keys, obj.Key)
+		bytes += obj.Size
+	}
+
+	if err := gc.engine.deleteObjects(ctx, ns.bucket, keys...); err != nil {
+		return err
+	}
+
+	report.Deleted += len(keys)
+	report.Bytes += bytes
+	return nil
+}
+
+// isExpired reports whether a pending asset outlived the pending ttl
+func (gc *GarbageCollector) isExpired(asset *Asset, now time.Time) bool {
+	return asset.State == StatusPending && gc.pendingTTL > 0 && now.Sub(asset.UpdatedAt) > gc.pendingTTL
+}
+
+// expired returns the assets whose ingress objects are due for collection
+func (gc *GarbageCollector) expired(assets map[string]*Asset, now time.Time) []*Asset {
+	var expired []*Asset
+	for _, a := range assets {
+		if gc.isExpired(a, now) {
+			expired = append(expired, a)
+		}
+	}
+	return expired
+}
+
+// Job wraps the collector as a maintenance job
+func (gc *GarbageCollector) Job(interval time.Duration) Job {
+	return Job{
+		Name:     "gc",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := gc.Run(ctx)
+			if errors.Is(err, ErrGarbageCollectionRunning) {
+				slog.Debug("Skipping garbage collection, another run holds the lock")
+				return nil
+			}
+			return err
+		},
+		Plan: gc.Plan,
+	}
+}
@@ -3,16 +3,17 @@ package registry
 import (
 	"crypto/sha256"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 
 	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
 )
 
 var (
-	ErrValidation = errors.New("validation error")
+	ErrValidation = registryerrors.ErrValidation
 )
 
 // ValidateString checks if a name matches the allowed pattern
@@ -21,6 +22,23 @@ func ValidateString(name string) bool {
 	return ValidNamePattern.MatchString(name)
 }
 
+// ParseTagName splits a key:value tag name, ok is false for flat names and malformed pairs
+func ParseTagName(name string) (key string, value string, ok bool) {
+	key, value, found := strings.Cut(name, TAG_KEY_SEPARATOR)
+	if !found || !ValidateString(key) || !ValidateString(value) {
+		return "", "", false
+	}
+	return key, value, true
+}
+
+// ValidateTagName checks a tag name is a valid flat name or a key:value pair of valid names
+func ValidateTagName(name string) bool {
+	if _, _, ok := ParseTagName(name); ok {
+		return true
+	}
+	return ValidateString(name)
+}
+
 // NormalizeString converts to lowercase and trims spaces
 func NormalizeString(name string) string {
 	return strings.ToLower(strings.TrimSpace(name))
@@ -48,12 +66,29 @@ func ValidateSHA256(hash string) error {
 func (a *Asset) BeforeCreate(tx *gorm.DB) error {
 	a.MimeType = NormalizeString(a.MimeType)
 	a.Checksum = NormalizeString(a.Checksum)
+	setRecordProject(tx, &a.Project)
 
 	// Validate checksum on creation
 	if err := ValidateSHA256(a.Checksum); err != nil {
 		return err
 	}
 
+	if err := ValidateLicense(a.License); err != nil {
+		return err
+	}
+
+	a.Owner = strings.TrimSpace(a.Owner)
+	if err := ValidateOwner(a.Owner); err != nil {
+		return err
+	}
+
+	if a.Classification == "" {
+		a.Classification = ClassInternal
+	}
+	if _, err := ParseClassification(string(a.Classification)); err != nil {
+		return err
+	}
+
 	// Set initial state
 	a.State = StatusPending
 	return nil
@@ -74,9 +109,30 @@ func (a *Asset) BeforeUpdate(tx *gorm.DB) error {
 // Hooks for tag
 func (t *Tag) BeforeSave(tx *gorm.DB) error {
 	t.Name = NormalizeString(t.Name)
-	if !ValidateString(t.Name) {
+	if !ValidateTagName(t.Name) {
 		return fmt.Errorf("%w: tag name contains invalid characters", ErrValidation)
 	}
+	t.Key, t.Value, _ = ParseTagName(t.Name)
+
+	t.Color = NormalizeString(t.Color)
+	return ValidateTagColor(t.Color)
+}
+
+func (t *Tag) BeforeCreate(tx *gorm.DB) error {
+	setRecordProject(tx, &t.Project)
+	return nil
+}
+
+// ValidateTagColor checks a tag color is a #rrggbb hex color, empty means no color
+func ValidateTagColor(color string) error {
+	if color == "" {
+		return nil
+	}
+
+	var tagColorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+	if !tagColorPattern.MatchString(NormalizeString(color)) {
+		return fmt.Errorf("%w: tag color %q is not a #rrggbb hex color", ErrValidation, color)
+	}
 	return nil
 }
 
@@ -86,6 +142,16 @@ func (d *Dataset) BeforeSave(tx *gorm.DB) error {
 	if !ValidateString(d.Name) {
 		return fmt.Errorf("%w: dataset name contains invalid characters", ErrValidation)
 	}
+
+	d.Owner = strings.TrimSpace(d.Owner)
+	if err := ValidateOwner(d.Owner); err != nil {
+		return err
+	}
+	return ValidateLicense(d.License)
+}
+
+func (d *Dataset) BeforeCreate(tx *gorm.DB) error {
+	setRecordProject(tx, &d.Project)
 	return nil
 }
 
@@ -0,0 +1,176 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+const (
+	DEFAULT_IDEMPOTENCY_TTL          = 24 * time.Hour
+	DEFAULT_IDEMPOTENCY_INTERVAL     = 10 * time.Minute
+	DEFAULT_IDEMPOTENCY_CLAIM_EXPIRY = 5 * time.Minute
+
+	// IDEMPOTENCY_KEY_MAX_LENGTH bounds the keys clients may send
+	IDEMPOTENCY_KEY_MAX_LENGTH = 255
+)
+
+var (
+	ErrIdempotencyKeyReused   = errors.New("idempotency key reused with a different request")
+	ErrIdempotencyKeyInFlight = registryerrors.New(registryerrors.ErrConflict, "request with this idempotency key is still in progress")
+)
+
+// IdempotencyRecord remembers the response to a request sent with an idempotency key, so a retry of the
+// same request gets the same response instead of running twice. Keys are scoped to the caller that sent them,
+// the fingerprint tells a retry from a different request reusing the key. A record without a status is
+// claimed by a request still running, claims older than DEFAULT_IDEMPOTENCY_CLAIM_EXPIRY are abandoned.
+type IdempotencyRecord struct {
+	Scope       string `gorm:"primaryKey;size:255"`
+	Key         string `gorm:"primaryKey;size:255"`
+	Fingerprint string `gorm:"size:64;not null"`
+	StatusCode  int
+	ContentType string `gorm:"size:255"`
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time `gorm:"index"`
+}
+
+// Completed reports whether the request finished and its response can be replayed
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// ValidateIdempotencyKey checks an idempotency key sent by a client
+func ValidateIdempotencyKey(key string) error {
+	if key == "" || len(key) > IDEMPOTENCY_KEY_MAX_LENGTH {
+		return fmt.Errorf("%w: idempotency key must have 1 to %d characters", ErrValidation, IDEMPOTENCY_KEY_MAX_LENGTH)
+	}
+	for _, r := range key {
+		if r < 0x21 || r > 0x7e {
+			return fmt.Errorf("%w: idempotency key must be printable ascii without spaces", ErrValidation)
+		}
+	}
+	return nil
+}
+
+// ClaimIdempotencyKey claims key for a request with fingerprint. It returns nil when the request may run,
+// it must then be completed or released. A completed record of the same request is returned for replay.
+func (engine *Engine) ClaimIdempotencyKey(scope string, key string, fingerprint string) (*IdempotencyRecord, error) {
+	if err := ValidateIdempotencyKey(key); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	claim := &IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(engine.idempotencyTTL),
+	}
+
+	for range 2 {
+		result := engine.DatabaseClient.Clauses(clause.OnConflict{DoNothing: true}).Create(claim)
+		if result.Error != nil {
+			return nil, fmt.Errorf("claim idempotency key %q: %w", key, result.Error)
+		}
+		if result.RowsAffected == 1 {
+			return nil, nil
+		}
+
+		var record IdempotencyRecord
+		err := engine.DatabaseClient.Where("scope = ? AND key = ?", scope, key).First(&record).Error
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			// purged in between, claim again
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get idempotency key %q: %w", key, err)
+		}
+
+		abandoned := !record.Completed() && now.Sub(record.CreatedAt) > DEFAULT_IDEMPOTENCY_CLAIM_EXPIRY
+		if now.After(record.ExpiresAt) || abandoned {
+			slog.Debug("Taking over idempotency key", "scope", scope, "key", key, "abandoned", abandoned)
+			taken := engine.DatabaseClient.Model(&IdempotencyRecord{}).
+				Where("scope = ? AND key = ? AND created_at = ?", scope, key, record.CreatedAt).
+				Updates(map[string]any{
+					"fingerprint":  fingerprint,
+					"status_code":  0,
+					"content_type": "",
+					"body":         nil,
+					"created_at":   claim.CreatedAt,
+					"expires_at":   claim.ExpiresAt,
+				})
+			if taken.Error != nil {
+				return nil, fmt.Errorf("claim idempotency key %q: %w", key, taken.Error)
+			}
+			if taken.RowsAffected == 1 {
+				return nil, nil
+			}
+			// another retry took it over first
+			continue
+		}
+
+		if record.Fingerprint != fingerprint {
+			return nil, fmt.Errorf("%w: %q", ErrIdempotencyKeyReused, key)
+		}
+		if !record.Completed() {
+			return nil, fmt.Errorf("%w: %q", ErrIdempotencyKeyInFlight, key)
+		}
+		return &record, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrIdempotencyKeyInFlight, key)
+}
+
+// CompleteIdempotencyKey stores the response of a claimed request for replay.
+// A positive ttl shorter than the idempotency ttl expires the record sooner, e.g. when the response holds presigned urls.
+func (engine *Engine) CompleteIdempotencyKey(scope string, key string, statusCode int, contentType string, body []byte, ttl time.Duration) error {
+	updates := map[string]any{
+		"status_code":  statusCode,
+		"content_type": contentType,
+		"body":         body,
+	}
+	if ttl > 0 && ttl < engine.idempotencyTTL {
+		updates["expires_at"] = time.Now().Add(ttl)
+	}
+
+	err := engine.DatabaseClient.Model(&IdempotencyRecord{}).
+		Where("scope = ? AND key = ?", scope, key).
+		Updates(updates).Error
+	if err != nil {
+		return fmt.Errorf("complete idempotency key %q: %w", key, err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey drops the claim of a request that failed, so a retry runs it again
+func (engine *Engine) ReleaseIdempotencyKey(scope string, key string) error {
+	err := engine.DatabaseClient.
+		Where("scope = ? AND key = ? AND status_code = 0", scope, key).
+		Delete(&IdempotencyRecord{}).Error
+	if err != nil {
+		return fmt.Errorf("release idempotency key %q: %w", key, err)
+	}
+	return nil
+}
+
+// PurgeIdempotencyKeys deletes the expired idempotency records
+func (engine *Engine) PurgeIdempotencyKeys(ctx context.Context) error {
+	result := engine.DatabaseClient.WithContext(ctx).
+		Where("expires_at < ?", time.Now()).
+		Delete(&IdempotencyRecord{})
+	if result.Error != nil {
+		return fmt.Errorf("purge idempotency keys: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		slog.Debug("Purged expired idempotency keys", "total", result.RowsAffected)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package registry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DEFAULT_LEADER_LOCK     = "aether:jobs"
+	DEFAULT_LEADER_INTERVAL = 15 * time.Second
+)
+
+// lockKey maps a lock name to a postgres advisory lock key
+func lockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// Lock is a postgres session advisory lock held on a dedicated connection.
+// The lock is released with Release, or by postgres when the connection dies.
+type Lock struct {
+	name string
+	key  int64
+	conn *sql.Conn
+
+	// local is set instead of conn on sqlite
+	local *sync.Mutex
+}
+
+// TryLock tries to take the named advisory lock without waiting.
+// It returns nil when another session holds the lock.
+func (engine *Engine) TryLock(ctx context.Context, name string) (*Lock, error) {
+	if engine.isSQLite() {
+		return tryLocalLock(ctx, name), nil
+	}
+
+	db, err := engine.DatabaseClient.DB()
+	if err != nil {
+		return nil, fmt.Errorf("lock %q: %w", name, err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lock %q: %w", name, err)
+	}
+
+	key := lockKey(name)
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("lock %q: %w", name, err)
+	}
+
+	if !acquired {
+		conn.Close()
+		return nil, nil
+	}
+
+	slog.Debug("Acquired advisory lock", "lock", name)
+	return &Lock{name: name, key: key, conn: conn}, nil
+}
+
+// Alive checks the lock connection is still up, a dead connection means the lock is gone
+func (l *Lock) Alive(ctx context.Context) bool {
+	if l.local != nil {
+		return true
+	}
+	return l.conn.PingContext(ctx) == nil
+}
+
+// Release unlocks and returns the connection to the pool
+func (l *Lock) Release() error {
+	if l.local != nil {
+		l.local.Unlock()
+		slog.Debug("Released local lock", "lock", l.name)
+		return nil
+	}
+
+	defer l.conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		return fmt.Errorf("unlock %q: %w", l.name, err)
+	}
+
+	slog.Debug("Released advisory lock", "lock", l.name)
+	return nil
+}
+
+// Elector elects one leader among serve replicas by holding an advisory lock.
+// Replicas that lose retry on every interval, so a new leader takes over when the old one dies.
+type Elector struct {
+	engine   *Engine
+	name     string
+	interval time.Duration
+
+	mu     sync.Mutex
+	lock   *Lock
+	leader atomic.Bool
+	wg     sync.WaitGroup
+}
+
+func (engine *Engine) NewElector(name string, interval time.Duration) *Elector {
+	return &Elector{engine: engine, name: name, interval: interval}
+}
+
+// IsLeader reports whether this replica currently holds the leadership lock
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Start makes a first election attempt right away and keeps campaigning until ctx is done
+func (e *Elector) Start(ctx context.Context) {
+	e.campaign(ctx)
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				e.resign()
+				return
+			case <-ticker.C:
+				e.campaign(ctx)
+			}
+		}
+	}()
+}
+
+// Wait blocks until the elector stopped campaigning and resigned
+func (e *Elector) Wait() {
+	e.wg.Wait()
+}
+
+// campaign takes the lock when free, or checks the held lock is still valid
+func (e *Elector) campaign(ctx context.Context) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.lock != nil {
+		if e.lock.Alive(ctx) {
+			return
+		}
+
+		slog.Warn("Lost leadership, lock connection is gone", "lock", e.name)
+		e.lock.conn.Close()
+		e.lock = nil
+		e.leader.Store(false)
+	}
+
+	lock, err := e.engine.TryLock(ctx, e.name)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			slog.Error("Leader election failed", "lock", e.name, "error", err)
+		}
+		return
+	}
+
+	if lock != nil {
+		e.lock = lock
+		e.leader.Store(true)
+		slog.Info("Elected as leader", "lock", e.name)
+	}
+}
+
+// resign gives up the leadership lock
+func (e *Elector) resign() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.lock == nil {
+		return
+	}
+
+	e.leader.Store(false)
+	if err := e.lock.Release(); err != nil {
+		slog.Warn("Failed to release leadership", "lock", e.name, "error", err)
+	}
+	e.lock = nil
+}
@@ -0,0 +1,119 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+// ROLE_EVERYONE in a restriction blocks every caller, including unauthenticated ones
+const ROLE_EVERYONE = "*"
+
+var ErrLicenseRestricted = errors.New("license restricts this use")
+
+// licenseRe matches SPDX license identifiers, including LicenseRef- custom ones
+var licenseRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.+-]{0,99}$`)
+
+// ValidateLicense checks an SPDX identifier, an empty license means unknown and is accepted
+func ValidateLicense(license string) error {
+	if license == "" || licenseRe.MatchString(license) {
+		return nil
+	}
+	return fmt.Errorf("%w: invalid SPDX license identifier %q", ErrValidation, license)
+}
+
+// LicenseRestriction blocks downloads of content under a license for the listed roles
+type LicenseRestriction struct {
+	gorm.Model
+	License      string                      `gorm:"uniqueIndex;not null;size:100"`
+	BlockedRoles datatypes.JSONSlice[string] `gorm:"type:jsonb"`
+	Note         string
+}
+
+// Blocks reports whether a caller holding roles is denied by the restriction.
+// A nil restriction blocks nobody.
+func (r *LicenseRestriction) Blocks(roles []string) bool {
+	if r == nil {
+		return false
+	}
+
+	for _, blocked := range r.BlockedRoles {
+		if blocked == ROLE_EVERYONE || slices.Contains(roles, blocked) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListLicenseRestrictionRecords returns restrictions, all of them when no license is given
+func (engine *Engine) ListLicenseRestrictionRecords(licenses ...string) ([]*LicenseRestriction, error) {
+	slog.Debug("Listing license restrictions", "licenses", licenses)
+
+	tx := engine.DatabaseClient.Order("license ASC")
+	if len(licenses) > 0 {
+		tx = tx.Where("license IN ?", licenses)
+	}
+
+	var restrictions []*LicenseRestriction
+	if err := tx.Find(&restrictions).Error; err != nil {
+		return nil, fmt.Errorf("list license restrictions: %w", err)
+	}
+	return restrictions, nil
+}
+
+// GetLicenseRestrictionRecord returns the restriction of a license, nil when there is none
+func (engine *Engine) GetLicenseRestrictionRecord(license string) (*LicenseRestriction, error) {
+	var restriction LicenseRestriction
+	err := engine.DatabaseClient.Where("license = ?", license).First(&restriction).Error
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get license restriction %q: %w", license, err)
+	}
+	return &restriction, nil
+}
+
+// SaveLicenseRestrictionRecord creates or replaces the restriction of a license
+func (engine *Engine) SaveLicenseRestrictionRecord(restriction *LicenseRestriction) error {
+	slog.Debug("Saving license restriction", "license", restriction.License)
+
+	if restriction.License == "" {
+		return fmt.Errorf("%w: restriction needs a license", ErrValidation)
+	}
+	if err := ValidateLicense(restriction.License); err != nil {
+		return err
+	}
+
+	existing, err := engine.GetLicenseRestrictionRecord(restriction.License)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		restriction.ID = existing.ID
+		restriction.CreatedAt = existing.CreatedAt
+	}
+
+	if err := engine.DatabaseClient.Save(restriction).Error; err != nil {
+		return fmt.Errorf("save license restriction %q: %w", restriction.License, err)
+	}
+	return nil
+}
+
+// DeleteLicenseRestrictionRecord removes the restriction of a license
+func (engine *Engine) DeleteLicenseRestrictionRecord(license string) error {
+	slog.Debug("Deleting license restriction", "license", license)
+
+	err := engine.DatabaseClient.Unscoped().Where("license = ?", license).Delete(&LicenseRestriction{}).Error
+	if err != nil {
+		return fmt.Errorf("delete license restriction %q: %w", license, err)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+const (
+	// LIFECYCLE_RULE_PREFIX marks lifecycle rules owned by aether, other rules are left untouched
+	LIFECYCLE_RULE_PREFIX = "aether:"
+
+	DEFAULT_INGRESS_EXPIRY_DAYS = 7
+	DEFAULT_ABORT_UPLOAD_DAYS   = 1
+)
+
+// LifecycleRule is an S3 lifecycle rule managed by the registry
+type LifecycleRule = types.LifecycleRule
+
+// LifecyclePolicy is the registry retention and tiering configuration expressed as S3 lifecycle rules
+type LifecyclePolicy struct {
+	// IngressExpiryDays removes uploads that were never promoted, 0 disables the rule
+	IngressExpiryDays int32
+	// AbortUploadDays cleans up incomplete multipart uploads, 0 disables the rule
+	AbortUploadDays int32
+	// CuratedTransitionDays moves curated objects to CuratedStorageClass, 0 disables the rule
+	CuratedTransitionDays int32
+	CuratedStorageClass   string
+}
+
+// Validate checks the policy values
+func (p LifecyclePolicy) Validate() error {
+	if p.IngressExpiryDays < 0 || p.AbortUploadDays < 0 || p.CuratedTransitionDays < 0 {
+		return fmt.Errorf("%w: lifecycle days must not be negative", ErrValidation)
+	}
+
+	if p.CuratedTransitionDays > 0 {
+		valid := false
+		for _, class := range types.TransitionStorageClass("").Values() {
+			if string(class) == p.CuratedStorageClass {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("%w: unknown storage class %q", ErrValidation, p.CuratedStorageClass)
+		}
+	}
+
+	return nil
+}
+
+// rules translates the policy into lifecycle rules for a namespace root
+func (p LifecyclePolicy) rules(root string) []types.LifecycleRule {
+	prefix := func(dir string) *string {
+		return aws.String(strings.TrimPrefix(path.Join(root, dir)+"/", "/"))
+	}
+	id := func(name string) *string {
+		return aws.String(LIFECYCLE_RULE_PREFIX + path.Join(root, name))
+	}
+
+	var rules []types.LifecycleRule
+
+	if p.IngressExpiryDays > 0 || p.AbortUploadDays > 0 {
+		rule := types.LifecycleRule{
+			ID:     id("ingress"),
+			Status: types.ExpirationStatusEnabled,
+			Filter: &types.LifecycleRuleFilter{Prefix: prefix("ingress")},
+		}
+		if p.IngressExpiryDays > 0 {
+			rule.Expiration = &types.LifecycleExpiration{Days: aws.Int32(p.IngressExpiryDays)}
+		}
+		if p.AbortUploadDays > 0 {
+			rule.AbortIncompleteMultipartUpload = &types.AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: aws.Int32(p.AbortUploadDays),
+			}
+		}
+		rules = append(rules, rule)
+	}
+
+	if p.CuratedTransitionDays > 0 {
+		rules = append(rules, types.LifecycleRule{
+			ID:     id("curated"),
+			Status: types.ExpirationStatusEnabled,
+			Filter: &types.LifecycleRuleFilter{Prefix: prefix("curated")},
+			Transitions: []types.Transition{{
+				Days:         aws.Int32(p.CuratedTransitionDays),
+				StorageClass: types.TransitionStorageClass(p.CuratedStorageClass),
+			}},
+		})
+	}
+
+	return rules
+}
+
+// LifecyclePlan is the lifecycle configuration computed for one bucket
+type LifecyclePlan struct {
+	Bucket  string
+	Roots   []string
+	Rules   []LifecycleRule
+	Foreign int
+	Applied bool
+}
+
+// SyncLifecycle writes the policy as lifecycle rules on every bucket the registry uses:
+// the shared bucket (default namespace and every project found under it) and each mapped bucket.
+// Rules not owned by aether are preserved. With dryRun nothing is written.
+func (engine *Engine) SyncLifecycle(ctx context.Context, policy LifecyclePolicy, dryRun bool) ([]*LifecyclePlan, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	targets, err := engine.lifecycleTargets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]*LifecyclePlan, 0, len(targets))
+	for _, target := range targets {
+		plan := &LifecyclePlan{Bucket: target.bucket.Name(), Roots: target.roots}
+		for _, root := range target.roots {
+			plan.Rules = append(plan.Rules, policy.rules(root)...)
+		}
+
+		foreign, err := foreignLifecycleRules(ctx, target.bucket)
+		if err != nil {
+			return plans, err
+		}
+		plan.Foreign = len(foreign)
+		plans = append(plans, plan)
+
+		if dryRun {
+			continue
+		}
+
+		if err := putLifecycleRules(ctx, target.bucket, append(foreign, plan.Rules...)); err != nil {
+			return plans, err
+		}
+		plan.Applied = true
+
+		slog.Info("Synchronized bucket lifecycle", "bucket", plan.Bucket, "rules", len(plan.Rules), "foreign", plan.Foreign)
+	}
+
+	return plans, nil
+}
+
+type lifecycleTarget struct {
+	bucket *S3Storage
+	roots  []string
+}
+
+// lifecycleTargets lists the buckets and namespace roots covered by lifecycle rules,
+// lifecycle rules are an S3 feature so the shared storage must be S3
+func (engine *Engine) lifecycleTargets(ctx context.Context) ([]*lifecycleTarget, error) {
+	b, err := s3Storage(engine.defaultBucket())
+	if err != nil {
+		return nil, err
+	}
+	shared := &lifecycleTarget{bucket: b, roots: []string{engine.prefix}}
+
+	projects, err := engine.sharedProjectRoots(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	shared.roots = append(shared.roots, projects...)
+
+	var mappings []*StorageMapping
+	if err := engine.DatabaseClient.Order("project").Find(&mappings).Error; err != nil {
+		return nil, fmt.Errorf("list storage mappings: %w", err)
+	}
+
+	targets := []*lifecycleTarget{shared}
+	byBucket := map[string]*lifecycleTarget{}
+	for _, mapping := range mappings {
+		mapped, err := engine.mappedBucket(mapping)
+		if err != nil {
+			return nil, err
+		}
+		b, err := s3Storage(mapped)
+		if err != nil {
+			return nil, err
+		}
+
+		if target, ok := byBucket[b.Name()]; ok {
+			target.roots = append(target.roots, mapping.Prefix)
+			continue
+		}
+
+		target := &lifecycleTarget{bucket: b, roots: []string{mapping.Prefix}}
+		byBucket[b.Name()] = target
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// sharedProjectRoots finds project namespaces stored in the shared bucket
+func (engine *Engine) sharedProjectRoots(ctx context.Context, b *S3Storage) ([]string, error) {
+	root := path.Join(engine.prefix, PROJECTS_KEY_ROOT) + "/"
+	root = strings.TrimPrefix(root, "/")
+
+	paginator := s3.NewListObjectsV2Paginator(b.client, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(b.bucket),
+		Prefix:    aws.String(root),
+		Delimiter: aws.String("/"),
+	})
+
+	var roots []string
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list project prefixes: %w", err)
+		}
+		for _, common := range page.CommonPrefixes {
+			roots = append(roots, strings.TrimSuffix(aws.ToString(common.Prefix), "/"))
+		}
+	}
+
+	sort.Strings(roots)
+	return roots, nil
+}
+
+// foreignLifecycleRules returns the bucket lifecycle rules not owned by aether
+func foreignLifecycleRules(ctx context.Context, b *S3Storage) ([]types.LifecycleRule, error) {
+	out, err := b.client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(b.bucket),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchLifecycleConfiguration" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get bucket %q lifecycle: %w", b.bucket, err)
+	}
+
+	var foreign []types.LifecycleRule
+	for _, rule := range out.Rules {
+		if !strings.HasPrefix(aws.ToString(rule.ID), LIFECYCLE_RULE_PREFIX) {
+			foreign = append(foreign, rule)
+		}
+	}
+	return foreign, nil
+}
+
+// putLifecycleRules replaces the bucket lifecycle configuration, removing it when no rule is left
+func putLifecycleRules(ctx context.Context, b *S3Storage, rules []types.LifecycleRule) error {
+	if len(rules) == 0 {
+		_, err := b.client.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{Bucket: aws.String(b.bucket)})
+		if err != nil {
+			return fmt.Errorf("delete bucket %q lifecycle: %w", b.bucket, err)
+		}
+		return nil
+	}
+
+	_, err := b.client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(b.bucket),
+		LifecycleConfiguration: &types.BucketLifecycleConfiguration{Rules: rules},
+	})
+	if err != nil {
+		return fmt.Errorf("put bucket %q lifecycle: %w", b.bucket, err)
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+var (
+	ErrVersionLocked         = registryerrors.New(registryerrors.ErrReadOnly, "dataset version is locked")
+	ErrVersionAssetsNotReady = registryerrors.New(registryerrors.ErrConflict, "dataset version has assets that are not ready")
+)
+
+// DatasetVersionAsset is the membership of an asset in a dataset version.
+// Its hooks refuse membership changes of locked versions, whatever code path issues them.
+type DatasetVersionAsset struct {
+	DatasetVersionID uint `gorm:"primaryKey"`
+	AssetID          uint `gorm:"primaryKey"`
+}
+
+func (m *DatasetVersionAsset) BeforeCreate(tx *gorm.DB) error {
+	return checkVersionUnlocked(tx, m.DatasetVersionID)
+}
+
+func (m *DatasetVersionAsset) BeforeDelete(tx *gorm.DB) error {
+	return checkVersionUnlocked(tx, m.DatasetVersionID)
+}
+
+// checkVersionUnlocked reads the stored status, the in memory version may be stale
+func checkVersionUnlocked(tx *gorm.DB, versionID uint) error {
+	if versionID == 0 {
+		return fmt.Errorf("%w: membership change without a dataset version", ErrValidation)
+	}
+
+	var status VersionStatus
+	err := tx.Session(&gorm.Session{NewDB: true}).
+		Model(&DatasetVersion{}).
+		Select("status").
+		Where("id = ?", versionID).
+		Scan(&status).Error
+	if err != nil {
+		return fmt.Errorf("check dataset version %d lock: %w", versionID, err)
+	}
+
+	if status == VersionPublished {
+		return fmt.Errorf("%w: published versions can't change", ErrVersionLocked)
+	}
+	return nil
+}
+
+// Locked reports whether the version contents are frozen
+func (dv *DatasetVersion) Locked() bool {
+	return dv.Status == VersionPublished
+}
+
+// ManifestEntry is one asset of a frozen dataset version
+type ManifestEntry struct {
+	Checksum  string `json:"checksum"`
+	SizeBytes int64  `json:"size_bytes"`
+	Display   string `json:"display,omitempty"`
+	MimeType  string `json:"mime_type,omitempty"`
+}
+
+// VersionManifest lists the exact contents of a published dataset version.
+// Digest is the SHA256 of the sorted "checksum size" lines, equal digests mean equal contents.
+type VersionManifest struct {
+	Dataset    string          `json:"dataset"`
+	Version    int             `json:"version"`
+	Count      int             `json:"count"`
+	TotalBytes int64           `json:"total_bytes"`
+	Digest     string          `json:"digest"`
+	Assets     []ManifestEntry `json:"assets"`
+
+	// Partial manifests hold a filtered slice of the version, Count, TotalBytes and Digest describe the slice
+	Partial bool `json:"partial,omitempty"`
+}
+
+// ManifestFilter narrows a version manifest to the assets a client needs, empty fields match everything.
+// MimeTypes accepts wildcards like image/*, Prefix matches the start of display names.
+type ManifestFilter struct {
+	Tags      []string
+	MimeTypes []string
+	Prefix    string
+}
+
+func (f *ManifestFilter) IsEmpty() bool {
+	return f == nil || (len(f.Tags) == 0 && len(f.MimeTypes) == 0 && f.Prefix == "")
+}
+
+func (f *ManifestFilter) matchMimeType(mimeType string) bool {
+	if len(f.MimeTypes) == 0 {
+		return true
+	}
+
+	mimeType = NormalizeString(mimeType)
+	for _, pattern := range f.MimeTypes {
+		pattern = NormalizeString(pattern)
+		if family, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(mimeType, family+"/") {
+				return true
+			}
+			continue
+		}
+		if mimeType == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildVersionManifest computes the manifest of a version, every member asset must be ready
+func (engine *Engine) BuildVersionManifest(dsv *DatasetVersion) (*VersionManifest, error) {
+	slog.Debug("Building dataset version manifest", "dataset", dsv.Dataset.Name, "version", dsv.Number)
+
+	manifest := &VersionManifest{
+		Dataset: dsv.Dataset.Name,
+		Version: dsv.Number,
+		Assets:  []ManifestEntry{},
+	}
+
+	var notReady []string
+	cursor := uint(0)
+	for {
+		assets, err := engine.ListVersionAssets(dsv, cursor, SearchMaxLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range assets {
+			if a.State != StatusReady {
+				notReady = append(notReady, a.Checksum)
+				continue
+			}
+			manifest.Assets = append(manifest.Assets, ManifestEntry{
+				Checksum:  a.Checksum,
+				SizeBytes: a.SizeBytes,
+				Display:   a.Display,
+				MimeType:  a.MimeType,
+			})
+			manifest.TotalBytes += a.SizeBytes
+		}
+
+		if len(assets) < SearchMaxLimit {
+			break
+		}
+		cursor = assets[len(assets)-1].ID
+	}
+
+	if len(notReady) > 0 {
+		return nil, fmt.Errorf("%w: %d asset(s), first %s", ErrVersionAssetsNotReady, len(notReady), notReady[0])
+	}
+
+	sort.Slice(manifest.Assets, func(i, j int) bool { return manifest.Assets[i].Checksum < manifest.Assets[j].Checksum })
+
+	manifest.Count = len(manifest.Assets)
+	manifest.Digest = manifest.ComputeDigest()
+
+	return manifest, nil
+}
+
+// ComputeDigest hashes the manifest entries, they must be sorted by checksum
+func (m *VersionManifest) ComputeDigest() string {
+	h := sha256.New()
+	for _, entry := range m.Assets {
+		fmt.Fprintf(h, "%s %d\n", entry.Checksum, entry.SizeBytes)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// FilterVersionManifest returns the slice of a version manifest matching filter.
+// Tags are matched against the current asset tags, an asset must carry all of them.
+func (engine *Engine) FilterVersionManifest(dsv *DatasetVersion, manifest *VersionManifest, filter *ManifestFilter) (*VersionManifest, error) {
+	slog.Debug("Filtering dataset version manifest", "dataset", manifest.Dataset, "version", manifest.Version)
+
+	var tagged map[string]bool
+	if len(filter.Tags) > 0 {
+		tags := make([]string, len(filter.Tags))
+		for i, tag := range filter.Tags {
+			tags[i] = NormalizeString(tag)
+		}
+		slices.Sort(tags)
+		tags = slices.Compact(tags)
+
+		withTags := engine.DatabaseClient.
+			Table("asset_tags").
+			Select("asset_tags.asset_id").
+			Joins("JOIN tags ON tags.id = asset_tags.tag_id").
+			Where("tags.name IN ?", tags).
+			Group("asset_tags.asset_id").
+			Having("COUNT(*) = ?", len(tags))
+
+		var checksums []string
+		err := engine.DatabaseClient.
+			Table("assets").
+			Joins("JOIN dataset_version_assets ON dataset_version_assets.asset_id = assets.id").
+			Where("dataset_version_assets.dataset_version_id = ? AND assets.id IN (?)", dsv.ID, withTags).
+			Pluck("assets.checksum", &checksums).Error
+		if err != nil {
+			return nil, fmt.Errorf("filter dataset version %d manifest: %w", dsv.Number, err)
+		}
+
+		tagged = make(map[string]bool, len(checksums))
+		for _, checksum := range checksums {
+			tagged[checksum] = true
+		}
+	}
+
+	filtered := &VersionManifest{
+		Dataset: manifest.Dataset,
+		Version: manifest.Version,
+		Assets:  []ManifestEntry{},
+		Partial: true,
+	}
+	for _, entry := range manifest.Assets {
+		if tagged != nil && !tagged[entry.Checksum] {
+			continue
+		}
+		if !filter.matchMimeType(entry.MimeType) || !strings.HasPrefix(entry.Display, filter.Prefix) {
+			continue
+		}
+		filtered.Assets = append(filtered.Assets, entry)
+		filtered.TotalBytes += entry.SizeBytes
+	}
+
+	filtered.Count = len(filtered.Assets)
+	filtered.Digest = filtered.ComputeDigest()
+	return filtered, nil
+}
+
+// ManifestColumns returns the version column updates storing a manifest
+func (m *VersionManifest) ManifestColumns() (map[string]any, error) {
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("encode dataset version manifest: %w", err)
+	}
+
+	return map[string]any{
+		"manifest":        raw,
+		"manifest_digest": m.Digest,
+		"asset_count":     m.Count,
+		"total_bytes":     m.TotalBytes,
+	}, nil
+}
+
+// GetManifest decodes the stored manifest of a published version, nil when it has none
+func (dv *DatasetVersion) GetManifest() (*VersionManifest, error) {
+	if len(dv.Manifest) == 0 {
+		return nil, nil
+	}
+
+	var manifest VersionManifest
+	if err := json.Unmarshal(dv.Manifest, &manifest); err != nil {
+		return nil, fmt.Errorf("decode dataset version %d manifest: %w", dv.Number, err)
+	}
+	return &manifest, nil
+}
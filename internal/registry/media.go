@@ -0,0 +1,176 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// EXTRA_MEDIA_KEY is the reserved Extra namespace holding extracted media metadata,
+	// searchable like any other key, e.g. _media.width
+	EXTRA_MEDIA_KEY = EXTRA_RESERVED_PREFIX + "media"
+
+	DEFAULT_MEDIA_INTERVAL = DEFAULT_ENRICH_INTERVAL
+
+	// MEDIA_HEAD_BYTES is how much of an image is read to find its header and EXIF block
+	MEDIA_HEAD_BYTES = 512 << 10
+)
+
+// MediaExtractor reads technical metadata out of a stored object, e.g. image dimensions or a video codec
+type MediaExtractor interface {
+	Name() string
+	MimeTypes() []string
+	Extract(ctx context.Context, asset *Asset, r io.Reader) (map[string]any, error)
+}
+
+// ImageExtractor reads dimensions, format and EXIF tags of gif, jpeg and png images
+type ImageExtractor struct{}
+
+func (ImageExtractor) Name() string {
+	return "image"
+}
+
+func (ImageExtractor) MimeTypes() []string {
+	return []string{"image/gif", "image/jpeg", "image/png"}
+}
+
+func (ImageExtractor) Extract(ctx context.Context, asset *Asset, r io.Reader) (map[string]any, error) {
+	head, err := io.ReadAll(io.LimitReader(r, MEDIA_HEAD_BYTES))
+	if err != nil {
+		return nil, fmt.Errorf("read image header: %w", err)
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(head))
+	if err != nil {
+		return nil, fmt.Errorf("decode image header: %w", err)
+	}
+
+	meta := map[string]any{
+		"format": format,
+		"width":  config.Width,
+		"height": config.Height,
+	}
+	if exif := parseJPEGExif(head); len(exif) > 0 {
+		meta["exif"] = exif
+	}
+	return meta, nil
+}
+
+// FFprobeExtractor reads duration, container and codecs of audio and video with the ffprobe binary.
+// The object is piped in, so containers that need seeking may only report partial metadata.
+type FFprobeExtractor struct {
+	Path string
+}
+
+func (FFprobeExtractor) Name() string {
+	return "ffprobe"
+}
+
+func (FFprobeExtractor) MimeTypes() []string {
+	return []string{"video/*", "audio/*"}
+}
+
+func (p FFprobeExtractor) Extract(ctx context.Context, asset *Asset, r io.Reader) (map[string]any, error) {
+	cmd := exec.CommandContext(ctx, p.Path, "-v", "error", "-print_format", "json", "-show_format", "-show_streams", "-")
+	cmd.Stdin = r
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("ffprobe: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var probe struct {
+		Format struct {
+			FormatName string `json:"format_name"`
+			Duration   string `json:"duration"`
+			BitRate    string `json:"bit_rate"`
+		} `json:"format"`
+		Streams []struct {
+			CodecType  string `json:"codec_type"`
+			CodecName  string `json:"codec_name"`
+			Width      int    `json:"width"`
+			Height     int    `json:"height"`
+			SampleRate string `json:"sample_rate"`
+			Channels   int    `json:"channels"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal(out, &probe); err != nil {
+		return nil, fmt.Errorf("ffprobe output: %w", err)
+	}
+
+	meta := map[string]any{"format": probe.Format.FormatName}
+	if d, err := strconv.ParseFloat(probe.Format.Duration, 64); err == nil {
+		meta["duration"] = d
+	}
+	if b, err := strconv.ParseInt(probe.Format.BitRate, 10, 64); err == nil {
+		meta["bit_rate"] = b
+	}
+
+	// first stream of each kind describes the asset
+	for _, s := range probe.Streams {
+		switch s.CodecType {
+		case "video":
+			if _, ok := meta["video_codec"]; !ok {
+				meta["video_codec"] = s.CodecName
+				meta["width"] = s.Width
+				meta["height"] = s.Height
+			}
+		case "audio":
+			if _, ok := meta["audio_codec"]; !ok {
+				meta["audio_codec"] = s.CodecName
+				meta["channels"] = s.Channels
+				if rate, err := strconv.Atoi(s.SampleRate); err == nil {
+					meta["sample_rate"] = rate
+				}
+			}
+		}
+	}
+	return meta, nil
+}
+
+// MediaEnricher fills the reserved media namespace with the metadata of its first accepting extractor
+type MediaEnricher struct {
+	Extractors []MediaExtractor
+}
+
+func (MediaEnricher) Name() string {
+	return "media"
+}
+
+func (m MediaEnricher) MimeTypes() []string {
+	var types []string
+	for _, e := range m.Extractors {
+		types = append(types, e.MimeTypes()...)
+	}
+	return types
+}
+
+func (m MediaEnricher) Enrich(ctx context.Context, asset *Asset, r io.Reader) (*Enrichment, error) {
+	for _, e := range m.Extractors {
+		if !MatchMimeType(e.MimeTypes(), asset.MimeType) {
+			continue
+		}
+
+		meta, err := e.Extract(ctx, asset, r)
+		if err != nil {
+			return nil, err
+		}
+		meta["extractor"] = e.Name()
+		return &Enrichment{Metadata: meta}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,81 @@
+package registry
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// checkVersionDraft fails unless the version contents may still change
+func checkVersionDraft(dsv *DatasetVersion) error {
+	if dsv.Status != VersionDraft {
+		return fmt.Errorf("%w: version %d is %s, only draft versions can change", ErrVersionStatus, dsv.Number, dsv.Status)
+	}
+	return nil
+}
+
+// AddAssetsToVersion adds assets to a draft dataset version, assets already in it are kept once
+func (engine *Engine) AddAssetsToVersion(dsv *DatasetVersion, assets ...*Asset) error {
+	slog.Debug("Adding assets to dataset version", "version", dsv.ID, "total", len(assets))
+
+	if err := checkVersionDraft(dsv); err != nil {
+		return err
+	}
+	if len(assets) == 0 {
+		return nil
+	}
+
+	if err := engine.DatabaseClient.Model(dsv).Omit("Assets.*").Association("Assets").Append(assets); err != nil {
+		return fmt.Errorf("add assets to dataset version %d: %w", dsv.Number, err)
+	}
+	return nil
+}
+
+// RemoveAssetsFromVersion removes assets from a draft dataset version, the assets themselves are left alone
+func (engine *Engine) RemoveAssetsFromVersion(dsv *DatasetVersion, assets ...*Asset) error {
+	slog.Debug("Removing assets from dataset version", "version", dsv.ID, "total", len(assets))
+
+	if err := checkVersionDraft(dsv); err != nil {
+		return err
+	}
+	if len(assets) == 0 {
+		return nil
+	}
+
+	// delete through the membership model so its lock hook sees the version
+	members := make([]DatasetVersionAsset, len(assets))
+	for i, a := range assets {
+		members[i] = DatasetVersionAsset{DatasetVersionID: dsv.ID, AssetID: a.ID}
+	}
+
+	err := engine.DatabaseClient.Delete(&members).Error
+	if err != nil {
+		return fmt.Errorf("remove assets from dataset version %d: %w", dsv.Number, err)
+	}
+	return nil
+}
+
+// ListVersionAssets returns a page of the dataset version assets with their tags, ordered by id after cursor
+func (engine *Engine) ListVersionAssets(dsv *DatasetVersion, cursor uint, limit uint) ([]*Asset, error) {
+	slog.Debug("Listing dataset version assets", "version", dsv.ID, "cursor", cursor, "limit", limit)
+
+	if limit == 0 || limit > SearchMaxLimit {
+		limit = SearchDefaultLimit
+	}
+
+	var assets []*Asset
+	err := engine.explainSlow(engine.DatabaseClient, "list dataset version assets").
+		Joins("JOIN dataset_version_assets ON dataset_version_assets.asset_id = assets.id").
+		Where("dataset_version_assets.dataset_version_id = ? AND assets.id > ?", dsv.ID, cursor).
+		Order("assets.id ASC").
+		Limit(int(limit)).
+		Find(&assets).Error
+	if err != nil {
+		return nil, fmt.Errorf("list dataset version %d assets: %w", dsv.Number, err)
+	}
+
+	if err := engine.loadTags(assets); err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
@@ -0,0 +1,268 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+const (
+	DEFAULT_METERING_INTERVAL = time.Hour
+	METERING_LOCK             = "aether-metering"
+)
+
+var ErrMeteringRunning = registryerrors.New(registryerrors.ErrConflict, "usage metering already running")
+
+// UsageRecord is the metered usage of a project during one calendar month (UTC).
+// Rows of the running month are refreshed by the metering job, past months keep their last snapshot.
+type UsageRecord struct {
+	gorm.Model
+	Project       string    `gorm:"not null;size:63;uniqueIndex:idx_usage_project_month"`
+	Month         time.Time `gorm:"not null;uniqueIndex:idx_usage_project_month"`
+	StoredBytes   int64     `gorm:"not null;default:0"`
+	StoredObjects int64     `gorm:"not null;default:0"`
+	Uploads       int64     `gorm:"not null;default:0"`
+	Downloads     int64     `gorm:"not null;default:0"`
+	MeteredAt     time.Time `gorm:"not null"`
+}
+
+// MonthStart truncates t to the first instant of its month in UTC
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// UsageQuery filters usage records, oldest month first
+type UsageQuery struct {
+	Project string
+	Since   time.Time
+	Until   time.Time
+}
+
+type UsageOption func(*UsageQuery) error
+
+func WithUsageProject(project string) UsageOption {
+	return func(q *UsageQuery) error {
+		q.Project = NormalizeString(project)
+		return nil
+	}
+}
+
+// WithUsageWindow keeps months within [since, until), zero values are open ended
+func WithUsageWindow(since time.Time, until time.Time) UsageOption {
+	return func(q *UsageQuery) error {
+		if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+			return fmt.Errorf("since must be before until")
+		}
+		q.Since = since
+		q.Until = until
+		return nil
+	}
+}
+
+func (engine *Engine) ListUsageRecords(opts ...UsageOption) ([]*UsageRecord, error) {
+	query := &UsageQuery{}
+	for _, opt := range opts {
+		if err := opt(query); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+
+	slog.Debug("Listing usage records", "query", fmt.Sprintf("%+v", *query))
+
+	db := engine.DatabaseClient.Model(&UsageRecord{})
+	if query.Project != "" {
+		db = db.Where("project = ?", query.Project)
+	}
+	if !query.Since.IsZero() {
+		db = db.Where("month >= ?", MonthStart(query.Since))
+	}
+	if !query.Until.IsZero() {
+		db = db.Where("month < ?", query.Until)
+	}
+
+	var records []*UsageRecord
+	if err := db.Order("month ASC, project ASC").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("list usage: %w", err)
+	}
+	return records, nil
+}
+
+// SaveUsageRecord upserts the usage of a project month
+func (engine *Engine) SaveUsageRecord(record *UsageRecord) error {
+	record.Month = MonthStart(record.Month)
+
+	err := engine.DatabaseClient.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "project"}, {Name: "month"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"stored_bytes", "stored_objects", "uploads", "downloads", "metered_at", "updated_at",
+		}),
+	}).Create(record).Error
+	if err != nil {
+		return fmt.Errorf("save usage %q %s: %w", record.Project, record.Month.Format("2006-01"), err)
+	}
+	return nil
+}
+
+// meteredProjects returns every project that may have usage: the default one,
+// projects with a storage mapping, and projects seen in the access log since from
+func (engine *Engine) meteredProjects(ctx context.Context, from time.Time) ([]string, error) {
+	db := engine.DatabaseClient.WithContext(ctx)
+
+	var mapped []string
+	if err := db.Model(&StorageMapping{}).Pluck("project", &mapped).Error; err != nil {
+		return nil, fmt.Errorf("list mapped projects: %w", err)
+	}
+
+	var seen []string
+	err := db.Model(&AccessLog{}).
+		Where("created_at >= ? AND project IS NOT NULL AND project <> ''", from).
+		Distinct("project").
+		Pluck("project", &seen).Error
+	if err != nil {
+		return nil, fmt.Errorf("list active projects: %w", err)
+	}
+
+	projects := append([]string{DEFAULT_PROJECT}, mapped...)
+	projects = append(projects, seen...)
+	slices.Sort(projects)
+	return slices.Compact(projects), nil
+}
+
+// countAccess sets the uploads and downloads of the record month from the access log
+func (engine *Engine) countAccess(ctx context.Context, record *UsageRecord) error {
+	type count struct {
+		Operation string
+		Total     int64
+	}
+
+	var counts []count
+	err := engine.DatabaseClient.WithContext(ctx).Model(&AccessLog{}).
+		Select("operation, COUNT(*) AS total").
+		Where("created_at >= ? AND created_at < ?", record.Month, record.Month.AddDate(0, 1, 0)).
+		Where("project = ?", record.Project).
+		Group("operation").
+		Scan(&counts).Error
+	if err != nil {
+		return fmt.Errorf("count project %q access: %w", record.Project, err)
+	}
+
+	record.Uploads, record.Downloads = 0, 0
+	for _, c := range counts {
+		switch c.Operation {
+		case "put":
+			record.Uploads = c.Total
+		case "get":
+			record.Downloads = c.Total
+		}
+	}
+	return nil
+}
+
+// meterProject measures the project usage during the month starting at month
+func (engine *Engine) meterProject(ctx context.Context, project string, month time.Time) (*UsageRecord, error) {
+	record := &UsageRecord{Project: project, Month: month, MeteredAt: time.Now()}
+	if err := engine.countAccess(ctx, record); err != nil {
+		return nil, err
+	}
+
+	ns, err := engine.Namespace(project)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ns.ListObjects(ctx, "curated", func(info ObjectInfo) error {
+		record.StoredBytes += info.Size
+		record.StoredObjects++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("measure project %q storage: %w", project, err)
+	}
+
+	return record, nil
+}
+
+// MeterUsage refreshes the usage records of the running month for every project.
+// Stored bytes are a snapshot of the curated objects, uploads and downloads count issued presigned urls.
+func (engine *Engine) MeterUsage(ctx context.Context) error {
+	lock, err := engine.TryLock(ctx, METERING_LOCK)
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		return ErrMeteringRunning
+	}
+	defer lock.Release()
+
+	month := MonthStart(time.Now())
+	if err := engine.closeUsageMonth(ctx, month.AddDate(0, -1, 0)); err != nil {
+		return err
+	}
+
+	projects, err := engine.meteredProjects(ctx, month)
+	if err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		record, err := engine.meterProject(ctx, project, month)
+		if err != nil {
+			return err
+		}
+		if err := engine.SaveUsageRecord(record); err != nil {
+			return err
+		}
+		slog.Debug("Metered project usage", "project", project, "storedBytes", record.StoredBytes, "uploads", record.Uploads, "downloads", record.Downloads)
+	}
+
+	slog.Info("Usage metering completed", "projects", len(projects), "month", month.Format("2006-01"))
+	return nil
+}
+
+// closeUsageMonth recounts the access of a past month metered before it ended,
+// urls issued after its last run would be missing otherwise. Stored bytes keep their last snapshot.
+func (engine *Engine) closeUsageMonth(ctx context.Context, month time.Time) error {
+	var open []*UsageRecord
+	err := engine.DatabaseClient.WithContext(ctx).
+		Where("month = ? AND metered_at < ?", month, month.AddDate(0, 1, 0)).
+		Find(&open).Error
+	if err != nil {
+		return fmt.Errorf("list open usage records: %w", err)
+	}
+
+	for _, record := range open {
+		if err := engine.countAccess(ctx, record); err != nil {
+			return err
+		}
+		record.MeteredAt = time.Now()
+		if err := engine.SaveUsageRecord(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// meteringJob wraps MeterUsage as a maintenance job
+func (engine *Engine) meteringJob(interval time.Duration) Job {
+	return Job{
+		Name:     "metering",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			err := engine.MeterUsage(ctx)
+			if errors.Is(err, ErrMeteringRunning) {
+				slog.Debug("Skipping usage metering, another run holds the lock")
+				return nil
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/pkg/metrics"
+)
+
+// engineMetrics are the collectors the engine updates, registered once per engine
+type engineMetrics struct {
+	queries       *prometheus.HistogramVec
+	presigns      *prometheus.CounterVec
+	storageErrors *prometheus.CounterVec
+}
+
+// MetricsRegistry returns the registerer of the engine metrics, see WithMetricsRegistry.
+// Servers register their own collectors with it and serve it when it is a prometheus.Gatherer.
+func (engine *Engine) MetricsRegistry() prometheus.Registerer {
+	return engine.metricsRegistry
+}
+
+// createMetrics registers the engine collectors, an engine sharing a registry reuses the ones registered first
+func (engine *Engine) createMetrics() error {
+	if engine.metricsRegistry == nil {
+		engine.metricsRegistry = prometheus.NewRegistry()
+	}
+	reg := engine.metricsRegistry
+
+	queries, err := metrics.Register(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "aether_db_query_duration_seconds",
+		Help: "Database statement durations, by operation and table.",
+	}, []string{"operation", "table"}))
+	if err != nil {
+		return err
+	}
+
+	presigns, err := metrics.Register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "aether_presigned_urls_total",
+		Help: "Presigned storage urls issued, by operation.",
+	}, []string{"operation"}))
+	if err != nil {
+		return err
+	}
+
+	storageErrors, err := metrics.Register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "aether_storage_errors_total",
+		Help: "Failed S3 requests, by operation. Missing objects are not counted.",
+	}, []string{"operation"}))
+	if err != nil {
+		return err
+	}
+
+	engine.metrics = &engineMetrics{queries: queries, presigns: presigns, storageErrors: storageErrors}
+	return nil
+}
+
+// registerMetricsCallbacks times every statement of db
+func (engine *Engine) registerMetricsCallbacks(db *gorm.DB) error {
+	const startKey = "metrics:start"
+
+	start := func(tx *gorm.DB) {
+		tx.InstanceSet(startKey, time.Now())
+	}
+	observe := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			started, ok := tx.InstanceGet(startKey)
+			if !ok {
+				return
+			}
+			table := tx.Statement.Table
+			if table == "" {
+				table = "raw"
+			}
+			engine.metrics.queries.WithLabelValues(operation, table).Observe(time.Since(started.(time.Time)).Seconds())
+		}
+	}
+
+	cb := db.Callback()
+	err := errors.Join(
+		cb.Create().Before("*").Register("metrics:before_create", start),
+		cb.Create().After("*").Register("metrics:after_create", observe("create")),
+		cb.Query().Before("*").Register("metrics:before_query", start),
+		cb.Query().After("*").Register("metrics:after_query", observe("query")),
+		cb.Update().Before("*").Register("metrics:before_update", start),
+		cb.Update().After("*").Register("metrics:after_update", observe("update")),
+		cb.Delete().Before("*").Register("metrics:before_delete", start),
+		cb.Delete().After("*").Register("metrics:after_delete", observe("delete")),
+		cb.Row().Before("*").Register("metrics:before_row", start),
+		cb.Row().After("*").Register("metrics:after_row", observe("row")),
+		cb.Raw().Before("*").Register("metrics:before_raw", start),
+		cb.Raw().After("*").Register("metrics:after_raw", observe("raw")),
+	)
+	if err != nil {
+		return fmt.Errorf("register metrics callbacks: %w", err)
+	}
+	return nil
+}
+
+// s3Metrics counts the failed requests of an S3 client
+func (engine *Engine) s3Metrics(o *s3.Options) {
+	o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("AetherMetrics", func(
+			ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+		) (middleware.InitializeOutput, middleware.Metadata, error) {
+			out, metadata, err := next.HandleInitialize(ctx, in)
+
+			var response *awshttp.ResponseError
+			if err != nil && !(errors.As(err, &response) && response.HTTPStatusCode() == http.StatusNotFound) {
+				engine.metrics.storageErrors.WithLabelValues(awsmiddleware.GetOperationName(ctx)).Inc()
+			}
+			return out, metadata, err
+		}), middleware.After)
+	})
+}
@@ -0,0 +1,39 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TestMetricsRegistry checks engines register with a registry of the caller, and share it
+func TestMetricsRegistry(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	first := newTestEngine(t, WithMetricsRegistry(reg))
+	second := newTestEngine(t, WithMetricsRegistry(reg))
+
+	for _, engine := range []*Engine{first, second} {
+		if _, err := engine.ListAssetsRecords(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() != "aether_db_query_duration_seconds" {
+			continue
+		}
+		var count uint64
+		for _, m := range family.GetMetric() {
+			count += m.GetHistogram().GetSampleCount()
+		}
+		if count < 2 {
+			t.Errorf("%d queries observed, want at least one per engine", count)
+		}
+		return
+	}
+	t.Error("aether_db_query_duration_seconds is not registered")
+}
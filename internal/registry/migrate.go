@@ -9,16 +9,43 @@ import (
 func (engine *Engine) Migrate() error {
 	slog.Info("Running database migrations")
 
-	// Step 1: Handle custom PostgreSQL types (AutoMigrate can't do this)
-	if err := engine.createCustomTypes(); err != nil {
-		return fmt.Errorf("failed to create custom types: %w", err)
+	if engine.isSQLite() {
+		// Sqlite has neither enum types nor partitioning, enums are stored as text
+		if err := engine.createSQLiteLogTables(); err != nil {
+			return err
+		}
+	} else {
+		// Step 1: Handle custom PostgreSQL types (AutoMigrate can't do this)
+		if err := engine.createCustomTypes(); err != nil {
+			return fmt.Errorf("failed to create custom types: %w", err)
+		}
+
+		// Step 2: Create partitioned tables (AutoMigrate can't do this either)
+		if err := engine.createPartitionedTables(); err != nil {
+			return fmt.Errorf("failed to create partitioned tables: %w", err)
+		}
 	}
 
-	// Step 2: Run AutoMigrate on all models
+	// Step 3: Run AutoMigrate on all models
 	if err := engine.autoMigrate(); err != nil {
 		return fmt.Errorf("failed to auto migrate: %w", err)
 	}
 
+	// Step 4: Records created before projects belong to the default one
+	if err := engine.ensureDefaultProject(); err != nil {
+		return err
+	}
+
+	// Step 5: Usage of projects that were never counted, e.g. after upgrading
+	if err := engine.seedProjectUsage(); err != nil {
+		return err
+	}
+
+	// Step 6: Full-text search columns, generated columns are not supported by AutoMigrate
+	if err := engine.createSearchVectors(); err != nil {
+		return err
+	}
+
 	slog.Info("Database migrations completed successfully")
 	return nil
 }
@@ -39,6 +66,32 @@ func (engine *Engine) createCustomTypes() error {
 		return fmt.Errorf("failed to create status enum: %w", err)
 	}
 
+	// Create dataset version publication status enum type
+	err = engine.DatabaseClient.Exec(`
+		DO $$ BEGIN
+			CREATE TYPE version_status AS ENUM ('draft', 'review', 'published');
+		EXCEPTION
+			WHEN duplicate_object THEN null;
+		END $$;
+	`).Error
+
+	if err != nil {
+		return fmt.Errorf("failed to create version status enum: %w", err)
+	}
+
+	// Create asset classification enum type
+	err = engine.DatabaseClient.Exec(`
+		DO $$ BEGIN
+			CREATE TYPE classification AS ENUM ('public', 'internal', 'confidential', 'pii');
+		EXCEPTION
+			WHEN duplicate_object THEN null;
+		END $$;
+	`).Error
+
+	if err != nil {
+		return fmt.Errorf("failed to create classification enum: %w", err)
+	}
+
 	// Add more custom types here as needed in the future:
 	// e.g., CREATE TYPE priority AS ENUM ('low', 'medium', 'high');
 
@@ -47,14 +100,49 @@ func (engine *Engine) createCustomTypes() error {
 
 // autoMigrate runs GORM AutoMigrate on all models
 func (engine *Engine) autoMigrate() error {
+	// Membership rows carry the version lock hooks
+	if err := engine.DatabaseClient.SetupJoinTable(&DatasetVersion{}, "Assets", &DatasetVersionAsset{}); err != nil {
+		return fmt.Errorf("failed to setup dataset version assets: %w", err)
+	}
+
+	// Names and checksums are unique per project since projects exist
+	migrator := engine.DatabaseClient.Migrator()
+	for model, index := range map[any]string{&Asset{}: "idx_assets_checksum", &Tag{}: "idx_tags_name", &Dataset{}: "idx_datasets_name"} {
+		if migrator.HasTable(model) && migrator.HasIndex(model, index) {
+			if err := migrator.DropIndex(model, index); err != nil {
+				return fmt.Errorf("failed to drop index %q: %w", index, err)
+			}
+		}
+	}
+
 	// Register all your models here
 	// Add new models to this list as your project grows
 	return engine.DatabaseClient.AutoMigrate(
 		// Core models
+		&Project{},
 		&Asset{},
+		&TagGroup{},
 		&Tag{},
 		&Dataset{},
 		&DatasetVersion{},
+		&DatasetVersionApproval{},
+		&DatasetChannel{},
+		&DatasetSchema{},
 		&Peer{},
+		&StorageMapping{},
+		&ContentPolicy{},
+		&LicenseRestriction{},
+		&UsageRecord{},
+		&ProjectUsage{},
+		&AssetContent{},
+		&AssetEnrichment{},
+		&AssetDigest{},
+		&AssetDeletion{},
+		&Webhook{},
+		&WebhookDelivery{},
+		&APIKey{},
+		&RetagJob{},
+		&RoleAssignment{},
+		&IdempotencyRecord{},
 	)
 }
@@ -0,0 +1,242 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_PROJECT   = "default"
+	PROJECTS_KEY_ROOT = "projects"
+)
+
+var (
+	ErrInvalidProject   = errors.New("invalid project name")
+	ErrKeyOutsideTenant = errors.New("key outside project namespace")
+
+	projectNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+)
+
+// Namespace scopes storage keys to a single project inside the bucket.
+// Every presign and delete issued through it is checked against its root,
+// so a bad key can never reach another project's keyspace.
+type Namespace struct {
+	engine  *Engine
+	bucket  Storage
+	project string
+	root    string
+
+	// isolated namespaces own a whole mapped bucket
+	isolated bool
+	// closed namespaces failed to resolve and contain no key
+	closed bool
+}
+
+// Namespace returns the storage namespace of a project.
+// Projects with a storage mapping get their own bucket and credentials.
+// Otherwise the default project keeps the legacy layout directly under the bucket prefix,
+// and other projects live under <prefix>/projects/<project>.
+func (engine *Engine) Namespace(project string) (*Namespace, error) {
+	project = NormalizeString(project)
+	if project == "" {
+		project = DEFAULT_PROJECT
+	}
+
+	if !projectNamePattern.MatchString(project) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidProject, project)
+	}
+
+	mapping, err := engine.GetStorageMappingRecord(project)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping != nil {
+		b, err := engine.mappedBucket(mapping)
+		if err != nil {
+			return nil, err
+		}
+		return &Namespace{engine: engine, bucket: b, project: project, root: mapping.Prefix, isolated: true}, nil
+	}
+
+	root := engine.prefix
+	if project != DEFAULT_PROJECT {
+		root = path.Join(engine.prefix, PROJECTS_KEY_ROOT, project)
+	}
+
+	return &Namespace{engine: engine, bucket: engine.defaultBucket(), project: project, root: root}, nil
+}
+
+// defaultNamespace is the namespace used by the engine helpers, that of the default project unless the engine is scoped
+func (engine *Engine) defaultNamespace() *Namespace {
+	if engine.namespace != nil {
+		return engine.namespace
+	}
+	return &Namespace{engine: engine, bucket: engine.defaultBucket(), project: DEFAULT_PROJECT, root: engine.prefix}
+}
+
+// KeyProject returns the project whose namespace in the default bucket holds key, "" when key is outside the bucket prefix
+func (engine *Engine) KeyProject(key string) string {
+	rel := key
+	if root := path.Join(engine.prefix); root != "" {
+		var ok bool
+		if rel, ok = strings.CutPrefix(key, root+"/"); !ok {
+			return ""
+		}
+	}
+
+	if rest, ok := strings.CutPrefix(rel, PROJECTS_KEY_ROOT+"/"); ok {
+		project, _, _ := strings.Cut(rest, "/")
+		return project
+	}
+	return DEFAULT_PROJECT
+}
+
+// Project returns the project name of the namespace
+func (ns *Namespace) Project() string {
+	return ns.project
+}
+
+// Root returns the key prefix owned by the namespace
+func (ns *Namespace) Root() string {
+	return ns.root
+}
+
+// Bucket returns the bucket holding the namespace objects
+func (ns *Namespace) Bucket() string {
+	return ns.bucket.Name()
+}
+
+// IngressKey generates the ingress S3 key path of the project
+func (ns *Namespace) IngressKey(checksum string) string {
+	return path.Join(ns.root, "ingress", checksum)
+}
+
+// StagingKey generates the key path of a staged upload of the project.
+// Staged uploads live in the ingress area, so the garbage collector removes abandoned ones as orphans.
+func (ns *Namespace) StagingKey(id string) string {
+	return path.Join(ns.root, "ingress", "staging", id)
+}
+
+// CuratedKey generates the curated S3 key path of the project
+func (ns *Namespace) CuratedKey(checksum string) string {
+	return path.Join(ns.root, "curated", checksum)
+}
+
+// PreviewKey generates the key path of the preview image of an asset of the project
+func (ns *Namespace) PreviewKey(checksum string) string {
+	return path.Join(ns.root, "previews", checksum+".jpg")
+}
+
+// Contains reports whether key belongs to the namespace.
+// Keys must be clean, live under the root, and the default project never owns other projects' keys.
+// Without a root a clean key can still climb out of the bucket with leading "..".
+func (ns *Namespace) Contains(key string) bool {
+	if ns.closed || key == "" || path.Clean(key) != key || strings.HasPrefix(key, "/") ||
+		key == ".." || strings.HasPrefix(key, "../") {
+		return false
+	}
+
+	rel := key
+	if ns.root != "" {
+		if !strings.HasPrefix(key, ns.root+"/") {
+			return false
+		}
+		rel = strings.TrimPrefix(key, ns.root+"/")
+	}
+
+	if ns.project == DEFAULT_PROJECT && !ns.isolated {
+		return rel != PROJECTS_KEY_ROOT && !strings.HasPrefix(rel, PROJECTS_KEY_ROOT+"/")
+	}
+	return true
+}
+
+// Check fails when any of the keys is outside the namespace
+func (ns *Namespace) Check(keys ...string) error {
+	for _, key := range keys {
+		if !ns.Contains(key) {
+			return fmt.Errorf("%w: project %q, key %q", ErrKeyOutsideTenant, ns.project, key)
+		}
+	}
+	return nil
+}
+
+// IngressUrlExpire generates a presigned upload URL inside the project
+func (ns *Namespace) IngressUrlExpire(ctx context.Context, sha256 string, expire time.Duration) (*PresignedUrl, error) {
+	return ns.IngressUrlSize(ctx, sha256, expire, 0)
+}
+
+// IngressUrlSize generates a presigned upload URL inside the project, bound to size when positive
+func (ns *Namespace) IngressUrlSize(ctx context.Context, sha256 string, expire time.Duration, size int64) (*PresignedUrl, error) {
+	key := ns.IngressKey(sha256)
+	if err := ns.Check(key); err != nil {
+		return nil, err
+	}
+	return ns.engine.presignPut(ctx, ns.bucket, key, sha256, expire, size)
+}
+
+// StagingUrlExpire generates a presigned upload URL of a staged upload, its content is not checked until it is promoted
+func (ns *Namespace) StagingUrlExpire(ctx context.Context, id string, expire time.Duration) (*PresignedUrl, error) {
+	key := ns.StagingKey(id)
+	if err := ns.Check(key); err != nil {
+		return nil, err
+	}
+	return ns.engine.presignPut(ctx, ns.bucket, key, "", expire, 0)
+}
+
+// CuratedUrlExpire generates a presigned download URL inside the project
+func (ns *Namespace) CuratedUrlExpire(ctx context.Context, sha256 string, expire time.Duration) (*PresignedUrl, error) {
+	key := ns.CuratedKey(sha256)
+	if err := ns.Check(key); err != nil {
+		return nil, err
+	}
+	return ns.engine.presignGet(ctx, ns.bucket, key, sha256, expire)
+}
+
+// PreviewUrlExpire generates a presigned download URL of an asset preview inside the project
+func (ns *Namespace) PreviewUrlExpire(ctx context.Context, sha256 string, expire time.Duration) (*PresignedUrl, error) {
+	key := ns.PreviewKey(sha256)
+	if err := ns.Check(key); err != nil {
+		return nil, err
+	}
+	return ns.engine.presignGet(ctx, ns.bucket, key, sha256, expire)
+}
+
+// ObjectExists reports whether a key of the project is stored
+func (ns *Namespace) ObjectExists(ctx context.Context, key string) (bool, error) {
+	if err := ns.Check(key); err != nil {
+		return false, err
+	}
+	return ns.engine.objectExists(ctx, ns.bucket, key)
+}
+
+// StatObject returns metadata of a stored project key, nil when missing
+func (ns *Namespace) StatObject(ctx context.Context, key string) (*ObjectInfo, error) {
+	if err := ns.Check(key); err != nil {
+		return nil, err
+	}
+	return ns.engine.statObject(ctx, ns.bucket, key)
+}
+
+// DeleteObjects removes keys of the project, refusing the whole call if any key is foreign
+func (ns *Namespace) DeleteObjects(ctx context.Context, keys ...string) error {
+	if err := ns.Check(keys...); err != nil {
+		return err
+	}
+	return ns.engine.deleteObjects(ctx, ns.bucket, keys...)
+}
+
+// ListObjects walks objects under prefix, relative to the project root
+func (ns *Namespace) ListObjects(ctx context.Context, prefix string, fn func(ObjectInfo) error) error {
+	return ns.engine.listObjects(ctx, ns.bucket, path.Join(ns.root, prefix), func(info ObjectInfo) error {
+		if !ns.Contains(info.Key) {
+			return nil
+		}
+		return fn(info)
+	})
+}
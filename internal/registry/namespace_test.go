@@ -0,0 +1,209 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestEngine creates an engine on a sqlite file and the filesystem storage backend, keys live under the "pfx" prefix
+func newTestEngine(t testing.TB, opts ...Option) *Engine {
+	t.Helper()
+
+	engine, err := New(append([]Option{
+		WithDatabaseDriver(DRIVER_SQLITE),
+		WithDatabaseName(t.TempDir() + "/aether.db"),
+		WithStorageBackend("filesystem"),
+		WithStoragePath(t.TempDir()),
+		WithStorageURL("http://127.0.0.1/api"),
+		WithStorageSigningKey("0123456789abcdef"),
+		WithBucketPrefix("pfx"),
+	}, opts...)...)
+	if err != nil {
+		t.Fatalf("create registry: %v", err)
+	}
+	t.Cleanup(func() { engine.Close() })
+	return engine
+}
+
+func TestNamespaceContains(t *testing.T) {
+	proj := &Namespace{project: "proj", root: "pfx/projects/proj"}
+	def := &Namespace{project: DEFAULT_PROJECT, root: "pfx"}
+	bare := &Namespace{project: DEFAULT_PROJECT}
+	mapped := &Namespace{project: "proj", root: "mapped", isolated: true}
+	closed := &Namespace{project: "proj", root: "pfx/projects/proj", closed: true}
+
+	for _, tt := range []struct {
+		name string
+		ns   *Namespace
+		key  string
+		want bool
+	}{
+		{name: "own key", ns: proj, key: "pfx/projects/proj/curated/abc", want: true},
+		{name: "empty key", ns: proj, key: ""},
+		{name: "root itself", ns: proj, key: "pfx/projects/proj"},
+		{name: "prefix sibling", ns: proj, key: "pfx/projects/proj2/curated/abc"},
+		{name: "other project", ns: proj, key: "pfx/projects/other/curated/abc"},
+		{name: "dot dot", ns: proj, key: ".."},
+		{name: "dot dot inside root", ns: proj, key: "pfx/projects/proj/../proj2/curated/abc"},
+		{name: "dot dot escaping root", ns: proj, key: "pfx/projects/proj/curated/../../../curated/abc"},
+		{name: "unclean", ns: proj, key: "pfx/projects/proj//curated/abc"},
+		{name: "absolute", ns: proj, key: "/pfx/projects/proj/curated/abc"},
+		{name: "default own key", ns: def, key: "pfx/curated/abc", want: true},
+		{name: "default reaching a project", ns: def, key: "pfx/projects/proj/curated/abc"},
+		{name: "default reaching the projects root", ns: def, key: "pfx/projects"},
+		{name: "default prefix sibling", ns: def, key: "pfx2/curated/abc"},
+		{name: "default without prefix", ns: bare, key: "curated/abc", want: true},
+		{name: "default without prefix reaching a project", ns: bare, key: "projects/proj/curated/abc"},
+		{name: "default without prefix dot dot", ns: bare, key: "../curated/abc"},
+		{name: "mapped own key", ns: mapped, key: "mapped/projects/x", want: true},
+		{name: "mapped prefix sibling", ns: mapped, key: "mapped2/x"},
+		{name: "closed", ns: closed, key: "pfx/projects/proj/curated/abc"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ns.Contains(tt.key); got != tt.want {
+				t.Errorf("Contains(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+
+			err := tt.ns.Check(tt.key)
+			if tt.want && err != nil {
+				t.Errorf("Check(%q) = %v, want nil", tt.key, err)
+			}
+			if !tt.want && !errors.Is(err, ErrKeyOutsideTenant) {
+				t.Errorf("Check(%q) = %v, want %v", tt.key, err, ErrKeyOutsideTenant)
+			}
+		})
+	}
+}
+
+// TestNamespaceRejectsForeignKeys checks presign, copy and delete refuse keys of another project before touching the store
+func TestNamespaceRejectsForeignKeys(t *testing.T) {
+	ctx := context.Background()
+	engine := newTestEngine(t)
+
+	ns, err := engine.Namespace("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sibling, err := engine.Namespace("proj2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checksum := strings.Repeat("a", 64)
+	victim := sibling.CuratedKey(checksum)
+	if err := sibling.bucket.Put(ctx, victim, bytes.NewReader([]byte("secret")), 6, "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	// checksums are joined into keys, these escape the project root once cleaned
+	escapes := []string{"../../proj2/curated/" + checksum, "../../../curated/" + checksum, "../.."}
+
+	t.Run("presign", func(t *testing.T) {
+		for _, escape := range escapes {
+			if _, err := ns.IngressUrlExpire(ctx, escape, 0); !errors.Is(err, ErrKeyOutsideTenant) {
+				t.Errorf("IngressUrlExpire(%q) = %v, want %v", escape, err, ErrKeyOutsideTenant)
+			}
+			if _, err := ns.CuratedUrlExpire(ctx, escape, 0); !errors.Is(err, ErrKeyOutsideTenant) {
+				t.Errorf("CuratedUrlExpire(%q) = %v, want %v", escape, err, ErrKeyOutsideTenant)
+			}
+		}
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		asset := &Asset{Project: "proj", Checksum: strings.Repeat("b", 64)}
+		if err := engine.CreateAssetRecord(asset); err != nil {
+			t.Fatal(err)
+		}
+		// a corrupted record, validation keeps such checksums out of the assets table
+		escape := "../../proj2/ingress/" + checksum
+		if err := engine.DatabaseClient.Model(asset).UpdateColumn("checksum", escape).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ns.PromoteAsset(ctx, escape); !errors.Is(err, ErrKeyOutsideTenant) {
+			t.Errorf("PromoteAsset(%q) = %v, want %v", escape, err, ErrKeyOutsideTenant)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		for _, key := range []string{victim, "", "..", ns.Root() + "/../proj2/curated/" + checksum} {
+			// a single foreign key refuses the whole call
+			if err := ns.DeleteObjects(ctx, ns.CuratedKey(checksum), key); !errors.Is(err, ErrKeyOutsideTenant) {
+				t.Errorf("DeleteObjects(%q) = %v, want %v", key, err, ErrKeyOutsideTenant)
+			}
+		}
+
+		info, err := sibling.StatObject(ctx, victim)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info == nil {
+			t.Errorf("%s was deleted through project proj", victim)
+		}
+	})
+}
+
+func TestKeyProject(t *testing.T) {
+	engine := newTestEngine(t)
+
+	ns, err := engine.Namespace("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksum := strings.Repeat("a", 64)
+
+	cases := map[string]string{
+		engine.defaultNamespace().IngressKey(checksum): DEFAULT_PROJECT,
+		ns.IngressKey(checksum):                        "proj",
+		ns.StagingKey("id"):                            "proj",
+		"other/ingress/" + checksum:                    "",
+	}
+	for key, want := range cases {
+		if got := engine.KeyProject(key); got != want {
+			t.Errorf("KeyProject(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+// TestGarbageCollectorSweepsEveryProject checks the scheduled collector, built on the root engine,
+// reaches the ingress areas of projects other than the default one
+func TestGarbageCollectorSweepsEveryProject(t *testing.T) {
+	ctx := context.Background()
+	engine := newTestEngine(t)
+
+	if err := engine.CreateProjectRecord(&Project{Name: "proj"}); err != nil {
+		t.Fatal(err)
+	}
+	ns, err := engine.Namespace("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orphan := ns.IngressKey(strings.Repeat("d", 64))
+	if err := ns.bucket.Put(ctx, orphan, bytes.NewReader([]byte("orphan")), 6, ""); err != nil {
+		t.Fatal(err)
+	}
+	file, err := engine.Storage().(*FilesystemStorage).path(orphan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * GC_MIN_AGE)
+	if err := os.Chtimes(file, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := engine.NewGarbageCollector().Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Orphaned != 1 || report.Deleted != 1 {
+		t.Errorf("collected %d orphaned and %d deleted objects, want 1 and 1", report.Orphaned, report.Deleted)
+	}
+	if info, err := ns.StatObject(ctx, orphan); info != nil || err != nil {
+		t.Errorf("orphan after collection got %+v, %v, want it deleted", info, err)
+	}
+}
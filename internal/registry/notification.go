@@ -0,0 +1,103 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ObjectNotification is an object created record of a bucket event notification.
+// S3 (directly, through SQS or through SNS) and MinIO webhooks share the record format.
+type ObjectNotification struct {
+	Event  string
+	Bucket string
+	Key    string
+	Size   int64
+}
+
+type bucketNotification struct {
+	Records []struct {
+		EventName string `json:"eventName"`
+		S3        struct {
+			Bucket struct {
+				Name string `json:"name"`
+			} `json:"bucket"`
+			Object struct {
+				Key  string `json:"key"`
+				Size int64  `json:"size"`
+			} `json:"object"`
+		} `json:"s3"`
+	} `json:"Records"`
+
+	// SNS envelope of a notification fanned out through a topic
+	Type    string `json:"Type"`
+	Message string `json:"Message"`
+}
+
+// ParseObjectNotifications decodes the object created records of a bucket notification body.
+// SNS envelopes are unwrapped, test events and other event types yield no records.
+func ParseObjectNotifications(body []byte) ([]ObjectNotification, error) {
+	var n bucketNotification
+	if err := json.Unmarshal(body, &n); err != nil {
+		return nil, fmt.Errorf("%w: invalid bucket notification: %w", ErrValidation, err)
+	}
+	if n.Type == "Notification" && n.Message != "" {
+		return ParseObjectNotifications([]byte(n.Message))
+	}
+
+	var records []ObjectNotification
+	for _, r := range n.Records {
+		// MinIO prefixes event names with s3:
+		event := strings.TrimPrefix(r.EventName, "s3:")
+		if !strings.HasPrefix(event, "ObjectCreated:") {
+			continue
+		}
+
+		// keys are form encoded in notifications
+		key, err := url.QueryUnescape(r.S3.Object.Key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid object key %q: %w", ErrValidation, r.S3.Object.Key, err)
+		}
+
+		records = append(records, ObjectNotification{
+			Event:  event,
+			Bucket: r.S3.Bucket.Name,
+			Key:    key,
+			Size:   r.S3.Object.Size,
+		})
+	}
+	return records, nil
+}
+
+// IngressChecksum returns the project and checksum of the asset an ingress key of the default bucket belongs to,
+// false for keys of other buckets, staged uploads or anything outside the ingress areas
+func (engine *Engine) IngressChecksum(bucket string, key string) (string, string, bool) {
+	if bucket != "" && bucket != engine.defaultBucket().Name() {
+		return "", "", false
+	}
+
+	checksum := path.Base(key)
+	if ValidateSHA256(checksum) != nil || checksum != NormalizeString(checksum) {
+		return "", "", false
+	}
+
+	// <prefix>/ingress/<checksum> or <prefix>/projects/<project>/ingress/<checksum>
+	project := DEFAULT_PROJECT
+	if root := path.Dir(path.Dir(key)); path.Dir(root) == path.Join(engine.prefix, PROJECTS_KEY_ROOT) {
+		project = path.Base(root)
+	}
+	if project != DEFAULT_PROJECT && !projectNamePattern.MatchString(project) {
+		return "", "", false
+	}
+
+	root := engine.prefix
+	if project != DEFAULT_PROJECT {
+		root = path.Join(engine.prefix, PROJECTS_KEY_ROOT, project)
+	}
+	if key != path.Join(root, "ingress", checksum) {
+		return "", "", false
+	}
+	return project, checksum, true
+}
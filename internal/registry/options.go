@@ -2,8 +2,12 @@ package registry
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Option func(*Engine) error
@@ -39,6 +43,87 @@ func WithBucketPrefix(prefix string) Option {
 	}
 }
 
+// WithStorageBackend selects the storage backend created by the engine, s3 (default) or filesystem
+func WithStorageBackend(backend string) Option {
+	return func(e *Engine) error {
+		backend = strings.ToLower(strings.TrimSpace(backend))
+		if !slices.Contains(Backends(), backend) {
+			return fmt.Errorf("unknown storage backend %q, expected one of %v", backend, Backends())
+		}
+		e.backend = backend
+		return nil
+	}
+}
+
+// WithStoragePath sets the directory of the filesystem storage backend
+func WithStoragePath(dir string) Option {
+	return func(e *Engine) error {
+		if dir == "" {
+			return fmt.Errorf("storage path value required")
+		}
+		e.storagePath = strings.TrimSpace(dir)
+		return nil
+	}
+}
+
+// WithStorageURL sets the public api url filesystem storage urls point at
+func WithStorageURL(url string) Option {
+	return func(e *Engine) error {
+		if url == "" {
+			return fmt.Errorf("storage url value required")
+		}
+		e.storageURL = strings.TrimSpace(url)
+		return nil
+	}
+}
+
+// WithStorageSigningKey sets the key signing filesystem storage urls, replicas must share it
+func WithStorageSigningKey(key string) Option {
+	return func(e *Engine) error {
+		if key == "" {
+			return fmt.Errorf("storage signing key value required")
+		}
+		e.storageKey = Secret(key)
+		return nil
+	}
+}
+
+// WithStorageMaxObjectSize caps filesystem storage uploads of unknown size, e.g. to unsized signed urls,
+// by default at MAX_COPY_SIZE, the largest object promote copies
+func WithStorageMaxObjectSize(size int64) Option {
+	return func(e *Engine) error {
+		if size <= 0 {
+			return fmt.Errorf("storage max object size must be positive")
+		}
+		e.storageMaxSize = size
+		return nil
+	}
+}
+
+// WithStorage uses the given storage instead of creating one, mostly for tests and embedding
+func WithStorage(store Storage) Option {
+	return func(e *Engine) error {
+		if store == nil {
+			return fmt.Errorf("storage value required")
+		}
+		e.store = store
+		return nil
+	}
+}
+
+// WithDatabaseDriver selects the registry database, postgres (default) or sqlite.
+// With sqlite the database name is the file path, :memory: keeps it in memory.
+func WithDatabaseDriver(driver string) Option {
+	return func(e *Engine) error {
+		driver = strings.ToLower(strings.TrimSpace(driver))
+		if !slices.Contains(DatabaseDrivers(), driver) {
+			return fmt.Errorf("unknown database driver %q, expected one of %v", driver, DatabaseDrivers())
+		}
+		e.databaseDriver = driver
+		return nil
+	}
+}
+
 func WithDatabaseEndpoint(endpoint string) Option {
 	return func(e *Engine) error {
 		if endpoint == "" {
@@ -92,3 +177,305 @@ func WithSslMode() Option {
 		return nil
 	}
 }
+
+// WithPreparedStatements prepares each distinct statement once per connection and reuses it, which saves
+// the parsing and planning of hot lookups such as assets by checksum and tags by name. The cache keeps up to
+// maxSize statements per replica, each for ttl after its last use. A maxSize of 0 is unbounded, a ttl of 0 is a day.
+// Replicas should restart after a migration changes the tables of cached statements.
+func WithPreparedStatements(maxSize int, ttl time.Duration) Option {
+	return func(e *Engine) error {
+		if maxSize < 0 {
+			return fmt.Errorf("statement cache size must not be negative")
+		}
+		if ttl < 0 {
+			return fmt.Errorf("statement cache ttl must not be negative")
+		}
+		e.prepareStmt, e.stmtCacheSize, e.stmtCacheTTL = true, maxSize, ttl
+		return nil
+	}
+}
+
+// WithSimpleProtocol sends postgres statements without preparing them, for connection poolers in
+// transaction mode that cannot keep prepared statements, e.g. pgbouncer. It excludes WithPreparedStatements.
+func WithSimpleProtocol() Option {
+	return func(e *Engine) error {
+		e.simpleProtocol = true
+		return nil
+	}
+}
+
+// WithStartupWait retries connecting to the database and storage for up to maxWait, waiting backoff
+// after the first failed attempt and doubling it after each one. A zero maxWait fails on the first error.
+func WithStartupWait(maxWait time.Duration, backoff time.Duration) Option {
+	return func(e *Engine) error {
+		if maxWait < 0 {
+			return fmt.Errorf("startup wait must not be negative")
+		}
+		if backoff <= 0 {
+			return fmt.Errorf("startup backoff must be positive")
+		}
+		e.startupWait, e.startupBackoff = maxWait, backoff
+		return nil
+	}
+}
+
+// WithStorageBreaker fails storage calls fast for cooldown once the store failed threshold times in a row,
+// a zero threshold disables the breaker
+func WithStorageBreaker(threshold int, cooldown time.Duration) Option {
+	return func(e *Engine) error {
+		if threshold < 0 {
+			return fmt.Errorf("storage breaker threshold must not be negative")
+		}
+		if threshold > 0 && cooldown <= 0 {
+			return fmt.Errorf("storage breaker cooldown must be positive")
+		}
+		e.breakerThreshold, e.breakerCooldown = threshold, cooldown
+		return nil
+	}
+}
+
+func WithPresignTTL(ttl time.Duration) Option {
+	return func(e *Engine) error {
+		if ttl <= 0 {
+			return fmt.Errorf("presign ttl must be positive")
+		}
+		e.presignTTL = ttl
+		return nil
+	}
+}
+
+// WithPresignBounds limits the expiry clients may request for presigned urls
+func WithPresignBounds(minTTL time.Duration, maxTTL time.Duration) Option {
+	return func(e *Engine) error {
+		if minTTL <= 0 || maxTTL <= 0 {
+			return fmt.Errorf("presign ttl bounds must be positive")
+		}
+		if minTTL > maxTTL {
+			return fmt.Errorf("presign min ttl %s exceeds max ttl %s", minTTL, maxTTL)
+		}
+		e.presignMin = minTTL
+		e.presignMax = maxTTL
+		return nil
+	}
+}
+
+// WithPresignSkew keeps presigned urls valid skew before they were signed and skew after their reported expiry,
+// so clients and stores whose clocks drift from ours don't see them as not yet valid or already expired
+func WithPresignSkew(skew time.Duration) Option {
+	return func(e *Engine) error {
+		if skew < 0 {
+			return fmt.Errorf("presign skew must not be negative")
+		}
+		e.presignSkew = skew
+		return nil
+	}
+}
+
+// WithListRate limits how many list requests per second ListObjects sends
+func WithListRate(requestsPerSecond int) Option {
+	return func(e *Engine) error {
+		if requestsPerSecond <= 0 {
+			return fmt.Errorf("list rate must be positive")
+		}
+		e.listRate = requestsPerSecond
+		return nil
+	}
+}
+
+// WithListPageSize sets how many keys ListObjects asks for per request
+func WithListPageSize(size int) Option {
+	return func(e *Engine) error {
+		if size <= 0 || size > 1000 {
+			return fmt.Errorf("list page size must be between 1 and 1000")
+		}
+		e.listPage = int32(size)
+		return nil
+	}
+}
+
+// WithAccessLogRetention sets how long access log partitions are kept, 0 keeps them forever
+func WithAccessLogRetention(retention time.Duration) Option {
+	return func(e *Engine) error {
+		if retention < 0 {
+			return fmt.Errorf("access log retention must not be negative")
+		}
+		e.accessLogRetention = retention
+		return nil
+	}
+}
+
+// WithEventRetention sets how long event partitions are kept, 0 keeps them forever
+func WithEventRetention(retention time.Duration) Option {
+	return func(e *Engine) error {
+		if retention < 0 {
+			return fmt.Errorf("event retention must not be negative")
+		}
+		e.eventRetention = retention
+		return nil
+	}
+}
+
+// WithAuditRetention sets how long audit log partitions are kept, 0 keeps them forever
+func WithAuditRetention(retention time.Duration) Option {
+	return func(e *Engine) error {
+		if retention < 0 {
+			return fmt.Errorf("audit retention must not be negative")
+		}
+		e.auditRetention = retention
+		return nil
+	}
+}
+
+// WithGarbageCollection sets how often the ingress garbage collector runs while serving, 0 disables it
+func WithGarbageCollection(interval time.Duration) Option {
+	return func(e *Engine) error {
+		if interval < 0 {
+			return fmt.Errorf("gc interval must not be negative")
+		}
+		e.gcInterval = interval
+		return nil
+	}
+}
+
+// WithUsageMetering sets how often the usage of the running month is metered while serving, 0 disables it
+func WithUsageMetering(interval time.Duration) Option {
+	return func(e *Engine) error {
+		if interval < 0 {
+			return fmt.Errorf("metering interval must not be negative")
+		}
+		e.meteringInterval = interval
+		return nil
+	}
+}
+
+// WithGCPendingTTL sets how long a pending asset keeps its ingress object before it is collected, 0 keeps it forever
+func WithGCPendingTTL(ttl time.Duration) Option {
+	return func(e *Engine) error {
+		if ttl < 0 {
+			return fmt.Errorf("gc pending ttl must not be negative")
+		}
+		e.gcPendingTTL = ttl
+		return nil
+	}
+}
+
+// WithDeletionGrace sets how long deletions of referenced assets wait for objections
+func WithDeletionGrace(grace time.Duration) Option {
+	return func(e *Engine) error {
+		if grace <= 0 {
+			return fmt.Errorf("deletion grace period must be positive")
+		}
+		e.deletionGrace = grace
+		return nil
+	}
+}
+
+// WithIdempotencyTTL sets how long the response to a request with an idempotency key is replayed to its retries
+func WithIdempotencyTTL(ttl time.Duration) Option {
+	return func(e *Engine) error {
+		if ttl <= 0 {
+			return fmt.Errorf("idempotency ttl must be positive")
+		}
+		e.idempotencyTTL = ttl
+		return nil
+	}
+}
+
+// WithEnricher runs an enricher over ready assets at interval while serving, 0 disables it
+func WithEnricher(interval time.Duration, enricher Enricher) Option {
+	return func(e *Engine) error {
+		if interval < 0 {
+			return fmt.Errorf("%s enrichment interval must not be negative", enricher.Name())
+		}
+		if err := ValidateEnricherName(enricher.Name()); err != nil {
+			return err
+		}
+		for _, s := range e.enrichers {
+			if s.enricher.Name() == enricher.Name() {
+				return fmt.Errorf("enricher %q registered twice", enricher.Name())
+			}
+		}
+
+		if interval > 0 {
+			e.enrichers = append(e.enrichers, scheduledEnricher{enricher: enricher, interval: interval})
+		}
+		return nil
+	}
+}
+
+// WithDigests sets how often ready assets get their digests under algorithms computed while serving, 0 disables it.
+// The algorithms must be registered, see RegisterDigestAlgorithm.
+func WithDigests(interval time.Duration, algorithms ...string) Option {
+	return func(e *Engine) error {
+		if interval < 0 {
+			return fmt.Errorf("digest interval must not be negative")
+		}
+		for _, algorithm := range algorithms {
+			if _, err := newDigestHash(algorithm); err != nil {
+				return err
+			}
+		}
+
+		e.digestAlgorithms, e.digestInterval = algorithms, interval
+		return nil
+	}
+}
+
+// WithMediaExtraction sets how often ready media assets get their metadata extracted while serving, 0 disables it
+func WithMediaExtraction(interval time.Duration, extractors ...MediaExtractor) Option {
+	if len(extractors) == 0 {
+		interval = 0
+	}
+	return WithEnricher(interval, MediaEnricher{Extractors: extractors})
+}
+
+// WithPreviews sets how often ready images get a preview of at most size pixels per side while serving, 0 disables it
+func WithPreviews(interval time.Duration, size int) Option {
+	return func(e *Engine) error {
+		if size <= 0 {
+			return fmt.Errorf("preview size must be positive")
+		}
+		return WithEnricher(interval, &PreviewEnricher{engine: e, size: size})(e)
+	}
+}
+
+// WithContentIndexing sets how often ready document assets get their text indexed while serving, 0 disables it
+func WithContentIndexing(interval time.Duration, extractors ...TextExtractor) Option {
+	if len(extractors) == 0 {
+		interval = 0
+	}
+	return WithEnricher(interval, ContentEnricher{Extractors: extractors})
+}
+
+// WithMetricsRegistry registers the engine metrics with reg instead of a registry of its own,
+// see Engine.MetricsRegistry
+func WithMetricsRegistry(reg prometheus.Registerer) Option {
+	return func(e *Engine) error {
+		if reg == nil {
+			return fmt.Errorf("metrics registry required")
+		}
+		e.metricsRegistry = reg
+		return nil
+	}
+}
+
+// WithSlowQueryPlans logs the plan of list and search queries running longer than threshold at warn level,
+// 0 disables it
+func WithSlowQueryPlans(threshold time.Duration) Option {
+	return func(e *Engine) error {
+		if threshold < 0 {
+			return fmt.Errorf("slow query threshold must not be negative")
+		}
+		e.slowQueryThreshold = threshold
+		return nil
+	}
+}
+
+// WithTracing records spans of traced requests for database statements, S3 calls and presigning,
+// see Engine.Tracing
+func WithTracing(provider trace.TracerProvider) Option {
+	return func(e *Engine) error {
+		e.tracing = provider
+		return nil
+	}
+}
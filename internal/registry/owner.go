@@ -0,0 +1,76 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+)
+
+// ownerMaxLength matches the owner columns
+const ownerMaxLength = 200
+
+// ValidateOwner checks an owner, a principal such as alice@example.com or key:ci, or a team such as team:vision.
+// An empty owner means nobody claimed the object and is accepted.
+func ValidateOwner(owner string) error {
+	if len(owner) > ownerMaxLength {
+		return fmt.Errorf("%w: owner is longer than %d characters", ErrValidation, ownerMaxLength)
+	}
+	if strings.TrimSpace(owner) != owner || strings.ContainsFunc(owner, unicode.IsControl) {
+		return fmt.Errorf("%w: owner %q contains invalid characters", ErrValidation, owner)
+	}
+	return nil
+}
+
+// OwnerlessReport totals the assets nobody owns, deleted ones are left out
+type OwnerlessReport struct {
+	TotalAssets int64
+	TotalBytes  int64
+	ByState     []StatsGroup
+}
+
+// ReportOwnerlessAssets counts the assets without an owner by state
+func (engine *Engine) ReportOwnerlessAssets(ctx context.Context) (*OwnerlessReport, error) {
+	slog.Debug("Reporting ownerless assets")
+
+	report := &OwnerlessReport{}
+	err := engine.DatabaseClient.WithContext(ctx).Model(&Asset{}).
+		Select("state AS key, COUNT(*) AS count, COALESCE(SUM(size_bytes), 0) AS bytes").
+		Where("owner = '' OR owner IS NULL").
+		Where("state <> ?", StatusDeleted).
+		Group("state").
+		Order("state").
+		Scan(&report.ByState).Error
+	if err != nil {
+		return nil, fmt.Errorf("count ownerless assets: %w", err)
+	}
+
+	for _, group := range report.ByState {
+		report.TotalAssets += group.Count
+		report.TotalBytes += group.Bytes
+	}
+	return report, nil
+}
+
+// ListOwnerlessAssetRecords returns a page of the assets without an owner, ordered by id after cursor, deleted ones are left out
+func (engine *Engine) ListOwnerlessAssetRecords(cursor uint, limit uint) ([]*Asset, error) {
+	if limit == 0 || limit > SearchMaxLimit {
+		limit = SearchDefaultLimit
+	}
+
+	var assets []*Asset
+	err := engine.explainSlow(engine.DatabaseClient, "list ownerless assets").
+		Where("owner = '' OR owner IS NULL").
+		Where("state <> ? AND id > ?", StatusDeleted, cursor).
+		Order("id ASC").
+		Limit(int(limit)).
+		Find(&assets).Error
+	if err != nil {
+		return nil, fmt.Errorf("list ownerless assets: %w", err)
+	}
+	if err := engine.loadTags(assets); err != nil {
+		return nil, err
+	}
+	return assets, nil
+}
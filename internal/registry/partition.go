@@ -0,0 +1,379 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DEFAULT_PARTITIONS_AHEAD       = 3
+	DEFAULT_ACCESS_LOG_RETENTION   = 365 * 24 * time.Hour
+	DEFAULT_EVENT_RETENTION        = 90 * 24 * time.Hour
+	DEFAULT_PARTITION_JOB_INTERVAL = 6 * time.Hour
+)
+
+// PartitionPeriod is the time span covered by one partition
+type PartitionPeriod string
+
+const (
+	PartitionDaily   PartitionPeriod = "daily"
+	PartitionMonthly PartitionPeriod = "monthly"
+)
+
+// start truncates t to the beginning of its period
+func (p PartitionPeriod) start(t time.Time) time.Time {
+	t = t.UTC()
+	if p == PartitionDaily {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// next returns the start of the following period
+func (p PartitionPeriod) next(start time.Time) time.Time {
+	if p == PartitionDaily {
+		return start.AddDate(0, 0, 1)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+func (p PartitionPeriod) layout() string {
+	if p == PartitionDaily {
+		return "20060102"
+	}
+	return "200601"
+}
+
+// PartitionedTable describes an append only table range partitioned by time.
+// Partitions are created ahead of time and dropped once they leave the retention window.
+type PartitionedTable struct {
+	DDL       string
+	Table     string
+	Column    string
+	Period    PartitionPeriod
+	Ahead     int
+	Retention time.Duration
+}
+
+func (pt PartitionedTable) partitionName(start time.Time) string {
+	return fmt.Sprintf("%s_p%s", pt.Table, start.Format(pt.Period.layout()))
+}
+
+// partitionStart parses the period start encoded in a partition name
+func (pt PartitionedTable) partitionStart(name string) (time.Time, bool) {
+	suffix, ok := strings.CutPrefix(name, pt.Table+"_p")
+	if !ok {
+		return time.Time{}, false
+	}
+
+	start, err := time.ParseInLocation(pt.Period.layout(), suffix, time.UTC)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
+// partitionedTables lists the tables maintained by the partition job
+func (engine *Engine) partitionedTables() []PartitionedTable {
+	return []PartitionedTable{
+		{
+			DDL:       accessLogsDDL,
+			Table:     "access_logs",
+			Column:    "created_at",
+			Period:    PartitionMonthly,
+			Ahead:     DEFAULT_PARTITIONS_AHEAD,
+			Retention: engine.accessLogRetention,
+		},
+		{
+			DDL:       eventsDDL,
+			Table:     "events",
+			Column:    "created_at",
+			Period:    PartitionMonthly,
+			Ahead:     DEFAULT_PARTITIONS_AHEAD,
+			Retention: engine.eventRetention,
+		},
+		{
+			DDL:       auditEntriesDDL,
+			Table:     "audit_entries",
+			Column:    "created_at",
+			Period:    PartitionMonthly,
+			Ahead:     DEFAULT_PARTITIONS_AHEAD,
+			Retention: engine.auditRetention,
+		},
+	}
+}
+
+// ensurePartitions creates partitions covering [from, now + ahead periods]
+func (engine *Engine) ensurePartitions(db *gorm.DB, pt PartitionedTable, from time.Time, now time.Time) error {
+	end := pt.Period.start(now)
+	for range pt.Ahead {
+		end = pt.Period.next(end)
+	}
+
+	for start := pt.Period.start(from); !start.After(end); start = pt.Period.next(start) {
+		name := pt.partitionName(start)
+		stmt := fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %q PARTITION OF %q FOR VALUES FROM ('%s') TO ('%s')`,
+			name, pt.Table, start.Format(time.RFC3339), pt.Period.next(start).Format(time.RFC3339),
+		)
+
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("create partition %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// expiredPartitions lists the partitions whose whole range is older than the retention
+func (engine *Engine) expiredPartitions(db *gorm.DB, pt PartitionedTable, now time.Time) ([]string, error) {
+	if pt.Retention <= 0 {
+		return nil, nil
+	}
+
+	var names []string
+	err := db.Raw(`
+		SELECT c.relname FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		WHERE p.relname = ?`, pt.Table).Scan(&names).Error
+	if err != nil {
+		return nil, fmt.Errorf("list %q partitions: %w", pt.Table, err)
+	}
+
+	cutoff := now.Add(-pt.Retention)
+	var expired []string
+	for _, name := range names {
+		start, ok := pt.partitionStart(name)
+		if !ok || pt.Period.next(start).After(cutoff) {
+			continue
+		}
+		expired = append(expired, name)
+	}
+	return expired, nil
+}
+
+// dropExpiredPartitions drops partitions whose whole range is older than the retention
+func (engine *Engine) dropExpiredPartitions(db *gorm.DB, pt PartitionedTable, now time.Time) ([]string, error) {
+	expired, err := engine.expiredPartitions(db, pt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	for _, name := range expired {
+		if err := db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %q`, name)).Error; err != nil {
+			return dropped, fmt.Errorf("drop partition %q: %w", name, err)
+		}
+		dropped = append(dropped, name)
+	}
+
+	return dropped, nil
+}
+
+// MaintainPartitions creates upcoming partitions and drops expired ones for every partitioned table
+func (engine *Engine) MaintainPartitions(ctx context.Context) error {
+	now := time.Now()
+	db := engine.DatabaseClient.WithContext(ctx)
+
+	if engine.isSQLite() {
+		return engine.pruneSQLiteLogTables(db, now)
+	}
+
+	for _, pt := range engine.partitionedTables() {
+		if err := engine.ensurePartitions(db, pt, now, now); err != nil {
+			return err
+		}
+
+		dropped, err := engine.dropExpiredPartitions(db, pt, now)
+		if err != nil {
+			return err
+		}
+		if len(dropped) > 0 {
+			slog.Info("Dropped expired partitions", "table", pt.Table, "partitions", dropped)
+		}
+	}
+
+	return nil
+}
+
+// PlanRetention lists the log partitions MaintainPartitions would drop with their size,
+// on sqlite the rows it would prune by table
+func (engine *Engine) PlanRetention(ctx context.Context) (*DryRunReport, error) {
+	now := time.Now()
+	db := engine.DatabaseClient.WithContext(ctx)
+	plan := &DryRunReport{Operation: "retention"}
+
+	if engine.isSQLite() {
+		return plan, engine.planSQLiteLogTables(db, now, plan)
+	}
+
+	for _, pt := range engine.partitionedTables() {
+		expired, err := engine.expiredPartitions(db, pt, now)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range expired {
+			var size int64
+			if err := db.Raw(`SELECT pg_total_relation_size(?::regclass)`, fmt.Sprintf("%q", name)).Scan(&size).Error; err != nil {
+				return nil, fmt.Errorf("size partition %q: %w", name, err)
+			}
+			plan.Add(Candidate{Kind: CandidatePartition, Name: name, Bytes: size, Reason: fmt.Sprintf("%s retention %s", pt.Table, pt.Retention)})
+		}
+	}
+	return plan, nil
+}
+
+// accessLogsDDL creates the partitioned access log table
+const accessLogsDDL = `
+	CREATE TABLE IF NOT EXISTS access_logs (
+		id         bigserial,
+		created_at timestamptz   NOT NULL,
+		principal  varchar(200)  NOT NULL,
+		project    varchar(63),
+		client_ip  varchar(100),
+		user_agent varchar(300),
+		checksum   varchar(64),
+		operation  varchar(10)   NOT NULL,
+		bucket     varchar(63),
+		key        varchar(1024),
+		expires_at timestamptz   NOT NULL,
+		expires_in bigint        NOT NULL,
+		PRIMARY KEY (id, created_at)
+	) PARTITION BY RANGE (created_at);
+
+	ALTER TABLE access_logs ADD COLUMN IF NOT EXISTS project varchar(63);
+
+	CREATE INDEX IF NOT EXISTS access_logs_created_at_idx ON access_logs (created_at);
+	CREATE INDEX IF NOT EXISTS access_logs_principal_idx ON access_logs (principal);
+	CREATE INDEX IF NOT EXISTS access_logs_project_idx ON access_logs (project);
+	CREATE INDEX IF NOT EXISTS access_logs_checksum_idx ON access_logs (checksum);
+	CREATE INDEX IF NOT EXISTS access_logs_operation_idx ON access_logs (operation);
+`
+
+// eventsDDL creates the partitioned events table
+const eventsDDL = `
+	CREATE TABLE IF NOT EXISTS events (
+		id         bigserial,
+		created_at timestamptz  NOT NULL,
+		kind       varchar(100) NOT NULL,
+		severity   varchar(10)  NOT NULL,
+		principal  varchar(200),
+		project    varchar(63),
+		message    text,
+		data       jsonb,
+		PRIMARY KEY (id, created_at)
+	) PARTITION BY RANGE (created_at);
+
+	CREATE INDEX IF NOT EXISTS events_created_at_idx ON events (created_at);
+	CREATE INDEX IF NOT EXISTS events_kind_idx ON events (kind);
+	CREATE INDEX IF NOT EXISTS events_principal_idx ON events (principal);
+`
+
+// auditEntriesDDL creates the partitioned audit log table
+const auditEntriesDDL = `
+	CREATE TABLE IF NOT EXISTS audit_entries (
+		id         bigserial,
+		created_at timestamptz  NOT NULL,
+		actor      varchar(200) NOT NULL,
+		project    varchar(63),
+		request_id varchar(100),
+		action     varchar(10)  NOT NULL,
+		entity     varchar(100) NOT NULL,
+		entity_id  varchar(100),
+		before     jsonb,
+		after      jsonb,
+		PRIMARY KEY (id, created_at)
+	) PARTITION BY RANGE (created_at);
+
+	CREATE INDEX IF NOT EXISTS audit_entries_created_at_idx ON audit_entries (created_at);
+	CREATE INDEX IF NOT EXISTS audit_entries_actor_idx ON audit_entries (actor);
+	CREATE INDEX IF NOT EXISTS audit_entries_request_id_idx ON audit_entries (request_id);
+	CREATE INDEX IF NOT EXISTS audit_entries_entity_idx ON audit_entries (entity, entity_id);
+`
+
+// createPartitionedTables creates partitioned tables, converting plain tables left by older versions
+func (engine *Engine) createPartitionedTables() error {
+	return engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		for _, pt := range engine.partitionedTables() {
+			var kind string
+			if err := tx.Raw(`SELECT relkind FROM pg_class WHERE relname = ? AND relkind IN ('r', 'p')`, pt.Table).Scan(&kind).Error; err != nil {
+				return fmt.Errorf("inspect %q: %w", pt.Table, err)
+			}
+
+			legacy := pt.Table + "_legacy"
+			if kind == "r" {
+				slog.Info("Converting table into a partitioned table", "table", pt.Table)
+				if err := tx.Exec(fmt.Sprintf(`ALTER TABLE %q RENAME TO %q`, pt.Table, legacy)).Error; err != nil {
+					return fmt.Errorf("rename %q: %w", pt.Table, err)
+				}
+			}
+
+			if err := tx.Exec(pt.DDL).Error; err != nil {
+				return fmt.Errorf("create %q: %w", pt.Table, err)
+			}
+
+			from := now
+			if kind == "r" {
+				var oldest *time.Time
+				if err := tx.Raw(fmt.Sprintf(`SELECT min(%q) FROM %q`, pt.Column, legacy)).Scan(&oldest).Error; err != nil {
+					return fmt.Errorf("inspect %q: %w", legacy, err)
+				}
+				if oldest != nil {
+					from = *oldest
+				}
+			}
+
+			if err := engine.ensurePartitions(tx, pt, from, now); err != nil {
+				return err
+			}
+
+			if kind == "r" {
+				if err := convertLegacyTable(tx, pt.Table, legacy); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// convertLegacyTable moves rows of a plain table into its partitioned replacement
+func convertLegacyTable(tx *gorm.DB, table string, legacy string) error {
+	var columns []string
+	err := tx.Raw(`
+		SELECT column_name FROM information_schema.columns
+		WHERE table_name = ? AND column_name IN (
+			SELECT column_name FROM information_schema.columns WHERE table_name = ?
+		) ORDER BY ordinal_position`, legacy, table).Scan(&columns).Error
+	if err != nil {
+		return fmt.Errorf("inspect %q columns: %w", legacy, err)
+	}
+
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = fmt.Sprintf("%q", c)
+	}
+	list := strings.Join(quoted, ", ")
+
+	steps := []string{
+		fmt.Sprintf(`INSERT INTO %q (%s) SELECT %s FROM %q`, table, list, list, legacy),
+		fmt.Sprintf(`SELECT setval(pg_get_serial_sequence('%s', 'id'), COALESCE((SELECT max(id) FROM %q), 1))`, table, table),
+		fmt.Sprintf(`DROP TABLE %q`, legacy),
+	}
+	for _, step := range steps {
+		if err := tx.Exec(step).Error; err != nil {
+			return fmt.Errorf("convert %q: %w", table, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Authorization roles, each role is allowed the actions of the ones before it
+const (
+	RoleReader      = "reader"
+	RoleContributor = "contributor"
+	RoleCurator     = "curator"
+	RoleAdmin       = "admin"
+)
+
+// Action is an operation the authorization roles are checked against
+type Action string
+
+const (
+	ActionRead           Action = "read"
+	ActionCreateAsset    Action = "asset.create"
+	ActionEditDataset    Action = "dataset.edit"
+	ActionTag            Action = "asset.tag"
+	ActionPublishDataset Action = "dataset.publish"
+	ActionDelete         Action = "asset.delete"
+	ActionAdmin          Action = "admin"
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// actionRoles is the least role allowed each action
+var actionRoles = map[Action]string{
+	ActionRead:           RoleReader,
+	ActionCreateAsset:    RoleContributor,
+	ActionEditDataset:    RoleContributor,
+	ActionTag:            RoleCurator,
+	ActionPublishDataset: RoleCurator,
+	ActionDelete:         RoleCurator,
+	ActionAdmin:          RoleAdmin,
+}
+
+// AuthorizationRoles lists the authorization roles, from the narrowest
+func AuthorizationRoles() []string {
+	return []string{RoleReader, RoleContributor, RoleCurator, RoleAdmin}
+}
+
+// RoleActions lists the actions a role is allowed
+func RoleActions(role string) []Action {
+	var actions []Action
+	for _, action := range []Action{ActionRead, ActionCreateAsset, ActionEditDataset, ActionTag, ActionPublishDataset, ActionDelete, ActionAdmin} {
+		if Allowed([]string{role}, action) {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// Allowed reports whether any of roles is allowed action, roles that are not authorization roles are ignored
+func Allowed(roles []string, action Action) bool {
+	required := slices.Index(AuthorizationRoles(), actionRoles[action])
+	if required < 0 {
+		return false
+	}
+	for _, role := range roles {
+		if i := slices.Index(AuthorizationRoles(), role); i >= required {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleAssignment grants an authorization role to a principal, e.g. key:<name> for api keys
+type RoleAssignment struct {
+	gorm.Model
+	Principal  string `gorm:"uniqueIndex;not null;size:200"`
+	Role       string `gorm:"not null;size:20"`
+	AssignedBy string `gorm:"size:200"`
+}
+
+// ValidateRoleAssignment checks the principal and role of an assignment
+func ValidateRoleAssignment(a *RoleAssignment) error {
+	if a.Principal == "" {
+		return fmt.Errorf("%w: role assignment needs a principal", ErrValidation)
+	}
+	if !slices.Contains(AuthorizationRoles(), a.Role) {
+		return fmt.Errorf("%w: unknown role %q, expected one of %v", ErrValidation, a.Role, AuthorizationRoles())
+	}
+	return nil
+}
+
+// AssignRoleRecord grants a role to a principal, replacing its previous role
+func (engine *Engine) AssignRoleRecord(a *RoleAssignment) error {
+	slog.Debug("Assigning role", "principal", a.Principal, "role", a.Role)
+
+	if err := ValidateRoleAssignment(a); err != nil {
+		return err
+	}
+
+	err := engine.DatabaseClient.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "principal"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "assigned_by", "updated_at"}),
+	}).Create(a).Error
+	if err != nil {
+		return fmt.Errorf("assign role %q to %q: %w", a.Role, a.Principal, err)
+	}
+	return nil
+}
+
+// GetRoleAssignmentRecord returns the role assignment of a principal
+func (engine *Engine) GetRoleAssignmentRecord(principal string) (*RoleAssignment, error) {
+	var a RoleAssignment
+	if err := engine.DatabaseClient.Where("principal = ?", principal).First(&a).Error; err != nil {
+		return nil, fmt.Errorf("get role assignment %q: %w", principal, err)
+	}
+	return &a, nil
+}
+
+// ListRoleAssignmentRecords returns every role assignment ordered by principal
+func (engine *Engine) ListRoleAssignmentRecords() ([]*RoleAssignment, error) {
+	var assignments []*RoleAssignment
+	if err := engine.DatabaseClient.Order("principal ASC").Find(&assignments).Error; err != nil {
+		return nil, fmt.Errorf("list role assignments: %w", err)
+	}
+	return assignments, nil
+}
+
+// DeleteRoleAssignmentRecord removes the role of a principal
+func (engine *Engine) DeleteRoleAssignmentRecord(a *RoleAssignment) error {
+	slog.Debug("Removing role assignment", "principal", a.Principal, "role", a.Role)
+
+	if err := engine.DatabaseClient.Unscoped().Delete(a).Error; err != nil {
+		return fmt.Errorf("delete role assignment %q: %w", a.Principal, err)
+	}
+	return nil
+}
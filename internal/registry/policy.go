@@ -0,0 +1,187 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+var (
+	ErrContentRejected = errors.New("content rejected by policy")
+	ErrObjectTooLarge  = errors.New("object exceeds the maximum size")
+)
+
+// ObjectTooLargeError reports an asset bigger than its project allows
+type ObjectTooLargeError struct {
+	Project string
+	Size    int64
+	Limit   int64
+}
+
+func (e *ObjectTooLargeError) Error() string {
+	return fmt.Sprintf("%s: %d bytes exceeds %d bytes allowed in project %q", ErrObjectTooLarge, e.Size, e.Limit, e.Project)
+}
+
+func (e *ObjectTooLargeError) Unwrap() error {
+	return ErrObjectTooLarge
+}
+
+// ContentPolicy restricts which MIME types and file extensions a project accepts.
+// Deny entries always win, non empty allow lists reject anything they don't match.
+// MIME entries may end with a wildcard subtype, e.g. "image/*".
+// MaxSizeBytes caps the object size, zero means unlimited.
+// AutoTag applies suggested tags to new assets instead of only returning them.
+type ContentPolicy struct {
+	gorm.Model
+	Project         string                      `gorm:"uniqueIndex;not null;size:63"`
+	AllowMimeTypes  datatypes.JSONSlice[string] `gorm:"type:jsonb"`
+	DenyMimeTypes   datatypes.JSONSlice[string] `gorm:"type:jsonb"`
+	AllowExtensions datatypes.JSONSlice[string] `gorm:"type:jsonb"`
+	DenyExtensions  datatypes.JSONSlice[string] `gorm:"type:jsonb"`
+	MaxSizeBytes    int64                       `gorm:"not null;default:0;check:max_size_bytes >= 0"`
+	AutoTag         bool                        `gorm:"not null;default:false"`
+}
+
+// Normalize lowercases entries and gives extensions a leading dot
+func (p *ContentPolicy) Normalize() {
+	p.Project = NormalizeString(p.Project)
+
+	mimes := func(values []string) datatypes.JSONSlice[string] {
+		out := make(datatypes.JSONSlice[string], 0, len(values))
+		for _, v := range values {
+			if v = NormalizeString(v); v != "" {
+				out = append(out, v)
+			}
+		}
+		return out
+	}
+	exts := func(values []string) datatypes.JSONSlice[string] {
+		out := make(datatypes.JSONSlice[string], 0, len(values))
+		for _, v := range values {
+			if v = NormalizeString(v); v != "" {
+				out = append(out, "."+strings.TrimPrefix(v, "."))
+			}
+		}
+		return out
+	}
+
+	p.AllowMimeTypes = mimes(p.AllowMimeTypes)
+	p.DenyMimeTypes = mimes(p.DenyMimeTypes)
+	p.AllowExtensions = exts(p.AllowExtensions)
+	p.DenyExtensions = exts(p.DenyExtensions)
+}
+
+func matchMimeType(pattern string, mimeType string) bool {
+	if base, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(mimeType, base+"/")
+	}
+	return pattern == mimeType
+}
+
+func matchAny(values []string, match func(string) bool) bool {
+	for _, v := range values {
+		if match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check tells whether content with the given MIME type and file name is accepted.
+// A nil policy accepts everything.
+func (p *ContentPolicy) Check(mimeType string, name string) error {
+	if p == nil {
+		return nil
+	}
+
+	mimeType = NormalizeString(strings.SplitN(mimeType, ";", 2)[0])
+	ext := strings.ToLower(path.Ext(name))
+
+	isMime := func(pattern string) bool { return matchMimeType(pattern, mimeType) }
+	isExt := func(e string) bool { return e == ext }
+
+	switch {
+	case mimeType != "" && matchAny(p.DenyMimeTypes, isMime):
+		return fmt.Errorf("%w: mime type %q is denied in project %q", ErrContentRejected, mimeType, p.Project)
+
+	case ext != "" && matchAny(p.DenyExtensions, isExt):
+		return fmt.Errorf("%w: extension %q is denied in project %q", ErrContentRejected, ext, p.Project)
+
+	case len(p.AllowMimeTypes) > 0 && mimeType == "":
+		return fmt.Errorf("%w: mime type is required in project %q", ErrContentRejected, p.Project)
+
+	case len(p.AllowMimeTypes) > 0 && !matchAny(p.AllowMimeTypes, isMime):
+		return fmt.Errorf("%w: mime type %q is not allowed in project %q", ErrContentRejected, mimeType, p.Project)
+
+	case len(p.AllowExtensions) > 0 && !matchAny(p.AllowExtensions, isExt):
+		return fmt.Errorf("%w: extension %q is not allowed in project %q", ErrContentRejected, ext, p.Project)
+	}
+
+	return nil
+}
+
+// AutoTags reports whether suggested tags are applied on asset creation.
+// A nil policy only suggests them.
+func (p *ContentPolicy) AutoTags() bool {
+	return p != nil && p.AutoTag
+}
+
+// CheckSize tells whether an object of the given size is accepted.
+// A nil policy accepts any size.
+func (p *ContentPolicy) CheckSize(size int64) error {
+	if p == nil || p.MaxSizeBytes <= 0 || size <= p.MaxSizeBytes {
+		return nil
+	}
+	return &ObjectTooLargeError{Project: p.Project, Size: size, Limit: p.MaxSizeBytes}
+}
+
+// GetContentPolicyRecord returns the project content policy, nil when the project has none
+func (engine *Engine) GetContentPolicyRecord(project string) (*ContentPolicy, error) {
+	var policy ContentPolicy
+	err := engine.DatabaseClient.Where("project = ?", NormalizeString(project)).First(&policy).Error
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get content policy %q: %w", project, err)
+	}
+	return &policy, nil
+}
+
+// SaveContentPolicyRecord creates or replaces the project content policy
+func (engine *Engine) SaveContentPolicyRecord(policy *ContentPolicy) error {
+	policy.Normalize()
+	slog.Debug("Saving content policy", "project", policy.Project)
+
+	existing, err := engine.GetContentPolicyRecord(policy.Project)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		policy.ID = existing.ID
+		policy.CreatedAt = existing.CreatedAt
+	}
+
+	if err := engine.DatabaseClient.Save(policy).Error; err != nil {
+		return fmt.Errorf("save content policy %q: %w", policy.Project, err)
+	}
+	return nil
+}
+
+// DeleteContentPolicyRecord removes the project content policy, accepting all content again
+func (engine *Engine) DeleteContentPolicyRecord(project string) error {
+	slog.Debug("Deleting content policy", "project", project)
+
+	err := engine.DatabaseClient.Unscoped().Where("project = ?", NormalizeString(project)).Delete(&ContentPolicy{}).Error
+	if err != nil {
+		return fmt.Errorf("delete content policy %q: %w", project, err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+)
+
+const (
+	// EXTRA_PREVIEW_KEY is the reserved Extra namespace describing the stored preview of an asset
+	EXTRA_PREVIEW_KEY = EXTRA_RESERVED_PREFIX + "preview"
+
+	DEFAULT_PREVIEW_INTERVAL = DEFAULT_ENRICH_INTERVAL
+	// DEFAULT_PREVIEW_SIZE is the longest side of a preview in pixels
+	DEFAULT_PREVIEW_SIZE = 320
+
+	PREVIEW_MIME_TYPE = "image/jpeg"
+	PREVIEW_QUALITY   = 80
+
+	// PREVIEW_MAX_BYTES and PREVIEW_MAX_PIXELS bound the images decoded in memory for a preview
+	PREVIEW_MAX_BYTES  = 64 << 20
+	PREVIEW_MAX_PIXELS = 50_000_000
+)
+
+// Preview describes the stored preview image of an asset
+type Preview struct {
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	MimeType  string `json:"mime_type"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// AssetPreview returns the preview of an asset, nil when it has none
+func AssetPreview(asset *Asset) *Preview {
+	if len(asset.Extra) == 0 {
+		return nil
+	}
+
+	var extra struct {
+		Preview *Preview `json:"_preview"`
+	}
+	if err := json.Unmarshal(asset.Extra, &extra); err != nil {
+		return nil
+	}
+	return extra.Preview
+}
+
+// PreviewEnricher stores a downscaled jpeg of gif, jpeg and png images next to the curated objects
+type PreviewEnricher struct {
+	engine *Engine
+	size   int
+}
+
+func (p *PreviewEnricher) Name() string {
+	return "preview"
+}
+
+func (p *PreviewEnricher) MimeTypes() []string {
+	return []string{"image/gif", "image/jpeg", "image/png"}
+}
+
+func (p *PreviewEnricher) Enrich(ctx context.Context, asset *Asset, r io.Reader) (*Enrichment, error) {
+	if asset.SizeBytes > PREVIEW_MAX_BYTES {
+		return nil, fmt.Errorf("image of %d bytes is too large for a preview", asset.SizeBytes)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(r, PREVIEW_MAX_BYTES))
+	if err != nil {
+		return nil, fmt.Errorf("read image: %w", err)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("decode image header: %w", err)
+	}
+	if config.Width*config.Height > PREVIEW_MAX_PIXELS {
+		return nil, fmt.Errorf("image of %dx%d pixels is too large for a preview", config.Width, config.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+
+	thumb := downscale(img, p.size)
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: PREVIEW_QUALITY}); err != nil {
+		return nil, fmt.Errorf("encode preview: %w", err)
+	}
+
+	size := int64(buf.Len())
+	ns := p.engine.ForProject(ctx, asset.Project).defaultNamespace()
+	if err := ns.bucket.Put(ctx, ns.PreviewKey(asset.Checksum), &buf, size, PREVIEW_MIME_TYPE); err != nil {
+		return nil, err
+	}
+
+	bounds := thumb.Bounds()
+	return &Enrichment{Metadata: map[string]any{
+		"width":      bounds.Dx(),
+		"height":     bounds.Dy(),
+		"mime_type":  PREVIEW_MIME_TYPE,
+		"size_bytes": size,
+	}}, nil
+}
+
+// downscale fits img into a maxSide square by averaging the source pixels of every target pixel.
+// Transparency is flattened onto white since jpeg has no alpha.
+func downscale(img image.Image, maxSide int) image.Image {
+	src := img.Bounds()
+	sw, sh := src.Dx(), src.Dy()
+
+	dw, dh := sw, sh
+	if sw > maxSide || sh > maxSide {
+		if sw >= sh {
+			dw, dh = maxSide, max(1, sh*maxSide/sw)
+		} else {
+			dw, dh = max(1, sw*maxSide/sh), maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := range dh {
+		y0, y1 := src.Min.Y+y*sh/dh, src.Min.Y+max((y+1)*sh/dh, y*sh/dh+1)
+		for x := range dw {
+			x0, x1 := src.Min.X+x*sw/dw, src.Min.X+max((x+1)*sw/dw, x*sw/dw+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+
+			// colors are premultiplied, so adding the missing coverage composites onto white
+			white := 0xffff - a/n
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r/n + white),
+				G: uint16(g/n + white),
+				B: uint16(b/n + white),
+				A: 0xffff,
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,337 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// projectScopedTables hold records owned by a project, requests only see the rows of their own project
+var projectScopedTables = map[string]bool{
+	"assets":   true,
+	"tags":     true,
+	"datasets": true,
+}
+
+// Project is a tenant, it owns assets, tags and datasets and a storage namespace, see Namespace
+type Project struct {
+	gorm.Model
+	Name        string `gorm:"uniqueIndex;not null;size:63"`
+	Description string `gorm:"size:2000"`
+	CreatedBy   string `gorm:"size:200"`
+
+	// DefaultTags are attached to every asset created in the project, DefaultExtra is the Extra template
+	// they start from, see ProjectExtraPlaceholders
+	DefaultTags  datatypes.JSONSlice[string] `gorm:"type:jsonb"`
+	DefaultExtra datatypes.JSON              `gorm:"type:jsonb"`
+
+	// QuotaBytes and QuotaObjects bound the ready assets of the project, zero means unlimited, see CheckQuota
+	QuotaBytes   int64 `gorm:"not null;default:0;check:quota_bytes >= 0"`
+	QuotaObjects int64 `gorm:"not null;default:0;check:quota_objects >= 0"`
+}
+
+// ProjectExtraPlaceholders lists the placeholders expanded in the string values of a project DefaultExtra
+func ProjectExtraPlaceholders() []string {
+	return []string{"{{project}}", "{{checksum}}", "{{display}}", "{{mime_type}}", "{{date}}"}
+}
+
+// ValidateProject checks the name of a project, it becomes part of storage keys
+func ValidateProject(p *Project) error {
+	p.Name = NormalizeString(p.Name)
+	if !projectNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("%w: %w: %q", ErrValidation, ErrInvalidProject, p.Name)
+	}
+	return nil
+}
+
+type projectKey struct{}
+
+// WithProject scopes the registry reads and writes made with ctx to project, see Engine.WithContext
+func WithProject(ctx context.Context, project string) context.Context {
+	return context.WithValue(ctx, projectKey{}, NormalizeString(project))
+}
+
+// ProjectFrom returns the project attached to ctx, empty when the caller is not scoped, e.g. background jobs
+func ProjectFrom(ctx context.Context) string {
+	project, _ := ctx.Value(projectKey{}).(string)
+	return project
+}
+
+// ForProject returns an engine scoped to project, e.g. to reach the objects of a record from a background job
+func (engine *Engine) ForProject(ctx context.Context, project string) *Engine {
+	return engine.WithContext(WithProject(ctx, project))
+}
+
+// registerProjectCallbacks restricts the queries, updates and deletes of project scoped tables
+// to the project of the statement context. Raw statements, joined tables and aliased tables are not scoped,
+// they add the condition themselves with scopeAlias, or in raw SQL by comparing <alias>.project to ProjectFrom.
+func registerProjectCallbacks(db *gorm.DB) error {
+	cb := db.Callback()
+	err := errors.Join(
+		cb.Query().Before("gorm:query").Register("project:query", scopeProject),
+		cb.Row().Before("gorm:row").Register("project:row", scopeProject),
+		cb.Update().Before("gorm:update").Register("project:update", scopeProject),
+		cb.Delete().Before("gorm:delete").Register("project:delete", scopeProject),
+	)
+	if err != nil {
+		return fmt.Errorf("register project callbacks: %w", err)
+	}
+	return nil
+}
+
+// scopeProject adds the project condition when the statement table is project scoped.
+// Queries through an alias, e.g. Table("assets AS a"), or over a join table such as asset_tags are left alone.
+func scopeProject(db *gorm.DB) {
+	project := ProjectFrom(db.Statement.Context)
+	if project == "" || db.Error != nil || !projectScopedTables[db.Statement.Table] {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: db.Statement.Table, Name: "project"}, Value: project},
+	}})
+}
+
+// scopeAlias restricts a project scoped table reached through alias, e.g. a joined "assets", to the project of ctx
+func scopeAlias(db *gorm.DB, ctx context.Context, alias string) *gorm.DB {
+	project := ProjectFrom(ctx)
+	if project == "" {
+		return db
+	}
+	return db.Where(clause.Eq{Column: clause.Column{Table: alias, Name: "project"}, Value: project})
+}
+
+// CreateProjectRecord registers a project
+func (engine *Engine) CreateProjectRecord(p *Project) error {
+	slog.Debug("Creating project", "project", p.Name)
+
+	if err := ValidateProject(p); err != nil {
+		return err
+	}
+	if err := engine.DatabaseClient.Create(p).Error; err != nil {
+		return fmt.Errorf("create project %q: %w", p.Name, err)
+	}
+	return nil
+}
+
+// GetProjectRecord returns a project by name
+func (engine *Engine) GetProjectRecord(name string) (*Project, error) {
+	var p Project
+	if err := engine.DatabaseClient.Where("name = ?", NormalizeString(name)).First(&p).Error; err != nil {
+		return nil, fmt.Errorf("get project %q: %w", name, err)
+	}
+	return &p, nil
+}
+
+// ListProjectRecords returns every project ordered by name
+func (engine *Engine) ListProjectRecords() ([]*Project, error) {
+	var projects []*Project
+	if err := engine.DatabaseClient.Order("name ASC").Find(&projects).Error; err != nil {
+		return nil, fmt.Errorf("list projects: %w", err)
+	}
+	return projects, nil
+}
+
+// ensureDefaultProject registers the default project, which owns every record created before projects existed
+func (engine *Engine) ensureDefaultProject() error {
+	err := engine.DatabaseClient.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&Project{Name: DEFAULT_PROJECT, Description: "Records created without a project"}).Error
+	if err != nil {
+		return fmt.Errorf("create default project: %w", err)
+	}
+	return nil
+}
+
+// setRecordProject assigns a new record without a project to the project of the statement
+func setRecordProject(tx *gorm.DB, project *string) {
+	if *project != "" {
+		return
+	}
+	if *project = ProjectFrom(tx.Statement.Context); *project == "" {
+		*project = DEFAULT_PROJECT
+	}
+}
+
+// SetProjectDefaultsRecord replaces the default tags and Extra template of a project.
+// The tags must exist in the project and must not exclude each other.
+func (engine *Engine) SetProjectDefaultsRecord(p *Project, tags []string, extra map[string]any) error {
+	slog.Debug("Setting project defaults", "project", p.Name, "tags", tags)
+
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeString(tag)
+		if !ValidateTagName(tag) {
+			return fmt.Errorf("%w: invalid tag name %q", ErrValidation, tag)
+		}
+		if !slices.Contains(names, tag) {
+			names = append(names, tag)
+		}
+	}
+
+	for key := range extra {
+		if IsReservedExtraKey(key) {
+			return fmt.Errorf("%w: %w: %q", ErrValidation, ErrReservedExtraKey, key)
+		}
+	}
+
+	records, err := engine.projectTags(p.Name, names)
+	if err != nil {
+		return err
+	}
+	if len(records) != len(names) {
+		for _, name := range names {
+			if !slices.ContainsFunc(records, func(t *Tag) bool { return t.Name == name }) {
+				return fmt.Errorf("%w: tag %q does not exist in project %q", ErrValidation, name, p.Name)
+			}
+		}
+	}
+	if err := engine.checkExclusiveTags(records); err != nil {
+		return err
+	}
+
+	var template datatypes.JSON
+	if len(extra) > 0 {
+		raw, err := json.Marshal(extra)
+		if err != nil {
+			return fmt.Errorf("encode project %q default extra: %w", p.Name, err)
+		}
+		template = datatypes.JSON(raw)
+	}
+
+	err = engine.DatabaseClient.Model(p).Updates(map[string]any{
+		"default_tags":  datatypes.JSONSlice[string](names),
+		"default_extra": template,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("set project %q defaults: %w", p.Name, err)
+	}
+
+	p.DefaultTags = names
+	p.DefaultExtra = template
+	return nil
+}
+
+// projectTags returns the tags of a project among names
+func (engine *Engine) projectTags(project string, names []string) ([]*Tag, error) {
+	var tags []*Tag
+	if len(names) == 0 {
+		return tags, nil
+	}
+
+	err := engine.DatabaseClient.
+		WithContext(WithProject(engine.DatabaseClient.Statement.Context, project)).
+		Where("name IN ?", names).
+		Find(&tags).Error
+	if err != nil {
+		return nil, fmt.Errorf("get project %q tags: %w", project, err)
+	}
+	return tags, nil
+}
+
+// applyProjectDefaults adds the default tags of their project to new assets and starts their Extra
+// from its template, the keys an asset sets itself win over the template
+func (engine *Engine) applyProjectDefaults(assets ...*Asset) error {
+	projects := make(map[string]*Project)
+	defaults := make(map[string][]*Tag)
+
+	for _, asset := range assets {
+		project := asset.Project
+		if project == "" {
+			project = ProjectFrom(engine.DatabaseClient.Statement.Context)
+		}
+		if project == "" {
+			project = DEFAULT_PROJECT
+		}
+
+		p, ok := projects[project]
+		if !ok {
+			var found []*Project
+			if err := engine.DatabaseClient.Where("name = ?", project).Limit(1).Find(&found).Error; err != nil {
+				return fmt.Errorf("get project %q: %w", project, err)
+			}
+			if len(found) > 0 {
+				p = found[0]
+				tags, err := engine.projectTags(project, p.DefaultTags)
+				if err != nil {
+					return err
+				}
+				defaults[project] = tags
+			}
+			projects[project] = p
+		}
+		if p == nil {
+			continue
+		}
+
+		for _, tag := range defaults[project] {
+			if !slices.ContainsFunc(asset.Tags, func(t Tag) bool { return t.ID == tag.ID }) {
+				asset.Tags = append(asset.Tags, *tag)
+			}
+		}
+
+		if len(p.DefaultExtra) == 0 {
+			continue
+		}
+		extra, err := p.renderDefaultExtra(asset)
+		if err != nil {
+			return err
+		}
+		var own map[string]any
+		if len(asset.Extra) > 0 {
+			if err := json.Unmarshal(asset.Extra, &own); err != nil {
+				return fmt.Errorf("decode asset %q extra: %w", asset.Checksum, err)
+			}
+		}
+
+		raw, err := json.Marshal(mergePatch(extra, own))
+		if err != nil {
+			return fmt.Errorf("encode asset %q extra: %w", asset.Checksum, err)
+		}
+		asset.Extra = datatypes.JSON(raw)
+	}
+	return nil
+}
+
+// renderDefaultExtra expands the placeholders of the project Extra template for an asset
+func (p *Project) renderDefaultExtra(asset *Asset) (map[string]any, error) {
+	template := map[string]any{}
+	if err := json.Unmarshal(p.DefaultExtra, &template); err != nil {
+		return nil, fmt.Errorf("decode project %q default extra: %w", p.Name, err)
+	}
+
+	replacer := strings.NewReplacer(
+		"{{project}}", p.Name,
+		"{{checksum}}", NormalizeString(asset.Checksum),
+		"{{display}}", asset.Display,
+		"{{mime_type}}", NormalizeString(asset.MimeType),
+		"{{date}}", time.Now().UTC().Format(time.DateOnly),
+	)
+	expandPlaceholders(template, replacer)
+	return template, nil
+}
+
+func expandPlaceholders(value any, replacer *strings.Replacer) any {
+	switch v := value.(type) {
+	case string:
+		return replacer.Replace(v)
+	case map[string]any:
+		for key, nested := range v {
+			v[key] = expandPlaceholders(nested, replacer)
+		}
+	case []any:
+		for i, nested := range v {
+			v[i] = expandPlaceholders(nested, replacer)
+		}
+	}
+	return value
+}
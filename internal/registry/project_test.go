@@ -0,0 +1,88 @@
+package registry_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/webtest"
+)
+
+// seedProject creates a project with a ready asset named display, tagged shared, whose content is text
+func seedProject(t *testing.T, engine *registry.Engine, project string, checksum string, display string, text string) {
+	t.Helper()
+
+	if err := engine.CreateProjectRecord(&registry.Project{Name: project}); err != nil {
+		t.Fatal(err)
+	}
+
+	asset := &registry.Asset{Project: project, Checksum: checksum, Display: display, SizeBytes: 10, State: registry.StatusReady}
+	if err := engine.CreateAssetRecord(asset); err != nil {
+		t.Fatal(err)
+	}
+	tag := &registry.Tag{Project: project, Name: "shared"}
+	if err := engine.CreateTagRecord(tag); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := engine.AttachTagToChecksums(tag, []string{checksum}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.DatabaseClient.Create(&registry.AssetContent{AssetID: asset.ID, Text: text}).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProjectScopedAggregates(t *testing.T) {
+	h := webtest.New(t, nil)
+	seedProject(t, h.Engine, "alpha", strings.Repeat("a", 64), "photo alpha", "quarterly report")
+	seedProject(t, h.Engine, "beta", strings.Repeat("b", 64), "photo beta", "quarterly report")
+
+	for _, tt := range []struct {
+		project string
+		want    string
+	}{
+		{project: "alpha", want: strings.Repeat("a", 64)},
+		{project: "beta", want: strings.Repeat("b", 64)},
+	} {
+		t.Run(tt.project, func(t *testing.T) {
+			ctx := registry.WithProject(context.Background(), tt.project)
+
+			stats, err := h.Engine.Stats(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(stats.ByTag) != 1 || stats.ByTag[0].Count != 1 || stats.ByTag[0].Bytes != 10 {
+				t.Errorf("ByTag = %+v, want shared with one asset of 10 bytes", stats.ByTag)
+			}
+
+			for _, s := range []struct {
+				name   string
+				query  string
+				search func(context.Context, string, uint) ([]*registry.AssetMatch, error)
+			}{
+				{name: "display", query: "photo", search: h.Engine.Search},
+				{name: "tag", query: "shared", search: h.Engine.Search},
+				{name: "content", query: "quarterly", search: h.Engine.SearchContent},
+			} {
+				// a limit of one fails when a hit of the other project takes the slot
+				matches, err := s.search(ctx, s.query, 1)
+				if err != nil {
+					t.Fatalf("%s search: %v", s.name, err)
+				}
+				if len(matches) != 1 || matches[0].Asset.Checksum != tt.want {
+					t.Errorf("%s search %q = %d matches, want only %s", s.name, s.query, len(matches), tt.want)
+				}
+			}
+		})
+	}
+
+	// unscoped callers, e.g. background jobs, see every project
+	stats, err := h.Engine.Stats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.ByTag) != 1 || stats.ByTag[0].Count != 2 {
+		t.Errorf("unscoped ByTag = %+v, want shared with two assets", stats.ByTag)
+	}
+}
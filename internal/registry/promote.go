@@ -0,0 +1,93 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+var (
+	ErrUploadNotFound   = registryerrors.New(registryerrors.ErrConflict, "uploaded object not found")
+	ErrChecksumMismatch = errors.New("uploaded object checksum mismatch")
+	ErrSizeMismatch     = errors.New("uploaded object size mismatch")
+	ErrAssetNotPending  = registryerrors.New(registryerrors.ErrConflict, "asset is not pending")
+)
+
+// MAX_COPY_SIZE is the largest object S3 copies in a single request
+const MAX_COPY_SIZE int64 = 5 << 30
+
+// PromoteAsset verifies the uploaded ingress object of a default project asset,
+// moves it to the curated key and marks the asset ready.
+func (engine *Engine) PromoteAsset(ctx context.Context, sha256 string) (*Asset, error) {
+	return engine.defaultNamespace().PromoteAsset(ctx, sha256)
+}
+
+// PromoteAsset verifies the uploaded ingress object of an asset, moves it to the curated key and marks the asset ready.
+// Promoting a ready asset is a no-op, so a finalize retried after a lost response succeeds.
+func (ns *Namespace) PromoteAsset(ctx context.Context, sha256 string) (*Asset, error) {
+	asset, err := ns.engine.GetAssetRecord(sha256)
+	if err != nil {
+		return nil, err
+	}
+
+	switch asset.State {
+	case StatusReady:
+		return asset, nil
+	case StatusPending:
+	default:
+		return nil, fmt.Errorf("%w: %s is %s", ErrAssetNotPending, asset.Checksum, asset.State)
+	}
+
+	ingress, curated := ns.IngressKey(asset.Checksum), ns.CuratedKey(asset.Checksum)
+	if err := ns.Check(ingress); err != nil {
+		return nil, err
+	}
+
+	info, err := ns.engine.statObject(ctx, ns.bucket, ingress)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUploadNotFound, ingress)
+	}
+
+	if err := verifyUpload(asset, info); err != nil {
+		return nil, err
+	}
+
+	if info.Size > MAX_COPY_SIZE {
+		return nil, fmt.Errorf("%w: %d bytes is above the %d bytes copy limit", ErrObjectTooLarge, info.Size, MAX_COPY_SIZE)
+	}
+
+	if err := ns.engine.copyObject(ctx, ns.bucket, ingress, curated); err != nil {
+		return nil, err
+	}
+
+	if err := ns.engine.CompleteAssetRecord(asset, info); err != nil {
+		return nil, err
+	}
+
+	// the asset is ready at this point, a leftover ingress copy only wastes space
+	if err := ns.engine.deleteObjects(ctx, ns.bucket, ingress); err != nil {
+		slog.Warn("failed to remove promoted ingress object", "key", ingress, "error", err)
+	}
+
+	slog.Debug("Promoted asset", "checksum", asset.Checksum, "size", info.Size, "project", ns.project)
+	return asset, nil
+}
+
+// verifyUpload compares a stored ingress object with its asset record
+func verifyUpload(asset *Asset, info *ObjectInfo) error {
+	if info.Checksum != asset.Checksum {
+		return fmt.Errorf("%w: expected %s, stored %q", ErrChecksumMismatch, asset.Checksum, info.Checksum)
+	}
+
+	if asset.SizeBytes > 0 && info.Size != asset.SizeBytes {
+		return fmt.Errorf("%w: declared %d bytes, stored %d bytes", ErrSizeMismatch, asset.SizeBytes, info.Size)
+	}
+
+	return nil
+}
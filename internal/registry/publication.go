@@ -0,0 +1,239 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm/clause"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+// VersionStatus is the publication stage of a dataset version
+type VersionStatus string
+
+const (
+	VersionDraft     VersionStatus = "draft"
+	VersionReview    VersionStatus = "review"
+	VersionPublished VersionStatus = "published"
+)
+
+const (
+	CHANNEL_LATEST     = "latest"
+	CHANNEL_PRODUCTION = "production"
+
+	// APPROVER_ANY is the role recorded when a dataset requires no specific approver roles
+	APPROVER_ANY = "any"
+)
+
+var (
+	ErrVersionStatus    = registryerrors.New(registryerrors.ErrConflict, "dataset version is not in the required status")
+	ErrApproverRole     = errors.New("approver lacks a required role")
+	ErrMissingApprovals = registryerrors.New(registryerrors.ErrConflict, "dataset version is missing approvals")
+)
+
+// MissingApprovalsError lists the roles that still have to approve a version
+type MissingApprovalsError struct {
+	Roles []string
+}
+
+func (e *MissingApprovalsError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrMissingApprovals, strings.Join(e.Roles, ", "))
+}
+
+func (e *MissingApprovalsError) Unwrap() error {
+	return ErrMissingApprovals
+}
+
+// Channels lists the channel names a dataset can point at
+func Channels() []string {
+	return []string{CHANNEL_LATEST, CHANNEL_PRODUCTION}
+}
+
+// RequiredRoles returns the roles that must approve a version of the dataset
+func (d *Dataset) RequiredRoles() []string {
+	if len(d.ApproverRoles) == 0 {
+		return []string{APPROVER_ANY}
+	}
+	return d.ApproverRoles
+}
+
+// ApproverRolesFor returns the required roles an approver holding roles can sign off
+func (d *Dataset) ApproverRolesFor(roles []string) []string {
+	if len(d.ApproverRoles) == 0 {
+		return []string{APPROVER_ANY}
+	}
+
+	var matched []string
+	for _, role := range d.ApproverRoles {
+		if slices.Contains(roles, role) {
+			matched = append(matched, role)
+		}
+	}
+	return matched
+}
+
+// MissingApprovals returns the required roles without an approval on the version
+func (dv *DatasetVersion) MissingApprovals(required []string) []string {
+	var missing []string
+	for _, role := range required {
+		approved := slices.ContainsFunc(dv.Approvals, func(a DatasetVersionApproval) bool {
+			return a.Role == role
+		})
+		if !approved {
+			missing = append(missing, role)
+		}
+	}
+	return missing
+}
+
+// SetDatasetApproverRolesRecord replaces the roles required to approve versions of a dataset
+func (engine *Engine) SetDatasetApproverRolesRecord(ds *Dataset, roles []string) error {
+	slog.Debug("Setting dataset approver roles", "dataset", ds.Name, "roles", roles)
+
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		role = NormalizeString(role)
+		if role != "" && !slices.Contains(normalized, role) {
+			normalized = append(normalized, role)
+		}
+	}
+
+	if err := engine.DatabaseClient.Model(ds).Update("approver_roles", datatypes.JSONSlice[string](normalized)).Error; err != nil {
+		return fmt.Errorf("set dataset %q approver roles: %w", ds.Name, err)
+	}
+
+	ds.ApproverRoles = normalized
+	return nil
+}
+
+// GetDatasetVersionRecord returns a dataset version with its dataset and approvals
+func (engine *Engine) GetDatasetVersionRecord(datasetName string, number int) (*DatasetVersion, error) {
+	slog.Debug("Getting dataset version", "dataset", datasetName, "version", number)
+
+	var dsv DatasetVersion
+	err := engine.DatabaseClient.
+		Joins("Dataset").
+		Preload("Approvals").
+		Where(`"Dataset".name = ? AND dataset_versions.number = ?`, NormalizeString(datasetName), number).
+		First(&dsv).Error
+	if err != nil {
+		return nil, fmt.Errorf("get dataset %q version %d: %w", datasetName, number, err)
+	}
+
+	return &dsv, nil
+}
+
+// TransitionDatasetVersionRecord moves a version from one status to another, with extra column updates.
+// The update only applies when the stored status still matches from, so concurrent transitions can't both win.
+func (engine *Engine) TransitionDatasetVersionRecord(dsv *DatasetVersion, from VersionStatus, to VersionStatus, updates map[string]any) error {
+	slog.Debug("Transitioning dataset version", "dataset", dsv.DatasetID, "version", dsv.Number, "from", from, "to", to)
+
+	values := map[string]any{"status": to}
+	for k, v := range updates {
+		values[k] = v
+	}
+
+	tx := engine.DatabaseClient.Model(&DatasetVersion{}).
+		Where("id = ? AND status = ?", dsv.ID, from).
+		Updates(values)
+	if tx.Error != nil {
+		return fmt.Errorf("transition dataset version %d: %w", dsv.Number, tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%w: version %d is not %s", ErrVersionStatus, dsv.Number, from)
+	}
+
+	dsv.Status = to
+	return nil
+}
+
+// CreateDatasetApprovalRecords adds approvals, repeated approvals by the same approver and role are ignored
+func (engine *Engine) CreateDatasetApprovalRecords(approvals ...*DatasetVersionApproval) error {
+	slog.Debug("Creating dataset version approvals", "total", len(approvals))
+	if len(approvals) == 0 {
+		return nil
+	}
+
+	err := engine.DatabaseClient.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(approvals).Error
+	if err != nil {
+		return fmt.Errorf("create dataset version approvals: %w", err)
+	}
+	return nil
+}
+
+// DeleteDatasetApprovalRecords removes every approval of a version, a resubmitted version is reviewed from scratch
+func (engine *Engine) DeleteDatasetApprovalRecords(dsv *DatasetVersion) error {
+	slog.Debug("Deleting dataset version approvals", "version", dsv.ID)
+
+	err := engine.DatabaseClient.Unscoped().
+		Where("dataset_version_id = ?", dsv.ID).
+		Delete(&DatasetVersionApproval{}).Error
+	if err != nil {
+		return fmt.Errorf("delete dataset version %d approvals: %w", dsv.Number, err)
+	}
+
+	dsv.Approvals = nil
+	return nil
+}
+
+// SetDatasetChannelRecord points a dataset channel at a version, only published versions are accepted
+func (engine *Engine) SetDatasetChannelRecord(dsv *DatasetVersion, name string, by string) (*DatasetChannel, error) {
+	slog.Debug("Setting dataset channel", "dataset", dsv.DatasetID, "channel", name, "version", dsv.Number)
+
+	name = NormalizeString(name)
+	if !slices.Contains(Channels(), name) {
+		return nil, fmt.Errorf("%w: unknown channel %q", ErrValidation, name)
+	}
+
+	if dsv.Status != VersionPublished {
+		return nil, fmt.Errorf("%w: channel %q needs a published version, version %d is %s", ErrVersionStatus, name, dsv.Number, dsv.Status)
+	}
+
+	channel := &DatasetChannel{
+		DatasetID:        dsv.DatasetID,
+		Name:             name,
+		DatasetVersionID: dsv.ID,
+		UpdatedBy:        by,
+	}
+
+	err := engine.DatabaseClient.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "dataset_id"}, {Name: "name"}},
+		DoUpdates: clause.Assignments(map[string]any{"dataset_version_id": dsv.ID, "updated_by": by, "updated_at": time.Now()}),
+	}).Create(channel).Error
+	if err != nil {
+		return nil, fmt.Errorf("set dataset channel %q: %w", name, err)
+	}
+
+	channel.DatasetVersion = *dsv
+	return channel, nil
+}
+
+// GetDatasetChannelRecord returns a dataset channel with the version it points at
+func (engine *Engine) GetDatasetChannelRecord(datasetName string, name string) (*DatasetChannel, error) {
+	slog.Debug("Getting dataset channel", "dataset", datasetName, "channel", name)
+
+	ds, err := engine.GetDatasetRecord(datasetName)
+	if err != nil {
+		return nil, err
+	}
+
+	var channel DatasetChannel
+	err = engine.DatabaseClient.
+		Preload("DatasetVersion").
+		Where("dataset_id = ? AND name = ?", ds.ID, NormalizeString(name)).
+		First(&channel).Error
+	if err != nil {
+		return nil, fmt.Errorf("get dataset %q channel %q: %w", datasetName, name, err)
+	}
+
+	channel.DatasetVersion.Dataset = *ds
+	return &channel, nil
+}
@@ -0,0 +1,275 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+const (
+	EventProjectQuota = "project.quota"
+
+	// QUOTA_WARNING_RATIO and QUOTA_CRITICAL_RATIO are the shares of a quota that warn before uploads are rejected
+	QUOTA_WARNING_RATIO  = 0.8
+	QUOTA_CRITICAL_RATIO = 0.9
+)
+
+var ErrQuotaExceeded = registryerrors.New(registryerrors.ErrQuotaExceeded, "project storage quota exceeded")
+
+// QuotaStatus tells how close a project is to its quota, see ProjectUsage.Status
+type QuotaStatus string
+
+const (
+	QuotaOK       QuotaStatus = "ok"
+	QuotaWarning  QuotaStatus = "warning"
+	QuotaCritical QuotaStatus = "critical"
+	QuotaExceeded QuotaStatus = "exceeded"
+)
+
+// rank orders statuses from ok to exceeded
+func (s QuotaStatus) rank() int {
+	return slices.Index([]QuotaStatus{QuotaOK, QuotaWarning, QuotaCritical, QuotaExceeded}, s)
+}
+
+// QuotaExceededError reports uploads that would take a project over its quota
+type QuotaExceededError struct {
+	Project string
+	Usage   *ProjectUsage
+	Bytes   int64
+	Objects int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	if e.Usage.QuotaBytes > 0 && e.Usage.StoredBytes+e.Bytes > e.Usage.QuotaBytes {
+		return fmt.Sprintf("%s: project %q stores %d bytes, %d more exceed the %d bytes allowed",
+			ErrQuotaExceeded, e.Project, e.Usage.StoredBytes, e.Bytes, e.Usage.QuotaBytes)
+	}
+	return fmt.Sprintf("%s: project %q stores %d objects, %d more exceed the %d objects allowed",
+		ErrQuotaExceeded, e.Project, e.Usage.StoredObjects, e.Objects, e.Usage.QuotaObjects)
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// ProjectUsage is the running total of the ready assets of a project, it changes when an asset
+// is finalized, deleted or restored. The quota fields are copied from the project, zero means unlimited.
+type ProjectUsage struct {
+	Project       string `gorm:"primaryKey;size:63"`
+	StoredBytes   int64  `gorm:"not null;default:0"`
+	StoredObjects int64  `gorm:"not null;default:0"`
+	UpdatedAt     time.Time
+
+	QuotaBytes   int64 `gorm:"-"`
+	QuotaObjects int64 `gorm:"-"`
+}
+
+// GetUsage returns the stored bytes and objects of a project with its quota
+func (engine *Engine) GetUsage(project string) (*ProjectUsage, error) {
+	p, err := engine.GetProjectRecord(project)
+	if err != nil {
+		return nil, err
+	}
+	return engine.projectUsage(p)
+}
+
+// projectUsage returns the usage of p with its quota, zero before anything was counted
+func (engine *Engine) projectUsage(p *Project) (*ProjectUsage, error) {
+	usage := ProjectUsage{Project: p.Name}
+	if err := engine.DatabaseClient.Where("project = ?", p.Name).Limit(1).Find(&usage).Error; err != nil {
+		return nil, fmt.Errorf("get project %q usage: %w", p.Name, err)
+	}
+
+	usage.QuotaBytes = p.QuotaBytes
+	usage.QuotaObjects = p.QuotaObjects
+	return &usage, nil
+}
+
+// Check tells whether the project may take objects more objects holding bytes more bytes.
+// Pending uploads are not counted, the quota only bounds what was finalized so far.
+func (u *ProjectUsage) Check(objects int64, bytes int64) error {
+	overBytes := u.QuotaBytes > 0 && u.StoredBytes+bytes > u.QuotaBytes
+	overObjects := u.QuotaObjects > 0 && u.StoredObjects+objects > u.QuotaObjects
+	if overBytes || overObjects {
+		return &QuotaExceededError{Project: u.Project, Usage: u, Bytes: bytes, Objects: objects}
+	}
+	return nil
+}
+
+// Status is the quota status of the fuller of the bytes and objects limits
+func (u *ProjectUsage) Status() QuotaStatus {
+	ratio := 0.0
+	if u.QuotaBytes > 0 {
+		ratio = float64(u.StoredBytes) / float64(u.QuotaBytes)
+	}
+	if u.QuotaObjects > 0 {
+		ratio = max(ratio, float64(u.StoredObjects)/float64(u.QuotaObjects))
+	}
+
+	switch {
+	case ratio >= 1:
+		return QuotaExceeded
+	case ratio >= QUOTA_CRITICAL_RATIO:
+		return QuotaCritical
+	case ratio >= QUOTA_WARNING_RATIO:
+		return QuotaWarning
+	default:
+		return QuotaOK
+	}
+}
+
+// CheckQuota tells whether a project may take objects more objects holding bytes more bytes, see ProjectUsage.Check
+func (engine *Engine) CheckQuota(project string, objects int64, bytes int64) error {
+	usage, err := engine.GetUsage(project)
+	if err != nil {
+		return err
+	}
+	return usage.Check(objects, bytes)
+}
+
+// SetProjectQuotaRecord limits the bytes and objects a project stores, zero removes a limit
+func (engine *Engine) SetProjectQuotaRecord(p *Project, bytes int64, objects int64) error {
+	slog.Debug("Setting project quota", "project", p.Name, "bytes", bytes, "objects", objects)
+
+	if bytes < 0 || objects < 0 {
+		return fmt.Errorf("%w: quota of project %q must not be negative", ErrValidation, p.Name)
+	}
+
+	err := engine.DatabaseClient.Model(p).Updates(map[string]any{
+		"quota_bytes":   bytes,
+		"quota_objects": objects,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("set project %q quota: %w", p.Name, err)
+	}
+
+	p.QuotaBytes = bytes
+	p.QuotaObjects = objects
+	return nil
+}
+
+// addUsage adds to the running usage of a project, negative values release usage
+func (engine *Engine) addUsage(project string, bytes int64, objects int64) error {
+	if project == "" {
+		project = DEFAULT_PROJECT
+	}
+
+	err := engine.DatabaseClient.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "project"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"stored_bytes":   gorm.Expr("project_usages.stored_bytes + ?", bytes),
+			"stored_objects": gorm.Expr("project_usages.stored_objects + ?", objects),
+			"updated_at":     time.Now(),
+		}),
+	}).Create(&ProjectUsage{Project: project, StoredBytes: bytes, StoredObjects: objects}).Error
+	if err != nil {
+		return fmt.Errorf("update project %q usage: %w", project, err)
+	}
+
+	if bytes > 0 || objects > 0 {
+		return engine.notifyQuota(project, bytes, objects)
+	}
+	return nil
+}
+
+// notifyQuota emits a project quota event and webhook when usage that grew by bytes and objects
+// moved the project to a worse quota status, a project dropping back below a threshold warns again next time
+func (engine *Engine) notifyQuota(project string, bytes int64, objects int64) error {
+	usage, err := engine.GetUsage(project)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if usage.QuotaBytes == 0 && usage.QuotaObjects == 0 {
+		return nil
+	}
+
+	before := *usage
+	before.StoredBytes -= bytes
+	before.StoredObjects -= objects
+
+	status := usage.Status()
+	if status.rank() <= before.Status().rank() {
+		return nil
+	}
+
+	severity, message := SeverityError, fmt.Sprintf("project %q exceeds its quota", usage.Project)
+	switch status {
+	case QuotaWarning:
+		severity, message = SeverityWarning, fmt.Sprintf("project %q passed %.0f%% of its quota", usage.Project, QUOTA_WARNING_RATIO*100)
+	case QuotaCritical:
+		message = fmt.Sprintf("project %q passed %.0f%% of its quota", usage.Project, QUOTA_CRITICAL_RATIO*100)
+	}
+	data := WebhookQuota{
+		Project:       usage.Project,
+		Status:        status,
+		StoredBytes:   usage.StoredBytes,
+		QuotaBytes:    usage.QuotaBytes,
+		StoredObjects: usage.StoredObjects,
+		QuotaObjects:  usage.QuotaObjects,
+	}
+	slog.Warn("Project usage crossed a quota threshold", "project", usage.Project, "status", status)
+
+	event, err := NewEvent(EventProjectQuota, severity, message, map[string]any{
+		"status":         status,
+		"stored_bytes":   usage.StoredBytes,
+		"quota_bytes":    usage.QuotaBytes,
+		"stored_objects": usage.StoredObjects,
+		"quota_objects":  usage.QuotaObjects,
+	})
+	if err != nil {
+		return err
+	}
+	event.Project = usage.Project
+	if err := engine.CreateEventRecord(event); err != nil {
+		return err
+	}
+	return engine.EnqueueWebhookEvent(WebhookProjectQuota, data)
+}
+
+// ListQuotaUsage returns the usage of the projects with a quota, ordered by name
+func (engine *Engine) ListQuotaUsage() ([]*ProjectUsage, error) {
+	var projects []*Project
+	err := engine.DatabaseClient.
+		Where("quota_bytes > 0 OR quota_objects > 0").
+		Order("name ASC").
+		Find(&projects).Error
+	if err != nil {
+		return nil, fmt.Errorf("list projects with a quota: %w", err)
+	}
+
+	usages := make([]*ProjectUsage, 0, len(projects))
+	for _, p := range projects {
+		usage, err := engine.projectUsage(p)
+		if err != nil {
+			return nil, err
+		}
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+// seedProjectUsage counts the ready assets of projects without usage yet, e.g. after upgrading
+func (engine *Engine) seedProjectUsage() error {
+	err := engine.DatabaseClient.Exec(`
+		INSERT INTO project_usages (project, stored_bytes, stored_objects, updated_at)
+		SELECT project, COALESCE(SUM(size_bytes), 0), COUNT(*), ?
+		FROM assets
+		WHERE state = ? AND deleted_at IS NULL
+		GROUP BY project
+		ON CONFLICT (project) DO NOTHING
+	`, time.Now(), StatusReady).Error
+	if err != nil {
+		return fmt.Errorf("seed project usage: %w", err)
+	}
+	return nil
+}
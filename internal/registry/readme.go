@@ -0,0 +1,28 @@
+package registry
+
+import (
+	"fmt"
+	"log/slog"
+	"unicode/utf8"
+)
+
+const DatasetReadmeMaxBytes = 256 << 10
+
+// SetDatasetReadmeRecord replaces the markdown readme of a dataset, an empty readme removes it
+func (engine *Engine) SetDatasetReadmeRecord(ds *Dataset, readme string) error {
+	slog.Debug("Setting dataset readme", "dataset", ds.Name, "bytes", len(readme))
+
+	if len(readme) > DatasetReadmeMaxBytes {
+		return fmt.Errorf("%w: readme is %d bytes, at most %d are allowed", ErrValidation, len(readme), DatasetReadmeMaxBytes)
+	}
+	if !utf8.ValidString(readme) {
+		return fmt.Errorf("%w: readme is not valid utf-8 text", ErrValidation)
+	}
+
+	if err := engine.DatabaseClient.Model(ds).Update("readme", readme).Error; err != nil {
+		return fmt.Errorf("set dataset %q readme: %w", ds.Name, err)
+	}
+
+	ds.Readme = readme
+	return nil
+}
@@ -3,6 +3,7 @@ package registry
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -11,7 +12,8 @@ import (
 
 type Asset struct {
 	gorm.Model
-	Checksum string         `gorm:"uniqueIndex;not null;size:64;check:checksum <> ''"`
+	Project  string         `gorm:"not null;default:'default';size:63;uniqueIndex:idx_assets_project_checksum"`
+	Checksum string         `gorm:"uniqueIndex:idx_assets_project_checksum;not null;size:64;check:checksum <> ''"`
 	Display  string         `gorm:"size:120"`
 	Extra    datatypes.JSON `gorm:"type:jsonb"`
 
@@ -19,6 +21,17 @@ type Asset struct {
 	SizeBytes int64
 	State     Status `gorm:"type:status;not null;default:'pending'"`
 
+	// License is an SPDX identifier, UsageNotes carries any extra terms
+	License    string `gorm:"size:100;index"`
+	UsageNotes string
+
+	// Classification gates downloads of sensitive content, ClassifiedBy is the principal or scanner that set it
+	Classification Classification `gorm:"type:classification;not null;default:'internal';index"`
+	ClassifiedBy   string         `gorm:"size:200"`
+
+	// Owner is the principal or team to contact about the asset, empty until someone claims it
+	Owner string `gorm:"size:200;index"`
+
 	Tags            []Tag            `gorm:"many2many:asset_tags;"`
 	DatasetVersions []DatasetVersion `gorm:"many2many:asset_dataset_versions;"`
 	Peers           []Peer           `gorm:"many2many:asset_peers;"`
@@ -29,6 +42,12 @@ func (a *Asset) SetExtra(extra map[string]any) error {
 		return fmt.Errorf("cannot set empty extra value")
 	}
 
+	for key := range extra {
+		if IsReservedExtraKey(key) {
+			return fmt.Errorf("%w: %w: %q", ErrValidation, ErrReservedExtraKey, key)
+		}
+	}
+
 	// Marshal back
 	data, err := json.Marshal(extra)
 	if err != nil {
@@ -41,15 +60,40 @@ func (a *Asset) SetExtra(extra map[string]any) error {
 
 type Tag struct {
 	gorm.Model
-	Name   string  `gorm:"uniqueIndex;not null;size:100"`
-	Assets []Asset `gorm:"many2many:asset_tags;"`
+	Project string  `gorm:"not null;default:'default';size:63;uniqueIndex:idx_tags_project_name"`
+	Name    string  `gorm:"uniqueIndex:idx_tags_project_name;not null;size:100"`
+	Assets  []Asset `gorm:"many2many:asset_tags;"`
+
+	// Description documents what the tag means, Color is a #rrggbb hex color for display
+	Description string
+	Color       string `gorm:"size:7"`
+	// CreatedBy is the principal that created the tag, empty for tags created by the server
+	CreatedBy string `gorm:"size:200"`
+	// TagGroupID is the mutually exclusive group of the tag, nil when ungrouped
+	TagGroupID *uint `gorm:"index"`
+	// Key and Value are the parts of a key:value tag name, both empty for flat tags
+	Key   string `gorm:"size:100;index"`
+	Value string `gorm:"size:100"`
 }
 
 type Dataset struct {
 	gorm.Model
-	Name        string `gorm:"uniqueIndex;not null;size:100"`
+	Project     string `gorm:"not null;default:'default';size:63;uniqueIndex:idx_datasets_project_name"`
+	Name        string `gorm:"uniqueIndex:idx_datasets_project_name;not null;size:100"`
 	Description string
 	Versions    []DatasetVersion
+
+	License    string `gorm:"size:100;index"`
+	UsageNotes string
+
+	// Owner is the principal or team to contact about the dataset
+	Owner string `gorm:"size:200;index"`
+
+	// Readme documents the dataset in markdown
+	Readme string
+
+	// ApproverRoles must each approve a version before it is published, empty accepts any single approval
+	ApproverRoles datatypes.JSONSlice[string] `gorm:"type:jsonb"`
 }
 
 func (d *Dataset) LatestVersion(tx *gorm.DB) (*DatasetVersion, error) {
@@ -72,6 +116,38 @@ type DatasetVersion struct {
 	DatasetID   uint `gorm:"not null;uniqueIndex:idx_dataset_number;index"`
 	Dataset     Dataset
 	Assets      []Asset `gorm:"many2many:dataset_version_assets;"`
+
+	// publication workflow
+	Status      VersionStatus `gorm:"type:version_status;not null;default:'draft'"`
+	SubmittedBy string        `gorm:"size:200"`
+	SubmittedAt *time.Time
+	PublishedBy string `gorm:"size:200"`
+	PublishedAt *time.Time
+	Approvals   []DatasetVersionApproval
+
+	// frozen manifest, computed when the version is published
+	Manifest       datatypes.JSON `gorm:"type:jsonb"`
+	ManifestDigest string         `gorm:"size:64"`
+	AssetCount     int
+	TotalBytes     int64
+}
+
+// DatasetVersionApproval is one approver sign off on a version in review, per required role
+type DatasetVersionApproval struct {
+	gorm.Model
+	DatasetVersionID uint   `gorm:"not null;uniqueIndex:idx_version_approval"`
+	Approver         string `gorm:"not null;size:200;uniqueIndex:idx_version_approval"`
+	Role             string `gorm:"not null;size:100;uniqueIndex:idx_version_approval"`
+}
+
+// DatasetChannel is a named pointer to a published dataset version, e.g. latest or production
+type DatasetChannel struct {
+	gorm.Model
+	DatasetID        uint   `gorm:"not null;uniqueIndex:idx_dataset_channel"`
+	Name             string `gorm:"not null;size:50;uniqueIndex:idx_dataset_channel"`
+	DatasetVersionID uint   `gorm:"not null;index"`
+	DatasetVersion   DatasetVersion
+	UpdatedBy        string `gorm:"size:200"`
 }
 
 type Peer struct {
@@ -80,4 +156,23 @@ type Peer struct {
 	Display string  `gorm:"size:200;not null"`
 	Type    string  `gorm:"not null;default:'default'"`
 	Assets  []Asset `gorm:"many2many:asset_peers;"`
+
+	// URL is the api root changes are pulled from, e.g. https://aether-eu:8080/api, empty for peers pulling from us
+	URL string `gorm:"size:500"`
+	// Watermark is the last change pulled from the peer, AckedWatermark the last change the peer pulled from us
+	Watermark      string `gorm:"size:100"`
+	AckedWatermark string `gorm:"size:100"`
+	SyncedAt       *time.Time
+}
+
+// StorageMapping routes a project to its own bucket, for tenants that need hard isolation.
+// Credentials are never stored here, they come from the named shared aws config profile.
+type StorageMapping struct {
+	gorm.Model
+	Project  string `gorm:"uniqueIndex;not null;size:63"`
+	Bucket   string `gorm:"not null;size:63;check:bucket <> ''"`
+	Prefix   string `gorm:"size:200"`
+	Endpoint string `gorm:"size:200"`
+	Region   string `gorm:"size:50"`
+	Profile  string `gorm:"size:100"`
 }
@@ -0,0 +1,109 @@
+package registry
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+const REFERENCE_DATASET_VERSION = "dataset_version"
+
+var ErrAssetReferenced = registryerrors.New(registryerrors.ErrConflict, "asset is still referenced")
+
+// AssetReference is something that still needs the stored object of an asset
+type AssetReference struct {
+	AssetID uint
+	Kind    string
+	Dataset string
+	Version int
+	Status  VersionStatus
+}
+
+// AssetsReferencedError lists the assets whose objects can't be purged yet
+type AssetsReferencedError struct {
+	Checksums []string
+}
+
+func (e *AssetsReferencedError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrAssetReferenced, strings.Join(e.Checksums, ", "))
+}
+
+func (e *AssetsReferencedError) Unwrap() error {
+	return ErrAssetReferenced
+}
+
+// referenceSource finds the references of a set of assets.
+// Every kind of record pointing at assets adds its source here, purges consult all of them.
+type referenceSource func(db *gorm.DB, ids []uint) ([]AssetReference, error)
+
+var referenceSources = []referenceSource{datasetVersionReferences}
+
+// datasetVersionReferences finds the dataset versions listing the assets, through either membership table
+func datasetVersionReferences(db *gorm.DB, ids []uint) ([]AssetReference, error) {
+	var refs []AssetReference
+	err := db.Raw(`
+		SELECT DISTINCT m.asset_id, ? AS kind, d.name AS dataset, v.number AS version, v.status
+		FROM (
+			SELECT asset_id, dataset_version_id FROM dataset_version_assets
+			UNION SELECT asset_id, dataset_version_id FROM asset_dataset_versions
+		) m
+		JOIN dataset_versions v ON v.id = m.dataset_version_id AND v.deleted_at IS NULL
+		JOIN datasets d ON d.id = v.dataset_id AND d.deleted_at IS NULL
+		WHERE m.asset_id IN ?
+		ORDER BY m.asset_id, d.name, v.number`, REFERENCE_DATASET_VERSION, ids).
+		Scan(&refs).Error
+	if err != nil {
+		return nil, fmt.Errorf("find dataset version references: %w", err)
+	}
+	return refs, nil
+}
+
+// AssetReferences returns the references of every asset by asset id, unreferenced assets are left out
+func (engine *Engine) AssetReferences(assets ...*Asset) (map[uint][]AssetReference, error) {
+	slog.Debug("Finding asset references", "total", len(assets))
+
+	refs := map[uint][]AssetReference{}
+	if len(assets) == 0 {
+		return refs, nil
+	}
+
+	ids := make([]uint, len(assets))
+	for i, a := range assets {
+		ids[i] = a.ID
+	}
+
+	for _, source := range referenceSources {
+		found, err := source(engine.DatabaseClient, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range found {
+			refs[ref.AssetID] = append(refs[ref.AssetID], ref)
+		}
+	}
+	return refs, nil
+}
+
+// CheckUnreferenced fails with an AssetsReferencedError when any asset is still referenced
+func (engine *Engine) CheckUnreferenced(assets ...*Asset) error {
+	refs, err := engine.AssetReferences(assets...)
+	if err != nil {
+		return err
+	}
+
+	var referenced []string
+	for _, a := range assets {
+		if len(refs[a.ID]) > 0 {
+			referenced = append(referenced, a.Checksum)
+		}
+	}
+
+	if len(referenced) > 0 {
+		return &AssetsReferencedError{Checksums: referenced}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Package registryerrors holds the kinds of errors the registry returns. Every registry error of a kind
+// matches its sentinel with errors.Is, so callers map errors by kind instead of by message or driver code.
+package registryerrors
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
+)
+
+// Kinds of registry errors
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrConflict      = errors.New("conflict")
+	ErrValidation    = errors.New("validation error")
+	ErrReadOnly      = errors.New("read only")
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
+// New creates a sentinel error of kind, errors.Is matches it and its kind
+func New(kind error, text string) error {
+	return &kindError{kind: kind, text: text}
+}
+
+type kindError struct {
+	kind error
+	text string
+}
+
+func (e *kindError) Error() string {
+	return e.text
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// Error gives a kind to an error of another package, e.g. the database driver. Its message is the one of Err,
+// errors.Is matches both the kind and Err.
+type Error struct {
+	Kind error
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// KindOf returns the kind of err, nil when it has none
+func KindOf(err error) error {
+	for _, kind := range []error{ErrNotFound, ErrAlreadyExists, ErrConflict, ErrValidation, ErrReadOnly, ErrQuotaExceeded} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
+
+// FromDatabase gives database errors their kind: missing records are not found, unique violations already exist.
+// Other errors and errors that already have a kind are returned as they are.
+func FromDatabase(err error) error {
+	if err == nil || KindOf(err) != nil {
+		return err
+	}
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return &Error{Kind: ErrNotFound, Err: err}
+	case IsUniqueViolation(err):
+		return &Error{Kind: ErrAlreadyExists, Err: err}
+	}
+	return err
+}
+
+// IsUniqueViolation reports whether err is a unique or primary key violation of postgres or sqlite
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23505"
+	}
+
+	var liteErr sqlite3.Error
+	if errors.As(err, &liteErr) {
+		return liteErr.ExtendedCode == sqlite3.ErrConstraintUnique || liteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
@@ -0,0 +1,425 @@
+package registry
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+const (
+	DEFAULT_REPLICATION_INTERVAL = time.Minute
+
+	// REPLICATION_MAX_SKEW bounds the clock difference of signed replication requests, it also caps replays
+	REPLICATION_MAX_SKEW = 5 * time.Minute
+
+	REPLICATION_PEER_HEADER      = "X-Aether-Peer"
+	REPLICATION_TIMESTAMP_HEADER = "X-Aether-Timestamp"
+	REPLICATION_SIGNATURE_HEADER = "X-Aether-Signature"
+)
+
+// Peer types, a source is the source of truth of the assets it sends
+const (
+	PeerSource  = "source"
+	PeerReplica = "replica"
+)
+
+var (
+	ErrInvalidWatermark     = errors.New("invalid replication watermark")
+	ErrInvalidPeerSignature = errors.New("invalid replication request signature")
+)
+
+// ReplicationAction is what applying a replicated change did locally
+type ReplicationAction string
+
+const (
+	ReplicationCreated ReplicationAction = "created"
+	ReplicationUpdated ReplicationAction = "updated"
+	// ReplicationKept means the local copy won the conflict
+	ReplicationKept ReplicationAction = "kept"
+	// ReplicationSkipped means there was nothing to replicate, e.g. an asset the peer never finished uploading
+	ReplicationSkipped ReplicationAction = "skipped"
+)
+
+// Watermark is the position of an asset change in the replication feed, ordered by update time then id
+type Watermark struct {
+	UpdatedAt time.Time
+	ID        uint
+}
+
+// String encodes the watermark as an opaque token, the zero watermark is empty
+func (w Watermark) String() string {
+	if w.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d", w.UpdatedAt.UnixNano(), w.ID)
+}
+
+func (w Watermark) IsZero() bool {
+	return w.ID == 0 && w.UpdatedAt.IsZero()
+}
+
+// ParseWatermark decodes a watermark token, an empty token starts the feed from the beginning
+func ParseWatermark(token string) (Watermark, error) {
+	if token == "" {
+		return Watermark{}, nil
+	}
+
+	nanos, id, ok := strings.Cut(token, "-")
+	if !ok {
+		return Watermark{}, fmt.Errorf("%w: %q", ErrInvalidWatermark, token)
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return Watermark{}, fmt.Errorf("%w: %q", ErrInvalidWatermark, token)
+	}
+	i, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return Watermark{}, fmt.Errorf("%w: %q", ErrInvalidWatermark, token)
+	}
+	return Watermark{UpdatedAt: time.Unix(0, n).UTC(), ID: uint(i)}, nil
+}
+
+// ReplicationChange is the replicated state of an asset, the latest state wins over earlier changes.
+// Checksums are unique within a project, the change is applied to the asset of the same project.
+type ReplicationChange struct {
+	Project        string         `json:"project"`
+	Checksum       string         `json:"checksum"`
+	Display        string         `json:"display"`
+	MimeType       string         `json:"mime_type"`
+	SizeBytes      int64          `json:"size_bytes"`
+	State          Status         `json:"state"`
+	Extra          datatypes.JSON `json:"extra,omitempty"`
+	License        string         `json:"license,omitempty"`
+	UsageNotes     string         `json:"usage_notes,omitempty"`
+	Owner          string         `json:"owner,omitempty"`
+	Classification Classification `json:"classification"`
+	Tags           []string       `json:"tags"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	Watermark      string         `json:"watermark"`
+}
+
+// ReplicationResult reports how a change was applied, NeedsObject asks the caller to copy the stored object
+type ReplicationResult struct {
+	Asset       *Asset
+	Action      ReplicationAction
+	NeedsObject bool
+}
+
+// SignReplicationRequest signs a replication request of peer with the key shared by the instances.
+// The signature covers the method, the path with its query, the timestamp, the peer and the body hash.
+func SignReplicationRequest(key Secret, method string, path string, peer string, timestamp time.Time, body []byte) string {
+	sum := sha256.Sum256(body)
+
+	mac := hmac.New(sha256.New, []byte(key.Value()))
+	fmt.Fprintf(mac, "%s\n%s\n%d\n%s\n%s", method, path, timestamp.Unix(), peer, hex.EncodeToString(sum[:]))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyReplicationRequest checks a replication request signature and that its timestamp is within the allowed skew
+func VerifyReplicationRequest(key Secret, method string, path string, peer string, timestamp string, signature string, body []byte) error {
+	if key.Value() == "" {
+		return fmt.Errorf("%w: replication is not configured", ErrInvalidPeerSignature)
+	}
+	if peer == "" {
+		return fmt.Errorf("%w: missing peer", ErrInvalidPeerSignature)
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: missing timestamp", ErrInvalidPeerSignature)
+	}
+	signedAt := time.Unix(unix, 0)
+	if skew := time.Since(signedAt); skew > REPLICATION_MAX_SKEW || skew < -REPLICATION_MAX_SKEW {
+		return fmt.Errorf("%w: timestamp outside the allowed skew", ErrInvalidPeerSignature)
+	}
+
+	expected, _ := hex.DecodeString(SignReplicationRequest(key, method, path, peer, signedAt, body))
+	provided, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(provided, expected) {
+		return ErrInvalidPeerSignature
+	}
+	return nil
+}
+
+// EnsurePeerRecord returns the peer named name, creating it with peerType and url when missing.
+// A non empty url or type of an existing peer is updated, so configuration changes apply on restart.
+func (engine *Engine) EnsurePeerRecord(name string, peerType string, url string) (*Peer, error) {
+	slog.Debug("Ensuring peer", "name", name, "type", peerType)
+
+	peer := &Peer{Name: NormalizeString(name)}
+	err := engine.DatabaseClient.
+		Where("name = ?", peer.Name).
+		Attrs(Peer{Display: name, Type: peerType, URL: url}).
+		FirstOrCreate(peer).Error
+	if err != nil {
+		return nil, fmt.Errorf("ensure peer %q: %w", name, err)
+	}
+
+	updates := map[string]any{}
+	if peerType != "" && peer.Type != peerType {
+		updates["type"] = peerType
+	}
+	if url != "" && peer.URL != url {
+		updates["url"] = url
+	}
+	if len(updates) > 0 {
+		if err := engine.DatabaseClient.Model(peer).Updates(updates).Error; err != nil {
+			return nil, fmt.Errorf("update peer %q: %w", name, err)
+		}
+	}
+	return peer, nil
+}
+
+// GetPeerRecord returns the peer named name
+func (engine *Engine) GetPeerRecord(name string) (*Peer, error) {
+	var peer Peer
+	if err := engine.DatabaseClient.Where("name = ?", NormalizeString(name)).First(&peer).Error; err != nil {
+		return nil, fmt.Errorf("get peer %q: %w", name, err)
+	}
+	return &peer, nil
+}
+
+// SavePeerWatermark records the last change pulled from a peer
+func (engine *Engine) SavePeerWatermark(peer *Peer, watermark string) error {
+	now := time.Now()
+	err := engine.DatabaseClient.Model(peer).Updates(map[string]any{
+		"watermark": watermark,
+		"synced_at": now,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("save peer %q watermark: %w", peer.Name, err)
+	}
+
+	peer.Watermark, peer.SyncedAt = watermark, &now
+	return nil
+}
+
+// AckPeerWatermark records the last change a peer reported to have pulled from this instance
+func (engine *Engine) AckPeerWatermark(peer *Peer, watermark string) error {
+	if _, err := ParseWatermark(watermark); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	if err := engine.DatabaseClient.Model(peer).Update("acked_watermark", watermark).Error; err != nil {
+		return fmt.Errorf("ack peer %q watermark: %w", peer.Name, err)
+	}
+	peer.AckedWatermark = watermark
+	return nil
+}
+
+// LatestWatermark returns the position of the most recent asset change
+func (engine *Engine) LatestWatermark() (Watermark, error) {
+	var asset Asset
+	err := engine.DatabaseClient.
+		Select("id", "updated_at").
+		Order("updated_at DESC, id DESC").
+		Limit(1).
+		Find(&asset).Error
+	if err != nil {
+		return Watermark{}, fmt.Errorf("get latest watermark: %w", err)
+	}
+	if asset.ID == 0 {
+		return Watermark{}, nil
+	}
+	return Watermark{UpdatedAt: asset.UpdatedAt, ID: asset.ID}, nil
+}
+
+// ListReplicationChanges returns the asset changes after since in feed order, of every project unless the engine is scoped.
+// Purged assets leave the feed, replicas keep their copy until purged locally.
+func (engine *Engine) ListReplicationChanges(since Watermark, limit uint) ([]*ReplicationChange, error) {
+	slog.Debug("Listing replication changes", "since", since.String(), "limit", limit)
+
+	if limit == 0 || limit > SearchMaxLimit {
+		limit = SearchDefaultLimit
+	}
+
+	db := engine.DatabaseClient
+	if !since.IsZero() {
+		db = db.Where("updated_at > ? OR (updated_at = ? AND id > ?)", since.UpdatedAt, since.UpdatedAt, since.ID)
+	}
+
+	var assets []*Asset
+	if err := db.Order("updated_at ASC, id ASC").Limit(int(limit)).Find(&assets).Error; err != nil {
+		return nil, fmt.Errorf("list replication changes: %w", err)
+	}
+	if err := engine.loadTags(assets); err != nil {
+		return nil, err
+	}
+
+	changes := make([]*ReplicationChange, len(assets))
+	for i, a := range assets {
+		changes[i] = newReplicationChange(a)
+	}
+	return changes, nil
+}
+
+func newReplicationChange(a *Asset) *ReplicationChange {
+	tags := make([]string, len(a.Tags))
+	for i, t := range a.Tags {
+		tags[i] = t.Name
+	}
+
+	return &ReplicationChange{
+		Project:        a.Project,
+		Checksum:       a.Checksum,
+		Display:        a.Display,
+		MimeType:       a.MimeType,
+		SizeBytes:      a.SizeBytes,
+		State:          a.State,
+		Extra:          a.Extra,
+		License:        a.License,
+		UsageNotes:     a.UsageNotes,
+		Owner:          a.Owner,
+		Classification: a.Classification,
+		Tags:           tags,
+		UpdatedAt:      a.UpdatedAt,
+		Watermark:      Watermark{UpdatedAt: a.UpdatedAt, ID: a.ID}.String(),
+	}
+}
+
+// ApplyReplicationChange applies an asset change pulled from peer.
+// Assets missing locally are created from ready changes. Assets present on both instances follow
+// the source of truth: a source peer overwrites the local copy, otherwise the local copy is kept.
+// Either way a local asset still waiting for its object asks for the peer copy.
+// Assets and tags are looked up and created in the project of the change, the default project for peers without projects.
+func (engine *Engine) ApplyReplicationChange(ctx context.Context, peer *Peer, change *ReplicationChange) (*ReplicationResult, error) {
+	slog.Debug("Applying replication change", "peer", peer.Name, "project", change.Project, "checksum", change.Checksum, "state", change.State)
+
+	checksum := NormalizeString(change.Checksum)
+	if err := ValidateSHA256(checksum); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	project := NormalizeString(change.Project)
+	if project == "" {
+		project = DEFAULT_PROJECT
+	}
+	if !projectNamePattern.MatchString(project) {
+		return nil, fmt.Errorf("%w: %w: %q", ErrValidation, ErrInvalidProject, project)
+	}
+	scoped := engine.ForProject(ctx, project)
+
+	var names []string
+	for _, t := range change.Tags {
+		if name := NormalizeString(t); ValidateTagName(name) {
+			names = append(names, name)
+		}
+	}
+
+	// tags are shared, create them before locking the asset
+	tags, err := scoped.EnsureTagRecords(names)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReplicationResult{}
+	err = scoped.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		var asset Asset
+		err := tx.Where("checksum = ?", checksum).First(&asset).Error
+		switch {
+		case errors.Is(err, registryerrors.ErrNotFound):
+			if change.State != StatusReady {
+				result.Action = ReplicationSkipped
+				return nil
+			}
+
+			asset = Asset{
+				Project:        project,
+				Checksum:       checksum,
+				Display:        change.Display,
+				MimeType:       change.MimeType,
+				SizeBytes:      change.SizeBytes,
+				Extra:          change.Extra,
+				License:        change.License,
+				UsageNotes:     change.UsageNotes,
+				Owner:          change.Owner,
+				Classification: change.Classification,
+				ClassifiedBy:   "peer:" + peer.Name,
+			}
+			if err := tx.Omit("Tags", "DatasetVersions", "Peers").Create(&asset).Error; err != nil {
+				return fmt.Errorf("create replicated asset %q: %w", checksum, err)
+			}
+			result.Action = ReplicationCreated
+
+		case err != nil:
+			return fmt.Errorf("get asset %q: %w", checksum, err)
+
+		case peer.Type != PeerSource:
+			result.Action = ReplicationKept
+
+		default:
+			updates := map[string]any{
+				"display":        change.Display,
+				"mime_type":      NormalizeString(change.MimeType),
+				"extra":          change.Extra,
+				"license":        change.License,
+				"usage_notes":    change.UsageNotes,
+				"owner":          change.Owner,
+				"classification": change.Classification,
+				"classified_by":  "peer:" + peer.Name,
+			}
+
+			from := asset.State
+			switch {
+			case change.State == StatusDeleted && from != StatusDeleted:
+				updates["state"] = StatusDeleted
+			case change.State == StatusReady && from != StatusReady && from != StatusPending:
+				// the object comes again from the source
+				updates["state"] = StatusPending
+				updates["size_bytes"] = change.SizeBytes
+			}
+
+			// the state the asset was read in guards the update, like UpdateAssetState
+			update := tx.Model(&asset).Where("state = ?", from).Updates(updates)
+			if update.Error != nil {
+				return fmt.Errorf("update replicated asset %q: %w", checksum, update.Error)
+			}
+			if update.RowsAffected != 1 {
+				return fmt.Errorf("%w: %s changed while replicating", ErrAssetStateChanged, checksum)
+			}
+			if state, ok := updates["state"].(Status); ok {
+				asset.State = state
+				// only ready assets count towards the project usage, pending ones are counted once promoted
+				if from == StatusReady {
+					if err := scoped.WithTx(tx).addUsage(project, -asset.SizeBytes, -1); err != nil {
+						return err
+					}
+				}
+			}
+			if err := tx.Model(&asset).Omit("Tags.*").Association("Tags").Replace(tags); err != nil {
+				return fmt.Errorf("replace replicated asset %q tags: %w", checksum, err)
+			}
+			result.Action = ReplicationUpdated
+		}
+
+		if result.Action == ReplicationCreated && len(tags) > 0 {
+			if err := tx.Model(&asset).Omit("Tags.*").Association("Tags").Append(tags); err != nil {
+				return fmt.Errorf("attach replicated asset %q tags: %w", checksum, err)
+			}
+		}
+
+		if err := tx.Model(&asset).Omit("Peers.*").Association("Peers").Append(peer); err != nil {
+			return fmt.Errorf("link asset %q to peer %q: %w", checksum, peer.Name, err)
+		}
+
+		result.Asset = &asset
+		result.NeedsObject = change.State == StatusReady && asset.State == StatusPending
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
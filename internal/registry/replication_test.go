@@ -0,0 +1,64 @@
+package registry
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestReplicationChangeKeepsProject applies changes to the project they come from,
+// assets of other projects sharing the checksum are left alone
+func TestReplicationChangeKeepsProject(t *testing.T) {
+	ctx := context.Background()
+	engine := newTestEngine(t)
+
+	peer, err := engine.EnsurePeerRecord("origin", PeerSource, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checksum := strings.Repeat("c", 64)
+	local := &Asset{Checksum: checksum, Display: "local", State: StatusReady}
+	if err := engine.CreateAssetRecord(local); err != nil {
+		t.Fatal(err)
+	}
+
+	change := &ReplicationChange{Project: "proj", Checksum: checksum, Display: "replicated", State: StatusReady, Tags: []string{"red"}}
+	for _, want := range []ReplicationAction{ReplicationCreated, ReplicationUpdated} {
+		result, err := engine.ApplyReplicationChange(ctx, peer, change)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Action != want || result.Asset.Project != "proj" {
+			t.Errorf("apply got %s in project %q, want %s in proj", result.Action, result.Asset.Project, want)
+		}
+	}
+
+	kept, err := engine.ForProject(ctx, DEFAULT_PROJECT).GetAssetRecord(checksum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kept.Display != "local" {
+		t.Errorf("default project asset display is %q, want it untouched", kept.Display)
+	}
+
+	var tag Tag
+	if err := engine.DatabaseClient.Where("name = ?", "red").First(&tag).Error; err != nil {
+		t.Fatal(err)
+	}
+	if tag.Project != "proj" {
+		t.Errorf("replicated tag is in project %q, want proj", tag.Project)
+	}
+
+	changes, err := engine.ListReplicationChanges(Watermark{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	projects := map[string]bool{}
+	for _, c := range changes {
+		projects[c.Project] = true
+	}
+	if !projects[DEFAULT_PROJECT] || !projects["proj"] {
+		t.Errorf("feed spans projects %v, want default and proj", projects)
+	}
+}
@@ -0,0 +1,372 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+const (
+	DEFAULT_RETAG_INTERVAL = time.Minute
+	DEFAULT_RETAG_BATCH    = SearchMaxLimit
+	DEFAULT_RETAG_LOCK     = "aether:retag"
+
+	// EventRetag reports the outcome of a bulk retag job
+	EventRetag = "tag.retag"
+)
+
+var ErrRetagStatus = registryerrors.New(registryerrors.ErrConflict, "invalid retag job status transition")
+
+// RetagAction is what a retag job does with its tag
+type RetagAction string
+
+const (
+	RetagAttach RetagAction = "attach"
+	RetagDetach RetagAction = "detach"
+)
+
+// RetagStatus is the stage of a retag job
+type RetagStatus string
+
+const (
+	RetagPending   RetagStatus = "pending"
+	RetagRunning   RetagStatus = "running"
+	RetagCompleted RetagStatus = "completed"
+	RetagFailed    RetagStatus = "failed"
+	RetagCancelled RetagStatus = "cancelled"
+)
+
+// RetagJob attaches or detaches a tag on every asset matching a stored search filter.
+// The retag worker walks the matches by id in batches, saving its cursor and counters after each batch,
+// so progress is visible while it runs and an interrupted job resumes where it stopped.
+type RetagJob struct {
+	gorm.Model
+	TagID       uint `gorm:"not null;index"`
+	Tag         Tag
+	Action      RetagAction    `gorm:"not null;size:20"`
+	Filter      datatypes.JSON `gorm:"type:jsonb"`
+	Status      RetagStatus    `gorm:"not null;size:20;default:'pending';index"`
+	RequestedBy string         `gorm:"size:200"`
+
+	// Total is the number of matches when the job started, Processed the matches walked so far,
+	// Changed the assets that gained or lost the tag and Detached the group tags swapped out
+	Total     int64
+	Processed int64
+	Changed   int64
+	Detached  int64
+	Cursor    uint
+
+	Error      string
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// Query rebuilds the search query of the job filter
+func (j *RetagJob) Query() (*SearchAssetsQuery, error) {
+	var query SearchAssetsQuery
+	if err := json.Unmarshal(j.Filter, &query); err != nil {
+		return nil, fmt.Errorf("decode retag job %d filter: %w", j.ID, err)
+	}
+	return &query, nil
+}
+
+// CreateRetagJobRecord queues a retag of tag on the assets matching opts, the page options are ignored
+func (engine *Engine) CreateRetagJobRecord(tag *Tag, action RetagAction, principal string, opts ...SearchAssetsOption) (*RetagJob, error) {
+	slog.Debug("Creating retag job", "tag", tag.Name, "action", action)
+
+	if action != RetagAttach && action != RetagDetach {
+		return nil, fmt.Errorf("%w: unknown retag action %q", ErrValidation, action)
+	}
+
+	query, err := NewSearchAssetsQuery(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	// the job walks matches by id, so the cursor keeps moving while tags change the matches
+	query.Cursor, query.OrderBy, query.Descending = 0, "id", false
+
+	filter, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("encode retag filter: %w", err)
+	}
+
+	job := &RetagJob{
+		TagID:       tag.ID,
+		Tag:         *tag,
+		Action:      action,
+		Filter:      datatypes.JSON(filter),
+		Status:      RetagPending,
+		RequestedBy: principal,
+	}
+	if err := engine.DatabaseClient.Omit("Tag").Create(job).Error; err != nil {
+		return nil, fmt.Errorf("create retag job: %w", err)
+	}
+	return job, nil
+}
+
+// GetRetagJobRecord returns a retag job with its tag
+func (engine *Engine) GetRetagJobRecord(id uint) (*RetagJob, error) {
+	var job RetagJob
+	if err := engine.DatabaseClient.Preload("Tag").First(&job, id).Error; err != nil {
+		return nil, fmt.Errorf("get retag job %d: %w", id, err)
+	}
+	return &job, nil
+}
+
+// ListRetagJobRecords returns a page of retag jobs in status, ordered by id after cursor
+func (engine *Engine) ListRetagJobRecords(status RetagStatus, cursor uint, limit uint) ([]*RetagJob, error) {
+	if limit == 0 || limit > SearchMaxLimit {
+		limit = SearchDefaultLimit
+	}
+
+	var jobs []*RetagJob
+	db := engine.DatabaseClient.Preload("Tag").Where("id > ?", cursor)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	if err := db.Order("id ASC").Limit(int(limit)).Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("list retag jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// CancelRetagJob stops a pending or running job, batches already applied are kept
+func (engine *Engine) CancelRetagJob(job *RetagJob) error {
+	slog.Debug("Cancelling retag job", "job", job.ID, "status", job.Status)
+
+	if job.Status != RetagPending && job.Status != RetagRunning {
+		return fmt.Errorf("%w: job is %s, only pending or running jobs can be cancelled", ErrRetagStatus, job.Status)
+	}
+
+	now := time.Now()
+	result := engine.DatabaseClient.Model(&RetagJob{}).
+		Where("id = ? AND status IN ?", job.ID, []RetagStatus{RetagPending, RetagRunning}).
+		Updates(map[string]any{"status": RetagCancelled, "finished_at": now})
+	if result.Error != nil {
+		return fmt.Errorf("cancel retag job %d: %w", job.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: job %d already finished", ErrRetagStatus, job.ID)
+	}
+
+	job.Status, job.FinishedAt = RetagCancelled, &now
+	return nil
+}
+
+// RetagWorker runs queued retag jobs one batch of assets at a time
+type RetagWorker struct {
+	engine *Engine
+	batch  int
+}
+
+// NewRetagWorker creates a worker for the queued retag jobs
+func (engine *Engine) NewRetagWorker() *RetagWorker {
+	return &RetagWorker{engine: engine, batch: DEFAULT_RETAG_BATCH}
+}
+
+// Job wraps the worker as a scheduled job
+func (w *RetagWorker) Job(interval time.Duration) Job {
+	return Job{
+		Name:     "retag",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := w.Run(ctx)
+			return err
+		},
+	}
+}
+
+// Run works through every pending or interrupted job, oldest first, and returns how many finished
+func (w *RetagWorker) Run(ctx context.Context) (int, error) {
+	lock, err := w.engine.TryLock(ctx, DEFAULT_RETAG_LOCK)
+	if err != nil || lock == nil {
+		return 0, err
+	}
+	defer lock.Release()
+
+	var jobs []*RetagJob
+	err = w.engine.DatabaseClient.
+		Preload("Tag").
+		Where("status IN ?", []RetagStatus{RetagPending, RetagRunning}).
+		Order("id ASC").
+		Find(&jobs).Error
+	if err != nil {
+		return 0, fmt.Errorf("list queued retag jobs: %w", err)
+	}
+
+	finished := 0
+	for _, job := range jobs {
+		if err := w.run(ctx, job); err != nil {
+			if ctx.Err() != nil {
+				// interrupted jobs keep their cursor and resume next run
+				return finished, err
+			}
+			if errors.Is(err, ErrRetagStatus) {
+				continue
+			}
+
+			slog.Error("Retag job failed", "job", job.ID, "tag", job.Tag.Name, "error", err)
+			w.finish(job, RetagFailed, err.Error())
+		}
+		finished++
+	}
+	return finished, nil
+}
+
+// run applies the job batch after batch from its cursor
+func (w *RetagWorker) run(ctx context.Context, job *RetagJob) error {
+	query, err := job.Query()
+	if err != nil {
+		return err
+	}
+
+	// the job only reaches the assets of the project of its tag
+	scoped := w.engine.ForProject(ctx, job.Tag.Project)
+
+	if job.Status == RetagPending {
+		if job.Total, err = scoped.CountAssetsRecords(query); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		updates := map[string]any{"status": RetagRunning, "started_at": now, "total": job.Total}
+		if err := scoped.updateRetagJob(job, updates); err != nil {
+			return err
+		}
+		job.Status, job.StartedAt = RetagRunning, &now
+	}
+
+	slog.Info("Running retag job", "job", job.ID, "tag", job.Tag.Name, "action", job.Action, "total", job.Total, "processed", job.Processed)
+
+	query.Limit = uint(w.batch)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		query.Cursor = job.Cursor
+		assets, err := scoped.SearchAssetsRecords(query)
+		if err != nil {
+			return err
+		}
+		if len(assets) == 0 {
+			break
+		}
+
+		checksums := make([]string, len(assets))
+		for i, asset := range assets {
+			checksums[i] = asset.Checksum
+		}
+
+		// the batch and its progress commit together, so a crash never applies a batch twice
+		err = scoped.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+			engine := scoped.WithTx(tx)
+
+			changed, swaps, err := engine.applyRetag(job, checksums)
+			if err != nil {
+				return err
+			}
+			for _, swap := range swaps {
+				event, err := NewTagSwapEvent(swap)
+				if err != nil {
+					return err
+				}
+				event.Principal = job.RequestedBy
+				if err := engine.CreateEventRecord(event); err != nil {
+					return err
+				}
+			}
+
+			updates := map[string]any{
+				"cursor":    assets[len(assets)-1].ID,
+				"processed": job.Processed + int64(len(assets)),
+				"changed":   job.Changed + changed,
+				"detached":  job.Detached + int64(len(swaps)),
+			}
+			if err := engine.updateRetagJob(job, updates); err != nil {
+				return err
+			}
+
+			job.Cursor = updates["cursor"].(uint)
+			job.Processed, job.Changed, job.Detached = updates["processed"].(int64), updates["changed"].(int64), updates["detached"].(int64)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		slog.Debug("Applied retag batch", "job", job.ID, "processed", job.Processed, "total", job.Total)
+		if len(assets) < w.batch {
+			break
+		}
+	}
+
+	w.finish(job, RetagCompleted, "")
+	return nil
+}
+
+// applyRetag attaches or detaches the job tag on checksums
+func (engine *Engine) applyRetag(job *RetagJob, checksums []string) (int64, []*TagSwap, error) {
+	if job.Action == RetagDetach {
+		changed, err := engine.DetachTagFromChecksums(&job.Tag, checksums)
+		return changed, nil, err
+	}
+	return engine.AttachTagToChecksums(&job.Tag, checksums)
+}
+
+// updateRetagJob saves progress of a running job, failing once it was cancelled
+func (engine *Engine) updateRetagJob(job *RetagJob, updates map[string]any) error {
+	result := engine.DatabaseClient.Model(&RetagJob{}).
+		Where("id = ? AND status IN ?", job.ID, []RetagStatus{RetagPending, RetagRunning}).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("update retag job %d: %w", job.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: job %d is no longer running", ErrRetagStatus, job.ID)
+	}
+	return nil
+}
+
+// finish closes a job and records its outcome event, failures are only logged
+func (w *RetagWorker) finish(job *RetagJob, status RetagStatus, reason string) {
+	now := time.Now()
+	updates := map[string]any{"status": status, "finished_at": now, "error": reason}
+	if err := w.engine.updateRetagJob(job, updates); err != nil {
+		slog.Error("Failed to finish retag job", "job", job.ID, "status", status, "error", err)
+		return
+	}
+	job.Status, job.FinishedAt, job.Error = status, &now, reason
+
+	severity, message := SeverityInfo, fmt.Sprintf("retag job %d %s: %s %s on %d assets", job.ID, status, job.Action, job.Tag.Name, job.Changed)
+	if status == RetagFailed {
+		severity, message = SeverityError, fmt.Sprintf("retag job %d failed: %s", job.ID, reason)
+	}
+
+	event, err := NewEvent(EventRetag, severity, message, map[string]any{
+		"job":       job.ID,
+		"tag":       job.Tag.Name,
+		"action":    job.Action,
+		"status":    status,
+		"processed": job.Processed,
+		"changed":   job.Changed,
+		"detached":  job.Detached,
+	})
+	if err != nil {
+		slog.Error("Failed to build retag event", "error", err)
+		return
+	}
+	event.Principal = job.RequestedBy
+	if err := w.engine.CreateEventRecord(event); err != nil {
+		slog.Error("Failed to record retag event", "error", err)
+	}
+}
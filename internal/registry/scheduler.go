@@ -0,0 +1,122 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Job is a periodic maintenance task. Destructive jobs also Plan, which lists what a run would remove.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+	Plan     func(ctx context.Context) (*DryRunReport, error)
+}
+
+// Scheduler runs jobs on their interval until its context is done
+type Scheduler struct {
+	jobs    []Job
+	elector *Elector
+	wg      sync.WaitGroup
+}
+
+func NewScheduler(jobs ...Job) *Scheduler {
+	return &Scheduler{jobs: jobs}
+}
+
+// RequireLeader only runs jobs while the elector holds leadership,
+// so a job runs on exactly one replica
+func (s *Scheduler) RequireLeader(elector *Elector) *Scheduler {
+	s.elector = elector
+	return s
+}
+
+// Start runs every job once right away and then on its interval
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Wait blocks until every job loop stopped
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	slog.Info("Scheduling job", "job", job.Name, "interval", job.Interval)
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		if s.elector == nil || s.elector.IsLeader() {
+			started := time.Now()
+			if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+				slog.Error("Job failed", "job", job.Name, "error", err)
+			} else {
+				slog.Debug("Job completed", "job", job.Name, "duration", time.Since(started))
+			}
+		} else {
+			slog.Debug("Skipping job, not the leader", "job", job.Name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// MaintenanceJobs returns the periodic jobs the registry needs while serving
+func (engine *Engine) MaintenanceJobs() []Job {
+	jobs := []Job{
+		{Name: "partitions", Interval: DEFAULT_PARTITION_JOB_INTERVAL, Run: engine.MaintainPartitions, Plan: engine.PlanRetention},
+		engine.NewDeletionWorker().Job(DEFAULT_DELETION_INTERVAL),
+		engine.NewWebhookWorker().Job(DEFAULT_WEBHOOK_INTERVAL),
+		engine.NewRetagWorker().Job(DEFAULT_RETAG_INTERVAL),
+		{Name: "idempotency", Interval: DEFAULT_IDEMPOTENCY_INTERVAL, Run: engine.PurgeIdempotencyKeys},
+	}
+
+	if engine.gcInterval > 0 {
+		jobs = append(jobs, engine.NewGarbageCollector().Job(engine.gcInterval))
+	}
+	if engine.meteringInterval > 0 {
+		jobs = append(jobs, engine.meteringJob(engine.meteringInterval))
+	}
+	for _, s := range engine.enrichers {
+		jobs = append(jobs, engine.NewEnrichmentWorker(s.enricher).Job(s.interval))
+	}
+	if engine.digestInterval > 0 {
+		jobs = append(jobs, engine.digestJobs(engine.digestAlgorithms, engine.digestInterval)...)
+	}
+	return jobs
+}
+
+// Jobs returns every maintenance job the registry knows, including the ones that are not scheduled,
+// so an operator can run any of them once, e.g. from cron instead of the embedded scheduler
+func (engine *Engine) Jobs() []Job {
+	jobs := []Job{
+		{Name: "partitions", Interval: DEFAULT_PARTITION_JOB_INTERVAL, Run: engine.MaintainPartitions, Plan: engine.PlanRetention},
+		engine.NewDeletionWorker().Job(DEFAULT_DELETION_INTERVAL),
+		engine.NewGarbageCollector().Job(cmp.Or(engine.gcInterval, DEFAULT_GC_INTERVAL)),
+		engine.meteringJob(cmp.Or(engine.meteringInterval, DEFAULT_METERING_INTERVAL)),
+		engine.NewWebhookWorker().Job(DEFAULT_WEBHOOK_INTERVAL),
+		engine.NewRetagWorker().Job(DEFAULT_RETAG_INTERVAL),
+		{Name: "idempotency", Interval: DEFAULT_IDEMPOTENCY_INTERVAL, Run: engine.PurgeIdempotencyKeys},
+	}
+	for _, s := range engine.enrichers {
+		jobs = append(jobs, engine.NewEnrichmentWorker(s.enricher).Job(s.interval))
+	}
+	// every registered algorithm can be backfilled on demand, not only the scheduled ones
+	jobs = append(jobs, engine.digestJobs(DigestAlgorithms(), cmp.Or(engine.digestInterval, DEFAULT_DIGEST_INTERVAL))...)
+	return jobs
+}
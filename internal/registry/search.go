@@ -2,13 +2,31 @@ package registry
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
 )
 
 const (
 	SearchDefaultLimit = 150
 	SearchMaxLimit     = 1000
+
+	SortAscending  = "asc"
+	SortDescending = "desc"
 )
 
+// ErrCursorNotFound is returned when the asset a sorted page continues after was purged, its sort value is gone with it
+var ErrCursorNotFound = registryerrors.New(registryerrors.ErrValidation, "page cursor asset no longer exists")
+
+// sortableAssetColumns maps the sort fields clients may order assets by to their column
+var sortableAssetColumns = map[string]string{
+	"id":         "id",
+	"size":       "size_bytes",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"display":    "display",
+}
+
 type SearchAssetsQuery struct {
 	Cursor       uint
 	Limit        uint
@@ -17,12 +35,28 @@ type SearchAssetsQuery struct {
 	IncludedTags []string
 	ExcludedTags []string
 	CheckSums    []string
+	Licenses     []string
+	Owners       []string
+	Ownerless    bool
+	Extra        []ExtraFilter
+
+	// time and size ranges, nil bounds are open
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	UpdatedAfter *time.Time
+	MinSize      *int64
+	MaxSize      *int64
+
+	// OrderBy is the sort column, ties and pages are always broken by id
+	OrderBy    string
+	Descending bool
 }
 
 func (q SearchAssetsQuery) String() string {
 	return fmt.Sprintf(
-		"SearchAssetsQuery{Cursor: %d, Limit: %d, MimeType: %q, State: %v, IncludedTags: %v, ExcludedTags: %v}",
-		q.Cursor, q.Limit, q.MimeType, q.State, q.IncludedTags, q.ExcludedTags,
+		"SearchAssetsQuery{Cursor: %d, Limit: %d, MimeType: %q, State: %v, IncludedTags: %v, ExcludedTags: %v, Licenses: %v, Owners: %v, Ownerless: %t, Extra: %v, CreatedFrom: %v, CreatedTo: %v, UpdatedAfter: %v, MinSize: %v, MaxSize: %v, OrderBy: %q, Descending: %t}",
+		q.Cursor, q.Limit, q.MimeType, q.State, q.IncludedTags, q.ExcludedTags, q.Licenses, q.Owners, q.Ownerless, q.Extra,
+		q.CreatedFrom, q.CreatedTo, q.UpdatedAfter, q.MinSize, q.MaxSize, q.OrderBy, q.Descending,
 	)
 }
 
@@ -132,6 +166,122 @@ func WithChecksums(checksums ...string) SearchAssetsOption {
 	}
 }
 
+// WithLicense keeps assets under any of the given SPDX identifiers
+func WithLicense(licenses ...string) SearchAssetsOption {
+	return func(q *SearchAssetsQuery) error {
+		if len(licenses) == 0 {
+			return fmt.Errorf("at least one license must be provided")
+		}
+		for _, license := range licenses {
+			if err := ValidateLicense(license); err != nil || license == "" {
+				return fmt.Errorf("invalid license %q", license)
+			}
+		}
+
+		q.Licenses = licenses
+		return nil
+	}
+}
+
+// WithOwner keeps assets owned by any of owners
+func WithOwner(owners ...string) SearchAssetsOption {
+	return func(q *SearchAssetsQuery) error {
+		if len(owners) == 0 {
+			return fmt.Errorf("at least one owner must be provided")
+		}
+		for _, owner := range owners {
+			if err := ValidateOwner(owner); err != nil || owner == "" {
+				return fmt.Errorf("invalid owner %q", owner)
+			}
+		}
+		q.Owners = owners
+		return nil
+	}
+}
+
+// WithoutOwner keeps assets nobody owns
+func WithoutOwner() SearchAssetsOption {
+	return func(q *SearchAssetsQuery) error {
+		q.Ownerless = true
+		return nil
+	}
+}
+
+// WithCreatedBetween keeps assets created in [from, to), a zero bound leaves that side open
+func WithCreatedBetween(from time.Time, to time.Time) SearchAssetsOption {
+	return func(q *SearchAssetsQuery) error {
+		if from.IsZero() && to.IsZero() {
+			return fmt.Errorf("%w: at least one creation bound must be provided", ErrValidation)
+		}
+		if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+			return fmt.Errorf("%w: created from %s must be before %s", ErrValidation, from.Format(time.RFC3339), to.Format(time.RFC3339))
+		}
+
+		q.CreatedFrom, q.CreatedTo = nil, nil
+		if !from.IsZero() {
+			q.CreatedFrom = &from
+		}
+		if !to.IsZero() {
+			q.CreatedTo = &to
+		}
+		return nil
+	}
+}
+
+// WithUpdatedAfter keeps assets changed after t
+func WithUpdatedAfter(t time.Time) SearchAssetsOption {
+	return func(q *SearchAssetsQuery) error {
+		if t.IsZero() {
+			return fmt.Errorf("%w: updated after time cannot be empty", ErrValidation)
+		}
+		q.UpdatedAfter = &t
+		return nil
+	}
+}
+
+// WithSizeRange keeps assets between min and max bytes inclusive, a negative bound leaves that side open
+func WithSizeRange(min int64, max int64) SearchAssetsOption {
+	return func(q *SearchAssetsQuery) error {
+		if min < 0 && max < 0 {
+			return fmt.Errorf("%w: at least one size bound must be provided", ErrValidation)
+		}
+		if min >= 0 && max >= 0 && min > max {
+			return fmt.Errorf("%w: min size %d exceeds max size %d", ErrValidation, min, max)
+		}
+
+		q.MinSize, q.MaxSize = nil, nil
+		if min >= 0 {
+			q.MinSize = &min
+		}
+		if max >= 0 {
+			q.MaxSize = &max
+		}
+		return nil
+	}
+}
+
+// WithOrderBy sorts assets by one of id, size, created_at, updated_at or display, direction is asc or desc
+func WithOrderBy(field string, direction string) SearchAssetsOption {
+	return func(q *SearchAssetsQuery) error {
+		column, ok := sortableAssetColumns[NormalizeString(field)]
+		if !ok {
+			return fmt.Errorf("%w: assets can't be sorted by %q", ErrValidation, field)
+		}
+
+		switch NormalizeString(direction) {
+		case "", SortAscending:
+			q.Descending = false
+		case SortDescending:
+			q.Descending = true
+		default:
+			return fmt.Errorf("%w: sort direction must be %s or %s, got %q", ErrValidation, SortAscending, SortDescending, direction)
+		}
+
+		q.OrderBy = column
+		return nil
+	}
+}
+
 func NewSearchAssetsQuery(opts ...SearchAssetsOption) (*SearchAssetsQuery, error) {
 	// Initialize with defaults
 	query := &SearchAssetsQuery{
@@ -139,6 +289,7 @@ func NewSearchAssetsQuery(opts ...SearchAssetsOption) (*SearchAssetsQuery, error
 		Limit:        SearchDefaultLimit,
 		IncludedTags: []string{},
 		ExcludedTags: []string{},
+		OrderBy:      "id",
 	}
 
 	// Apply all options
@@ -0,0 +1,180 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DRIVER_POSTGRES = "postgres"
+	DRIVER_SQLITE   = "sqlite"
+
+	DEFAULT_DATABASE_DRIVER = DRIVER_POSTGRES
+	DEFAULT_SQLITE_DATABASE = "aether.db"
+)
+
+// DatabaseDrivers lists the supported registry database drivers
+func DatabaseDrivers() []string {
+	return []string{DRIVER_POSTGRES, DRIVER_SQLITE}
+}
+
+// isSQLite reports whether the registry runs on the embedded sqlite driver
+func (engine *Engine) isSQLite() bool {
+	return engine.databaseDriver == DRIVER_SQLITE
+}
+
+// sqliteDSN points at the database file, :memory: is shared by every pooled connection
+func (engine *Engine) sqliteDSN() DSN {
+	name := engine.databaseName
+	if name == "" || name == DEFAULT_DATABASE_NAME {
+		name = DEFAULT_SQLITE_DATABASE
+	}
+
+	params := "_foreign_keys=on&_busy_timeout=5000"
+	if name == ":memory:" {
+		return DSN("file::memory:?cache=shared&" + params)
+	}
+
+	sep := "?"
+	if strings.Contains(name, "?") {
+		sep = "&"
+	}
+	return DSN("file:" + name + sep + "_journal_mode=WAL&" + params)
+}
+
+// sqliteLogsDDL creates the append only tables that are range partitioned on postgres.
+// Sqlite has no partitioning, expired rows are deleted instead.
+const sqliteLogsDDL = `
+	CREATE TABLE IF NOT EXISTS access_logs (
+		id         integer PRIMARY KEY AUTOINCREMENT,
+		created_at datetime      NOT NULL,
+		principal  varchar(200)  NOT NULL,
+		project    varchar(63),
+		client_ip  varchar(100),
+		user_agent varchar(300),
+		checksum   varchar(64),
+		operation  varchar(10)   NOT NULL,
+		bucket     varchar(63),
+		key        varchar(1024),
+		expires_at datetime      NOT NULL,
+		expires_in bigint        NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS access_logs_created_at_idx ON access_logs (created_at);
+	CREATE INDEX IF NOT EXISTS access_logs_principal_idx ON access_logs (principal);
+	CREATE INDEX IF NOT EXISTS access_logs_project_idx ON access_logs (project);
+	CREATE INDEX IF NOT EXISTS access_logs_checksum_idx ON access_logs (checksum);
+	CREATE INDEX IF NOT EXISTS access_logs_operation_idx ON access_logs (operation);
+
+	CREATE TABLE IF NOT EXISTS events (
+		id         integer PRIMARY KEY AUTOINCREMENT,
+		created_at datetime     NOT NULL,
+		kind       varchar(100) NOT NULL,
+		severity   varchar(10)  NOT NULL,
+		principal  varchar(200),
+		project    varchar(63),
+		message    text,
+		data       jsonb
+	);
+
+	CREATE INDEX IF NOT EXISTS events_created_at_idx ON events (created_at);
+	CREATE INDEX IF NOT EXISTS events_kind_idx ON events (kind);
+	CREATE INDEX IF NOT EXISTS events_principal_idx ON events (principal);
+
+	CREATE TABLE IF NOT EXISTS audit_entries (
+		id         integer PRIMARY KEY AUTOINCREMENT,
+		created_at datetime     NOT NULL,
+		actor      varchar(200) NOT NULL,
+		project    varchar(63),
+		request_id varchar(100),
+		action     varchar(10)  NOT NULL,
+		entity     varchar(100) NOT NULL,
+		entity_id  varchar(100),
+		before     jsonb,
+		after      jsonb
+	);
+
+	CREATE INDEX IF NOT EXISTS audit_entries_created_at_idx ON audit_entries (created_at);
+	CREATE INDEX IF NOT EXISTS audit_entries_actor_idx ON audit_entries (actor);
+	CREATE INDEX IF NOT EXISTS audit_entries_request_id_idx ON audit_entries (request_id);
+	CREATE INDEX IF NOT EXISTS audit_entries_entity_idx ON audit_entries (entity, entity_id);
+`
+
+// createSQLiteLogTables creates the access log, event and audit tables
+func (engine *Engine) createSQLiteLogTables() error {
+	// columns added after the table was first created
+	migrator := engine.DatabaseClient.Migrator()
+	if migrator.HasTable(&AccessLog{}) && !migrator.HasColumn(&AccessLog{}, "Project") {
+		if err := migrator.AddColumn(&AccessLog{}, "Project"); err != nil {
+			return fmt.Errorf("add access log project: %w", err)
+		}
+	}
+
+	for _, stmt := range strings.Split(sqliteLogsDDL, ";") {
+		if strings.TrimSpace(stmt) == "" {
+			continue
+		}
+		if err := engine.DatabaseClient.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("create log tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// pruneSQLiteLogTables deletes rows older than the table retention
+func (engine *Engine) pruneSQLiteLogTables(db *gorm.DB, now time.Time) error {
+	for _, pt := range engine.partitionedTables() {
+		if pt.Retention <= 0 {
+			continue
+		}
+
+		tx := db.Exec(fmt.Sprintf(`DELETE FROM %q WHERE %q < ?`, pt.Table, pt.Column), now.Add(-pt.Retention))
+		if tx.Error != nil {
+			return fmt.Errorf("prune %q: %w", pt.Table, tx.Error)
+		}
+		if tx.RowsAffected > 0 {
+			slog.Info("Pruned expired rows", "table", pt.Table, "rows", tx.RowsAffected)
+		}
+	}
+	return nil
+}
+
+// planSQLiteLogTables adds the rows pruneSQLiteLogTables would delete to plan, one candidate per table.
+// Sqlite does not size rows, their candidates carry no bytes.
+func (engine *Engine) planSQLiteLogTables(db *gorm.DB, now time.Time, plan *DryRunReport) error {
+	for _, pt := range engine.partitionedTables() {
+		if pt.Retention <= 0 {
+			continue
+		}
+
+		var rows int64
+		err := db.Raw(fmt.Sprintf(`SELECT COUNT(*) FROM %q WHERE %q < ?`, pt.Table, pt.Column), now.Add(-pt.Retention)).Scan(&rows).Error
+		if err != nil {
+			return fmt.Errorf("count %q expired rows: %w", pt.Table, err)
+		}
+		if rows > 0 {
+			plan.Add(Candidate{Kind: CandidateRows, Name: pt.Table, Reason: fmt.Sprintf("%d rows older than %s", rows, pt.Retention)})
+		}
+	}
+	return nil
+}
+
+// localLocks stands in for advisory locks on sqlite, an embedded database has a single process
+var localLocks sync.Map
+
+// tryLocalLock takes a process wide named lock, nil when it is held
+func tryLocalLock(ctx context.Context, name string) *Lock {
+	mu, _ := localLocks.LoadOrStore(name, &sync.Mutex{})
+	if !mu.(*sync.Mutex).TryLock() {
+		return nil
+	}
+
+	slog.Debug("Acquired local lock", "lock", name)
+	return &Lock{name: name, key: lockKey(name), local: mu.(*sync.Mutex)}
+}
@@ -0,0 +1,114 @@
+package registry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"time"
+)
+
+// STAGING_ID_BYTES is the random length of a staged upload id
+const STAGING_ID_BYTES = 16
+
+var stagingIDRe = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// A staged upload lets a client stream content whose checksum it only knows once the last byte is sent:
+// the object is written to a random staging key first, then promoted under the checksum computed by the client.
+
+// NewStagingID generates the random id of a staged upload
+func NewStagingID() (string, error) {
+	raw := make([]byte, STAGING_ID_BYTES)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate staging id: %w", err)
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// ValidateStagingID checks the format of a staged upload id
+func ValidateStagingID(id string) error {
+	if !stagingIDRe.MatchString(id) {
+		return fmt.Errorf("%w: invalid staging id %q", ErrValidation, id)
+	}
+	return nil
+}
+
+// StagingUrlExpire generates a presigned upload URL of a default project staged upload
+func (engine *Engine) StagingUrlExpire(ctx context.Context, id string, expire time.Duration) (*PresignedUrl, error) {
+	return engine.defaultNamespace().StagingUrlExpire(ctx, id, expire)
+}
+
+// StatStagedUpload returns the stored metadata of a default project staged upload
+func (engine *Engine) StatStagedUpload(ctx context.Context, id string) (*ObjectInfo, error) {
+	if err := ValidateStagingID(id); err != nil {
+		return nil, err
+	}
+
+	ns := engine.defaultNamespace()
+	info, err := ns.StatObject(ctx, ns.StagingKey(id))
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("%w: staging id %s", ErrUploadNotFound, id)
+	}
+	return info, nil
+}
+
+// PromoteStagedAsset moves a default project staged upload to the ingress key of its asset and promotes it
+func (engine *Engine) PromoteStagedAsset(ctx context.Context, id string, sha256 string) (*Asset, error) {
+	return engine.defaultNamespace().PromoteStagedAsset(ctx, id, sha256)
+}
+
+// PromoteStagedAsset moves a staged upload to the ingress key of its pending asset, then promotes it like a regular upload.
+// The copy makes the store record the object checksum, which promotion checks against the asset.
+// A ready asset only drops the staged copy, the content is already stored.
+func (ns *Namespace) PromoteStagedAsset(ctx context.Context, id string, sha256 string) (*Asset, error) {
+	if err := ValidateStagingID(id); err != nil {
+		return nil, err
+	}
+
+	asset, err := ns.engine.GetAssetRecord(sha256)
+	if err != nil {
+		return nil, err
+	}
+
+	staging := ns.StagingKey(id)
+	if err := ns.Check(staging); err != nil {
+		return nil, err
+	}
+
+	switch asset.State {
+	case StatusReady:
+	case StatusPending:
+		info, err := ns.engine.statObject(ctx, ns.bucket, staging)
+		if err != nil {
+			return nil, err
+		}
+		if info == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUploadNotFound, staging)
+		}
+		if info.Size > MAX_COPY_SIZE {
+			return nil, fmt.Errorf("%w: %d bytes is above the %d bytes copy limit", ErrObjectTooLarge, info.Size, MAX_COPY_SIZE)
+		}
+
+		if err := ns.engine.copyObject(ctx, ns.bucket, staging, ns.IngressKey(asset.Checksum)); err != nil {
+			return nil, err
+		}
+
+		if asset, err = ns.PromoteAsset(ctx, sha256); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s is %s", ErrAssetNotPending, asset.Checksum, asset.State)
+	}
+
+	if err := ns.engine.deleteObjects(ctx, ns.bucket, staging); err != nil {
+		slog.Warn("failed to remove staged upload", "key", staging, "error", err)
+	}
+
+	slog.Debug("Promoted staged upload", "checksum", asset.Checksum, "staging", id, "project", ns.project)
+	return asset, nil
+}
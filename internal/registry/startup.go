@@ -0,0 +1,45 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	DEFAULT_STARTUP_BACKOFF     = time.Second
+	DEFAULT_STARTUP_MAX_BACKOFF = 30 * time.Second
+)
+
+// waitFor calls connect until it succeeds, backing off exponentially between attempts.
+// Without a startup wait connect is called once, see WithStartupWait.
+func (engine *Engine) waitFor(dependency string, connect func(ctx context.Context) error) error {
+	if engine.startupWait <= 0 {
+		return connect(context.Background())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), engine.startupWait)
+	defer cancel()
+
+	backoff := engine.startupBackoff
+	for attempt := 1; ; attempt++ {
+		err := connect(ctx)
+		if err == nil {
+			if attempt > 1 {
+				slog.Info("Dependency is ready", "dependency", dependency, "attempts", attempt)
+			}
+			return nil
+		}
+
+		// give up when the next attempt would come after the wait
+		deadline, _ := ctx.Deadline()
+		if time.Until(deadline) < backoff {
+			return fmt.Errorf("%s not ready within %s, gave up after %d attempts: %w", dependency, engine.startupWait, attempt, err)
+		}
+
+		slog.Warn("Dependency not ready, retrying", "dependency", dependency, "attempt", attempt, "retry_in", backoff, "error", err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, DEFAULT_STARTUP_MAX_BACKOFF)
+	}
+}
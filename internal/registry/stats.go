@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// StatsGroup is the asset count and bytes of one value of a grouping
+type StatsGroup struct {
+	Key   string
+	Count int64
+	Bytes int64
+}
+
+// AssetStats aggregates the registry contents.
+// Totals count every asset, StoredBytes only ready ones, ByTag leaves deleted assets out.
+type AssetStats struct {
+	TotalAssets int64
+	TotalBytes  int64
+	StoredBytes int64
+	ByState     []StatsGroup
+	ByMimeType  []StatsGroup
+	ByTag       []StatsGroup
+}
+
+// Stats computes asset totals and their breakdown by state, mime type and tag
+func (engine *Engine) Stats(ctx context.Context) (*AssetStats, error) {
+	slog.Debug("Computing asset stats")
+
+	db := engine.DatabaseClient.WithContext(ctx)
+	stats := &AssetStats{}
+
+	err := db.Model(&Asset{}).
+		Select("state AS key, COUNT(*) AS count, COALESCE(SUM(size_bytes), 0) AS bytes").
+		Group("state").
+		Order("state").
+		Scan(&stats.ByState).Error
+	if err != nil {
+		return nil, fmt.Errorf("count assets by state: %w", err)
+	}
+
+	for _, group := range stats.ByState {
+		stats.TotalAssets += group.Count
+		stats.TotalBytes += group.Bytes
+		if group.Key == string(StatusReady) {
+			stats.StoredBytes = group.Bytes
+		}
+	}
+
+	err = db.Model(&Asset{}).
+		Select("COALESCE(mime_type, '') AS key, COUNT(*) AS count, COALESCE(SUM(size_bytes), 0) AS bytes").
+		Group("mime_type").
+		Order("count DESC, key").
+		Scan(&stats.ByMimeType).Error
+	if err != nil {
+		return nil, fmt.Errorf("count assets by mime type: %w", err)
+	}
+
+	byTag := db.Table("asset_tags").
+		Select("tags.name AS key, COUNT(*) AS count, COALESCE(SUM(assets.size_bytes), 0) AS bytes").
+		Joins("JOIN tags ON tags.id = asset_tags.tag_id AND tags.deleted_at IS NULL").
+		Joins("JOIN assets ON assets.id = asset_tags.asset_id AND assets.deleted_at IS NULL").
+		Where("assets.state <> ?", StatusDeleted)
+	err = scopeAlias(byTag, ctx, "assets").
+		Group("tags.name").
+		Order("count DESC, key").
+		Scan(&stats.ByTag).Error
+	if err != nil {
+		return nil, fmt.Errorf("count assets by tag: %w", err)
+	}
+
+	return stats, nil
+}
@@ -2,72 +2,197 @@ package registry
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log/slog"
 	"path"
+	"sync"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/UnivocalX/aether/pkg/tracing"
 )
 
-// Ping verifies S3 connection
+// Ping verifies the storage connection
 func (engine *Engine) PingStorage(ctx context.Context) error {
-	_, err := engine.S3Client.HeadBucket(ctx, &s3.HeadBucketInput{
-		Bucket: aws.String(engine.bucket),
-	})
+	return engine.store.Ping(ctx)
+}
+
+// ObjectExists reports whether an object is stored under the given key
+func (engine *Engine) ObjectExists(ctx context.Context, key string) (bool, error) {
+	return engine.objectExists(ctx, engine.defaultBucket(), key)
+}
+
+func (engine *Engine) objectExists(ctx context.Context, b Storage, key string) (bool, error) {
+	info, err := b.Stat(ctx, key)
 	if err != nil {
-		return fmt.Errorf("bucket access: %w", err)
+		return false, err
 	}
-	return nil
+	return info != nil, nil
+}
+
+// DeleteObjects removes the given keys of the default project, missing keys are ignored
+func (engine *Engine) DeleteObjects(ctx context.Context, keys ...string) error {
+	return engine.defaultNamespace().DeleteObjects(ctx, keys...)
+}
+
+func (engine *Engine) deleteObjects(ctx context.Context, b Storage, keys ...string) error {
+	slog.Debug("Deleting objects", "total", len(keys))
+	return b.Delete(ctx, keys...)
+}
+
+// StatObject returns the stored object metadata of a default project key, nil when missing
+func (engine *Engine) StatObject(ctx context.Context, key string) (*ObjectInfo, error) {
+	return engine.defaultNamespace().StatObject(ctx, key)
+}
+
+func (engine *Engine) statObject(ctx context.Context, b Storage, key string) (*ObjectInfo, error) {
+	return b.Stat(ctx, key)
 }
 
-// IngressKey generates the ingress S3 key path
+// copyObject copies a key within the store, keeping its SHA256 checksum
+func (engine *Engine) copyObject(ctx context.Context, b Storage, src string, dst string) error {
+	return b.Promote(ctx, src, dst)
+}
+
+// ObjectInfo describes a stored object returned by ListObjects or StatObject
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+	// Checksum is the hex SHA256 stored with the object, only set by StatObject
+	Checksum string
+	// ContentType is the media type stored with the object, only set by StatObject on S3
+	ContentType string
+}
+
+// ErrStopListing can be returned by a ListObjects callback to end the listing early
+var ErrStopListing = errors.New("stop listing")
+
+// ListObjects walks every object under prefix (relative to the engine prefix) and calls fn for each.
+// Pages are fetched at most at the configured list rate, and the walk stops when ctx is done.
+func (engine *Engine) ListObjects(ctx context.Context, prefix string, fn func(ObjectInfo) error) error {
+	return engine.listObjects(ctx, engine.defaultBucket(), path.Join(engine.prefix, prefix), fn)
+}
+
+func (engine *Engine) listObjects(ctx context.Context, b Storage, root string, fn func(ObjectInfo) error) error {
+	if root != "" && root != "." {
+		root += "/"
+	} else {
+		root = ""
+	}
+
+	slog.Debug("Listing objects", "prefix", root)
+
+	ticker := time.NewTicker(time.Second / time.Duration(engine.listRate))
+	defer ticker.Stop()
+
+	first := true
+	err := b.List(ctx, root, engine.listPage, func(page []ObjectInfo) error {
+		if !first {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+		first = false
+
+		for _, info := range page {
+			if err := fn(info); err != nil {
+				return err
+			}
+		}
+		return ctx.Err()
+	})
+
+	if errors.Is(err, ErrStopListing) {
+		return nil
+	}
+	return err
+}
+
+// IngressKey generates the ingress S3 key path of the default project
 func (engine *Engine) IngressKey(checksum string) string {
-	return path.Join(engine.prefix, "ingress", checksum)
+	return engine.defaultNamespace().IngressKey(checksum)
 }
 
-// CuratedKey generates the curated S3 key path
+// CuratedKey generates the curated S3 key path of the default project
 func (engine *Engine) CuratedKey(checksum string) string {
-	return path.Join(engine.prefix, "curated", checksum)
+	return engine.defaultNamespace().CuratedKey(checksum)
+}
+
+// PreviewKey generates the preview image key path of the default project
+func (engine *Engine) PreviewKey(checksum string) string {
+	return engine.defaultNamespace().PreviewKey(checksum)
+}
+
+// AssetKeys lists every key an asset of the default project may have stored, for purges
+func (engine *Engine) AssetKeys(checksum string) []string {
+	return []string{engine.IngressKey(checksum), engine.CuratedKey(checksum), engine.PreviewKey(checksum)}
+}
+
+// StoredBytes sums the size of the stored objects of an asset, see AssetKeys
+func (engine *Engine) StoredBytes(ctx context.Context, checksum string) (int64, error) {
+	var total int64
+	for _, key := range engine.AssetKeys(checksum) {
+		info, err := engine.StatObject(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+		if info != nil {
+			total += info.Size
+		}
+	}
+	return total, nil
 }
 
 // IngressURL generates a presigned URL for upload (default expiry)
 func (engine *Engine) IngressURL(ctx context.Context, checksum string) (*PresignedUrl, error) {
-	return engine.IngressUrlExpire(ctx, checksum, DEFAULT_PRESIGN_TTL)
+	return engine.IngressUrlExpire(ctx, checksum, engine.presignTTL)
 }
 
+// IngressUrlExpire generates a presigned URL for upload, the expiry is clamped into the configured bounds
 func (engine *Engine) IngressUrlExpire(ctx context.Context, sha256 string, expire time.Duration) (*PresignedUrl, error) {
-	key := engine.IngressKey(sha256)
+	return engine.defaultNamespace().IngressUrlExpire(ctx, sha256, expire)
+}
 
-	input := &s3.PutObjectInput{
-		Bucket:            aws.String(engine.bucket),
-		Key:               aws.String(key),
-		ChecksumAlgorithm: types.ChecksumAlgorithmSha256,
-		ChecksumSHA256:    aws.String(sha256),
-	}
+// IngressUrlSize generates a presigned upload URL bound to a declared size, the upload must send exactly that many bytes
+func (engine *Engine) IngressUrlSize(ctx context.Context, sha256 string, expire time.Duration, size int64) (*PresignedUrl, error) {
+	return engine.defaultNamespace().IngressUrlSize(ctx, sha256, expire, size)
+}
+
+func (engine *Engine) presignPut(ctx context.Context, b Storage, key string, sha256 string, expire time.Duration, size int64) (*PresignedUrl, error) {
+	expire = engine.PresignTTL(expire)
 
-	res, err := engine.PresignClient.PresignPutObject(ctx, input,
-		s3.WithPresignExpires(expire),
-	)
+	ctx, span := engine.startSpan(ctx, "presign put", trace.WithAttributes(
+		attribute.String("storage.bucket", b.Name()),
+		attribute.String("storage.key", key),
+	))
+	defer span.End()
 
+	url, err := b.IngressURL(ctx, key, sha256, expire+engine.presignSkew, size)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate presigned put url: %w", err)
+		tracing.RecordError(span, err)
+		return nil, err
 	}
 
+	// the url stays valid presignSkew longer, the reported expiry leaves that margin to skewed clocks
 	now := time.Now()
 	expiresAt := now.Add(expire)
 
 	presignUrl := &PresignedUrl{
-		URL:       Secret(res.URL),
+		URL:       Secret(url),
 		ExpiresAt: expiresAt,
 		ExpiresIn: expire,
 		Checksum:  sha256,
 		Key:       key,
 		Operation: "put",
-		Bucket:    engine.bucket,
+		Bucket:    b.Name(),
 	}
+	engine.metrics.presigns.WithLabelValues(presignUrl.Operation).Inc()
 
 	slog.Debug("Generated PUT URL with checksum validation",
 		"sha256", sha256,
@@ -79,37 +204,80 @@ func (engine *Engine) IngressUrlExpire(ctx context.Context, sha256 string, expir
 
 // CuratedUrl generates a presigned URL for download (default expiry)
 func (engine *Engine) CuratedUrl(ctx context.Context, sha256 string) (*PresignedUrl, error) {
-	return engine.CuratedUrlExpire(ctx, sha256, DEFAULT_PRESIGN_TTL)
+	return engine.CuratedUrlExpire(ctx, sha256, engine.presignTTL)
 }
 
-// CuratedUrlExpire generates a presigned URL for download with custom expiry
+// CuratedUrlExpire generates a presigned URL for download, the expiry is clamped into the configured bounds
 func (engine *Engine) CuratedUrlExpire(ctx context.Context, sha256 string, expire time.Duration) (*PresignedUrl, error) {
-	key := engine.CuratedKey(sha256)
+	return engine.defaultNamespace().CuratedUrlExpire(ctx, sha256, expire)
+}
+
+// PresignConcurrency bounds the presign calls a batch runs at once
+const PresignConcurrency = 16
 
-	input := &s3.GetObjectInput{
-		Bucket: aws.String(engine.bucket),
-		Key:    aws.String(key),
+// CuratedUrlsExpire presigns downloads of many assets concurrently.
+// Results and errors are aligned with checksums, a failed presign leaves a nil url and its error.
+func (engine *Engine) CuratedUrlsExpire(ctx context.Context, checksums []string, expire time.Duration) ([]*PresignedUrl, []error) {
+	slog.Debug("Generating GET URLs", "total", len(checksums))
+	return presignAll(ctx, checksums, expire, engine.CuratedUrlExpire)
+}
+
+// PreviewUrlsExpire presigns downloads of many asset previews concurrently, aligned like CuratedUrlsExpire
+func (engine *Engine) PreviewUrlsExpire(ctx context.Context, checksums []string, expire time.Duration) ([]*PresignedUrl, []error) {
+	slog.Debug("Generating preview GET URLs", "total", len(checksums))
+	return presignAll(ctx, checksums, expire, engine.defaultNamespace().PreviewUrlExpire)
+}
+
+// presignAll runs presign over checksums with at most PresignConcurrency calls at once
+func presignAll(ctx context.Context, checksums []string, expire time.Duration, presign func(context.Context, string, time.Duration) (*PresignedUrl, error)) ([]*PresignedUrl, []error) {
+	urls := make([]*PresignedUrl, len(checksums))
+	errs := make([]error, len(checksums))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, PresignConcurrency)
+	for i, checksum := range checksums {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			urls[i], errs[i] = presign(ctx, checksum, expire)
+		}()
 	}
+	wg.Wait()
+
+	return urls, errs
+}
+
+func (engine *Engine) presignGet(ctx context.Context, b Storage, key string, sha256 string, expire time.Duration) (*PresignedUrl, error) {
+	expire = engine.PresignTTL(expire)
+
+	ctx, span := engine.startSpan(ctx, "presign get", trace.WithAttributes(
+		attribute.String("storage.bucket", b.Name()),
+		attribute.String("storage.key", key),
+	))
+	defer span.End()
 
-	res, err := engine.PresignClient.PresignGetObject(ctx, input,
-		s3.WithPresignExpires(expire),
-	)
+	url, err := b.CuratedURL(ctx, key, expire+engine.presignSkew)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate presign get url: %w", err)
+		tracing.RecordError(span, err)
+		return nil, err
 	}
 
+	// the url stays valid presignSkew longer, the reported expiry leaves that margin to skewed clocks
 	now := time.Now()
 	expiresAt := now.Add(expire)
 
 	presignUrl := &PresignedUrl{
-		URL:       Secret(res.URL),
+		URL:       Secret(url),
 		ExpiresAt: expiresAt,
 		ExpiresIn: expire,
 		Checksum:  sha256,
 		Key:       key,
 		Operation: "get",
-		Bucket:    engine.bucket,
+		Bucket:    b.Name(),
 	}
+	engine.metrics.presigns.WithLabelValues(presignUrl.Operation).Inc()
 
 	slog.Debug("Generated GET URL",
 		"sha256", sha256,
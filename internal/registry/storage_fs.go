@@ -0,0 +1,424 @@
+package registry
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+const (
+	// STORAGE_URL_PATH is where the api serves filesystem objects, relative to the storage url
+	STORAGE_URL_PATH = "/v1/storage/"
+
+	// uploads are staged here, under the root so renames stay on one filesystem
+	fsStagingDir = ".uploads"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid storage url signature")
+	ErrObjectNotFound   = registryerrors.New(registryerrors.ErrNotFound, "object not found")
+)
+
+// FilesystemStorage stores registry objects as files under a local directory.
+// There is no object server in front of it, so presigned urls point back at the aether api,
+// which checks the HMAC signature before streaming the object in or out.
+type FilesystemStorage struct {
+	root    string
+	baseURL *url.URL
+	key     []byte
+
+	// maxSize caps writes of unknown size, see WithStorageMaxObjectSize
+	maxSize int64
+}
+
+// SignedRequest is a verified filesystem storage url
+type SignedRequest struct {
+	Operation string
+	Key       string
+	Checksum  string
+	Size      int64
+	ExpiresAt time.Time
+}
+
+// NewFilesystemStorage creates a store under root, signing urls that point at baseURL (the api root, e.g. http://aether:8080/api).
+// Without a signing key a random one is generated, its urls don't survive restarts and only work on this replica.
+func NewFilesystemStorage(root string, baseURL string, key Secret) (*FilesystemStorage, error) {
+	if root == "" {
+		return nil, fmt.Errorf("filesystem storage path required")
+	}
+	if baseURL == "" {
+		return nil, fmt.Errorf("filesystem storage url required")
+	}
+
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("filesystem storage path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, fsStagingDir), 0o750); err != nil {
+		return nil, fmt.Errorf("filesystem storage path: %w", err)
+	}
+
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid filesystem storage url %q", baseURL)
+	}
+
+	signingKey := []byte(key.Value())
+	if len(signingKey) == 0 {
+		slog.Warn("No storage signing key configured, generated a random one; storage urls only work on this replica until restart")
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			return nil, fmt.Errorf("generate storage signing key: %w", err)
+		}
+	}
+
+	return &FilesystemStorage{root: root, baseURL: u, key: signingKey, maxSize: MAX_COPY_SIZE}, nil
+}
+
+func (s *FilesystemStorage) Name() string {
+	return s.root
+}
+
+func (s *FilesystemStorage) Ping(ctx context.Context) error {
+	info, err := os.Stat(s.root)
+	if err != nil {
+		return fmt.Errorf("storage path access: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("storage path %q is not a directory", s.root)
+	}
+	return nil
+}
+
+func (s *FilesystemStorage) IngressURL(ctx context.Context, key string, sha256 string, expire time.Duration, size int64) (string, error) {
+	// staged uploads are signed without a checksum, it is only known once they are written
+	if sha256 != "" {
+		if err := ValidateSHA256(sha256); err != nil {
+			return "", err
+		}
+	}
+	return s.sign("put", key, sha256, size, expire)
+}
+
+func (s *FilesystemStorage) CuratedURL(ctx context.Context, key string, expire time.Duration) (string, error) {
+	return s.sign("get", key, "", 0, expire)
+}
+
+// Promote copies src to dst through the staging area, so dst is never seen half written
+func (s *FilesystemStorage) Promote(ctx context.Context, src string, dst string) error {
+	slog.Debug("Copying object", "from", src, "to", dst)
+
+	srcPath, err := s.path(src)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(srcPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("copy object %q: %w", src, ErrObjectNotFound)
+		}
+		return fmt.Errorf("copy object %q: %w", src, err)
+	}
+	defer f.Close()
+
+	if _, err := s.Write(ctx, dst, f, "", -1); err != nil {
+		return fmt.Errorf("copy object %q to %q: %w", src, dst, err)
+	}
+	return nil
+}
+
+// Put stores r under key, objects are served without a content type
+func (s *FilesystemStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := s.Write(ctx, key, r, "", size)
+	return err
+}
+
+func (s *FilesystemStorage) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		p, err := s.path(key)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("delete object %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Get opens the file stored under key, the caller closes it
+func (s *FilesystemStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.Open(key)
+}
+
+// Stat hashes the file to report its checksum, files carry no stored checksum
+func (s *FilesystemStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("stat object %q: %w", key, err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat object %q: %w", key, err)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return nil, fmt.Errorf("hash object %q: %w", key, err)
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         fi.Size(),
+		ETag:         checksum,
+		LastModified: fi.ModTime(),
+		Checksum:     checksum,
+	}, nil
+}
+
+// List walks the files in lexical key order, like S3 listings
+func (s *FilesystemStorage) List(ctx context.Context, prefix string, pageSize int32, fn func([]ObjectInfo) error) error {
+	dir := prefix[:strings.LastIndex(prefix, "/")+1]
+	start := filepath.Join(s.root, filepath.FromSlash(dir))
+
+	page := make([]ObjectInfo, 0, pageSize)
+	err := filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && p == start {
+				return fs.SkipAll
+			}
+			return err
+		}
+
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if key == fsStagingDir {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		page = append(page, ObjectInfo{Key: key, Size: fi.Size(), LastModified: fi.ModTime()})
+
+		if int32(len(page)) >= pageSize {
+			if err := fn(page); err != nil {
+				return err
+			}
+			page = page[:0]
+		}
+		return ctx.Err()
+	})
+	if err != nil {
+		return fmt.Errorf("list objects %q: %w", prefix, err)
+	}
+
+	if len(page) > 0 {
+		return fn(page)
+	}
+	return nil
+}
+
+// Verify checks a storage url signature for an operation on key
+func (s *FilesystemStorage) Verify(operation string, key string, query url.Values) (*SignedRequest, error) {
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing expiry", ErrInvalidSignature)
+	}
+
+	req := &SignedRequest{
+		Operation: operation,
+		Key:       key,
+		Checksum:  query.Get("sha256"),
+		ExpiresAt: time.Unix(expires, 0),
+	}
+	if raw := query.Get("size"); raw != "" {
+		if req.Size, err = strconv.ParseInt(raw, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: invalid size", ErrInvalidSignature)
+		}
+	}
+
+	signature, err := hex.DecodeString(query.Get("signature"))
+	if err != nil || !hmac.Equal(signature, s.mac(req)) {
+		return nil, ErrInvalidSignature
+	}
+	if time.Now().After(req.ExpiresAt) {
+		return nil, fmt.Errorf("%w: expired at %s", ErrInvalidSignature, req.ExpiresAt.Format(time.RFC3339))
+	}
+
+	return req, nil
+}
+
+// Write stores r under key, checking the SHA256 when set and the size when not negative.
+// Objects of unknown size are capped at the store maximum, reading stops one byte past the size or the cap.
+// A failed check leaves nothing behind.
+func (s *FilesystemStorage) Write(ctx context.Context, key string, r io.Reader, sha256sum string, size int64) (*ObjectInfo, error) {
+	limit := size
+	if size < 0 {
+		limit = s.maxSize
+	}
+	return s.write(ctx, key, r, sha256sum, size, limit)
+}
+
+// WriteAtMost stores r under key like Write with an unknown size, failing with ObjectTooLargeError
+// as soon as more than limit bytes arrive. The store maximum applies to limits above it.
+func (s *FilesystemStorage) WriteAtMost(ctx context.Context, key string, r io.Reader, sha256sum string, limit int64) (*ObjectInfo, error) {
+	if limit <= 0 || limit >= s.maxSize {
+		return s.Write(ctx, key, r, sha256sum, -1)
+	}
+	return s.write(ctx, key, r, sha256sum, -1, limit)
+}
+
+// write stores r under key, reading at most limit bytes
+func (s *FilesystemStorage) write(ctx context.Context, key string, r io.Reader, sha256sum string, size int64, limit int64) (*ObjectInfo, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Join(s.root, fsStagingDir), "object-*")
+	if err != nil {
+		return nil, fmt.Errorf("stage object %q: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("write object %q: %w", key, err)
+	}
+	if written > limit {
+		if size >= 0 {
+			return nil, fmt.Errorf("%w: expected %d bytes, received more", ErrSizeMismatch, size)
+		}
+		if limit < s.maxSize {
+			return nil, &ObjectTooLargeError{Size: written, Limit: limit}
+		}
+		return nil, fmt.Errorf("%w: more than the %d bytes storage limit", ErrObjectTooLarge, limit)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("write object %q: %w", key, err)
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if sha256sum != "" && checksum != sha256sum {
+		return nil, fmt.Errorf("%w: expected %s, received %s", ErrChecksumMismatch, sha256sum, checksum)
+	}
+	if size >= 0 && written != size {
+		return nil, fmt.Errorf("%w: expected %d bytes, received %d bytes", ErrSizeMismatch, size, written)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return nil, fmt.Errorf("write object %q: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return nil, fmt.Errorf("write object %q: %w", key, err)
+	}
+
+	return &ObjectInfo{Key: key, Size: written, ETag: checksum, LastModified: time.Now(), Checksum: checksum}, nil
+}
+
+// Open returns the file stored under key, ErrObjectNotFound when missing
+func (s *FilesystemStorage) Open(key string) (*os.File, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+		}
+		return nil, fmt.Errorf("open object %q: %w", key, err)
+	}
+	return f, nil
+}
+
+// path maps a key to its file, refusing keys that could escape the root
+func (s *FilesystemStorage) path(key string) (string, error) {
+	if key == "" || path.Clean(key) != key || strings.HasPrefix(key, "/") || key == ".." || strings.HasPrefix(key, "../") {
+		return "", fmt.Errorf("%w: invalid object key %q", ErrValidation, key)
+	}
+	if key == fsStagingDir || strings.HasPrefix(key, fsStagingDir+"/") {
+		return "", fmt.Errorf("%w: reserved object key %q", ErrValidation, key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// sign builds a storage url for an operation on key
+func (s *FilesystemStorage) sign(operation string, key string, sha256sum string, size int64, expire time.Duration) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+
+	req := &SignedRequest{
+		Operation: operation,
+		Key:       key,
+		Checksum:  sha256sum,
+		Size:      size,
+		ExpiresAt: time.Now().Add(expire).Truncate(time.Second),
+	}
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(req.ExpiresAt.Unix(), 10))
+	if sha256sum != "" {
+		query.Set("sha256", sha256sum)
+	}
+	if size > 0 {
+		query.Set("size", strconv.FormatInt(size, 10))
+	}
+	query.Set("signature", hex.EncodeToString(s.mac(req)))
+
+	u := *s.baseURL
+	u.Path = u.Path + STORAGE_URL_PATH + key
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// mac signs every field of a storage request, so none can be changed without breaking the url
+func (s *FilesystemStorage) mac(req *SignedRequest) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s\n%s\n%d\n%s\n%d", req.Operation, req.Key, req.ExpiresAt.Unix(), req.Checksum, req.Size)
+	return mac.Sum(nil)
+}
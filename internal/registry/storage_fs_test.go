@@ -0,0 +1,50 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// endless is a body that never ends, writes must stop reading it at their limit
+type endless struct{}
+
+func (endless) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'a'
+	}
+	return len(p), nil
+}
+
+func TestFilesystemWriteStopsAtLimit(t *testing.T) {
+	engine := newTestEngine(t, WithStorageMaxObjectSize(16))
+	store := engine.Storage().(*FilesystemStorage)
+	ctx := context.Background()
+
+	cases := []struct {
+		name string
+		size int64
+		want error
+	}{
+		{"declared size", 4, ErrSizeMismatch},
+		{"unknown size", -1, ErrObjectTooLarge},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			key := "pfx/ingress/" + strings.ReplaceAll(c.name, " ", "-")
+			if _, err := store.Write(ctx, key, endless{}, "", c.size); !errors.Is(err, c.want) {
+				t.Fatalf("write got %v, want %v", err, c.want)
+			}
+			if info, err := store.Stat(ctx, key); info != nil || err != nil {
+				t.Errorf("stat after failed write got %+v, %v, want nothing stored", info, err)
+			}
+		})
+	}
+
+	// objects within the cap are stored whole
+	info, err := store.Write(ctx, "pfx/ingress/small", strings.NewReader("sixteen bytes!!!"), "", -1)
+	if err != nil || info.Size != 16 {
+		t.Fatalf("write within the cap got %+v, %v", info, err)
+	}
+}
@@ -0,0 +1,263 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Storage stores registry objects in one S3 bucket
+type S3Storage struct {
+	bucket  string
+	client  *s3.Client
+	presign *s3.PresignClient
+
+	// notBefore backdates presign signatures, see backdated
+	notBefore time.Duration
+}
+
+// NewS3Storage wraps an S3 client for a bucket
+func NewS3Storage(client *s3.Client, bucket string) *S3Storage {
+	return &S3Storage{bucket: bucket, client: client, presign: s3.NewPresignClient(client)}
+}
+
+// newS3Storage wraps an S3 client for a bucket, signing urls with the engine's presign skew
+func (engine *Engine) newS3Storage(client *s3.Client, bucket string) *S3Storage {
+	store := NewS3Storage(client, bucket)
+	store.notBefore = engine.presignSkew
+	return store
+}
+
+// backdated signs a presigned url notBefore earlier and extends its expiry by as much,
+// so a store whose clock runs behind ours accepts it right away while it still expires on time
+func (s *S3Storage) backdated(expire time.Duration) func(*s3.PresignOptions) {
+	return func(o *s3.PresignOptions) {
+		o.Expires = expire + s.notBefore
+		if s.notBefore > 0 {
+			o.Presigner = backdatedPresigner{HTTPPresignerV4: o.Presigner, by: s.notBefore}
+		}
+	}
+}
+
+// backdatedPresigner moves the signing time of a presigner into the past
+type backdatedPresigner struct {
+	s3.HTTPPresignerV4
+	by time.Duration
+}
+
+func (p backdatedPresigner) PresignHTTP(
+	ctx context.Context, credentials aws.Credentials, r *http.Request,
+	payloadHash string, service string, region string, signingTime time.Time,
+	optFns ...func(*v4.SignerOptions),
+) (string, http.Header, error) {
+	return p.HTTPPresignerV4.PresignHTTP(ctx, credentials, r, payloadHash, service, region, signingTime.Add(-p.by), optFns...)
+}
+
+func (s *S3Storage) Name() string {
+	return s.bucket
+}
+
+func (s *S3Storage) Ping(ctx context.Context) error {
+	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(s.bucket),
+	})
+	if err != nil {
+		return fmt.Errorf("bucket access: %w", err)
+	}
+	return nil
+}
+
+func (s *S3Storage) IngressURL(ctx context.Context, key string, sha256 string, expire time.Duration, size int64) (string, error) {
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}
+
+	// staged uploads are signed without a checksum, it is only known once they are written
+	if sha256 != "" {
+		// S3 expects the checksum base64 encoded, registry checksums are hex
+		raw, err := hex.DecodeString(sha256)
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid SHA256 hex: %w", ErrValidation, err)
+		}
+		input.ChecksumAlgorithm = types.ChecksumAlgorithmSha256
+		input.ChecksumSHA256 = aws.String(base64.StdEncoding.EncodeToString(raw))
+	}
+
+	// signing the length makes S3 reject uploads of any other size
+	if size > 0 {
+		input.ContentLength = aws.Int64(size)
+	}
+
+	res, err := s.presign.PresignPutObject(ctx, input, s.backdated(expire))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate presigned put url: %w", err)
+	}
+	return res.URL, nil
+}
+
+func (s *S3Storage) CuratedURL(ctx context.Context, key string, expire time.Duration) (string, error) {
+	res, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s.backdated(expire))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate presign get url: %w", err)
+	}
+	return res.URL, nil
+}
+
+// Promote copies a key within the bucket, keeping its SHA256 checksum
+func (s *S3Storage) Promote(ctx context.Context, src string, dst string) error {
+	slog.Debug("Copying object", "from", src, "to", dst)
+
+	_, err := s.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:            aws.String(s.bucket),
+		Key:               aws.String(dst),
+		CopySource:        aws.String(url.PathEscape(s.bucket + "/" + src)),
+		ChecksumAlgorithm: types.ChecksumAlgorithmSha256,
+	})
+	if err != nil {
+		return fmt.Errorf("copy object %q to %q: %w", src, dst, err)
+	}
+	return nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		Body:          r,
+		ContentLength: aws.Int64(size),
+		ContentType:   aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("put object %q: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, keys ...string) error {
+	// S3 accepts at most 1000 keys per request
+	for start := 0; start < len(keys); start += 1000 {
+		end := min(start+1000, len(keys))
+
+		objects := make([]types.ObjectIdentifier, 0, end-start)
+		for _, key := range keys[start:end] {
+			objects = append(objects, types.ObjectIdentifier{Key: aws.String(key)})
+		}
+
+		out, err := s.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(s.bucket),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("delete objects: %w", err)
+		}
+
+		if len(out.Errors) > 0 {
+			first := out.Errors[0]
+			return fmt.Errorf("delete object %q: %s", aws.ToString(first.Key), aws.ToString(first.Message))
+		}
+	}
+
+	return nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+		}
+		return nil, fmt.Errorf("get object %q: %w", key, err)
+	}
+	return out.Body, nil
+}
+
+func (s *S3Storage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket:       aws.String(s.bucket),
+		Key:          aws.String(key),
+		ChecksumMode: types.ChecksumModeEnabled,
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("head object %q: %w", key, err)
+	}
+
+	info := &ObjectInfo{
+		Key:          key,
+		Size:         aws.ToInt64(out.ContentLength),
+		ETag:         aws.ToString(out.ETag),
+		LastModified: aws.ToTime(out.LastModified),
+		ContentType:  aws.ToString(out.ContentType),
+	}
+
+	// S3 returns the stored checksum base64 encoded, composite (multipart) checksums are skipped
+	if raw, err := base64.StdEncoding.DecodeString(aws.ToString(out.ChecksumSHA256)); err == nil && len(raw) == sha256.Size {
+		info.Checksum = hex.EncodeToString(raw)
+	}
+
+	return info, nil
+}
+
+func (s *S3Storage) List(ctx context.Context, prefix string, pageSize int32, fn func([]ObjectInfo) error) error {
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(s.bucket),
+		Prefix:  aws.String(prefix),
+		MaxKeys: aws.Int32(pageSize),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("list objects %q: %w", prefix, err)
+		}
+
+		objects := make([]ObjectInfo, 0, len(page.Contents))
+		for _, obj := range page.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          aws.ToString(obj.Key),
+				Size:         aws.ToInt64(obj.Size),
+				ETag:         aws.ToString(obj.ETag),
+				LastModified: aws.ToTime(obj.LastModified),
+			})
+		}
+
+		if err := fn(objects); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// s3Storage returns the S3 backend of a store, S3-only features like lifecycle rules need it
+func s3Storage(store Storage) (*S3Storage, error) {
+	s, ok := unwrapStorage(store).(*S3Storage)
+	if !ok {
+		return nil, fmt.Errorf("%w: storage %q is not S3", ErrStorageUnsupported, store.Name())
+	}
+	return s, nil
+}
@@ -0,0 +1,77 @@
+package registry
+
+import (
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var tagInvalidChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// TagSuggester proposes tag names for a newly registered asset
+type TagSuggester interface {
+	Name() string
+	Suggest(asset *Asset) []string
+}
+
+// PathTagSuggester proposes tags from the directory segments and file extension of the asset display name,
+// e.g. "images/cats/tabby.JPG" suggests images, cats and jpg
+type PathTagSuggester struct {
+	// MaxSegments keeps only the last directory segments, zero keeps them all
+	MaxSegments int
+}
+
+func (p PathTagSuggester) Name() string {
+	return "path"
+}
+
+func (p PathTagSuggester) Suggest(asset *Asset) []string {
+	name := strings.ReplaceAll(strings.TrimSpace(asset.Display), "\\", "/")
+	if name == "" {
+		return nil
+	}
+
+	dir, file := path.Split(path.Clean("/" + name))
+	segments := strings.Split(strings.Trim(dir, "/"), "/")
+	if p.MaxSegments > 0 && len(segments) > p.MaxSegments {
+		segments = segments[len(segments)-p.MaxSegments:]
+	}
+
+	candidates := append(segments, strings.TrimPrefix(path.Ext(file), "."))
+
+	var tags []string
+	for _, c := range candidates {
+		if tag := SuggestedTagName(c); tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// SuggestedTagName turns free text into a valid tag name, empty when nothing usable is left
+func SuggestedTagName(value string) string {
+	tag := tagInvalidChars.ReplaceAllString(NormalizeString(value), "-")
+	tag = strings.Trim(tag, "._-")
+	if len(tag) > 100 || !ValidateString(tag) {
+		return ""
+	}
+	return tag
+}
+
+// EnsureTagRecords returns the named tags, creating the missing ones
+func (engine *Engine) EnsureTagRecords(names []string) ([]*Tag, error) {
+	slog.Debug("Ensuring tags", "total", len(names))
+
+	tags := make([]*Tag, 0, len(names))
+	for _, name := range names {
+		tag := &Tag{Name: NormalizeString(name)}
+		if err := engine.DatabaseClient.Where("name = ?", tag.Name).FirstOrCreate(tag).Error; err != nil {
+			return nil, fmt.Errorf("ensure tag %q: %w", name, err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
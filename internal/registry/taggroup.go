@@ -0,0 +1,223 @@
+package registry
+
+import (
+	"fmt"
+	"log/slog"
+
+	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+// EventTagGroupSwap audits a tag detached because another tag of its group was attached
+const EventTagGroupSwap = "tag.group_swap"
+
+var ErrTagGrouped = registryerrors.New(registryerrors.ErrConflict, "tag already belongs to a group")
+
+// TagGroup makes its tags mutually exclusive, e.g. split holding train, val and test.
+// Attaching one of them detaches the others, so an asset carries at most one tag of the group.
+type TagGroup struct {
+	gorm.Model
+	Name        string `gorm:"uniqueIndex;not null;size:100"`
+	Description string
+	Tags        []Tag
+	CreatedBy   string `gorm:"size:200"`
+}
+
+// TagSwap is a tag detached from an asset because another tag of its group was attached
+type TagSwap struct {
+	AssetID  uint
+	Checksum string
+	Group    string
+	Detached string
+	Attached string
+}
+
+// NewTagSwapEvent builds the audit event of a swap, the caller attributes it
+func NewTagSwapEvent(swap *TagSwap) (*Event, error) {
+	return NewEvent(EventTagGroupSwap, SeverityInfo,
+		fmt.Sprintf("tag %s replaced %s on asset %s", swap.Attached, swap.Detached, swap.Checksum),
+		map[string]any{
+			"checksum": swap.Checksum,
+			"group":    swap.Group,
+			"detached": swap.Detached,
+			"attached": swap.Attached,
+		})
+}
+
+// ValidateTagGroup checks the group name
+func ValidateTagGroup(g *TagGroup) error {
+	g.Name = NormalizeString(g.Name)
+	if !ValidateString(g.Name) {
+		return fmt.Errorf("%w: tag group name contains invalid characters", ErrValidation)
+	}
+	return nil
+}
+
+// CreateTagGroupRecord creates a group of the named tags, creating the missing ones.
+// Assets already carrying several of the tags keep them until one is attached again.
+func (engine *Engine) CreateTagGroupRecord(g *TagGroup, names []string) error {
+	slog.Debug("Creating tag group", "name", g.Name, "tags", names)
+
+	if err := ValidateTagGroup(g); err != nil {
+		return err
+	}
+
+	return engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(g).Error; err != nil {
+			return fmt.Errorf("create tag group %q: %w", g.Name, err)
+		}
+		return engine.WithTx(tx).setTagGroupTags(g, names)
+	})
+}
+
+// GetTagGroupRecord returns the group named name with its tags
+func (engine *Engine) GetTagGroupRecord(name string) (*TagGroup, error) {
+	var g TagGroup
+	err := engine.DatabaseClient.
+		Preload("Tags", func(db *gorm.DB) *gorm.DB { return db.Order("name ASC") }).
+		Where("name = ?", NormalizeString(name)).
+		First(&g).Error
+	if err != nil {
+		return nil, fmt.Errorf("get tag group %q: %w", name, err)
+	}
+	return &g, nil
+}
+
+// ListTagGroupRecords returns every group with its tags, ordered by name
+func (engine *Engine) ListTagGroupRecords() ([]*TagGroup, error) {
+	var groups []*TagGroup
+	err := engine.DatabaseClient.
+		Preload("Tags", func(db *gorm.DB) *gorm.DB { return db.Order("name ASC") }).
+		Order("name ASC").
+		Find(&groups).Error
+	if err != nil {
+		return nil, fmt.Errorf("list tag groups: %w", err)
+	}
+	return groups, nil
+}
+
+// UpdateTagGroupRecord replaces the tags of a group, tags left out become ungrouped
+func (engine *Engine) UpdateTagGroupRecord(g *TagGroup, names []string) error {
+	slog.Debug("Updating tag group", "name", g.Name, "tags", names)
+
+	return engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(g).Update("description", g.Description).Error; err != nil {
+			return fmt.Errorf("update tag group %q: %w", g.Name, err)
+		}
+		if err := tx.Model(&Tag{}).Where("tag_group_id = ?", g.ID).UpdateColumn("tag_group_id", nil).Error; err != nil {
+			return fmt.Errorf("update tag group %q: %w", g.Name, err)
+		}
+		return engine.WithTx(tx).setTagGroupTags(g, names)
+	})
+}
+
+// DeleteTagGroupRecord removes a group, its tags and their assets are kept
+func (engine *Engine) DeleteTagGroupRecord(g *TagGroup) error {
+	slog.Debug("Deleting tag group", "name", g.Name)
+
+	return engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Tag{}).Where("tag_group_id = ?", g.ID).UpdateColumn("tag_group_id", nil).Error; err != nil {
+			return fmt.Errorf("delete tag group %q: %w", g.Name, err)
+		}
+		if err := tx.Unscoped().Delete(g).Error; err != nil {
+			return fmt.Errorf("delete tag group %q: %w", g.Name, err)
+		}
+		return nil
+	})
+}
+
+// setTagGroupTags moves the named tags into the group, failing on tags of another group
+func (engine *Engine) setTagGroupTags(g *TagGroup, names []string) error {
+	tags, err := engine.EnsureTagRecords(names)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if tag.TagGroupID != nil && *tag.TagGroupID != g.ID {
+			return fmt.Errorf("%w: %s", ErrTagGrouped, tag.Name)
+		}
+		tag.TagGroupID = &g.ID
+	}
+
+	ids := make([]uint, len(tags))
+	for i, tag := range tags {
+		ids[i] = tag.ID
+	}
+	if len(ids) > 0 {
+		if err := engine.DatabaseClient.Model(&Tag{}).Where("id IN ?", ids).UpdateColumn("tag_group_id", g.ID).Error; err != nil {
+			return fmt.Errorf("set tag group %q tags: %w", g.Name, err)
+		}
+	}
+
+	g.Tags = make([]Tag, len(tags))
+	for i, tag := range tags {
+		g.Tags[i] = *tag
+	}
+	return nil
+}
+
+// checkExclusiveTags fails when tags hold two tags of the same group, they can't be attached together
+func (engine *Engine) checkExclusiveTags(tags []*Tag) error {
+	seen := make(map[uint]string, len(tags))
+	for _, tag := range tags {
+		if tag.TagGroupID == nil {
+			continue
+		}
+		if other, ok := seen[*tag.TagGroupID]; ok && other != tag.Name {
+			return fmt.Errorf("%w: tags %q and %q are mutually exclusive", ErrValidation, other, tag.Name)
+		}
+		seen[*tag.TagGroupID] = tag.Name
+	}
+	return nil
+}
+
+// detachGroupSiblings removes every other tag of the groups of tags from the assets of assetIDs.
+// Run it in the attach transaction.
+func (engine *Engine) detachGroupSiblings(assetIDs []uint, tags []*Tag) ([]*TagSwap, error) {
+	var swaps []*TagSwap
+	if len(assetIDs) == 0 {
+		return swaps, nil
+	}
+
+	for _, tag := range tags {
+		if tag.TagGroupID == nil {
+			continue
+		}
+
+		var group TagGroup
+		if err := engine.DatabaseClient.Select("id", "name").First(&group, *tag.TagGroupID).Error; err != nil {
+			return nil, fmt.Errorf("get tag %q group: %w", tag.Name, err)
+		}
+
+		var detached []*TagSwap
+		err := engine.DatabaseClient.Table("asset_tags").
+			Select("asset_tags.asset_id, assets.checksum, tags.name AS detached").
+			Joins("JOIN tags ON tags.id = asset_tags.tag_id").
+			Joins("JOIN assets ON assets.id = asset_tags.asset_id").
+			Where("tags.tag_group_id = ? AND tags.id <> ? AND asset_tags.asset_id IN ?", group.ID, tag.ID, assetIDs).
+			Scan(&detached).Error
+		if err != nil {
+			return nil, fmt.Errorf("find tag group %q siblings: %w", group.Name, err)
+		}
+		if len(detached) == 0 {
+			continue
+		}
+
+		err = engine.DatabaseClient.Exec(`
+			DELETE FROM asset_tags
+			WHERE asset_id IN ? AND tag_id IN (SELECT id FROM tags WHERE tag_group_id = ? AND id <> ?)`,
+			assetIDs, group.ID, tag.ID).Error
+		if err != nil {
+			return nil, fmt.Errorf("detach tag group %q siblings: %w", group.Name, err)
+		}
+
+		for _, swap := range detached {
+			swap.Group, swap.Attached = group.Name, tag.Name
+		}
+		swaps = append(swaps, detached...)
+	}
+
+	return swaps, nil
+}
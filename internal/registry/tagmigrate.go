@@ -0,0 +1,161 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// TAG_KEY_SEPARATOR splits the key and value of a structured tag name, e.g. split:train
+const TAG_KEY_SEPARATOR = ":"
+
+// Tag migration actions
+const (
+	TagMigrationStructured = "structured"
+	TagMigrationRenamed    = "renamed"
+	TagMigrationMerged     = "merged"
+	TagMigrationAmbiguous  = "ambiguous"
+)
+
+// errTagMigrationDryRun rolls back a dry run once its report is built
+var errTagMigrationDryRun = errors.New("tag migration dry run")
+
+// TagMigration is the outcome of one flat tag named after the key:value convention.
+// Target is the structured name, Assets the associations moved when the tag was merged into it
+// and Reason why an ambiguous tag was left for manual review.
+type TagMigration struct {
+	TagID   uint
+	Project string
+	Name    string
+	Target  string
+	Action  string
+	Assets  int64
+	Reason  string
+}
+
+// MigrateTagNames moves flat tags whose name follows the key:value convention to the structured form.
+// A tag already named in its normalized form gets its key and value, one differing only by case or spaces
+// is renamed, and one whose normalized name is taken is merged into that tag, its associations moved over.
+// Names with several separators, empty or invalid parts, or a merge target in another tag group are left
+// untouched and reported as ambiguous. Everything runs in one transaction, a dry run reports and rolls back.
+func (engine *Engine) MigrateTagNames(dryRun bool) ([]*TagMigration, error) {
+	slog.Debug("Migrating key:value tag names", "dryRun", dryRun)
+
+	var report []*TagMigration
+	err := engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		var tags []*Tag
+		err := tx.
+			Where("name LIKE ? AND (key IS NULL OR key = '')", "%"+TAG_KEY_SEPARATOR+"%").
+			Order("id ASC").
+			Find(&tags).Error
+		if err != nil {
+			return fmt.Errorf("list key:value tags: %w", err)
+		}
+
+		for _, tag := range tags {
+			m, err := migrateTagName(tx, tag)
+			if err != nil {
+				return err
+			}
+			report = append(report, m)
+		}
+
+		if dryRun {
+			return errTagMigrationDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errTagMigrationDryRun) {
+		return nil, err
+	}
+	return report, nil
+}
+
+// migrateTagName moves one flat tag to the structured form within tx
+func migrateTagName(tx *gorm.DB, tag *Tag) (*TagMigration, error) {
+	m := &TagMigration{TagID: tag.ID, Project: tag.Project, Name: tag.Name, Action: TagMigrationAmbiguous}
+
+	parts := strings.Split(tag.Name, TAG_KEY_SEPARATOR)
+	if len(parts) != 2 {
+		m.Reason = fmt.Sprintf("name holds %d separators, expected one", len(parts)-1)
+		return m, nil
+	}
+	key, value := NormalizeString(parts[0]), NormalizeString(parts[1])
+	if !ValidateString(key) || !ValidateString(value) {
+		m.Reason = "key or value is empty or contains invalid characters"
+		return m, nil
+	}
+	m.Target = key + TAG_KEY_SEPARATOR + value
+
+	if m.Target == tag.Name {
+		m.Action = TagMigrationStructured
+		return m, updateTagKey(tx, tag, m.Target, key, value)
+	}
+
+	var existing Tag
+	err := tx.Where("project = ? AND name = ? AND id <> ?", tag.Project, m.Target, tag.ID).Limit(1).Find(&existing).Error
+	if err != nil {
+		return nil, fmt.Errorf("migrate tag %q: %w", tag.Name, err)
+	}
+	if existing.ID == 0 {
+		m.Action = TagMigrationRenamed
+		return m, updateTagKey(tx, tag, m.Target, key, value)
+	}
+
+	if !sameTagGroup(tag.TagGroupID, existing.TagGroupID) {
+		m.Reason = fmt.Sprintf("tag %q it merges into belongs to another tag group", existing.Name)
+		return m, nil
+	}
+
+	// structured tags of older rows may still miss their key
+	if existing.Key == "" {
+		if err := updateTagKey(tx, &existing, existing.Name, key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	result := tx.Exec(`
+		INSERT INTO asset_tags (asset_id, tag_id)
+		SELECT asset_id, ? FROM asset_tags WHERE tag_id = ?
+		ON CONFLICT DO NOTHING`, existing.ID, tag.ID)
+	if result.Error != nil {
+		return nil, fmt.Errorf("merge tag %q into %q: %w", tag.Name, existing.Name, result.Error)
+	}
+	m.Assets = result.RowsAffected
+
+	if err := tx.Exec("DELETE FROM asset_tags WHERE tag_id = ?", tag.ID).Error; err != nil {
+		return nil, fmt.Errorf("merge tag %q into %q: %w", tag.Name, existing.Name, err)
+	}
+	if err := tx.Model(&RetagJob{}).Where("tag_id = ?", tag.ID).Update("tag_id", existing.ID).Error; err != nil {
+		return nil, fmt.Errorf("merge tag %q into %q: %w", tag.Name, existing.Name, err)
+	}
+	if err := tx.Delete(tag).Error; err != nil {
+		return nil, fmt.Errorf("merge tag %q into %q: %w", tag.Name, existing.Name, err)
+	}
+
+	m.Action = TagMigrationMerged
+	return m, nil
+}
+
+// updateTagKey renames a tag and sets its key and value, skipping the hooks that reject legacy names
+func updateTagKey(tx *gorm.DB, tag *Tag, name string, key string, value string) error {
+	err := tx.Model(&Tag{}).Where("id = ?", tag.ID).UpdateColumns(map[string]any{
+		"name":  name,
+		"key":   key,
+		"value": value,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("migrate tag %q: %w", tag.Name, err)
+	}
+	return nil
+}
+
+func sameTagGroup(a *uint, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
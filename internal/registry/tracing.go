@@ -0,0 +1,55 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/uptrace/opentelemetry-go-extra/otelgorm"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"github.com/UnivocalX/aether/pkg/tracing"
+)
+
+// Tracing returns the provider of the engine spans, nil when tracing is disabled, see WithTracing
+func (engine *Engine) Tracing() trace.TracerProvider {
+	return engine.tracing
+}
+
+// startSpan starts a child of the span of ctx, contexts without a span are not traced
+func (engine *Engine) startSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return tracing.Tracer(tracing.ChildrenOnly(engine.tracing)).Start(ctx, name, opts...)
+}
+
+// registerTracingCallbacks records a span for every statement of a traced request,
+// statements of contexts without a span, e.g. maintenance jobs, are not traced
+func (engine *Engine) registerTracingCallbacks(db *gorm.DB) error {
+	if engine.tracing == nil {
+		return nil
+	}
+
+	// query values stay out of the spans, the metrics are served by the engine registry
+	plugin := otelgorm.NewPlugin(
+		otelgorm.WithTracerProvider(tracing.ChildrenOnly(engine.tracing)),
+		otelgorm.WithoutQueryVariables(),
+		otelgorm.WithoutMetrics(),
+	)
+	if err := db.Use(plugin); err != nil {
+		return fmt.Errorf("register tracing callbacks: %w", err)
+	}
+	return nil
+}
+
+// s3Tracing records a span for every S3 call of a traced request, presigning included.
+// No trace context is sent along, it would become a signed header of the presigned urls.
+func (engine *Engine) s3Tracing(o *s3.Options) {
+	if engine.tracing == nil {
+		return
+	}
+	otelaws.AppendMiddlewares(&o.APIOptions, otelaws.WithTracerProvider(tracing.ChildrenOnly(engine.tracing)))
+}
@@ -0,0 +1,484 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	DEFAULT_WEBHOOK_INTERVAL     = 15 * time.Second
+	DEFAULT_WEBHOOK_LOCK         = "aether:webhooks"
+	DEFAULT_WEBHOOK_TIMEOUT      = 10 * time.Second
+	DEFAULT_WEBHOOK_MAX_ATTEMPTS = 10
+
+	// DEFAULT_WEBHOOK_BACKOFF doubles after every failed attempt, up to DEFAULT_WEBHOOK_MAX_BACKOFF
+	DEFAULT_WEBHOOK_BACKOFF     = 30 * time.Second
+	DEFAULT_WEBHOOK_MAX_BACKOFF = 6 * time.Hour
+
+	WEBHOOK_SECRET_BYTES   = 32
+	WEBHOOK_EVENT_ID_BYTES = 16
+
+	WEBHOOK_EVENT_HEADER     = "X-Aether-Event"
+	WEBHOOK_DELIVERY_HEADER  = "X-Aether-Delivery"
+	WEBHOOK_TIMESTAMP_HEADER = "X-Aether-Timestamp"
+	WEBHOOK_SIGNATURE_HEADER = "X-Aether-Signature"
+)
+
+// Webhook events
+const (
+	WebhookAssetCreated     = "asset.created"
+	WebhookAssetReady       = "asset.ready"
+	WebhookAssetDeleted     = "asset.deleted"
+	WebhookVersionPublished = "dataset.version.published"
+	WebhookProjectQuota     = "project.quota"
+)
+
+// WebhookEvents lists the events webhooks can subscribe to
+func WebhookEvents() []string {
+	return []string{WebhookAssetCreated, WebhookAssetReady, WebhookAssetDeleted, WebhookVersionPublished, WebhookProjectQuota}
+}
+
+// DeliveryStatus is the stage of a webhook delivery
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryFailed means every attempt failed, the delivery is not retried anymore
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Webhook posts the registry events it subscribes to to a url, signed with its secret
+type Webhook struct {
+	gorm.Model
+	Name      string                      `gorm:"uniqueIndex;not null;size:100"`
+	URL       string                      `gorm:"not null"`
+	Secret    Secret                      `gorm:"not null"`
+	Events    datatypes.JSONSlice[string] `gorm:"type:jsonb"`
+	Disabled  bool
+	CreatedBy string `gorm:"size:200"`
+}
+
+// Subscribes reports whether the webhook is enabled and receives event
+func (w *Webhook) Subscribes(event string) bool {
+	return !w.Disabled && slices.Contains(w.Events, event)
+}
+
+// WebhookDelivery is one event queued for a webhook. The payload is stored as sent,
+// so retries carry the same body and receivers can deduplicate on the event id.
+type WebhookDelivery struct {
+	gorm.Model
+	WebhookID     uint `gorm:"not null;index"`
+	Webhook       Webhook
+	Event         string         `gorm:"not null;size:100"`
+	Payload       datatypes.JSON `gorm:"type:jsonb;not null"`
+	Status        DeliveryStatus `gorm:"not null;size:20;default:'pending';index"`
+	Attempts      int
+	NextAttemptAt time.Time `gorm:"not null;index"`
+	ResponseCode  int
+	LastError     string
+	DeliveredAt   *time.Time
+}
+
+// WebhookPayload is the body posted to webhooks
+type WebhookPayload struct {
+	ID        string    `json:"id"`
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// WebhookAsset is the data of asset events
+type WebhookAsset struct {
+	Checksum       string         `json:"checksum"`
+	Display        string         `json:"display"`
+	MimeType       string         `json:"mime_type"`
+	SizeBytes      int64          `json:"size_bytes"`
+	State          Status         `json:"state"`
+	License        string         `json:"license,omitempty"`
+	Classification Classification `json:"classification"`
+}
+
+// WebhookDatasetVersion is the data of dataset version events
+type WebhookDatasetVersion struct {
+	Dataset        string `json:"dataset"`
+	Version        int    `json:"version"`
+	Description    string `json:"description,omitempty"`
+	AssetCount     int    `json:"asset_count"`
+	TotalBytes     int64  `json:"total_bytes"`
+	ManifestDigest string `json:"manifest_digest"`
+	PublishedBy    string `json:"published_by,omitempty"`
+}
+
+// WebhookQuota is the data of project quota events
+type WebhookQuota struct {
+	Project       string      `json:"project"`
+	Status        QuotaStatus `json:"status"`
+	StoredBytes   int64       `json:"stored_bytes"`
+	QuotaBytes    int64       `json:"quota_bytes"`
+	StoredObjects int64       `json:"stored_objects"`
+	QuotaObjects  int64       `json:"quota_objects"`
+}
+
+// NewWebhookSecret generates a random signing secret
+func NewWebhookSecret() (Secret, error) {
+	raw := make([]byte, WEBHOOK_SECRET_BYTES)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return Secret(hex.EncodeToString(raw)), nil
+}
+
+// newWebhookEventID generates the id receivers deduplicate redelivered events on
+func newWebhookEventID() (string, error) {
+	raw := make([]byte, WEBHOOK_EVENT_ID_BYTES)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate webhook event id: %w", err)
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// SignWebhookPayload signs a delivery body, receivers recompute the HMAC-SHA256 of "<timestamp>.<body>"
+// with the webhook secret and compare it to the signature header
+func SignWebhookPayload(secret Secret, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret.Value()))
+	fmt.Fprintf(mac, "%d.", timestamp.Unix())
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateWebhook checks the name, url and events of a webhook
+func ValidateWebhook(w *Webhook) error {
+	w.Name = NormalizeString(w.Name)
+	if !ValidateString(w.Name) {
+		return fmt.Errorf("%w: webhook name contains invalid characters", ErrValidation)
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: webhook url %q is not an absolute http(s) url", ErrValidation, w.URL)
+	}
+
+	if len(w.Events) == 0 {
+		return fmt.Errorf("%w: webhook needs at least one event, expected any of %v", ErrValidation, WebhookEvents())
+	}
+	for _, event := range w.Events {
+		if !slices.Contains(WebhookEvents(), event) {
+			return fmt.Errorf("%w: unknown webhook event %q, expected one of %v", ErrValidation, event, WebhookEvents())
+		}
+	}
+	return nil
+}
+
+// CreateWebhookRecord registers a webhook, a missing secret is generated
+func (engine *Engine) CreateWebhookRecord(w *Webhook) error {
+	slog.Debug("Creating webhook", "name", w.Name, "events", w.Events)
+
+	if err := ValidateWebhook(w); err != nil {
+		return err
+	}
+	if w.Secret == "" {
+		secret, err := NewWebhookSecret()
+		if err != nil {
+			return err
+		}
+		w.Secret = secret
+	}
+
+	if err := engine.DatabaseClient.Create(w).Error; err != nil {
+		return fmt.Errorf("create webhook %q: %w", w.Name, err)
+	}
+	return nil
+}
+
+// GetWebhookRecord returns the webhook named name
+func (engine *Engine) GetWebhookRecord(name string) (*Webhook, error) {
+	var w Webhook
+	if err := engine.DatabaseClient.Where("name = ?", NormalizeString(name)).First(&w).Error; err != nil {
+		return nil, fmt.Errorf("get webhook %q: %w", name, err)
+	}
+	return &w, nil
+}
+
+// ListWebhookRecords returns every webhook ordered by name
+func (engine *Engine) ListWebhookRecords() ([]*Webhook, error) {
+	var webhooks []*Webhook
+	if err := engine.DatabaseClient.Order("name ASC").Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("list webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhookRecord saves the url, events, secret and state of a webhook
+func (engine *Engine) UpdateWebhookRecord(w *Webhook) error {
+	slog.Debug("Updating webhook", "name", w.Name, "events", w.Events, "disabled", w.Disabled)
+
+	if err := ValidateWebhook(w); err != nil {
+		return err
+	}
+
+	err := engine.DatabaseClient.Model(w).Updates(map[string]any{
+		"url":      w.URL,
+		"events":   w.Events,
+		"secret":   w.Secret,
+		"disabled": w.Disabled,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("update webhook %q: %w", w.Name, err)
+	}
+	return nil
+}
+
+// DeleteWebhookRecord removes a webhook and its deliveries
+func (engine *Engine) DeleteWebhookRecord(w *Webhook) error {
+	slog.Debug("Deleting webhook", "name", w.Name)
+
+	return engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("webhook_id = ?", w.ID).Delete(&WebhookDelivery{}).Error; err != nil {
+			return fmt.Errorf("delete webhook %q deliveries: %w", w.Name, err)
+		}
+		if err := tx.Unscoped().Delete(w).Error; err != nil {
+			return fmt.Errorf("delete webhook %q: %w", w.Name, err)
+		}
+		return nil
+	})
+}
+
+// ListWebhookDeliveryRecords returns the latest deliveries of a webhook, newest first
+func (engine *Engine) ListWebhookDeliveryRecords(w *Webhook, status DeliveryStatus, limit uint) ([]*WebhookDelivery, error) {
+	if limit == 0 || limit > SearchMaxLimit {
+		limit = SearchDefaultLimit
+	}
+
+	db := engine.DatabaseClient.Where("webhook_id = ?", w.ID)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	var deliveries []*WebhookDelivery
+	if err := db.Order("id DESC").Limit(int(limit)).Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("list webhook %q deliveries: %w", w.Name, err)
+	}
+	return deliveries, nil
+}
+
+// EnqueueWebhookEvent queues an event for every webhook subscribed to it, one delivery per data item.
+// Called with a transaction engine, the deliveries commit together with the change they announce.
+func (engine *Engine) EnqueueWebhookEvent(event string, data ...any) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var webhooks []*Webhook
+	if err := engine.DatabaseClient.Where("disabled = ?", false).Find(&webhooks).Error; err != nil {
+		return fmt.Errorf("list webhooks of %s: %w", event, err)
+	}
+	webhooks = slices.DeleteFunc(webhooks, func(w *Webhook) bool { return !w.Subscribes(event) })
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	deliveries := make([]*WebhookDelivery, 0, len(webhooks)*len(data))
+	for _, d := range data {
+		id, err := newWebhookEventID()
+		if err != nil {
+			return err
+		}
+		payload, err := json.Marshal(WebhookPayload{ID: id, Event: event, CreatedAt: now, Data: d})
+		if err != nil {
+			return fmt.Errorf("encode %s webhook payload: %w", event, err)
+		}
+
+		for _, w := range webhooks {
+			deliveries = append(deliveries, &WebhookDelivery{
+				WebhookID:     w.ID,
+				Event:         event,
+				Payload:       payload,
+				Status:        DeliveryPending,
+				NextAttemptAt: now,
+			})
+		}
+	}
+
+	slog.Debug("Enqueuing webhook deliveries", "event", event, "deliveries", len(deliveries))
+	if err := engine.DatabaseClient.Omit("Webhook").CreateInBatches(deliveries, SearchDefaultLimit).Error; err != nil {
+		return fmt.Errorf("enqueue %s webhook deliveries: %w", event, err)
+	}
+	return nil
+}
+
+// notifyAssets queues an asset event, failures are only logged so they never fail the asset change
+func (engine *Engine) notifyAssets(event string, assets ...*Asset) {
+	data := make([]any, len(assets))
+	for i, a := range assets {
+		data[i] = WebhookAsset{
+			Checksum:       a.Checksum,
+			Display:        a.Display,
+			MimeType:       a.MimeType,
+			SizeBytes:      a.SizeBytes,
+			State:          a.State,
+			License:        a.License,
+			Classification: a.Classification,
+		}
+	}
+
+	if err := engine.EnqueueWebhookEvent(event, data...); err != nil {
+		slog.Error("Failed to enqueue webhook deliveries", "event", event, "error", err)
+	}
+}
+
+// WebhookWorker posts due deliveries, failed attempts are retried with exponential backoff
+type WebhookWorker struct {
+	engine *Engine
+	http   *http.Client
+	batch  int
+}
+
+// NewWebhookWorker creates a worker delivering the queued webhook events
+func (engine *Engine) NewWebhookWorker() *WebhookWorker {
+	return &WebhookWorker{
+		engine: engine,
+		http:   &http.Client{Timeout: DEFAULT_WEBHOOK_TIMEOUT},
+		batch:  SearchDefaultLimit,
+	}
+}
+
+// Job wraps the worker as a scheduled job
+func (w *WebhookWorker) Job(interval time.Duration) Job {
+	return Job{
+		Name:     "webhooks",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := w.Run(ctx)
+			return err
+		},
+	}
+}
+
+// Run attempts every due delivery and returns how many were delivered
+func (w *WebhookWorker) Run(ctx context.Context) (int, error) {
+	lock, err := w.engine.TryLock(ctx, DEFAULT_WEBHOOK_LOCK)
+	if err != nil || lock == nil {
+		return 0, err
+	}
+	defer lock.Release()
+
+	delivered, cursor, now := 0, uint(0), time.Now()
+	for {
+		var due []*WebhookDelivery
+		err := w.engine.DatabaseClient.
+			Preload("Webhook").
+			Where("status = ? AND next_attempt_at <= ? AND id > ?", DeliveryPending, now, cursor).
+			Order("id ASC").
+			Limit(w.batch).
+			Find(&due).Error
+		if err != nil {
+			return delivered, fmt.Errorf("list due webhook deliveries: %w", err)
+		}
+
+		for _, d := range due {
+			if err := ctx.Err(); err != nil {
+				return delivered, err
+			}
+
+			code, err := w.post(ctx, d)
+			if err := w.record(d, code, err); err != nil {
+				return delivered, err
+			}
+			if d.Status == DeliveryDelivered {
+				delivered++
+			}
+		}
+
+		if len(due) < w.batch {
+			break
+		}
+		cursor = due[len(due)-1].ID
+	}
+
+	if delivered > 0 {
+		slog.Info("Delivered webhook events", "deliveries", delivered)
+	}
+	return delivered, nil
+}
+
+// post sends a delivery, any 2xx answer acknowledges it
+func (w *WebhookWorker) post(ctx context.Context, d *WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Webhook.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "aether-webhooks")
+	req.Header.Set(WEBHOOK_EVENT_HEADER, d.Event)
+	req.Header.Set(WEBHOOK_DELIVERY_HEADER, strconv.FormatUint(uint64(d.ID), 10))
+	req.Header.Set(WEBHOOK_TIMESTAMP_HEADER, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(WEBHOOK_SIGNATURE_HEADER, SignWebhookPayload(d.Webhook.Secret, now, d.Payload))
+
+	resp, err := w.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// record stores the outcome of an attempt, scheduling the next one or giving up after the last
+func (w *WebhookWorker) record(d *WebhookDelivery, code int, attemptErr error) error {
+	now := time.Now()
+	updates := map[string]any{
+		"attempts":      d.Attempts + 1,
+		"response_code": code,
+		"last_error":    "",
+	}
+
+	switch {
+	case attemptErr == nil:
+		updates["status"] = DeliveryDelivered
+		updates["delivered_at"] = now
+	case d.Attempts+1 >= DEFAULT_WEBHOOK_MAX_ATTEMPTS:
+		slog.Error("Giving up webhook delivery", "webhook", d.Webhook.Name, "delivery", d.ID, "event", d.Event, "attempts", d.Attempts+1, "error", attemptErr)
+		updates["status"] = DeliveryFailed
+		updates["last_error"] = attemptErr.Error()
+	default:
+		backoff := min(DEFAULT_WEBHOOK_BACKOFF<<d.Attempts, DEFAULT_WEBHOOK_MAX_BACKOFF)
+		slog.Warn("Webhook delivery failed, retrying", "webhook", d.Webhook.Name, "delivery", d.ID, "event", d.Event, "retry_in", backoff.String(), "error", attemptErr)
+		updates["next_attempt_at"] = now.Add(backoff)
+		updates["last_error"] = attemptErr.Error()
+	}
+
+	if err := w.engine.DatabaseClient.Model(&WebhookDelivery{}).Where("id = ?", d.ID).Updates(updates).Error; err != nil {
+		return fmt.Errorf("record webhook delivery %d: %w", d.ID, err)
+	}
+
+	d.Attempts++
+	d.ResponseCode = code
+	if status, ok := updates["status"].(DeliveryStatus); ok {
+		d.Status = status
+	}
+	return nil
+}
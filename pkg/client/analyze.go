@@ -6,6 +6,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"mime"
+	"net/http"
 	"os"
 	"path/filepath"
 	"time"
@@ -20,6 +22,8 @@ import (
 type fileAnalysis struct {
 	Path     string `yaml:"path"`
 	Checksum string `yaml:"checksum" binding:"required,len=64,hexadecimal"`
+	MimeType string `yaml:"mime_type"`
+	Size     int64  `yaml:"size"`
 }
 
 // Analyze a single file and return its FileChecksum.
@@ -34,6 +38,13 @@ func analyzeFile(path string) (fileAnalysis, error) {
 	}
 	fc.Path = abs
 
+	// file size, the server signs upload urls for exactly this length
+	info, err := os.Stat(abs)
+	if err != nil {
+		return fc, err
+	}
+	fc.Size = info.Size()
+
 	// compute checksum
 	c, err := checksum(abs)
 	if err != nil {
@@ -41,6 +52,13 @@ func analyzeFile(path string) (fileAnalysis, error) {
 	}
 	fc.Checksum = c
 
+	// detect content type
+	m, err := detectMimeType(abs)
+	if err != nil {
+		return fc, err
+	}
+	fc.MimeType = m
+
 	slog.Debug("analyze completed", "checksum", c, "path", abs)
 	return fc, nil
 }
@@ -63,7 +81,28 @@ func checksum(path string) (string, error) {
 	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
-func analyzePipeline(ctx context.Context, paths []string, progress bool) universe.Stream[fileAnalysis] {
+// Detect a file MIME type from its extension, sniffing its content when the extension is unknown.
+func detectMimeType(path string) (string, error) {
+	if m := mime.TypeByExtension(filepath.Ext(path)); m != "" {
+		return m, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("detect mime type: %w %q", err, path)
+	}
+
+	return http.DetectContentType(head[:n]), nil
+}
+
+func analyzePipeline(ctx context.Context, paths []string, progress bool, tracer universe.Tracer) universe.Stream[fileAnalysis] {
 	slog.Info("starting to analyze paths...", "total", len(paths))
 
 	analyzer := universe.TransformValue(analyzeFile)
@@ -92,13 +131,22 @@ func analyzePipeline(ctx context.Context, paths []string, progress bool) univers
 	// handle progress bar progress
 	barHandler := func(meta *universe.Meta, env universe.Envelope[fileAnalysis]) {
 		if env.Err != nil {
-			slog.Error("analyze failed", "error", env.Err)
+			slog.Error(
+				"analyze failed",
+				"error", env.Err,
+				"index", env.Origin.Index,
+				"attempts", env.Attempts,
+				"elapsed", env.Elapsed(),
+			)
 		}
 		bar.Add(1)
 	}
 
 	// build pipeline
 	source := universe.From(ctx, paths...)
+	if tracer != nil {
+		source = source.WithTracer(tracer)
+	}
 	return universe.Map(source, analyzer, 8).Tap(barHandler, 1).Run(ctx)
 }
 
@@ -108,13 +156,14 @@ type AssetsAnalysis struct {
 }
 
 // handleAnalysisResult partitions a stream of file analyses into successes and failures.
-// If both exist and interactive is true, prompts the user whether to continue.
+// If both exist and the failure policy does not tolerate them, prompts the user whether to continue when interactive is true.
 // Returns slices of successful and failed analyses, plus an error if partitioning failed
 // or the user aborted.
 func handleAnalysisResult(
 	ctx context.Context,
 	stream universe.Stream[fileAnalysis],
 	interactive bool,
+	policy FailurePolicy,
 ) (
 	[]universe.Envelope[fileAnalysis],
 	[]universe.Envelope[fileAnalysis],
@@ -135,6 +184,11 @@ func handleAnalysisResult(
 	case len(failure) == 0:
 		return success, failure, nil
 
+	// If the failure policy tolerates the failures, continue without asking
+	case policy.Tolerates(len(failure), len(success)+len(failure)):
+		slog.Warn("continuing despite analysis failures", "failed", len(failure), "total", len(success)+len(failure), "fail_on", policy.String())
+		return success, failure, nil
+
 	// If there are both successes and failures, ask the user whether to continue
 	default:
 		prompt := fmt.Sprintf(
@@ -7,15 +7,30 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/UnivocalX/aether/internal/registry"
 	"github.com/UnivocalX/aether/pkg/universe"
 	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
 )
 
 const (
-	BatchSize          = 1000
-	AssetsBatchApiPath = "/batch/assets"
+	BatchSize                = 1000
+	AssetsApiPath            = "/assets"
+	AssetsBatchApiPath       = "/batch/assets"
+	AssetFinalizeApiPath     = "/assets/%s/finalize"
+	AssetApiPath             = "/assets/%s"
+	AssetIngressApiPath      = "/assets/%s/ingress"
+	AssetEgressApiPath       = "/assets/%s/egress"
+	AssetsExistApiPath       = "/assets/exists"
+	AssetDownloadUrlsApiPath = "/assets/download-urls"
+
+	// AliasesExtraKey holds the other file names of an asset found more than once in a single load
+	AliasesExtraKey = "aliases"
 )
 
 // matches should get a list of file path so move glob outside
@@ -35,49 +50,153 @@ func (c *Client) LoadAssets(ctx context.Context, pattern string) error {
 	slog.Info("found candidates", "total", len(matches))
 
 	// analyze matches (validate file, get checksum, resolve full path)
-	stream := analyzePipeline(ctx, matches, c.durable)
+	stream := analyzePipeline(ctx, matches, c.durable, c.tracer)
 
 	// fail if not durable
 	// if durable, continue
 	// return success and failure
 
-	success, _, err := handleAnalysisResult(ctx, stream, c.durable)
+	success, failure, err := handleAnalysisResult(ctx, stream, c.durable, c.failurePolicy)
+	var failed failures
+	for _, env := range failure {
+		failed.add(1, fmt.Errorf("analyze %s: %w", env.Value.Path, env.Err))
+		c.report.Record(ReportEntry{Path: env.Value.Path}, time.Time{}, env.Err)
+	}
 	if err != nil {
 		return err
 	}
 
-	// post assets
-	responses, err := c.PostAssets(ctx, assets...)
+	// identical files are registered and uploaded once
+	assets, paths := dedupeAnalyses(success)
+
+	// assets the server already holds need neither a post nor an upload
+	assets = c.skipReadyAssets(ctx, assets, paths)
+	if len(assets) == 0 {
+		slog.Info("every asset is already loaded")
+		return failed.err("load", len(matches))
+	}
+
+	// post assets, retrying transient failures
+	report, err := c.postAssets(ctx, assets...)
 	if err != nil {
 		return err
 	}
+	report.Log()
+	c.recordReconciliation(report, assets, paths)
+	for _, itemErr := range report.Failed {
+		failed.add(1, fmt.Errorf("register %s: %s", itemErr.Checksum, itemErr.Message))
+	}
 
-	// upload assets
-	if err := c.UploadAssets(ctx, result, responses...); err != nil {
+	// upload assets, failed uploads are counted and the others go on
+	if err := failed.merge(c.UploadAssets(ctx, paths, report.Created...)); err != nil {
 		return err
 	}
 
-	slog.Info("successfully loaded assets", "total", len(result.successes))
-	return nil
+	slog.Info("loaded assets", "total", len(matches), "created", len(report.Created), "failed", failed.count)
+	return failed.err("load", len(matches))
 }
 
-// PostAssets splits assets into batches and posts each one.
-func (c *Client) postAssets(ctx context.Context, analysis ...universe.Envelope[fileAnalysis]) ([]*v1.AssetsBatchResponse, error) {
-	slog.Info("posting assets", "total", len(assets))
+// dedupeAnalyses maps every distinct checksum to a single local file.
+// The first path in lexical order is registered, the names of its copies are kept as aliases in the asset extra.
+func dedupeAnalyses(analyses []universe.Envelope[fileAnalysis]) ([]v1.AssetPayload, map[string]string) {
+	slices.SortFunc(analyses, func(a, b universe.Envelope[fileAnalysis]) int {
+		return strings.Compare(a.Value.Path, b.Value.Path)
+	})
 
-	responses := make([]*v1.AssetsBatchResponse, 0, len(assets)/BatchSize+1)
-	for start := 0; start < len(assets); start += BatchSize {
-		end := min(start+BatchSize, len(assets))
+	paths := make(map[string]string, len(analyses))
+	aliases := make(map[string][]string)
+	assets := make([]v1.AssetPayload, 0, len(analyses))
+	var duplicates, savedBytes int64
+	for _, env := range analyses {
+		name := filepath.Base(env.Value.Path)
+		if primary, ok := paths[env.Value.Checksum]; ok {
+			slog.Debug("skipping duplicate file", "path", env.Value.Path, "duplicate_of", primary)
+			duplicates++
+			savedBytes += env.Value.Size
+			if name != filepath.Base(primary) && !slices.Contains(aliases[env.Value.Checksum], name) {
+				aliases[env.Value.Checksum] = append(aliases[env.Value.Checksum], name)
+			}
+			continue
+		}
 
-		batch := v1.CreateAssetsBatchRequest{Assets: assets[start:end]}
-		batchResp, err := c.PostAssetsBatch(ctx, batch)
-		if err != nil {
+		paths[env.Value.Checksum] = env.Value.Path
+		assets = append(assets, v1.AssetPayload{
+			Checksum:  env.Value.Checksum,
+			Display:   name,
+			MimeType:  env.Value.MimeType,
+			SizeBytes: env.Value.Size,
+		})
+	}
+
+	for i := range assets {
+		if names, ok := aliases[assets[i].Checksum]; ok {
+			assets[i].Extra = map[string]any{AliasesExtraKey: names}
+		}
+	}
+
+	if duplicates > 0 {
+		slog.Info("deduplicated identical files", "duplicates", duplicates, "assets", len(assets), "saved_bytes", savedBytes)
+	}
+	return assets, paths
+}
+
+// skipReadyAssets drops the assets that are already ready on the server.
+// The check is an optimization, when it fails every asset is kept.
+func (c *Client) skipReadyAssets(ctx context.Context, assets []v1.AssetPayload, paths map[string]string) []v1.AssetPayload {
+	checksums := make([]string, len(assets))
+	for i, a := range assets {
+		checksums[i] = a.Checksum
+	}
+
+	existing, err := c.AssetsExist(ctx, checksums...)
+	if err != nil {
+		slog.Warn("failed to check which assets exist, posting all of them", "error", err)
+		return assets
+	}
+
+	ready := make(map[string]bool, len(existing.Found))
+	for _, found := range existing.Found {
+		if found.State == registry.StatusReady {
+			ready[found.Checksum] = true
+		}
+	}
+	if len(ready) == 0 {
+		return assets
+	}
+
+	pending := make([]v1.AssetPayload, 0, len(assets)-len(ready))
+	for _, a := range assets {
+		if !ready[a.Checksum] {
+			pending = append(pending, a)
+			continue
+		}
+		c.report.Record(ReportEntry{Path: paths[a.Checksum], Checksum: a.Checksum, Outcome: OutcomeSkipped, Bytes: a.SizeBytes}, time.Time{}, nil)
+	}
+
+	slog.Info("skipping assets already loaded", "total", len(ready))
+	return pending
+}
+
+// AssetsExist reports which checksums the server knows, with their state, in batches.
+func (c *Client) AssetsExist(ctx context.Context, checksums ...string) (*v1.AssetsExistResponse, error) {
+	slog.Debug("checking assets existence", "total", len(checksums))
+
+	result := &v1.AssetsExistResponse{}
+	for start := 0; start < len(checksums); start += BatchSize {
+		end := min(start+BatchSize, len(checksums))
+
+		var response v1.AssetsExistResponse
+		request := v1.AssetsExistRequest{Checksums: checksums[start:end]}
+		if err := c.sendJSON(ctx, http.MethodPost, AssetsExistApiPath, request, &response, http.StatusOK); err != nil {
 			return nil, err
 		}
-		responses = append(responses, batchResp)
+
+		result.Response = response.Response
+		result.Found = append(result.Found, response.Found...)
+		result.Missing = append(result.Missing, response.Missing...)
 	}
 
-	return responses, nil
+	return result, nil
 }
 
 func (c *Client) PostAssetsBatch(ctx context.Context, req v1.CreateAssetsBatchRequest) (*v1.AssetsBatchResponse, error) {
@@ -92,7 +211,8 @@ func (c *Client) PostAssetsBatch(ctx context.Context, req v1.CreateAssetsBatchRe
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	// partial failures are reported per item with a multi status
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusMultiStatus {
 		return nil, decodeErrorResponse(resp)
 	}
 
@@ -104,22 +224,252 @@ func (c *Client) PostAssetsBatch(ctx context.Context, req v1.CreateAssetsBatchRe
 	return &response, nil
 }
 
-// UploadAssets uploads each file to its corresponding ingress URL, matched by checksum.
-func (c *Client) UploadAssets(ctx context.Context, result AnalysisResults, responses ...*v1.AssetsBatchResponse) error {
-	for _, response := range responses {
+// CreateAssets registers assets in batches of BatchSize with upload urls valid for the presign ttl.
+// Items the server rejects are reported in Errors with their index into assets, like a single batch.
+func (c *Client) CreateAssets(ctx context.Context, assets ...v1.AssetPayload) (*v1.AssetsBatchResponse, error) {
+	slog.Debug("creating assets", "total", len(assets))
+
+	result := &v1.AssetsBatchResponse{}
+	for start := 0; start < len(assets); start += BatchSize {
+		end := min(start+BatchSize, len(assets))
+
+		request := v1.CreateAssetsBatchRequest{Assets: assets[start:end], ExpiresIn: uint(c.presignTTL.Seconds())}
+		response, err := c.PostAssetsBatch(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
 		for _, asset := range response.Assets {
-			env, ok := result.successes[asset.Checksum]
-			if !ok {
-				return fmt.Errorf("no local file found for checksum %s", asset.Checksum)
-			}
-			if _, err := c.upload2Storage(ctx, env.Value.Path, asset.IngressUrl); err != nil {
-				return fmt.Errorf("failed to upload asset %s: %w", env.Value.Path, err)
-			}
+			asset.Index += start
+		}
+		for _, itemErr := range response.Errors {
+			itemErr.Index += start
 		}
+		result.Response = response.Response
+		result.Total += response.Total
+		result.Failed += response.Failed
+		result.Assets = append(result.Assets, response.Assets...)
+		result.Errors = append(result.Errors, response.Errors...)
+	}
+
+	return result, nil
+}
+
+// ListAssets returns a page of assets matching the filters of request, pass the NextPageToken of the
+// response to get the next one. Assets iterates over every page.
+func (c *Client) ListAssets(ctx context.Context, request v1.ListAssetsRequest) (*v1.ListAssetsResponse, error) {
+	slog.Debug("listing assets", "limit", request.Limit, "continued", request.PageToken != "")
+
+	var response v1.ListAssetsResponse
+	if err := c.sendJSON(ctx, http.MethodGet, AssetsApiPath, request, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// UploadAssets uploads each file to its corresponding ingress URL, matched by checksum, and finalizes it.
+// Uploads run one at a time as a pipeline stage, so with a tracer every file gets a span covering its requests.
+// A failed upload does not stop the others, they are reported together as a *PartialFailureError.
+func (c *Client) UploadAssets(ctx context.Context, paths map[string]string, assets ...*v1.BatchAssetDetails) error {
+	for _, asset := range assets {
+		if _, ok := paths[asset.Checksum]; !ok {
+			return fmt.Errorf("no local file found for checksum %s", asset.Checksum)
+		}
+	}
+
+	uploader := universe.TransformContext(func(ctx context.Context, asset *v1.BatchAssetDetails) (*v1.BatchAssetDetails, error) {
+		path := paths[asset.Checksum]
+
+		started := time.Now()
+		err := c.uploadAsset(ctx, path, asset)
+		entry := ReportEntry{Path: path, Checksum: asset.Checksum, Outcome: OutcomeUploaded}
+		if info, statErr := os.Stat(path); statErr == nil {
+			entry.Bytes = info.Size()
+		}
+		c.report.Record(entry, started, err)
+		return asset, err
+	})
+
+	source := universe.From(ctx, assets...)
+	if c.tracer != nil {
+		source = source.WithTracer(c.tracer)
+	}
+	stream := universe.Map(source, uploader, 1).Run(ctx)
+
+	var failed failures
+	for env := range stream.Data {
+		if env.Err == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			return env.Err
+		}
+		slog.Error("upload failed", "path", paths[env.Value.Checksum], "error", env.Err)
+		failed.add(1, env.Err)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return failed.err("upload", len(assets))
+}
+
+func (c *Client) uploadAsset(ctx context.Context, path string, asset *v1.BatchAssetDetails) error {
+	ctx, cancel := c.fileContext(ctx)
+	defer cancel()
+
+	resp, err := c.upload2Storage(ctx, path, asset.IngressUrl)
+	if err != nil {
+		return fmt.Errorf("failed to upload asset %s: %w", path, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to upload asset %s: unexpected status %d", path, resp.StatusCode)
+	}
+
+	if _, err := c.FinalizeAsset(ctx, asset.Checksum); err != nil {
+		return fmt.Errorf("failed to finalize asset %s: %w", path, err)
 	}
 	return nil
 }
 
+// FinalizeAsset asks the server to verify an uploaded asset and promote it to curated.
+func (c *Client) FinalizeAsset(ctx context.Context, checksum string) (*v1.FinalizeAssetResponse, error) {
+	slog.Debug("finalizing asset", "checksum", checksum)
+
+	var response v1.FinalizeAssetResponse
+	path := fmt.Sprintf(AssetFinalizeApiPath, checksum)
+	if err := c.sendJSON(ctx, http.MethodPost, path, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// PatchAsset edits the display name, mime type or extra of an asset, omitted fields are kept.
+func (c *Client) PatchAsset(ctx context.Context, checksum string, request v1.PatchAssetRequest) (*v1.GetAssetResponse, error) {
+	slog.Debug("patching asset", "checksum", checksum)
+
+	var response v1.GetAssetResponse
+	if err := c.sendJSON(ctx, http.MethodPatch, fmt.Sprintf(AssetApiPath, checksum), request, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetAssetIngress presigns an upload of a registered asset that is not ready yet.
+func (c *Client) GetAssetIngress(ctx context.Context, checksum string) (*v1.AssetIngressResponse, error) {
+	slog.Debug("getting asset ingress url", "checksum", checksum)
+
+	var response v1.AssetIngressResponse
+	if err := c.sendJSON(ctx, http.MethodGet, c.presignPath(AssetIngressApiPath, checksum), nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetUploadURL presigns an upload of a registered asset that is not ready yet and returns the url,
+// PUT the content to it and FinalizeAsset afterwards.
+func (c *Client) GetUploadURL(ctx context.Context, checksum string) (string, error) {
+	response, err := c.GetAssetIngress(ctx, checksum)
+	if err != nil {
+		return "", err
+	}
+	if response.UploadURL == "" {
+		return "", fmt.Errorf("no upload url granted for asset %s", checksum)
+	}
+	return response.UploadURL, nil
+}
+
+// GetAssetEgress presigns a download of a ready asset.
+func (c *Client) GetAssetEgress(ctx context.Context, checksum string) (*v1.AssetEgressResponse, error) {
+	slog.Debug("getting asset egress url", "checksum", checksum)
+
+	var response v1.AssetEgressResponse
+	if err := c.sendJSON(ctx, http.MethodGet, c.presignPath(AssetEgressApiPath, checksum), nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetAssetDownloadUrls presigns downloads of up to BatchSize ready assets in a single request.
+// Assets that can't be downloaded are reported per item next to the granted urls.
+func (c *Client) GetAssetDownloadUrls(ctx context.Context, checksums ...string) (*v1.AssetDownloadUrlsResponse, error) {
+	slog.Debug("getting asset download urls", "total", len(checksums))
+
+	request := v1.AssetDownloadUrlsRequest{Checksums: checksums, ExpiresIn: uint(c.presignTTL.Seconds())}
+	b, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.post(ctx, AssetDownloadUrlsApiPath, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// unavailable assets are reported per item with a multi status
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusMultiStatus {
+		return nil, decodeErrorResponse(resp)
+	}
+
+	var response v1.AssetDownloadUrlsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// presignPath formats an asset presign path, asking for the client presign ttl when set.
+func (c *Client) presignPath(format string, checksum string) string {
+	p := fmt.Sprintf(format, checksum)
+	if c.presignTTL > 0 {
+		p += fmt.Sprintf("?expires_in=%d", uint(c.presignTTL.Seconds()))
+	}
+	return p
+}
+
+// DeleteAsset moves an asset to the trash, purge also removes its stored objects.
+// Assets referenced by dataset versions are only scheduled for deletion, their pending deletion is returned.
+func (c *Client) DeleteAsset(ctx context.Context, checksum string, purge bool) (*v1.AssetDeletionDetails, error) {
+	slog.Debug("deleting asset", "checksum", checksum, "purge", purge)
+
+	path := fmt.Sprintf(AssetApiPath, checksum)
+	if purge {
+		path += "?purge=true"
+	}
+
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil, nil
+	case http.StatusAccepted:
+		var response v1.AssetDeletionResponse
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+			return nil, err
+		}
+		return response.Deletion, nil
+	default:
+		return nil, decodeErrorResponse(resp)
+	}
+}
+
 // Summary
 // Issue  						| Impact at 10k 			| Fix
 // Sequential uploads 			| High major bottleneck 	| Parallelize like analysis
@@ -0,0 +1,349 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/apierrors"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+)
+
+const (
+	BundleManifestName = "manifest.json"
+	BundleObjectsDir   = "objects"
+	BundleTarExt       = ".tar"
+)
+
+var bundleObjectRe = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// A bundle carries a published dataset version across an air gap:
+// manifest.json holds the version manifest and objects/<checksum> every asset object.
+// It is written as a directory, or as a tar archive of the same layout when the path ends with .tar.
+
+// bundleWriter stores the files of a bundle
+type bundleWriter interface {
+	Write(name string, size int64, r io.Reader) error
+	Close() error
+}
+
+type dirBundle struct {
+	root string
+}
+
+func (b *dirBundle) Write(name string, size int64, r io.Reader) error {
+	target := filepath.Join(b.root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, r); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return file.Close()
+}
+
+func (b *dirBundle) Close() error { return nil }
+
+type tarBundle struct {
+	file *os.File
+	tw   *tar.Writer
+}
+
+func (b *tarBundle) Write(name string, size int64, r io.Reader) error {
+	header := &tar.Header{Name: name, Mode: 0o644, Size: size, Typeflag: tar.TypeReg}
+	if err := b.tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	// tar entries must match their declared size exactly
+	if _, err := io.CopyN(b.tw, r, size); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+func (b *tarBundle) Close() error {
+	if err := b.tw.Close(); err != nil {
+		b.file.Close()
+		return err
+	}
+	return b.file.Close()
+}
+
+func newBundleWriter(target string) (bundleWriter, error) {
+	if !strings.HasSuffix(target, BundleTarExt) {
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return nil, err
+		}
+		return &dirBundle{root: target}, nil
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return nil, err
+	}
+	return &tarBundle{file: file, tw: tar.NewWriter(file)}, nil
+}
+
+// ExportBundle downloads a published dataset version, its manifest and every asset object, into a bundle at target.
+// Objects are verified against their checksum while they are written, the free disk space is checked first.
+func (c *Client) ExportBundle(ctx context.Context, dataset string, version int, target string) (*registry.VersionManifest, error) {
+	slog.Info("exporting dataset bundle", "dataset", dataset, "version", version, "target", target)
+
+	response, err := c.GetDatasetVersionManifest(ctx, dataset, version, nil)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &response.VersionManifest
+
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode manifest: %w", err)
+	}
+
+	size, files, dir := manifest.TotalBytes+int64(len(raw)), manifest.Count+1, target
+	if strings.HasSuffix(target, BundleTarExt) {
+		files, dir = 1, filepath.Dir(target)
+	}
+	if err := checkDiskSpace(dir, size, files); err != nil {
+		return nil, err
+	}
+
+	bundle, err := newBundleWriter(target)
+	if err != nil {
+		return nil, fmt.Errorf("create bundle: %w", err)
+	}
+	defer bundle.Close()
+
+	if err := bundle.Write(BundleManifestName, int64(len(raw)), strings.NewReader(string(raw))); err != nil {
+		return nil, err
+	}
+
+	for i, entry := range manifest.Assets {
+		started := time.Now()
+		err := c.exportObject(ctx, bundle, entry)
+		c.report.Record(ReportEntry{Path: path.Join(BundleObjectsDir, entry.Checksum), Checksum: entry.Checksum, Outcome: OutcomeExported, Bytes: entry.SizeBytes}, started, err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export asset %s: %w", entry.Checksum, err)
+		}
+		slog.Debug("exported asset", "checksum", entry.Checksum, "done", i+1, "total", manifest.Count)
+	}
+
+	if err := bundle.Close(); err != nil {
+		return nil, fmt.Errorf("close bundle: %w", err)
+	}
+
+	slog.Info("exported dataset bundle", "dataset", dataset, "version", version, "assets", manifest.Count, "bytes", manifest.TotalBytes)
+	return manifest, nil
+}
+
+func (c *Client) exportObject(ctx context.Context, bundle bundleWriter, entry registry.ManifestEntry) error {
+	ctx, cancel := c.fileContext(ctx)
+	defer cancel()
+
+	egress, err := c.GetAssetEgress(ctx, entry.Checksum)
+	if err != nil {
+		return err
+	}
+
+	body, err := c.downloadObject(ctx, egress.DownloadURL)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	h := sha256.New()
+	if err := bundle.Write(path.Join(BundleObjectsDir, entry.Checksum), entry.SizeBytes, io.TeeReader(body, h)); err != nil {
+		return err
+	}
+
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != entry.Checksum {
+		return fmt.Errorf("%w: downloaded object hashes to %s", registry.ErrChecksumMismatch, sum)
+	}
+	return nil
+}
+
+// ImportBundle registers and uploads every asset of a bundle, then recreates its dataset version as a draft.
+// Assets that are already ready are not uploaded again, so an interrupted import can simply be rerun.
+// dataset overrides the bundled dataset name when set.
+func (c *Client) ImportBundle(ctx context.Context, source string, dataset string) (*v1.DatasetVersionResponse, error) {
+	slog.Info("importing dataset bundle", "source", source)
+
+	root := source
+	if strings.HasSuffix(source, BundleTarExt) {
+		dir, err := os.MkdirTemp("", "aether-bundle-")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+
+		if err := extractBundle(source, dir); err != nil {
+			return nil, fmt.Errorf("extract bundle: %w", err)
+		}
+		root = dir
+	}
+
+	manifest, paths, err := readBundle(root)
+	if err != nil {
+		return nil, err
+	}
+	if dataset == "" {
+		dataset = manifest.Dataset
+	}
+
+	if err := c.importAssets(ctx, manifest, paths); err != nil {
+		return nil, err
+	}
+
+	// the dataset may already exist from an earlier version or import
+	_, err = c.CreateDataset(ctx, v1.CreateDatasetRequest{Name: dataset})
+	if err != nil && ErrorCode(err) != apierrors.DatasetExists {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("imported from bundle %s v%d, digest %s", manifest.Dataset, manifest.Version, manifest.Digest)
+	dsv, err := c.CreateDatasetVersion(ctx, dataset, description)
+	if err != nil {
+		return nil, err
+	}
+
+	checksums := make([]string, len(manifest.Assets))
+	for i, entry := range manifest.Assets {
+		checksums[i] = entry.Checksum
+	}
+	if len(checksums) > 0 {
+		if dsv, err = c.AddDatasetVersionAssets(ctx, dataset, dsv.Version, checksums...); err != nil {
+			return nil, err
+		}
+	}
+
+	slog.Info("imported dataset bundle", "dataset", dataset, "version", dsv.Version, "assets", manifest.Count)
+	return dsv, nil
+}
+
+// importAssets registers the bundle assets and uploads every one that is not ready yet
+func (c *Client) importAssets(ctx context.Context, manifest *registry.VersionManifest, paths map[string]string) error {
+	assets := make([]v1.AssetPayload, len(manifest.Assets))
+	for i, entry := range manifest.Assets {
+		assets[i] = v1.AssetPayload{
+			Checksum:  entry.Checksum,
+			Display:   entry.Display,
+			MimeType:  entry.MimeType,
+			SizeBytes: entry.SizeBytes,
+		}
+	}
+
+	report, err := c.postAssets(ctx, assets...)
+	if err != nil {
+		return err
+	}
+	report.Log()
+	c.recordReconciliation(report, assets, paths)
+
+	if len(report.Failed) > 0 {
+		return fmt.Errorf("failed to register %d out of %d assets", len(report.Failed), len(assets))
+	}
+
+	// registered assets may still wait for their object, e.g. after an interrupted import
+	uploads := report.Created
+	for _, skipped := range report.Skipped {
+		ingress, err := c.GetAssetIngress(ctx, skipped.Checksum)
+		switch {
+		case ErrorCode(err) == apierrors.AssetReady:
+			continue
+		case err != nil:
+			return fmt.Errorf("failed to get ingress url of %s: %w", skipped.Checksum, err)
+		}
+		uploads = append(uploads, &v1.BatchAssetDetails{Checksum: ingress.Checksum, IngressUrl: registry.Secret(ingress.UploadURL)})
+	}
+
+	return c.UploadAssets(ctx, paths, uploads...)
+}
+
+// readBundle loads and checks the manifest of a bundle directory and maps each asset to its object file
+func readBundle(root string) (*registry.VersionManifest, map[string]string, error) {
+	raw, err := os.ReadFile(filepath.Join(root, BundleManifestName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("read bundle manifest: %w", err)
+	}
+
+	var manifest registry.VersionManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("decode bundle manifest: %w", err)
+	}
+
+	if digest := manifest.ComputeDigest(); digest != manifest.Digest {
+		return nil, nil, fmt.Errorf("bundle manifest digest is %s, expected %s", digest, manifest.Digest)
+	}
+
+	paths := make(map[string]string, len(manifest.Assets))
+	for _, entry := range manifest.Assets {
+		p := filepath.Join(root, BundleObjectsDir, entry.Checksum)
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, nil, fmt.Errorf("bundle object %s: %w", entry.Checksum, err)
+		}
+		if info.Size() != entry.SizeBytes {
+			return nil, nil, fmt.Errorf("%w: bundle object %s is %d bytes, expected %d", registry.ErrSizeMismatch, entry.Checksum, info.Size(), entry.SizeBytes)
+		}
+		paths[entry.Checksum] = p
+	}
+
+	return &manifest, paths, nil
+}
+
+// extractBundle unpacks a tar bundle into dir, only the manifest and object files are accepted
+func extractBundle(source string, dir string) error {
+	file, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tr := tar.NewReader(file)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean(header.Name)
+		objectDir, checksum := path.Split(name)
+		switch {
+		case header.Typeflag == tar.TypeDir:
+			continue
+		case header.Typeflag != tar.TypeReg:
+			return fmt.Errorf("unexpected entry %q", header.Name)
+		case name != BundleManifestName && (objectDir != BundleObjectsDir+"/" || !bundleObjectRe.MatchString(checksum)):
+			return fmt.Errorf("unexpected entry %q", header.Name)
+		}
+
+		bundle := &dirBundle{root: dir}
+		if err := bundle.Write(name, header.Size, tr); err != nil {
+			return err
+		}
+	}
+}
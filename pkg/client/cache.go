@@ -0,0 +1,162 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// responses larger than this are never cached
+	maxCachedBodyBytes = 1 << 20
+)
+
+type cacheEntry struct {
+	etag    string
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// cachingTransport serves idempotent JSON GETs from a small in-memory cache.
+// Fresh entries are returned without a round trip, stale entries are
+// revalidated with If-None-Match and reused on 304 Not Modified.
+type cachingTransport struct {
+	next       http.RoundTripper
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	order   []string
+}
+
+func newCachingTransport(next http.RoundTripper, ttl time.Duration, maxEntries int) *cachingTransport {
+	return &cachingTransport{
+		next:       next,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*cacheEntry, maxEntries),
+	}
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// only body-less GETs are idempotent lookups, searches carry a payload
+	if req.Method != http.MethodGet || (req.Body != nil && req.Body != http.NoBody) {
+		return t.next.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	entry := t.get(key)
+
+	if entry != nil && time.Now().Before(entry.expires) {
+		slog.Debug("serving cached response", "url", req.URL.Path)
+		return entry.response(req), nil
+	}
+
+	if entry != nil && entry.etag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		resp.Body.Close()
+		t.refresh(key, entry)
+		slog.Debug("revalidated cached response", "url", req.URL.Path)
+		return entry.response(req), nil
+
+	case resp.StatusCode == http.StatusOK && cacheable(resp):
+		// chunked responses have no length, read one byte past the limit to tell whether they fit
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBodyBytes+1))
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		if len(body) > maxCachedBodyBytes {
+			resp.Body = readCloser{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		t.put(key, &cacheEntry{
+			etag:    resp.Header.Get("ETag"),
+			header:  resp.Header.Clone(),
+			body:    body,
+			expires: time.Now().Add(t.ttl),
+		})
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	return resp, nil
+}
+
+// readCloser reads a response body through Reader and closes the original body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// cacheable reports whether a response may be cached, bodies of unknown length are checked once read
+func cacheable(resp *http.Response) bool {
+	if resp.ContentLength > maxCachedBodyBytes {
+		return false
+	}
+	if strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return false
+	}
+	return strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json")
+}
+
+func (t *cachingTransport) get(key string) *cacheEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.entries[key]
+}
+
+func (t *cachingTransport) refresh(key string, entry *cacheEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if current, ok := t.entries[key]; ok && current == entry {
+		entry.expires = time.Now().Add(t.ttl)
+	}
+}
+
+func (t *cachingTransport) put(key string, entry *cacheEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.entries[key]; !ok {
+		t.order = append(t.order, key)
+	}
+	t.entries[key] = entry
+
+	// evict oldest entries first
+	for len(t.order) > t.maxEntries {
+		delete(t.entries, t.order[0])
+		t.order = t.order[1:]
+	}
+}
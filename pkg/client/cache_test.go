@@ -0,0 +1,63 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCachingTransportBodyLength checks chunked responses are cached when they fit and passed through whole otherwise
+func TestCachingTransportBodyLength(t *testing.T) {
+	small := []byte(`{"name":"red"}`)
+	large := append([]byte(`{"pad":"`+strings.Repeat("x", maxCachedBodyBytes)), `"}`...)
+
+	hits := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+
+		body := small
+		if r.URL.Path == "/large" {
+			body = large
+		}
+		// flushing before the end sends the body chunked, without a Content-Length
+		w.Write(body[:1])
+		w.(http.Flusher).Flush()
+		w.Write(body[1:])
+	}))
+	defer server.Close()
+
+	transport := newCachingTransport(http.DefaultTransport, time.Minute, 10)
+	c := &http.Client{Transport: transport}
+
+	for _, tt := range []struct {
+		path string
+		want []byte
+		hits int
+	}{
+		{path: "/small", want: small, hits: 1},
+		{path: "/large", want: large, hits: 2},
+	} {
+		for range 2 {
+			resp, err := c.Get(server.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(body, tt.want) {
+				t.Errorf("%s: got %d bytes, want %d", tt.path, len(body), len(tt.want))
+			}
+		}
+		if hits[tt.path] != tt.hits {
+			t.Errorf("%s: %d requests reached the server, want %d", tt.path, hits[tt.path], tt.hits)
+		}
+	}
+}
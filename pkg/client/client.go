@@ -1,32 +1,71 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"net/url"
+	"path"
+	"strings"
+	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/universe"
 )
 
 const (
 	DefaultScheme = "http"
 	DefaultHost   = "localhost:8080"
-	DefaultPath   = "/health"
+	DefaultPath   = "/api/v1"
+	DefaultAgent  = "aether-client"
 	MinTimeout    = 1 * time.Second
 	MaxTimeout    = 24 * time.Hour
+
+	// DefaultRequestTimeout bounds a single api request, object transfers are bounded by the file timeout
+	DefaultRequestTimeout = 30 * time.Second
 )
 
 type Client struct {
-	durable bool
-	url     *url.URL
-	http    *http.Client
+	durable    bool
+	presignTTL time.Duration
+	headers    http.Header
+	url        *url.URL
+	failover   *failover
+	http       *http.Client
+
+	// replication peers sign every request
+	peer    string
+	peerKey registry.Secret
+
+	// report collects the file outcomes of long running operations
+	report *Report
+
+	// failurePolicy decides whether failed files fail batch operations
+	failurePolicy FailurePolicy
+
+	// fileTimeout bounds the transfer of each file, zero leaves it to the caller's deadline
+	fileTimeout time.Duration
+
+	// tracer follows the items of file pipelines, e.g. a metrics.PipelineTracer
+	tracer universe.Tracer
+
+	// tracing starts a span for every request and propagates it to the server
+	tracing trace.TracerProvider
+
+	// skewWarning warns about a skewed local clock only once, see checkClockSkew
+	skewWarning sync.Once
 }
 
 // New creates a new client with options applied and validated
 func New(opts ...Option) (*Client, error) {
 	c := &Client{
 		durable: false,
+		headers: http.Header{"User-Agent": []string{DefaultAgent}},
 		url: &url.URL{
 			Scheme: DefaultScheme,
 			Host:   DefaultHost,
@@ -55,7 +94,7 @@ func New(opts ...Option) (*Client, error) {
 				// Avoid connection churn
 				ForceAttemptHTTP2: true,
 			},
-			Timeout: 30 * time.Second,
+			Timeout: DefaultRequestTimeout,
 		},
 	}
 
@@ -104,3 +143,179 @@ func WithDurable(durable bool) Option {
 		return nil
 	}
 }
+
+// WithPresignTTL requests a custom expiry for presigned upload urls, the server clamps it into its bounds
+func WithPresignTTL(ttl time.Duration) Option {
+	return func(c *Client) error {
+		if ttl < 0 {
+			return errors.New("presign ttl cannot be negative")
+		}
+		c.presignTTL = ttl
+		return nil
+	}
+}
+
+// WithReport records the file outcomes of loads, uploads and pulls into report
+func WithReport(report *Report) Option {
+	return func(c *Client) error {
+		c.report = report
+		return nil
+	}
+}
+
+// WithTracer starts a span for every file handled by each stage of the file pipelines
+func WithTracer(tracer universe.Tracer) Option {
+	return func(c *Client) error {
+		c.tracer = tracer
+		return nil
+	}
+}
+
+// WithTracing starts a client span for every request, a child of the span of the request context,
+// and sends its trace context so the server spans join the same trace
+func WithTracing(provider trace.TracerProvider) Option {
+	return func(c *Client) error {
+		c.tracing = provider
+		return nil
+	}
+}
+
+// WithFailurePolicy sets how many failed files batch operations tolerate before giving up
+func WithFailurePolicy(policy FailurePolicy) Option {
+	return func(c *Client) error {
+		c.failurePolicy = policy
+		return nil
+	}
+}
+
+// WithRequestTimeout bounds each attempt of an api request, retries get a fresh budget
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout < MinTimeout || timeout > MaxTimeout {
+			return fmt.Errorf("request timeout must be between %s and %s", MinTimeout, MaxTimeout)
+		}
+		c.http.Timeout = timeout
+		return nil
+	}
+}
+
+// WithFileTimeout bounds each file upload or download, a file that runs over fails on its own
+// and batch operations carry on with the next one. Zero disables the bound.
+func WithFileTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout != 0 && (timeout < MinTimeout || timeout > MaxTimeout) {
+			return fmt.Errorf("file timeout must be 0 or between %s and %s", MinTimeout, MaxTimeout)
+		}
+		c.fileTimeout = timeout
+		return nil
+	}
+}
+
+// fileContext derives the context of a single file transfer from ctx
+func (c *Client) fileContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.fileTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.fileTimeout)
+}
+
+// WithCache enables an in-memory cache for idempotent GET lookups.
+// Entries are served for ttl and revalidated with ETags afterwards.
+func WithCache(ttl time.Duration, maxEntries int) Option {
+	return func(c *Client) error {
+		if ttl <= 0 || maxEntries <= 0 {
+			return errors.New("cache ttl and size must be positive")
+		}
+
+		next := c.http.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		c.http.Transport = newCachingTransport(next, ttl, maxEntries)
+		return nil
+	}
+}
+
+// WithHeader adds a header sent with every api request (trace headers, org identifiers).
+// Headers are not sent to presigned storage urls.
+func WithHeader(key string, value string) Option {
+	return func(c *Client) error {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("header name cannot be empty")
+		}
+		c.headers.Add(key, value)
+		return nil
+	}
+}
+
+// WithAPIKey authenticates every request with an api key
+func WithAPIKey(key string) Option {
+	return WithHeader("Authorization", "Bearer "+key)
+}
+
+// WithProject sends every request in a project, its assets, tags and datasets are apart from the other projects
+func WithProject(project string) Option {
+	return WithHeader("X-Aether-Project", project)
+}
+
+// WithUserAgent overrides the default User-Agent header
+func WithUserAgent(agent string) Option {
+	return func(c *Client) error {
+		if strings.TrimSpace(agent) == "" {
+			return errors.New("user agent cannot be empty")
+		}
+		c.headers.Set("User-Agent", agent)
+		return nil
+	}
+}
+
+// WithEndpoints configures several api hosts, the first one is preferred.
+// Requests stick to the active host and fail over to the next healthy one
+// when it stops responding.
+func WithEndpoints(hosts ...string) Option {
+	return func(c *Client) error {
+		if len(hosts) == 0 {
+			return errors.New("at least one endpoint is required")
+		}
+
+		for _, h := range hosts {
+			if strings.TrimSpace(h) == "" {
+				return errors.New("endpoint cannot be empty")
+			}
+		}
+
+		c.url.Host = hosts[0]
+		if len(hosts) > 1 {
+			c.failover = newFailover(hosts, DefaultFailoverCooldown)
+		}
+		return nil
+	}
+}
+
+// WithBaseURL points the client at an api root, e.g. https://aether:8080/api
+func WithBaseURL(raw string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return fmt.Errorf("invalid base url: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid base url %q, expected scheme://host/path", raw)
+		}
+
+		c.url.Scheme, c.url.Host = u.Scheme, u.Host
+		c.url.Path = path.Join("/", u.Path, "v1")
+		return nil
+	}
+}
+
+// WithPeerSigning signs replication requests as peer with the key shared by the instances
+func WithPeerSigning(peer string, key registry.Secret) Option {
+	return func(c *Client) error {
+		if strings.TrimSpace(peer) == "" || key.Value() == "" {
+			return errors.New("replication peer name and key are required")
+		}
+		c.peer, c.peerKey = peer, key
+		return nil
+	}
+}
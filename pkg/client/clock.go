@@ -0,0 +1,42 @@
+package client
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	// serverTimeHeader carries the server clock on every api response
+	serverTimeHeader = "X-Aether-Server-Time"
+
+	// clockSkewWarning is the skew past which presigned urls may look expired or not yet valid
+	clockSkewWarning = 30 * time.Second
+)
+
+// checkClockSkew compares the server clock of resp with ours and warns once when they drift apart.
+// The server stamped the response somewhere between sent and now, so half the round trip is tolerated on top.
+func (c *Client) checkClockSkew(resp *http.Response, sent time.Time) {
+	stamp := resp.Header.Get(serverTimeHeader)
+	if stamp == "" {
+		return
+	}
+	serverTime, err := time.Parse(time.RFC3339Nano, stamp)
+	if err != nil {
+		return
+	}
+
+	received := time.Now()
+	halfTrip := received.Sub(sent) / 2
+	skew := serverTime.Sub(sent.Add(halfTrip))
+	if skew.Abs()-halfTrip <= clockSkewWarning {
+		return
+	}
+
+	c.skewWarning.Do(func() {
+		slog.Warn("local clock differs from the server, presigned urls may be rejected or expire early, sync the system clock",
+			"skew", skew.Round(time.Second),
+			"serverTime", serverTime,
+			"localTime", received.UTC())
+	})
+}
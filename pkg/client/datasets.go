@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+)
+
+const (
+	DatasetsApiPath             = "/datasets"
+	DatasetVersionsApiPath      = "/datasets/%s/versions"
+	DatasetVersionManifestPath  = "/datasets/%s/versions/%d/manifest"
+	DatasetVersionAssetsApiPath = "/datasets/%s/versions/%d/assets"
+	DatasetReadmeApiPath        = "/datasets/%s/readme"
+)
+
+// CreateDataset registers a new dataset.
+func (c *Client) CreateDataset(ctx context.Context, request v1.CreateDatasetRequest) (*v1.CreateDatasetResponse, error) {
+	slog.Debug("creating dataset", "name", request.Name)
+
+	var response v1.CreateDatasetResponse
+	if err := c.sendJSON(ctx, http.MethodPost, DatasetsApiPath, request, &response, http.StatusCreated); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// CreateDatasetVersion opens a new draft version of a dataset.
+func (c *Client) CreateDatasetVersion(ctx context.Context, dataset string, description string) (*v1.DatasetVersionResponse, error) {
+	slog.Debug("creating dataset version", "dataset", dataset)
+
+	var response v1.DatasetVersionResponse
+	request := v1.CreateDatasetVersionRequest{Description: description}
+	if err := c.sendJSON(ctx, http.MethodPost, fmt.Sprintf(DatasetVersionsApiPath, dataset), request, &response, http.StatusCreated); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetDatasetVersionManifest returns the frozen manifest of a published dataset version,
+// the server narrows it to a partial manifest when filter is set.
+func (c *Client) GetDatasetVersionManifest(ctx context.Context, dataset string, version int, filter *registry.ManifestFilter) (*v1.DatasetVersionManifestResponse, error) {
+	slog.Debug("getting dataset version manifest", "dataset", dataset, "version", version)
+
+	p := fmt.Sprintf(DatasetVersionManifestPath, dataset, version)
+	if !filter.IsEmpty() {
+		query := url.Values{"tag": filter.Tags, "mime_type": filter.MimeTypes}
+		if filter.Prefix != "" {
+			query.Set("prefix", filter.Prefix)
+		}
+		p += "?" + query.Encode()
+	}
+
+	var response v1.DatasetVersionManifestResponse
+	if err := c.sendJSON(ctx, http.MethodGet, p, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// AddDatasetVersionAssets adds assets to a draft dataset version in batches.
+func (c *Client) AddDatasetVersionAssets(ctx context.Context, dataset string, version int, checksums ...string) (*v1.DatasetVersionResponse, error) {
+	slog.Debug("adding dataset version assets", "dataset", dataset, "version", version, "total", len(checksums))
+
+	var response v1.DatasetVersionResponse
+	p := fmt.Sprintf(DatasetVersionAssetsApiPath, dataset, version)
+	for start := 0; start < len(checksums); start += BatchSize {
+		end := min(start+BatchSize, len(checksums))
+
+		request := v1.DatasetVersionAssetsRequest{Checksums: checksums[start:end]}
+		if err := c.sendJSON(ctx, http.MethodPost, p, request, &response, http.StatusOK); err != nil {
+			return nil, err
+		}
+	}
+
+	return &response, nil
+}
+
+// ListDatasetVersionAssets returns a page of the assets of a dataset version, pass the NextPageToken
+// of the response to get the next one. DatasetVersionAssets iterates over every page.
+func (c *Client) ListDatasetVersionAssets(ctx context.Context, dataset string, version int, request v1.ListDatasetVersionAssetsRequest) (*v1.ListAssetsResponse, error) {
+	slog.Debug("listing dataset version assets", "dataset", dataset, "version", version, "continued", request.PageToken != "")
+
+	var response v1.ListAssetsResponse
+	if err := c.sendJSON(ctx, http.MethodGet, fmt.Sprintf(DatasetVersionAssetsApiPath, dataset, version), request, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetDatasetReadme returns the markdown readme of a dataset with its rendered HTML.
+func (c *Client) GetDatasetReadme(ctx context.Context, dataset string) (*v1.DatasetReadmeResponse, error) {
+	slog.Debug("getting dataset readme", "dataset", dataset)
+
+	var response v1.DatasetReadmeResponse
+	if err := c.sendJSON(ctx, http.MethodGet, fmt.Sprintf(DatasetReadmeApiPath, dataset), nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// SetDatasetReadme replaces the markdown readme of a dataset, an empty readme removes it.
+func (c *Client) SetDatasetReadme(ctx context.Context, dataset string, readme string) (*v1.DatasetReadmeResponse, error) {
+	slog.Debug("setting dataset readme", "dataset", dataset, "bytes", len(readme))
+
+	var response v1.DatasetReadmeResponse
+	request := v1.PutDatasetReadmeRequest{Readme: readme}
+	if err := c.sendJSON(ctx, http.MethodPut, fmt.Sprintf(DatasetReadmeApiPath, dataset), request, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+var ErrInsufficientSpace = errors.New("not enough free disk space")
+
+// diskSpace is what the file system holding a directory can still store,
+// Inodes is negative on file systems that don't limit the number of files.
+type diskSpace struct {
+	Bytes  uint64
+	Inodes int64
+}
+
+// checkDiskSpace fails early when the file system holding dir can't fit size more bytes in files new files.
+// Platforms without file system statistics are not checked.
+func checkDiskSpace(dir string, size int64, files int) error {
+	free, err := statDiskSpace(existingDir(dir))
+	if err != nil {
+		return fmt.Errorf("check free disk space of %s: %w", dir, err)
+	}
+	if free == nil {
+		slog.Debug("free disk space is unknown on this platform, skipping the check", "dir", dir)
+		return nil
+	}
+
+	if size > 0 && uint64(size) > free.Bytes {
+		return fmt.Errorf("%w: %s needs %d bytes, %d are available", ErrInsufficientSpace, dir, size, free.Bytes)
+	}
+	if free.Inodes >= 0 && int64(files) > free.Inodes {
+		return fmt.Errorf("%w: %s needs %d files, %d inodes are available", ErrInsufficientSpace, dir, files, free.Inodes)
+	}
+
+	slog.Debug("checked free disk space", "dir", dir, "bytes", size, "files", files, "free_bytes", free.Bytes, "free_inodes", free.Inodes)
+	return nil
+}
+
+// existingDir returns the closest existing ancestor of dir, targets are often created by the download itself
+func existingDir(dir string) string {
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,18 @@
+//go:build linux || darwin
+
+package client
+
+import "syscall"
+
+func statDiskSpace(dir string) (*diskSpace, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return nil, err
+	}
+
+	space := &diskSpace{Bytes: uint64(st.Bavail) * uint64(st.Bsize), Inodes: -1}
+	if st.Files > 0 {
+		space.Inodes = int64(st.Ffree)
+	}
+	return space, nil
+}
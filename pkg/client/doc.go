@@ -0,0 +1,14 @@
+// Package client is the Go client of the aether api. Requests are retried with backoff when the
+// client is durable, fail over between endpoints and carry an idempotency key, so callers get typed
+// methods instead of building JSON against the api structs themselves.
+//
+//	c, err := client.New(client.WithBaseURL("https://aether.example.com/api"), client.WithAPIKey(key), client.WithDurable(true))
+//	if err != nil {
+//		return err
+//	}
+//	created, err := c.CreateAssets(ctx, v1.AssetPayload{Checksum: sum, Display: "cat.png", MimeType: "image/png"})
+//	...
+//	for asset, err := range c.Assets(ctx, v1.ListAssetsFilters{State: "active"}) {
+//		...
+//	}
+package client
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultFailoverCooldown = 30 * time.Second
+	HealthApiPath           = "/api/health"
+	probeTimeout            = 2 * time.Second
+)
+
+// failover tracks the configured api hosts and sticks to the active one
+// until it fails, then moves on to the next host that passes a health probe.
+type failover struct {
+	mu        sync.Mutex
+	hosts     []string
+	active    int
+	downUntil map[string]time.Time
+	cooldown  time.Duration
+}
+
+func newFailover(hosts []string, cooldown time.Duration) *failover {
+	return &failover{
+		hosts:     hosts,
+		downUntil: make(map[string]time.Time, len(hosts)),
+		cooldown:  cooldown,
+	}
+}
+
+// Current returns the active host
+func (f *failover) Current() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.hosts[f.active]
+}
+
+// Has reports whether the host belongs to the pool (presigned storage urls don't)
+func (f *failover) Has(host string) bool {
+	return slices.Contains(f.hosts, host)
+}
+
+// Fail marks the host as down and switches to the next healthy host.
+// It returns false when no other host is available.
+func (f *failover) Fail(host string, probe func(host string) bool) (string, bool) {
+	f.mu.Lock()
+	now := time.Now()
+	f.downUntil[host] = now.Add(f.cooldown)
+
+	// another request may have switched already
+	if current := f.hosts[f.active]; current != host {
+		f.mu.Unlock()
+		return current, true
+	}
+
+	start := slices.Index(f.hosts, host)
+	var candidates []int
+	for i := 1; i < len(f.hosts); i++ {
+		idx := (start + i) % len(f.hosts)
+		if now.After(f.downUntil[f.hosts[idx]]) {
+			candidates = append(candidates, idx)
+		}
+	}
+	f.mu.Unlock()
+
+	for _, idx := range candidates {
+		candidate := f.hosts[idx]
+		if !probe(candidate) {
+			f.mu.Lock()
+			f.downUntil[candidate] = time.Now().Add(f.cooldown)
+			f.mu.Unlock()
+			continue
+		}
+
+		f.mu.Lock()
+		f.active = idx
+		f.mu.Unlock()
+
+		slog.Warn("api endpoint failed, switching", "from", host, "to", candidate)
+		return candidate, true
+	}
+
+	return host, false
+}
+
+// probe checks the health endpoint of a host
+func (c *Client) probe(host string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	u := *c.url
+	u.Host = host
+	u.Path = HealthApiPath
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		slog.Debug("health probe failed", "host", host, "error", err)
+		return false
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+// failoverRequest points a failed api request to the next healthy host.
+// It reports whether the request was moved.
+func (c *Client) failoverRequest(req *http.Request, err error, resp *http.Response) bool {
+	if c.failover == nil || !c.failover.Has(req.URL.Host) {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	// only node level failures justify switching
+	if err == nil && resp.StatusCode != http.StatusBadGateway &&
+		resp.StatusCode != http.StatusServiceUnavailable &&
+		resp.StatusCode != http.StatusGatewayTimeout {
+		return false
+	}
+
+	next, ok := c.failover.Fail(req.URL.Host, c.probe)
+	if !ok || next == req.URL.Host {
+		return false
+	}
+
+	req.URL.Host = next
+	req.Host = next
+	return true
+}
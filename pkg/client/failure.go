@@ -0,0 +1,121 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Failure policy modes
+const (
+	FailOnAny       = "any"
+	FailOnNone      = "none"
+	FailOnThreshold = "threshold"
+)
+
+// FailurePolicy decides whether the items that failed in a batch operation fail the whole operation.
+// The zero policy fails on any item.
+type FailurePolicy struct {
+	Mode string
+
+	// Threshold is the tolerated share of failed items, between 0 and 1
+	Threshold float64
+}
+
+// ParseFailurePolicy reads a policy written as none, any, threshold=5% or threshold=0.05
+func ParseFailurePolicy(raw string) (FailurePolicy, error) {
+	mode, value, hasValue := strings.Cut(strings.TrimSpace(strings.ToLower(raw)), "=")
+	switch {
+	case mode == FailOnAny && !hasValue, mode == FailOnNone && !hasValue:
+		return FailurePolicy{Mode: mode}, nil
+	case mode == FailOnThreshold && hasValue:
+		percent := strings.HasSuffix(value, "%")
+		threshold, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err == nil && percent {
+			threshold /= 100
+		}
+		if err != nil || threshold < 0 || threshold > 1 {
+			return FailurePolicy{}, fmt.Errorf("invalid failure threshold %q, expected a share like 5%% or 0.05", value)
+		}
+		return FailurePolicy{Mode: mode, Threshold: threshold}, nil
+	default:
+		return FailurePolicy{}, fmt.Errorf("invalid failure policy %q, expected none, any or threshold=<percent>", raw)
+	}
+}
+
+// Tolerates reports whether failed out of total items still count as a success
+func (p FailurePolicy) Tolerates(failed int, total int) bool {
+	switch {
+	case failed <= 0:
+		return true
+	case p.Mode == FailOnNone:
+		return true
+	case p.Mode == FailOnThreshold && total > 0:
+		return float64(failed)/float64(total) <= p.Threshold
+	default:
+		return false
+	}
+}
+
+func (p FailurePolicy) String() string {
+	switch p.Mode {
+	case FailOnNone:
+		return FailOnNone
+	case FailOnThreshold:
+		return fmt.Sprintf("%s=%g%%", FailOnThreshold, p.Threshold*100)
+	default:
+		return FailOnAny
+	}
+}
+
+// PartialFailureError reports a batch operation that went through with some failed items,
+// Err is the first item failure.
+type PartialFailureError struct {
+	Operation string
+	Failed    int
+	Total     int
+	Err       error
+}
+
+func (e *PartialFailureError) Error() string {
+	return fmt.Sprintf("%s failed for %d out of %d items, first error: %v", e.Operation, e.Failed, e.Total, e.Err)
+}
+
+func (e *PartialFailureError) Unwrap() error {
+	return e.Err
+}
+
+// failures counts the failed items of a batch operation and keeps the first failure
+type failures struct {
+	count int
+	first error
+}
+
+func (f *failures) add(n int, err error) {
+	if n <= 0 {
+		return
+	}
+	f.count += n
+	if f.first == nil {
+		f.first = err
+	}
+}
+
+// merge adds the items of a partial failure, any other error is returned as is
+func (f *failures) merge(err error) error {
+	var partial *PartialFailureError
+	if errors.As(err, &partial) {
+		f.add(partial.Failed, partial.Err)
+		return nil
+	}
+	return err
+}
+
+// err returns a partial failure of total items, nil when nothing failed
+func (f *failures) err(operation string, total int) error {
+	if f.count == 0 {
+		return nil
+	}
+	return &PartialFailureError{Operation: operation, Failed: f.count, Total: total, Err: f.first}
+}
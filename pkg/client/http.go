@@ -12,10 +12,17 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"strconv"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/tracing"
+	"github.com/UnivocalX/aether/pkg/web/api/apierrors"
 	"github.com/UnivocalX/aether/pkg/web/api/dto"
 )
 
@@ -102,12 +109,84 @@ func (c *Client) upload2Storage(ctx context.Context, path string, presignedUrl r
 		return io.NopCloser(file), nil
 	}
 
-	return c.send(req)
+	return c.sendWith(c.objectClient(), req)
+}
+
+// uploadStream sends r to a presigned upload url, a negative size streams it chunked.
+// The body can't be rewound, so the upload is never retried.
+func (c *Client) uploadStream(ctx context.Context, presignedUrl string, r io.Reader, size int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, presignedUrl, io.NopCloser(r))
+	if err != nil {
+		return err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+
+	resp, err := c.objectClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// objectClient shares the api connection pool but leaves object transfers to the context deadline,
+// objects may take longer than the api timeout.
+func (c *Client) objectClient() *http.Client {
+	return &http.Client{Transport: c.http.Transport}
+}
+
+// downloadObject opens a presigned object download, objects may take longer than the api timeout.
+// The caller is responsible for closing the body.
+func (c *Client) downloadObject(ctx context.Context, presignedUrl string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, presignedUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.objectClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download object: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("download object: unexpected status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
 }
 
 func (c *Client) send(req *http.Request) (*http.Response, error) {
-	var resp *http.Response
-	var err error
+	return c.sendWith(c.http, req)
+}
+
+// sendWith retries req on hc, object transfers use a client without the api request timeout.
+// With tracing one client span covers every attempt.
+func (c *Client) sendWith(hc *http.Client, req *http.Request) (resp *http.Response, err error) {
+	if c.tracing != nil {
+		ctx, span := tracing.Tracer(c.tracing).Start(req.Context(), "HTTP "+req.Method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("http.method", req.Method),
+				attribute.String("server.address", req.URL.Host),
+				attribute.String("url.path", req.URL.Path),
+			),
+		)
+		defer func() {
+			if resp != nil {
+				span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+			}
+			tracing.RecordError(span, err)
+			span.End()
+		}()
+		req = req.WithContext(ctx)
+		tracing.Inject(ctx, req.Header)
+	}
 
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		// rewind body if possible
@@ -118,7 +197,22 @@ func (c *Client) send(req *http.Request) (*http.Response, error) {
 			}
 		}
 
-		resp, err = c.http.Do(req)
+		sent := time.Now()
+		resp, err = hc.Do(req)
+		if err == nil {
+			c.checkClockSkew(resp, sent)
+		}
+
+		// switch to another api node right away instead of waiting on a dead one
+		if c.failoverRequest(req, err, resp) {
+			if resp != nil {
+				resp.Body.Close()
+				err = fmt.Errorf("api endpoint unavailable: status %d", resp.StatusCode)
+				resp = nil
+			}
+			continue
+		}
+
 		if !shouldRetry(err, resp) {
 			// success
 			break
@@ -149,15 +243,30 @@ func (c *Client) send(req *http.Request) (*http.Response, error) {
 }
 
 // newRequest builds an HTTP request targeting the client's base URL at the given path.
-func (c *Client) newRequest(ctx context.Context, method string, path string, body io.Reader) (*http.Request, error) {
+func (c *Client) newRequest(ctx context.Context, method string, p string, body io.Reader) (*http.Request, error) {
+	// p may carry a query, e.g. "/assets/x?purge=true"
+	p, rawQuery, _ := strings.Cut(p, "?")
+
 	u := *c.url
-	u.Path = path
+	u.Path = path.Join(c.url.Path, p)
+	u.RawQuery = rawQuery
+	if c.failover != nil {
+		u.Host = c.failover.Current()
+	}
 
 	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	// Default headers
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	setIdempotencyKey(req)
+
 	// If body is a file, set Content-Length and GetBody for retries
 	if f, ok := body.(*os.File); ok {
 		info, statErr := f.Stat()
@@ -213,13 +322,144 @@ func shouldRetry(err error, resp *http.Response) bool {
 		code == http.StatusGatewayTimeout
 }
 
+// BodyFactory opens a fresh request body for every attempt,
+// so streamed bodies can be retried without buffering them in memory.
+type BodyFactory func() (io.ReadCloser, error)
+
+// JSONBody streams the JSON encoding of payload through a pipe
+func JSONBody(payload any) BodyFactory {
+	return func() (io.ReadCloser, error) {
+		pr, pw := io.Pipe()
+		go func() {
+			// the transport closes the reader on failure, which unblocks the encoder
+			pw.CloseWithError(json.NewEncoder(pw).Encode(payload))
+		}()
+		return pr, nil
+	}
+}
+
+// FileBody streams a file from disk, reopening it for every attempt
+func FileBody(path string) BodyFactory {
+	return func() (io.ReadCloser, error) {
+		return os.Open(path)
+	}
+}
+
+// ReaderBody streams whatever the producer writes, re-running it for every attempt
+func ReaderBody(produce func(w io.Writer) error) BodyFactory {
+	return func() (io.ReadCloser, error) {
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(produce(pw))
+		}()
+		return pr, nil
+	}
+}
+
+// newStreamRequest builds a request with a chunked body produced by the factory.
+func (c *Client) newStreamRequest(ctx context.Context, method string, path string, contentType string, body BodyFactory) (*http.Request, error) {
+	rc, err := body()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open request body: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, method, path, rc)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+
+	req.ContentLength = -1
+	req.GetBody = body
+	req.Header.Set("Content-Type", contentType)
+	return req, nil
+}
+
+// Stream sends a streamed body and decodes a successful JSON response into out.
+func (c *Client) Stream(ctx context.Context, method string, path string, contentType string, body BodyFactory, out any, expected int) error {
+	req, err := c.newStreamRequest(ctx, method, path, contentType, body)
+	if err != nil {
+		return err
+	}
+	return c.do(req, out, expected)
+}
+
+// sendJSON streams the payload (if any) as JSON and decodes a successful response into out.
+func (c *Client) sendJSON(ctx context.Context, method string, path string, payload any, out any, expected int) error {
+	if payload != nil {
+		return c.Stream(ctx, method, path, "application/json", JSONBody(payload), out, expected)
+	}
+
+	req, err := c.newRequest(ctx, method, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, out, expected)
+}
+
+// do sends the request and decodes a successful JSON response into out.
+func (c *Client) do(req *http.Request, out any, expected int) error {
+	resp, err := c.send(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expected {
+		return decodeErrorResponse(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// APIError is a non successful response returned by the aether api
+type APIError struct {
+	StatusCode int
+	Code       apierrors.Code
+	Msg        string
+	Detail     string
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.Msg == "":
+		return fmt.Sprintf("unexpected status %d", e.StatusCode)
+	case e.Detail == "":
+		return e.Msg
+	default:
+		return fmt.Sprintf("%s: %s", e.Msg, e.Detail)
+	}
+}
+
+// Temporary reports whether the server may accept the same request later
+func (e *APIError) Temporary() bool {
+	return e.StatusCode >= http.StatusInternalServerError || e.StatusCode == http.StatusTooManyRequests
+}
+
+// ErrorCode returns the api error code of err, empty when err is not an api error or the server sent none
+func ErrorCode(err error) apierrors.Code {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
 func decodeErrorResponse(resp *http.Response) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
 	var errResp dto.ErrorResponse
 	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
-		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		return apiErr
 	}
+
+	apiErr.Msg = errResp.Msg
+	apiErr.Code = errResp.Code
 	if errResp.Err != nil {
-		return fmt.Errorf("%s: %s", errResp.Msg, errResp.Err.Msg)
+		apiErr.Detail = errResp.Err.Msg
 	}
-	return errors.New(errResp.Msg)
-}
\ No newline at end of file
+	return apiErr
+}
@@ -0,0 +1,16 @@
+package client
+
+import (
+	"crypto/rand"
+	"net/http"
+)
+
+// idempotencyKeyHeader makes the server run a creating request once, however often it is retried
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// setIdempotencyKey gives a POST a random key its retries share, unless the caller set one
+func setIdempotencyKey(req *http.Request) {
+	if req.Method == http.MethodPost && req.Header.Get(idempotencyKeyHeader) == "" {
+		req.Header.Set(idempotencyKeyHeader, rand.Text())
+	}
+}
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+)
+
+const InfoApiPath = "/info"
+
+// GetServerInfo returns the limits of the server, e.g. its presign expiry bounds.
+// They only change on restart, with WithCache the lookup is served from the cache and revalidated by ETag.
+func (c *Client) GetServerInfo(ctx context.Context) (*v1.ServerInfoResponse, error) {
+	slog.Debug("getting server info")
+
+	var response v1.ServerInfoResponse
+	if err := c.sendJSON(ctx, http.MethodGet, InfoApiPath, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,279 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math"
+	mathrand "math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+)
+
+// Load test operations
+const (
+	LoadRegister = "register"
+	LoadPresign  = "presign"
+	LoadLookup   = "lookup"
+)
+
+// LoadTestOperations lists the operations a load test mixes, in report order
+var LoadTestOperations = []string{LoadRegister, LoadPresign, LoadLookup}
+
+// LoadTestConfig shapes the synthetic traffic of a load test.
+type LoadTestConfig struct {
+	// Rate is the number of operations started per second across all workers
+	Rate float64
+
+	// Duration bounds the test, the caller's deadline still applies
+	Duration time.Duration
+
+	// Concurrency is the number of workers sending requests
+	Concurrency int
+
+	// Mix weighs the operations, an operation missing or weighed 0 is never sent
+	Mix map[string]int
+}
+
+// LoadTestStats holds the latencies of one operation.
+type LoadTestStats struct {
+	Operation string
+	Count     int
+	Errors    int
+	Mean      time.Duration
+	P50       time.Duration
+	P90       time.Duration
+	P99       time.Duration
+	Max       time.Duration
+}
+
+// LoadTestResult reports a finished load test.
+type LoadTestResult struct {
+	Elapsed    time.Duration
+	Throughput float64
+	Operations []LoadTestStats
+}
+
+// loadTest keeps the state shared by the workers of a load test
+type loadTest struct {
+	client *Client
+	ops    []string
+	weight int
+	mix    map[string]int
+
+	mu        sync.Mutex
+	checksums []string
+	latencies map[string][]time.Duration
+	errors    map[string]int
+}
+
+// LoadTest sends synthetic asset registrations, ingress presigns and existence lookups at the configured rate
+// and reports their latency percentiles. Registered assets are real pending assets, run it against a test registry.
+func (c *Client) LoadTest(ctx context.Context, cfg LoadTestConfig) (*LoadTestResult, error) {
+	if cfg.Rate <= 0 {
+		return nil, fmt.Errorf("load test rate must be positive, got %v", cfg.Rate)
+	}
+	if cfg.Duration <= 0 {
+		return nil, fmt.Errorf("load test duration must be positive, got %s", cfg.Duration)
+	}
+
+	lt := &loadTest{
+		client:    c,
+		mix:       cfg.Mix,
+		latencies: make(map[string][]time.Duration),
+		errors:    make(map[string]int),
+	}
+	for op, weight := range cfg.Mix {
+		if !slices.Contains(LoadTestOperations, op) {
+			return nil, fmt.Errorf("unknown load test operation %q, expected one of %v", op, LoadTestOperations)
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("load test operation %s has a negative weight", op)
+		}
+		lt.weight += weight
+	}
+	if lt.weight == 0 {
+		return nil, fmt.Errorf("load test mix has no operation with a positive weight")
+	}
+	for _, op := range LoadTestOperations {
+		if cfg.Mix[op] > 0 {
+			lt.ops = append(lt.ops, op)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	workers := max(cfg.Concurrency, 1)
+	queue := make(chan string, workers)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for op := range queue {
+				lt.run(ctx, op)
+			}
+		}()
+	}
+
+	slog.Info("starting load test", "rate", cfg.Rate, "duration", cfg.Duration.String(), "concurrency", workers)
+	started := time.Now()
+
+	// a full queue blocks the ticker, the achieved throughput is reported next to the target rate
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / cfg.Rate))
+	defer ticker.Stop()
+
+dispatch:
+	for {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case <-ticker.C:
+			select {
+			case queue <- lt.pick():
+			case <-ctx.Done():
+				break dispatch
+			}
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return lt.result(time.Since(started)), nil
+}
+
+// pick draws the next operation by weight
+func (lt *loadTest) pick() string {
+	n := mathrand.IntN(lt.weight)
+	for _, op := range lt.ops {
+		if n < lt.mix[op] {
+			return op
+		}
+		n -= lt.mix[op]
+	}
+	return lt.ops[len(lt.ops)-1]
+}
+
+// run sends one operation and records its latency, requests cut short by the end of the test are not counted
+func (lt *loadTest) run(ctx context.Context, op string) {
+	checksum, known := lt.known()
+	if !known && op != LoadRegister {
+		// presigns and lookups need a registered asset
+		op = LoadRegister
+	}
+
+	started := time.Now()
+	var err error
+	switch op {
+	case LoadRegister:
+		checksum, err = lt.register(ctx)
+	case LoadPresign:
+		_, err = lt.client.GetAssetIngress(ctx, checksum)
+	case LoadLookup:
+		_, err = lt.client.AssetsExist(ctx, checksum)
+	}
+	elapsed := time.Since(started)
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	lt.latencies[op] = append(lt.latencies[op], elapsed)
+	if err != nil {
+		slog.Debug("load test operation failed", "operation", op, "error", err)
+		lt.errors[op]++
+		return
+	}
+	if op == LoadRegister {
+		lt.checksums = append(lt.checksums, checksum)
+	}
+}
+
+// register creates a synthetic pending asset with a random checksum
+func (lt *loadTest) register(ctx context.Context) (string, error) {
+	sum := make([]byte, 32)
+	if _, err := rand.Read(sum); err != nil {
+		return "", err
+	}
+	checksum := hex.EncodeToString(sum)
+
+	request := v1.CreateAssetsBatchRequest{Assets: []v1.AssetPayload{{
+		Checksum:  checksum,
+		Display:   "loadtest-" + checksum[:12],
+		MimeType:  "application/octet-stream",
+		SizeBytes: mathrand.Int64N(1 << 20),
+		Extra:     map[string]any{"loadtest": true},
+	}}}
+
+	response, err := lt.client.PostAssetsBatch(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	if response.Failed > 0 && len(response.Errors) > 0 {
+		return "", fmt.Errorf("failed to register asset %s: %s", checksum, response.Errors[0].Message)
+	}
+	return checksum, nil
+}
+
+// known returns a random asset registered by the test
+func (lt *loadTest) known() (string, bool) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	if len(lt.checksums) == 0 {
+		return "", false
+	}
+	return lt.checksums[mathrand.IntN(len(lt.checksums))], true
+}
+
+func (lt *loadTest) result(elapsed time.Duration) *LoadTestResult {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	result := &LoadTestResult{Elapsed: elapsed}
+	var total int
+	for _, op := range LoadTestOperations {
+		latencies := lt.latencies[op]
+		if len(latencies) == 0 {
+			continue
+		}
+		total += len(latencies)
+
+		slices.Sort(latencies)
+		var sum time.Duration
+		for _, l := range latencies {
+			sum += l
+		}
+
+		result.Operations = append(result.Operations, LoadTestStats{
+			Operation: op,
+			Count:     len(latencies),
+			Errors:    lt.errors[op],
+			Mean:      sum / time.Duration(len(latencies)),
+			P50:       percentile(latencies, 0.50),
+			P90:       percentile(latencies, 0.90),
+			P99:       percentile(latencies, 0.99),
+			Max:       latencies[len(latencies)-1],
+		})
+	}
+	if elapsed > 0 {
+		result.Throughput = float64(total) / elapsed.Seconds()
+	}
+	return result
+}
+
+// percentile returns the nearest rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+)
+
+// PageSize is the number of assets the iterators request per page, the most the api returns
+const PageSize = 1000
+
+// Assets iterates over every asset matching filters, fetching a page at a time as the loop advances.
+// A failed page ends the iteration with its error, breaking out of the loop stops fetching.
+//
+//	for asset, err := range c.Assets(ctx, v1.ListAssetsFilters{IncludedTags: []string{"train"}}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Client) Assets(ctx context.Context, filters v1.ListAssetsFilters) iter.Seq2[*v1.AssetDetails, error] {
+	return paginate(func(token string) (*v1.ListAssetsResponse, error) {
+		return c.ListAssets(ctx, v1.ListAssetsRequest{PageToken: token, Limit: PageSize, ListAssetsFilters: filters})
+	})
+}
+
+// DatasetVersionAssets iterates over the assets of a dataset version like Assets does
+func (c *Client) DatasetVersionAssets(ctx context.Context, dataset string, version int) iter.Seq2[*v1.AssetDetails, error] {
+	return paginate(func(token string) (*v1.ListAssetsResponse, error) {
+		return c.ListDatasetVersionAssets(ctx, dataset, version, v1.ListDatasetVersionAssetsRequest{PageToken: token, Limit: PageSize})
+	})
+}
+
+// paginate yields the assets of the pages list returns, following their tokens until one has none
+func paginate(list func(token string) (*v1.ListAssetsResponse, error)) iter.Seq2[*v1.AssetDetails, error] {
+	return func(yield func(*v1.AssetDetails, error) bool) {
+		token := ""
+		for {
+			page, err := list(token)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, asset := range page.Assets {
+				if !yield(asset, nil) {
+					return
+				}
+			}
+
+			if page.NextPageToken == "" {
+				return
+			}
+			token = page.NextPageToken
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// PullDataset materializes a published dataset version into dir, only the slice matching filter when it is set.
+// Files are named after the asset display names, falling back to the checksum for unsafe or repeated names.
+// Files already present with the expected size are kept, so an interrupted pull can simply be rerun.
+// maxBytes, when positive, stops the pull before the first asset that would exceed it and marks the workspace partial.
+// The free disk space is checked before anything is downloaded, failed assets don't stop the others
+// and are returned as a *PartialFailureError once the workspace is saved.
+// The directory is recorded as a workspace of the version, see Workspace.
+func (c *Client) PullDataset(ctx context.Context, dataset string, version int, dir string, filter *registry.ManifestFilter, maxBytes int64) (*registry.VersionManifest, error) {
+	slog.Info("pulling dataset", "dataset", dataset, "version", version, "dir", dir)
+
+	response, err := c.GetDatasetVersionManifest(ctx, dataset, version, filter)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &response.VersionManifest
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	plan := planPull(manifest, dir, maxBytes)
+	if len(plan.Items) < len(manifest.Assets) {
+		slog.Warn("pull limited by max bytes", "max_bytes", maxBytes, "assets", len(plan.Items), "skipped", len(manifest.Assets)-len(plan.Items))
+	}
+	if err := checkDiskSpace(dir, plan.DownloadBytes, plan.Downloads); err != nil {
+		return nil, err
+	}
+
+	ws := &Workspace{
+		Dataset:  manifest.Dataset,
+		Version:  manifest.Version,
+		Digest:   manifest.Digest,
+		Partial:  manifest.Partial || len(plan.Items) < len(manifest.Assets),
+		PulledAt: time.Now().UTC(),
+		Files:    make(map[string]*WorkspaceFile, len(plan.Items)),
+	}
+
+	var pulled, kept int
+	var failed failures
+	for start := 0; start < len(plan.Items); start += BatchSize {
+		chunk := plan.Items[start:min(start+BatchSize, len(plan.Items))]
+
+		// download urls are presigned a chunk at a time so they don't expire during long pulls
+		urls, urlErrs, err := c.pullUrls(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range chunk {
+			target := filepath.Join(dir, item.Name)
+
+			entry := ReportEntry{Path: target, Checksum: item.Entry.Checksum, Outcome: OutcomeKept, Bytes: item.Entry.SizeBytes}
+			if item.Keep {
+				slog.Debug("kept existing file", "checksum", item.Entry.Checksum, "path", target)
+				c.report.Record(entry, time.Time{}, nil)
+				kept++
+			} else {
+				started := time.Now()
+				err := urlErrs[item.Entry.Checksum]
+				if err == nil {
+					err = c.pullObject(ctx, item.Entry, urls[item.Entry.Checksum], target)
+				}
+				entry.Outcome = OutcomePulled
+				c.report.Record(entry, started, err)
+				if err != nil {
+					if ctx.Err() != nil {
+						return nil, err
+					}
+					// a failed asset is left out of the workspace, which is then marked partial
+					slog.Error("failed to pull asset", "checksum", item.Entry.Checksum, "error", err)
+					failed.add(1, fmt.Errorf("pull asset %s: %w", item.Entry.Checksum, err))
+					continue
+				}
+				slog.Debug("pulled asset", "checksum", item.Entry.Checksum, "path", target)
+				pulled++
+			}
+
+			if err := ws.Track(dir, item.Name, item.Entry.Checksum); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ws.Partial = ws.Partial || failed.count > 0
+
+	// the workspace state lets status report local drift against the version
+	if err := ws.Save(dir); err != nil {
+		return nil, fmt.Errorf("save workspace state: %w", err)
+	}
+
+	slog.Info("pulled dataset", "dataset", dataset, "version", version, "assets", len(plan.Items), "pulled", pulled, "kept", kept, "failed", failed.count, "partial", ws.Partial)
+	if err := failed.err("pull", len(plan.Items)); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// pullPlan lists the assets a pull materializes and what still has to be downloaded
+type pullPlan struct {
+	Items         []pullItem
+	Downloads     int
+	DownloadBytes int64
+}
+
+type pullItem struct {
+	Entry registry.ManifestEntry
+	Name  string
+	Keep  bool
+}
+
+func planPull(manifest *registry.VersionManifest, dir string, maxBytes int64) *pullPlan {
+	plan := &pullPlan{Items: make([]pullItem, 0, len(manifest.Assets))}
+	used := make(map[string]bool, len(manifest.Assets))
+
+	var total int64
+	for _, entry := range manifest.Assets {
+		if maxBytes > 0 && total+entry.SizeBytes > maxBytes {
+			break
+		}
+		total += entry.SizeBytes
+
+		item := pullItem{Entry: entry, Name: materializedName(entry, used)}
+		if info, err := os.Stat(filepath.Join(dir, item.Name)); err == nil && info.Size() == entry.SizeBytes {
+			item.Keep = true
+		} else {
+			plan.Downloads++
+			plan.DownloadBytes += entry.SizeBytes
+		}
+		plan.Items = append(plan.Items, item)
+	}
+
+	return plan
+}
+
+// materializedName picks the relative file name of an asset, display names must stay inside the target dir
+func materializedName(entry registry.ManifestEntry, used map[string]bool) string {
+	name := filepath.Clean(filepath.FromSlash(entry.Display))
+	first := strings.Split(name, string(filepath.Separator))[0]
+	if entry.Display == "" || filepath.IsAbs(name) || first == ".." || first == WorkspaceDir || used[name] {
+		name = entry.Checksum
+	}
+
+	used[name] = true
+	return name
+}
+
+// pullUrls presigns the downloads of the items that are not kept in a single request,
+// assets the server refuses are returned with their error
+func (c *Client) pullUrls(ctx context.Context, items []pullItem) (map[string]string, map[string]error, error) {
+	checksums := make([]string, 0, len(items))
+	for _, item := range items {
+		if !item.Keep {
+			checksums = append(checksums, item.Entry.Checksum)
+		}
+	}
+	if len(checksums) == 0 {
+		return nil, nil, nil
+	}
+
+	response, err := c.GetAssetDownloadUrls(ctx, checksums...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get download urls: %w", err)
+	}
+
+	urls := make(map[string]string, len(response.Urls))
+	for _, url := range response.Urls {
+		urls[url.Checksum] = url.DownloadURL
+	}
+	errs := make(map[string]error, len(response.Errors))
+	for _, itemErr := range response.Errors {
+		errs[itemErr.Checksum] = fmt.Errorf("%s: %s", itemErr.Code, itemErr.Message)
+	}
+	return urls, errs, nil
+}
+
+// pullObject downloads an asset next to target and moves it in place once its checksum is verified
+func (c *Client) pullObject(ctx context.Context, entry registry.ManifestEntry, downloadURL string, target string) error {
+	ctx, cancel := c.fileContext(ctx)
+	defer cancel()
+
+	body, err := c.downloadObject(ctx, downloadURL)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), pullTempPrefix+"*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), body); err != nil {
+		return fmt.Errorf("write %s: %w", target, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != entry.Checksum {
+		return fmt.Errorf("%w: downloaded object hashes to %s", registry.ErrChecksumMismatch, sum)
+	}
+	return os.Rename(tmp.Name(), target)
+}
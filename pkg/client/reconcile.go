@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/pkg/web/api/apierrors"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+const (
+	maxBatchAttempts  = 4
+	minRetryBatchSize = 50
+)
+
+// Reconciliation summarizes the outcome of posting assets, after retries
+type Reconciliation struct {
+	Created  []*v1.BatchAssetDetails
+	Skipped  []*data.ItemError
+	Failed   []*data.ItemError
+	Attempts int
+}
+
+func (r *Reconciliation) Log() {
+	for _, itemErr := range r.Skipped {
+		slog.Debug("skipped asset", "checksum", itemErr.Checksum, "code", itemErr.Code, "reason", itemErr.Message)
+	}
+	for _, itemErr := range r.Failed {
+		slog.Error("failed to register asset", "checksum", itemErr.Checksum, "code", itemErr.Code, "field", itemErr.Field, "reason", itemErr.Message)
+	}
+
+	slog.Info("reconciliation report",
+		"created", len(r.Created),
+		"skipped", len(r.Skipped),
+		"failed", len(r.Failed),
+		"attempts", r.Attempts,
+	)
+}
+
+// recordReconciliation adds the assets that were skipped or failed to register to the client report,
+// created assets are recorded once uploaded
+func (c *Client) recordReconciliation(r *Reconciliation, assets []v1.AssetPayload, paths map[string]string) {
+	if c.report == nil {
+		return
+	}
+
+	sizes := make(map[string]int64, len(assets))
+	for _, a := range assets {
+		sizes[a.Checksum] = a.SizeBytes
+	}
+
+	for _, itemErr := range r.Skipped {
+		c.report.Record(ReportEntry{Path: paths[itemErr.Checksum], Checksum: itemErr.Checksum, Outcome: OutcomeSkipped, Bytes: sizes[itemErr.Checksum]}, time.Time{}, nil)
+	}
+	for _, itemErr := range r.Failed {
+		c.report.Record(ReportEntry{Path: paths[itemErr.Checksum], Checksum: itemErr.Checksum, Bytes: sizes[itemErr.Checksum]}, time.Time{}, errors.New(itemErr.Message))
+	}
+}
+
+type itemAction int
+
+const (
+	actionSkip itemAction = iota
+	actionRetry
+	actionReport
+)
+
+// classify decides what to do with a failed item: conflicts are skipped,
+// transient errors are retried and everything else is reported
+func classify(itemErr *data.ItemError) itemAction {
+	switch {
+	case itemErr.Retryable:
+		return actionRetry
+	case itemErr.Code == apierrors.AssetExists, itemErr.Code == apierrors.DuplicateItem:
+		return actionSkip
+	default:
+		return actionReport
+	}
+}
+
+// isTransient reports whether a failed batch request is worth retrying
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Temporary()
+	}
+
+	// transport failures
+	return true
+}
+
+// postAssets registers assets in batches, re-submitting retryable failures
+// in smaller batches with backoff until they succeed or attempts run out.
+func (c *Client) postAssets(ctx context.Context, assets ...v1.AssetPayload) (*Reconciliation, error) {
+	slog.Info("posting assets", "total", len(assets))
+
+	report := &Reconciliation{}
+	lastErr := make(map[string]*data.ItemError)
+	pending := assets
+	batchSize := BatchSize
+
+	for attempt := 0; len(pending) > 0; attempt++ {
+		if attempt == maxBatchAttempts {
+			for _, a := range pending {
+				report.Failed = append(report.Failed, lastErr[a.Checksum])
+			}
+			break
+		}
+
+		if attempt > 0 {
+			batchSize = max(batchSize/2, minRetryBatchSize)
+			slog.Warn("retrying failed assets", "total", len(pending), "attempt", attempt, "batchSize", batchSize)
+
+			select {
+			case <-time.After(backoff(attempt - 1)):
+			case <-ctx.Done():
+				return report, ctx.Err()
+			}
+		}
+
+		report.Attempts++
+		var retry []v1.AssetPayload
+
+		for start := 0; start < len(pending); start += batchSize {
+			end := min(start+batchSize, len(pending))
+			chunk := pending[start:end]
+
+			batch := v1.CreateAssetsBatchRequest{
+				Assets:    chunk,
+				ExpiresIn: uint(c.presignTTL.Seconds()),
+			}
+
+			response, err := c.PostAssetsBatch(ctx, batch)
+			if err != nil {
+				if !isTransient(err) {
+					return report, err
+				}
+
+				// the whole batch failed, retry every item
+				slog.Warn("failed to post assets batch", "total", len(chunk), "error", err)
+				for i, a := range chunk {
+					lastErr[a.Checksum] = data.NewItemError(i, a.Checksum, data.CodeInternal, err)
+				}
+				retry = append(retry, chunk...)
+				continue
+			}
+
+			report.Created = append(report.Created, response.Assets...)
+			for _, itemErr := range response.Errors {
+				switch classify(itemErr) {
+				case actionSkip:
+					report.Skipped = append(report.Skipped, itemErr)
+				case actionRetry:
+					lastErr[itemErr.Checksum] = itemErr
+					retry = append(retry, chunk[itemErr.Index])
+				default:
+					report.Failed = append(report.Failed, itemErr)
+				}
+			}
+		}
+
+		pending = retry
+	}
+
+	return report, nil
+}
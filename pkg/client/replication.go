@@ -0,0 +1,127 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+)
+
+const (
+	ReplicationApiPath = "/replication"
+)
+
+// GetReplicationWatermark returns the latest change of the peer feed and how far we acknowledged it
+func (c *Client) GetReplicationWatermark(ctx context.Context) (*v1.ReplicationWatermarkResponse, error) {
+	slog.Debug("getting replication watermark")
+
+	var response v1.ReplicationWatermarkResponse
+	if err := c.sendSigned(ctx, http.MethodGet, path.Join(ReplicationApiPath, "watermark"), nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// AckReplicationWatermark reports the last change of the peer feed we applied
+func (c *Client) AckReplicationWatermark(ctx context.Context, watermark string) error {
+	slog.Debug("acking replication watermark", "watermark", watermark)
+
+	request := v1.AckReplicationWatermarkRequest{Watermark: watermark}
+	return c.sendSigned(ctx, http.MethodPut, path.Join(ReplicationApiPath, "watermark"), request, nil, http.StatusNoContent)
+}
+
+// ListReplicationChanges returns a page of the peer asset changes after since
+func (c *Client) ListReplicationChanges(ctx context.Context, since string, limit uint) (*v1.ListReplicationChangesResponse, error) {
+	slog.Debug("listing replication changes", "since", since, "limit", limit)
+
+	request := v1.ListReplicationChangesRequest{Since: since, Limit: limit}
+
+	var response v1.ListReplicationChangesResponse
+	if err := c.sendSigned(ctx, http.MethodGet, path.Join(ReplicationApiPath, "changes"), request, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetReplicationObjectURL returns a download url of a ready peer asset object of project
+func (c *Client) GetReplicationObjectURL(ctx context.Context, project string, checksum string) (*v1.ReplicationObjectResponse, error) {
+	slog.Debug("getting replication object url", "project", project, "checksum", checksum)
+
+	var response v1.ReplicationObjectResponse
+	p := path.Join(ReplicationApiPath, "assets", checksum, "object") + "?" + url.Values{"project": {project}}.Encode()
+	if err := c.sendSigned(ctx, http.MethodGet, p, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// CopyObject streams a presigned download into a presigned upload of size bytes
+func (c *Client) CopyObject(ctx context.Context, from string, to registry.Secret, size int64) error {
+	src, err := c.downloadObject(ctx, from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	put, err := http.NewRequestWithContext(ctx, http.MethodPut, to.Value(), src)
+	if err != nil {
+		return err
+	}
+	put.ContentLength = size
+
+	dst, err := c.objectClient().Do(put)
+	if err != nil {
+		return fmt.Errorf("upload object: %w", err)
+	}
+	defer dst.Body.Close()
+
+	if dst.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(dst.Body, 1024))
+		return fmt.Errorf("upload object: unexpected status %d: %s", dst.StatusCode, bytes.TrimSpace(body))
+	}
+	return nil
+}
+
+// sendSigned sends a replication request signed with the peer key and decodes a successful response into out
+func (c *Client) sendSigned(ctx context.Context, method string, p string, payload any, out any, expected int) error {
+	if c.peerKey.Value() == "" {
+		return errors.New("replication requests need a peer key")
+	}
+
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	req, err := c.newRequest(ctx, method, p, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	now := time.Now()
+	req.Header.Set(registry.REPLICATION_PEER_HEADER, c.peer)
+	req.Header.Set(registry.REPLICATION_TIMESTAMP_HEADER, fmt.Sprint(now.Unix()))
+	req.Header.Set(registry.REPLICATION_SIGNATURE_HEADER,
+		registry.SignReplicationRequest(c.peerKey, method, req.URL.RequestURI(), c.peer, now, body))
+
+	return c.do(req, out, expected)
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Report outcomes of a single file
+const (
+	OutcomeUploaded = "uploaded"
+	OutcomePulled   = "pulled"
+	OutcomeExported = "exported"
+	OutcomeKept     = "kept"
+	OutcomeSkipped  = "skipped"
+	OutcomeFailed   = "failed"
+)
+
+// Report is a machine readable summary of a long running operation, CI jobs collect it as an artifact.
+// Client methods record one entry per file when the client has a report, see WithReport.
+type Report struct {
+	Operation  string        `json:"operation"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	DurationMs int64         `json:"duration_ms"`
+	Totals     ReportTotals  `json:"totals"`
+	Files      []ReportEntry `json:"files"`
+	Error      string        `json:"error,omitempty"`
+
+	mu sync.Mutex
+}
+
+// ReportTotals counts the files of a report by outcome, Bytes covers every file that did not fail
+// and TransferredBytes only those that were actually uploaded or downloaded.
+type ReportTotals struct {
+	Files            int            `json:"files"`
+	Bytes            int64          `json:"bytes"`
+	TransferredBytes int64          `json:"transferred_bytes"`
+	Outcomes         map[string]int `json:"outcomes"`
+}
+
+// ReportEntry is the outcome of a single file
+type ReportEntry struct {
+	Path       string `json:"path,omitempty"`
+	Checksum   string `json:"checksum,omitempty"`
+	Outcome    string `json:"outcome"`
+	Bytes      int64  `json:"bytes"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+func NewReport(operation string) *Report {
+	return &Report{
+		Operation: operation,
+		StartedAt: time.Now().UTC(),
+		Files:     []ReportEntry{},
+		Totals:    ReportTotals{Outcomes: map[string]int{}},
+	}
+}
+
+// Record adds a file outcome, started is when the work on the file began and err its failure if any.
+// Recording on a nil report does nothing.
+func (r *Report) Record(entry ReportEntry, started time.Time, err error) {
+	if r == nil {
+		return
+	}
+	if !started.IsZero() {
+		entry.DurationMs = time.Since(started).Milliseconds()
+	}
+	if err != nil {
+		entry.Outcome = OutcomeFailed
+		entry.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Files = append(r.Files, entry)
+	r.Totals.Files++
+	r.Totals.Outcomes[entry.Outcome]++
+	switch entry.Outcome {
+	case OutcomeFailed:
+	case OutcomeKept, OutcomeSkipped:
+		r.Totals.Bytes += entry.Bytes
+	default:
+		r.Totals.Bytes += entry.Bytes
+		r.Totals.TransferredBytes += entry.Bytes
+	}
+}
+
+// Write finishes the report with the operation error and writes it to path as JSON
+func (r *Report) Write(path string, opErr error) error {
+	r.mu.Lock()
+	r.FinishedAt = time.Now().UTC()
+	r.DurationMs = r.FinishedAt.Sub(r.StartedAt).Milliseconds()
+	if opErr != nil {
+		r.Error = opErr.Error()
+	}
+	raw, err := json.MarshalIndent(r, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0o644)
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+)
+
+const (
+	StagingApiPath       = "/staging"
+	StagingCommitApiPath = "/staging/%s/commit"
+)
+
+// CreateStagedUpload presigns an upload of content whose checksum is not known yet.
+func (c *Client) CreateStagedUpload(ctx context.Context) (*v1.StagedUploadResponse, error) {
+	slog.Debug("creating staged upload")
+
+	p := StagingApiPath
+	if c.presignTTL > 0 {
+		p += fmt.Sprintf("?expires_in=%d", uint(c.presignTTL.Seconds()))
+	}
+
+	var response v1.StagedUploadResponse
+	if err := c.sendJSON(ctx, http.MethodPost, p, nil, &response, http.StatusCreated); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// CommitStagedUpload registers a staged upload under the checksum and size computed while it streamed.
+func (c *Client) CommitStagedUpload(ctx context.Context, stagingID string, asset v1.AssetPayload) (*v1.FinalizeAssetResponse, error) {
+	slog.Debug("committing staged upload", "staging", stagingID, "checksum", asset.Checksum)
+
+	var response v1.FinalizeAssetResponse
+	request := v1.CommitStagedUploadRequest{AssetPayload: asset}
+	if err := c.sendJSON(ctx, http.MethodPost, fmt.Sprintf(StagingCommitApiPath, stagingID), request, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// StreamAsset uploads content read once from r, like stdin or generated data, hashing it on the fly.
+// The bytes go to a staging key and the asset is registered under the computed checksum once the stream ends.
+// size is sent as the upload length when it is not negative, S3 storage requires it.
+// The mime type of asset is sniffed from the first bytes when unset, its checksum and size are filled in.
+func (c *Client) StreamAsset(ctx context.Context, r io.Reader, size int64, asset v1.AssetPayload) (*v1.FinalizeAssetResponse, error) {
+	slog.Info("streaming asset", "display", asset.Display)
+
+	staged, err := c.CreateStagedUpload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReaderSize(r, 512)
+	if asset.MimeType == "" {
+		head, err := br.Peek(512)
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("detect mime type: %w", err)
+		}
+		asset.MimeType = http.DetectContentType(head)
+	}
+
+	h := sha256.New()
+	counter := &countingReader{r: io.TeeReader(br, h)}
+	uploadCtx, cancel := c.fileContext(ctx)
+	defer cancel()
+	if err := c.uploadStream(uploadCtx, staged.UploadURL, counter, size); err != nil {
+		return nil, fmt.Errorf("failed to upload stream: %w", err)
+	}
+
+	asset.Checksum = hex.EncodeToString(h.Sum(nil))
+	asset.SizeBytes = counter.n
+
+	response, err := c.CommitStagedUpload(ctx, staged.StagingID, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("streamed asset", "checksum", response.Checksum, "size", response.SizeBytes)
+	return response, nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+)
+
+const StatsApiPath = "/stats"
+
+// GetStats returns the aggregate asset counts and bytes of the registry.
+func (c *Client) GetStats(ctx context.Context) (*v1.StatsResponse, error) {
+	slog.Debug("getting stats")
+
+	var response v1.StatsResponse
+	if err := c.sendJSON(ctx, http.MethodGet, StatsApiPath, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+)
+
+const (
+	TagsApiPath      = "/tags"
+	TagAssetsApiPath = "/tags/%s/assets"
+	AssetTagApiPath  = "/assets/%s/tags/%s"
+)
+
+// CreateTag registers a new tag.
+func (c *Client) CreateTag(ctx context.Context, request v1.CreateTagRequest) (*v1.AddTagResponse, error) {
+	slog.Debug("creating tag", "name", request.Name)
+
+	var response v1.AddTagResponse
+	if err := c.sendJSON(ctx, http.MethodPost, TagsApiPath, request, &response, http.StatusCreated); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetTag looks up a tag by name.
+func (c *Client) GetTag(ctx context.Context, name string) (*v1.GetTagResponse, error) {
+	slog.Debug("getting tag", "name", name)
+
+	var response v1.GetTagResponse
+	if err := c.sendJSON(ctx, http.MethodGet, path.Join(TagsApiPath, name), nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// TagAsset attaches an existing tag to an asset.
+func (c *Client) TagAsset(ctx context.Context, checksum string, tag string) error {
+	slog.Debug("tagging asset", "checksum", checksum, "tag", tag)
+	return c.sendJSON(ctx, http.MethodPut, fmt.Sprintf(AssetTagApiPath, checksum, tag), nil, nil, http.StatusNoContent)
+}
+
+// UntagAsset detaches a tag from an asset.
+func (c *Client) UntagAsset(ctx context.Context, checksum string, tag string) error {
+	slog.Debug("untagging asset", "checksum", checksum, "tag", tag)
+	return c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf(AssetTagApiPath, checksum, tag), nil, nil, http.StatusNoContent)
+}
+
+// TagAssets attaches a tag to many assets in batches. Unknown checksums are reported in Missing.
+func (c *Client) TagAssets(ctx context.Context, tag string, checksums ...string) (*v1.TagAssetsResponse, error) {
+	slog.Debug("tagging assets", "tag", tag, "total", len(checksums))
+
+	result := &v1.TagAssetsResponse{Tag: tag}
+	for start := 0; start < len(checksums); start += BatchSize {
+		end := min(start+BatchSize, len(checksums))
+
+		var response v1.TagAssetsResponse
+		request := v1.TagAssetsRequest{Checksums: checksums[start:end]}
+		if err := c.sendJSON(ctx, http.MethodPost, fmt.Sprintf(TagAssetsApiPath, tag), request, &response, http.StatusOK); err != nil {
+			return nil, err
+		}
+
+		result.Response = response.Response
+		result.Requested += response.Requested
+		result.Changed += response.Changed
+		result.Missing = append(result.Missing, response.Missing...)
+		result.Detached = append(result.Detached, response.Detached...)
+	}
+
+	return result, nil
+}
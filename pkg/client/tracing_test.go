@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/universe"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+)
+
+// TestTracingSampledFlag checks the traceparent sent to the server carries the sampling decision of the client span
+func TestTracingSampledFlag(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	for _, tt := range []struct {
+		name    string
+		sampler sdktrace.Sampler
+		flags   string
+	}{
+		{name: "sampled", sampler: sdktrace.AlwaysSample(), flags: "-01"},
+		{name: "not sampled", sampler: sdktrace.NeverSample(), flags: "-00"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSampler(tt.sampler))
+			defer provider.Shutdown(context.Background())
+
+			c, err := New(WithBaseURL(server.URL), WithTracing(provider))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := c.GetServerInfo(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.HasPrefix(traceparent, "00-") || !strings.HasSuffix(traceparent, tt.flags) {
+				t.Errorf("traceparent %q, want flags %s", traceparent, tt.flags)
+			}
+		})
+	}
+}
+
+// stageTracer starts an otel span for every pipeline stage item
+type stageTracer struct {
+	tracer trace.Tracer
+}
+
+type stageSpan struct {
+	span trace.Span
+}
+
+func (t stageTracer) Start(ctx context.Context, name string) (context.Context, universe.Span) {
+	ctx, span := t.tracer.Start(ctx, "stage "+name)
+	return ctx, stageSpan{span: span}
+}
+
+func (s stageSpan) End(err error) {
+	s.span.End()
+}
+
+// TestUploadSpansUnderItemSpan checks the upload and finalize requests of a file start under the span of its pipeline item
+func TestUploadSpansUnderItemSpan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer provider.Shutdown(context.Background())
+
+	c, err := New(WithBaseURL(server.URL), WithTracing(provider), WithTracer(stageTracer{tracer: provider.Tracer("test")}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checksum := strings.Repeat("a", 64)
+	path := filepath.Join(t.TempDir(), "file.bin")
+	if err := os.WriteFile(path, []byte("content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	asset := &v1.BatchAssetDetails{Checksum: checksum, IngressUrl: registry.Secret(server.URL + "/ingress/" + checksum)}
+	if err := c.UploadAssets(context.Background(), map[string]string{checksum: path}, asset); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	var item trace.SpanContext
+	for _, span := range spans {
+		if strings.HasPrefix(span.Name(), "stage ") {
+			item = span.SpanContext()
+		}
+	}
+	if !item.IsValid() {
+		t.Fatalf("no item span among %d spans", len(spans))
+	}
+
+	requests := map[string]bool{}
+	for _, span := range spans {
+		if strings.HasPrefix(span.Name(), "HTTP ") {
+			requests[span.Name()] = true
+			if span.Parent().SpanID() != item.SpanID() {
+				t.Errorf("%s span parent is %s, want the item span %s", span.Name(), span.Parent().SpanID(), item.SpanID())
+			}
+		}
+	}
+	if !requests["HTTP PUT"] || !requests["HTTP POST"] {
+		t.Errorf("request spans %v, want the upload PUT and the finalize POST", requests)
+	}
+}
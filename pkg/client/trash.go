@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+)
+
+const (
+	TrashApiPath        = "/trash/assets"
+	TrashRestoreApiPath = "/trash/assets/restore"
+	TrashPurgeApiPath   = "/trash/assets/purge"
+)
+
+// ListTrash returns a page of deleted assets, the first one when pageToken is empty.
+func (c *Client) ListTrash(ctx context.Context, pageToken string, limit uint) (*v1.ListAssetsResponse, error) {
+	slog.Debug("listing trash", "limit", limit)
+
+	var response v1.ListAssetsResponse
+	request := v1.ListTrashRequest{PageToken: pageToken, Limit: limit}
+	if err := c.sendJSON(ctx, http.MethodGet, TrashApiPath, request, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListAllTrash pages through the whole trash and returns every deleted asset checksum.
+func (c *Client) ListAllTrash(ctx context.Context) ([]string, error) {
+	var checksums []string
+	var pageToken string
+
+	for {
+		page, err := c.ListTrash(ctx, pageToken, BatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, asset := range page.Assets {
+			checksums = append(checksums, asset.Checksum)
+		}
+
+		if page.NextPageToken == "" {
+			return checksums, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// RestoreTrash moves deleted assets out of the trash.
+func (c *Client) RestoreTrash(ctx context.Context, checksums ...string) (*v1.TrashAssetsResponse, error) {
+	return c.trashAction(ctx, TrashRestoreApiPath, checksums...)
+}
+
+// PurgeTrash permanently removes deleted assets and their stored objects.
+func (c *Client) PurgeTrash(ctx context.Context, checksums ...string) (*v1.TrashAssetsResponse, error) {
+	return c.trashAction(ctx, TrashPurgeApiPath, checksums...)
+}
+
+// PlanPurgeTrash lists the deleted assets PurgeTrash would remove and the bytes it would free, nothing is purged.
+func (c *Client) PlanPurgeTrash(ctx context.Context, checksums ...string) (*v1.DryRunResponse, error) {
+	slog.Debug("planning trash purge", "total", len(checksums))
+	merged := &v1.DryRunResponse{}
+
+	for start := 0; start < len(checksums); start += BatchSize {
+		end := min(start+BatchSize, len(checksums))
+
+		var response v1.DryRunResponse
+		request := v1.PurgeTrashRequest{TrashAssetsRequest: v1.TrashAssetsRequest{Checksums: checksums[start:end]}, DryRun: true}
+		if err := c.sendJSON(ctx, http.MethodPost, TrashPurgeApiPath, request, &response, http.StatusOK); err != nil {
+			return merged, err
+		}
+
+		merged.Response = response.Response
+		merged.Operation = response.Operation
+		merged.DryRun = response.DryRun
+		merged.Candidates = append(merged.Candidates, response.Candidates...)
+		merged.Total += response.Total
+		merged.Bytes += response.Bytes
+	}
+
+	return merged, nil
+}
+
+// trashAction posts checksums to a trash endpoint in batches and merges the results.
+func (c *Client) trashAction(ctx context.Context, path string, checksums ...string) (*v1.TrashAssetsResponse, error) {
+	slog.Debug("executing trash action", "path", path, "total", len(checksums))
+	merged := &v1.TrashAssetsResponse{}
+
+	for start := 0; start < len(checksums); start += BatchSize {
+		end := min(start+BatchSize, len(checksums))
+
+		var response v1.TrashAssetsResponse
+		request := v1.TrashAssetsRequest{Checksums: checksums[start:end]}
+		if err := c.sendJSON(ctx, http.MethodPost, path, request, &response, http.StatusOK); err != nil {
+			return merged, err
+		}
+
+		merged.Response = response.Response
+		merged.Assets = append(merged.Assets, response.Assets...)
+		merged.Total += response.Total
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	WorkspaceDir   = ".aether"
+	WorkspaceState = "workspace"
+
+	pullTempPrefix = ".aether-pull-"
+)
+
+// Workspace records which dataset version a local directory was materialized from,
+// it is the baseline local drift is measured against.
+type Workspace struct {
+	Dataset  string                    `json:"dataset"`
+	Version  int                       `json:"version"`
+	Digest   string                    `json:"digest"`
+	Partial  bool                      `json:"partial,omitempty"`
+	PulledAt time.Time                 `json:"pulled_at"`
+	Files    map[string]*WorkspaceFile `json:"files"`
+}
+
+// WorkspaceFile is a materialized asset, keyed by its slash separated path in the workspace
+type WorkspaceFile struct {
+	Checksum  string    `json:"checksum"`
+	SizeBytes int64     `json:"size_bytes"`
+	ModTime   time.Time `json:"mod_time"`
+}
+
+// WorkspaceDrift lists the local changes of a workspace against its dataset version
+type WorkspaceDrift struct {
+	Modified  []string
+	Missing   []string
+	Untracked []string
+}
+
+func (d *WorkspaceDrift) Clean() bool {
+	return len(d.Modified) == 0 && len(d.Missing) == 0 && len(d.Untracked) == 0
+}
+
+func workspacePath(root string) string {
+	return filepath.Join(root, WorkspaceDir, WorkspaceState)
+}
+
+// LoadWorkspace reads the workspace state of root
+func LoadWorkspace(root string) (*Workspace, error) {
+	raw, err := os.ReadFile(workspacePath(root))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s is not a dataset workspace, pull a dataset version into it first", root)
+		}
+		return nil, err
+	}
+
+	var ws Workspace
+	if err := json.Unmarshal(raw, &ws); err != nil {
+		return nil, fmt.Errorf("decode workspace state: %w", err)
+	}
+	return &ws, nil
+}
+
+// Save writes the workspace state under root, replacing it atomically
+func (w *Workspace) Save(root string) error {
+	target := workspacePath(root)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	raw, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode workspace state: %w", err)
+	}
+
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, target)
+}
+
+// Track records a materialized file, its modification time is the baseline for drift
+func (w *Workspace) Track(root string, name string, checksum string) error {
+	info, err := os.Stat(filepath.Join(root, name))
+	if err != nil {
+		return err
+	}
+
+	w.Files[filepath.ToSlash(name)] = &WorkspaceFile{
+		Checksum:  checksum,
+		SizeBytes: info.Size(),
+		ModTime:   info.ModTime(),
+	}
+	return nil
+}
+
+// Drift compares root against the workspace state.
+// Files whose size and modification time are unchanged are trusted, the others are hashed.
+func (w *Workspace) Drift(root string) (*WorkspaceDrift, error) {
+	drift := &WorkspaceDrift{}
+
+	for name, file := range w.Files {
+		info, err := os.Stat(filepath.Join(root, filepath.FromSlash(name)))
+		switch {
+		case os.IsNotExist(err):
+			drift.Missing = append(drift.Missing, name)
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		if info.Size() != file.SizeBytes {
+			drift.Modified = append(drift.Modified, name)
+			continue
+		}
+		if info.ModTime().Equal(file.ModTime) {
+			continue
+		}
+
+		sum, err := checksum(filepath.Join(root, filepath.FromSlash(name)))
+		if err != nil {
+			return nil, err
+		}
+		if sum != file.Checksum {
+			drift.Modified = append(drift.Modified, name)
+		}
+	}
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if rel == WorkspaceDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), pullTempPrefix) {
+			return nil
+		}
+
+		if _, ok := w.Files[filepath.ToSlash(rel)]; !ok {
+			drift.Untracked = append(drift.Untracked, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(drift.Modified)
+	slices.Sort(drift.Missing)
+	slices.Sort(drift.Untracked)
+	return drift, nil
+}
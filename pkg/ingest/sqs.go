@@ -0,0 +1,195 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+const (
+	// DEFAULT_INTERVAL is how often the queue is drained while serving
+	DEFAULT_INTERVAL = 30 * time.Second
+	// DEFAULT_BATCH is the most messages SQS hands out per receive
+	DEFAULT_BATCH = 10
+	// DEFAULT_WAIT long polls briefly, so a drained queue ends the run without missing in-flight messages
+	DEFAULT_WAIT = 2
+)
+
+// Handler ingests the object notifications of a message, an error leaves the message on the queue
+type Handler func(ctx context.Context, notifications []registry.ObjectNotification) error
+
+// SQSConsumer drains the bucket notifications S3 sends to an SQS queue.
+// Messages are deleted once handled, a failed one becomes visible again after the queue visibility timeout.
+type SQSConsumer struct {
+	queue  string
+	region string
+	creds  aws.CredentialsProvider
+	signer *v4.Signer
+	http   *http.Client
+	handle Handler
+}
+
+type sqsMessage struct {
+	MessageId     string
+	ReceiptHandle string
+	Body          string
+}
+
+// NewSQSConsumer creates a consumer of queueURL with the default AWS credentials,
+// the region comes from the AWS configuration or the queue url
+func NewSQSConsumer(ctx context.Context, queueURL string, handle Handler) (*SQSConsumer, error) {
+	u, err := url.Parse(queueURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid sqs queue url %q", queueURL)
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("aws config: %w", err)
+	}
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("sqs queue %q: no aws credentials configured", queueURL)
+	}
+
+	region := cfg.Region
+	if host := strings.Split(u.Hostname(), "."); region == "" && len(host) > 2 && host[0] == "sqs" {
+		region = host[1]
+	}
+	if region == "" {
+		return nil, fmt.Errorf("sqs queue %q: no aws region configured", queueURL)
+	}
+
+	return &SQSConsumer{
+		queue:  queueURL,
+		region: region,
+		creds:  cfg.Credentials,
+		signer: v4.NewSigner(),
+		http:   &http.Client{Timeout: 30 * time.Second},
+		handle: handle,
+	}, nil
+}
+
+// Job wraps the consumer as a scheduled job
+func (c *SQSConsumer) Job(interval time.Duration) registry.Job {
+	return registry.Job{
+		Name:     "storage-events",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := c.Run(ctx)
+			return err
+		},
+	}
+}
+
+// Run handles messages until the queue is drained and returns how many were handled
+func (c *SQSConsumer) Run(ctx context.Context) (int, error) {
+	handled := 0
+	for {
+		messages, err := c.receive(ctx)
+		if err != nil {
+			return handled, err
+		}
+		if len(messages) == 0 {
+			if handled > 0 {
+				slog.Info("Ingested storage events", "queue", c.queue, "messages", handled)
+			}
+			return handled, nil
+		}
+
+		for _, m := range messages {
+			notifications, err := registry.ParseObjectNotifications([]byte(m.Body))
+			if err != nil {
+				// an unreadable message would come back forever
+				slog.Warn("Dropping unreadable storage event", "message", m.MessageId, "error", err)
+			} else if err := c.handle(ctx, notifications); err != nil {
+				slog.Error("Failed to ingest storage event, leaving it for redelivery", "message", m.MessageId, "error", err)
+				continue
+			}
+
+			if err := c.delete(ctx, m.ReceiptHandle); err != nil {
+				return handled, err
+			}
+			handled++
+		}
+	}
+}
+
+func (c *SQSConsumer) receive(ctx context.Context) ([]sqsMessage, error) {
+	var out struct {
+		Messages []sqsMessage
+	}
+	err := c.call(ctx, "ReceiveMessage", map[string]any{
+		"QueueUrl":            c.queue,
+		"MaxNumberOfMessages": DEFAULT_BATCH,
+		"WaitTimeSeconds":     DEFAULT_WAIT,
+	}, &out)
+	return out.Messages, err
+}
+
+func (c *SQSConsumer) delete(ctx context.Context, receipt string) error {
+	return c.call(ctx, "DeleteMessage", map[string]any{
+		"QueueUrl":      c.queue,
+		"ReceiptHandle": receipt,
+	}, nil)
+}
+
+// call sends a signed request of the SQS JSON protocol to the queue endpoint
+func (c *SQSConsumer) call(ctx context.Context, action string, payload any, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.queue, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", "AmazonSQS."+action)
+
+	creds, err := c.creds.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("sqs %s: retrieve credentials: %w", action, err)
+	}
+	hash := sha256.Sum256(body)
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), "sqs", c.region, time.Now()); err != nil {
+		return fmt.Errorf("sqs %s: sign request: %w", action, err)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("sqs %s: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("sqs %s: unexpected status %d: %s %s", action, resp.StatusCode, apiErr.Type, apiErr.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("sqs %s: decode response: %w", action, err)
+	}
+	return nil
+}
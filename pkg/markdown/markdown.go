@@ -0,0 +1,244 @@
+// Package markdown renders the markdown subset dataset readmes use into HTML.
+// Raw HTML is always escaped and links only keep http, https, mailto and relative targets,
+// so the output is safe to embed in a page.
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+)
+
+// Render converts markdown to HTML: headings, paragraphs, fenced code, block quotes,
+// flat lists, rules, and inline code, emphasis and links
+func Render(src string) string {
+	var b strings.Builder
+	renderBlocks(&b, strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n"))
+	return b.String()
+}
+
+func renderBlocks(b *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := strings.TrimSpace(lines[i])
+
+		switch {
+		case line == "":
+			i++
+
+		case strings.HasPrefix(line, "```"):
+			lang := strings.TrimSpace(strings.TrimPrefix(line, "```"))
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			i++
+
+			if lang != "" {
+				fmt.Fprintf(b, "<pre><code class=\"language-%s\">", html.EscapeString(lang))
+			} else {
+				b.WriteString("<pre><code>")
+			}
+			b.WriteString(html.EscapeString(strings.Join(code, "\n")))
+			b.WriteString("</code></pre>\n")
+
+		case headingLevel(line) > 0:
+			level := headingLevel(line)
+			text := strings.TrimRight(strings.TrimSpace(line[level:]), "#")
+			fmt.Fprintf(b, "<h%d>%s</h%d>\n", level, renderInline(strings.TrimSpace(text)), level)
+			i++
+
+		case isRule(line):
+			b.WriteString("<hr>\n")
+			i++
+
+		case strings.HasPrefix(line, ">"):
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				q := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quote = append(quote, strings.TrimPrefix(q, " "))
+			}
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, quote)
+			b.WriteString("</blockquote>\n")
+
+		case listMarker(line) != "":
+			tag := listMarker(line)
+
+			var items []string
+			for ; i < len(lines); i++ {
+				l := strings.TrimSpace(lines[i])
+				if l == "" || (listMarker(l) != "" && listMarker(l) != tag) {
+					break
+				}
+				if listMarker(l) == "" {
+					// continuation of the previous item
+					items[len(items)-1] += " " + l
+					continue
+				}
+				items = append(items, listText(l))
+			}
+
+			fmt.Fprintf(b, "<%s>\n", tag)
+			for _, item := range items {
+				fmt.Fprintf(b, "<li>%s</li>\n", renderInline(item))
+			}
+			fmt.Fprintf(b, "</%s>\n", tag)
+
+		default:
+			var para []string
+			for ; i < len(lines); i++ {
+				l := strings.TrimSpace(lines[i])
+				if l == "" || (len(para) > 0 && startsBlock(l)) {
+					break
+				}
+				para = append(para, l)
+			}
+			fmt.Fprintf(b, "<p>%s</p>\n", renderInline(strings.Join(para, "\n")))
+		}
+	}
+}
+
+// startsBlock reports whether a line interrupts a paragraph
+func startsBlock(line string) bool {
+	return strings.HasPrefix(line, "```") || strings.HasPrefix(line, ">") ||
+		headingLevel(line) > 0 || isRule(line) || listMarker(line) != ""
+}
+
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ') {
+		return 0
+	}
+	return level
+}
+
+func isRule(line string) bool {
+	compact := strings.ReplaceAll(line, " ", "")
+	if len(compact) < 3 {
+		return false
+	}
+	for _, marker := range []string{"-", "*", "_"} {
+		if strings.Trim(compact, marker) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// listMarker returns ul or ol when the line starts a list item
+func listMarker(line string) string {
+	if len(line) > 1 && strings.ContainsRune("-*+", rune(line[0])) && line[1] == ' ' {
+		return "ul"
+	}
+
+	digits := 0
+	for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits < 10 && strings.HasPrefix(line[digits:], ". ") {
+		return "ol"
+	}
+	return ""
+}
+
+func listText(line string) string {
+	if listMarker(line) == "ul" {
+		return strings.TrimSpace(line[2:])
+	}
+	return strings.TrimSpace(line[strings.Index(line, ". ")+2:])
+}
+
+// renderInline escapes text and renders code spans, strong and emphasized text and links
+func renderInline(s string) string {
+	var b strings.Builder
+	var prev byte
+
+	for len(s) > 0 {
+		i := strings.IndexAny(s, "`*_[")
+		if i < 0 {
+			b.WriteString(html.EscapeString(s))
+			break
+		}
+		if i > 0 {
+			b.WriteString(html.EscapeString(s[:i]))
+			prev, s = s[i-1], s[i:]
+		}
+
+		if n, ok := renderSpan(&b, s, prev); ok {
+			prev, s = s[n-1], s[n:]
+			continue
+		}
+		b.WriteString(html.EscapeString(s[:1]))
+		prev, s = s[0], s[1:]
+	}
+	return b.String()
+}
+
+// renderSpan renders the inline element s starts with and returns how many bytes it used
+func renderSpan(b *strings.Builder, s string, prev byte) (int, bool) {
+	switch s[0] {
+	case '`':
+		if end := strings.IndexByte(s[1:], '`'); end > 0 {
+			fmt.Fprintf(b, "<code>%s</code>", html.EscapeString(s[1:1+end]))
+			return end + 2, true
+		}
+
+	case '*', '_':
+		// snake_case words are not emphasis
+		if s[0] == '_' && isWordByte(prev) {
+			return 0, false
+		}
+
+		delim, tag := s[:1], "em"
+		if len(s) > 1 && s[1] == s[0] {
+			delim, tag = s[:2], "strong"
+		}
+		rest := s[len(delim):]
+		end := strings.Index(rest, delim)
+		if end <= 0 || rest[0] == ' ' || rest[end-1] == ' ' {
+			return 0, false
+		}
+		fmt.Fprintf(b, "<%s>%s</%s>", tag, renderInline(rest[:end]), tag)
+		return len(delim)*2 + end, true
+
+	case '[':
+		mid := strings.Index(s, "](")
+		if mid < 0 {
+			return 0, false
+		}
+		end := strings.IndexByte(s[mid+2:], ')')
+		if end < 0 {
+			return 0, false
+		}
+
+		text, href := s[1:mid], strings.TrimSpace(s[mid+2:mid+2+end])
+		if safeURL(href) {
+			fmt.Fprintf(b, "<a href=\"%s\">%s</a>", html.EscapeString(href), renderInline(text))
+		} else {
+			b.WriteString(renderInline(text))
+		}
+		return mid + 3 + end, true
+	}
+	return 0, false
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// safeURL keeps links to web pages, mail addresses and relative targets
+func safeURL(href string) bool {
+	u, err := url.Parse(href)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return true
+	}
+	return false
+}
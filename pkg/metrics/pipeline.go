@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/UnivocalX/aether/pkg/universe"
+)
+
+// PipelineTracer is a universe.Tracer counting the items every pipeline stage handles and how long they take
+type PipelineTracer struct {
+	items    *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewPipelineTracer registers the pipeline stage metrics with reg
+func NewPipelineTracer(reg prometheus.Registerer) (*PipelineTracer, error) {
+	items, err := Register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "aether_pipeline_items_total",
+		Help: "Items handled by pipeline stages, by stage and result.",
+	}, []string{"stage", "result"}))
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := Register(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "aether_pipeline_stage_duration_seconds",
+		Help: "Time pipeline stages spend on one item.",
+	}, []string{"stage"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PipelineTracer{items: items, duration: duration}, nil
+}
+
+type pipelineSpan struct {
+	tracer *PipelineTracer
+	stage  string
+	start  time.Time
+}
+
+func (t *PipelineTracer) Start(ctx context.Context, name string) (context.Context, universe.Span) {
+	return ctx, &pipelineSpan{tracer: t, stage: name, start: time.Now()}
+}
+
+func (s *pipelineSpan) End(err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	s.tracer.items.WithLabelValues(s.stage, result).Inc()
+	s.tracer.duration.WithLabelValues(s.stage).Observe(time.Since(s.start).Seconds())
+}
@@ -0,0 +1,163 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/client"
+)
+
+const DEFAULT_BATCH = 500
+
+// Puller pulls the asset change feed of a peer into the local registry.
+// Its watermark only moves past changes that were fully applied, so a failed change is retried next run.
+type Puller struct {
+	engine *registry.Engine
+	client *client.Client
+	peer   *registry.Peer
+	batch  uint
+}
+
+// NewPuller creates a puller of peer, signing requests as name with the replication key
+func NewPuller(engine *registry.Engine, peer *registry.Peer, name string, key registry.Secret) (*Puller, error) {
+	c, err := client.New(
+		client.WithBaseURL(peer.URL),
+		client.WithPeerSigning(name, key),
+		client.WithUserAgent("aether-replication"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("replication client of peer %q: %w", peer.Name, err)
+	}
+
+	return &Puller{engine: engine, client: c, peer: peer, batch: DEFAULT_BATCH}, nil
+}
+
+// Job wraps the puller as a scheduled job
+func (p *Puller) Job(interval time.Duration) registry.Job {
+	return registry.Job{
+		Name:     "replicate-" + p.peer.Name,
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := p.Run(ctx)
+			return err
+		},
+	}
+}
+
+// Run applies the peer changes after the stored watermark and returns how many were applied
+func (p *Puller) Run(ctx context.Context) (int, error) {
+	lock, err := p.engine.TryLock(ctx, "aether:replicate:"+p.peer.Name)
+	if err != nil || lock == nil {
+		return 0, err
+	}
+	defer lock.Release()
+
+	// another replica may have pulled since, the database holds the current watermark
+	peer, err := p.engine.GetPeerRecord(p.peer.Name)
+	if err != nil {
+		return 0, err
+	}
+	p.peer = peer
+
+	marks, err := p.client.GetReplicationWatermark(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get peer %q watermark: %w", peer.Name, err)
+	}
+	if marks.Watermark == peer.Watermark {
+		if marks.Acked != peer.Watermark {
+			p.ack(ctx, peer.Watermark)
+		}
+		return 0, nil
+	}
+
+	applied, since := 0, peer.Watermark
+	for {
+		page, err := p.client.ListReplicationChanges(ctx, since, p.batch)
+		if err != nil {
+			return applied, p.save(ctx, since, fmt.Errorf("list peer %q changes: %w", peer.Name, err))
+		}
+
+		for _, change := range page.Changes {
+			if err := p.apply(ctx, change); err != nil {
+				return applied, p.save(ctx, since, err)
+			}
+			since = change.Watermark
+			applied++
+		}
+
+		if page.NextSince == nil {
+			break
+		}
+	}
+
+	if err := p.save(ctx, since, nil); err != nil {
+		return applied, err
+	}
+
+	if applied > 0 {
+		slog.Info("Replicated peer changes", "peer", peer.Name, "changes", applied)
+	}
+	return applied, nil
+}
+
+// apply stores a change and copies the object of assets still waiting for it
+func (p *Puller) apply(ctx context.Context, change *registry.ReplicationChange) error {
+	result, err := p.engine.ApplyReplicationChange(ctx, p.peer, change)
+	if err != nil {
+		return err
+	}
+	slog.Debug("Applied replication change", "peer", p.peer.Name, "project", change.Project, "checksum", change.Checksum, "action", result.Action)
+
+	if !result.NeedsObject {
+		return nil
+	}
+	return p.copyObject(ctx, result.Asset)
+}
+
+// copyObject uploads the peer object through a local ingress url and promotes it like a client upload
+func (p *Puller) copyObject(ctx context.Context, asset *registry.Asset) error {
+	src, err := p.client.GetReplicationObjectURL(ctx, asset.Project, asset.Checksum)
+	if err != nil {
+		return fmt.Errorf("get peer %q object %q: %w", p.peer.Name, asset.Checksum, err)
+	}
+
+	engine := p.engine.ForProject(ctx, asset.Project)
+	dst, err := engine.IngressUrlSize(ctx, asset.Checksum, 0, asset.SizeBytes)
+	if err != nil {
+		return err
+	}
+
+	if err := p.client.CopyObject(ctx, src.DownloadURL, dst.URL, asset.SizeBytes); err != nil {
+		return fmt.Errorf("copy peer %q object %q: %w", p.peer.Name, asset.Checksum, err)
+	}
+
+	if _, err := engine.PromoteAsset(ctx, asset.Checksum); err != nil {
+		return err
+	}
+	return nil
+}
+
+// save stores the watermark and acknowledges it to the peer, cause is returned when set
+func (p *Puller) save(ctx context.Context, watermark string, cause error) error {
+	if watermark != p.peer.Watermark {
+		if err := p.engine.SavePeerWatermark(p.peer, watermark); err != nil {
+			slog.Error("Failed to save peer watermark", "peer", p.peer.Name, "error", err)
+			if cause == nil {
+				cause = err
+			}
+			return cause
+		}
+		p.ack(ctx, watermark)
+	}
+	return cause
+}
+
+// ack reports the applied watermark to the peer, the peer only uses it to show the replication lag
+func (p *Puller) ack(ctx context.Context, watermark string) {
+	if err := p.client.AckReplicationWatermark(ctx, watermark); err != nil {
+		slog.Warn("Failed to ack peer watermark", "peer", p.peer.Name, "error", err)
+	}
+}
@@ -0,0 +1,17 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Propagator carries the W3C trace context between services in the traceparent and tracestate headers
+var Propagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+// Inject writes the span context of ctx to header, nothing is written without a span.
+// The sampled flag follows the sampling decision of the span, so the server records the trace only when the caller does.
+func Inject(ctx context.Context, header http.Header) {
+	Propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
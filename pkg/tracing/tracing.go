@@ -0,0 +1,59 @@
+// Package tracing sets up the OpenTelemetry SDK exporting spans to a collector over OTLP/HTTP,
+// and holds the helpers the traced packages share.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// INSTRUMENTATION_NAME names the tracer of the spans aether starts itself
+const INSTRUMENTATION_NAME = "github.com/UnivocalX/aether"
+
+// Tracer returns the aether tracer of provider, a nil provider starts no spans so tracing can be left unconfigured
+func Tracer(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		return noop.NewTracerProvider().Tracer(INSTRUMENTATION_NAME)
+	}
+	return provider.Tracer(INSTRUMENTATION_NAME)
+}
+
+// ChildrenOnly wraps provider so spans only start within a traced context, e.g. a request.
+// Work without a parent span, like the statements of maintenance jobs, is left untraced.
+func ChildrenOnly(provider trace.TracerProvider) trace.TracerProvider {
+	if provider == nil {
+		return noop.NewTracerProvider()
+	}
+	return childrenOnlyProvider{provider}
+}
+
+type childrenOnlyProvider struct {
+	trace.TracerProvider
+}
+
+func (p childrenOnlyProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return childrenOnlyTracer{p.TracerProvider.Tracer(name, opts...)}
+}
+
+type childrenOnlyTracer struct {
+	trace.Tracer
+}
+
+func (t childrenOnlyTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return t.Tracer.Start(ctx, name, opts...)
+}
+
+// RecordError marks span failed with err, nil errors are ignored
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -1,5 +1,7 @@
 package universe
 
+import "context"
+
 func TransformValue[T, U any](fn func(value T) (U, error)) Transformer[T, U] {
 	return func(meta *Meta, env Envelope[T]) Envelope[U] {
 		v, e := fn(env.Value)
@@ -10,6 +12,18 @@ func TransformValue[T, U any](fn func(value T) (U, error)) Transformer[T, U] {
 	}
 }
 
+// TransformContext is like TransformValue but hands the item context to fn,
+// so calls made by the stage (e.g. http requests) join the item trace.
+func TransformContext[T, U any](fn func(ctx context.Context, value T) (U, error)) Transformer[T, U] {
+	return func(meta *Meta, env Envelope[T]) Envelope[U] {
+		v, e := fn(env.Context(), env.Value)
+		return Envelope[U]{
+			Value: v,
+			Err:   e,
+		}
+	}
+}
+
 func FilterValue[T any](fn func(value T) bool) Predicate[T] {
 	return func(meta *Meta, env Envelope[T]) bool {
 		return fn(env.Value)
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // Pipeline represents a composable, lazily-evaluated stream of envelopes.
@@ -22,6 +23,38 @@ type Stream[T any] struct {
 type Envelope[T any] struct {
 	Value T
 	Err   error
+
+	// FirstSeen is when the item entered the pipeline
+	FirstSeen time.Time
+	// Attempts counts how many times the latest stage ran for the item
+	Attempts int
+	// Origin tells where the item came from and which stage produced it
+	Origin Origin
+
+	// ctx carries per-item trace context between stages
+	ctx context.Context
+}
+
+// Context returns the item context, stage functions use it to link their work to the item span.
+func (e Envelope[T]) Context() context.Context {
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
+}
+
+// Elapsed returns how long the item has been in the pipeline.
+func (e Envelope[T]) Elapsed() time.Duration {
+	if e.FirstSeen.IsZero() {
+		return 0
+	}
+	return time.Since(e.FirstSeen)
+}
+
+// Origin describes the source position of an item and the last stage that handled it.
+type Origin struct {
+	Index int
+	Stage string
 }
  
 // Meta holds attributes and tracks errors during pipeline execution.
@@ -29,6 +62,7 @@ type Meta struct {
 	OriginTotalItems int
 	Attributes       sync.Map
 	errorOccurred    atomic.Bool
+	tracer           Tracer
 }
 
 func (m *Meta) MarkErrorOccurred(statement bool) {
@@ -61,11 +95,19 @@ func From[T any](ctx context.Context, values ...T) *Pipeline[T] {
 
 			go func() {
 				defer close(out)
-				for _, v := range values {
+				for i, v := range values {
+					env := Envelope[T]{
+						Value:     v,
+						FirstSeen: time.Now(),
+						Attempts:  1,
+						Origin:    Origin{Index: i, Stage: "source"},
+						ctx:       ctx,
+					}
+
 					select {
 					case <-ctx.Done():
 						return
-					case out <- Envelope[T]{Value: v}:
+					case out <- env:
 					}
 				}
 			}()
@@ -82,12 +124,14 @@ func Map[T, U any](p *Pipeline[T], fn Transformer[T, U], workers int) *Pipeline[
 			inStream := p.generator(ctx)
 			meta := inStream.Meta
 
+			stage := func(env Envelope[T]) Envelope[U] { return fn(meta, env) }
+
 			worker := func(ctx context.Context, in <-chan Envelope[T]) <-chan Envelope[U] {
 				out := make(chan Envelope[U])
 				go func() {
 					defer close(out)
 					for env := range in {
-						oe := fn(meta, env)
+						oe := traced(meta, "map", env, stage)
 						select {
 						case <-ctx.Done():
 							return
@@ -112,12 +156,14 @@ func (p *Pipeline[T]) Transform(fn Transformer[T, T], workers int) *Pipeline[T]
 			inStream := p.generator(ctx)
 			meta := inStream.Meta
 
+			stage := func(env Envelope[T]) Envelope[T] { return fn(meta, env) }
+
 			worker := func(ctx context.Context, in <-chan Envelope[T]) <-chan Envelope[T] {
 				out := make(chan Envelope[T])
 				go func() {
 					defer close(out)
 					for env := range in {
-						oe := fn(meta, env)
+						oe := traced(meta, "transform", env, stage)
 						select {
 						case <-ctx.Done():
 							return
@@ -173,12 +219,17 @@ func (p *Pipeline[T]) Tap(fn Observer[T], workers int) *Pipeline[T] {
 			inStream := p.generator(ctx)
 			meta := inStream.Meta
 
+			stage := func(env Envelope[T]) Envelope[T] {
+				fn(meta, env)
+				return env
+			}
+
 			worker := func(ctx context.Context, in <-chan Envelope[T]) <-chan Envelope[T] {
 				out := make(chan Envelope[T])
 				go func() {
 					defer close(out)
 					for env := range in {
-						fn(meta, env)
+						env = traced(meta, "tap", env, stage)
 						select {
 						case <-ctx.Done():
 							return
@@ -0,0 +1,41 @@
+package universe
+
+import (
+	"time"
+)
+
+// Retry wraps a transformer and runs it again while it fails, up to attempts times.
+// retryable decides which errors are worth another attempt, nil retries every error.
+// The returned envelope records how many attempts were made.
+func Retry[T, U any](fn Transformer[T, U], attempts int, backoff time.Duration, retryable func(error) bool) Transformer[T, U] {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	return func(meta *Meta, env Envelope[T]) Envelope[U] {
+		var oe Envelope[U]
+
+		for attempt := 1; attempt <= attempts; attempt++ {
+			oe = fn(meta, env)
+			oe.Attempts = attempt
+
+			if oe.Err == nil || attempt == attempts {
+				break
+			}
+
+			if retryable != nil && !retryable(oe.Err) {
+				break
+			}
+
+			timer := time.NewTimer(backoff * time.Duration(attempt))
+			select {
+			case <-env.Context().Done():
+				timer.Stop()
+				return oe
+			case <-timer.C:
+			}
+		}
+
+		return oe
+	}
+}
@@ -0,0 +1,91 @@
+package universe
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Span is a unit of work started for a single envelope by a pipeline stage.
+type Span interface {
+	End(err error)
+}
+
+// Tracer starts spans, adapt it to any tracing backend.
+// The returned context carries the span and is handed to the stage function.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// WithTracer enables per-item spans for every stage of the pipeline.
+func (p *Pipeline[T]) WithTracer(tracer Tracer) *Pipeline[T] {
+	return &Pipeline[T]{
+		generator: func(ctx context.Context) Stream[T] {
+			stream := p.generator(ctx)
+			stream.Meta.tracer = tracer
+			return stream
+		},
+	}
+}
+
+// traced runs a stage function for one envelope, inside a span when tracing is enabled.
+// The stage sees the span context, the output keeps the item context and metadata.
+func traced[T, U any](meta *Meta, stage string, env Envelope[T], fn func(Envelope[T]) Envelope[U]) Envelope[U] {
+	parent := env.ctx
+
+	var oe Envelope[U]
+	if meta.tracer == nil {
+		oe = fn(env)
+	} else {
+		ctx, span := meta.tracer.Start(env.Context(), stage)
+		env.ctx = ctx
+		oe = fn(env)
+		span.End(oe.Err)
+	}
+
+	oe.ctx = parent
+	oe.FirstSeen = env.FirstSeen
+	oe.Origin = Origin{Index: env.Origin.Index, Stage: stage}
+	if oe.Attempts == 0 {
+		oe.Attempts = 1
+	}
+	return oe
+}
+
+// SetAttribute stores a typed value in the pipeline metadata.
+func SetAttribute[V any](m *Meta, key string, value V) {
+	m.Attributes.Store(key, value)
+}
+
+// GetAttribute loads a typed value from the pipeline metadata.
+// It reports false when the key is missing or holds another type.
+func GetAttribute[V any](m *Meta, key string) (V, bool) {
+	var zero V
+
+	raw, ok := m.Attributes.Load(key)
+	if !ok {
+		return zero, false
+	}
+
+	value, ok := raw.(V)
+	if !ok {
+		return zero, false
+	}
+	return value, true
+}
+
+// LogTracer is a Tracer that logs every span with its duration at debug level.
+type LogTracer struct{}
+
+type logSpan struct {
+	name  string
+	start time.Time
+}
+
+func (LogTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, &logSpan{name: name, start: time.Now()}
+}
+
+func (s *logSpan) End(err error) {
+	slog.Debug("pipeline span", "stage", s.name, "duration", time.Since(s.start), "error", err)
+}
@@ -0,0 +1,69 @@
+// Package apierrors is the catalog of machine readable error codes the api returns in the code field of
+// error responses and batch item errors. Codes are stable, clients branch on them instead of on messages.
+package apierrors
+
+// Code is a stable machine readable error reason
+type Code string
+
+// Request errors
+const (
+	Validation      Code = "VALIDATION_FAILED"
+	DuplicateItem   Code = "DUPLICATE_ITEM"
+	PayloadTooLarge Code = "PAYLOAD_TOO_LARGE"
+	Unauthenticated Code = "UNAUTHENTICATED"
+	AccessDenied    Code = "ACCESS_DENIED"
+	RateLimited     Code = "RATE_LIMITED"
+	NotFound        Code = "NOT_FOUND"
+	AlreadyExists   Code = "ALREADY_EXISTS"
+	Conflict        Code = "CONFLICT"
+	ReadOnly        Code = "READ_ONLY"
+	Internal        Code = "INTERNAL"
+)
+
+// Idempotency errors
+const (
+	IdempotencyKeyReused   Code = "IDEMPOTENCY_KEY_REUSED"
+	IdempotencyKeyInFlight Code = "IDEMPOTENCY_KEY_IN_FLIGHT"
+)
+
+// Asset errors
+const (
+	AssetNotFound    Code = "ASSET_NOT_FOUND"
+	AssetExists      Code = "ASSET_EXISTS"
+	AssetDeleted     Code = "ASSET_DELETED"
+	AssetNotReady    Code = "ASSET_NOT_READY"
+	AssetReady       Code = "ASSET_READY"
+	AssetReferenced  Code = "ASSET_REFERENCED"
+	ContentRejected  Code = "CONTENT_REJECTED"
+	ObjectTooLarge   Code = "OBJECT_TOO_LARGE"
+	ChecksumMismatch Code = "CHECKSUM_MISMATCH"
+	SizeMismatch     Code = "SIZE_MISMATCH"
+	PresignFailed    Code = "PRESIGN_FAILED"
+)
+
+// Tag, dataset and project errors
+const (
+	TagNotFound            Code = "TAG_NOT_FOUND"
+	TagExists              Code = "TAG_EXISTS"
+	DatasetNotFound        Code = "DATASET_NOT_FOUND"
+	DatasetExists          Code = "DATASET_EXISTS"
+	DatasetVersionNotFound Code = "DATASET_VERSION_NOT_FOUND"
+	ApprovalsMissing       Code = "APPROVALS_MISSING"
+	ProjectNotFound        Code = "PROJECT_NOT_FOUND"
+	ProjectExists          Code = "PROJECT_EXISTS"
+	QuotaExceeded          Code = "QUOTA_EXCEEDED"
+)
+
+// Dependency errors
+const (
+	StorageUnavailable Code = "STORAGE_UNAVAILABLE"
+)
+
+// Retryable reports whether resubmitting the same request may succeed
+func (c Code) Retryable() bool {
+	switch c {
+	case PresignFailed, StorageUnavailable, RateLimited, IdempotencyKeyInFlight, Internal:
+		return true
+	}
+	return false
+}
@@ -6,4 +6,5 @@ import "errors"
 var (
 	ErrInvalidUri = errors.New("Invalid URI parameters")
 	ErrInvalidPayload = errors.New("Invalid payload")
+	ErrInvalidQuery = errors.New("Invalid query parameters")
 )
\ No newline at end of file
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotModified sets a weak ETag on the response and reports whether the client
+// copy (If-None-Match) is still current, in which case a 304 has been written.
+func NotModified(c *gin.Context, version string) bool {
+	etag := fmt.Sprintf(`W/"%s"`, version)
+	c.Header("ETag", etag)
+
+	for _, candidate := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == etag || candidate == "*" {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,9 +4,12 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+	"github.com/UnivocalX/aether/pkg/web/api/apierrors"
 	dataService "github.com/UnivocalX/aether/pkg/web/services/data"
 	"github.com/gin-gonic/gin"
 )
@@ -19,14 +22,15 @@ type Response struct {
 
 // Error response wrapper
 type ErrorDetails struct {
-	Msg string          `json:"message"`
+	Msg     string          `json:"message"`
 	Details *map[string]any `json:"details,omitempty"`
 }
 
 type ErrorResponse struct {
-	Msg string            `json:"message"`
-	Err   *ErrorDetails     `json:"error"`
-	Meta    *ResponseMetadata `json:"meta,omitempty"`
+	Msg  string            `json:"message"`
+	Code apierrors.Code    `json:"code"`
+	Err  *ErrorDetails     `json:"error"`
+	Meta *ResponseMetadata `json:"meta,omitempty"`
 }
 
 // Metadata attached to every response
@@ -45,7 +49,8 @@ func NewResponse(c *gin.Context, msg string) *Response {
 
 func NewErrorResponse(c *gin.Context, msg string, err error) *ErrorResponse {
 	return &ErrorResponse{
-		Msg: msg,
+		Msg:  msg,
+		Code: apierrors.Internal,
 		Err: &ErrorDetails{
 			Msg: err.Error(),
 		},
@@ -61,17 +66,22 @@ func buildMeta(c *gin.Context) *ResponseMetadata {
 	}
 }
 
-func (r *Response) OK(c *gin.Context)                   { c.JSON(http.StatusOK, r) }
-func (r *Response) Created(c *gin.Context)              { c.JSON(http.StatusCreated, r) }
+// Success responses take the whole body, a method on the embedded Response would only encode the message
+func OK(c *gin.Context, body any)                       { c.JSON(http.StatusOK, body) }
+func Created(c *gin.Context, body any)                  { c.JSON(http.StatusCreated, body) }
+func Accepted(c *gin.Context, body any)                 { c.JSON(http.StatusAccepted, body) }
+func MultiStatus(c *gin.Context, body any)              { c.JSON(http.StatusMultiStatus, body) }
 func (r *Response) NoContent(c *gin.Context)            { c.Status(http.StatusNoContent) }
-func (r *Response) MultiStatus(c *gin.Context)          { c.JSON(http.StatusMultiStatus, r) }
 func (r *ErrorResponse) BadRequest(c *gin.Context)      { c.JSON(http.StatusBadRequest, r) }
 func (r *ErrorResponse) Unauthorized(c *gin.Context)    { c.JSON(http.StatusUnauthorized, r) }
 func (r *ErrorResponse) Forbidden(c *gin.Context)       { c.JSON(http.StatusForbidden, r) }
 func (r *ErrorResponse) NotFound(c *gin.Context)        { c.JSON(http.StatusNotFound, r) }
 func (r *ErrorResponse) ContentTooLarge(c *gin.Context) { c.JSON(http.StatusRequestEntityTooLarge, r) }
 func (r *ErrorResponse) Conflict(c *gin.Context)        { c.JSON(http.StatusConflict, r) }
+func (r *ErrorResponse) TooManyRequests(c *gin.Context) { c.JSON(http.StatusTooManyRequests, r) }
+func (r *ErrorResponse) Unprocessable(c *gin.Context)   { c.JSON(http.StatusUnprocessableEntity, r) }
 func (r *ErrorResponse) InternalError(c *gin.Context)   { c.JSON(http.StatusInternalServerError, r) }
+func (r *ErrorResponse) Unavailable(c *gin.Context)     { c.JSON(http.StatusServiceUnavailable, r) }
 
 func HandleErrorResponse(ctx *gin.Context, msg string, err error) {
 	response := NewErrorResponse(ctx, msg, err)
@@ -81,38 +91,222 @@ func HandleErrorResponse(ctx *gin.Context, msg string, err error) {
 		err.Error(),
 	)
 
+	described := DescribeError(err)
+	response.Code = described.Code
+	if described.Msg != "" {
+		response.Err.Msg = described.Msg
+	}
+	response.Err.Details = described.Details
+	if described.RetryAfter != "" {
+		ctx.Header("Retry-After", described.RetryAfter)
+	}
+	ctx.JSON(described.Status, response)
+}
+
+// ErrorDescription is how an error is answered whatever the transport: its HTTP status, code and details
+type ErrorDescription struct {
+	Status  int
+	Code    apierrors.Code
+	Msg     string
+	Details *map[string]any
+	// RetryAfter is the Retry-After header value, empty when the request should not be retried as is
+	RetryAfter string
+}
+
+// DescribeError maps err to the status, code and details of its error response, unknown errors are internal
+func DescribeError(err error) ErrorDescription {
+	description := ErrorDescription{Status: http.StatusInternalServerError, Code: apierrors.Internal}
+
 	var assetsExistError dataService.AssetsExistsError
 	var maxBytesError *http.MaxBytesError
+	var tooLargeError *registry.ObjectTooLargeError
+	var missingApprovalsError *registry.MissingApprovalsError
+	var referencedError *registry.AssetsReferencedError
+	var quotaError *registry.QuotaExceededError
+	var unavailableError *registry.StorageUnavailableError
 
 	switch {
 	case errors.As(err, &maxBytesError):
-		response.Err.Msg = maxBytesError.Error()
-		response.Err.Details = &map[string]any{
+		description.Code = apierrors.PayloadTooLarge
+		description.Msg = maxBytesError.Error()
+		description.Details = &map[string]any{
 			"max_bytes": maxBytesError.Limit,
 		}
-		response.ContentTooLarge(ctx)
+		description.Status = http.StatusRequestEntityTooLarge
+
+	case errors.As(err, &tooLargeError):
+		description.Code = apierrors.ObjectTooLarge
+		description.Details = &map[string]any{
+			"size_bytes":     tooLargeError.Size,
+			"max_size_bytes": tooLargeError.Limit,
+		}
+		description.Status = http.StatusRequestEntityTooLarge
 
 	case errors.Is(err, ErrInvalidUri),
 		errors.Is(err, ErrInvalidPayload),
-		errors.Is(err, registry.ErrValidation):
-		response.BadRequest(ctx)
+		errors.Is(err, ErrInvalidQuery),
+		errors.Is(err, registryerrors.ErrValidation),
+		errors.Is(err, dataService.ErrInvalidPageToken),
+		errors.Is(err, dataService.ErrJobNoDryRun):
+		description.Code = apierrors.Validation
+		description.Status = http.StatusBadRequest
+
+	case errors.Is(err, dataService.ErrUnauthenticated),
+		errors.Is(err, dataService.ErrClientCertRequired),
+		errors.Is(err, registry.ErrInvalidAPIKey):
+		description.Code = apierrors.Unauthenticated
+		description.Status = http.StatusUnauthorized
+
+	case errors.As(err, &unavailableError):
+		// metadata stays readable, only the requests needing the object store wait for it
+		description.Code = apierrors.StorageUnavailable
+		retryAfter := int(unavailableError.RetryAfter.Round(time.Second).Seconds())
+		description.RetryAfter = strconv.Itoa(retryAfter)
+		description.Details = &map[string]any{
+			"dependency":          "storage",
+			"retry_after_seconds": retryAfter,
+		}
+		description.Status = http.StatusServiceUnavailable
+
+	case errors.Is(err, dataService.ErrTooManyProbes),
+		errors.Is(err, dataService.ErrRateLimited),
+		errors.Is(err, dataService.ErrPresignRateExceeded):
+		description.Code = apierrors.RateLimited
+		description.Status = http.StatusTooManyRequests
+
+	case errors.As(err, &quotaError):
+		description.Code = apierrors.QuotaExceeded
+		description.Details = &map[string]any{
+			"stored_bytes":   quotaError.Usage.StoredBytes,
+			"stored_objects": quotaError.Usage.StoredObjects,
+			"quota_bytes":    quotaError.Usage.QuotaBytes,
+			"quota_objects":  quotaError.Usage.QuotaObjects,
+		}
+		description.Status = http.StatusForbidden
+
+	case errors.Is(err, dataService.ErrAssetNotAccessible),
+		errors.Is(err, registry.ErrApproverRole),
+		errors.Is(err, registry.ErrLicenseRestricted),
+		errors.Is(err, registry.ErrInvalidSignature),
+		errors.Is(err, registry.ErrInvalidPeerSignature),
+		errors.Is(err, dataService.ErrInvalidStorageToken),
+		errors.Is(err, dataService.ErrScopeDenied),
+		errors.Is(err, registry.ErrPermissionDenied),
+		errors.Is(err, dataService.ErrProjectDenied),
+		errors.Is(err, registry.ErrClassificationRestricted),
+		errors.Is(err, registryerrors.ErrQuotaExceeded):
+		description.Code = errorCode(err, apierrors.AccessDenied)
+		description.Status = http.StatusForbidden
+
+	case errors.As(err, &missingApprovalsError):
+		description.Code = apierrors.ApprovalsMissing
+		description.Details = &map[string]any{
+			"missing_roles": missingApprovalsError.Roles,
+		}
+		description.Status = http.StatusConflict
+
+	case errors.As(err, &referencedError):
+		description.Code = apierrors.AssetReferenced
+		description.Details = &map[string]any{
+			"checksums": referencedError.Checksums,
+		}
+		description.Status = http.StatusConflict
+
+	case errors.Is(err, registry.ErrContentRejected),
+		errors.Is(err, registry.ErrChecksumMismatch),
+		errors.Is(err, registry.ErrSizeMismatch),
+		errors.Is(err, registry.ErrIdempotencyKeyReused):
+		description.Code = errorCode(err, apierrors.Validation)
+		description.Status = http.StatusUnprocessableEntity
+
+	case errors.Is(err, registry.ErrIdempotencyKeyInFlight):
+		description.Code = apierrors.IdempotencyKeyInFlight
+		description.RetryAfter = "1"
+		description.Status = http.StatusConflict
+
+	case errors.Is(err, registryerrors.ErrConflict),
+		errors.Is(err, registryerrors.ErrReadOnly),
+		errors.Is(err, dataService.ErrAssetNotReady):
+		description.Code = errorCode(err, apierrors.Conflict)
+		description.Status = http.StatusConflict
 
 	case errors.Is(err, dataService.ErrAssetNotFound),
-		errors.Is(err, dataService.ErrTagNotFound):
-		response.NotFound(ctx)
+		errors.Is(err, dataService.ErrTagNotFound),
+		errors.Is(err, dataService.ErrProjectNotFound),
+		errors.Is(err, dataService.ErrJobNotFound),
+		errors.Is(err, dataService.ErrContentPolicyNotFound),
+		errors.Is(err, dataService.ErrLicenseRestrictionNotFound),
+		errors.Is(err, dataService.ErrStorageNotServed),
+		errors.Is(err, dataService.ErrDatasetNotFound),
+		errors.Is(err, dataService.ErrDatasetVersionNotFound),
+		errors.Is(err, dataService.ErrDatasetChannelNotFound),
+		errors.Is(err, dataService.ErrDatasetSchemaNotFound),
+		errors.Is(err, dataService.ErrManifestNotFound),
+		errors.Is(err, dataService.ErrAssetDeletionNotFound),
+		errors.Is(err, dataService.ErrReplicationDisabled),
+		errors.Is(err, dataService.ErrStorageEventsDisabled),
+		errors.Is(err, dataService.ErrWebhookNotFound),
+		errors.Is(err, dataService.ErrAPIKeyNotFound),
+		errors.Is(err, dataService.ErrTagGroupNotFound),
+		errors.Is(err, dataService.ErrRetagJobNotFound),
+		errors.Is(err, dataService.ErrRoleAssignmentNotFound),
+		errors.Is(err, registryerrors.ErrNotFound):
+		description.Code = errorCode(err, apierrors.NotFound)
+		description.Status = http.StatusNotFound
 
 	case errors.As(err, &assetsExistError):
-		response.Err.Details = &map[string]any{
+		description.Code = apierrors.AssetExists
+		description.Details = &map[string]any{
 			"checksums": assetsExistError.Checksums,
 		}
-		response.Conflict(ctx)
+		description.Status = http.StatusConflict
 
 	case errors.Is(err, dataService.ErrAssetAlreadyExists),
+		errors.Is(err, dataService.ErrAssetIsReady),
 		errors.Is(err, dataService.ErrTagAlreadyExists),
-		errors.Is(err, dataService.ErrDatasetAlreadyExists):
-		response.Conflict(ctx)
+		errors.Is(err, dataService.ErrDatasetAlreadyExists),
+		errors.Is(err, dataService.ErrWebhookAlreadyExists),
+		errors.Is(err, dataService.ErrAPIKeyAlreadyExists),
+		errors.Is(err, dataService.ErrTagGroupAlreadyExists),
+		errors.Is(err, dataService.ErrProjectAlreadyExists),
+		errors.Is(err, registryerrors.ErrAlreadyExists):
+		description.Code = errorCode(err, apierrors.AlreadyExists)
+		description.Status = http.StatusConflict
+
+	}
+	return description
+}
+
+// errorCodes give sentinel errors a code more specific than the one of their status
+var errorCodes = []struct {
+	err  error
+	code apierrors.Code
+}{
+	{dataService.ErrAssetNotFound, apierrors.AssetNotFound},
+	{dataService.ErrTagNotFound, apierrors.TagNotFound},
+	{dataService.ErrProjectNotFound, apierrors.ProjectNotFound},
+	{dataService.ErrDatasetNotFound, apierrors.DatasetNotFound},
+	{dataService.ErrDatasetVersionNotFound, apierrors.DatasetVersionNotFound},
+	{dataService.ErrAssetAlreadyExists, apierrors.AssetExists},
+	{dataService.ErrAssetIsReady, apierrors.AssetReady},
+	{dataService.ErrTagAlreadyExists, apierrors.TagExists},
+	{dataService.ErrDatasetAlreadyExists, apierrors.DatasetExists},
+	{dataService.ErrProjectAlreadyExists, apierrors.ProjectExists},
+	{dataService.ErrAssetNotReady, apierrors.AssetNotReady},
+	{registry.ErrContentRejected, apierrors.ContentRejected},
+	{registry.ErrChecksumMismatch, apierrors.ChecksumMismatch},
+	{registry.ErrSizeMismatch, apierrors.SizeMismatch},
+	{registry.ErrIdempotencyKeyReused, apierrors.IdempotencyKeyReused},
+	{registryerrors.ErrQuotaExceeded, apierrors.QuotaExceeded},
+	{registryerrors.ErrReadOnly, apierrors.ReadOnly},
+}
 
-	default:
-		response.InternalError(ctx)
+// errorCode returns the code of the first sentinel err matches, fallback when none does
+func errorCode(err error, fallback apierrors.Code) apierrors.Code {
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
 	}
+	return fallback
 }
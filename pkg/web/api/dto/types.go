@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type TagUri struct {
 	TagName string `uri:"tag_name" binding:"required,min=1,max=100"`
 }
@@ -12,7 +14,75 @@ type DatasetUri struct {
 	DatasetName string `uri:"dataset_name" binding:"required,max=100"`
 }
 
+type DatasetVersionUri struct {
+	DatasetUri
+	Version int `uri:"version" binding:"required,min=1"`
+}
+
+type DatasetChannelUri struct {
+	DatasetUri
+	Channel string `uri:"channel_name" binding:"required,max=50"`
+}
+
+type DigestUri struct {
+	Algorithm string `uri:"algorithm" binding:"required,min=1,max=20"`
+	Digest    string `uri:"digest" binding:"required,max=128,hexadecimal"`
+}
+
 type AssetTagUri struct {
 	TagUri
 	AssetUri
 }
+
+// PresignQuery lets clients request a custom presigned url expiry in seconds,
+// the server clamps it into its configured bounds. Requests over a week, what s3 allows, fail validation.
+type PresignQuery struct {
+	ExpiresIn uint `form:"expires_in" binding:"omitempty,min=1,max=604800"`
+}
+
+func (q PresignQuery) Duration() time.Duration {
+	return time.Duration(q.ExpiresIn) * time.Second
+}
+
+type StagingUri struct {
+	StagingID string `uri:"staging_id" binding:"required,len=32,hexadecimal"`
+}
+
+type LicenseUri struct {
+	License string `uri:"license" binding:"required,min=1,max=100"`
+}
+
+type ProjectUri struct {
+	Project string `uri:"project" binding:"required,min=1,max=63"`
+}
+
+type RetagJobUri struct {
+	JobID uint `uri:"job_id" binding:"required,min=1"`
+}
+
+type TagGroupUri struct {
+	GroupName string `uri:"group_name" binding:"required,min=1,max=100"`
+}
+
+type WebhookUri struct {
+	WebhookName string `uri:"webhook_name" binding:"required,min=1,max=100"`
+}
+
+// ExpandQuery selects optional parts of asset listings, e.g. ?expand=previews.
+// ExpiresIn bounds the presigned urls of expanded parts.
+type ExpandQuery struct {
+	Expand string `form:"expand" binding:"omitempty,oneof=previews"`
+	PresignQuery
+}
+
+func (q ExpandQuery) Previews() bool {
+	return q.Expand == "previews"
+}
+
+type APIKeyUri struct {
+	KeyName string `uri:"key_name" binding:"required,min=1,max=100"`
+}
+
+type PrincipalUri struct {
+	Principal string `uri:"principal" binding:"required,min=1,max=200"`
+}
@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// RevokeAPIKeyHandler revokes a key, the revoked record stays listed for auditing
+func RevokeAPIKeyHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.APIKeyUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to revoke api key", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	key, err := svc.RevokeAPIKey(ctx.Request.Context(), uri.KeyName)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to revoke api key", err)
+		return
+	}
+
+	// Success response
+	response := newAPIKeyResponse(ctx, "revoked api key successfully", key)
+	dto.OK(ctx, response)
+}
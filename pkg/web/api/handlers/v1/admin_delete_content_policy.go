@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+func DeleteContentPolicyHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.ProjectUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to delete content policy", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	if err := svc.DeleteContentPolicy(ctx.Request.Context(), uri.Project); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to delete content policy", err)
+		return
+	}
+
+	// Success response
+	response := dto.NewResponse(ctx, "deleted content policy successfully")
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"project", uri.Project,
+	)
+
+	response.NoContent(ctx)
+}
@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+func DeleteLicenseRestrictionHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.LicenseUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to delete license restriction", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	if err := svc.DeleteLicenseRestriction(ctx.Request.Context(), uri.License); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to delete license restriction", err)
+		return
+	}
+
+	// Success response
+	response := dto.NewResponse(ctx, "deleted license restriction successfully")
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"license", uri.License,
+	)
+
+	response.NoContent(ctx)
+}
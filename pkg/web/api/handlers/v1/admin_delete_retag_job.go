@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// CancelRetagJobHandler stops a pending or running job, tags already changed are kept
+func CancelRetagJobHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.RetagJobUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to cancel retag job", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	job, err := svc.CancelRetagJob(ctx.Request.Context(), uri.JobID)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to cancel retag job", err)
+		return
+	}
+
+	// Success response
+	response := newRetagJobResponse(ctx, "cancelled retag job successfully", job)
+	dto.OK(ctx, response)
+}
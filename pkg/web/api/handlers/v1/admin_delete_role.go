@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// RevokeRoleAssignmentHandler removes the role assigned to a principal
+func RevokeRoleAssignmentHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.PrincipalUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to revoke role", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	assignment, err := svc.RevokeRole(ctx.Request.Context(), uri.Principal)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to revoke role", err)
+		return
+	}
+
+	// Success response
+	response := dto.NewResponse(ctx, "revoked role successfully")
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"principal", assignment.Principal,
+		"role", assignment.Role,
+	)
+
+	response.NoContent(ctx)
+}
@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListAccessLogQuery struct {
+	PageToken string `form:"page_token" binding:"omitempty,max=4096"`
+	Limit     uint   `form:"limit" binding:"omitempty,gte=1,lte=5000"`
+	ListAccessLogFilters
+}
+
+// ListAccessLogFilters is the query state page tokens are bound to
+type ListAccessLogFilters struct {
+	Principal string    `form:"principal" binding:"omitempty,max=200"`
+	Project   string    `form:"project" binding:"omitempty,max=63"`
+	Checksum  string    `form:"checksum" binding:"omitempty,len=64,hexadecimal"`
+	Operation string    `form:"operation" binding:"omitempty,oneof=put get"`
+	Since     time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until     time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type ListAccessLogResponse struct {
+	dto.Response
+	Total         int                 `json:"total"`
+	NextPageToken string              `json:"next_page_token,omitempty"`
+	Entries       []*AccessLogDetails `json:"entries"`
+}
+
+type AccessLogDetails struct {
+	ID        uint      `json:"id"`
+	IssuedAt  time.Time `json:"issued_at"`
+	Principal string    `json:"principal"`
+	Project   string    `json:"project,omitempty"`
+	ClientIP  string    `json:"client_ip"`
+	UserAgent string    `json:"user_agent"`
+	Checksum  string    `json:"checksum"`
+	Operation string    `json:"operation"`
+	Bucket    string    `json:"bucket"`
+	Key       string    `json:"key"`
+	ExpiresAt time.Time `json:"expires_at"`
+	ExpiresIn int64     `json:"expires_in"`
+}
+
+func ListAccessLogHandler(svc *data.Service, ctx *gin.Context) {
+	var query ListAccessLogQuery
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to list access log",
+			fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err),
+		)
+		return
+	}
+
+	if !query.Since.IsZero() && !query.Until.IsZero() && !query.Since.Before(query.Until) {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to list access log",
+			fmt.Errorf("%w, since must be before until", dto.ErrInvalidQuery),
+		)
+		return
+	}
+
+	cursor, err := svc.DecodePageToken(query.PageToken, &query.ListAccessLogFilters)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list access log", err)
+		return
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = registry.AccessLogDefaultLimit
+	}
+
+	opts := []registry.AccessLogOption{
+		registry.WithAccessLimit(limit),
+		registry.WithAccessWindow(query.Since, query.Until),
+	}
+	if cursor > 0 {
+		opts = append(opts, registry.WithAccessCursor(cursor))
+	}
+	if query.Principal != "" {
+		opts = append(opts, registry.WithAccessPrincipal(query.Principal))
+	}
+	if query.Project != "" {
+		opts = append(opts, registry.WithAccessProject(query.Project))
+	}
+	if query.Checksum != "" {
+		opts = append(opts, registry.WithAccessChecksum(query.Checksum))
+	}
+	if query.Operation != "" {
+		opts = append(opts, registry.WithAccessOperation(query.Operation))
+	}
+
+	entries, err := svc.ListAccessLog(ctx.Request.Context(), opts...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list access log", err)
+		return
+	}
+
+	var last uint
+	if len(entries) > 0 {
+		last = entries[len(entries)-1].ID
+	}
+	token, err := nextPageToken(svc, len(entries), limit, last, query.ListAccessLogFilters)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list access log", err)
+		return
+	}
+
+	// Success response
+	response := newListAccessLogResponse(ctx, entries, token)
+	dto.OK(ctx, response)
+}
+
+func newListAccessLogResponse(ctx *gin.Context, entries []*registry.AccessLog, nextPageToken string) ListAccessLogResponse {
+	items := make([]*AccessLogDetails, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, &AccessLogDetails{
+			ID:        e.ID,
+			IssuedAt:  e.CreatedAt,
+			Principal: e.Principal,
+			Project:   e.Project,
+			ClientIP:  e.ClientIP,
+			UserAgent: e.UserAgent,
+			Checksum:  e.Checksum,
+			Operation: e.Operation,
+			Bucket:    e.Bucket,
+			Key:       e.Key,
+			ExpiresAt: e.ExpiresAt,
+			ExpiresIn: e.ExpiresIn,
+		})
+	}
+
+	response := ListAccessLogResponse{
+		Response:      *dto.NewResponse(ctx, "listed access log successfully"),
+		Total:         len(entries),
+		NextPageToken: nextPageToken,
+		Entries:       items,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", len(entries),
+	)
+	return response
+}
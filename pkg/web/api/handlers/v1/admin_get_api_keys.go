@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListAPIKeysResponse struct {
+	dto.Response
+	Total int              `json:"total"`
+	Keys  []*APIKeyDetails `json:"keys"`
+}
+
+func ListAPIKeysHandler(svc *data.Service, ctx *gin.Context) {
+	keys, err := svc.ListAPIKeys(ctx.Request.Context())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list api keys", err)
+		return
+	}
+
+	// Success response
+	response := ListAPIKeysResponse{
+		Response: *dto.NewResponse(ctx, "listed api keys successfully"),
+		Total:    len(keys),
+		Keys:     make([]*APIKeyDetails, 0, len(keys)),
+	}
+	for _, key := range keys {
+		response.Keys = append(response.Keys, newAPIKeyDetails(key))
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", response.Total,
+	)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ContentPolicyResponse struct {
+	dto.Response
+	Project         string    `json:"project"`
+	AllowMimeTypes  []string  `json:"allow_mime_types"`
+	DenyMimeTypes   []string  `json:"deny_mime_types"`
+	AllowExtensions []string  `json:"allow_extensions"`
+	DenyExtensions  []string  `json:"deny_extensions"`
+	MaxSizeBytes    int64     `json:"max_size_bytes"`
+	AutoTag         bool      `json:"auto_tag"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func GetContentPolicyHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.ProjectUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get content policy", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	policy, err := svc.GetContentPolicy(ctx.Request.Context(), uri.Project)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get content policy", err)
+		return
+	}
+
+	// Success response
+	response := newContentPolicyResponse(ctx, "got content policy successfully", policy)
+	dto.OK(ctx, response)
+}
+
+func newContentPolicyResponse(ctx *gin.Context, msg string, policy *registry.ContentPolicy) ContentPolicyResponse {
+	response := ContentPolicyResponse{
+		Response:        *dto.NewResponse(ctx, msg),
+		Project:         policy.Project,
+		AllowMimeTypes:  policy.AllowMimeTypes,
+		DenyMimeTypes:   policy.DenyMimeTypes,
+		AllowExtensions: policy.AllowExtensions,
+		DenyExtensions:  policy.DenyExtensions,
+		MaxSizeBytes:    policy.MaxSizeBytes,
+		AutoTag:         policy.AutoTag,
+		UpdatedAt:       policy.UpdatedAt,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"project", policy.Project,
+	)
+	return response
+}
@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+)
+
+type ListEventsQuery struct {
+	PageToken string `form:"page_token" binding:"omitempty,max=4096"`
+	Limit     uint   `form:"limit" binding:"omitempty,gte=1,lte=5000"`
+	ListEventsFilters
+}
+
+// ListEventsFilters is the query state page tokens are bound to
+type ListEventsFilters struct {
+	Kind      string    `form:"kind" binding:"omitempty,max=100"`
+	Severity  string    `form:"severity" binding:"omitempty,oneof=info warning error"`
+	Principal string    `form:"principal" binding:"omitempty,max=200"`
+	Since     time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type ListEventsResponse struct {
+	dto.Response
+	Total         int             `json:"total"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+	Events        []*EventDetails `json:"events"`
+}
+
+type EventDetails struct {
+	ID        uint           `json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	Kind      string         `json:"kind"`
+	Severity  string         `json:"severity"`
+	Principal string         `json:"principal,omitempty"`
+	Project   string         `json:"project,omitempty"`
+	Message   string         `json:"message"`
+	Data      datatypes.JSON `json:"data,omitempty"`
+}
+
+func ListEventsHandler(svc *data.Service, ctx *gin.Context) {
+	var query ListEventsQuery
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to list events",
+			fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err),
+		)
+		return
+	}
+
+	cursor, err := svc.DecodePageToken(query.PageToken, &query.ListEventsFilters)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list events", err)
+		return
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = registry.EventsDefaultLimit
+	}
+
+	opts := []registry.EventOption{registry.WithEventLimit(limit)}
+	if cursor > 0 {
+		opts = append(opts, registry.WithEventCursor(cursor))
+	}
+	if query.Kind != "" {
+		opts = append(opts, registry.WithEventKind(query.Kind))
+	}
+	if query.Severity != "" {
+		opts = append(opts, registry.WithEventSeverity(registry.Severity(query.Severity)))
+	}
+	if query.Principal != "" {
+		opts = append(opts, registry.WithEventPrincipal(query.Principal))
+	}
+	if !query.Since.IsZero() {
+		opts = append(opts, registry.WithEventSince(query.Since))
+	}
+
+	events, err := svc.ListEvents(ctx.Request.Context(), opts...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list events", err)
+		return
+	}
+
+	var last uint
+	if len(events) > 0 {
+		last = events[len(events)-1].ID
+	}
+	token, err := nextPageToken(svc, len(events), limit, last, query.ListEventsFilters)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list events", err)
+		return
+	}
+
+	// Success response
+	response := newListEventsResponse(ctx, events, token)
+	dto.OK(ctx, response)
+}
+
+func newListEventsResponse(ctx *gin.Context, events []*registry.Event, nextPageToken string) ListEventsResponse {
+	items := make([]*EventDetails, 0, len(events))
+	for _, e := range events {
+		items = append(items, &EventDetails{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+			Kind:      e.Kind,
+			Severity:  string(e.Severity),
+			Principal: e.Principal,
+			Project:   e.Project,
+			Message:   e.Message,
+			Data:      e.Data,
+		})
+	}
+
+	response := ListEventsResponse{
+		Response:      *dto.NewResponse(ctx, "listed events successfully"),
+		Total:         len(events),
+		NextPageToken: nextPageToken,
+		Events:        items,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", len(events),
+	)
+	return response
+}
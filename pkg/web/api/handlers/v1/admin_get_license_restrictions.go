@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type LicenseRestrictionDetails struct {
+	License      string    `json:"license"`
+	BlockedRoles []string  `json:"blocked_roles"`
+	Note         string    `json:"note,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type ListLicenseRestrictionsResponse struct {
+	dto.Response
+	Total        int                          `json:"total"`
+	Restrictions []*LicenseRestrictionDetails `json:"restrictions"`
+}
+
+func ListLicenseRestrictionsHandler(svc *data.Service, ctx *gin.Context) {
+	restrictions, err := svc.ListLicenseRestrictions(ctx.Request.Context())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list license restrictions", err)
+		return
+	}
+
+	// Success response
+	response := ListLicenseRestrictionsResponse{
+		Response:     *dto.NewResponse(ctx, "listed license restrictions successfully"),
+		Total:        len(restrictions),
+		Restrictions: make([]*LicenseRestrictionDetails, 0, len(restrictions)),
+	}
+	for _, restriction := range restrictions {
+		response.Restrictions = append(response.Restrictions, newLicenseRestrictionDetails(restriction))
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", response.Total,
+	)
+	dto.OK(ctx, response)
+}
+
+func newLicenseRestrictionDetails(restriction *registry.LicenseRestriction) *LicenseRestrictionDetails {
+	return &LicenseRestrictionDetails{
+		License:      restriction.License,
+		BlockedRoles: restriction.BlockedRoles,
+		Note:         restriction.Note,
+		UpdatedAt:    restriction.UpdatedAt,
+	}
+}
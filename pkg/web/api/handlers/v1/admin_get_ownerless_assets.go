@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListOwnerlessAssetsRequest struct {
+	PageToken string `json:"page_token" binding:"omitempty,max=4096"`
+	Limit     uint   `json:"limit" binding:"omitempty,gte=1,lte=1000"`
+}
+
+// OwnerlessAssetsResponse totals the assets without an owner and lists a page of them, deleted ones are left out
+type OwnerlessAssetsResponse struct {
+	dto.Response
+	TotalAssets   int64                `json:"total_assets"`
+	TotalBytes    int64                `json:"total_bytes"`
+	ByState       []*StatsGroupDetails `json:"by_state"`
+	NextPageToken string               `json:"next_page_token,omitempty"`
+	Assets        []*AssetDetails      `json:"assets"`
+}
+
+func ListOwnerlessAssetsHandler(svc *data.Service, ctx *gin.Context) {
+	var request ListOwnerlessAssetsRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to report ownerless assets", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	// the report has no filters, tokens only carry the cursor
+	var state struct{}
+	cursor, err := svc.DecodePageToken(request.PageToken, &state)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to report ownerless assets", err)
+		return
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = registry.SearchDefaultLimit
+	}
+
+	report, err := svc.GetOwnerlessReport(ctx.Request.Context())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to report ownerless assets", err)
+		return
+	}
+
+	assets, err := svc.ListOwnerlessAssets(ctx.Request.Context(), cursor, limit)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to report ownerless assets", err)
+		return
+	}
+
+	token, err := nextPageToken(svc, len(assets), limit, lastAssetID(assets), state)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to report ownerless assets", err)
+		return
+	}
+
+	// Success response
+	response := OwnerlessAssetsResponse{
+		Response:      *dto.NewResponse(ctx, "reported ownerless assets successfully"),
+		TotalAssets:   report.TotalAssets,
+		TotalBytes:    report.TotalBytes,
+		ByState:       newStatsGroupDetails(report.ByState),
+		NextPageToken: token,
+		Assets:        make([]*AssetDetails, 0, len(assets)),
+	}
+	for _, asset := range assets {
+		response.Assets = append(response.Assets, newAssetDetails(asset))
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"assets", report.TotalAssets,
+		"bytes", report.TotalBytes,
+	)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListRetagJobsRequest struct {
+	PageToken string `json:"page_token" binding:"omitempty,max=4096"`
+	Limit     uint   `json:"limit" binding:"omitempty,gte=1,lte=1000"`
+	ListRetagJobsFilters
+}
+
+// ListRetagJobsFilters is the query state page tokens are bound to
+type ListRetagJobsFilters struct {
+	Status string `json:"status" binding:"omitempty,oneof=pending running completed failed cancelled"`
+}
+
+type ListRetagJobsResponse struct {
+	dto.Response
+	Total         int                `json:"total"`
+	NextPageToken string             `json:"next_page_token,omitempty"`
+	Jobs          []*RetagJobDetails `json:"jobs"`
+}
+
+func ListRetagJobsHandler(svc *data.Service, ctx *gin.Context) {
+	var request ListRetagJobsRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list retag jobs", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	cursor, err := svc.DecodePageToken(request.PageToken, &request.ListRetagJobsFilters)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list retag jobs", err)
+		return
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = registry.SearchDefaultLimit
+	}
+
+	jobs, err := svc.ListRetagJobs(ctx.Request.Context(), registry.RetagStatus(request.Status), cursor, limit)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list retag jobs", err)
+		return
+	}
+
+	var last uint
+	if len(jobs) > 0 {
+		last = jobs[len(jobs)-1].ID
+	}
+	token, err := nextPageToken(svc, len(jobs), limit, last, request.ListRetagJobsFilters)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list retag jobs", err)
+		return
+	}
+
+	// Success response
+	response := ListRetagJobsResponse{
+		Response:      *dto.NewResponse(ctx, "listed retag jobs successfully"),
+		Total:         len(jobs),
+		NextPageToken: token,
+		Jobs:          make([]*RetagJobDetails, 0, len(jobs)),
+	}
+	for _, job := range jobs {
+		response.Jobs = append(response.Jobs, newRetagJobDetails(job))
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", response.Total,
+	)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type RoleAssignmentDetails struct {
+	Principal  string            `json:"principal"`
+	Role       string            `json:"role"`
+	Actions    []registry.Action `json:"actions"`
+	AssignedBy string            `json:"assigned_by,omitempty"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+type ListRoleAssignmentsResponse struct {
+	dto.Response
+	Total       int                      `json:"total"`
+	Assignments []*RoleAssignmentDetails `json:"assignments"`
+}
+
+func ListRoleAssignmentsHandler(svc *data.Service, ctx *gin.Context) {
+	assignments, err := svc.ListRoleAssignments(ctx.Request.Context())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list role assignments", err)
+		return
+	}
+
+	// Success response
+	response := ListRoleAssignmentsResponse{
+		Response:    *dto.NewResponse(ctx, "listed role assignments successfully"),
+		Total:       len(assignments),
+		Assignments: make([]*RoleAssignmentDetails, 0, len(assignments)),
+	}
+	for _, assignment := range assignments {
+		response.Assignments = append(response.Assignments, newRoleAssignmentDetails(assignment))
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", response.Total,
+	)
+	dto.OK(ctx, response)
+}
+
+func newRoleAssignmentDetails(assignment *registry.RoleAssignment) *RoleAssignmentDetails {
+	return &RoleAssignmentDetails{
+		Principal:  assignment.Principal,
+		Role:       assignment.Role,
+		Actions:    registry.RoleActions(assignment.Role),
+		AssignedBy: assignment.AssignedBy,
+		UpdatedAt:  assignment.UpdatedAt,
+	}
+}
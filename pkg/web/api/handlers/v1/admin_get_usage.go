@@ -0,0 +1,175 @@
+package v1
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+const usageMonthFormat = "2006-01"
+
+type ListUsageQuery struct {
+	Project string    `form:"project" binding:"omitempty,max=63"`
+	Since   time.Time `form:"since" time_format:"2006-01"`
+	Until   time.Time `form:"until" time_format:"2006-01"`
+	Format  string    `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+type ListUsageResponse struct {
+	dto.Response
+	Total int             `json:"total"`
+	Usage []*UsageDetails `json:"usage"`
+
+	// Quotas is the current usage of the projects with a quota, warning from 80% and critical from 90% of it
+	Quotas []*QuotaUsageDetails `json:"quotas"`
+}
+
+type QuotaUsageDetails struct {
+	Project       string               `json:"project"`
+	Status        registry.QuotaStatus `json:"status"`
+	StoredBytes   int64                `json:"stored_bytes"`
+	QuotaBytes    int64                `json:"quota_bytes"`
+	StoredObjects int64                `json:"stored_objects"`
+	QuotaObjects  int64                `json:"quota_objects"`
+}
+
+type UsageDetails struct {
+	Project       string    `json:"project"`
+	Month         string    `json:"month"`
+	StoredBytes   int64     `json:"stored_bytes"`
+	StoredObjects int64     `json:"stored_objects"`
+	Uploads       int64     `json:"uploads"`
+	Downloads     int64     `json:"downloads"`
+	MeteredAt     time.Time `json:"metered_at"`
+}
+
+func ListUsageHandler(svc *data.Service, ctx *gin.Context) {
+	var query ListUsageQuery
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to list usage",
+			fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err),
+		)
+		return
+	}
+
+	if !query.Since.IsZero() && !query.Until.IsZero() && query.Until.Before(query.Since) {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to list usage",
+			fmt.Errorf("%w, since must not be after until", dto.ErrInvalidQuery),
+		)
+		return
+	}
+
+	// until is inclusive, usage of that month is listed too
+	until := query.Until
+	if !until.IsZero() {
+		until = until.AddDate(0, 1, 0)
+	}
+
+	opts := []registry.UsageOption{registry.WithUsageWindow(query.Since, until)}
+	if query.Project != "" {
+		opts = append(opts, registry.WithUsageProject(query.Project))
+	}
+
+	records, err := svc.ListUsage(ctx.Request.Context(), opts...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list usage", err)
+		return
+	}
+
+	if query.Format == "csv" {
+		writeUsageCSV(ctx, records)
+		return
+	}
+
+	quotas, err := svc.ListQuotaUsage(ctx.Request.Context(), query.Project)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list usage", err)
+		return
+	}
+
+	// Success response
+	response := newListUsageResponse(ctx, records, quotas)
+	dto.OK(ctx, response)
+}
+
+// writeUsageCSV streams usage records as a chargeback spreadsheet
+func writeUsageCSV(ctx *gin.Context, records []*registry.UsageRecord) {
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", `attachment; filename="aether-usage.csv"`)
+	ctx.Status(http.StatusOK)
+
+	w := csv.NewWriter(ctx.Writer)
+	_ = w.Write([]string{"project", "month", "stored_bytes", "stored_objects", "uploads", "downloads", "metered_at"})
+	for _, r := range records {
+		_ = w.Write([]string{
+			r.Project,
+			r.Month.Format(usageMonthFormat),
+			strconv.FormatInt(r.StoredBytes, 10),
+			strconv.FormatInt(r.StoredObjects, 10),
+			strconv.FormatInt(r.Uploads, 10),
+			strconv.FormatInt(r.Downloads, 10),
+			r.MeteredAt.UTC().Format(time.RFC3339),
+		})
+	}
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to write usage csv", "error", err)
+		return
+	}
+	slog.InfoContext(ctx.Request.Context(), "exported usage successfully",
+		"total", len(records),
+	)
+}
+
+func newListUsageResponse(ctx *gin.Context, records []*registry.UsageRecord, quotas []*registry.ProjectUsage) ListUsageResponse {
+	items := make([]*UsageDetails, 0, len(records))
+	for _, r := range records {
+		items = append(items, &UsageDetails{
+			Project:       r.Project,
+			Month:         r.Month.Format(usageMonthFormat),
+			StoredBytes:   r.StoredBytes,
+			StoredObjects: r.StoredObjects,
+			Uploads:       r.Uploads,
+			Downloads:     r.Downloads,
+			MeteredAt:     r.MeteredAt,
+		})
+	}
+
+	quotaItems := make([]*QuotaUsageDetails, 0, len(quotas))
+	for _, u := range quotas {
+		quotaItems = append(quotaItems, &QuotaUsageDetails{
+			Project:       u.Project,
+			Status:        u.Status(),
+			StoredBytes:   u.StoredBytes,
+			QuotaBytes:    u.QuotaBytes,
+			StoredObjects: u.StoredObjects,
+			QuotaObjects:  u.QuotaObjects,
+		})
+	}
+
+	response := ListUsageResponse{
+		Response: *dto.NewResponse(ctx, "listed usage successfully"),
+		Total:    len(records),
+		Usage:    items,
+		Quotas:   quotaItems,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", len(records),
+	)
+	return response
+}
@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAPIKeyRequest issues a key, roles and project become those of the requests it authenticates
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,min=1,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,max=3,dive,oneof=read write admin"`
+	Roles     []string   `json:"roles" binding:"omitempty,max=20,dive,min=1,max=100"`
+	Project   string     `json:"project" binding:"omitempty,min=1,max=63"`
+	ExpiresAt *time.Time `json:"expires_at" binding:"omitempty"`
+}
+
+// APIKeyDetails describes a key, the key itself is never returned after creation
+type APIKeyDetails struct {
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	Roles      []string   `json:"roles,omitempty"`
+	Project    string     `json:"project,omitempty"`
+	CreatedBy  string     `json:"created_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type APIKeyResponse struct {
+	dto.Response
+	*APIKeyDetails
+}
+
+// CreateAPIKeyResponse is the only response carrying the key
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func CreateAPIKeyHandler(svc *data.Service, ctx *gin.Context) {
+	var request CreateAPIKeyRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create api key", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	key := &registry.APIKey{
+		Name:      request.Name,
+		Scopes:    request.Scopes,
+		Roles:     request.Roles,
+		Project:   request.Project,
+		ExpiresAt: request.ExpiresAt,
+	}
+
+	secret, err := svc.CreateAPIKey(ctx.Request.Context(), key)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create api key", err)
+		return
+	}
+
+	// Success response
+	response := CreateAPIKeyResponse{
+		APIKeyResponse: newAPIKeyResponse(ctx, "api key created successfully", key),
+		Key:            secret.Value(),
+	}
+	dto.Created(ctx, response)
+}
+
+func newAPIKeyDetails(key *registry.APIKey) *APIKeyDetails {
+	return &APIKeyDetails{
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		Roles:      key.Roles,
+		Project:    key.Project,
+		CreatedBy:  key.CreatedBy,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
+
+func newAPIKeyResponse(ctx *gin.Context, msg string, key *registry.APIKey) APIKeyResponse {
+	response := APIKeyResponse{
+		Response:      *dto.NewResponse(ctx, msg),
+		APIKeyDetails: newAPIKeyDetails(key),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"key", key.Name,
+		"prefix", key.Prefix,
+		"scopes", key.Scopes,
+	)
+	return response
+}
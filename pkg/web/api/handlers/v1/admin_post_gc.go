@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// StartGarbageCollectionHandler starts a collection, with dry_run it lists the objects to delete instead
+func StartGarbageCollectionHandler(svc *data.Service, ctx *gin.Context) {
+	var query DryRunQuery
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to start garbage collection", fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err))
+		return
+	}
+
+	if query.DryRun {
+		plan, err := svc.PlanGarbageCollection(ctx.Request.Context())
+		if err != nil {
+			dto.HandleErrorResponse(ctx, "failed to plan garbage collection", err)
+			return
+		}
+		dto.OK(ctx, newDryRunResponse(ctx, "planned garbage collection successfully", plan))
+		return
+	}
+
+	svc.StartGarbageCollection(ctx.Request.Context())
+
+	// Accepted response, the outcome is reported as a storage.gc event
+	response := dto.NewResponse(ctx, "started garbage collection")
+	slog.InfoContext(ctx.Request.Context(), response.Msg)
+	dto.Accepted(ctx, response)
+}
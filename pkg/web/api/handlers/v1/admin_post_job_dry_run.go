@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type JobUri struct {
+	Name string `uri:"job_name" binding:"required,min=1,max=100"`
+}
+
+// DryRunQuery lists what a destructive operation would remove instead of acting
+type DryRunQuery struct {
+	DryRun bool `form:"dry_run"`
+}
+
+type CandidateDetails struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Bytes  int64  `json:"bytes"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// DryRunResponse lists the candidates of a destructive operation, nothing was changed
+type DryRunResponse struct {
+	dto.Response
+	Operation  string              `json:"operation"`
+	DryRun     bool                `json:"dry_run"`
+	Total      int                 `json:"total"`
+	Bytes      int64               `json:"bytes"`
+	Candidates []*CandidateDetails `json:"candidates"`
+}
+
+// DryRunJobHandler lists what a run of a maintenance job would remove, e.g. gc, deletion or partitions
+func DryRunJobHandler(svc *data.Service, ctx *gin.Context) {
+	var uri JobUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to plan job", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	plan, err := svc.PlanJob(ctx.Request.Context(), uri.Name)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to plan job", err)
+		return
+	}
+
+	// Success response
+	dto.OK(ctx, newDryRunResponse(ctx, "planned job successfully", plan))
+}
+
+func newDryRunResponse(ctx *gin.Context, msg string, plan *registry.DryRunReport) DryRunResponse {
+	response := DryRunResponse{
+		Response:   *dto.NewResponse(ctx, msg),
+		Operation:  plan.Operation,
+		DryRun:     true,
+		Total:      len(plan.Candidates),
+		Bytes:      plan.Bytes,
+		Candidates: make([]*CandidateDetails, 0, len(plan.Candidates)),
+	}
+	for _, c := range plan.Candidates {
+		response.Candidates = append(response.Candidates, &CandidateDetails{
+			Kind:   c.Kind,
+			Name:   c.Name,
+			Bytes:  c.Bytes,
+			Reason: c.Reason,
+		})
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"operation", response.Operation,
+		"total", response.Total,
+		"bytes", response.Bytes,
+	)
+	return response
+}
@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateProjectRequest registers a project, requests select it with the X-Aether-Project header
+type CreateProjectRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=63"`
+	Description string `json:"description" binding:"omitempty,max=2000"`
+}
+
+type ProjectDetails struct {
+	Name         string          `json:"name"`
+	Description  string          `json:"description,omitempty"`
+	DefaultTags  []string        `json:"default_tags,omitempty"`
+	DefaultExtra json.RawMessage `json:"default_extra,omitempty"`
+	QuotaBytes   int64           `json:"quota_bytes,omitempty"`
+	QuotaObjects int64           `json:"quota_objects,omitempty"`
+	CreatedBy    string          `json:"created_by,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+type ProjectResponse struct {
+	dto.Response
+	*ProjectDetails
+}
+
+func CreateProjectHandler(svc *data.Service, ctx *gin.Context) {
+	var request CreateProjectRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create project", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	project, err := svc.CreateProject(ctx.Request.Context(), request.Name, request.Description)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create project", err)
+		return
+	}
+
+	// Success response
+	response := ProjectResponse{
+		Response:       *dto.NewResponse(ctx, "project created successfully"),
+		ProjectDetails: newProjectDetails(project),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"project", project.Name,
+	)
+	dto.Created(ctx, response)
+}
+
+func newProjectDetails(project *registry.Project) *ProjectDetails {
+	return &ProjectDetails{
+		Name:         project.Name,
+		Description:  project.Description,
+		DefaultTags:  project.DefaultTags,
+		DefaultExtra: json.RawMessage(project.DefaultExtra),
+		QuotaBytes:   project.QuotaBytes,
+		QuotaObjects: project.QuotaObjects,
+		CreatedBy:    project.CreatedBy,
+		CreatedAt:    project.CreatedAt,
+	}
+}
@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateRetagJobRequest attaches or detaches Tag on every asset matching Filter, the asset listing filters.
+// Sorting is ignored, the job walks the matches by id.
+type CreateRetagJobRequest struct {
+	Tag    string            `json:"tag" binding:"required,min=1,max=100"`
+	Action string            `json:"action" binding:"required,oneof=attach detach"`
+	Filter ListAssetsFilters `json:"filter"`
+}
+
+// RetagJobDetails describes a retag job and its progress
+type RetagJobDetails struct {
+	ID          uint       `json:"id"`
+	Tag         string     `json:"tag"`
+	Action      string     `json:"action"`
+	Status      string     `json:"status"`
+	RequestedBy string     `json:"requested_by,omitempty"`
+	Total       int64      `json:"total"`
+	Processed   int64      `json:"processed"`
+	Changed     int64      `json:"changed"`
+	Detached    int64      `json:"detached"`
+	Progress    float64    `json:"progress"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+type RetagJobResponse struct {
+	dto.Response
+	*RetagJobDetails
+}
+
+func CreateRetagJobHandler(svc *data.Service, ctx *gin.Context) {
+	var request CreateRetagJobRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create retag job", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	opts := ToSearchOptions(&ListAssetsRequest{ListAssetsFilters: request.Filter})
+	job, err := svc.CreateRetagJob(ctx.Request.Context(), request.Tag, registry.RetagAction(request.Action), opts...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create retag job", err)
+		return
+	}
+
+	// Accepted response, the retag worker applies the job in batches
+	response := newRetagJobResponse(ctx, "queued retag job successfully", job)
+	dto.Accepted(ctx, response)
+}
+
+func newRetagJobDetails(job *registry.RetagJob) *RetagJobDetails {
+	details := &RetagJobDetails{
+		ID:          job.ID,
+		Tag:         job.Tag.Name,
+		Action:      string(job.Action),
+		Status:      string(job.Status),
+		RequestedBy: job.RequestedBy,
+		Total:       job.Total,
+		Processed:   job.Processed,
+		Changed:     job.Changed,
+		Detached:    job.Detached,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		FinishedAt:  job.FinishedAt,
+	}
+
+	// matches can grow while the job runs, so progress is capped
+	switch {
+	case job.Status == registry.RetagCompleted:
+		details.Progress = 1
+	case job.Total > 0:
+		details.Progress = min(1, float64(job.Processed)/float64(job.Total))
+	}
+	return details
+}
+
+func newRetagJobResponse(ctx *gin.Context, msg string, job *registry.RetagJob) RetagJobResponse {
+	response := RetagJobResponse{
+		Response:        *dto.NewResponse(ctx, msg),
+		RetagJobDetails: newRetagJobDetails(job),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"job", job.ID,
+		"tag", job.Tag.Name,
+		"action", job.Action,
+		"status", job.Status,
+	)
+	return response
+}
@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type PutContentPolicyRequest struct {
+	AllowMimeTypes  []string `json:"allow_mime_types" binding:"omitempty,max=200,dive,min=1,max=255"`
+	DenyMimeTypes   []string `json:"deny_mime_types" binding:"omitempty,max=200,dive,min=1,max=255"`
+	AllowExtensions []string `json:"allow_extensions" binding:"omitempty,max=200,dive,min=1,max=32"`
+	DenyExtensions  []string `json:"deny_extensions" binding:"omitempty,max=200,dive,min=1,max=32"`
+	MaxSizeBytes    int64    `json:"max_size_bytes" binding:"omitempty,min=0"`
+	AutoTag         bool     `json:"auto_tag"`
+}
+
+func PutContentPolicyHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.ProjectUri
+	var request PutContentPolicyRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to save content policy", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to save content policy", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	policy := &registry.ContentPolicy{
+		Project:         uri.Project,
+		AllowMimeTypes:  request.AllowMimeTypes,
+		DenyMimeTypes:   request.DenyMimeTypes,
+		AllowExtensions: request.AllowExtensions,
+		DenyExtensions:  request.DenyExtensions,
+		MaxSizeBytes:    request.MaxSizeBytes,
+		AutoTag:         request.AutoTag,
+	}
+
+	if err := svc.SaveContentPolicy(ctx.Request.Context(), policy); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to save content policy", err)
+		return
+	}
+
+	// Success response
+	response := newContentPolicyResponse(ctx, "saved content policy successfully", policy)
+	dto.OK(ctx, response)
+}
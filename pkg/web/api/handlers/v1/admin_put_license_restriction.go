@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type PutLicenseRestrictionRequest struct {
+	BlockedRoles []string `json:"blocked_roles" binding:"required,min=1,max=100,dive,min=1,max=100"`
+	Note         string   `json:"note" binding:"omitempty,max=1000"`
+}
+
+type LicenseRestrictionResponse struct {
+	dto.Response
+	*LicenseRestrictionDetails
+}
+
+func PutLicenseRestrictionHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.LicenseUri
+	var request PutLicenseRestrictionRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to save license restriction", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to save license restriction", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	restriction := &registry.LicenseRestriction{
+		License:      uri.License,
+		BlockedRoles: request.BlockedRoles,
+		Note:         request.Note,
+	}
+
+	if err := svc.SaveLicenseRestriction(ctx.Request.Context(), restriction); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to save license restriction", err)
+		return
+	}
+
+	// Success response
+	response := LicenseRestrictionResponse{
+		Response:                  *dto.NewResponse(ctx, "saved license restriction successfully"),
+		LicenseRestrictionDetails: newLicenseRestrictionDetails(restriction),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"license", restriction.License,
+		"blockedRoles", restriction.BlockedRoles,
+	)
+	dto.OK(ctx, response)
+}
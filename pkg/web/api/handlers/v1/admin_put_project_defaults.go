@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// PutProjectDefaultsRequest replaces the defaults of every asset created in a project.
+// String values of extra may use the placeholders of registry.ProjectExtraPlaceholders.
+type PutProjectDefaultsRequest struct {
+	Tags  []string       `json:"tags" binding:"omitempty,max=50,dive,min=1,max=100"`
+	Extra map[string]any `json:"extra" binding:"omitempty,max=100"`
+}
+
+// PutProjectDefaultsHandler sets the tags and extra template applied to new assets of a project
+func PutProjectDefaultsHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.ProjectUri
+	var request PutProjectDefaultsRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set project defaults", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set project defaults", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	project, err := svc.SetProjectDefaults(ctx.Request.Context(), uri.Project, request.Tags, request.Extra)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set project defaults", err)
+		return
+	}
+
+	// Success response
+	response := ProjectResponse{
+		Response:       *dto.NewResponse(ctx, "set project defaults successfully"),
+		ProjectDetails: newProjectDetails(project),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"project", project.Name,
+		"tags", project.DefaultTags,
+	)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// PutProjectQuotaRequest replaces the quota of a project, zero removes a limit
+type PutProjectQuotaRequest struct {
+	Bytes   int64 `json:"bytes" binding:"min=0"`
+	Objects int64 `json:"objects" binding:"min=0"`
+}
+
+// PutProjectQuotaHandler limits the bytes and objects a project stores
+func PutProjectQuotaHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.ProjectUri
+	var request PutProjectQuotaRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set project quota", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set project quota", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	project, err := svc.SetProjectQuota(ctx.Request.Context(), uri.Project, request.Bytes, request.Objects)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set project quota", err)
+		return
+	}
+
+	// Success response
+	response := ProjectResponse{
+		Response:       *dto.NewResponse(ctx, "set project quota successfully"),
+		ProjectDetails: newProjectDetails(project),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"project", project.Name,
+		"bytes", project.QuotaBytes,
+		"objects", project.QuotaObjects,
+	)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type PutRoleAssignmentRequest struct {
+	Role string `json:"role" binding:"required,oneof=reader contributor curator admin"`
+}
+
+type RoleAssignmentResponse struct {
+	dto.Response
+	*RoleAssignmentDetails
+}
+
+// PutRoleAssignmentHandler grants a role to a principal, e.g. key:<name> for api keys
+func PutRoleAssignmentHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.PrincipalUri
+	var request PutRoleAssignmentRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to assign role", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to assign role", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	assignment, err := svc.AssignRole(ctx.Request.Context(), uri.Principal, request.Role)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to assign role", err)
+		return
+	}
+
+	// Success response
+	response := RoleAssignmentResponse{
+		Response:              *dto.NewResponse(ctx, "assigned role successfully"),
+		RoleAssignmentDetails: newRoleAssignmentDetails(assignment),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"principal", assignment.Principal,
+		"role", assignment.Role,
+	)
+	dto.OK(ctx, response)
+}
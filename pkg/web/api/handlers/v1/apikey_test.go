@@ -0,0 +1,53 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/UnivocalX/aether/pkg/web/webtest"
+)
+
+// TestReadKeyScopeFollowsRouteAction checks read keys are scoped by what a route does, not by its method:
+// upload urls are denied although they are fetched with GET, reads sent as POST are allowed
+func TestReadKeyScopeFollowsRouteAction(t *testing.T) {
+	h := webtest.New(t, nil, data.WithRequiredAPIKeys())
+
+	secret, err := h.Engine.CreateAPIKeyRecord(&registry.APIKey{Name: "reader", Scopes: []string{registry.ScopeRead}, Roles: []string{registry.RoleAdmin}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checksum := strings.Repeat("a", 64)
+	exists, err := json.Marshal(v1.AssetsExistRequest{Checksums: []string{checksum}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		method string
+		path   string
+		body   []byte
+		want   int
+	}{
+		{method: http.MethodGet, path: "/assets/" + checksum + "/ingress", want: http.StatusForbidden},
+		{method: http.MethodGet, path: "/batch/assets/ingress?checksums=" + checksum, want: http.StatusForbidden},
+		{method: http.MethodPost, path: "/assets/exists", body: exists, want: http.StatusOK},
+	} {
+		req, err := http.NewRequest(tt.method, h.URL+tt.path, bytes.NewReader(tt.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+secret.Value())
+		req.Header.Set("Content-Type", "application/json")
+
+		if resp := h.Send(req); resp.StatusCode != tt.want {
+			t.Errorf("%s %s: status %d, want %d", tt.method, tt.path, resp.StatusCode, tt.want)
+		}
+	}
+}
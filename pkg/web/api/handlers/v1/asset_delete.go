@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteAssetQuery with purge also removes the stored objects
+type DeleteAssetQuery struct {
+	Purge bool `form:"purge"`
+}
+
+func DeleteAssetHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+	var query DeleteAssetQuery
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to delete asset", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to delete asset", fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err))
+		return
+	}
+
+	_, deletion, err := svc.DeleteAsset(ctx.Request.Context(), uri.AssetChecksum, query.Purge)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to delete asset", err)
+		return
+	}
+
+	// Accepted response, referenced assets wait out the grace period
+	if deletion != nil {
+		response := newAssetDeletionResponse(ctx, "requested asset deletion", deletion)
+		dto.Accepted(ctx, response)
+		return
+	}
+
+	// Success response
+	response := dto.NewResponse(ctx, "deleted asset successfully")
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"assetChecksum", uri.AssetChecksum,
+		"purge", query.Purge,
+	)
+
+	response.NoContent(ctx)
+}
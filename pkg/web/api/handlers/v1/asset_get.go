@@ -14,15 +14,19 @@ import (
 
 type GetAssetResponse struct {
 	dto.Response
-	ID        uint            `json:"id"`
-	Checksum  string          `json:"checksum"`
-	Display   string          `json:"display"`
-	Extra     json.RawMessage `json:"extra,omitempty"`
-	MimeType  string          `json:"mime_type"`
-	SizeBytes int64           `json:"size_bytes"`
-	State     registry.Status `json:"state"`
-	CreatedAt string          `json:"created_at"`
-	UpdatedAt string          `json:"updated_at"`
+	ID             uint                    `json:"id"`
+	Checksum       string                  `json:"checksum"`
+	Display        string                  `json:"display"`
+	Extra          json.RawMessage         `json:"extra,omitempty"`
+	MimeType       string                  `json:"mime_type"`
+	SizeBytes      int64                   `json:"size_bytes"`
+	License        string                  `json:"license,omitempty"`
+	UsageNotes     string                  `json:"usage_notes,omitempty"`
+	Owner          string                  `json:"owner,omitempty"`
+	Classification registry.Classification `json:"classification"`
+	State          registry.Status         `json:"state"`
+	CreatedAt      string                  `json:"created_at"`
+	UpdatedAt      string                  `json:"updated_at"`
 }
 
 func GetAssetHandler(svc *data.Service, ctx *gin.Context) {
@@ -44,28 +48,33 @@ func GetAssetHandler(svc *data.Service, ctx *gin.Context) {
 		return
 	}
 
-	// Success response
-	response := newGetAssetResponse(ctx, asset)
-	response.OK(ctx)
-}
-
-func newGetAssetResponse(ctx *gin.Context, asset *registry.Asset) GetAssetResponse {
-	response := GetAssetResponse{
-		Response:  *dto.NewResponse(ctx, "got asset successfully"),
-		ID:        asset.ID,
-		CreatedAt: asset.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: asset.UpdatedAt.Format(time.RFC3339),
-		Checksum:  asset.Checksum,
-		Display:   asset.Display,
-		Extra:     json.RawMessage(asset.Extra),
-		MimeType:  asset.MimeType,
-		SizeBytes: asset.SizeBytes,
-		State:     asset.State,
+	if dto.NotModified(ctx, fmt.Sprintf("%d-%d", asset.ID, asset.UpdatedAt.UnixNano())) {
+		return
 	}
 
+	// Success response
+	response := newGetAssetResponse(ctx, asset, "got asset successfully")
 	slog.InfoContext(ctx.Request.Context(), response.Msg,
 		"checksum", asset.Checksum,
 	)
+	dto.OK(ctx, response)
+}
 
-	return response
+func newGetAssetResponse(ctx *gin.Context, asset *registry.Asset, msg string) GetAssetResponse {
+	return GetAssetResponse{
+		Response:       *dto.NewResponse(ctx, msg),
+		ID:             asset.ID,
+		CreatedAt:      asset.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      asset.UpdatedAt.Format(time.RFC3339),
+		Checksum:       asset.Checksum,
+		Display:        asset.Display,
+		Extra:          json.RawMessage(asset.Extra),
+		MimeType:       asset.MimeType,
+		SizeBytes:      asset.SizeBytes,
+		License:        asset.License,
+		UsageNotes:     asset.UsageNotes,
+		Owner:          asset.Owner,
+		Classification: asset.Classification,
+		State:          asset.State,
+	}
 }
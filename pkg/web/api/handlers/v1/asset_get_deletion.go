@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type AssetDeletionResponse struct {
+	dto.Response
+	Deletion *AssetDeletionDetails `json:"deletion"`
+}
+
+type AssetDeletionDetails struct {
+	ID          uint       `json:"id"`
+	Checksum    string     `json:"checksum"`
+	Status      string     `json:"status"`
+	Purge       bool       `json:"purge"`
+	RequestedBy string     `json:"requested_by,omitempty"`
+	RequestedAt time.Time  `json:"requested_at"`
+	PurgeAfter  time.Time  `json:"purge_after"`
+	ObjectedBy  string     `json:"objected_by,omitempty"`
+	Objection   string     `json:"objection,omitempty"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+}
+
+func newAssetDeletionDetails(d *registry.AssetDeletion) *AssetDeletionDetails {
+	return &AssetDeletionDetails{
+		ID:          d.ID,
+		Checksum:    d.Asset.Checksum,
+		Status:      string(d.Status),
+		Purge:       d.Purge,
+		RequestedBy: d.RequestedBy,
+		RequestedAt: d.CreatedAt,
+		PurgeAfter:  d.PurgeAfter,
+		ObjectedBy:  d.ObjectedBy,
+		Objection:   d.Objection,
+		ClosedAt:    d.ClosedAt,
+	}
+}
+
+func GetAssetDeletionHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to get asset deletion",
+			fmt.Errorf("%w, %w", dto.ErrInvalidUri, err),
+		)
+		return
+	}
+
+	deletion, err := svc.GetAssetDeletion(ctx.Request.Context(), uri.AssetChecksum)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get asset deletion", err)
+		return
+	}
+
+	// Success response
+	response := newAssetDeletionResponse(ctx, "got asset deletion successfully", deletion)
+	dto.OK(ctx, response)
+}
+
+func newAssetDeletionResponse(ctx *gin.Context, msg string, deletion *registry.AssetDeletion) AssetDeletionResponse {
+	response := AssetDeletionResponse{
+		Response: *dto.NewResponse(ctx, msg),
+		Deletion: newAssetDeletionDetails(deletion),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"checksum", deletion.Asset.Checksum,
+		"status", deletion.Status,
+		"purgeAfter", deletion.PurgeAfter,
+	)
+	return response
+}
@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type AssetDigestsResponse struct {
+	dto.Response
+	Checksum string                `json:"checksum"`
+	Digests  []*AssetDigestDetails `json:"digests"`
+}
+
+type AssetDigestDetails struct {
+	Algorithm  string `json:"algorithm"`
+	Digest     string `json:"digest"`
+	ComputedAt string `json:"computed_at"`
+}
+
+func GetAssetDigestsHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to get asset digests",
+			fmt.Errorf("%w, %w", dto.ErrInvalidUri, err),
+		)
+		return
+	}
+
+	digests, err := svc.GetAssetDigests(ctx.Request.Context(), uri.AssetChecksum)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get asset digests", err)
+		return
+	}
+
+	// Success response
+	response := newAssetDigestsResponse(ctx, uri.AssetChecksum, digests)
+	dto.OK(ctx, response)
+}
+
+func newAssetDigestsResponse(ctx *gin.Context, checksum string, digests []*registry.AssetDigest) AssetDigestsResponse {
+	items := make([]*AssetDigestDetails, len(digests))
+	for i, d := range digests {
+		items[i] = &AssetDigestDetails{
+			Algorithm:  d.Algorithm,
+			Digest:     d.Digest,
+			ComputedAt: d.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	response := AssetDigestsResponse{
+		Response: *dto.NewResponse(ctx, "got asset digests successfully"),
+		Checksum: checksum,
+		Digests:  items,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"checksum", checksum,
+		"total", len(items),
+	)
+	return response
+}
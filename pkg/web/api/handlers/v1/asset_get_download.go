@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// DownloadAssetHandler redirects to a presigned download of a ready asset,
+// clients asking for application/json get the egress response instead.
+func DownloadAssetHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+	var query dto.PresignQuery
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to download asset", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to download asset", fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err))
+		return
+	}
+
+	egressUrl, err := svc.GetAssetEgressUrl(ctx.Request.Context(), uri.AssetChecksum, query.Duration())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to download asset", err)
+		return
+	}
+
+	if ctx.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON {
+		dto.OK(ctx, newAssetEgressResponse(ctx, egressUrl))
+		return
+	}
+
+	// presigned urls expire, the redirect must not be cached past them
+	ctx.Header("Cache-Control", "no-store")
+	slog.InfoContext(ctx.Request.Context(), "redirected asset download", "checksum", egressUrl.Checksum)
+	ctx.Redirect(http.StatusFound, egressUrl.URL.Value())
+}
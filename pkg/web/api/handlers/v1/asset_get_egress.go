@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type AssetEgressResponse struct {
+	dto.Response
+	Checksum    string     `json:"checksum"`
+	DownloadURL string     `json:"download_url,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	ExpiresIn   int64      `json:"expires_in,omitempty"`
+}
+
+func GetAssetEgressHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+	var query dto.PresignQuery
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to get asset egress url",
+			fmt.Errorf("%w, %w", dto.ErrInvalidUri, err),
+		)
+		return
+	}
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to get asset egress url",
+			fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err),
+		)
+		return
+	}
+
+	egressUrl, err := svc.GetAssetEgressUrl(ctx.Request.Context(), uri.AssetChecksum, query.Duration())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get asset egress url", err)
+		return
+	}
+
+	// Success response
+	response := newAssetEgressResponse(ctx, egressUrl)
+	dto.OK(ctx, response)
+}
+
+func newAssetEgressResponse(ctx *gin.Context, presignedUrl *registry.PresignedUrl) AssetEgressResponse {
+	response := AssetEgressResponse{
+		Response:    *dto.NewResponse(ctx, "got asset egress url successfully"),
+		Checksum:    presignedUrl.Checksum,
+		DownloadURL: presignedUrl.URL.Value(),
+		ExpiresAt:   &presignedUrl.ExpiresAt,
+		ExpiresIn:   int64(presignedUrl.ExpiresIn.Seconds()),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"checksum", presignedUrl.Checksum,
+	)
+	return response
+}
@@ -16,10 +16,12 @@ type AssetIngressResponse struct {
 	Checksum  string     `json:"checksum"`
 	UploadURL string     `json:"upload_url,omitempty"`
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ExpiresIn int64      `json:"expires_in,omitempty"`
 }
 
 func GetAssetIngressHandler(svc *data.Service, ctx *gin.Context) {
 	var uri dto.AssetUri
+	var query dto.PresignQuery
 
 	// Bind URI parameters
 	if err := ctx.ShouldBindUri(&uri); err != nil {
@@ -31,8 +33,18 @@ func GetAssetIngressHandler(svc *data.Service, ctx *gin.Context) {
 		return
 	}
 
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to get asset ingress url",
+			fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err),
+		)
+		return
+	}
+
 	// business logic
-	ingressUrl, err := svc.GetAssetIngressUrl(ctx.Request.Context(), uri.AssetChecksum)
+	ingressUrl, err := svc.GetAssetIngressUrl(ctx.Request.Context(), uri.AssetChecksum, query.Duration())
 	if err != nil {
 		dto.HandleErrorResponse(ctx, "failed to get asset ingress url", err)
 		return
@@ -40,7 +52,7 @@ func GetAssetIngressHandler(svc *data.Service, ctx *gin.Context) {
 
 	// Success response
 	response := newAssetIngressResponse(ctx, ingressUrl)
-	response.OK(ctx)
+	dto.OK(ctx, response)
 }
 
 func newAssetIngressResponse(ctx *gin.Context, presignedUrl *registry.PresignedUrl) AssetIngressResponse {
@@ -49,6 +61,7 @@ func newAssetIngressResponse(ctx *gin.Context, presignedUrl *registry.PresignedU
 		Checksum:  presignedUrl.Checksum,
 		UploadURL: presignedUrl.URL.Value(),
 		ExpiresAt: &presignedUrl.ExpiresAt,
+		ExpiresIn: int64(presignedUrl.ExpiresIn.Seconds()),
 	}
 	slog.InfoContext(ctx.Request.Context(), response.Msg,
 		"checksum", presignedUrl.Checksum,
@@ -1,8 +1,12 @@
 package v1
 
 import (
+	"cmp"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/UnivocalX/aether/internal/registry"
 	"github.com/UnivocalX/aether/pkg/web/api/dto"
@@ -12,34 +16,77 @@ import (
 )
 
 type ListAssetsRequest struct {
-	Cursor       uint     `json:"cursor" binding:"omitempty,gte=0"`
-	Limit        uint     `json:"limit" binding:"omitempty,gte=1,lte=1000"`
-	MimeType     string   `json:"mime_type" binding:"omitempty"`
-	State        string   `json:"state" binding:"omitempty,oneof=pending active archived"`
-	IncludedTags []string `json:"included_tags" binding:"omitempty,dive,min=1,max=100"`
-	ExcludedTags []string `json:"excluded_tags" binding:"omitempty,dive,min=1,max=100"`
+	PageToken string `json:"page_token" binding:"omitempty,max=4096"`
+	Limit     uint   `json:"limit" binding:"omitempty,gte=1,lte=1000"`
+	ListAssetsFilters
+}
+
+// ListAssetsFilters is the query state page tokens are bound to
+type ListAssetsFilters struct {
+	MimeType      string         `json:"mime_type" binding:"omitempty"`
+	State         string         `json:"state" binding:"omitempty,oneof=pending active archived"`
+	IncludedTags  []string       `json:"included_tags" binding:"omitempty,dive,min=1,max=100"`
+	ExcludedTags  []string       `json:"excluded_tags" binding:"omitempty,dive,min=1,max=100"`
+	Licenses      []string       `json:"licenses" binding:"omitempty,dive,min=1,max=100"`
+	Owners        []string       `json:"owners" binding:"omitempty,excluded_with=Ownerless,dive,min=1,max=200"`
+	Ownerless     bool           `json:"ownerless"`
+	ExtraEquals   map[string]any `json:"extra_equals" binding:"omitempty,max=20"`
+	ExtraContains map[string]any `json:"extra_contains" binding:"omitempty"`
+	ExtraExists   []string       `json:"extra_exists" binding:"omitempty,max=20,dive,min=1,max=800"`
+	CreatedFrom   *time.Time     `json:"created_from" binding:"omitempty"`
+	CreatedTo     *time.Time     `json:"created_to" binding:"omitempty"`
+	UpdatedAfter  *time.Time     `json:"updated_after" binding:"omitempty"`
+	MinSize       *int64         `json:"min_size" binding:"omitempty,min=0"`
+	MaxSize       *int64         `json:"max_size" binding:"omitempty,min=0"`
+	Sort          string         `json:"sort" binding:"omitempty,oneof=id size created_at updated_at display"`
+	Order         string         `json:"order" binding:"omitempty,oneof=asc desc"`
 }
 
 type ListAssetsResponse struct {
 	dto.Response
-	Total      int             `json:"total"`
-	NextCursor *uint           `json:"next_cursor,omitempty"`
-	Assets     []*AssetDetails `json:"assets"`
+	Total         int             `json:"total"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+	Assets        []*AssetDetails `json:"assets"`
 }
 
 type AssetDetails struct {
-	ID        uint           `json:"id"`
-	Checksum  string         `json:"checksum"`
-	Display   string         `json:"display"`
-	Extra     datatypes.JSON `json:"extra"`
-	MimeType  string         `json:"mime_type"`
-	SizeBytes int64          `json:"size_bytes"`
-	State     string         `json:"state"`
-	Tags      []string       `json:"tags"`
+	ID             uint           `json:"id"`
+	Checksum       string         `json:"checksum"`
+	Display        string         `json:"display"`
+	Extra          datatypes.JSON `json:"extra"`
+	MimeType       string         `json:"mime_type"`
+	SizeBytes      int64          `json:"size_bytes"`
+	License        string         `json:"license,omitempty"`
+	UsageNotes     string         `json:"usage_notes,omitempty"`
+	Owner          string         `json:"owner,omitempty"`
+	Classification string         `json:"classification"`
+	State          string         `json:"state"`
+	Tags           []string       `json:"tags"`
+	Preview        *AssetPreview  `json:"preview,omitempty"`
+}
+
+// AssetPreview is a short lived link to the preview image of an asset, listed with ?expand=previews
+type AssetPreview struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+	MimeType  string    `json:"mime_type"`
 }
 
 func ListAssetsHandler(svc *data.Service, ctx *gin.Context) {
 	var request ListAssetsRequest
+	var query dto.ExpandQuery
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to list assets",
+			fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err),
+		)
+		return
+	}
 
 	// Bind JSON payload
 	if err := ctx.ShouldBindJSON(&request); err != nil {
@@ -51,17 +98,68 @@ func ListAssetsHandler(svc *data.Service, ctx *gin.Context) {
 		return
 	}
 
-	assets, err := svc.ListAssets(ctx.Request.Context(), ToSearchOptions(&request)...)
+	// a page token carries the filters of the listing it continues
+	cursor, err := svc.DecodePageToken(request.PageToken, &request.ListAssetsFilters)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list assets", err)
+		return
+	}
+
+	limit := cmp.Or(request.Limit, registry.SearchDefaultLimit)
+	opts := append(ToSearchOptions(&request), registry.WithCursor(cursor), registry.WithLimit(limit))
+
+	assets, err := svc.ListAssets(ctx.Request.Context(), opts...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list assets", err)
+		return
+	}
+
+	token, err := nextPageToken(svc, len(assets), limit, lastAssetID(assets), request.ListAssetsFilters)
 	if err != nil {
 		dto.HandleErrorResponse(ctx, "failed to list assets", err)
 		return
 	}
 
 	// Success response
-	response := newListAssetsResponse(ctx, assets, request.Limit)
-	response.OK(ctx)
+	response := newListAssetsResponse(ctx, assets, token)
+	if query.Previews() {
+		expandPreviews(ctx, svc, response.Assets, assets, query.Duration())
+	}
+	dto.OK(ctx, response)
+}
+
+// expandPreviews attaches preview links to the listed asset details, aligned with assets
+func expandPreviews(ctx *gin.Context, svc *data.Service, items []*AssetDetails, assets []*registry.Asset, expiresIn time.Duration) {
+	previews := svc.GetAssetPreviewUrls(ctx.Request.Context(), assets, expiresIn)
+	for _, item := range items {
+		if p, ok := previews[item.Checksum]; ok {
+			item.Preview = &AssetPreview{
+				URL:       p.Url.URL.Value(),
+				ExpiresAt: p.Url.ExpiresAt,
+				Width:     p.Preview.Width,
+				Height:    p.Preview.Height,
+				MimeType:  p.Preview.MimeType,
+			}
+		}
+	}
+}
+
+// nextPageToken issues the token of the page after a full one, whose last item is cursor
+func nextPageToken(svc *data.Service, count int, limit uint, cursor uint, state any) (string, error) {
+	if count == 0 || count < int(limit) {
+		return "", nil
+	}
+	return svc.EncodePageToken(cursor, state)
 }
 
+func lastAssetID(assets []*registry.Asset) uint {
+	if len(assets) == 0 {
+		return 0
+	}
+	return assets[len(assets)-1].ID
+}
+
+// ToSearchOptions converts the filters of a request, the handler adds the page cursor and limit
 func ToSearchOptions(req *ListAssetsRequest) []registry.SearchAssetsOption {
 	var opts []registry.SearchAssetsOption
 
@@ -72,48 +170,79 @@ func ToSearchOptions(req *ListAssetsRequest) []registry.SearchAssetsOption {
 		}
 	}
 
-	addIfSet(req.Cursor > 0, registry.WithCursor(req.Cursor))
-	addIfSet(req.Limit > 0, registry.WithLimit(req.Limit))
 	addIfSet(req.MimeType != "", registry.WithMimeType(req.MimeType))
 	addIfSet(req.State != "", registry.WithState(registry.Status(req.State)))
 	addIfSet(len(req.IncludedTags) > 0, registry.WithIncludedTags(req.IncludedTags...))
 	addIfSet(len(req.ExcludedTags) > 0, registry.WithExcludedTags(req.ExcludedTags...))
+	addIfSet(len(req.Licenses) > 0, registry.WithLicense(req.Licenses...))
+	addIfSet(len(req.Owners) > 0, registry.WithOwner(req.Owners...))
+	addIfSet(req.Ownerless, registry.WithoutOwner())
+	addIfSet(len(req.ExtraContains) > 0, registry.WithExtraContains(req.ExtraContains))
+	addIfSet(req.CreatedFrom != nil || req.CreatedTo != nil, registry.WithCreatedBetween(timeOrZero(req.CreatedFrom), timeOrZero(req.CreatedTo)))
+	addIfSet(req.UpdatedAfter != nil, registry.WithUpdatedAfter(timeOrZero(req.UpdatedAfter)))
+	addIfSet(req.MinSize != nil || req.MaxSize != nil, registry.WithSizeRange(sizeOrOpen(req.MinSize), sizeOrOpen(req.MaxSize)))
+	addIfSet(req.Sort != "" || req.Order != "", registry.WithOrderBy(cmp.Or(req.Sort, "id"), req.Order))
+
+	// Extra paths are dotted, e.g. {"camera.model": "x100"}
+	for _, path := range slices.Sorted(maps.Keys(req.ExtraEquals)) {
+		opts = append(opts, registry.WithExtraFilter(path, req.ExtraEquals[path]))
+	}
+	for _, path := range req.ExtraExists {
+		opts = append(opts, registry.WithExtraExists(path))
+	}
 
 	return opts
 }
 
-func newListAssetsResponse(ctx *gin.Context, assets []*registry.Asset, limit uint) ListAssetsResponse {
-	items := make([]*AssetDetails, 0, len(assets))
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
 
-	for _, asset := range assets {
-		tags := make([]string, 0, len(asset.Tags))
-		for _, tag := range asset.Tags {
-			tags = append(tags, tag.Name)
-		}
+// sizeOrOpen maps a missing size bound to the open bound of WithSizeRange
+func sizeOrOpen(size *int64) int64 {
+	if size == nil {
+		return -1
+	}
+	return *size
+}
 
-		items = append(items, &AssetDetails{
-			ID:        asset.ID,
-			Checksum:  asset.Checksum,
-			Display:   asset.Display,
-			Extra:     asset.Extra,
-			MimeType:  asset.MimeType,
-			SizeBytes: asset.SizeBytes,
-			State:     string(asset.State),
-			Tags:      tags,
-		})
+func newAssetDetails(asset *registry.Asset) *AssetDetails {
+	tags := make([]string, 0, len(asset.Tags))
+	for _, tag := range asset.Tags {
+		tags = append(tags, tag.Name)
 	}
 
-	var nextCursor *uint
-	// Only include next_cursor if we got a full page (might be more)
-	if len(assets) == int(limit) && len(assets) > 0 {
-		nextCursor = &assets[len(assets)-1].ID
+	return &AssetDetails{
+		ID:             asset.ID,
+		Checksum:       asset.Checksum,
+		Display:        asset.Display,
+		Extra:          asset.Extra,
+		MimeType:       asset.MimeType,
+		SizeBytes:      asset.SizeBytes,
+		License:        asset.License,
+		UsageNotes:     asset.UsageNotes,
+		Owner:          asset.Owner,
+		Classification: string(asset.Classification),
+		State:          string(asset.State),
+		Tags:           tags,
+	}
+}
+
+func newListAssetsResponse(ctx *gin.Context, assets []*registry.Asset, nextPageToken string) ListAssetsResponse {
+	items := make([]*AssetDetails, 0, len(assets))
+
+	for _, asset := range assets {
+		items = append(items, newAssetDetails(asset))
 	}
 
 	response := ListAssetsResponse{
-		Response:   *dto.NewResponse(ctx, "listed assets successfully"),
-		Total:      len(assets),
-		Assets:     items,
-		NextCursor: nextCursor,
+		Response:      *dto.NewResponse(ctx, "listed assets successfully"),
+		Total:         len(assets),
+		Assets:        items,
+		NextPageToken: nextPageToken,
 	}
 	slog.InfoContext(ctx.Request.Context(), response.Msg,
 		"total", len(assets),
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type AssetReferencesResponse struct {
+	dto.Response
+	Total      int                     `json:"total"`
+	Purgeable  bool                    `json:"purgeable"`
+	References []*AssetReferenceDetail `json:"references"`
+}
+
+type AssetReferenceDetail struct {
+	Kind    string `json:"kind"`
+	Dataset string `json:"dataset,omitempty"`
+	Version int    `json:"version,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
+
+func GetAssetReferencesHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to get asset references",
+			fmt.Errorf("%w, %w", dto.ErrInvalidUri, err),
+		)
+		return
+	}
+
+	refs, err := svc.GetAssetReferences(ctx.Request.Context(), uri.AssetChecksum)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get asset references", err)
+		return
+	}
+
+	// Success response
+	response := newAssetReferencesResponse(ctx, uri.AssetChecksum, refs)
+	dto.OK(ctx, response)
+}
+
+func newAssetReferencesResponse(ctx *gin.Context, checksum string, refs []registry.AssetReference) AssetReferencesResponse {
+	items := make([]*AssetReferenceDetail, len(refs))
+	for i, ref := range refs {
+		items[i] = &AssetReferenceDetail{
+			Kind:    ref.Kind,
+			Dataset: ref.Dataset,
+			Version: ref.Version,
+			Status:  string(ref.Status),
+		}
+	}
+
+	response := AssetReferencesResponse{
+		Response:   *dto.NewResponse(ctx, "got asset references successfully"),
+		Total:      len(items),
+		Purgeable:  len(items) == 0,
+		References: items,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"checksum", checksum,
+		"total", len(items),
+	)
+	return response
+}
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
 
@@ -35,9 +37,22 @@ func ListAssetTagsHandler(svc *data.Service, ctx *gin.Context) {
 		return
 	}
 
+	if dto.NotModified(ctx, tagsVersion(tags)) {
+		return
+	}
+
 	// Success response
 	response := newAssetTagsResponse(ctx, tags)
-	response.OK(ctx)
+	dto.OK(ctx, response)
+}
+
+// tagsVersion fingerprints a tag set, tagging doesn't touch the asset timestamps
+func tagsVersion(tags []*registry.Tag) string {
+	hasher := sha256.New()
+	for _, tag := range tags {
+		fmt.Fprintf(hasher, "%d:%s;", tag.ID, tag.Name)
+	}
+	return hex.EncodeToString(hasher.Sum(nil))[:16]
 }
 
 func newAssetTagsResponse(ctx *gin.Context, tags []*registry.Tag) AssetTagsResponse {
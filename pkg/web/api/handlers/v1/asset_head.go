@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	AssetStateHeader = "X-Asset-State"
+	AssetSizeHeader  = "X-Asset-Size"
+)
+
+// HeadAssetHandler answers whether an asset exists without a body, its state and size are set as headers
+func HeadAssetHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to check asset", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	asset, err := svc.GetAsset(ctx.Request.Context(), uri.AssetChecksum)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to check asset", err)
+		return
+	}
+
+	ctx.Header(AssetStateHeader, string(asset.State))
+	ctx.Header(AssetSizeHeader, strconv.FormatInt(asset.SizeBytes, 10))
+	if dto.NotModified(ctx, fmt.Sprintf("%d-%d", asset.ID, asset.UpdatedAt.UnixNano())) {
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// PatchAssetRequest edits asset metadata, omitted fields are kept.
+// Extra is a JSON merge patch: objects merge key by key and null removes a key.
+type PatchAssetRequest struct {
+	Display  *string        `json:"display" binding:"omitempty,min=1,max=120"`
+	MimeType *string        `json:"mime_type" binding:"omitempty,min=1,max=255"`
+	Owner    *string        `json:"owner" binding:"omitempty,max=200"`
+	Extra    map[string]any `json:"extra"`
+}
+
+func PatchAssetHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+	var request PatchAssetRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to patch asset", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to patch asset", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	patch := registry.AssetPatch{
+		Display:  request.Display,
+		MimeType: request.MimeType,
+		Owner:    request.Owner,
+		Extra:    request.Extra,
+	}
+
+	asset, err := svc.PatchAsset(ctx.Request.Context(), uri.AssetChecksum, patch)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to patch asset", err)
+		return
+	}
+
+	// Success response
+	response := newGetAssetResponse(ctx, asset, "patched asset successfully")
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"checksum", asset.Checksum,
+	)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ObjectAssetDeletionRequest struct {
+	Reason string `json:"reason" binding:"required,min=1,max=1000"`
+}
+
+func ObjectAssetDeletionHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+	var request ObjectAssetDeletionRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to object to asset deletion", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to object to asset deletion", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	deletion, err := svc.ObjectToAssetDeletion(ctx.Request.Context(), uri.AssetChecksum, request.Reason)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to object to asset deletion", err)
+		return
+	}
+
+	// Success response
+	response := newAssetDeletionResponse(ctx, "objected to asset deletion successfully", deletion)
+	dto.OK(ctx, response)
+}
+
+func CancelAssetDeletionHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to cancel asset deletion", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	deletion, err := svc.CancelAssetDeletion(ctx.Request.Context(), uri.AssetChecksum)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to cancel asset deletion", err)
+		return
+	}
+
+	// Success response
+	response := newAssetDeletionResponse(ctx, "cancelled asset deletion successfully", deletion)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type AssetDownloadUrlsRequest struct {
+	Checksums []string `json:"checksums" binding:"required,min=1,max=1000,dive,len=64,hexadecimal"`
+	ExpiresIn uint     `json:"expires_in,omitempty" binding:"omitempty,min=1,max=604800"`
+}
+
+type AssetDownloadUrlsResponse struct {
+	dto.Response
+	Urls   []*AssetDownloadUrl `json:"urls"`
+	Errors []*data.ItemError   `json:"errors,omitempty"`
+}
+
+type AssetDownloadUrl struct {
+	Index       int       `json:"index"`
+	Checksum    string    `json:"checksum"`
+	DownloadURL string    `json:"download_url"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	ExpiresIn   int64     `json:"expires_in"`
+}
+
+func GetAssetDownloadUrlsHandler(svc *data.Service, ctx *gin.Context) {
+	var request AssetDownloadUrlsRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get asset download urls", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	expiresIn := time.Duration(request.ExpiresIn) * time.Second
+	results, failures, err := svc.GetAssetEgressUrls(ctx.Request.Context(), request.Checksums, expiresIn)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get asset download urls", err)
+		return
+	}
+
+	// Success response
+	response := AssetDownloadUrlsResponse{
+		Response: *dto.NewResponse(ctx, "got asset download urls successfully"),
+		Urls:     make([]*AssetDownloadUrl, len(results)),
+		Errors:   failures,
+	}
+	for i, r := range results {
+		response.Urls[i] = &AssetDownloadUrl{
+			Index:       r.Index,
+			Checksum:    r.Url.Checksum,
+			DownloadURL: r.Url.URL.Value(),
+			ExpiresAt:   r.Url.ExpiresAt,
+			ExpiresIn:   int64(r.Url.ExpiresIn.Seconds()),
+		}
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", len(request.Checksums),
+		"granted", len(response.Urls),
+		"failed", len(failures),
+	)
+
+	if len(failures) > 0 {
+		dto.MultiStatus(ctx, response)
+		return
+	}
+	dto.OK(ctx, response)
+}
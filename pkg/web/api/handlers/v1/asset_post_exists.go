@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type AssetsExistRequest struct {
+	Checksums []string `json:"checksums" binding:"required,min=1,max=1000,dive,len=64,hexadecimal"`
+}
+
+type AssetsExistResponse struct {
+	dto.Response
+	Found   []AssetExistence `json:"found"`
+	Missing []string         `json:"missing"`
+}
+
+type AssetExistence struct {
+	Checksum  string          `json:"checksum"`
+	State     registry.Status `json:"state"`
+	SizeBytes int64           `json:"size_bytes"`
+}
+
+func AssetsExistHandler(svc *data.Service, ctx *gin.Context) {
+	var request AssetsExistRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to check assets", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	assets, missing, err := svc.AssetsExist(ctx.Request.Context(), request.Checksums)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to check assets", err)
+		return
+	}
+
+	// Success response
+	response := AssetsExistResponse{
+		Response: *dto.NewResponse(ctx, "checked assets successfully"),
+		Found:    make([]AssetExistence, len(assets)),
+		Missing:  missing,
+	}
+	if response.Missing == nil {
+		response.Missing = []string{}
+	}
+	for i, asset := range assets {
+		response.Found[i] = AssetExistence{
+			Checksum:  asset.Checksum,
+			State:     asset.State,
+			SizeBytes: asset.SizeBytes,
+		}
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", len(request.Checksums),
+		"found", len(response.Found),
+		"missing", len(response.Missing),
+	)
+	dto.OK(ctx, response)
+}
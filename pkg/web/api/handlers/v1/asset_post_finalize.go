@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type FinalizeAssetResponse struct {
+	dto.Response
+	ID        uint            `json:"id"`
+	Checksum  string          `json:"checksum"`
+	SizeBytes int64           `json:"size_bytes"`
+	State     registry.Status `json:"state"`
+}
+
+func FinalizeAssetHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to finalize asset",
+			fmt.Errorf("%w, %w", dto.ErrInvalidUri, err),
+		)
+		return
+	}
+
+	asset, err := svc.FinalizeAsset(ctx.Request.Context(), uri.AssetChecksum)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to finalize asset", err)
+		return
+	}
+
+	// Success response
+	response := newFinalizeAssetResponse(ctx, asset)
+	dto.OK(ctx, response)
+}
+
+func newFinalizeAssetResponse(ctx *gin.Context, asset *registry.Asset) FinalizeAssetResponse {
+	response := FinalizeAssetResponse{
+		Response:  *dto.NewResponse(ctx, "finalized asset successfully"),
+		ID:        asset.ID,
+		Checksum:  asset.Checksum,
+		SizeBytes: asset.SizeBytes,
+		State:     asset.State,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"checksum", asset.Checksum,
+		"size", asset.SizeBytes,
+	)
+	return response
+}
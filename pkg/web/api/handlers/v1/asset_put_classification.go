@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ClassifyAssetRequest struct {
+	Classification string `json:"classification" binding:"required,oneof=public internal confidential pii"`
+}
+
+type ClassifyAssetResponse struct {
+	dto.Response
+	Checksum       string                  `json:"checksum"`
+	Classification registry.Classification `json:"classification"`
+	ClassifiedBy   string                  `json:"classified_by"`
+}
+
+func ClassifyAssetHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+	var request ClassifyAssetRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to classify asset", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to classify asset", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	asset, err := svc.ClassifyAsset(ctx.Request.Context(), uri.AssetChecksum, registry.Classification(request.Classification))
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to classify asset", err)
+		return
+	}
+
+	// Success response
+	response := ClassifyAssetResponse{
+		Response:       *dto.NewResponse(ctx, "classified asset successfully"),
+		Checksum:       asset.Checksum,
+		Classification: asset.Classification,
+		ClassifiedBy:   asset.ClassifiedBy,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"checksum", asset.Checksum,
+		"classification", asset.Classification,
+	)
+	dto.OK(ctx, response)
+}
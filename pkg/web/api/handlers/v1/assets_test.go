@@ -0,0 +1,51 @@
+package v1_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+	"github.com/UnivocalX/aether/pkg/web/webtest"
+)
+
+// TestAssetLifecycle creates an asset, uploads its content, finalizes it and downloads it back through the api
+func TestAssetLifecycle(t *testing.T) {
+	h := webtest.New(t, nil)
+
+	content := []byte("the quick brown fox jumps over the lazy dog")
+	checksum := h.CreateAsset(content, "fox.txt")
+
+	var asset v1.GetAssetResponse
+	h.DoJSON(http.MethodGet, "/assets/"+checksum, nil, http.StatusOK, &asset)
+	if asset.State != registry.StatusReady || asset.SizeBytes != int64(len(content)) {
+		t.Errorf("asset is %s with %d bytes, want ready with %d", asset.State, asset.SizeBytes, len(content))
+	}
+
+	if got := h.Download(checksum); !bytes.Equal(got, content) {
+		t.Errorf("downloaded %q, want %q", got, content)
+	}
+
+	// finalize is idempotent, a retry after a lost response succeeds
+	h.DoJSON(http.MethodPost, "/assets/"+checksum+"/finalize", nil, http.StatusOK, nil)
+}
+
+// TestExpiresInOutOfRange checks expiries too long to be durations are rejected instead of wrapping around
+func TestExpiresInOutOfRange(t *testing.T) {
+	h := webtest.New(t, nil)
+
+	checksum := h.CreateAsset([]byte("expiring"), "expiring.txt")
+	const expiresIn = 1 << 62
+
+	h.DoJSON(http.MethodPost, "/batch/assets", v1.CreateAssetsBatchRequest{
+		Assets:    []v1.AssetPayload{{Checksum: checksum, Display: "expiring.txt"}},
+		ExpiresIn: expiresIn,
+	}, http.StatusBadRequest, nil)
+	h.DoJSON(http.MethodPost, "/assets/download-urls", v1.AssetDownloadUrlsRequest{
+		Checksums: []string{checksum},
+		ExpiresIn: expiresIn,
+	}, http.StatusBadRequest, nil)
+	h.DoJSON(http.MethodGet, fmt.Sprintf("/assets/%s/egress?expires_in=%d", checksum, uint(expiresIn)), nil, http.StatusBadRequest, nil)
+}
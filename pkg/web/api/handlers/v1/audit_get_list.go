@@ -0,0 +1,157 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListAuditQuery struct {
+	PageToken string `form:"page_token" binding:"omitempty,max=4096"`
+	Limit     uint   `form:"limit" binding:"omitempty,gte=1,lte=5000"`
+	ListAuditFilters
+}
+
+// ListAuditFilters is the query state page tokens are bound to
+type ListAuditFilters struct {
+	Actor     string    `form:"actor" binding:"omitempty,max=200"`
+	Project   string    `form:"project" binding:"omitempty,max=63"`
+	RequestID string    `form:"request_id" binding:"omitempty,max=100"`
+	Action    string    `form:"action" binding:"omitempty,oneof=create update delete"`
+	Entity    string    `form:"entity" binding:"omitempty,max=100"`
+	EntityID  string    `form:"entity_id" binding:"omitempty,max=100"`
+	Since     time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until     time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type ListAuditResponse struct {
+	dto.Response
+	Total         int                  `json:"total"`
+	NextPageToken string               `json:"next_page_token,omitempty"`
+	Entries       []*AuditEntryDetails `json:"entries"`
+}
+
+type AuditEntryDetails struct {
+	ID        uint            `json:"id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Actor     string          `json:"actor"`
+	Project   string          `json:"project,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
+	Action    string          `json:"action"`
+	Entity    string          `json:"entity"`
+	EntityID  string          `json:"entity_id"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+}
+
+func ListAuditHandler(svc *data.Service, ctx *gin.Context) {
+	var query ListAuditQuery
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to list audit log",
+			fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err),
+		)
+		return
+	}
+
+	if query.EntityID != "" && query.Entity == "" {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to list audit log",
+			fmt.Errorf("%w, entity_id needs an entity", dto.ErrInvalidQuery),
+		)
+		return
+	}
+
+	if !query.Since.IsZero() && !query.Until.IsZero() && !query.Since.Before(query.Until) {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to list audit log",
+			fmt.Errorf("%w, since must be before until", dto.ErrInvalidQuery),
+		)
+		return
+	}
+
+	cursor, err := svc.DecodePageToken(query.PageToken, &query.ListAuditFilters)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list audit log", err)
+		return
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = registry.AuditDefaultLimit
+	}
+
+	opts := []registry.AuditOption{
+		registry.WithAuditLimit(limit),
+		registry.WithAuditActorFilter(query.Actor),
+		registry.WithAuditProject(query.Project),
+		registry.WithAuditRequestID(query.RequestID),
+		registry.WithAuditAction(query.Action),
+		registry.WithAuditEntity(query.Entity, query.EntityID),
+		registry.WithAuditSince(query.Since),
+		registry.WithAuditUntil(query.Until),
+	}
+	if cursor > 0 {
+		opts = append(opts, registry.WithAuditCursor(cursor))
+	}
+
+	entries, err := svc.ListAuditEntries(ctx.Request.Context(), opts...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list audit log", err)
+		return
+	}
+
+	var last uint
+	if len(entries) > 0 {
+		last = entries[len(entries)-1].ID
+	}
+	token, err := nextPageToken(svc, len(entries), limit, last, query.ListAuditFilters)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list audit log", err)
+		return
+	}
+
+	// Success response
+	response := newListAuditResponse(ctx, entries, token)
+	dto.OK(ctx, response)
+}
+
+func newListAuditResponse(ctx *gin.Context, entries []*registry.AuditEntry, nextPageToken string) ListAuditResponse {
+	items := make([]*AuditEntryDetails, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, &AuditEntryDetails{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+			Actor:     e.Actor,
+			Project:   e.Project,
+			RequestID: e.RequestID,
+			Action:    e.Action,
+			Entity:    e.Entity,
+			EntityID:  e.EntityID,
+			Before:    json.RawMessage(e.Before),
+			After:     json.RawMessage(e.After),
+		})
+	}
+
+	response := ListAuditResponse{
+		Response:      *dto.NewResponse(ctx, "listed audit log successfully"),
+		Total:         len(entries),
+		NextPageToken: nextPageToken,
+		Entries:       items,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", len(entries),
+	)
+	return response
+}
@@ -1,37 +1,58 @@
 package v1
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/UnivocalX/aether/internal/registry"
 	"github.com/UnivocalX/aether/pkg/web/api/dto"
 	"github.com/UnivocalX/aether/pkg/web/services/data"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 )
 
+// Items are validated one by one so a single bad item doesn't fail the batch
 type CreateAssetsBatchRequest struct {
-	Assets []AssetPayload `json:"assets" binding:"required,max=1000,dive"`
+	Assets    []AssetPayload `json:"assets" binding:"required,max=1000"`
+	ExpiresIn uint           `json:"expires_in,omitempty" binding:"omitempty,min=1,max=604800"`
 }
 
 type AssetPayload struct {
-	Checksum string         `json:"checksum" binding:"required,len=64,hexadecimal"`
-	Display  string         `json:"display" binding:"omitempty,max=120"`
-	Extra    map[string]any `json:"extra" binding:"omitempty"`
+	Checksum       string         `json:"checksum" binding:"required,len=64,hexadecimal"`
+	Display        string         `json:"display" binding:"omitempty,max=120"`
+	MimeType       string         `json:"mime_type" binding:"omitempty,max=255"`
+	SizeBytes      int64          `json:"size_bytes" binding:"omitempty,min=0"`
+	License        string         `json:"license" binding:"omitempty,max=100"`
+	UsageNotes     string         `json:"usage_notes" binding:"omitempty,max=2000"`
+	Owner          string         `json:"owner" binding:"omitempty,max=200"`
+	Classification string         `json:"classification" binding:"omitempty,oneof=public internal confidential pii"`
+	Extra          map[string]any `json:"extra" binding:"omitempty"`
 }
 
 type AssetsBatchResponse struct {
 	dto.Response
-	Assets []*BatchAssetDetails
+	Total  int                  `json:"total"`
+	Failed int                  `json:"failed"`
+	Assets []*BatchAssetDetails `json:"assets"`
+	Errors []*data.ItemError    `json:"errors,omitempty"`
 }
 
 type BatchAssetDetails struct {
+	Index      int             `json:"index"`
 	ID         uint            `json:"id"`
 	Checksum   string          `json:"checksum"`
 	State      string          `json:"state"`
 	IngressUrl registry.Secret `json:"ingress_url,omitempty"`
 	ExpiresAt  *time.Time      `json:"expires_at,omitempty"`
+	ExpiresIn  int64           `json:"expires_in,omitempty"`
+
+	// tags proposed from the asset display name, applied when the project opts in
+	SuggestedTags []string `json:"suggested_tags,omitempty"`
+	TagsApplied   bool     `json:"tags_applied,omitempty"`
 }
 
 func CreateAssetsBatchHandler(svc *data.Service, ctx *gin.Context) {
@@ -47,80 +68,143 @@ func CreateAssetsBatchHandler(svc *data.Service, ctx *gin.Context) {
 		return
 	}
 
-	// Convert request to records
-	assets, err := assetsBatchRequest2Records(&request)
-	if err != nil {
-		dto.HandleErrorResponse(
-			ctx,
-			"failed to execute batch",
-			fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err),
-		)
-		return
-	}
+	// Convert request to records, invalid items are reported individually
+	assets, indices, failures := AssetsBatchRecords(&request)
 
-	ingressUrls, err := svc.CreateAssets(ctx.Request.Context(), assets...)
+	expiresIn := time.Duration(request.ExpiresIn) * time.Second
+	results, itemErrors, err := svc.CreateAssets(ctx.Request.Context(), expiresIn, assets...)
 	if err != nil {
 		dto.HandleErrorResponse(ctx, "failed to execute batch", err)
 		return
 	}
 
+	// Map service indexes back to request indexes
+	for _, r := range results {
+		r.Index = indices[r.Index]
+	}
+	for _, e := range itemErrors {
+		e.Index = indices[e.Index]
+	}
+	failures = append(failures, itemErrors...)
+
 	// Success response
-	response := newAssetsBatchResponse(ctx, assets, ingressUrls)
-	response.Created(ctx)
+	response := newAssetsBatchResponse(ctx, results, failures)
+	if len(failures) > 0 {
+		dto.MultiStatus(ctx, response)
+		return
+	}
+	dto.Created(ctx, response)
 }
 
-func assetsBatchRequest2Records(payload *CreateAssetsBatchRequest) ([]*registry.Asset, error) {
-	records := make([]*registry.Asset, len(payload.Assets))
+// AssetsBatchRecords converts valid items to records, returning
+// each record's request index and the errors of the invalid items
+func AssetsBatchRecords(payload *CreateAssetsBatchRequest) ([]*registry.Asset, []int, []*data.ItemError) {
+	records := make([]*registry.Asset, 0, len(payload.Assets))
+	indices := make([]int, 0, len(payload.Assets))
+	var failures []*data.ItemError
 
 	for i, asset := range payload.Assets {
-		record := &registry.Asset{
-			Checksum: asset.Checksum,
-			Display:  asset.Display,
+		if err := binding.Validator.ValidateStruct(&asset); err != nil {
+			failures = append(failures, newValidationItemError(i, asset.Checksum, err))
+			continue
+		}
+
+		// checked here too, the record hooks would fail the whole batch
+		if err := registry.ValidateOwner(strings.TrimSpace(asset.Owner)); err != nil {
+			itemErr := data.NewItemError(i, asset.Checksum, data.CodeValidation, err)
+			itemErr.Field = "owner"
+			failures = append(failures, itemErr)
+			continue
 		}
 
-		if len(asset.Extra) > 0 {
-			if err := record.SetExtra(asset.Extra); err != nil {
-				return nil, err
-			}
+		record, err := assetPayload2Record(&asset)
+		if err != nil {
+			itemErr := data.NewItemError(i, asset.Checksum, data.CodeValidation, err)
+			itemErr.Field = "extra"
+			failures = append(failures, itemErr)
+			continue
 		}
 
-		records[i] = record
+		records = append(records, record)
+		indices = append(indices, i)
 	}
 
-	return records, nil
+	return records, indices, failures
+}
+
+// assetPayload2Record converts a validated asset payload to a record, only its extra can still be rejected
+func assetPayload2Record(asset *AssetPayload) (*registry.Asset, error) {
+	record := &registry.Asset{
+		Checksum:       asset.Checksum,
+		Display:        asset.Display,
+		MimeType:       asset.MimeType,
+		SizeBytes:      asset.SizeBytes,
+		License:        asset.License,
+		UsageNotes:     asset.UsageNotes,
+		Owner:          asset.Owner,
+		Classification: registry.Classification(asset.Classification),
+	}
+
+	if len(asset.Extra) > 0 {
+		if err := record.SetExtra(asset.Extra); err != nil {
+			return nil, err
+		}
+	}
+	return record, nil
+}
+
+// newValidationItemError reports the first offending field of an invalid item
+func newValidationItemError(index int, checksum string, err error) *data.ItemError {
+	itemErr := data.NewItemError(index, checksum, data.CodeValidation, err)
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		fieldErr := validationErrors[0]
+		itemErr.Field = strings.ToLower(fieldErr.Field())
+		itemErr.Message = fmt.Sprintf("%s failed on the %q rule", itemErr.Field, fieldErr.Tag())
+	}
+
+	return itemErr
 }
 
 func newAssetsBatchResponse(
 	ctx *gin.Context,
-	assets []*registry.Asset,
-	urls []*registry.PresignedUrl,
+	results []*data.CreateAssetResult,
+	failures []*data.ItemError,
 ) AssetsBatchResponse {
 
-	// Build lookup map: checksum → presigned URL
-	urlMap := make(map[string]*registry.PresignedUrl, len(urls))
-	for _, u := range urls {
-		urlMap[u.Checksum] = u
-	}
-
 	// Build response assets
-	batchAssets := make([]*BatchAssetDetails, len(assets))
-	for i, a := range assets {
-		uploadURL := urlMap[a.Checksum] // may be nil if no URL exists
+	batchAssets := make([]*BatchAssetDetails, len(results))
+	for i, r := range results {
 		batchAssets[i] = &BatchAssetDetails{
-			ID:         a.ID,
-			Checksum:   a.Checksum,
-			State:      string(a.State),
-			IngressUrl: uploadURL.URL,
-			ExpiresAt:  &uploadURL.ExpiresAt,
+			Index:      r.Index,
+			ID:         r.Asset.ID,
+			Checksum:   r.Asset.Checksum,
+			State:      string(r.Asset.State),
+			IngressUrl: r.Url.URL,
+			ExpiresAt:  &r.Url.ExpiresAt,
+			ExpiresIn:  int64(r.Url.ExpiresIn.Seconds()),
+
+			SuggestedTags: r.SuggestedTags,
+			TagsApplied:   r.TagsApplied,
 		}
 	}
 
+	msg := "successfully executed batch"
+	if len(failures) > 0 {
+		msg = fmt.Sprintf("executed batch with %d failed item(s)", len(failures))
+	}
+
 	response := AssetsBatchResponse{
-		Response: *dto.NewResponse(ctx, "successfully executed batch"),
+		Response: *dto.NewResponse(ctx, msg),
+		Total:    len(results) + len(failures),
+		Failed:   len(failures),
 		Assets:   batchAssets,
+		Errors:   failures,
 	}
 	slog.InfoContext(ctx.Request.Context(), response.Msg,
-		"total", len(assets),
+		"total", response.Total,
+		"failed", response.Failed,
 	)
 	return response
 }
@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type DatasetChannelResponse struct {
+	dto.Response
+	Dataset   string    `json:"dataset"`
+	Channel   string    `json:"channel"`
+	Version   int       `json:"version"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func GetDatasetChannelHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetChannelUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get dataset channel", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	channel, err := svc.GetDatasetChannel(ctx.Request.Context(), uri.DatasetName, uri.Channel)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get dataset channel", err)
+		return
+	}
+
+	// Success response
+	response := newDatasetChannelResponse(ctx, "got dataset channel successfully", channel)
+	dto.OK(ctx, response)
+}
+
+func newDatasetChannelResponse(ctx *gin.Context, msg string, channel *registry.DatasetChannel) DatasetChannelResponse {
+	response := DatasetChannelResponse{
+		Response:  *dto.NewResponse(ctx, msg),
+		Dataset:   channel.DatasetVersion.Dataset.Name,
+		Channel:   channel.Name,
+		Version:   channel.DatasetVersion.Number,
+		UpdatedBy: channel.UpdatedBy,
+		UpdatedAt: channel.UpdatedAt,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"dataset", response.Dataset,
+		"channel", response.Channel,
+		"version", response.Version,
+	)
+	return response
+}
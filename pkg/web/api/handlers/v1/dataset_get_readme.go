@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/markdown"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+const MIMEMarkdown = "text/markdown"
+
+// readmePage wraps a rendered readme for browsers, the markup is escaped by the renderer
+const readmePage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>body{max-width:50rem;margin:2rem auto;padding:0 1rem;font-family:sans-serif;line-height:1.5}pre{background:#f4f4f4;padding:1rem;overflow-x:auto}blockquote{color:#555;border-left:3px solid #ddd;margin-left:0;padding-left:1rem}</style>
+</head>
+<body>
+%s</body>
+</html>
+`
+
+type DatasetReadmeResponse struct {
+	dto.Response
+	Dataset   string    `json:"dataset"`
+	Readme    string    `json:"readme"`
+	HTML      string    `json:"html"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetDatasetReadmeHandler returns a dataset readme as JSON with its rendered HTML,
+// browsers get the rendered page and text/markdown clients the source.
+func GetDatasetReadmeHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get dataset readme", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	ds, err := svc.GetDatasetReadme(ctx.Request.Context(), uri.DatasetName)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get dataset readme", err)
+		return
+	}
+
+	format := ctx.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML, MIMEMarkdown)
+	ctx.Header("Vary", "Accept")
+	if dto.NotModified(ctx, fmt.Sprintf("%d-%d-%s", ds.ID, ds.UpdatedAt.UnixNano(), format)) {
+		return
+	}
+
+	switch format {
+	case gin.MIMEHTML:
+		ctx.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; img-src https:")
+		page := fmt.Sprintf(readmePage, html.EscapeString(ds.Name), markdown.Render(ds.Readme))
+		slog.InfoContext(ctx.Request.Context(), "rendered dataset readme", "dataset", ds.Name)
+		ctx.Data(http.StatusOK, gin.MIMEHTML+"; charset=utf-8", []byte(page))
+
+	case MIMEMarkdown:
+		slog.InfoContext(ctx.Request.Context(), "got dataset readme successfully", "dataset", ds.Name)
+		ctx.Data(http.StatusOK, MIMEMarkdown+"; charset=utf-8", []byte(ds.Readme))
+
+	default:
+		dto.OK(ctx, newDatasetReadmeResponse(ctx, "got dataset readme successfully", ds))
+	}
+}
+
+func newDatasetReadmeResponse(ctx *gin.Context, msg string, ds *registry.Dataset) DatasetReadmeResponse {
+	response := DatasetReadmeResponse{
+		Response:  *dto.NewResponse(ctx, msg),
+		Dataset:   ds.Name,
+		Readme:    ds.Readme,
+		HTML:      markdown.Render(ds.Readme),
+		UpdatedAt: ds.UpdatedAt,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"dataset", ds.Name,
+		"bytes", len(ds.Readme),
+	)
+	return response
+}
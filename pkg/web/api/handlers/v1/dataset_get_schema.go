@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// GetDatasetSchemaQuery selects a dictionary revision, the current one when unset
+type GetDatasetSchemaQuery struct {
+	Revision int `form:"revision" binding:"omitempty,min=1"`
+}
+
+type DatasetSchemaResponse struct {
+	dto.Response
+	DatasetSchemaDetails
+}
+
+type DatasetSchemaDetails struct {
+	Dataset     string                  `json:"dataset"`
+	Revision    int                     `json:"revision"`
+	Description string                  `json:"description"`
+	Columns     []registry.SchemaColumn `json:"columns"`
+	CreatedBy   string                  `json:"created_by"`
+	CreatedAt   time.Time               `json:"created_at"`
+}
+
+func GetDatasetSchemaHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetUri
+	var query GetDatasetSchemaQuery
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get dataset schema", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get dataset schema", fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err))
+		return
+	}
+
+	schema, err := svc.GetDatasetSchema(ctx.Request.Context(), uri.DatasetName, query.Revision)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get dataset schema", err)
+		return
+	}
+
+	if dto.NotModified(ctx, fmt.Sprintf("%d-%d", schema.DatasetID, schema.Revision)) {
+		return
+	}
+
+	// Success response
+	response := newDatasetSchemaResponse(ctx, "got dataset schema successfully", uri.DatasetName, schema)
+	dto.OK(ctx, response)
+}
+
+func newDatasetSchemaDetails(dataset string, schema *registry.DatasetSchema) DatasetSchemaDetails {
+	return DatasetSchemaDetails{
+		Dataset:     dataset,
+		Revision:    schema.Revision,
+		Description: schema.Description,
+		Columns:     schema.Columns,
+		CreatedBy:   schema.CreatedBy,
+		CreatedAt:   schema.CreatedAt,
+	}
+}
+
+func newDatasetSchemaResponse(ctx *gin.Context, msg string, dataset string, schema *registry.DatasetSchema) DatasetSchemaResponse {
+	response := DatasetSchemaResponse{
+		Response:             *dto.NewResponse(ctx, msg),
+		DatasetSchemaDetails: newDatasetSchemaDetails(dataset, schema),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"dataset", dataset,
+		"revision", schema.Revision,
+	)
+	return response
+}
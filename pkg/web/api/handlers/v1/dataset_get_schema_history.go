@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type DatasetSchemaHistoryResponse struct {
+	dto.Response
+	Dataset   string                 `json:"dataset"`
+	Revisions []DatasetSchemaDetails `json:"revisions"`
+}
+
+func ListDatasetSchemaHistoryHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list dataset schema history", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	schemas, err := svc.ListDatasetSchemas(ctx.Request.Context(), uri.DatasetName)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list dataset schema history", err)
+		return
+	}
+
+	// Success response
+	response := newDatasetSchemaHistoryResponse(ctx, uri.DatasetName, schemas)
+	dto.OK(ctx, response)
+}
+
+func newDatasetSchemaHistoryResponse(ctx *gin.Context, dataset string, schemas []*registry.DatasetSchema) DatasetSchemaHistoryResponse {
+	response := DatasetSchemaHistoryResponse{
+		Response:  *dto.NewResponse(ctx, "listed dataset schema history successfully"),
+		Dataset:   dataset,
+		Revisions: make([]DatasetSchemaDetails, len(schemas)),
+	}
+	for i, schema := range schemas {
+		response.Revisions[i] = newDatasetSchemaDetails(dataset, schema)
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"dataset", dataset,
+		"total", len(schemas),
+	)
+	return response
+}
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListDatasetVersionAssetsRequest struct {
+	PageToken string `json:"page_token" binding:"omitempty,max=4096"`
+	Limit     uint   `json:"limit" binding:"omitempty,gte=1,lte=1000"`
+}
+
+func ListDatasetVersionAssetsHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetVersionUri
+	var request ListDatasetVersionAssetsRequest
+	var query dto.ExpandQuery
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list dataset version assets", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list dataset version assets", fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list dataset version assets", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	// tokens are bound to the dataset version they page through
+	cursor, err := svc.DecodePageToken(request.PageToken, &uri)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list dataset version assets", err)
+		return
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = registry.SearchDefaultLimit
+	}
+
+	assets, err := svc.ListDatasetVersionAssets(ctx.Request.Context(), uri.DatasetName, uri.Version, cursor, limit)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list dataset version assets", err)
+		return
+	}
+
+	token, err := nextPageToken(svc, len(assets), limit, lastAssetID(assets), uri)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list dataset version assets", err)
+		return
+	}
+
+	// Success response
+	response := newListAssetsResponse(ctx, assets, token)
+	response.Msg = "listed dataset version assets successfully"
+	if query.Previews() {
+		expandPreviews(ctx, svc, response.Assets, assets, query.Duration())
+	}
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// DatasetVersionManifestQuery narrows the manifest to a slice of the version, e.g. ?tag=train&mime_type=image/*
+type DatasetVersionManifestQuery struct {
+	Tags      []string `form:"tag" binding:"omitempty,max=20,dive,min=1,max=100"`
+	MimeTypes []string `form:"mime_type" binding:"omitempty,max=20,dive,min=1,max=255"`
+	Prefix    string   `form:"prefix" binding:"omitempty,max=120"`
+}
+
+type DatasetVersionManifestResponse struct {
+	dto.Response
+	registry.VersionManifest
+}
+
+func GetDatasetVersionManifestHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetVersionUri
+	var query DatasetVersionManifestQuery
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get dataset version manifest", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get dataset version manifest", fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err))
+		return
+	}
+
+	filter := &registry.ManifestFilter{Tags: query.Tags, MimeTypes: query.MimeTypes, Prefix: query.Prefix}
+	manifest, err := svc.GetDatasetVersionManifest(ctx.Request.Context(), uri.DatasetName, uri.Version, filter)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get dataset version manifest", err)
+		return
+	}
+
+	// Success response
+	response := DatasetVersionManifestResponse{
+		Response:        *dto.NewResponse(ctx, "got dataset version manifest successfully"),
+		VersionManifest: *manifest,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"dataset", manifest.Dataset,
+		"version", manifest.Version,
+		"count", manifest.Count,
+		"partial", manifest.Partial,
+	)
+	dto.OK(ctx, response)
+}
@@ -13,6 +13,9 @@ import (
 type CreateDatasetRequest struct {
 	Name        string `json:"name" binding:"required,max=100"`
 	Description string `json:"description" binding:"omitempty,max=1000"`
+	License     string `json:"license" binding:"omitempty,max=100"`
+	UsageNotes  string `json:"usage_notes" binding:"omitempty,max=2000"`
+	Owner       string `json:"owner" binding:"omitempty,max=200"`
 }
 
 type CreateDatasetResponse struct {
@@ -20,6 +23,9 @@ type CreateDatasetResponse struct {
 	ID          uint   `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	License     string `json:"license"`
+	UsageNotes  string `json:"usage_notes"`
+	Owner       string `json:"owner,omitempty"`
 }
 
 func CreateDatasetHandler(svc *data.Service, ctx *gin.Context) {
@@ -35,14 +41,22 @@ func CreateDatasetHandler(svc *data.Service, ctx *gin.Context) {
 		return
 	}
 
-	dsv, err := svc.CreateDataset(ctx.Request.Context(), payload.Name, payload.Description)
+	ds := &registry.Dataset{
+		Name:        payload.Name,
+		Description: payload.Description,
+		License:     payload.License,
+		UsageNotes:  payload.UsageNotes,
+		Owner:       payload.Owner,
+	}
+
+	dsv, err := svc.CreateDataset(ctx.Request.Context(), ds)
 	if err != nil {
 		dto.HandleErrorResponse(ctx, "failed to create dataset", err)
 		return
 	}
 
 	response := newCreateDatasetResponse(ctx, dsv)
-	response.Created(ctx)
+	dto.Created(ctx, response)
 }
 
 func newCreateDatasetResponse(ctx *gin.Context, dsv *registry.DatasetVersion) CreateDatasetResponse {
@@ -51,6 +65,9 @@ func newCreateDatasetResponse(ctx *gin.Context, dsv *registry.DatasetVersion) Cr
 		ID:          dsv.DatasetID,
 		Name:        dsv.Dataset.Name,
 		Description: dsv.Dataset.Description,
+		License:     dsv.Dataset.License,
+		UsageNotes:  dsv.Dataset.UsageNotes,
+		Owner:       dsv.Dataset.Owner,
 	}
 	slog.InfoContext(ctx.Request.Context(), response.Msg,
 		"dataset", dsv,
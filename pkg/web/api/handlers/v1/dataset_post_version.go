@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type CreateDatasetVersionRequest struct {
+	Description string `json:"description" binding:"omitempty,max=1000"`
+}
+
+type DatasetVersionResponse struct {
+	dto.Response
+	Dataset     string                 `json:"dataset"`
+	Version     int                    `json:"version"`
+	Description string                 `json:"description"`
+	Status      registry.VersionStatus `json:"status"`
+	SubmittedBy string                 `json:"submitted_by,omitempty"`
+	SubmittedAt *time.Time             `json:"submitted_at,omitempty"`
+	PublishedBy string                 `json:"published_by,omitempty"`
+	PublishedAt *time.Time             `json:"published_at,omitempty"`
+	Approvals   []*VersionApproval     `json:"approvals"`
+	Missing     []string               `json:"missing_approvals"`
+	Locked      bool                   `json:"locked"`
+
+	// set once the version is published
+	ManifestDigest string `json:"manifest_digest,omitempty"`
+	AssetCount     int    `json:"asset_count,omitempty"`
+	TotalBytes     int64  `json:"total_bytes,omitempty"`
+}
+
+type VersionApproval struct {
+	Approver   string    `json:"approver"`
+	Role       string    `json:"role"`
+	ApprovedAt time.Time `json:"approved_at"`
+}
+
+func CreateDatasetVersionHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetUri
+	var request CreateDatasetVersionRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create dataset version", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create dataset version", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	dsv, err := svc.CreateDatasetVersion(ctx.Request.Context(), uri.DatasetName, request.Description)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create dataset version", err)
+		return
+	}
+
+	// Success response
+	response := newDatasetVersionResponse(ctx, "created dataset version successfully", dsv)
+	dto.Created(ctx, response)
+}
+
+func newDatasetVersionResponse(ctx *gin.Context, msg string, dsv *registry.DatasetVersion) DatasetVersionResponse {
+	response := DatasetVersionResponse{
+		Response:    *dto.NewResponse(ctx, msg),
+		Dataset:     dsv.Dataset.Name,
+		Version:     dsv.Number,
+		Description: dsv.Description,
+		Status:      dsv.Status,
+		SubmittedBy: dsv.SubmittedBy,
+		SubmittedAt: dsv.SubmittedAt,
+		PublishedBy: dsv.PublishedBy,
+		PublishedAt: dsv.PublishedAt,
+		Approvals:   make([]*VersionApproval, len(dsv.Approvals)),
+		Missing:     []string{},
+		Locked:      dsv.Locked(),
+
+		ManifestDigest: dsv.ManifestDigest,
+		AssetCount:     dsv.AssetCount,
+		TotalBytes:     dsv.TotalBytes,
+	}
+
+	for i, a := range dsv.Approvals {
+		response.Approvals[i] = &VersionApproval{
+			Approver:   a.Approver,
+			Role:       a.Role,
+			ApprovedAt: a.CreatedAt,
+		}
+	}
+
+	if dsv.Status == registry.VersionReview {
+		response.Missing = append(response.Missing, dsv.MissingApprovals(dsv.Dataset.RequiredRoles())...)
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"dataset", response.Dataset,
+		"version", response.Version,
+		"status", response.Status,
+	)
+	return response
+}
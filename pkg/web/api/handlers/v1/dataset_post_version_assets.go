@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type DatasetVersionAssetsRequest struct {
+	Checksums []string `json:"checksums" binding:"required,min=1,max=1000,dive,len=64,hexadecimal"`
+}
+
+func AddDatasetVersionAssetsHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetVersionUri
+	var request DatasetVersionAssetsRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to add dataset version assets", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to add dataset version assets", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	dsv, err := svc.AddDatasetVersionAssets(ctx.Request.Context(), uri.DatasetName, uri.Version, request.Checksums...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to add dataset version assets", err)
+		return
+	}
+
+	// Success response
+	response := newDatasetVersionResponse(ctx, "added dataset version assets successfully", dsv)
+	dto.OK(ctx, response)
+}
+
+func RemoveDatasetVersionAssetsHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetVersionUri
+	var request DatasetVersionAssetsRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to remove dataset version assets", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to remove dataset version assets", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	dsv, err := svc.RemoveDatasetVersionAssets(ctx.Request.Context(), uri.DatasetName, uri.Version, request.Checksums...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to remove dataset version assets", err)
+		return
+	}
+
+	// Success response
+	response := newDatasetVersionResponse(ctx, "removed dataset version assets successfully", dsv)
+	dto.OK(ctx, response)
+}
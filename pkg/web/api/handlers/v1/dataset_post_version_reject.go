@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type RejectDatasetVersionRequest struct {
+	Reason string `json:"reason" binding:"omitempty,max=1000"`
+}
+
+func RejectDatasetVersionHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetVersionUri
+	var request RejectDatasetVersionRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to reject dataset version", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to reject dataset version", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	dsv, err := svc.RejectDatasetVersion(ctx.Request.Context(), uri.DatasetName, uri.Version, request.Reason)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to reject dataset version", err)
+		return
+	}
+
+	// Success response
+	response := newDatasetVersionResponse(ctx, "rejected dataset version successfully", dsv)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+func SubmitDatasetVersionHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetVersionUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to submit dataset version", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	dsv, err := svc.SubmitDatasetVersion(ctx.Request.Context(), uri.DatasetName, uri.Version)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to submit dataset version", err)
+		return
+	}
+
+	// Success response
+	response := newDatasetVersionResponse(ctx, "submitted dataset version successfully", dsv)
+	dto.OK(ctx, response)
+}
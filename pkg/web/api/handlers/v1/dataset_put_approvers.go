@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// PutDatasetApproversRequest with no roles accepts a single approval from anyone
+type PutDatasetApproversRequest struct {
+	Roles []string `json:"roles" binding:"omitempty,max=20,dive,min=1,max=100"`
+}
+
+type DatasetApproversResponse struct {
+	dto.Response
+	Dataset string   `json:"dataset"`
+	Roles   []string `json:"roles"`
+}
+
+func PutDatasetApproversHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetUri
+	var request PutDatasetApproversRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set dataset approvers", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set dataset approvers", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	ds, err := svc.SetDatasetApprovers(ctx.Request.Context(), uri.DatasetName, request.Roles)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set dataset approvers", err)
+		return
+	}
+
+	// Success response
+	response := DatasetApproversResponse{
+		Response: *dto.NewResponse(ctx, "set dataset approvers successfully"),
+		Dataset:  ds.Name,
+		Roles:    ds.RequiredRoles(),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"dataset", ds.Name,
+		"roles", response.Roles,
+	)
+	dto.OK(ctx, response)
+}
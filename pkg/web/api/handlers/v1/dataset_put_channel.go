@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type PutDatasetChannelRequest struct {
+	Version int `json:"version" binding:"required,min=1"`
+}
+
+func PutDatasetChannelHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetChannelUri
+	var request PutDatasetChannelRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set dataset channel", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set dataset channel", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	channel, err := svc.SetDatasetChannel(ctx.Request.Context(), uri.DatasetName, uri.Channel, request.Version)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set dataset channel", err)
+		return
+	}
+
+	// Success response
+	response := newDatasetChannelResponse(ctx, "set dataset channel successfully", channel)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// PutDatasetReadmeRequest with an empty readme removes it
+type PutDatasetReadmeRequest struct {
+	Readme string `json:"readme"`
+}
+
+// PutDatasetReadmeHandler replaces a dataset readme, sent as JSON or as a raw text/markdown body
+func PutDatasetReadmeHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetUri
+	var request PutDatasetReadmeRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set dataset readme", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind the markdown body or JSON payload
+	switch ctx.ContentType() {
+	case MIMEMarkdown, gin.MIMEPlain:
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			dto.HandleErrorResponse(ctx, "failed to set dataset readme", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+			return
+		}
+		request.Readme = string(body)
+
+	default:
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			dto.HandleErrorResponse(ctx, "failed to set dataset readme", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+			return
+		}
+	}
+
+	ds, err := svc.SetDatasetReadme(ctx.Request.Context(), uri.DatasetName, request.Readme)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to set dataset readme", err)
+		return
+	}
+
+	// Success response
+	response := newDatasetReadmeResponse(ctx, "set dataset readme successfully", ds)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type PutDatasetSchemaRequest struct {
+	Description string                `json:"description" binding:"omitempty,max=10000"`
+	Columns     []SchemaColumnPayload `json:"columns" binding:"required,min=1,max=1000,dive"`
+}
+
+type SchemaColumnPayload struct {
+	Name        string `json:"name" binding:"required,max=200"`
+	Type        string `json:"type" binding:"required,max=100"`
+	Unit        string `json:"unit" binding:"omitempty,max=50"`
+	Nullable    bool   `json:"nullable"`
+	Description string `json:"description" binding:"omitempty,max=2000"`
+}
+
+func PutDatasetSchemaHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DatasetUri
+	var request PutDatasetSchemaRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to save dataset schema", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to save dataset schema", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	schema := &registry.DatasetSchema{
+		Description: request.Description,
+		Columns:     make([]registry.SchemaColumn, len(request.Columns)),
+	}
+	for i, c := range request.Columns {
+		schema.Columns[i] = registry.SchemaColumn(c)
+	}
+
+	if err := svc.SaveDatasetSchema(ctx.Request.Context(), uri.DatasetName, schema); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to save dataset schema", err)
+		return
+	}
+
+	// Success response
+	response := newDatasetSchemaResponse(ctx, "saved dataset schema successfully", uri.DatasetName, schema)
+	dto.Created(ctx, response)
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListAssetDeletionsRequest struct {
+	PageToken string `json:"page_token" binding:"omitempty,max=4096"`
+	Limit     uint   `json:"limit" binding:"omitempty,gte=1,lte=1000"`
+	ListAssetDeletionsFilters
+}
+
+// ListAssetDeletionsFilters is the query state page tokens are bound to
+type ListAssetDeletionsFilters struct {
+	Status string `json:"status" binding:"omitempty,oneof=pending objected cancelled completed"`
+}
+
+type ListAssetDeletionsResponse struct {
+	dto.Response
+	Total         int                     `json:"total"`
+	NextPageToken string                  `json:"next_page_token,omitempty"`
+	Deletions     []*AssetDeletionDetails `json:"deletions"`
+}
+
+func ListAssetDeletionsHandler(svc *data.Service, ctx *gin.Context) {
+	var request ListAssetDeletionsRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to list asset deletions",
+			fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err),
+		)
+		return
+	}
+
+	cursor, err := svc.DecodePageToken(request.PageToken, &request.ListAssetDeletionsFilters)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list asset deletions", err)
+		return
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = registry.SearchDefaultLimit
+	}
+
+	deletions, err := svc.ListAssetDeletions(ctx.Request.Context(), registry.DeletionStatus(request.Status), cursor, limit)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list asset deletions", err)
+		return
+	}
+
+	var last uint
+	if len(deletions) > 0 {
+		last = deletions[len(deletions)-1].ID
+	}
+	token, err := nextPageToken(svc, len(deletions), limit, last, request.ListAssetDeletionsFilters)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list asset deletions", err)
+		return
+	}
+
+	// Success response
+	response := newListAssetDeletionsResponse(ctx, deletions, token)
+	dto.OK(ctx, response)
+}
+
+func newListAssetDeletionsResponse(ctx *gin.Context, deletions []*registry.AssetDeletion, nextPageToken string) ListAssetDeletionsResponse {
+	items := make([]*AssetDeletionDetails, 0, len(deletions))
+	for _, d := range deletions {
+		items = append(items, newAssetDeletionDetails(d))
+	}
+
+	response := ListAssetDeletionsResponse{
+		Response:      *dto.NewResponse(ctx, "listed asset deletions successfully"),
+		Total:         len(deletions),
+		NextPageToken: nextPageToken,
+		Deletions:     items,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", len(deletions),
+	)
+	return response
+}
@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+func GetAssetByDigestHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.DigestUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to get asset by digest",
+			fmt.Errorf("%w, %w", dto.ErrInvalidUri, err),
+		)
+		return
+	}
+
+	asset, err := svc.GetAssetByDigest(ctx.Request.Context(), uri.Algorithm, uri.Digest)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get asset by digest", err)
+		return
+	}
+
+	// Success response
+	response := newGetAssetResponse(ctx, asset, "got asset by digest successfully")
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"algorithm", uri.Algorithm,
+		"checksum", asset.Checksum,
+	)
+	dto.OK(ctx, response)
+}
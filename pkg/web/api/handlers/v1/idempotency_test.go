@@ -0,0 +1,101 @@
+package v1_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+	"github.com/UnivocalX/aether/pkg/web/middleware"
+	"github.com/UnivocalX/aether/pkg/web/webtest"
+)
+
+// TestIdempotencyScopeIgnoresForwardedFor checks anonymous idempotency records belong to the connection address:
+// a retry naming another address in X-Forwarded-For is still replayed, and key holders' records are not replayed
+// to anonymous callers sending the same key
+func TestIdempotencyScopeIgnoresForwardedFor(t *testing.T) {
+	h := webtest.New(t, nil)
+
+	secret, err := h.Engine.CreateAPIKeyRecord(&registry.APIKey{Name: "uploader", Scopes: []string{registry.ScopeWrite}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	create := func(idempotencyKey string, forwarded string, authorization string, checksum string) *http.Response {
+		body, err := json.Marshal(v1.CreateAssetsBatchRequest{Assets: []v1.AssetPayload{{Checksum: checksum, Display: "x.bin"}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := http.NewRequest(http.MethodPost, h.URL+"/batch/assets", bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.IdempotencyKeyHeader, idempotencyKey)
+		req.Header.Set("X-Forwarded-For", forwarded)
+		if authorization != "" {
+			req.Header.Set("Authorization", "Bearer "+authorization)
+		}
+		return h.Send(req)
+	}
+	checksum := func(content string) string {
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+
+	// an anonymous retry from the same connection is replayed whatever address it claims
+	first := checksum("anonymous")
+	if resp := create("anonymous-key", "203.0.113.1", "", first); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create: status %d", resp.StatusCode)
+	}
+	if resp := create("anonymous-key", "203.0.113.2", "", first); resp.Header.Get(middleware.IdempotentReplayedHeader) != "true" {
+		t.Errorf("retry with another X-Forwarded-For was not replayed, status %d", resp.StatusCode)
+	}
+
+	// a key holder's response is not replayed to anonymous callers reusing its idempotency key
+	second := checksum("key holder")
+	if resp := create("shared-key", "203.0.113.3", secret.Value(), second); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create with api key: status %d", resp.StatusCode)
+	}
+	if resp := create("shared-key", "203.0.113.3", "", second); resp.Header.Get(middleware.IdempotentReplayedHeader) != "" {
+		t.Errorf("anonymous request got the response stored for the api key")
+	}
+}
+
+// TestIdempotentPresignedResponseExpires checks a stored response holding presigned urls is kept only until they expire
+func TestIdempotentPresignedResponseExpires(t *testing.T) {
+	h := webtest.New(t, nil)
+
+	sum := sha256.Sum256([]byte("presigned"))
+	body, err := json.Marshal(v1.CreateAssetsBatchRequest{
+		Assets:    []v1.AssetPayload{{Checksum: hex.EncodeToString(sum[:]), Display: "x.bin"}},
+		ExpiresIn: 60,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		req, err := http.NewRequest(http.MethodPost, h.URL+"/batch/assets", bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.IdempotencyKeyHeader, "presigned-key")
+		if resp := h.Send(req); resp.StatusCode != http.StatusCreated {
+			t.Fatalf("create: status %d", resp.StatusCode)
+		}
+	}
+
+	var record registry.IdempotencyRecord
+	if err := h.Engine.DatabaseClient.Where("key = ?", "presigned-key").First(&record).Error; err != nil {
+		t.Fatal(err)
+	}
+	if limit := time.Now().Add(time.Minute); record.ExpiresAt.After(limit) {
+		t.Errorf("record expires at %s, want no later than the presigned urls at %s", record.ExpiresAt, limit)
+	}
+}
@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ServerInfoResponse struct {
+	dto.Response
+	APIVersion        string `json:"api_version"`
+	PresignTTLSeconds int64  `json:"presign_ttl_seconds"`
+	PresignMaxSeconds int64  `json:"presign_max_seconds"`
+	SearchMaxLimit    uint   `json:"search_max_limit"`
+	RequireAPIKeys    bool   `json:"require_api_keys"`
+}
+
+func GetServerInfoHandler(svc *data.Service, ctx *gin.Context) {
+	info := svc.GetServerInfo()
+
+	version := fmt.Sprintf("%d-%d-%d-%t", info.PresignTTL, info.PresignMaxTTL, info.SearchMaxLimit, info.RequireAPIKeys)
+	if dto.NotModified(ctx, version) {
+		return
+	}
+
+	// Success response
+	dto.OK(ctx, ServerInfoResponse{
+		Response:          *dto.NewResponse(ctx, "got server info successfully"),
+		APIVersion:        "v1",
+		PresignTTLSeconds: int64(info.PresignTTL.Seconds()),
+		PresignMaxSeconds: int64(info.PresignMaxTTL.Seconds()),
+		SearchMaxLimit:    info.SearchMaxLimit,
+		RequireAPIKeys:    info.RequireAPIKeys,
+	})
+}
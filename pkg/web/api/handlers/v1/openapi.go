@@ -0,0 +1,938 @@
+package v1
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/api/openapi"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// OpenAPIBasePath is where the v1 routes are served
+const OpenAPIBasePath = "/api/v1"
+
+// Routes documents every v1 route with the types its handler binds and answers with.
+// A route registered in RegisterRoutes without an entry here is logged at startup, see CheckOpenAPI.
+var Routes = []openapi.Route{
+	{
+		Method:    http.MethodGet,
+		Path:      "/openapi.json",
+		ID:        "getOpenAPI",
+		Summary:   "This OpenAPI document",
+		Responses: map[int]any{http.StatusOK: openapi.Raw("application/json")},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/docs",
+		ID:        "getDocs",
+		Summary:   "Browse this OpenAPI document",
+		Responses: map[int]any{http.StatusOK: openapi.Raw(gin.MIMEHTML)},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/docs/assets/*file",
+		ID:        "getDocsAsset",
+		Summary:   "Files of the embedded Swagger UI",
+		Responses: map[int]any{http.StatusOK: openapi.Raw("application/octet-stream")},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/info",
+		ID:        "getServerInfo",
+		Summary:   "Limits of the server clients fit their requests to, e.g. the presign expiry bounds",
+		Responses: map[int]any{http.StatusOK: ServerInfoResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/stats",
+		ID:        "getStats",
+		Summary:   "Asset counts and bytes in total and by state, mime type and tag",
+		Responses: map[int]any{http.StatusOK: StatsResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/search",
+		ID:        "search",
+		Summary:   "Full-text search of asset display names and tag names, or indexed document text",
+		Query:     SearchQuery{},
+		Responses: map[int]any{http.StatusOK: SearchResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/assets",
+		ID:        "listAssets",
+		Summary:   "List assets",
+		Query:     dto.ExpandQuery{},
+		Body:      ListAssetsRequest{},
+		Responses: map[int]any{http.StatusOK: ListAssetsResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/assets/exists",
+		ID:        "assetsExist",
+		Summary:   "Check which of many assets exist",
+		Body:      AssetsExistRequest{},
+		Responses: map[int]any{http.StatusOK: AssetsExistResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/assets/download-urls",
+		ID:        "getAssetDownloadUrls",
+		Summary:   "Get download urls of many ready assets at once",
+		Body:      AssetDownloadUrlsRequest{},
+		Responses: map[int]any{http.StatusOK: AssetDownloadUrlsResponse{}, http.StatusMultiStatus: AssetDownloadUrlsResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/assets/:asset_checksum",
+		ID:        "getAsset",
+		Summary:   "Get a specific asset",
+		Uri:       dto.AssetUri{},
+		Responses: map[int]any{http.StatusOK: GetAssetResponse{}},
+	},
+	{
+		Method:    http.MethodHead,
+		Path:      "/assets/:asset_checksum",
+		ID:        "headAsset",
+		Summary:   "Check whether a specific asset exists",
+		Uri:       dto.AssetUri{},
+		Responses: map[int]any{http.StatusOK: nil},
+	},
+	{
+		Method:    http.MethodPatch,
+		Path:      "/assets/:asset_checksum",
+		ID:        "patchAsset",
+		Summary:   "Edit an asset display name, mime type or extra",
+		Uri:       dto.AssetUri{},
+		Body:      PatchAssetRequest{},
+		Responses: map[int]any{http.StatusOK: GetAssetResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/assets/:asset_checksum/tags",
+		ID:        "listAssetTags",
+		Summary:   "Get a specific asset tags",
+		Uri:       dto.AssetUri{},
+		Responses: map[int]any{http.StatusOK: AssetTagsResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/assets/:asset_checksum/references",
+		ID:        "getAssetReferences",
+		Summary:   "List what still references an asset, referenced assets can't be purged",
+		Uri:       dto.AssetUri{},
+		Responses: map[int]any{http.StatusOK: AssetReferencesResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/assets/:asset_checksum/digests",
+		ID:        "getAssetDigests",
+		Summary:   "List the digests of an asset under other algorithms than sha256",
+		Uri:       dto.AssetUri{},
+		Responses: map[int]any{http.StatusOK: AssetDigestsResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/digests/:algorithm/:digest",
+		ID:        "getAssetByDigest",
+		Summary:   "Find an asset by its digest under another algorithm",
+		Uri:       dto.DigestUri{},
+		Responses: map[int]any{http.StatusOK: GetAssetResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/assets/:asset_checksum/ingress",
+		ID:        "getAssetIngress",
+		Summary:   "Get an asset ingress Url",
+		Uri:       dto.AssetUri{},
+		Query:     dto.PresignQuery{},
+		Responses: map[int]any{http.StatusOK: AssetIngressResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/assets/:asset_checksum/egress",
+		ID:        "getAssetEgress",
+		Summary:   "Get a download url of a ready asset",
+		Uri:       dto.AssetUri{},
+		Query:     dto.PresignQuery{},
+		Responses: map[int]any{http.StatusOK: AssetEgressResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/assets/:asset_checksum/download",
+		ID:        "downloadAsset",
+		Summary:   "Redirect to a download url of a ready asset",
+		Uri:       dto.AssetUri{},
+		Query:     dto.PresignQuery{},
+		Responses: map[int]any{http.StatusFound: nil},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/assets/:asset_checksum/finalize",
+		ID:        "finalizeAsset",
+		Summary:   "Promote an uploaded asset to curated",
+		Uri:       dto.AssetUri{},
+		Responses: map[int]any{http.StatusOK: FinalizeAssetResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/assets/:asset_checksum/classification",
+		ID:        "classifyAsset",
+		Summary:   "Set an asset classification",
+		Uri:       dto.AssetUri{},
+		Body:      ClassifyAssetRequest{},
+		Responses: map[int]any{http.StatusOK: ClassifyAssetResponse{}},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/assets/:asset_checksum",
+		ID:        "deleteAsset",
+		Summary:   "Move an asset to the trash",
+		Uri:       dto.AssetUri{},
+		Query:     DeleteAssetQuery{},
+		Responses: map[int]any{http.StatusAccepted: AssetDeletionResponse{}, http.StatusNoContent: nil},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/assets/:asset_checksum/deletion",
+		ID:        "getAssetDeletion",
+		Summary:   "Get the latest deletion request of an asset",
+		Uri:       dto.AssetUri{},
+		Responses: map[int]any{http.StatusOK: AssetDeletionResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/assets/:asset_checksum/deletion/objection",
+		ID:        "objectAssetDeletion",
+		Summary:   "Object to a pending asset deletion, keeping the asset",
+		Uri:       dto.AssetUri{},
+		Body:      ObjectAssetDeletionRequest{},
+		Responses: map[int]any{http.StatusOK: AssetDeletionResponse{}},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/assets/:asset_checksum/deletion",
+		ID:        "cancelAssetDeletion",
+		Summary:   "Cancel a pending asset deletion",
+		Uri:       dto.AssetUri{},
+		Responses: map[int]any{http.StatusOK: AssetDeletionResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/deletions",
+		ID:        "listAssetDeletions",
+		Summary:   "List asset deletion requests",
+		Body:      ListAssetDeletionsRequest{},
+		Responses: map[int]any{http.StatusOK: ListAssetDeletionsResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/assets/:asset_checksum/tags/:tag_name",
+		ID:        "tagAsset",
+		Summary:   "Tag a specific asset",
+		Uri:       dto.AssetTagUri{},
+		Responses: map[int]any{http.StatusNoContent: nil},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/assets/:asset_checksum/tags/:tag_name",
+		ID:        "untagAsset",
+		Summary:   "Untag a specific asset",
+		Uri:       dto.AssetTagUri{},
+		Responses: map[int]any{http.StatusNoContent: nil},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/tags/:tag_name",
+		ID:        "getTag",
+		Summary:   "Get a specific tag",
+		Uri:       dto.TagUri{},
+		Responses: map[int]any{http.StatusOK: GetTagResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/tags/:tag_name/assets",
+		ID:        "listTagAssets",
+		Summary:   "List tag assets",
+		Uri:       dto.TagUri{},
+		Body:      ListTagAssetsRequest{},
+		Responses: map[int]any{http.StatusOK: ListTagAssetsResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/tags/:tag_name/assets",
+		ID:        "tagAssets",
+		Summary:   "Tag many assets at once",
+		Uri:       dto.TagUri{},
+		Body:      TagAssetsRequest{},
+		Responses: map[int]any{http.StatusOK: TagAssetsResponse{}},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/tags/:tag_name/assets",
+		ID:        "untagAssets",
+		Summary:   "Untag many assets at once",
+		Uri:       dto.TagUri{},
+		Body:      TagAssetsRequest{},
+		Responses: map[int]any{http.StatusOK: TagAssetsResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/tags",
+		ID:        "createTag",
+		Summary:   "Add tag",
+		Body:      CreateTagRequest{},
+		Responses: map[int]any{http.StatusCreated: AddTagResponse{}},
+	},
+	{
+		Method:    http.MethodPatch,
+		Path:      "/tags/:tag_name",
+		ID:        "updateTag",
+		Summary:   "Edit a tag description and color",
+		Uri:       dto.TagUri{},
+		Body:      UpdateTagRequest{},
+		Responses: map[int]any{http.StatusOK: GetTagResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/tag-groups",
+		ID:        "createTagGroup",
+		Summary:   "Create a group of mutually exclusive tags",
+		Body:      CreateTagGroupRequest{},
+		Responses: map[int]any{http.StatusCreated: TagGroupResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/tag-groups",
+		ID:        "listTagGroups",
+		Summary:   "List tag groups",
+		Responses: map[int]any{http.StatusOK: ListTagGroupsResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/tag-groups/:group_name",
+		ID:        "getTagGroup",
+		Summary:   "Get a tag group",
+		Uri:       dto.TagGroupUri{},
+		Responses: map[int]any{http.StatusOK: TagGroupResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/tag-groups/:group_name",
+		ID:        "updateTagGroup",
+		Summary:   "Replace a tag group description and tags",
+		Uri:       dto.TagGroupUri{},
+		Body:      UpdateTagGroupRequest{},
+		Responses: map[int]any{http.StatusOK: TagGroupResponse{}},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/tag-groups/:group_name",
+		ID:        "deleteTagGroup",
+		Summary:   "Remove a tag group, keeping its tags",
+		Uri:       dto.TagGroupUri{},
+		Responses: map[int]any{http.StatusNoContent: nil},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/datasets",
+		ID:        "createDataset",
+		Summary:   "Create dataset",
+		Body:      CreateDatasetRequest{},
+		Responses: map[int]any{http.StatusCreated: CreateDatasetResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/datasets/:dataset_name/versions",
+		ID:        "createDatasetVersion",
+		Summary:   "Open a new draft dataset version",
+		Uri:       dto.DatasetUri{},
+		Body:      CreateDatasetVersionRequest{},
+		Responses: map[int]any{http.StatusCreated: DatasetVersionResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/datasets/:dataset_name/versions/:version/submit",
+		ID:        "submitDatasetVersion",
+		Summary:   "Submit a draft dataset version for review",
+		Uri:       dto.DatasetVersionUri{},
+		Responses: map[int]any{http.StatusOK: DatasetVersionResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/datasets/:dataset_name/versions/:version/approve",
+		ID:        "approveDatasetVersion",
+		Summary:   "Approve a dataset version in review",
+		Uri:       dto.DatasetVersionUri{},
+		Responses: map[int]any{http.StatusOK: DatasetVersionResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/datasets/:dataset_name/versions/:version/reject",
+		ID:        "rejectDatasetVersion",
+		Summary:   "Send a dataset version in review back to draft",
+		Uri:       dto.DatasetVersionUri{},
+		Body:      RejectDatasetVersionRequest{},
+		Responses: map[int]any{http.StatusOK: DatasetVersionResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/datasets/:dataset_name/versions/:version/publish",
+		ID:        "publishDatasetVersion",
+		Summary:   "Publish an approved dataset version",
+		Uri:       dto.DatasetVersionUri{},
+		Responses: map[int]any{http.StatusOK: DatasetVersionResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/datasets/:dataset_name/versions/:version/manifest",
+		ID:        "getDatasetVersionManifest",
+		Summary:   "Get the frozen manifest of a published dataset version",
+		Uri:       dto.DatasetVersionUri{},
+		Query:     DatasetVersionManifestQuery{},
+		Responses: map[int]any{http.StatusOK: DatasetVersionManifestResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/datasets/:dataset_name/versions/:version/assets",
+		ID:        "listDatasetVersionAssets",
+		Summary:   "List the assets of a dataset version",
+		Uri:       dto.DatasetVersionUri{},
+		Query:     dto.ExpandQuery{},
+		Body:      ListDatasetVersionAssetsRequest{},
+		Responses: map[int]any{http.StatusOK: ListAssetsResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/datasets/:dataset_name/versions/:version/assets",
+		ID:        "addDatasetVersionAssets",
+		Summary:   "Add assets to a draft dataset version",
+		Uri:       dto.DatasetVersionUri{},
+		Body:      DatasetVersionAssetsRequest{},
+		Responses: map[int]any{http.StatusOK: DatasetVersionResponse{}},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/datasets/:dataset_name/versions/:version/assets",
+		ID:        "removeDatasetVersionAssets",
+		Summary:   "Remove assets from a draft dataset version",
+		Uri:       dto.DatasetVersionUri{},
+		Body:      DatasetVersionAssetsRequest{},
+		Responses: map[int]any{http.StatusOK: DatasetVersionResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/datasets/:dataset_name/approvers",
+		ID:        "putDatasetApprovers",
+		Summary:   "Set the roles required to approve dataset versions",
+		Uri:       dto.DatasetUri{},
+		Body:      PutDatasetApproversRequest{},
+		Responses: map[int]any{http.StatusOK: DatasetApproversResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/datasets/:dataset_name/readme",
+		ID:        "getDatasetReadme",
+		Summary:   "Get a dataset readme, rendered for browsers",
+		Uri:       dto.DatasetUri{},
+		Responses: map[int]any{http.StatusOK: DatasetReadmeResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/datasets/:dataset_name/readme",
+		ID:        "putDatasetReadme",
+		Summary:   "Replace a dataset readme",
+		Uri:       dto.DatasetUri{},
+		Body:      PutDatasetReadmeRequest{},
+		Responses: map[int]any{http.StatusOK: DatasetReadmeResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/datasets/:dataset_name/channels/:channel_name",
+		ID:        "getDatasetChannel",
+		Summary:   "Get the version a dataset channel points at",
+		Uri:       dto.DatasetChannelUri{},
+		Responses: map[int]any{http.StatusOK: DatasetChannelResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/datasets/:dataset_name/channels/:channel_name",
+		ID:        "putDatasetChannel",
+		Summary:   "Point a dataset channel at a published version",
+		Uri:       dto.DatasetChannelUri{},
+		Body:      PutDatasetChannelRequest{},
+		Responses: map[int]any{http.StatusOK: DatasetChannelResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/datasets/:dataset_name/schema",
+		ID:        "getDatasetSchema",
+		Summary:   "Get a dataset data dictionary",
+		Uri:       dto.DatasetUri{},
+		Query:     GetDatasetSchemaQuery{},
+		Responses: map[int]any{http.StatusOK: DatasetSchemaResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/datasets/:dataset_name/schema",
+		ID:        "putDatasetSchema",
+		Summary:   "Store a new dataset data dictionary revision",
+		Uri:       dto.DatasetUri{},
+		Body:      PutDatasetSchemaRequest{},
+		Responses: map[int]any{http.StatusCreated: DatasetSchemaResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/datasets/:dataset_name/schema/history",
+		ID:        "listDatasetSchemaHistory",
+		Summary:   "List the dataset data dictionary history",
+		Uri:       dto.DatasetUri{},
+		Responses: map[int]any{http.StatusOK: DatasetSchemaHistoryResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/trash/assets",
+		ID:        "listTrash",
+		Summary:   "List deleted assets",
+		Body:      ListTrashRequest{},
+		Responses: map[int]any{http.StatusOK: ListAssetsResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/trash/assets/restore",
+		ID:        "restoreTrash",
+		Summary:   "Restore deleted assets",
+		Body:      TrashAssetsRequest{},
+		Responses: map[int]any{http.StatusOK: TrashAssetsResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/trash/assets/purge",
+		ID:        "purgeTrash",
+		Summary:   "Permanently remove deleted assets, dry_run lists them with the bytes it would free",
+		Body:      PurgeTrashRequest{},
+		Responses: map[int]any{http.StatusOK: TrashAssetsResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/audit",
+		ID:        "listAudit",
+		Summary:   "List the audit log of mutating operations",
+		Query:     ListAuditQuery{},
+		Responses: map[int]any{http.StatusOK: ListAuditResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/admin/access-log",
+		ID:        "listAccessLog",
+		Summary:   "List issued presigned urls",
+		Query:     ListAccessLogQuery{},
+		Responses: map[int]any{http.StatusOK: ListAccessLogResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/admin/events",
+		ID:        "listEvents",
+		Summary:   "List registry events",
+		Query:     ListEventsQuery{},
+		Responses: map[int]any{http.StatusOK: ListEventsResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/admin/usage",
+		ID:        "listUsage",
+		Summary:   "Get metered project usage, JSON or CSV for chargeback",
+		Query:     ListUsageQuery{},
+		Responses: map[int]any{http.StatusOK: ListUsageResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/admin/ownerless-assets",
+		ID:        "listOwnerlessAssets",
+		Summary:   "Report the assets without an owner",
+		Body:      ListOwnerlessAssetsRequest{},
+		Responses: map[int]any{http.StatusOK: OwnerlessAssetsResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/admin/gc",
+		ID:        "startGarbageCollection",
+		Summary:   "Start an ingress garbage collection run, dry_run lists the objects it would delete",
+		Query:     DryRunQuery{},
+		Responses: map[int]any{http.StatusOK: DryRunResponse{}, http.StatusAccepted: dto.Response{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/admin/jobs/:job_name/dry-run",
+		ID:        "dryRunJob",
+		Summary:   "List what a maintenance job run would remove, e.g. gc, deletion or partitions",
+		Uri:       JobUri{},
+		Responses: map[int]any{http.StatusOK: DryRunResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/admin/content-policies/:project",
+		ID:        "getContentPolicy",
+		Summary:   "Get a project content policy",
+		Uri:       dto.ProjectUri{},
+		Responses: map[int]any{http.StatusOK: ContentPolicyResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/admin/content-policies/:project",
+		ID:        "putContentPolicy",
+		Summary:   "Create or replace a project content policy",
+		Uri:       dto.ProjectUri{},
+		Body:      PutContentPolicyRequest{},
+		Responses: map[int]any{http.StatusOK: ContentPolicyResponse{}},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/admin/content-policies/:project",
+		ID:        "deleteContentPolicy",
+		Summary:   "Remove a project content policy",
+		Uri:       dto.ProjectUri{},
+		Responses: map[int]any{http.StatusNoContent: nil},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/admin/license-restrictions",
+		ID:        "listLicenseRestrictions",
+		Summary:   "List license restrictions",
+		Responses: map[int]any{http.StatusOK: ListLicenseRestrictionsResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/admin/license-restrictions/:license",
+		ID:        "putLicenseRestriction",
+		Summary:   "Create or replace a license restriction",
+		Uri:       dto.LicenseUri{},
+		Body:      PutLicenseRestrictionRequest{},
+		Responses: map[int]any{http.StatusOK: LicenseRestrictionResponse{}},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/admin/license-restrictions/:license",
+		ID:        "deleteLicenseRestriction",
+		Summary:   "Remove a license restriction",
+		Uri:       dto.LicenseUri{},
+		Responses: map[int]any{http.StatusNoContent: nil},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/admin/api-keys",
+		ID:        "createAPIKey",
+		Summary:   "Issue an api key, the key is only returned once",
+		Body:      CreateAPIKeyRequest{},
+		Responses: map[int]any{http.StatusCreated: CreateAPIKeyResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/admin/api-keys",
+		ID:        "listAPIKeys",
+		Summary:   "List api keys, revoked ones included",
+		Responses: map[int]any{http.StatusOK: ListAPIKeysResponse{}},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/admin/api-keys/:key_name",
+		ID:        "revokeAPIKey",
+		Summary:   "Revoke an api key",
+		Uri:       dto.APIKeyUri{},
+		Responses: map[int]any{http.StatusOK: APIKeyResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/admin/retag-jobs",
+		ID:        "createRetagJob",
+		Summary:   "Queue attaching or detaching a tag on every asset matching a filter",
+		Body:      CreateRetagJobRequest{},
+		Responses: map[int]any{http.StatusAccepted: RetagJobResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/admin/retag-jobs",
+		ID:        "listRetagJobs",
+		Summary:   "List retag jobs and their progress",
+		Body:      ListRetagJobsRequest{},
+		Responses: map[int]any{http.StatusOK: ListRetagJobsResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/admin/retag-jobs/:job_id",
+		ID:        "getRetagJob",
+		Summary:   "Get a retag job progress",
+		Uri:       dto.RetagJobUri{},
+		Responses: map[int]any{http.StatusOK: RetagJobResponse{}},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/admin/retag-jobs/:job_id",
+		ID:        "cancelRetagJob",
+		Summary:   "Cancel a pending or running retag job",
+		Uri:       dto.RetagJobUri{},
+		Responses: map[int]any{http.StatusOK: RetagJobResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/admin/roles",
+		ID:        "listRoleAssignments",
+		Summary:   "List principals and their assigned roles",
+		Responses: map[int]any{http.StatusOK: ListRoleAssignmentsResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/admin/roles/:principal",
+		ID:        "putRoleAssignment",
+		Summary:   "Assign a role to a principal, replacing its previous role",
+		Uri:       dto.PrincipalUri{},
+		Body:      PutRoleAssignmentRequest{},
+		Responses: map[int]any{http.StatusOK: RoleAssignmentResponse{}},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/admin/roles/:principal",
+		ID:        "revokeRoleAssignment",
+		Summary:   "Revoke the role assigned to a principal",
+		Uri:       dto.PrincipalUri{},
+		Responses: map[int]any{http.StatusNoContent: nil},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/admin/projects",
+		ID:        "createProject",
+		Summary:   "Register a project, its assets, tags and datasets are kept apart from the other projects",
+		Body:      CreateProjectRequest{},
+		Responses: map[int]any{http.StatusCreated: ProjectResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/admin/projects/:project/defaults",
+		ID:        "putProjectDefaults",
+		Summary:   "Set the tags and extra template applied to every asset created in a project",
+		Uri:       dto.ProjectUri{},
+		Body:      PutProjectDefaultsRequest{},
+		Responses: map[int]any{http.StatusOK: ProjectResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/admin/projects/:project/quota",
+		ID:        "putProjectQuota",
+		Summary:   "Limit the bytes and objects a project stores, new uploads are rejected past the limit",
+		Uri:       dto.ProjectUri{},
+		Body:      PutProjectQuotaRequest{},
+		Responses: map[int]any{http.StatusOK: ProjectResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/projects",
+		ID:        "listProjects",
+		Summary:   "List projects",
+		Responses: map[int]any{http.StatusOK: ListProjectsResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/projects/:project/usage",
+		ID:        "getProjectUsage",
+		Summary:   "Get the stored bytes and objects of the caller project with its quota",
+		Uri:       dto.ProjectUri{},
+		Responses: map[int]any{http.StatusOK: ProjectUsageResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/webhooks",
+		ID:        "createWebhook",
+		Summary:   "Register a webhook",
+		Body:      CreateWebhookRequest{},
+		Responses: map[int]any{http.StatusCreated: CreateWebhookResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/webhooks",
+		ID:        "listWebhooks",
+		Summary:   "List webhooks",
+		Responses: map[int]any{http.StatusOK: ListWebhooksResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/webhooks/:webhook_name",
+		ID:        "getWebhook",
+		Summary:   "Get a webhook",
+		Uri:       dto.WebhookUri{},
+		Responses: map[int]any{http.StatusOK: WebhookResponse{}},
+	},
+	{
+		Method:    http.MethodPatch,
+		Path:      "/webhooks/:webhook_name",
+		ID:        "updateWebhook",
+		Summary:   "Update a webhook url, events, secret or state",
+		Uri:       dto.WebhookUri{},
+		Body:      UpdateWebhookRequest{},
+		Responses: map[int]any{http.StatusOK: WebhookResponse{}},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/webhooks/:webhook_name",
+		ID:        "deleteWebhook",
+		Summary:   "Remove a webhook and its queued deliveries",
+		Uri:       dto.WebhookUri{},
+		Responses: map[int]any{http.StatusNoContent: nil},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/webhooks/:webhook_name/deliveries",
+		ID:        "listWebhookDeliveries",
+		Summary:   "List the latest deliveries of a webhook",
+		Uri:       dto.WebhookUri{},
+		Query:     ListWebhookDeliveriesQuery{},
+		Responses: map[int]any{http.StatusOK: ListWebhookDeliveriesResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/storage/*key",
+		ID:        "putStorageObject",
+		Summary:   "Upload to a signed filesystem storage url",
+		Body:      openapi.Raw("application/octet-stream"),
+		Responses: map[int]any{http.StatusOK: PutStorageObjectResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/storage/*key",
+		ID:        "getStorageObject",
+		Summary:   "Download from a signed filesystem storage url",
+		Responses: map[int]any{http.StatusOK: openapi.Raw("application/octet-stream")},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/storage-events",
+		ID:        "postStorageEvents",
+		Summary:   "Finalize uploads announced by bucket notification webhooks, outside /storage/ so the request size cap applies",
+		Body:      openapi.Raw("application/json"),
+		Responses: map[int]any{http.StatusOK: StorageEventsResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/batch/assets",
+		ID:        "createAssetsBatch",
+		Summary:   "Post assets",
+		Body:      CreateAssetsBatchRequest{},
+		Responses: map[int]any{http.StatusCreated: AssetsBatchResponse{}, http.StatusMultiStatus: AssetsBatchResponse{}},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/batch/assets/ingress",
+		ID:      "getAssetsBatchIngress",
+		Summary: "Get assets ingress Urls",
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/staging",
+		ID:        "createStagedUpload",
+		Summary:   "Presign an upload of content whose checksum is computed while it streams",
+		Query:     dto.PresignQuery{},
+		Responses: map[int]any{http.StatusCreated: StagedUploadResponse{}},
+	},
+	{
+		Method:    http.MethodPost,
+		Path:      "/staging/:staging_id/commit",
+		ID:        "commitStagedUpload",
+		Summary:   "Register a staged upload under its computed checksum and promote it",
+		Uri:       dto.StagingUri{},
+		Body:      CommitStagedUploadRequest{},
+		Responses: map[int]any{http.StatusOK: FinalizeAssetResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/replication/watermark",
+		ID:        "getReplicationWatermark",
+		Summary:   "Get the latest change and the last change the peer acknowledged",
+		Responses: map[int]any{http.StatusOK: ReplicationWatermarkResponse{}},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/replication/watermark",
+		ID:        "ackReplicationWatermark",
+		Summary:   "Acknowledge the changes the peer applied",
+		Body:      AckReplicationWatermarkRequest{},
+		Responses: map[int]any{http.StatusNoContent: nil},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/replication/changes",
+		ID:        "listReplicationChanges",
+		Summary:   "List asset changes after a watermark",
+		Body:      ListReplicationChangesRequest{},
+		Responses: map[int]any{http.StatusOK: ListReplicationChangesResponse{}},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/replication/assets/:asset_checksum/object",
+		ID:        "getReplicationObject",
+		Summary:   "Get a download url of a ready asset object",
+		Uri:       dto.AssetUri{},
+		Query:     ReplicationObjectQuery{},
+		Responses: map[int]any{http.StatusOK: ReplicationObjectResponse{}},
+	},
+}
+
+// openAPIDocument is built once, on first request, from Routes
+var openAPIDocument = sync.OnceValue(func() *openapi.Document {
+	info := openapi.Info{
+		Title:       "Aether API",
+		Version:     "v1",
+		Description: "Registry of content addressed assets, their tags and versioned datasets.",
+	}
+	return openapi.Build(info, OpenAPIBasePath, dto.ErrorResponse{}, Routes...)
+})
+
+func GetOpenAPIHandler(ctx *gin.Context) {
+	dto.OK(ctx, openAPIDocument())
+}
+
+// swaggerPage loads the Swagger UI embedded in the binary, pointed at the document next to it.
+// The swagger-ui-dist release is pinned by the version of the swaggo/files module.
+const swaggerPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Aether API</title>
+  <link rel="stylesheet" href="docs/assets/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="docs/assets/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+func GetDocsHandler(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, gin.MIMEHTML+"; charset=utf-8", []byte(swaggerPage))
+}
+
+// GetDocsAssetHandler serves the embedded Swagger UI files, they only change with the module version
+func GetDocsAssetHandler(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=86400")
+	ctx.FileFromFS(ctx.Param("file"), http.FS(swaggerFiles.FS))
+}
+
+// UndocumentedRoutes returns the registered v1 routes missing from Routes, written as "METHOD path"
+func UndocumentedRoutes(routes gin.RoutesInfo) []string {
+	registered := make([]string, 0, len(routes))
+	for _, route := range routes {
+		registered = append(registered, route.Method+" "+route.Path)
+	}
+	return openAPIDocument().Undocumented(OpenAPIBasePath, registered...)
+}
+
+// CheckOpenAPI warns about registered v1 routes missing from Routes, TestRoutesDocumented fails on them
+func CheckOpenAPI(routes gin.RoutesInfo) {
+	for _, route := range UndocumentedRoutes(routes) {
+		slog.Warn("route missing from the openapi document", "route", route)
+	}
+}
@@ -0,0 +1,49 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	v1.RegisterRoutes(router.Group("/api"), data.NewService(nil))
+	return router
+}
+
+// TestRoutesDocumented fails on v1 routes registered without an entry in Routes
+func TestRoutesDocumented(t *testing.T) {
+	for _, route := range v1.UndocumentedRoutes(newRouter().Routes()) {
+		t.Errorf("%s is missing from v1.Routes", route)
+	}
+}
+
+// TestDocsAssets checks the Swagger UI page only loads files served by the binary
+func TestDocsAssets(t *testing.T) {
+	router := newRouter()
+
+	page := httptest.NewRecorder()
+	router.ServeHTTP(page, httptest.NewRequest(http.MethodGet, v1.OpenAPIBasePath+"/docs", nil))
+	if page.Code != http.StatusOK {
+		t.Fatalf("docs page: status %d", page.Code)
+	}
+
+	links := regexp.MustCompile(`(?:src|href)="([^"]+)"`).FindAllStringSubmatch(page.Body.String(), -1)
+	if len(links) == 0 {
+		t.Fatal("docs page links no files")
+	}
+	for _, link := range links {
+		asset := httptest.NewRecorder()
+		router.ServeHTTP(asset, httptest.NewRequest(http.MethodGet, v1.OpenAPIBasePath+"/"+link[1], nil))
+		if asset.Code != http.StatusOK || asset.Body.Len() == 0 {
+			t.Errorf("%s: status %d with %d bytes", link[1], asset.Code, asset.Body.Len())
+		}
+	}
+}
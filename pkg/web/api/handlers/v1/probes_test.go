@@ -0,0 +1,74 @@
+package v1_test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/UnivocalX/aether/pkg/web/webtest"
+)
+
+// TestProbeQuotaIgnoresForwardedFor checks anonymous callers can't reset their probe quota with another X-Forwarded-For
+func TestProbeQuotaIgnoresForwardedFor(t *testing.T) {
+	h := webtest.New(t, nil, data.WithProbeGuard(data.NewProbeGuard(2, time.Minute, false)))
+
+	for i, forwarded := range []string{"203.0.113.1", "203.0.113.2", "203.0.113.3"} {
+		unknown := make([]byte, 32)
+		rand.Read(unknown)
+
+		req, err := http.NewRequest(http.MethodGet, h.URL+"/assets/"+hex.EncodeToString(unknown), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-Forwarded-For", forwarded)
+
+		want := http.StatusNotFound
+		if i == 2 {
+			want = http.StatusTooManyRequests
+		}
+		if resp := h.Send(req); resp.StatusCode != want {
+			t.Errorf("lookup %d from %s: status %d, want %d", i+1, forwarded, resp.StatusCode, want)
+		}
+	}
+}
+
+// TestDebugVarsAdminOnly checks the expvar counters are only served to admin keys
+func TestDebugVarsAdminOnly(t *testing.T) {
+	h := webtest.New(t, nil, data.WithRequiredAPIKeys(), data.WithAuthorization(""))
+
+	key := func(name string, scope string, role string) string {
+		secret, err := h.Engine.CreateAPIKeyRecord(&registry.APIKey{Name: name, Scopes: []string{scope}, Roles: []string{role}})
+		if err != nil {
+			t.Fatalf("create key %s: %v", name, err)
+		}
+		return secret.Value()
+	}
+
+	for _, tt := range []struct {
+		name   string
+		secret string
+		want   int
+	}{
+		{name: "anonymous", want: http.StatusUnauthorized},
+		{name: "reader", secret: key("reader", registry.ScopeRead, registry.RoleReader), want: http.StatusForbidden},
+		{name: "curator", secret: key("curator", registry.ScopeWrite, registry.RoleCurator), want: http.StatusForbidden},
+		{name: "admin", secret: key("admin", registry.ScopeAdmin, registry.RoleAdmin), want: http.StatusOK},
+	} {
+		req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(h.URL, "/v1")+"/debug/vars", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.secret != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.secret)
+		}
+
+		if resp := h.Send(req); resp.StatusCode != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, resp.StatusCode, tt.want)
+		}
+	}
+}
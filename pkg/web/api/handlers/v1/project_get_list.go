@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListProjectsResponse struct {
+	dto.Response
+	Total    int               `json:"total"`
+	Projects []*ProjectDetails `json:"projects"`
+}
+
+func ListProjectsHandler(svc *data.Service, ctx *gin.Context) {
+	projects, err := svc.ListProjects(ctx.Request.Context())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list projects", err)
+		return
+	}
+
+	// Success response
+	response := ListProjectsResponse{
+		Response: *dto.NewResponse(ctx, "listed projects successfully"),
+		Total:    len(projects),
+		Projects: make([]*ProjectDetails, 0, len(projects)),
+	}
+	for _, project := range projects {
+		response.Projects = append(response.Projects, newProjectDetails(project))
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", response.Total,
+	)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectUsageResponse is the stored bytes and objects of the ready assets of a project,
+// a zero quota means unlimited
+type ProjectUsageResponse struct {
+	dto.Response
+	Project       string               `json:"project"`
+	QuotaStatus   registry.QuotaStatus `json:"quota_status"`
+	StoredBytes   int64                `json:"stored_bytes"`
+	StoredObjects int64                `json:"stored_objects"`
+	QuotaBytes    int64                `json:"quota_bytes"`
+	QuotaObjects  int64                `json:"quota_objects"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+func GetProjectUsageHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.ProjectUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get project usage", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	usage, err := svc.GetProjectUsage(ctx.Request.Context(), uri.Project)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get project usage", err)
+		return
+	}
+
+	// Success response
+	response := ProjectUsageResponse{
+		Response:      *dto.NewResponse(ctx, "got project usage successfully"),
+		Project:       usage.Project,
+		QuotaStatus:   usage.Status(),
+		StoredBytes:   usage.StoredBytes,
+		StoredObjects: usage.StoredObjects,
+		QuotaBytes:    usage.QuotaBytes,
+		QuotaObjects:  usage.QuotaObjects,
+		UpdatedAt:     usage.UpdatedAt,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"project", usage.Project,
+		"storedBytes", usage.StoredBytes,
+		"storedObjects", usage.StoredObjects,
+	)
+	dto.OK(ctx, response)
+}
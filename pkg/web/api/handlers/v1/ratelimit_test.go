@@ -0,0 +1,29 @@
+package v1_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/UnivocalX/aether/pkg/web/webtest"
+)
+
+// TestRateLimitIgnoresForwardedFor checks anonymous callers keep their bucket when they send another X-Forwarded-For
+func TestRateLimitIgnoresForwardedFor(t *testing.T) {
+	h := webtest.New(t, nil, data.WithRateLimiter(data.NewRateLimiter(0.01, 2)))
+
+	for i, forwarded := range []string{"203.0.113.1", "203.0.113.2", "203.0.113.3"} {
+		req, err := http.NewRequest(http.MethodGet, h.URL+"/assets", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-Forwarded-For", forwarded)
+		req.Header.Set("X-Real-IP", forwarded)
+
+		// the burst covers the first two requests, whatever address they claim
+		limited := h.Send(req).StatusCode == http.StatusTooManyRequests
+		if limited != (i == 2) {
+			t.Errorf("request %d from %s: limited %v, want %v", i+1, forwarded, limited, i == 2)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"log/slog"
 
+	"github.com/UnivocalX/aether/pkg/web/middleware"
 	"github.com/UnivocalX/aether/pkg/web/services/data"
 	"github.com/gin-gonic/gin"
 )
@@ -12,27 +13,136 @@ func RegisterRoutes(r gin.IRouter, svc *data.Service) {
 
 	v1 := r.Group("/v1")
 
+	// Creating requests run once per Idempotency-Key, retries after network failures get the first response
+	idempotent := middleware.Idempotency(svc)
+
+	// OpenAPI
+	// The OpenAPI document of these routes, built from Routes
+	v1.GET("/openapi.json", GetOpenAPIHandler)
+
+	// Browse the OpenAPI document with Swagger UI
+	v1.GET("/docs", GetDocsHandler)
+	v1.GET("/docs/assets/*file", GetDocsAssetHandler)
+
+	// Server info
+	// Limits of the server clients fit their requests to, e.g. the presign expiry bounds
+	v1.GET("/info", func(ctx *gin.Context) {
+		GetServerInfoHandler(svc, ctx)
+	})
+
+	// Stats
+	// Asset counts and bytes in total and by state, mime type and tag
+	v1.GET("/stats", func(ctx *gin.Context) {
+		GetStatsHandler(svc, ctx)
+	})
+
+	// Search
+	// Full-text search of asset display names and tag names, or indexed document text
+	v1.GET("/search", func(ctx *gin.Context) {
+		SearchHandler(svc, ctx)
+	})
+
 	// Assets
 	// List assets
 	v1.GET("/assets", func(ctx *gin.Context) {
 		ListAssetsHandler(svc, ctx)
 	})
 
+	// Check which of many assets exist
+	v1.POST("/assets/exists", func(ctx *gin.Context) {
+		AssetsExistHandler(svc, ctx)
+	})
+
+	// Get download urls of many ready assets at once
+	v1.POST("/assets/download-urls", func(ctx *gin.Context) {
+		GetAssetDownloadUrlsHandler(svc, ctx)
+	})
+
 	// Get a specific asset
 	v1.GET("/assets/:asset_checksum", func(ctx *gin.Context) {
 		GetAssetHandler(svc, ctx)
 	})
 
+	// Check whether a specific asset exists
+	v1.HEAD("/assets/:asset_checksum", func(ctx *gin.Context) {
+		HeadAssetHandler(svc, ctx)
+	})
+
+	// Edit an asset display name, mime type or extra
+	v1.PATCH("/assets/:asset_checksum", func(ctx *gin.Context) {
+		PatchAssetHandler(svc, ctx)
+	})
+
 	// Get a specific asset tags
 	v1.GET("/assets/:asset_checksum/tags", func(ctx *gin.Context) {
 		ListAssetTagsHandler(svc, ctx)
 	})
 
+	// List what still references an asset, referenced assets can't be purged
+	v1.GET("/assets/:asset_checksum/references", func(ctx *gin.Context) {
+		GetAssetReferencesHandler(svc, ctx)
+	})
+
+	// List the digests of an asset under other algorithms than sha256
+	v1.GET("/assets/:asset_checksum/digests", func(ctx *gin.Context) {
+		GetAssetDigestsHandler(svc, ctx)
+	})
+
+	// Find an asset by its digest under another algorithm
+	v1.GET("/digests/:algorithm/:digest", func(ctx *gin.Context) {
+		GetAssetByDigestHandler(svc, ctx)
+	})
+
 	// Get an asset ingress Url
 	v1.GET("/assets/:asset_checksum/ingress", func(ctx *gin.Context) {
 		GetAssetIngressHandler(svc, ctx)
 	})
 
+	// Get a download url of a ready asset
+	v1.GET("/assets/:asset_checksum/egress", func(ctx *gin.Context) {
+		GetAssetEgressHandler(svc, ctx)
+	})
+
+	// Redirect to a download url of a ready asset
+	v1.GET("/assets/:asset_checksum/download", func(ctx *gin.Context) {
+		DownloadAssetHandler(svc, ctx)
+	})
+
+	// Promote an uploaded asset to curated
+	v1.POST("/assets/:asset_checksum/finalize", idempotent, func(ctx *gin.Context) {
+		FinalizeAssetHandler(svc, ctx)
+	})
+
+	// Set an asset classification
+	v1.PUT("/assets/:asset_checksum/classification", func(ctx *gin.Context) {
+		ClassifyAssetHandler(svc, ctx)
+	})
+
+	// Move an asset to the trash
+	v1.DELETE("/assets/:asset_checksum", func(ctx *gin.Context) {
+		DeleteAssetHandler(svc, ctx)
+	})
+
+	// Get the latest deletion request of an asset
+	v1.GET("/assets/:asset_checksum/deletion", func(ctx *gin.Context) {
+		GetAssetDeletionHandler(svc, ctx)
+	})
+
+	// Object to a pending asset deletion, keeping the asset
+	v1.POST("/assets/:asset_checksum/deletion/objection", func(ctx *gin.Context) {
+		ObjectAssetDeletionHandler(svc, ctx)
+	})
+
+	// Cancel a pending asset deletion
+	v1.DELETE("/assets/:asset_checksum/deletion", func(ctx *gin.Context) {
+		CancelAssetDeletionHandler(svc, ctx)
+	})
+
+	// List asset deletion requests
+	v1.GET("/deletions", func(ctx *gin.Context) {
+		ListAssetDeletionsHandler(svc, ctx)
+	})
+
 	// Tag a specific asset
 	v1.PUT("/assets/:asset_checksum/tags/:tag_name", func(ctx *gin.Context) {
 		TagAssetHandler(svc, ctx)
@@ -44,25 +154,361 @@ func RegisterRoutes(r gin.IRouter, svc *data.Service) {
 	})
 
 	// Tags
+	// Get a specific tag
+	v1.GET("/tags/:tag_name", func(ctx *gin.Context) {
+		GetTagHandler(svc, ctx)
+	})
+
 	// List tag assets
 	v1.GET("/tags/:tag_name/assets", func(ctx *gin.Context) {
 		ListTagAssetsHandler(svc, ctx)
 	})
 
+	// Tag many assets at once
+	v1.POST("/tags/:tag_name/assets", func(ctx *gin.Context) {
+		TagAssetsHandler(svc, ctx)
+	})
+
+	// Untag many assets at once
+	v1.DELETE("/tags/:tag_name/assets", func(ctx *gin.Context) {
+		UntagAssetsHandler(svc, ctx)
+	})
+
 	// Add tag
 	v1.POST("/tags", func(ctx *gin.Context) {
 		CreateTagHandler(svc, ctx)
 	})
 
+	// Edit a tag description and color
+	v1.PATCH("/tags/:tag_name", func(ctx *gin.Context) {
+		UpdateTagHandler(svc, ctx)
+	})
+
+	// Tag groups
+	// Create a group of mutually exclusive tags
+	v1.POST("/tag-groups", func(ctx *gin.Context) {
+		CreateTagGroupHandler(svc, ctx)
+	})
+
+	// List tag groups
+	v1.GET("/tag-groups", func(ctx *gin.Context) {
+		ListTagGroupsHandler(svc, ctx)
+	})
+
+	// Get a tag group
+	v1.GET("/tag-groups/:group_name", func(ctx *gin.Context) {
+		GetTagGroupHandler(svc, ctx)
+	})
+
+	// Replace a tag group description and tags
+	v1.PUT("/tag-groups/:group_name", func(ctx *gin.Context) {
+		UpdateTagGroupHandler(svc, ctx)
+	})
+
+	// Remove a tag group, keeping its tags
+	v1.DELETE("/tag-groups/:group_name", func(ctx *gin.Context) {
+		DeleteTagGroupHandler(svc, ctx)
+	})
+
 	// Datasets
 	// Create dataset
 	v1.POST("/datasets", func(ctx *gin.Context) {
 		CreateDatasetHandler(svc, ctx)
 	})
 
+	// Open a new draft dataset version
+	v1.POST("/datasets/:dataset_name/versions", func(ctx *gin.Context) {
+		CreateDatasetVersionHandler(svc, ctx)
+	})
+
+	// Submit a draft dataset version for review
+	v1.POST("/datasets/:dataset_name/versions/:version/submit", func(ctx *gin.Context) {
+		SubmitDatasetVersionHandler(svc, ctx)
+	})
+
+	// Approve a dataset version in review
+	v1.POST("/datasets/:dataset_name/versions/:version/approve", func(ctx *gin.Context) {
+		ApproveDatasetVersionHandler(svc, ctx)
+	})
+
+	// Send a dataset version in review back to draft
+	v1.POST("/datasets/:dataset_name/versions/:version/reject", func(ctx *gin.Context) {
+		RejectDatasetVersionHandler(svc, ctx)
+	})
+
+	// Publish an approved dataset version
+	v1.POST("/datasets/:dataset_name/versions/:version/publish", func(ctx *gin.Context) {
+		PublishDatasetVersionHandler(svc, ctx)
+	})
+
+	// Get the frozen manifest of a published dataset version
+	v1.GET("/datasets/:dataset_name/versions/:version/manifest", func(ctx *gin.Context) {
+		GetDatasetVersionManifestHandler(svc, ctx)
+	})
+
+	// List the assets of a dataset version
+	v1.GET("/datasets/:dataset_name/versions/:version/assets", func(ctx *gin.Context) {
+		ListDatasetVersionAssetsHandler(svc, ctx)
+	})
+
+	// Add assets to a draft dataset version
+	v1.POST("/datasets/:dataset_name/versions/:version/assets", func(ctx *gin.Context) {
+		AddDatasetVersionAssetsHandler(svc, ctx)
+	})
+
+	// Remove assets from a draft dataset version
+	v1.DELETE("/datasets/:dataset_name/versions/:version/assets", func(ctx *gin.Context) {
+		RemoveDatasetVersionAssetsHandler(svc, ctx)
+	})
+
+	// Set the roles required to approve dataset versions
+	v1.PUT("/datasets/:dataset_name/approvers", func(ctx *gin.Context) {
+		PutDatasetApproversHandler(svc, ctx)
+	})
+
+	// Get a dataset readme, rendered for browsers
+	v1.GET("/datasets/:dataset_name/readme", func(ctx *gin.Context) {
+		GetDatasetReadmeHandler(svc, ctx)
+	})
+
+	// Replace a dataset readme
+	v1.PUT("/datasets/:dataset_name/readme", func(ctx *gin.Context) {
+		PutDatasetReadmeHandler(svc, ctx)
+	})
+
+	// Get the version a dataset channel points at
+	v1.GET("/datasets/:dataset_name/channels/:channel_name", func(ctx *gin.Context) {
+		GetDatasetChannelHandler(svc, ctx)
+	})
+
+	// Point a dataset channel at a published version
+	v1.PUT("/datasets/:dataset_name/channels/:channel_name", func(ctx *gin.Context) {
+		PutDatasetChannelHandler(svc, ctx)
+	})
+
+	// Get a dataset data dictionary
+	v1.GET("/datasets/:dataset_name/schema", func(ctx *gin.Context) {
+		GetDatasetSchemaHandler(svc, ctx)
+	})
+
+	// Store a new dataset data dictionary revision
+	v1.PUT("/datasets/:dataset_name/schema", func(ctx *gin.Context) {
+		PutDatasetSchemaHandler(svc, ctx)
+	})
+
+	// List the dataset data dictionary history
+	v1.GET("/datasets/:dataset_name/schema/history", func(ctx *gin.Context) {
+		ListDatasetSchemaHistoryHandler(svc, ctx)
+	})
+
+	// Trash
+	// List deleted assets
+	v1.GET("/trash/assets", func(ctx *gin.Context) {
+		ListTrashHandler(svc, ctx)
+	})
+
+	// Restore deleted assets
+	v1.POST("/trash/assets/restore", func(ctx *gin.Context) {
+		RestoreTrashHandler(svc, ctx)
+	})
+
+	// Permanently remove deleted assets, dry_run lists them with the bytes it would free
+	v1.POST("/trash/assets/purge", func(ctx *gin.Context) {
+		PurgeTrashHandler(svc, ctx)
+	})
+
+	// List the audit log of mutating operations
+	v1.GET("/audit", func(ctx *gin.Context) {
+		ListAuditHandler(svc, ctx)
+	})
+
+	// Admin
+	// List issued presigned urls
+	v1.GET("/admin/access-log", func(ctx *gin.Context) {
+		ListAccessLogHandler(svc, ctx)
+	})
+
+	// List registry events
+	v1.GET("/admin/events", func(ctx *gin.Context) {
+		ListEventsHandler(svc, ctx)
+	})
+
+	// Get metered project usage, JSON or CSV for chargeback
+	v1.GET("/admin/usage", func(ctx *gin.Context) {
+		ListUsageHandler(svc, ctx)
+	})
+
+	// Report the assets without an owner
+	v1.GET("/admin/ownerless-assets", func(ctx *gin.Context) {
+		ListOwnerlessAssetsHandler(svc, ctx)
+	})
+
+	// Start an ingress garbage collection run, dry_run lists the objects it would delete
+	v1.POST("/admin/gc", func(ctx *gin.Context) {
+		StartGarbageCollectionHandler(svc, ctx)
+	})
+
+	// List what a maintenance job run would remove, e.g. gc, deletion or partitions
+	v1.POST("/admin/jobs/:job_name/dry-run", func(ctx *gin.Context) {
+		DryRunJobHandler(svc, ctx)
+	})
+
+	// Get a project content policy
+	v1.GET("/admin/content-policies/:project", func(ctx *gin.Context) {
+		GetContentPolicyHandler(svc, ctx)
+	})
+
+	// Create or replace a project content policy
+	v1.PUT("/admin/content-policies/:project", func(ctx *gin.Context) {
+		PutContentPolicyHandler(svc, ctx)
+	})
+
+	// Remove a project content policy
+	v1.DELETE("/admin/content-policies/:project", func(ctx *gin.Context) {
+		DeleteContentPolicyHandler(svc, ctx)
+	})
+
+	// List license restrictions
+	v1.GET("/admin/license-restrictions", func(ctx *gin.Context) {
+		ListLicenseRestrictionsHandler(svc, ctx)
+	})
+
+	// Create or replace a license restriction
+	v1.PUT("/admin/license-restrictions/:license", func(ctx *gin.Context) {
+		PutLicenseRestrictionHandler(svc, ctx)
+	})
+
+	// Remove a license restriction
+	v1.DELETE("/admin/license-restrictions/:license", func(ctx *gin.Context) {
+		DeleteLicenseRestrictionHandler(svc, ctx)
+	})
+
+	// Issue an api key, the key is only returned once
+	v1.POST("/admin/api-keys", func(ctx *gin.Context) {
+		CreateAPIKeyHandler(svc, ctx)
+	})
+
+	// List api keys, revoked ones included
+	v1.GET("/admin/api-keys", func(ctx *gin.Context) {
+		ListAPIKeysHandler(svc, ctx)
+	})
+
+	// Revoke an api key
+	v1.DELETE("/admin/api-keys/:key_name", func(ctx *gin.Context) {
+		RevokeAPIKeyHandler(svc, ctx)
+	})
+
+	// Queue attaching or detaching a tag on every asset matching a filter
+	v1.POST("/admin/retag-jobs", func(ctx *gin.Context) {
+		CreateRetagJobHandler(svc, ctx)
+	})
+
+	// List retag jobs and their progress
+	v1.GET("/admin/retag-jobs", func(ctx *gin.Context) {
+		ListRetagJobsHandler(svc, ctx)
+	})
+
+	// Get a retag job progress
+	v1.GET("/admin/retag-jobs/:job_id", func(ctx *gin.Context) {
+		GetRetagJobHandler(svc, ctx)
+	})
+
+	// Cancel a pending or running retag job
+	v1.DELETE("/admin/retag-jobs/:job_id", func(ctx *gin.Context) {
+		CancelRetagJobHandler(svc, ctx)
+	})
+
+	// List principals and their assigned roles
+	v1.GET("/admin/roles", func(ctx *gin.Context) {
+		ListRoleAssignmentsHandler(svc, ctx)
+	})
+
+	// Assign a role to a principal, replacing its previous role
+	v1.PUT("/admin/roles/:principal", func(ctx *gin.Context) {
+		PutRoleAssignmentHandler(svc, ctx)
+	})
+
+	// Revoke the role assigned to a principal
+	v1.DELETE("/admin/roles/:principal", func(ctx *gin.Context) {
+		RevokeRoleAssignmentHandler(svc, ctx)
+	})
+
+	// Register a project, its assets, tags and datasets are kept apart from the other projects
+	v1.POST("/admin/projects", func(ctx *gin.Context) {
+		CreateProjectHandler(svc, ctx)
+	})
+
+	// Set the tags and extra template applied to every asset created in a project
+	v1.PUT("/admin/projects/:project/defaults", func(ctx *gin.Context) {
+		PutProjectDefaultsHandler(svc, ctx)
+	})
+
+	// Limit the bytes and objects a project stores, new uploads are rejected past the limit
+	v1.PUT("/admin/projects/:project/quota", func(ctx *gin.Context) {
+		PutProjectQuotaHandler(svc, ctx)
+	})
+
+	// Projects
+	// List projects
+	v1.GET("/projects", func(ctx *gin.Context) {
+		ListProjectsHandler(svc, ctx)
+	})
+
+	// Get the stored bytes and objects of the caller project with its quota
+	v1.GET("/projects/:project/usage", func(ctx *gin.Context) {
+		GetProjectUsageHandler(svc, ctx)
+	})
+
+	// Webhooks
+	// Register a webhook
+	v1.POST("/webhooks", func(ctx *gin.Context) {
+		CreateWebhookHandler(svc, ctx)
+	})
+
+	// List webhooks
+	v1.GET("/webhooks", func(ctx *gin.Context) {
+		ListWebhooksHandler(svc, ctx)
+	})
+
+	// Get a webhook
+	v1.GET("/webhooks/:webhook_name", func(ctx *gin.Context) {
+		GetWebhookHandler(svc, ctx)
+	})
+
+	// Update a webhook url, events, secret or state
+	v1.PATCH("/webhooks/:webhook_name", func(ctx *gin.Context) {
+		UpdateWebhookHandler(svc, ctx)
+	})
+
+	// Remove a webhook and its queued deliveries
+	v1.DELETE("/webhooks/:webhook_name", func(ctx *gin.Context) {
+		DeleteWebhookHandler(svc, ctx)
+	})
+
+	// List the latest deliveries of a webhook
+	v1.GET("/webhooks/:webhook_name/deliveries", func(ctx *gin.Context) {
+		ListWebhookDeliveriesHandler(svc, ctx)
+	})
+
+	// Storage
+	// Upload to a signed filesystem storage url
+	v1.PUT("/storage/*key", func(ctx *gin.Context) {
+		PutStorageObjectHandler(svc, ctx)
+	})
+
+	// Download from a signed filesystem storage url
+	v1.GET("/storage/*key", func(ctx *gin.Context) {
+		GetStorageObjectHandler(svc, ctx)
+	})
+
+	// Finalize uploads announced by bucket notification webhooks, outside /storage/ so the request size cap applies
+	v1.POST("/storage-events", func(ctx *gin.Context) {
+		PostStorageEventsHandler(svc, ctx)
+	})
+
 	// Batch
 	// Post assets
-	v1.POST("/batch/assets", func(ctx *gin.Context) {
+	v1.POST("/batch/assets", idempotent, func(ctx *gin.Context) {
 		CreateAssetsBatchHandler(svc, ctx)
 	})
 
@@ -70,4 +516,38 @@ func RegisterRoutes(r gin.IRouter, svc *data.Service) {
 	v1.GET("/batch/assets/ingress", func(ctx *gin.Context) {
 		GetAssetsBatchIngressHandler(svc, ctx)
 	})
+
+	// Staging
+	// Presign an upload of content whose checksum is computed while it streams
+	v1.POST("/staging", idempotent, func(ctx *gin.Context) {
+		CreateStagedUploadHandler(svc, ctx)
+	})
+
+	// Register a staged upload under its computed checksum and promote it
+	v1.POST("/staging/:staging_id/commit", idempotent, func(ctx *gin.Context) {
+		CommitStagedUploadHandler(svc, ctx)
+	})
+
+	// Replication, every request is signed by a peer instance
+	replication := v1.Group("/replication", middleware.PeerSignature(svc))
+
+	// Get the latest change and the last change the peer acknowledged
+	replication.GET("/watermark", func(ctx *gin.Context) {
+		GetReplicationWatermarkHandler(svc, ctx)
+	})
+
+	// Acknowledge the changes the peer applied
+	replication.PUT("/watermark", func(ctx *gin.Context) {
+		AckReplicationWatermarkHandler(svc, ctx)
+	})
+
+	// List asset changes after a watermark
+	replication.GET("/changes", func(ctx *gin.Context) {
+		ListReplicationChangesHandler(svc, ctx)
+	})
+
+	// Get a download url of a ready asset object
+	replication.GET("/assets/:asset_checksum/object", func(ctx *gin.Context) {
+		GetReplicationObjectHandler(svc, ctx)
+	})
 }
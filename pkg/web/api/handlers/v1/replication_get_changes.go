@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListReplicationChangesRequest struct {
+	Since string `json:"since" binding:"omitempty,max=100"`
+	Limit uint   `json:"limit" binding:"omitempty,gte=1,lte=1000"`
+}
+
+type ListReplicationChangesResponse struct {
+	dto.Response
+	Total     int                           `json:"total"`
+	NextSince *string                       `json:"next_since,omitempty"`
+	Changes   []*registry.ReplicationChange `json:"changes"`
+}
+
+func ListReplicationChangesHandler(svc *data.Service, ctx *gin.Context) {
+	var request ListReplicationChangesRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to list replication changes",
+			fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err),
+		)
+		return
+	}
+
+	changes, err := svc.ListReplicationChanges(ctx.Request.Context(), request.Since, request.Limit)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list replication changes", err)
+		return
+	}
+
+	// Success response
+	response := newListReplicationChangesResponse(ctx, changes, request.Limit)
+	dto.OK(ctx, response)
+}
+
+func newListReplicationChangesResponse(ctx *gin.Context, changes []*registry.ReplicationChange, limit uint) ListReplicationChangesResponse {
+	if limit == 0 {
+		limit = registry.SearchDefaultLimit
+	}
+
+	var nextSince *string
+	// Only include next_since if we got a full page (might be more)
+	if len(changes) == int(limit) && len(changes) > 0 {
+		nextSince = &changes[len(changes)-1].Watermark
+	}
+
+	response := ListReplicationChangesResponse{
+		Response:  *dto.NewResponse(ctx, "listed replication changes successfully"),
+		Total:     len(changes),
+		NextSince: nextSince,
+		Changes:   changes,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", len(changes),
+	)
+	return response
+}
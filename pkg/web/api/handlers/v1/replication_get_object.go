@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ReplicationObjectQuery struct {
+	dto.PresignQuery
+	// Project of the asset, the default project when empty
+	Project string `form:"project" binding:"omitempty,max=63"`
+}
+
+type ReplicationObjectResponse struct {
+	dto.Response
+	Checksum    string     `json:"checksum"`
+	DownloadURL string     `json:"download_url"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+func GetReplicationObjectHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.AssetUri
+	var query ReplicationObjectQuery
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to get replication object url",
+			fmt.Errorf("%w, %w", dto.ErrInvalidUri, err),
+		)
+		return
+	}
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to get replication object url",
+			fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err),
+		)
+		return
+	}
+
+	url, err := svc.GetReplicationObjectUrl(ctx.Request.Context(), query.Project, uri.AssetChecksum, query.Duration())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get replication object url", err)
+		return
+	}
+
+	// Success response
+	response := newReplicationObjectResponse(ctx, url)
+	dto.OK(ctx, response)
+}
+
+func newReplicationObjectResponse(ctx *gin.Context, url *registry.PresignedUrl) ReplicationObjectResponse {
+	response := ReplicationObjectResponse{
+		Response:    *dto.NewResponse(ctx, "got replication object url successfully"),
+		Checksum:    url.Checksum,
+		DownloadURL: url.URL.Value(),
+		ExpiresAt:   &url.ExpiresAt,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"checksum", url.Checksum,
+	)
+	return response
+}
@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ReplicationWatermarkResponse struct {
+	dto.Response
+	Watermark string `json:"watermark"`
+	Acked     string `json:"acked"`
+}
+
+func GetReplicationWatermarkHandler(svc *data.Service, ctx *gin.Context) {
+	watermarks, err := svc.GetReplicationWatermarks(ctx.Request.Context())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get replication watermark", err)
+		return
+	}
+
+	// Success response
+	response := ReplicationWatermarkResponse{
+		Response:  *dto.NewResponse(ctx, "got replication watermark successfully"),
+		Watermark: watermarks.Latest,
+		Acked:     watermarks.Acked,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"watermark", watermarks.Latest,
+		"acked", watermarks.Acked,
+	)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type AckReplicationWatermarkRequest struct {
+	Watermark string `json:"watermark" binding:"required,max=100"`
+}
+
+func AckReplicationWatermarkHandler(svc *data.Service, ctx *gin.Context) {
+	var request AckReplicationWatermarkRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to ack replication watermark",
+			fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err),
+		)
+		return
+	}
+
+	if err := svc.AckReplicationWatermark(ctx.Request.Context(), request.Watermark); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to ack replication watermark", err)
+		return
+	}
+
+	// Success response
+	response := dto.NewResponse(ctx, "acked replication watermark successfully")
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"watermark", request.Watermark,
+	)
+	response.NoContent(ctx)
+}
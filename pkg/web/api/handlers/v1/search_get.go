@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchQuery struct {
+	Query string `form:"q" binding:"required,min=1,max=200"`
+	Limit uint   `form:"limit" binding:"omitempty,gte=1,lte=1000"`
+	In    string `form:"in" binding:"omitempty,oneof=names content"`
+}
+
+type SearchResponse struct {
+	dto.Response
+	Total   int            `json:"total"`
+	Results []*SearchMatch `json:"results"`
+}
+
+type SearchMatch struct {
+	Rank  float64       `json:"rank"`
+	Asset *AssetDetails `json:"asset"`
+}
+
+func SearchHandler(svc *data.Service, ctx *gin.Context) {
+	var query SearchQuery
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to search assets",
+			fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err),
+		)
+		return
+	}
+
+	// names searches display and tag names, content the indexed document text
+	search := svc.SearchAssets
+	if query.In == "content" {
+		search = svc.SearchContents
+	}
+
+	matches, err := search(ctx.Request.Context(), query.Query, query.Limit)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to search assets", err)
+		return
+	}
+
+	// Success response
+	response := newSearchResponse(ctx, query.Query, matches)
+	dto.OK(ctx, response)
+}
+
+func newSearchResponse(ctx *gin.Context, query string, matches []*registry.AssetMatch) SearchResponse {
+	items := make([]*SearchMatch, 0, len(matches))
+	for _, m := range matches {
+		items = append(items, &SearchMatch{
+			Rank:  m.Rank,
+			Asset: newAssetDetails(m.Asset),
+		})
+	}
+
+	response := SearchResponse{
+		Response: *dto.NewResponse(ctx, "searched assets successfully"),
+		Total:    len(matches),
+		Results:  items,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"query", query,
+		"total", len(matches),
+	)
+	return response
+}
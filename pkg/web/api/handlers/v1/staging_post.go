@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type StagedUploadResponse struct {
+	dto.Response
+	StagingID string     `json:"staging_id"`
+	UploadURL string     `json:"upload_url"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ExpiresIn int64      `json:"expires_in,omitempty"`
+}
+
+func CreateStagedUploadHandler(svc *data.Service, ctx *gin.Context) {
+	var query dto.PresignQuery
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to create staged upload",
+			fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err),
+		)
+		return
+	}
+
+	// business logic
+	staged, err := svc.CreateStagedUpload(ctx.Request.Context(), query.Duration())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create staged upload", err)
+		return
+	}
+
+	// Success response
+	response := newStagedUploadResponse(ctx, staged)
+	dto.Created(ctx, response)
+}
+
+func newStagedUploadResponse(ctx *gin.Context, staged *data.StagedUpload) StagedUploadResponse {
+	response := StagedUploadResponse{
+		Response:  *dto.NewResponse(ctx, "created staged upload successfully"),
+		StagingID: staged.ID,
+		UploadURL: staged.Url.URL.Value(),
+		ExpiresAt: &staged.Url.ExpiresAt,
+		ExpiresIn: int64(staged.Url.ExpiresIn.Seconds()),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"staging", staged.ID,
+	)
+	return response
+}
@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// CommitStagedUploadRequest describes the asset of a staged upload,
+// its checksum and size are the ones the client computed while streaming
+type CommitStagedUploadRequest struct {
+	AssetPayload
+}
+
+func CommitStagedUploadHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.StagingUri
+	var req CommitStagedUploadRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to commit staged upload",
+			fmt.Errorf("%w, %w", dto.ErrInvalidUri, err),
+		)
+		return
+	}
+
+	// Bind JSON body
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to commit staged upload",
+			fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err),
+		)
+		return
+	}
+
+	record, err := assetPayload2Record(&req.AssetPayload)
+	if err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to commit staged upload",
+			fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err),
+		)
+		return
+	}
+
+	asset, err := svc.CommitStagedUpload(ctx.Request.Context(), uri.StagingID, record)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to commit staged upload", err)
+		return
+	}
+
+	// Success response, a committed upload is a finalized asset
+	response := newFinalizeAssetResponse(ctx, asset)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type StatsResponse struct {
+	dto.Response
+	TotalAssets int64                `json:"total_assets"`
+	TotalBytes  int64                `json:"total_bytes"`
+	StoredBytes int64                `json:"stored_bytes"`
+	ByState     []*StatsGroupDetails `json:"by_state"`
+	ByMimeType  []*StatsGroupDetails `json:"by_mime_type"`
+	ByTag       []*StatsGroupDetails `json:"by_tag"`
+}
+
+type StatsGroupDetails struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+	Bytes int64  `json:"bytes"`
+}
+
+func GetStatsHandler(svc *data.Service, ctx *gin.Context) {
+	stats, err := svc.GetStats(ctx.Request.Context())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get stats", err)
+		return
+	}
+
+	// Success response
+	response := newStatsResponse(ctx, stats)
+	dto.OK(ctx, response)
+}
+
+func newStatsGroupDetails(groups []registry.StatsGroup) []*StatsGroupDetails {
+	items := make([]*StatsGroupDetails, 0, len(groups))
+	for _, g := range groups {
+		items = append(items, &StatsGroupDetails{Key: g.Key, Count: g.Count, Bytes: g.Bytes})
+	}
+	return items
+}
+
+func newStatsResponse(ctx *gin.Context, stats *registry.AssetStats) StatsResponse {
+	response := StatsResponse{
+		Response:    *dto.NewResponse(ctx, "got stats successfully"),
+		TotalAssets: stats.TotalAssets,
+		TotalBytes:  stats.TotalBytes,
+		StoredBytes: stats.StoredBytes,
+		ByState:     newStatsGroupDetails(stats.ByState),
+		ByMimeType:  newStatsGroupDetails(stats.ByMimeType),
+		ByTag:       newStatsGroupDetails(stats.ByTag),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"assets", stats.TotalAssets,
+		"bytes", stats.TotalBytes,
+	)
+	return response
+}
@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// GetStorageObjectHandler streams objects of signed filesystem storage urls, ranges included
+func GetStorageObjectHandler(svc *data.Service, ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	file, err := svc.OpenStorageObject(ctx.Request.Context(), key, ctx.Request.URL.Query())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get object", err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get object", err)
+		return
+	}
+
+	// downloads can take much longer than the server write timeout
+	if err := http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		slog.WarnContext(ctx.Request.Context(), "failed to lift download write deadline", "error", err)
+	}
+
+	slog.InfoContext(ctx.Request.Context(), "serving object",
+		"key", key,
+		"size", info.Size(),
+	)
+
+	ctx.Header("Content-Type", "application/octet-stream")
+	http.ServeContent(ctx.Writer, ctx.Request, path.Base(key), info.ModTime(), file)
+}
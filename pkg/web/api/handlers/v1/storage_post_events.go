@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type StorageEventsResponse struct {
+	dto.Response
+	Finalized int `json:"finalized"`
+	Skipped   int `json:"skipped"`
+	Rejected  int `json:"rejected"`
+}
+
+// PostStorageEventsHandler receives bucket notification webhooks (MinIO) and finalizes the uploaded assets.
+// A failure worth retrying answers 500, so the notification is delivered again.
+func PostStorageEventsHandler(svc *data.Service, ctx *gin.Context) {
+	if err := svc.VerifyStorageEventToken(ctx.GetHeader("Authorization")); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to ingest storage events", err)
+		return
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to ingest storage events", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	notifications, err := registry.ParseObjectNotifications(body)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to ingest storage events", err)
+		return
+	}
+
+	report, err := svc.IngestObjectNotifications(ctx.Request.Context(), notifications)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to ingest storage events", err)
+		return
+	}
+
+	// Success response
+	response := StorageEventsResponse{
+		Response:  *dto.NewResponse(ctx, "ingested storage events successfully"),
+		Finalized: report.Finalized,
+		Skipped:   report.Skipped,
+		Rejected:  report.Rejected,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"records", len(notifications),
+		"finalized", report.Finalized,
+		"skipped", report.Skipped,
+		"rejected", report.Rejected,
+	)
+	dto.OK(ctx, response)
+}
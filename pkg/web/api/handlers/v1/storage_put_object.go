@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type PutStorageObjectResponse struct {
+	dto.Response
+	Key       string `json:"key"`
+	Checksum  string `json:"checksum"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// PutStorageObjectHandler receives uploads to signed filesystem storage urls
+func PutStorageObjectHandler(svc *data.Service, ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	// uploads can take much longer than the server read timeout
+	if err := http.NewResponseController(ctx.Writer).SetReadDeadline(time.Time{}); err != nil {
+		slog.WarnContext(ctx.Request.Context(), "failed to lift upload read deadline", "error", err)
+	}
+
+	info, err := svc.WriteStorageObject(ctx.Request.Context(), key, ctx.Request.URL.Query(), ctx.Request.Body)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to store object", err)
+		return
+	}
+
+	// Success response
+	response := PutStorageObjectResponse{
+		Response:  *dto.NewResponse(ctx, "stored object successfully"),
+		Key:       info.Key,
+		Checksum:  info.Checksum,
+		SizeBytes: info.Size,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"key", info.Key,
+		"size", info.Size,
+	)
+
+	ctx.Header("ETag", `"`+info.ETag+`"`)
+	dto.OK(ctx, response)
+}
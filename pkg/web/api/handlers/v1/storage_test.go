@@ -0,0 +1,57 @@
+package v1_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/apierrors"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+	"github.com/UnivocalX/aether/pkg/web/webtest"
+)
+
+// TestUnsizedUploadStopsAtProjectLimit uploads more than the project allows to an ingress url signed without a size,
+// the upload fails with the project limit instead of waiting for finalize
+func TestUnsizedUploadStopsAtProjectLimit(t *testing.T) {
+	h := webtest.New(t, nil)
+	if _, ok := h.Engine.Storage().(*registry.FilesystemStorage); !ok {
+		t.Skip("storage urls are served by the api only with the filesystem storage backend")
+	}
+
+	if err := h.Engine.SaveContentPolicyRecord(&registry.ContentPolicy{Project: registry.DEFAULT_PROJECT, MaxSizeBytes: 8}); err != nil {
+		t.Fatal(err)
+	}
+
+	content := bytes.Repeat([]byte("a"), 64)
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	var created v1.AssetsBatchResponse
+	request := v1.CreateAssetsBatchRequest{Assets: []v1.AssetPayload{{Checksum: checksum, Display: "big.bin"}}}
+	h.DoJSON(http.MethodPost, "/batch/assets", request, http.StatusCreated, &created)
+	if len(created.Assets) != 1 || created.Assets[0].IngressUrl.Value() == "" {
+		t.Fatalf("create asset %s: no ingress url", checksum)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, created.Assets[0].IngressUrl.Value(), bytes.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := h.Send(req)
+	var body dto.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusRequestEntityTooLarge || body.Code != apierrors.ObjectTooLarge {
+		t.Fatalf("upload got status %d code %s, want %d %s", resp.StatusCode, body.Code, http.StatusRequestEntityTooLarge, apierrors.ObjectTooLarge)
+	}
+
+	if info, err := h.Engine.StatObject(t.Context(), h.Engine.IngressKey(checksum)); info != nil || err != nil {
+		t.Errorf("ingress object after failed upload got %+v, %v, want nothing stored", info, err)
+	}
+}
@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type GetTagResponse struct {
+	dto.Response
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Key         string `json:"key,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Description string `json:"description,omitempty"`
+	Color       string `json:"color,omitempty"`
+	CreatedBy   string `json:"created_by,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+func GetTagHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.TagUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to get tag",
+			fmt.Errorf("%w, %w", dto.ErrInvalidUri, err),
+		)
+		return
+	}
+
+	tag, err := svc.GetTag(ctx.Request.Context(), uri.TagName)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get tag", err)
+		return
+	}
+
+	if dto.NotModified(ctx, fmt.Sprintf("%d-%d", tag.ID, tag.UpdatedAt.UnixNano())) {
+		return
+	}
+
+	// Success response
+	response := newGetTagResponse(ctx, tag)
+	dto.OK(ctx, response)
+}
+
+func newGetTagResponse(ctx *gin.Context, tag *registry.Tag) GetTagResponse {
+	return newTagResponse(ctx, "got tag successfully", tag)
+}
+
+func newTagResponse(ctx *gin.Context, msg string, tag *registry.Tag) GetTagResponse {
+	response := GetTagResponse{
+		Response:    *dto.NewResponse(ctx, msg),
+		ID:          tag.ID,
+		Name:        tag.Name,
+		Key:         tag.Key,
+		Value:       tag.Value,
+		Description: tag.Description,
+		Color:       tag.Color,
+		CreatedBy:   tag.CreatedBy,
+		CreatedAt:   tag.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   tag.UpdatedAt.Format(time.RFC3339),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"name", tag.Name,
+	)
+	return response
+}
@@ -61,7 +61,7 @@ func ListTagAssetsHandler(svc *data.Service, ctx *gin.Context) {
 
 	// Success response
 	response := newListTagAssetsResponse(ctx, assets, request.Limit, request.Offset)
-	response.OK(ctx)
+	dto.OK(ctx, response)
 }
 
 func newListTagAssetsResponse(ctx *gin.Context, assets []*registry.Asset, limit uint, offset uint) ListTagAssetsResponse {
@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteTagGroupHandler removes a group, its tags and their assets are kept
+func DeleteTagGroupHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.TagGroupUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to delete tag group", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	if err := svc.DeleteTagGroup(ctx.Request.Context(), uri.GroupName); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to delete tag group", err)
+		return
+	}
+
+	// Success response
+	response := dto.NewResponse(ctx, "deleted tag group successfully")
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"group", uri.GroupName,
+	)
+
+	response.NoContent(ctx)
+}
@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+func GetTagGroupHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.TagGroupUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get tag group", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	group, err := svc.GetTagGroup(ctx.Request.Context(), uri.GroupName)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get tag group", err)
+		return
+	}
+
+	// Success response
+	response := newTagGroupResponse(ctx, "got tag group successfully", group)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListTagGroupsResponse struct {
+	dto.Response
+	Total  int               `json:"total"`
+	Groups []TagGroupDetails `json:"groups"`
+}
+
+func ListTagGroupsHandler(svc *data.Service, ctx *gin.Context) {
+	groups, err := svc.ListTagGroups(ctx.Request.Context())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list tag groups", err)
+		return
+	}
+
+	// Success response
+	response := ListTagGroupsResponse{
+		Response: *dto.NewResponse(ctx, "listed tag groups successfully"),
+		Total:    len(groups),
+		Groups:   make([]TagGroupDetails, 0, len(groups)),
+	}
+	for _, group := range groups {
+		response.Groups = append(response.Groups, newTagGroupDetails(group))
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", response.Total,
+	)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateTagGroupRequest makes Tags mutually exclusive, missing tags are created
+type CreateTagGroupRequest struct {
+	Name        string   `json:"name" binding:"required,min=1,max=100"`
+	Description string   `json:"description" binding:"omitempty,max=2000"`
+	Tags        []string `json:"tags" binding:"required,min=2,max=100,dive,min=1,max=100"`
+}
+
+type TagGroupDetails struct {
+	ID          uint     `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags"`
+	CreatedBy   string   `json:"created_by,omitempty"`
+}
+
+type TagGroupResponse struct {
+	dto.Response
+	TagGroupDetails
+}
+
+func CreateTagGroupHandler(svc *data.Service, ctx *gin.Context) {
+	var request CreateTagGroupRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create tag group", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	group := &registry.TagGroup{Name: request.Name, Description: request.Description}
+	if err := svc.CreateTagGroup(ctx.Request.Context(), group, request.Tags); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create tag group", err)
+		return
+	}
+
+	// Success response
+	response := newTagGroupResponse(ctx, "created tag group successfully", group)
+	dto.Created(ctx, response)
+}
+
+func newTagGroupDetails(group *registry.TagGroup) TagGroupDetails {
+	details := TagGroupDetails{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		Tags:        make([]string, 0, len(group.Tags)),
+		CreatedBy:   group.CreatedBy,
+	}
+	for _, tag := range group.Tags {
+		details.Tags = append(details.Tags, tag.Name)
+	}
+	return details
+}
+
+func newTagGroupResponse(ctx *gin.Context, msg string, group *registry.TagGroup) TagGroupResponse {
+	response := TagGroupResponse{
+		Response:        *dto.NewResponse(ctx, msg),
+		TagGroupDetails: newTagGroupDetails(group),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"group", group.Name,
+		"tags", response.Tags,
+	)
+	return response
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateTagGroupRequest replaces the group description and tags, tags left out become ungrouped
+type UpdateTagGroupRequest struct {
+	Description string   `json:"description" binding:"omitempty,max=2000"`
+	Tags        []string `json:"tags" binding:"required,min=2,max=100,dive,min=1,max=100"`
+}
+
+func UpdateTagGroupHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.TagGroupUri
+	var request UpdateTagGroupRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to update tag group", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to update tag group", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	group, err := svc.UpdateTagGroup(ctx.Request.Context(), uri.GroupName, request.Description, request.Tags)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to update tag group", err)
+		return
+	}
+
+	// Success response
+	response := newTagGroupResponse(ctx, "updated tag group successfully", group)
+	dto.OK(ctx, response)
+}
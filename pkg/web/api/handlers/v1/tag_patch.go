@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateTagRequest edits tag metadata, omitted fields are kept and empty strings clear them
+type UpdateTagRequest struct {
+	Description *string `json:"description" binding:"omitempty,max=2000"`
+	Color       *string `json:"color" binding:"omitempty,max=7"`
+}
+
+func UpdateTagHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.TagUri
+	var request UpdateTagRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to update tag",
+			fmt.Errorf("%w, %w", dto.ErrInvalidUri, err),
+		)
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to update tag",
+			fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err),
+		)
+		return
+	}
+
+	tag, err := svc.UpdateTag(ctx.Request.Context(), uri.TagName, data.TagPatch{
+		Description: request.Description,
+		Color:       request.Color,
+	})
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to update tag", err)
+		return
+	}
+
+	// Success response
+	response := newTagResponse(ctx, "updated tag successfully", tag)
+	dto.OK(ctx, response)
+}
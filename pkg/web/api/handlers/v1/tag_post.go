@@ -11,13 +11,20 @@ import (
 )
 
 type CreateTagRequest struct {
-	Name string `json:"name" binding:"required,min=1,max=100"`
+	Name        string `json:"name" binding:"required,min=1,max=100"`
+	Description string `json:"description" binding:"omitempty,max=2000"`
+	Color       string `json:"color" binding:"omitempty,max=7"`
 }
 
 type AddTagResponse struct {
 	dto.Response
-	ID   uint   `json:"id"`
-	Name string `json:"name"`
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Key         string `json:"key,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Description string `json:"description,omitempty"`
+	Color       string `json:"color,omitempty"`
+	CreatedBy   string `json:"created_by,omitempty"`
 }
 
 func CreateTagHandler(svc *data.Service, ctx *gin.Context) {
@@ -34,21 +41,26 @@ func CreateTagHandler(svc *data.Service, ctx *gin.Context) {
 	}
 
 	// Execute business logic
-	tag, err := svc.CreateTag(ctx.Request.Context(), request.Name)
+	tag, err := svc.CreateTag(ctx.Request.Context(), request.Name, request.Description, request.Color)
 	if err != nil {
 		dto.HandleErrorResponse(ctx, "failed to add tag", err)
 		return
 	}
 
 	response := newAddTagResponse(ctx, tag)
-	response.Created(ctx)
+	dto.Created(ctx, response)
 }
 
 func newAddTagResponse(ctx *gin.Context, tag *registry.Tag) AddTagResponse {
 	response := AddTagResponse{
-		Response: *dto.NewResponse(ctx, "tag added successfully"),
-		ID:       tag.ID,
-		Name:     tag.Name,
+		Response:    *dto.NewResponse(ctx, "tag added successfully"),
+		ID:          tag.ID,
+		Name:        tag.Name,
+		Key:         tag.Key,
+		Value:       tag.Value,
+		Description: tag.Description,
+		Color:       tag.Color,
+		CreatedBy:   tag.CreatedBy,
 	}
 	slog.InfoContext(ctx.Request.Context(), response.Msg,
 		"Id", tag.ID,
@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type TagAssetsRequest struct {
+	Checksums []string `json:"checksums" binding:"required,min=1,max=10000,dive,len=64,hexadecimal"`
+}
+
+type TagAssetsResponse struct {
+	dto.Response
+	Tag       string              `json:"tag"`
+	Requested int                 `json:"requested"`
+	Changed   int64               `json:"changed"`
+	Missing   []string            `json:"missing"`
+	Detached  []DetachedTagDetail `json:"detached,omitempty"`
+}
+
+// DetachedTagDetail is a tag of the same group removed from an asset by the attach
+type DetachedTagDetail struct {
+	Checksum string `json:"checksum"`
+	Group    string `json:"group"`
+	Tag      string `json:"tag"`
+}
+
+func TagAssetsHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.TagUri
+	var request TagAssetsRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to tag assets", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to tag assets", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	result, err := svc.TagAssets(ctx.Request.Context(), uri.TagName, request.Checksums...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to tag assets", err)
+		return
+	}
+
+	// Success response
+	response := newTagAssetsResponse(ctx, "tagged assets successfully", result, len(request.Checksums))
+	dto.OK(ctx, response)
+}
+
+func UntagAssetsHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.TagUri
+	var request TagAssetsRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to untag assets", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to untag assets", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	result, err := svc.UntagAssets(ctx.Request.Context(), uri.TagName, request.Checksums...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to untag assets", err)
+		return
+	}
+
+	// Success response
+	response := newTagAssetsResponse(ctx, "untagged assets successfully", result, len(request.Checksums))
+	dto.OK(ctx, response)
+}
+
+func newTagAssetsResponse(ctx *gin.Context, msg string, result *data.TagBatchResult, requested int) TagAssetsResponse {
+	response := TagAssetsResponse{
+		Response:  *dto.NewResponse(ctx, msg),
+		Tag:       result.Tag.Name,
+		Requested: requested,
+		Changed:   result.Changed,
+		Missing:   result.Missing,
+	}
+	for _, swap := range result.Swaps {
+		response.Detached = append(response.Detached, DetachedTagDetail{
+			Checksum: swap.Checksum,
+			Group:    swap.Group,
+			Tag:      swap.Detached,
+		})
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"tag", result.Tag.Name,
+		"requested", requested,
+		"changed", result.Changed,
+		"missing", len(result.Missing),
+		"detached", len(response.Detached),
+	)
+	return response
+}
@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListTrashRequest struct {
+	PageToken string `json:"page_token" binding:"omitempty,max=4096"`
+	Limit     uint   `json:"limit" binding:"omitempty,gte=1,lte=1000"`
+}
+
+func ListTrashHandler(svc *data.Service, ctx *gin.Context) {
+	var request ListTrashRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to list trash",
+			fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err),
+		)
+		return
+	}
+
+	// the trash has no filters, tokens only carry the cursor
+	var state struct{}
+	cursor, err := svc.DecodePageToken(request.PageToken, &state)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list trash", err)
+		return
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = registry.SearchDefaultLimit
+	}
+
+	opts := []registry.SearchAssetsOption{registry.WithLimit(limit), registry.WithCursor(cursor)}
+
+	assets, err := svc.ListTrash(ctx.Request.Context(), opts...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list trash", err)
+		return
+	}
+
+	token, err := nextPageToken(svc, len(assets), limit, lastAssetID(assets), state)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list trash", err)
+		return
+	}
+
+	// Success response
+	response := newListAssetsResponse(ctx, assets, token)
+	response.Msg = "listed trash successfully"
+	dto.OK(ctx, response)
+}
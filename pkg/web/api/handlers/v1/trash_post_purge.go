@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// PurgeTrashRequest with dry_run lists the assets and bytes a purge would remove instead of purging
+type PurgeTrashRequest struct {
+	TrashAssetsRequest
+	DryRun bool `json:"dry_run"`
+}
+
+func PurgeTrashHandler(svc *data.Service, ctx *gin.Context) {
+	var request PurgeTrashRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to purge assets",
+			fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err),
+		)
+		return
+	}
+
+	if request.DryRun {
+		plan, err := svc.PlanPurgeAssets(ctx.Request.Context(), request.Checksums...)
+		if err != nil {
+			dto.HandleErrorResponse(ctx, "failed to plan purge", err)
+			return
+		}
+		dto.OK(ctx, newDryRunResponse(ctx, "planned purge successfully", plan))
+		return
+	}
+
+	assets, err := svc.PurgeAssets(ctx.Request.Context(), request.Checksums...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to purge assets", err)
+		return
+	}
+
+	// Success response
+	response := newTrashAssetsResponse(ctx, "purged assets successfully", assets)
+	dto.OK(ctx, response)
+}
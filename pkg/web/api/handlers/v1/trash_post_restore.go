@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type TrashAssetsRequest struct {
+	Checksums []string `json:"checksums" binding:"required,min=1,max=1000,dive,len=64,hexadecimal"`
+}
+
+type TrashAssetsResponse struct {
+	dto.Response
+	Total  int                  `json:"total"`
+	Assets []*TrashAssetDetails `json:"assets"`
+}
+
+type TrashAssetDetails struct {
+	Checksum string `json:"checksum"`
+	State    string `json:"state"`
+}
+
+func RestoreTrashHandler(svc *data.Service, ctx *gin.Context) {
+	var request TrashAssetsRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(
+			ctx,
+			"failed to restore assets",
+			fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err),
+		)
+		return
+	}
+
+	assets, err := svc.RestoreAssets(ctx.Request.Context(), request.Checksums...)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to restore assets", err)
+		return
+	}
+
+	// Success response
+	response := newTrashAssetsResponse(ctx, "restored assets successfully", assets)
+	dto.OK(ctx, response)
+}
+
+func newTrashAssetsResponse(ctx *gin.Context, msg string, assets []*registry.Asset) TrashAssetsResponse {
+	items := make([]*TrashAssetDetails, len(assets))
+	for i, asset := range assets {
+		items[i] = &TrashAssetDetails{
+			Checksum: asset.Checksum,
+			State:    string(asset.State),
+		}
+	}
+
+	response := TrashAssetsResponse{
+		Response: *dto.NewResponse(ctx, msg),
+		Total:    len(items),
+		Assets:   items,
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", len(items),
+	)
+	return response
+}
@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+func DeleteWebhookHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.WebhookUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to delete webhook", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	if err := svc.DeleteWebhook(ctx.Request.Context(), uri.WebhookName); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to delete webhook", err)
+		return
+	}
+
+	// Success response
+	response := dto.NewResponse(ctx, "deleted webhook successfully")
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"webhook", uri.WebhookName,
+	)
+
+	response.NoContent(ctx)
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookDetails describes a webhook, the signing secret is never returned after creation
+type WebhookDetails struct {
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Disabled  bool      `json:"disabled"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func GetWebhookHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.WebhookUri
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get webhook", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	webhook, err := svc.GetWebhook(ctx.Request.Context(), uri.WebhookName)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to get webhook", err)
+		return
+	}
+
+	// Success response
+	response := newWebhookResponse(ctx, "got webhook successfully", webhook)
+	dto.OK(ctx, response)
+}
+
+func newWebhookDetails(webhook *registry.Webhook) *WebhookDetails {
+	return &WebhookDetails{
+		Name:      webhook.Name,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		Disabled:  webhook.Disabled,
+		CreatedBy: webhook.CreatedBy,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+func newWebhookResponse(ctx *gin.Context, msg string, webhook *registry.Webhook) WebhookResponse {
+	response := WebhookResponse{
+		Response:       *dto.NewResponse(ctx, msg),
+		WebhookDetails: newWebhookDetails(webhook),
+	}
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"webhook", webhook.Name,
+		"events", webhook.Events,
+		"disabled", webhook.Disabled,
+	)
+	return response
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListWebhookDeliveriesQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending delivered failed"`
+	Limit  uint   `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
+
+type WebhookDeliveryDetails struct {
+	ID            uint            `json:"id"`
+	Event         string          `json:"event"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	ResponseCode  int             `json:"response_code,omitempty"`
+	LastError     string          `json:"last_error,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at,omitempty"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+}
+
+type ListWebhookDeliveriesResponse struct {
+	dto.Response
+	Webhook    string                    `json:"webhook"`
+	Total      int                       `json:"total"`
+	Deliveries []*WebhookDeliveryDetails `json:"deliveries"`
+}
+
+func ListWebhookDeliveriesHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.WebhookUri
+	var query ListWebhookDeliveriesQuery
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list webhook deliveries", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind query parameters
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list webhook deliveries", fmt.Errorf("%w, %w", dto.ErrInvalidQuery, err))
+		return
+	}
+
+	deliveries, err := svc.ListWebhookDeliveries(ctx.Request.Context(), uri.WebhookName, registry.DeliveryStatus(query.Status), query.Limit)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list webhook deliveries", err)
+		return
+	}
+
+	// Success response
+	response := ListWebhookDeliveriesResponse{
+		Response:   *dto.NewResponse(ctx, "listed webhook deliveries successfully"),
+		Webhook:    uri.WebhookName,
+		Total:      len(deliveries),
+		Deliveries: make([]*WebhookDeliveryDetails, 0, len(deliveries)),
+	}
+	for _, d := range deliveries {
+		details := &WebhookDeliveryDetails{
+			ID:           d.ID,
+			Event:        d.Event,
+			Status:       string(d.Status),
+			Attempts:     d.Attempts,
+			ResponseCode: d.ResponseCode,
+			LastError:    d.LastError,
+			Payload:      json.RawMessage(d.Payload),
+			CreatedAt:    d.CreatedAt,
+			DeliveredAt:  d.DeliveredAt,
+		}
+		if d.Status == registry.DeliveryPending {
+			details.NextAttemptAt = &d.NextAttemptAt
+		}
+		response.Deliveries = append(response.Deliveries, details)
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"webhook", uri.WebhookName,
+		"total", response.Total,
+	)
+	dto.OK(ctx, response)
+}
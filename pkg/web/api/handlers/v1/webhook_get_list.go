@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"log/slog"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+type ListWebhooksResponse struct {
+	dto.Response
+	Total    int               `json:"total"`
+	Webhooks []*WebhookDetails `json:"webhooks"`
+}
+
+func ListWebhooksHandler(svc *data.Service, ctx *gin.Context) {
+	webhooks, err := svc.ListWebhooks(ctx.Request.Context())
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to list webhooks", err)
+		return
+	}
+
+	// Success response
+	response := ListWebhooksResponse{
+		Response: *dto.NewResponse(ctx, "listed webhooks successfully"),
+		Total:    len(webhooks),
+		Webhooks: make([]*WebhookDetails, 0, len(webhooks)),
+	}
+	for _, webhook := range webhooks {
+		response.Webhooks = append(response.Webhooks, newWebhookDetails(webhook))
+	}
+
+	slog.InfoContext(ctx.Request.Context(), response.Msg,
+		"total", response.Total,
+	)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateWebhookRequest edits a webhook, omitted fields are kept
+type UpdateWebhookRequest struct {
+	URL      *string  `json:"url" binding:"omitempty,url,max=2000"`
+	Events   []string `json:"events" binding:"omitempty,min=1,max=20,dive,min=1,max=100"`
+	Secret   *string  `json:"secret" binding:"omitempty,min=16,max=200"`
+	Disabled *bool    `json:"disabled"`
+}
+
+func UpdateWebhookHandler(svc *data.Service, ctx *gin.Context) {
+	var uri dto.WebhookUri
+	var request UpdateWebhookRequest
+
+	// Bind URI parameters
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to update webhook", fmt.Errorf("%w, %w", dto.ErrInvalidUri, err))
+		return
+	}
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to update webhook", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	patch := data.WebhookPatch{
+		URL:      request.URL,
+		Events:   request.Events,
+		Disabled: request.Disabled,
+	}
+	if request.Secret != nil {
+		secret := registry.Secret(*request.Secret)
+		patch.Secret = &secret
+	}
+
+	webhook, err := svc.UpdateWebhook(ctx.Request.Context(), uri.WebhookName, patch)
+	if err != nil {
+		dto.HandleErrorResponse(ctx, "failed to update webhook", err)
+		return
+	}
+
+	// Success response
+	response := newWebhookResponse(ctx, "updated webhook successfully", webhook)
+	dto.OK(ctx, response)
+}
@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateWebhookRequest registers a webhook, a signing secret is generated when none is given
+type CreateWebhookRequest struct {
+	Name     string   `json:"name" binding:"required,min=1,max=100"`
+	URL      string   `json:"url" binding:"required,url,max=2000"`
+	Events   []string `json:"events" binding:"required,min=1,max=20,dive,min=1,max=100"`
+	Secret   string   `json:"secret" binding:"omitempty,min=16,max=200"`
+	Disabled bool     `json:"disabled"`
+}
+
+type WebhookResponse struct {
+	dto.Response
+	*WebhookDetails
+}
+
+// CreateWebhookResponse is the only response carrying the signing secret
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+func CreateWebhookHandler(svc *data.Service, ctx *gin.Context) {
+	var request CreateWebhookRequest
+
+	// Bind JSON payload
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create webhook", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+		return
+	}
+
+	webhook := &registry.Webhook{
+		Name:     request.Name,
+		URL:      request.URL,
+		Events:   request.Events,
+		Secret:   registry.Secret(request.Secret),
+		Disabled: request.Disabled,
+	}
+
+	if err := svc.CreateWebhook(ctx.Request.Context(), webhook); err != nil {
+		dto.HandleErrorResponse(ctx, "failed to create webhook", err)
+		return
+	}
+
+	// Success response
+	response := CreateWebhookResponse{
+		WebhookResponse: newWebhookResponse(ctx, "webhook created successfully", webhook),
+		Secret:          webhook.Secret.Value(),
+	}
+	dto.Created(ctx, response)
+}
@@ -0,0 +1,233 @@
+// Package openapi builds an OpenAPI 3.1 document from the request and response types of the api handlers.
+// Schemas are derived with reflection from the same structs the handlers bind and encode, so the document
+// follows the types instead of drifting from them.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const Version = "3.1.0"
+
+// Document is an OpenAPI document
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Security   []map[string][]string `json:"security,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem holds the operations of a path by lower case method
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []*Parameter        `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// Raw documents a body that is not JSON by its media type, e.g. Raw("application/octet-stream")
+type Raw string
+
+// Route documents one handler. Uri, Query and Body are zero values of the structs the handler binds with
+// ShouldBindUri, ShouldBindQuery and ShouldBindJSON, Responses the values it answers with by status.
+// A nil response has no body.
+type Route struct {
+	Method    string
+	Path      string
+	ID        string
+	Summary   string
+	Uri       any
+	Query     any
+	Body      any
+	Responses map[int]any
+}
+
+// Build documents routes, their paths are relative to base and use gin parameters, e.g. /assets/:asset_checksum.
+// errorResponse is the body of every failed request.
+func Build(info Info, base string, errorResponse any, routes ...Route) *Document {
+	g := newGenerator()
+	doc := &Document{
+		OpenAPI:  Version,
+		Info:     info,
+		Servers:  []Server{{URL: base}},
+		Security: []map[string][]string{{"apiKey": {}}},
+		Paths:    make(map[string]PathItem),
+		Components: Components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]*SecurityScheme{
+				"apiKey": {Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+
+	errorSchema := g.schema(reflect.TypeOf(errorResponse))
+	for _, route := range routes {
+		path, params := pathParameters(route.Path)
+		op := &Operation{
+			OperationID: route.ID,
+			Summary:     route.Summary,
+			Tags:        []string{tagOf(route.Path)},
+			Responses:   make(map[string]Response),
+		}
+
+		uri := g.parameters(route.Uri, "uri", "path")
+		for _, name := range params {
+			param, ok := uri[name]
+			if !ok {
+				param = &Parameter{Name: name, In: "path", Schema: &Schema{Type: "string"}}
+			}
+			param.Required = true
+			op.Parameters = append(op.Parameters, param)
+		}
+		query := g.parameters(route.Query, "form", "query")
+		for _, name := range sortedKeys(query) {
+			op.Parameters = append(op.Parameters, query[name])
+		}
+
+		if route.Body != nil {
+			// list requests carry optional filters in the body of a GET
+			op.RequestBody = &RequestBody{Required: route.Method != http.MethodGet, Content: g.content(route.Body)}
+		}
+
+		for status, body := range route.Responses {
+			response := Response{Description: http.StatusText(status)}
+			if body != nil {
+				response.Content = g.content(body)
+			}
+			op.Responses[strconv.Itoa(status)] = response
+		}
+		op.Responses["default"] = Response{
+			Description: "Error",
+			Content:     map[string]MediaType{"application/json": {Schema: errorSchema}},
+		}
+
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = op
+	}
+	return doc
+}
+
+// Undocumented returns the routes of registered, written as "METHOD path" with gin parameters, missing from doc
+func (d *Document) Undocumented(base string, registered ...string) []string {
+	var missing []string
+	for _, route := range registered {
+		method, path, _ := strings.Cut(route, " ")
+		rel, ok := strings.CutPrefix(path, base)
+		if !ok {
+			continue
+		}
+		rel, _ = pathParameters(rel)
+		if _, ok := d.Paths[rel][strings.ToLower(method)]; !ok {
+			missing = append(missing, route)
+		}
+	}
+	return missing
+}
+
+func (g *generator) content(body any) map[string]MediaType {
+	if raw, ok := body.(Raw); ok {
+		return map[string]MediaType{string(raw): {Schema: &Schema{Type: "string", Format: "binary"}}}
+	}
+	return map[string]MediaType{"application/json": {Schema: g.schema(reflect.TypeOf(body))}}
+}
+
+// parameters reads the path or query parameters of a struct from the tag binding them
+func (g *generator) parameters(v any, tag string, in string) map[string]*Parameter {
+	params := make(map[string]*Parameter)
+	if v == nil {
+		return params
+	}
+
+	for _, f := range fields(reflect.TypeOf(v), tag) {
+		params[f.name] = &Parameter{
+			Name:     f.name,
+			In:       in,
+			Required: f.required,
+			Schema:   g.field(f),
+		}
+	}
+	return params
+}
+
+// pathParameters rewrites gin parameters as OpenAPI templates and returns their names in order
+func pathParameters(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var names []string
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			names = append(names, s[1:])
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), names
+}
+
+// tagOf groups operations by the first segment of their path
+func tagOf(path string) string {
+	first, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return first
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
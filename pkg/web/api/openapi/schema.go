@@ -0,0 +1,267 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is a JSON schema as used by OpenAPI 3.1
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	durationType      = reflect.TypeFor[time.Duration]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+	// component names drop the characters generic type names add
+	unsafeName = regexp.MustCompile(`[^A-Za-z0-9_.]+`)
+)
+
+// generator collects the named structs it meets as components
+type generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newGenerator() *generator {
+	return &generator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// field is a struct field as encoded or bound under tag
+type field struct {
+	name     string
+	typ      reflect.Type
+	required bool
+	rules    []string
+}
+
+// schema returns the schema of t, named structs are referenced as components
+func (g *generator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == durationType:
+		return &Schema{Type: "integer", Format: "int64"}
+	case t.Kind() == reflect.Struct && t.Name() != "" && !marshals(t):
+		return &Schema{Ref: "#/components/schemas/" + g.component(t)}
+	case implements(t, jsonMarshalerType):
+		// custom encodings, e.g. raw JSON documents, are kept open unless they encode a string
+		if t.Kind() == reflect.String {
+			return &Schema{Type: "string"}
+		}
+		return &Schema{}
+	case implements(t, textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s := &Schema{Type: "integer"}
+		if t.Kind() == reflect.Int64 || t.Kind() == reflect.Uint64 {
+			s.Format = "int64"
+		}
+		return s
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		return g.object(t)
+	default:
+		// interfaces hold any value
+		return &Schema{}
+	}
+}
+
+// component registers the object schema of a named struct once and returns its name
+func (g *generator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := unsafeName.ReplaceAllString(t.Name(), "")
+	if _, taken := g.schemas[name]; taken {
+		// structs of other packages may share a name
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = unsafeName.ReplaceAllString(pkg, "") + "." + name
+	}
+
+	// registered before the fields so recursive types reference themselves
+	g.names[t] = name
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.object(t)
+	return name
+}
+
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, f := range fields(t, "json") {
+		s.Properties[f.name] = g.field(f)
+		if f.required {
+			s.Required = append(s.Required, f.name)
+		}
+	}
+	return s
+}
+
+// field returns the schema of a field with the constraints of its binding tag
+func (g *generator) field(f field) *Schema {
+	s := g.schema(f.typ)
+	if s.Ref != "" {
+		return s
+	}
+
+	for _, rule := range f.rules {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "dive":
+			// the rules that follow apply to the elements
+			return s
+		case "oneof":
+			s.Enum = strings.Fields(value)
+		case "hexadecimal":
+			s.Pattern = "^[0-9a-fA-F]*$"
+		case "len":
+			s.bound(value, value)
+		case "min", "gte":
+			s.bound(value, "")
+		case "max", "lte":
+			s.bound("", value)
+		}
+	}
+	return s
+}
+
+// bound sets the length, item count or value bounds the schema type supports
+func (s *Schema) bound(min string, max string) {
+	parse := func(raw string) *float64 {
+		if raw == "" {
+			return nil
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil
+		}
+		return &v
+	}
+	count := func(v *float64) *int {
+		if v == nil {
+			return nil
+		}
+		n := int(*v)
+		return &n
+	}
+
+	lo, hi := parse(min), parse(max)
+	switch s.Type {
+	case "string":
+		s.MinLength, s.MaxLength = or(count(lo), s.MinLength), or(count(hi), s.MaxLength)
+	case "array":
+		s.MinItems, s.MaxItems = or(count(lo), s.MinItems), or(count(hi), s.MaxItems)
+	case "integer", "number":
+		s.Minimum, s.Maximum = or(lo, s.Minimum), or(hi, s.Maximum)
+	}
+}
+
+func or[T any](v *T, fallback *T) *T {
+	if v != nil {
+		return v
+	}
+	return fallback
+}
+
+// fields lists the fields of a struct under tag the way encoding/json and gin see them:
+// embedded structs without a tag are flattened, fields tagged "-" and unexported fields are skipped
+func fields(t reflect.Type, tag string) []field {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var out []field
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		raw, tagged := sf.Tag.Lookup(tag)
+		name, _, _ := strings.Cut(raw, ",")
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && !tagged && ft.Kind() == reflect.Struct {
+			out = append(out, fields(ft, tag)...)
+			continue
+		}
+		if !sf.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			if tag != "json" {
+				// uri and form bindings only read tagged fields
+				continue
+			}
+			name = sf.Name
+		}
+
+		rules := strings.Split(sf.Tag.Get("binding"), ",")
+		required := false
+		for _, rule := range rules {
+			if rule == "dive" {
+				break
+			}
+			required = required || rule == "required"
+		}
+		out = append(out, field{name: name, typ: sf.Type, required: required, rules: rules})
+	}
+	return out
+}
+
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// marshals reports whether a struct has its own encoding
+func marshals(t reflect.Type) bool {
+	return implements(t, jsonMarshalerType) || implements(t, textMarshalerType)
+}
@@ -0,0 +1,75 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadyTimeout bounds each dependency check of the readiness probe
+var ReadyTimeout = 2 * time.Second
+
+// HealthPaths are the probe endpoints, served outside /api and without authentication
+var HealthPaths = []string{"/healthz", "/livez", "/readyz"}
+
+// HealthResponse reports the probe status and, for readiness, the result of every dependency check.
+// Probes are unauthenticated so failures are only detailed in the server log.
+type HealthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// healthCheck is a dependency the server needs to serve traffic
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+func (s *Server) registerHealthRoutes() {
+	// process up, for load balancers
+	s.Router.GET("/healthz", s.live)
+	// process up, for kubernetes liveness probes, restarting won't fix a dependency so none is checked
+	s.Router.GET("/livez", s.live)
+	// listening with the database and storage reachable, for kubernetes readiness probes
+	s.Router.GET("/readyz", s.readyz)
+}
+
+func (s *Server) live(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+func (s *Server) readyz(c *gin.Context) {
+	if !s.ready.Load() {
+		c.JSON(http.StatusServiceUnavailable, HealthResponse{Status: "starting"})
+		return
+	}
+
+	checks := []healthCheck{
+		{name: "database", check: s.Registry.PingDatabase},
+		{name: "storage", check: s.Registry.PingStorage},
+	}
+
+	response := HealthResponse{Status: "ok", Checks: make(map[string]string, len(checks))}
+	for _, hc := range checks {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), ReadyTimeout)
+		err := hc.check(ctx)
+		cancel()
+
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "readiness check failed", "check", hc.name, "error", err)
+			response.Status = "unavailable"
+			response.Checks[hc.name] = "failed"
+			continue
+		}
+		response.Checks[hc.name] = "ok"
+	}
+
+	if response.Status != "ok" {
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// AdminPaths need the admin scope whatever the method
+var AdminPaths = []string{"/api/v1/admin", "/api/v1/webhooks", "/api/v1/audit", "/api/debug"}
+
+// APIKey authenticates bearer api keys, the key becomes the request principal.
+// Paths under any of the skip prefixes are left alone, they carry their own signatures or tokens.
+func APIKey(svc *data.Service, skip ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, prefix := range skip {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		key, err := svc.Authenticate(c.Request.Context(), c.GetHeader("Authorization"), RequiredScope(c.Request.Method, c.FullPath()))
+		if err != nil {
+			if errors.Is(err, data.ErrUnauthenticated) || errors.Is(err, registry.ErrInvalidAPIKey) {
+				c.Header("WWW-Authenticate", `Bearer realm="aether"`)
+			}
+			dto.HandleErrorResponse(c, "failed to authenticate", err)
+			c.Abort()
+			return
+		}
+
+		if key != nil {
+			principal := data.PrincipalFrom(c.Request.Context())
+			principal.ID = "key:" + key.Name
+			principal.Roles = key.Roles
+			if key.Project != "" {
+				if header := registry.NormalizeString(c.GetHeader(ProjectHeader)); header != "" && header != key.Project {
+					dto.HandleErrorResponse(c, "failed to authenticate",
+						fmt.Errorf("%w: key %s belongs to project %s", data.ErrProjectDenied, key.Name, key.Project))
+					c.Abort()
+					return
+				}
+				principal.Project = key.Project
+			}
+			c.Request = c.Request.WithContext(data.WithPrincipal(c.Request.Context(), principal))
+		}
+		c.Next()
+	}
+}
+
+// RequiredScope is the api key scope a route needs, the one of its action, see RequiredAction.
+// Unmatched requests, with an empty route, need read for safe methods and write otherwise before answering 404.
+func RequiredScope(method string, route string) string {
+	if route == "" {
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return registry.ScopeRead
+		default:
+			return registry.ScopeWrite
+		}
+	}
+	return ActionScope(RequiredAction(method, route))
+}
+
+// ActionScope is the api key scope of an action: read for reads, admin for admin actions and write otherwise
+func ActionScope(action registry.Action) string {
+	switch action {
+	case registry.ActionRead:
+		return registry.ScopeRead
+	case registry.ActionAdmin:
+		return registry.ScopeAdmin
+	default:
+		return registry.ScopeWrite
+	}
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// routeActions maps the routes that are not plain reads to the action they perform, by method and route path
+var routeActions = map[string]registry.Action{
+	// reads sent as POST
+	"POST /api/v1/assets/exists":        registry.ActionRead,
+	"POST /api/v1/assets/download-urls": registry.ActionRead,
+
+	// uploads and asset edits
+	"GET /api/v1/assets/:asset_checksum/ingress":   registry.ActionCreateAsset,
+	"PATCH /api/v1/assets/:asset_checksum":         registry.ActionCreateAsset,
+	"POST /api/v1/assets/:asset_checksum/finalize": registry.ActionCreateAsset,
+	"POST /api/v1/batch/assets":                    registry.ActionCreateAsset,
+	"GET /api/v1/batch/assets/ingress":             registry.ActionCreateAsset,
+	"POST /api/v1/staging":                         registry.ActionCreateAsset,
+	"POST /api/v1/staging/:staging_id/commit":      registry.ActionCreateAsset,
+
+	// tagging and classification
+	"PUT /api/v1/assets/:asset_checksum/classification":    registry.ActionTag,
+	"PUT /api/v1/assets/:asset_checksum/tags/:tag_name":    registry.ActionTag,
+	"DELETE /api/v1/assets/:asset_checksum/tags/:tag_name": registry.ActionTag,
+	"POST /api/v1/tags/:tag_name/assets":                   registry.ActionTag,
+	"DELETE /api/v1/tags/:tag_name/assets":                 registry.ActionTag,
+	"POST /api/v1/tags":                                    registry.ActionTag,
+	"PATCH /api/v1/tags/:tag_name":                         registry.ActionTag,
+	"POST /api/v1/tag-groups":                              registry.ActionTag,
+	"PUT /api/v1/tag-groups/:group_name":                   registry.ActionTag,
+	"DELETE /api/v1/tag-groups/:group_name":                registry.ActionTag,
+
+	// deletion and trash
+	"DELETE /api/v1/assets/:asset_checksum":                  registry.ActionDelete,
+	"POST /api/v1/assets/:asset_checksum/deletion/objection": registry.ActionDelete,
+	"DELETE /api/v1/assets/:asset_checksum/deletion":         registry.ActionDelete,
+	"POST /api/v1/trash/assets/restore":                      registry.ActionDelete,
+	"POST /api/v1/trash/assets/purge":                        registry.ActionDelete,
+
+	// dataset drafts
+	"POST /api/v1/datasets":                                          registry.ActionEditDataset,
+	"POST /api/v1/datasets/:dataset_name/versions":                   registry.ActionEditDataset,
+	"POST /api/v1/datasets/:dataset_name/versions/:version/submit":   registry.ActionEditDataset,
+	"POST /api/v1/datasets/:dataset_name/versions/:version/assets":   registry.ActionEditDataset,
+	"DELETE /api/v1/datasets/:dataset_name/versions/:version/assets": registry.ActionEditDataset,
+	"PUT /api/v1/datasets/:dataset_name/readme":                      registry.ActionEditDataset,
+	"PUT /api/v1/datasets/:dataset_name/schema":                      registry.ActionEditDataset,
+
+	// dataset review and publication
+	"POST /api/v1/datasets/:dataset_name/versions/:version/approve": registry.ActionPublishDataset,
+	"POST /api/v1/datasets/:dataset_name/versions/:version/reject":  registry.ActionPublishDataset,
+	"POST /api/v1/datasets/:dataset_name/versions/:version/publish": registry.ActionPublishDataset,
+	"PUT /api/v1/datasets/:dataset_name/approvers":                  registry.ActionPublishDataset,
+	"PUT /api/v1/datasets/:dataset_name/channels/:channel_name":     registry.ActionPublishDataset,
+}
+
+// Authorize checks the request principal roles allow the action of the route, see RequiredAction.
+// Paths under any of the skip prefixes are left alone, they carry their own signatures or tokens.
+func Authorize(svc *data.Service, skip ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, prefix := range skip {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		// unmatched routes answer 404 on their own
+		if c.FullPath() == "" {
+			c.Next()
+			return
+		}
+
+		if err := svc.Authorize(c.Request.Context(), RequiredAction(c.Request.Method, c.FullPath())); err != nil {
+			dto.HandleErrorResponse(c, "failed to authorize", err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequiredAction is the action a route performs: admin for admin paths, read for safe methods and
+// the mapped action otherwise. Unmapped writes need admin, so new routes fail closed.
+func RequiredAction(method string, route string) registry.Action {
+	for _, prefix := range AdminPaths {
+		if route == prefix || strings.HasPrefix(route, prefix+"/") {
+			return registry.ActionAdmin
+		}
+	}
+
+	if action, ok := routeActions[method+" "+route]; ok {
+		return action
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return registry.ActionRead
+	default:
+		return registry.ActionAdmin
+	}
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+const (
+	// IdempotencyKeyHeader lets clients retry a request without running it twice
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader marks a response replayed from an earlier request with the same key
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// Idempotency runs a request sent with an Idempotency-Key once per caller and key, its retries get the stored response.
+// Retrying with the key while the first request runs fails with 409, reusing the key for another request with 422.
+// Failures a retry may fix, server errors and throttling, are not stored. Responses holding presigned urls
+// are replayed only until the first of them expires, see responseExpiry.
+func Idempotency(svc *data.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			dto.HandleErrorResponse(c, "failed to read request", err)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		record, err := svc.ClaimIdempotencyKey(ctx, key, requestFingerprint(c.Request, body))
+		if err != nil {
+			dto.HandleErrorResponse(c, "failed to claim idempotency key", err)
+			c.Abort()
+			return
+		}
+		if record != nil {
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(record.StatusCode, record.ContentType, record.Body)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// a panicking handler leaves no response to store, the key is released for a retry
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := svc.ReleaseIdempotencyKey(ctx, key); err != nil {
+				slog.Warn("failed to release idempotency key", "key", key, "error", err)
+			}
+		}()
+
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			return
+		}
+		contentType, response := recorder.Header().Get("Content-Type"), recorder.body.Bytes()
+		if err := svc.CompleteIdempotencyKey(ctx, key, status, contentType, response, responseExpiry(contentType, response)); err != nil {
+			slog.Warn("failed to store idempotent response", "key", key, "error", err)
+			return
+		}
+		completed = true
+	}
+}
+
+// requestFingerprint identifies a request by method, target and body
+func requestFingerprint(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, req.Method+" "+req.URL.RequestURI()+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseExpiry is the shortest expires_in found anywhere in a json response, zero when there is none
+func responseExpiry(contentType string, body []byte) time.Duration {
+	if !strings.HasPrefix(contentType, "application/json") {
+		return 0
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return 0
+	}
+
+	var shortest float64
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			for name, field := range v {
+				if seconds, ok := field.(float64); ok && name == "expires_in" && seconds > 0 && (shortest == 0 || seconds < shortest) {
+					shortest = seconds
+				}
+				walk(field)
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(value)
+	return time.Duration(shortest * float64(time.Second))
+}
+
+// responseRecorder keeps a copy of the response body written through it
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
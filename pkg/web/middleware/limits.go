@@ -2,12 +2,21 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
-func MaxRequestSizeLimit(maxBytes int64) gin.HandlerFunc {
+// MaxRequestSizeLimit caps request bodies, paths under any of the skip prefixes stream without a cap
+func MaxRequestSizeLimit(maxBytes int64, skip ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		for _, prefix := range skip {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
 		c.Request.Body = http.MaxBytesReader(
 			c.Writer,
 			c.Request.Body,
@@ -59,8 +59,9 @@ func (w *slogWriter) Write(p []byte) (int, error) {
 // logging middle ware logs HTTP requests using slog.LogAttrs which respects default handler
 func Logger() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Common attrs (no request_id for now)
+		// Common attrs
 		common := []slog.Attr{
+			slog.String("request_id", c.GetString("requestId")),
 			slog.String("client_ip", getClientIP(c)),
 			slog.String("method", c.Request.Method),
 			slog.String("path", c.Request.RequestURI),
@@ -74,14 +75,9 @@ func Logger() gin.HandlerFunc {
 	}
 }
 
-// getClientIP gets the client IP address
+// getClientIP gets the client IP address, the address of the connection without its port.
+// X-Forwarded-For and X-Real-IP are only read from the proxies the router trusts, see Server.TrustProxies,
+// any other caller could name an address of its choosing in them.
 func getClientIP(c *gin.Context) string {
-	// Try different headers for client IP
-	if ip := c.Request.Header.Get("X-Forwarded-For"); ip != "" {
-		return ip
-	}
-	if ip := c.Request.Header.Get("X-Real-IP"); ip != "" {
-		return ip
-	}
-	return c.Request.RemoteAddr
+	return c.ClientIP()
 }
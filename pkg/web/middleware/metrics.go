@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/UnivocalX/aether/pkg/metrics"
+)
+
+// Metrics observes the latency of every request by method, route and status.
+// Requests matching no route share the "unmatched" route, so unknown paths can't grow the series.
+func Metrics(reg prometheus.Registerer) gin.HandlerFunc {
+	requests, err := metrics.Register(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "aether_http_request_duration_seconds",
+		Help: "HTTP request latencies, by method, route and status.",
+	}, []string{"method", "route", "status"}))
+	if err != nil {
+		slog.Error("failed to register request metrics", "error", err)
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		requests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
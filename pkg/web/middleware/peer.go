@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// PeerSignature only lets through replication requests signed by a peer,
+// the peer becomes the request principal
+func PeerSignature(svc *data.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			dto.HandleErrorResponse(c, "failed to verify peer", fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		peer, err := svc.VerifyPeerRequest(
+			c.Request.Context(),
+			c.Request.Method,
+			c.Request.URL.RequestURI(),
+			c.GetHeader(registry.REPLICATION_PEER_HEADER),
+			c.GetHeader(registry.REPLICATION_TIMESTAMP_HEADER),
+			c.GetHeader(registry.REPLICATION_SIGNATURE_HEADER),
+			body,
+		)
+		if err != nil {
+			dto.HandleErrorResponse(c, "failed to verify peer", err)
+			c.Abort()
+			return
+		}
+
+		principal := data.PrincipalFrom(c.Request.Context())
+		principal.ID = "peer:" + peer.Name
+
+		ctx := data.WithPrincipal(c.Request.Context(), principal)
+		c.Request = c.Request.WithContext(data.WithPeer(ctx, peer))
+		c.Next()
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// ProjectHeader selects the project of a request, the default project when absent
+const ProjectHeader = "X-Aether-Project"
+
+// Principal attaches the caller identity and project to the request context,
+// services read it back for scoping and auditing
+func Principal() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := data.Principal{
+			ID:        data.AnonymousPrincipal,
+			ClientIP:  getClientIP(c),
+			UserAgent: c.Request.UserAgent(),
+			RequestID: c.GetString("requestId"),
+			Project:   registry.NormalizeString(c.GetHeader(ProjectHeader)),
+		}
+
+		c.Request = c.Request.WithContext(data.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// Project rejects requests made in a project that does not exist.
+// Paths under any of the skip prefixes are left alone, they carry their own signatures or tokens.
+func Project(svc *data.Service, skip ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, prefix := range skip {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		if err := svc.CheckProject(c.Request.Context()); err != nil {
+			dto.HandleErrorResponse(c, "failed to resolve project", err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
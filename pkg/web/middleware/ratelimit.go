@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// RateLimit rejects callers that spent their request tokens with 429 and Retry-After, see data.RateLimiter.
+// It runs after APIKey so key holders are limited per key. Paths under any of the skip prefixes are not limited.
+func RateLimit(svc *data.Service, skip ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, prefix := range skip {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		wait, err := svc.AllowRequest(c.Request.Context())
+		if err != nil {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			dto.HandleErrorResponse(c, "too many requests", err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the request id, callers may set it to correlate their own logs
+const RequestIDHeader = "X-Request-Id"
+
+// RequestID tags every request with an id, the caller's one when it is sane, and echoes it back.
+// Responses and the audit log carry it.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := EnsureRequestID(c.GetHeader(RequestIDHeader))
+
+		c.Set("requestId", id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// EnsureRequestID returns the caller's request id when it is sane, a new one otherwise
+func EnsureRequestID(id string) string {
+	if !validRequestID(id) {
+		return newRequestID()
+	}
+	return id
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 100 {
+		return false
+	}
+	for _, r := range id {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	raw := make([]byte, 16)
+	_, _ = rand.Read(raw)
+	return hex.EncodeToString(raw)
+}
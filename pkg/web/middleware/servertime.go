@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ServerTimeHeader carries the server clock, clients compare it with theirs to detect skew
+const ServerTimeHeader = "X-Aether-Server-Time"
+
+// ServerTime stamps every response with the time the server received the request, in milliseconds
+func ServerTime() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header(ServerTimeHeader, time.Now().UTC().Format("2006-01-02T15:04:05.000Z07:00"))
+		c.Next()
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/UnivocalX/aether/pkg/tracing"
+)
+
+// Tracing starts a server span for every request, continuing the trace of the traceparent header,
+// and tags it with the request id. Use it after RequestID.
+// Handlers pass the request context down, so database statements, S3 calls and presigning join the trace.
+func Tracing(provider trace.TracerProvider) gin.HandlersChain {
+	if provider == nil {
+		return gin.HandlersChain{func(c *gin.Context) { c.Next() }}
+	}
+
+	return gin.HandlersChain{
+		otelgin.Middleware("aether",
+			otelgin.WithTracerProvider(provider),
+			otelgin.WithPropagators(tracing.Propagator),
+		),
+		func(c *gin.Context) {
+			if id := c.GetString("requestId"); id != "" {
+				trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.String("aether.request_id", id))
+			}
+			c.Next()
+		},
+	}
+}
@@ -0,0 +1,185 @@
+package rpc
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+	"github.com/UnivocalX/aether/pkg/web/rpc/aetherv1"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+type assetServer struct {
+	aetherv1.UnimplementedAssetServiceServer
+	svc *data.Service
+}
+
+func (s *assetServer) GetAsset(ctx context.Context, req *aetherv1.GetAssetRequest) (*aetherv1.Asset, error) {
+	if err := validate(&dto.AssetUri{AssetChecksum: req.GetChecksum()}); err != nil {
+		return nil, err
+	}
+
+	asset, err := s.svc.GetAsset(ctx, req.GetChecksum())
+	if err != nil {
+		return nil, err
+	}
+	return toAsset(asset), nil
+}
+
+// ListAssets pages through assets like GET /api/v1/assets, page tokens of both apis are interchangeable
+func (s *assetServer) ListAssets(ctx context.Context, req *aetherv1.ListAssetsRequest) (*aetherv1.ListAssetsResponse, error) {
+	request := v1.ListAssetsRequest{
+		PageToken: req.GetPageToken(),
+		Limit:     uint(req.GetLimit()),
+		ListAssetsFilters: v1.ListAssetsFilters{
+			MimeType:     req.GetMimeType(),
+			State:        req.GetState(),
+			IncludedTags: req.GetIncludedTags(),
+			ExcludedTags: req.GetExcludedTags(),
+			Licenses:     req.GetLicenses(),
+			Owners:       req.GetOwners(),
+			Ownerless:    req.GetOwnerless(),
+			Sort:         req.GetSort(),
+			Order:        req.GetOrder(),
+		},
+	}
+	if err := validate(&request); err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.svc.DecodePageToken(request.PageToken, &request.ListAssetsFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := cmp.Or(request.Limit, registry.SearchDefaultLimit)
+	opts := append(v1.ToSearchOptions(&request), registry.WithCursor(cursor), registry.WithLimit(limit))
+
+	assets, err := s.svc.ListAssets(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := nextPageToken(s.svc, assets, limit, request.ListAssetsFilters)
+	if err != nil {
+		return nil, err
+	}
+	return &aetherv1.ListAssetsResponse{Assets: toAssets(assets), NextPageToken: token}, nil
+}
+
+func (s *assetServer) CreateAssets(ctx context.Context, req *aetherv1.CreateAssetsRequest) (*aetherv1.CreateAssetsResponse, error) {
+	return s.createAssets(ctx, req)
+}
+
+// StreamCreateAssets registers the batches of a stream one after the other. A failed batch, e.g. one over
+// the size limit, ends the stream, failed items are reported in the response of their batch.
+func (s *assetServer) StreamCreateAssets(stream aetherv1.AssetService_StreamCreateAssetsServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.createAssets(stream.Context(), req)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// createAssets registers a batch like POST /api/v1/batch/assets
+func (s *assetServer) createAssets(ctx context.Context, req *aetherv1.CreateAssetsRequest) (*aetherv1.CreateAssetsResponse, error) {
+	request := v1.CreateAssetsBatchRequest{
+		Assets:    make([]v1.AssetPayload, len(req.GetAssets())),
+		ExpiresIn: uint(req.GetExpiresIn()),
+	}
+	for i, asset := range req.GetAssets() {
+		request.Assets[i] = v1.AssetPayload{
+			Checksum:       asset.GetChecksum(),
+			Display:        asset.GetDisplay(),
+			MimeType:       asset.GetMimeType(),
+			SizeBytes:      asset.GetSizeBytes(),
+			License:        asset.GetLicense(),
+			UsageNotes:     asset.GetUsageNotes(),
+			Owner:          asset.GetOwner(),
+			Classification: asset.GetClassification(),
+			Extra:          asset.GetExtra().AsMap(),
+		}
+	}
+	if err := validate(&request); err != nil {
+		return nil, err
+	}
+
+	// invalid items are reported individually
+	records, indices, failures := v1.AssetsBatchRecords(&request)
+
+	results, itemErrors, err := s.svc.CreateAssets(ctx, time.Duration(request.ExpiresIn)*time.Second, records...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &aetherv1.CreateAssetsResponse{}
+	for _, r := range results {
+		resp.Assets = append(resp.Assets, &aetherv1.CreatedAsset{
+			Index:         int32(indices[r.Index]),
+			Asset:         toAsset(r.Asset),
+			IngressUrl:    toPresignedUrl(r.Url),
+			SuggestedTags: r.SuggestedTags,
+			TagsApplied:   r.TagsApplied,
+		})
+	}
+	for _, e := range itemErrors {
+		e.Index = indices[e.Index]
+	}
+	for _, e := range append(failures, itemErrors...) {
+		resp.Errors = append(resp.Errors, toItemError(e))
+	}
+	return resp, nil
+}
+
+func (s *assetServer) GetIngressUrl(ctx context.Context, req *aetherv1.GetIngressUrlRequest) (*aetherv1.PresignedUrl, error) {
+	if err := validate(&dto.AssetUri{AssetChecksum: req.GetChecksum()}); err != nil {
+		return nil, err
+	}
+
+	url, err := s.svc.GetAssetIngressUrl(ctx, req.GetChecksum(), time.Duration(req.GetExpiresIn())*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return toPresignedUrl(url), nil
+}
+
+func (s *assetServer) GetEgressUrl(ctx context.Context, req *aetherv1.GetEgressUrlRequest) (*aetherv1.PresignedUrl, error) {
+	if err := validate(&dto.AssetUri{AssetChecksum: req.GetChecksum()}); err != nil {
+		return nil, err
+	}
+
+	url, err := s.svc.GetAssetEgressUrl(ctx, req.GetChecksum(), time.Duration(req.GetExpiresIn())*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return toPresignedUrl(url), nil
+}
+
+func (s *assetServer) FinalizeAsset(ctx context.Context, req *aetherv1.FinalizeAssetRequest) (*aetherv1.Asset, error) {
+	if err := validate(&dto.AssetUri{AssetChecksum: req.GetChecksum()}); err != nil {
+		return nil, err
+	}
+
+	asset, err := s.svc.FinalizeAsset(ctx, req.GetChecksum())
+	if err != nil {
+		return nil, err
+	}
+	return toAsset(asset), nil
+}
@@ -0,0 +1,169 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/middleware"
+	"github.com/UnivocalX/aether/pkg/web/rpc/aetherv1"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// methodActions maps the methods to the action they perform, unmapped methods need admin so new methods fail closed
+var methodActions = map[string]registry.Action{
+	aetherv1.AssetService_GetAsset_FullMethodName:           registry.ActionRead,
+	aetherv1.AssetService_ListAssets_FullMethodName:         registry.ActionRead,
+	aetherv1.AssetService_GetEgressUrl_FullMethodName:       registry.ActionRead,
+	aetherv1.AssetService_CreateAssets_FullMethodName:       registry.ActionCreateAsset,
+	aetherv1.AssetService_StreamCreateAssets_FullMethodName: registry.ActionCreateAsset,
+	aetherv1.AssetService_GetIngressUrl_FullMethodName:      registry.ActionCreateAsset,
+	aetherv1.AssetService_FinalizeAsset_FullMethodName:      registry.ActionCreateAsset,
+
+	aetherv1.TagService_GetTag_FullMethodName:      registry.ActionRead,
+	aetherv1.TagService_CreateTag_FullMethodName:   registry.ActionTag,
+	aetherv1.TagService_TagAssets_FullMethodName:   registry.ActionTag,
+	aetherv1.TagService_UntagAssets_FullMethodName: registry.ActionTag,
+
+	aetherv1.DatasetService_ListVersionAssets_FullMethodName:    registry.ActionRead,
+	aetherv1.DatasetService_CreateDataset_FullMethodName:        registry.ActionEditDataset,
+	aetherv1.DatasetService_CreateDatasetVersion_FullMethodName: registry.ActionEditDataset,
+	aetherv1.DatasetService_AddVersionAssets_FullMethodName:     registry.ActionEditDataset,
+	aetherv1.DatasetService_RemoveVersionAssets_FullMethodName:  registry.ActionEditDataset,
+}
+
+// authenticate attaches the caller principal to ctx and runs the checks of the REST middlewares:
+// api key, rate limit, project and role. Metadata keys are the lower case REST headers.
+func authenticate(ctx context.Context, svc *data.Service, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	get := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	principal := data.Principal{
+		ID:        data.AnonymousPrincipal,
+		UserAgent: get("user-agent"),
+		RequestID: middleware.EnsureRequestID(get(strings.ToLower(middleware.RequestIDHeader))),
+		Project:   registry.NormalizeString(get(strings.ToLower(middleware.ProjectHeader))),
+		ClientIP:  peerIP(ctx),
+	}
+
+	action, ok := methodActions[method]
+	if !ok {
+		action = registry.ActionAdmin
+	}
+
+	key, err := svc.Authenticate(data.WithPrincipal(ctx, principal), get("authorization"), middleware.ActionScope(action))
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		principal.ID = "key:" + key.Name
+		principal.Roles = key.Roles
+		if key.Project != "" {
+			if principal.Project != "" && principal.Project != key.Project {
+				return nil, fmt.Errorf("%w: key %s belongs to project %s", data.ErrProjectDenied, key.Name, key.Project)
+			}
+			principal.Project = key.Project
+		}
+	}
+	ctx = data.WithPrincipal(ctx, principal)
+
+	if _, err := svc.AllowRequest(ctx); err != nil {
+		return nil, err
+	}
+	if err := svc.CheckProject(ctx); err != nil {
+		return nil, err
+	}
+	if err := svc.Authorize(ctx, action); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// peerIP is the address of the caller connection without its port, like the client address of REST requests.
+// Every connection of an anonymous caller shares its rate limit, probe quota and idempotency records.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
+func unaryInterceptor(svc *data.Service) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		authenticated, err := authenticate(ctx, svc, info.FullMethod)
+		if err != nil {
+			err = statusOf(err)
+			logCall(ctx, info.FullMethod, start, err)
+			return nil, err
+		}
+
+		resp, err := handler(authenticated, req)
+		err = statusOf(err)
+		logCall(authenticated, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func streamInterceptor(svc *data.Service) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		authenticated, err := authenticate(stream.Context(), svc, info.FullMethod)
+		if err != nil {
+			err = statusOf(err)
+			logCall(stream.Context(), info.FullMethod, start, err)
+			return err
+		}
+
+		err = statusOf(handler(srv, &principalStream{ServerStream: stream, ctx: authenticated}))
+		logCall(authenticated, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// principalStream hands the authenticated context to stream handlers
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
+
+// logCall logs a finished call like the REST access log does
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	principal := data.PrincipalFrom(ctx)
+	attrs := []any{
+		"method", method,
+		"code", status.Code(err).String(),
+		"duration", time.Since(start).String(),
+		"principal", principal.ID,
+		"request_id", principal.RequestID,
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "rpc failed", append(attrs, "error", err)...)
+		return
+	}
+	slog.InfoContext(ctx, "rpc handled", attrs...)
+}
@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/peer"
+)
+
+// TestPeerIPWithoutPort checks the connections of a caller share one address whatever their port
+func TestPeerIPWithoutPort(t *testing.T) {
+	for _, tt := range []struct {
+		addr net.Addr
+		want string
+	}{
+		{addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.7"), Port: 40001}, want: "192.0.2.7"},
+		{addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.7"), Port: 40002}, want: "192.0.2.7"},
+		{addr: &net.TCPAddr{IP: net.ParseIP("2001:db8::7"), Port: 40003}, want: "2001:db8::7"},
+		{addr: &net.UnixAddr{Name: "/run/aether.sock", Net: "unix"}, want: "/run/aether.sock"},
+	} {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tt.addr})
+		if got := peerIP(ctx); got != tt.want {
+			t.Errorf("peerIP(%s) = %q, want %q", tt.addr, got, tt.want)
+		}
+	}
+
+	if got := peerIP(context.Background()); got != "" {
+		t.Errorf("peerIP without peer = %q, want empty", got)
+	}
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/datatypes"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/rpc/aetherv1"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// validate checks a request with the binding rules of the REST payload it mirrors
+func validate(payloads ...any) error {
+	for _, payload := range payloads {
+		if err := binding.Validator.ValidateStruct(payload); err != nil {
+			return fmt.Errorf("%w, %w", dto.ErrInvalidPayload, err)
+		}
+	}
+	return nil
+}
+
+// nextPageToken issues the token of the page after a full one, like the REST listings do
+func nextPageToken(svc *data.Service, assets []*registry.Asset, limit uint, state any) (string, error) {
+	if len(assets) == 0 || len(assets) < int(limit) {
+		return "", nil
+	}
+	return svc.EncodePageToken(assets[len(assets)-1].ID, state)
+}
+
+func toAsset(asset *registry.Asset) *aetherv1.Asset {
+	tags := make([]string, 0, len(asset.Tags))
+	for _, tag := range asset.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	return &aetherv1.Asset{
+		Id:             uint64(asset.ID),
+		Checksum:       asset.Checksum,
+		Display:        asset.Display,
+		MimeType:       asset.MimeType,
+		SizeBytes:      asset.SizeBytes,
+		State:          string(asset.State),
+		License:        asset.License,
+		UsageNotes:     asset.UsageNotes,
+		Owner:          asset.Owner,
+		Classification: string(asset.Classification),
+		Tags:           tags,
+		Extra:          toStruct(asset.Extra),
+		CreatedAt:      timestamppb.New(asset.CreatedAt),
+		UpdatedAt:      timestamppb.New(asset.UpdatedAt),
+	}
+}
+
+func toAssets(assets []*registry.Asset) []*aetherv1.Asset {
+	out := make([]*aetherv1.Asset, len(assets))
+	for i, asset := range assets {
+		out[i] = toAsset(asset)
+	}
+	return out
+}
+
+// toStruct converts the extra of an asset, nil when it has none or it is not an object
+func toStruct(extra datatypes.JSON) *structpb.Struct {
+	if len(extra) == 0 {
+		return nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(extra, &fields); err != nil {
+		return nil
+	}
+	s, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil
+	}
+	return s
+}
+
+func toPresignedUrl(url *registry.PresignedUrl) *aetherv1.PresignedUrl {
+	return &aetherv1.PresignedUrl{
+		Url:       url.URL.Value(),
+		ExpiresAt: timestamppb.New(url.ExpiresAt),
+		ExpiresIn: int64(url.ExpiresIn.Seconds()),
+	}
+}
+
+func toItemError(e *data.ItemError) *aetherv1.ItemError {
+	return &aetherv1.ItemError{
+		Index:     int32(e.Index),
+		Checksum:  e.Checksum,
+		Code:      string(e.Code),
+		Field:     e.Field,
+		Message:   e.Message,
+		Retryable: e.Retryable,
+	}
+}
+
+func toTag(tag *registry.Tag) *aetherv1.Tag {
+	return &aetherv1.Tag{
+		Id:          uint64(tag.ID),
+		Name:        tag.Name,
+		Description: tag.Description,
+		Color:       tag.Color,
+		CreatedBy:   tag.CreatedBy,
+		CreatedAt:   timestamppb.New(tag.CreatedAt),
+	}
+}
+
+// toDatasetVersion converts a version of the dataset named name, its Dataset is not always loaded
+func toDatasetVersion(name string, dsv *registry.DatasetVersion) *aetherv1.DatasetVersion {
+	return &aetherv1.DatasetVersion{
+		Id:          uint64(dsv.ID),
+		Dataset:     name,
+		Number:      int32(dsv.Number),
+		Description: dsv.Description,
+		Status:      string(dsv.Status),
+		CreatedAt:   timestamppb.New(dsv.CreatedAt),
+	}
+}
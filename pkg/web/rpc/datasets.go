@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+	"github.com/UnivocalX/aether/pkg/web/rpc/aetherv1"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+type datasetServer struct {
+	aetherv1.UnimplementedDatasetServiceServer
+	svc *data.Service
+}
+
+func (s *datasetServer) CreateDataset(ctx context.Context, req *aetherv1.CreateDatasetRequest) (*aetherv1.DatasetVersion, error) {
+	request := v1.CreateDatasetRequest{Name: req.GetName(), Description: req.GetDescription()}
+	if err := validate(&request); err != nil {
+		return nil, err
+	}
+
+	dsv, err := s.svc.CreateDataset(ctx, &registry.Dataset{Name: request.Name, Description: request.Description})
+	if err != nil {
+		return nil, err
+	}
+	return toDatasetVersion(request.Name, dsv), nil
+}
+
+func (s *datasetServer) CreateDatasetVersion(ctx context.Context, req *aetherv1.CreateDatasetVersionRequest) (*aetherv1.DatasetVersion, error) {
+	uri := dto.DatasetUri{DatasetName: req.GetName()}
+	if err := validate(&uri, &v1.CreateDatasetVersionRequest{Description: req.GetDescription()}); err != nil {
+		return nil, err
+	}
+
+	dsv, err := s.svc.CreateDatasetVersion(ctx, uri.DatasetName, req.GetDescription())
+	if err != nil {
+		return nil, err
+	}
+	return toDatasetVersion(uri.DatasetName, dsv), nil
+}
+
+func (s *datasetServer) AddVersionAssets(ctx context.Context, req *aetherv1.VersionAssetsRequest) (*aetherv1.DatasetVersion, error) {
+	uri := versionUri(req.GetName(), req.GetVersion())
+	if err := validate(&uri, &v1.DatasetVersionAssetsRequest{Checksums: req.GetChecksums()}); err != nil {
+		return nil, err
+	}
+
+	dsv, err := s.svc.AddDatasetVersionAssets(ctx, uri.DatasetName, uri.Version, req.GetChecksums()...)
+	if err != nil {
+		return nil, err
+	}
+	return toDatasetVersion(uri.DatasetName, dsv), nil
+}
+
+func (s *datasetServer) RemoveVersionAssets(ctx context.Context, req *aetherv1.VersionAssetsRequest) (*aetherv1.DatasetVersion, error) {
+	uri := versionUri(req.GetName(), req.GetVersion())
+	if err := validate(&uri, &v1.DatasetVersionAssetsRequest{Checksums: req.GetChecksums()}); err != nil {
+		return nil, err
+	}
+
+	dsv, err := s.svc.RemoveDatasetVersionAssets(ctx, uri.DatasetName, uri.Version, req.GetChecksums()...)
+	if err != nil {
+		return nil, err
+	}
+	return toDatasetVersion(uri.DatasetName, dsv), nil
+}
+
+// ListVersionAssets pages through the assets of a dataset version, page tokens are bound to the version
+func (s *datasetServer) ListVersionAssets(ctx context.Context, req *aetherv1.ListVersionAssetsRequest) (*aetherv1.ListAssetsResponse, error) {
+	uri := versionUri(req.GetName(), req.GetVersion())
+	request := v1.ListDatasetVersionAssetsRequest{PageToken: req.GetPageToken(), Limit: uint(req.GetLimit())}
+	if err := validate(&uri, &request); err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.svc.DecodePageToken(request.PageToken, &uri)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = registry.SearchDefaultLimit
+	}
+
+	assets, err := s.svc.ListDatasetVersionAssets(ctx, uri.DatasetName, uri.Version, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := nextPageToken(s.svc, assets, limit, uri)
+	if err != nil {
+		return nil, err
+	}
+	return &aetherv1.ListAssetsResponse{Assets: toAssets(assets), NextPageToken: token}, nil
+}
+
+func versionUri(name string, version int32) dto.DatasetVersionUri {
+	return dto.DatasetVersionUri{DatasetUri: dto.DatasetUri{DatasetName: name}, Version: int(version)}
+}
@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/UnivocalX/aether/pkg/web/api/apierrors"
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+)
+
+// ErrorDomain is the domain of the ErrorInfo details, their reason is the api error code
+const ErrorDomain = "aether"
+
+// statusCodes maps the HTTP status of REST error responses to gRPC codes
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.FailedPrecondition,
+	http.StatusRequestEntityTooLarge: codes.InvalidArgument,
+	http.StatusUnprocessableEntity:   codes.InvalidArgument,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusServiceUnavailable:    codes.Unavailable,
+}
+
+// errorCodes give api error codes a gRPC code more specific than the one of their HTTP status
+var errorCodes = map[apierrors.Code]codes.Code{
+	apierrors.AlreadyExists:          codes.AlreadyExists,
+	apierrors.AssetExists:            codes.AlreadyExists,
+	apierrors.TagExists:              codes.AlreadyExists,
+	apierrors.DatasetExists:          codes.AlreadyExists,
+	apierrors.ProjectExists:          codes.AlreadyExists,
+	apierrors.IdempotencyKeyInFlight: codes.Aborted,
+	apierrors.QuotaExceeded:          codes.ResourceExhausted,
+}
+
+// statusOf translates a service error to a status described like its REST error response,
+// the api error code travels as the reason of an ErrorInfo detail. Status errors pass through.
+func statusOf(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	description := dto.DescribeError(err)
+	code, ok := errorCodes[description.Code]
+	if !ok {
+		if code, ok = statusCodes[description.Status]; !ok {
+			code = codes.Internal
+		}
+	}
+
+	msg := err.Error()
+	if description.Msg != "" {
+		msg = description.Msg
+	}
+
+	st, detailErr := status.New(code, msg).WithDetails(&errdetails.ErrorInfo{Reason: string(description.Code), Domain: ErrorDomain})
+	if detailErr != nil {
+		return status.Error(code, msg)
+	}
+
+	if seconds, err := strconv.Atoi(description.RetryAfter); err == nil && seconds > 0 {
+		retry := &errdetails.RetryInfo{RetryDelay: durationpb.New(time.Duration(seconds) * time.Second)}
+		if retried, err := st.WithDetails(retry); err == nil {
+			st = retried
+		}
+	}
+	return st.Err()
+}
@@ -0,0 +1,32 @@
+// Package rpc serves the gRPC api next to the REST one. Its services translate the protobuf messages of
+// aetherv1 and call the same data.Service methods as the v1 handlers, so both apis share validation,
+// authorization and error codes. Ingestion agents use StreamCreateAssets to register batches on one stream.
+package rpc
+
+//go:generate protoc -I proto --go_out=aetherv1 --go_opt=paths=source_relative --go-grpc_out=aetherv1 --go-grpc_opt=paths=source_relative aether/v1/assets.proto aether/v1/tags.proto aether/v1/datasets.proto
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/UnivocalX/aether/pkg/web/rpc/aetherv1"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// MaxMessageSize bounds received messages, a full CreateAssets batch fits with room to spare
+const MaxMessageSize = 8 << 20 // 8 MiB
+
+// NewServer returns a gRPC server with the asset, tag and dataset services, calls are authenticated
+// and authorized like REST requests, see authenticate
+func NewServer(svc *data.Service, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.MaxRecvMsgSize(MaxMessageSize),
+		grpc.ChainUnaryInterceptor(unaryInterceptor(svc)),
+		grpc.ChainStreamInterceptor(streamInterceptor(svc)),
+	}, opts...)
+
+	server := grpc.NewServer(opts...)
+	aetherv1.RegisterAssetServiceServer(server, &assetServer{svc: svc})
+	aetherv1.RegisterTagServiceServer(server, &tagServer{svc: svc})
+	aetherv1.RegisterDatasetServiceServer(server, &datasetServer{svc: svc})
+	return server
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+	"github.com/UnivocalX/aether/pkg/web/rpc/aetherv1"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+type tagServer struct {
+	aetherv1.UnimplementedTagServiceServer
+	svc *data.Service
+}
+
+func (s *tagServer) CreateTag(ctx context.Context, req *aetherv1.CreateTagRequest) (*aetherv1.Tag, error) {
+	request := v1.CreateTagRequest{Name: req.GetName(), Description: req.GetDescription(), Color: req.GetColor()}
+	if err := validate(&request); err != nil {
+		return nil, err
+	}
+
+	tag, err := s.svc.CreateTag(ctx, request.Name, request.Description, request.Color)
+	if err != nil {
+		return nil, err
+	}
+	return toTag(tag), nil
+}
+
+func (s *tagServer) GetTag(ctx context.Context, req *aetherv1.GetTagRequest) (*aetherv1.Tag, error) {
+	if err := validate(&dto.TagUri{TagName: req.GetName()}); err != nil {
+		return nil, err
+	}
+
+	tag, err := s.svc.GetTag(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return toTag(tag), nil
+}
+
+func (s *tagServer) TagAssets(ctx context.Context, req *aetherv1.TagAssetsRequest) (*aetherv1.TagAssetsResponse, error) {
+	if err := validate(&dto.TagUri{TagName: req.GetName()}, &v1.TagAssetsRequest{Checksums: req.GetChecksums()}); err != nil {
+		return nil, err
+	}
+
+	result, err := s.svc.TagAssets(ctx, req.GetName(), req.GetChecksums()...)
+	if err != nil {
+		return nil, err
+	}
+	return toTagAssetsResponse(result), nil
+}
+
+func (s *tagServer) UntagAssets(ctx context.Context, req *aetherv1.TagAssetsRequest) (*aetherv1.TagAssetsResponse, error) {
+	if err := validate(&dto.TagUri{TagName: req.GetName()}, &v1.TagAssetsRequest{Checksums: req.GetChecksums()}); err != nil {
+		return nil, err
+	}
+
+	result, err := s.svc.UntagAssets(ctx, req.GetName(), req.GetChecksums()...)
+	if err != nil {
+		return nil, err
+	}
+	return toTagAssetsResponse(result), nil
+}
+
+func toTagAssetsResponse(result *data.TagBatchResult) *aetherv1.TagAssetsResponse {
+	return &aetherv1.TagAssetsResponse{
+		Tag:     toTag(result.Tag),
+		Changed: result.Changed,
+		Missing: result.Missing,
+	}
+}
@@ -1,15 +1,30 @@
 package web
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"expvar"
 	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/metrics"
 	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
 	"github.com/UnivocalX/aether/pkg/web/middleware"
+	"github.com/UnivocalX/aether/pkg/web/rpc"
 	"github.com/UnivocalX/aether/pkg/web/services/data"
 )
 
@@ -18,15 +33,49 @@ var (
 	MaxRequestSize     int64 = 4 << 20 // 4 MiB for JSON batch requests
 )
 
+// DEFAULT_DRAIN_TIMEOUT is how long shutdown waits for in-flight requests by default
+const DEFAULT_DRAIN_TIMEOUT = 30 * time.Second
+
 type Server struct {
 	Registry *registry.Engine
 	Router   *gin.Engine
 	DataSvc  *data.Service
 	Prod     bool
+
+	// TLS terminates TLS when set, see NewTLSConfig
+	TLS *tls.Config
+
+	// GRPCPort serves the gRPC api on a second port when set, with the TLS settings of the http server
+	GRPCPort string
+
+	// DrainTimeout bounds how long shutdown waits for in-flight requests,
+	// connections still open afterwards are closed
+	DrainTimeout time.Duration
+
+	// ready is set once the server listens, readiness probes fail before and while shutting down
+	ready atomic.Bool
+
+	// background workers, cancelled and waited for on shutdown, see Go
+	background context.Context
+	stop       context.CancelFunc
+	workers    sync.WaitGroup
+}
+
+// Go runs a background worker until the server shuts down, shutdown waits for it to return
+// before closing the database connections
+func (s *Server) Go(worker func(ctx context.Context)) {
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		worker(s.background)
+	}()
 }
 
+// Run serves on port, and the gRPC api on GRPCPort, until the process gets SIGINT or SIGTERM, then
+// shuts down gracefully: readiness fails, in-flight requests get DrainTimeout to finish, background
+// workers are cancelled and the database connection pool is closed.
 func (s *Server) Run(port string) error {
-	slog.Info("Starting server...", "port", port, "production", s.Prod)
+	slog.Info("Starting server...", "port", port, "grpc_port", s.GRPCPort, "production", s.Prod, "tls", s.TLS != nil)
 
 	httpServer := &http.Server{
 		Addr:           ":" + port,
@@ -35,12 +84,127 @@ func (s *Server) Run(port string) error {
 		WriteTimeout:   30 * time.Second,
 		IdleTimeout:    120 * time.Second,
 		MaxHeaderBytes: 1 << 20,
+		TLSConfig:      s.TLS,
+	}
+
+	listener, err := net.Listen("tcp", httpServer.Addr)
+	if err != nil {
+		s.shutdownWorkers()
+		return err
 	}
 
-	return httpServer.ListenAndServe()
+	var grpcServer *grpc.Server
+	var grpcListener net.Listener
+	if s.GRPCPort != "" {
+		if grpcListener, err = net.Listen("tcp", ":"+s.GRPCPort); err != nil {
+			listener.Close()
+			s.shutdownWorkers()
+			return err
+		}
+		grpcServer = rpc.NewServer(s.DataSvc, s.grpcOptions()...)
+	}
+
+	signals, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	served := make(chan error, 2)
+	s.ready.Store(true)
+	if grpcServer != nil {
+		go func() {
+			served <- grpcServer.Serve(grpcListener)
+		}()
+	}
+	go func() {
+		if s.TLS != nil {
+			// the certificates come with TLSConfig
+			served <- httpServer.ServeTLS(listener, "", "")
+			return
+		}
+		served <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-served:
+		s.ready.Store(false)
+		httpServer.Close()
+		if grpcServer != nil {
+			grpcServer.Stop()
+		}
+		s.shutdownWorkers()
+		return err
+	case <-signals.Done():
+		// a second signal kills the process right away
+		stop()
+	}
+	return s.shutdown(httpServer, grpcServer)
+}
+
+// TrustProxies reads the client address of requests from the addresses or CIDRs in proxies
+// from their X-Forwarded-For or X-Real-IP header, callers are told apart by the address of their connection otherwise.
+// Rate limits, probe quotas and idempotency records of anonymous callers are keyed by the client address.
+func (s *Server) TrustProxies(proxies ...string) error {
+	return s.Router.SetTrustedProxies(proxies)
 }
 
-func NewServer(prod bool, engine *registry.Engine) *Server {
+// grpcOptions applies the TLS settings of the http server to the gRPC server. Client certificates
+// are required when a client CA is set, gRPC has no health probes to exempt.
+func (s *Server) grpcOptions() []grpc.ServerOption {
+	if s.TLS == nil {
+		return nil
+	}
+
+	config := s.TLS.Clone()
+	if config.ClientCAs != nil {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(config))}
+}
+
+// shutdown drains the in-flight requests of httpServer and grpcServer, when set, then stops the
+// workers and closes the database
+func (s *Server) shutdown(httpServer *http.Server, grpcServer *grpc.Server) error {
+	s.ready.Store(false)
+	slog.Info("Shutting down server, draining connections...", "timeout", s.DrainTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.DrainTimeout)
+	defer cancel()
+
+	var errs []error
+	if err := httpServer.Shutdown(ctx); err != nil {
+		slog.Warn("Drain timed out, closing open connections", "error", err)
+		errs = append(errs, httpServer.Close())
+	}
+	if grpcServer != nil {
+		drained := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(drained)
+		}()
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			slog.Warn("gRPC drain timed out, closing open streams")
+			grpcServer.Stop()
+		}
+	}
+
+	s.shutdownWorkers()
+	errs = append(errs, s.Registry.Close())
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	slog.Info("Server stopped")
+	return nil
+}
+
+// shutdownWorkers cancels the background workers and waits for them to return
+func (s *Server) shutdownWorkers() {
+	s.stop()
+	s.workers.Wait()
+}
+
+func NewServer(prod bool, engine *registry.Engine, svcOpts ...data.ServiceOption) *Server {
 	// set gin mode
 	if prod {
 		gin.SetMode(gin.ReleaseMode)
@@ -48,17 +212,44 @@ func NewServer(prod bool, engine *registry.Engine) *Server {
 
 	// Create router
 	router := gin.New()
+	// forwarding headers name the client only once proxies are trusted, see TrustProxies
+	router.SetTrustedProxies(nil)
 	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.ServerTime())
+	router.Use(middleware.Tracing(engine.Tracing())...)
+	router.Use(middleware.Metrics(engine.MetricsRegistry()))
 	router.Use(middleware.Logger())
-	router.Use(middleware.MaxRequestSizeLimit(MaxRequestSize))
+	router.Use(middleware.Principal())
+	// filesystem storage uploads carry whole objects, their size is checked against the signed url instead
+	router.Use(middleware.MaxRequestSizeLimit(MaxRequestSize, "/api"+registry.STORAGE_URL_PATH))
 	router.MaxMultipartMemory = MaxMultipartMemory
 
 	server := &Server{
-		Registry: engine,
-		Router:   router,
-		DataSvc:  data.NewService(engine),
-		Prod:     prod,
+		Registry:     engine,
+		Router:       router,
+		DataSvc:      data.NewService(engine, svcOpts...),
+		Prod:         prod,
+		DrainTimeout: DEFAULT_DRAIN_TIMEOUT,
 	}
+	server.background, server.stop = context.WithCancel(context.Background())
+
+	// signed storage urls, bucket notifications and replication authenticate on their own
+	skip := append([]string{
+		"/api/health",
+		"/api" + registry.STORAGE_URL_PATH,
+		"/api/v1/storage-events",
+		"/api/v1/replication/",
+		// the api reference is public
+		"/api/v1/openapi.json",
+		"/api/v1/docs",
+	}, HealthPaths...)
+	router.Use(server.requireClientCert)
+	router.Use(middleware.APIKey(server.DataSvc, skip...))
+	// uploads to signed storage urls were limited when presigned
+	router.Use(middleware.RateLimit(server.DataSvc, append([]string{"/metrics", "/api" + registry.STORAGE_URL_PATH}, HealthPaths...)...))
+	router.Use(middleware.Project(server.DataSvc, skip...))
+	router.Use(middleware.Authorize(server.DataSvc, skip...))
 
 	// Register Routes
 	server.RegisterRoutes()
@@ -67,12 +258,23 @@ func NewServer(prod bool, engine *registry.Engine) *Server {
 
 func (s *Server) RegisterRoutes() {
 	slog.Info("Registering API routes")
+	s.registerHealthRoutes()
+
 	api := s.Router.Group("/api")
 	// health
 	api.GET("/health", func(c *gin.Context) {
 		c.Status(http.StatusNoContent)
 	})
 
+	// runtime counters
+	api.GET("/debug/vars", gin.WrapH(expvar.Handler()))
+
+	// prometheus metrics, served unless the engine metrics go to a registry that gathers elsewhere
+	if gatherer, ok := s.Registry.MetricsRegistry().(prometheus.Gatherer); ok {
+		s.Router.GET("/metrics", gin.WrapH(metrics.Handler(gatherer)))
+	}
+
 	// V1 routes
 	v1.RegisterRoutes(api, s.DataSvc)
+	v1.CheckOpenAPI(s.Router.Routes())
 }
@@ -0,0 +1,35 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// recordAccess enforces presign rate limits and writes issued presigned urls to the access log.
+// Callers must not hand out the urls when it fails, so egress is never unaudited.
+func (s *Service) recordAccess(ctx context.Context, urls ...*registry.PresignedUrl) error {
+	if err := s.checkPresignRate(ctx, urls...); err != nil {
+		return err
+	}
+
+	principal := PrincipalFrom(ctx)
+	entries := registry.NewAccessLogEntries(principal.ID, principal.ClientIP, principal.UserAgent, urls...)
+	for _, entry := range entries {
+		entry.Project = principal.Project
+	}
+
+	if err := s.engine.WithContext(ctx).CreateAccessLogRecords(entries...); err != nil {
+		slog.Error("failed to record presigned url access", "total", len(urls), "error", err)
+		return fmt.Errorf("%w: access log unavailable", ErrCantGeneratePresignedUrl)
+	}
+	return nil
+}
+
+// ListAccessLog returns issued presigned urls, newest first
+func (s *Service) ListAccessLog(ctx context.Context, opts ...registry.AccessLogOption) ([]*registry.AccessLog, error) {
+	slog.Debug("attempting to list access log")
+	return s.engine.WithContext(ctx).ListAccessLogRecords(opts...)
+}
@@ -0,0 +1,100 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+var (
+	ErrAPIKeyNotFound      = errors.New("api key not found")
+	ErrAPIKeyAlreadyExists = errors.New("api key already exists")
+	ErrUnauthenticated     = errors.New("missing api key")
+	ErrScopeDenied         = errors.New("api key scope denied")
+	ErrClientCertRequired  = errors.New("client certificate required")
+)
+
+// WithRequiredAPIKeys rejects requests without an api key, keys are checked whenever they are sent
+func WithRequiredAPIKeys() ServiceOption {
+	return func(s *Service) {
+		s.requireAPIKeys = true
+	}
+}
+
+// Authenticate resolves the Authorization header of a request to its api key and checks it holds scope.
+// Without a header it returns nil unless keys are required, the caller stays anonymous.
+func (s *Service) Authenticate(ctx context.Context, authorization string, scope string) (*registry.APIKey, error) {
+	token, ok := strings.CutPrefix(strings.TrimSpace(authorization), "Bearer ")
+	if !ok || strings.TrimSpace(token) == "" {
+		if authorization != "" {
+			return nil, fmt.Errorf("%w: expected a bearer token", registry.ErrInvalidAPIKey)
+		}
+		if s.requireAPIKeys {
+			return nil, ErrUnauthenticated
+		}
+		return nil, nil
+	}
+
+	key, err := s.engine.WithContext(ctx).AuthenticateAPIKey(strings.TrimSpace(token))
+	if err != nil {
+		return nil, err
+	}
+	if !key.Allows(scope) {
+		return nil, fmt.Errorf("%w: %s needs the %s scope", ErrScopeDenied, key.Name, scope)
+	}
+	return key, nil
+}
+
+// CreateAPIKey generates a key attributed to the request principal and returns its only copy
+func (s *Service) CreateAPIKey(ctx context.Context, key *registry.APIKey) (registry.Secret, error) {
+	slog.Debug("attempting to create api key", "name", key.Name, "scopes", key.Scopes)
+
+	key.CreatedBy = PrincipalFrom(ctx).ID
+	secret, err := s.engine.WithContext(ctx).CreateAPIKeyRecord(key)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrAlreadyExists) {
+			return "", fmt.Errorf("%w: %s", ErrAPIKeyAlreadyExists, key.Name)
+		}
+		return "", err
+	}
+	return secret, nil
+}
+
+// GetAPIKey returns the key named name
+func (s *Service) GetAPIKey(ctx context.Context, name string) (*registry.APIKey, error) {
+	slog.Debug("attempting to get api key", "name", name)
+
+	key, err := s.engine.WithContext(ctx).GetAPIKeyRecord(name)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrAPIKeyNotFound, name)
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+// ListAPIKeys returns every key, revoked ones included
+func (s *Service) ListAPIKeys(ctx context.Context) ([]*registry.APIKey, error) {
+	slog.Debug("attempting to list api keys")
+	return s.engine.WithContext(ctx).ListAPIKeyRecords()
+}
+
+// RevokeAPIKey stops a key from authenticating
+func (s *Service) RevokeAPIKey(ctx context.Context, name string) (*registry.APIKey, error) {
+	slog.Debug("attempting to revoke api key", "name", name)
+
+	key, err := s.GetAPIKey(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.engine.WithContext(ctx).RevokeAPIKeyRecord(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
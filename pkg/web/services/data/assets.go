@@ -5,18 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
+	"time"
 
 	"github.com/UnivocalX/aether/internal/registry"
-	"gorm.io/gorm"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
 )
 
 func (s *Service) GetAsset(ctx context.Context, checksum string) (*registry.Asset, error) {
 	slog.Debug("attempting to get asset", "checksum", checksum)
-	asset, err := s.engine.GetAssetRecord(checksum)
+	if err := s.probes.Check(ctx); err != nil {
+		return nil, err
+	}
+
+	asset, err := s.engine.WithContext(ctx).GetAssetRecord(checksum)
 
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("%w: %s", ErrAssetNotFound, checksum)
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, s.probes.Miss(ctx, fmt.Errorf("%w: %s", ErrAssetNotFound, checksum))
 		}
 
 		return nil, err
@@ -25,6 +31,37 @@ func (s *Service) GetAsset(ctx context.Context, checksum string) (*registry.Asse
 	return asset, nil
 }
 
+// AssetsExist looks up many checksums at once, it returns the known assets and the unknown checksums.
+// Unknown checksums spend the caller probe quota like single lookups do.
+func (s *Service) AssetsExist(ctx context.Context, checksums []string) ([]*registry.Asset, []string, error) {
+	slog.Debug("attempting to check assets existence", "total", len(checksums))
+	if err := s.probes.Check(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	assets, err := s.engine.WithContext(ctx).FindAssetRecords(checksums)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	found := make(map[string]bool, len(assets))
+	for _, asset := range assets {
+		found[asset.Checksum] = true
+	}
+
+	var missing []string
+	for _, checksum := range checksums {
+		checksum = registry.NormalizeString(checksum)
+		if !found[checksum] {
+			found[checksum] = true
+			missing = append(missing, checksum)
+		}
+	}
+
+	s.probes.record(ctx, len(missing))
+	return assets, missing, nil
+}
+
 func (s *Service) TagAsset(ctx context.Context, checksum string, tagName string) error {
 	slog.Debug("attempting to tag asset", "tagName", tagName, "assetChecksum", checksum)
 
@@ -38,11 +75,10 @@ func (s *Service) TagAsset(ctx context.Context, checksum string, tagName string)
 		return err
 	}
 
-	if err := s.engine.AttachTags(asset, []*registry.Tag{tag}); err != nil {
-		return err
-	}
-
-	return nil
+	_, err = s.attachGroupedTags(ctx, func(engine *registry.Engine) ([]*registry.TagSwap, error) {
+		return engine.AttachTags(asset, []*registry.Tag{tag})
+	})
+	return err
 }
 
 func (s *Service) UntagAsset(ctx context.Context, checksum string, tagName string) error {
@@ -58,7 +94,7 @@ func (s *Service) UntagAsset(ctx context.Context, checksum string, tagName strin
 		return err
 	}
 
-	if err := s.engine.DetachTags(asset, []*registry.Tag{tag}); err != nil {
+	if err := s.engine.WithContext(ctx).DetachTags(asset, []*registry.Tag{tag}); err != nil {
 		return err
 	}
 
@@ -67,11 +103,14 @@ func (s *Service) UntagAsset(ctx context.Context, checksum string, tagName strin
 
 func (s *Service) GetAssetTags(ctx context.Context, checksum string) ([]*registry.Tag, error) {
 	slog.Debug("attempting to get asset tags", "checksum", checksum)
+	if err := s.probes.Check(ctx); err != nil {
+		return nil, err
+	}
 
-	tags, err := s.engine.GetAssetRecordTags(checksum)
+	tags, err := s.engine.WithContext(ctx).GetAssetRecordTags(checksum)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("%w: %s", ErrAssetNotFound, checksum)
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, s.probes.Miss(ctx, fmt.Errorf("%w: %s", ErrAssetNotFound, checksum))
 		}
 
 		return nil, err
@@ -80,14 +119,19 @@ func (s *Service) GetAssetTags(ctx context.Context, checksum string) ([]*registr
 	return tags, nil
 }
 
-func (s *Service) GetAssetIngressUrl(ctx context.Context, checksum string) (*registry.PresignedUrl, error) {
+// GetAssetIngressUrl presigns an upload url, a zero expiresIn uses the server default
+func (s *Service) GetAssetIngressUrl(ctx context.Context, checksum string, expiresIn time.Duration) (*registry.PresignedUrl, error) {
 	slog.Debug("attempting to get asset Presigned Url", "checksum", checksum)
 
+	if err := s.probes.Check(ctx); err != nil {
+		return nil, err
+	}
+
 	// Check asset status
-	asset, err := s.engine.GetAssetRecord(checksum)
+	asset, err := s.engine.WithContext(ctx).GetAssetRecord(checksum)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("%w: %s", ErrAssetNotFound, checksum)
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, s.probes.Miss(ctx, fmt.Errorf("%w: %s", ErrAssetNotFound, checksum))
 		}
 
 		return nil, err
@@ -99,77 +143,396 @@ func (s *Service) GetAssetIngressUrl(ctx context.Context, checksum string) (*reg
 		return nil, fmt.Errorf("%w: %s", ErrAssetIsReady, checksum)
 	}
 
-	return s.engine.IngressURL(ctx, checksum)
+	policy, err := s.projectContentPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.CheckSize(asset.SizeBytes); err != nil {
+		return nil, err
+	}
+	if err := s.checkQuota(ctx, 1, asset.SizeBytes); err != nil {
+		return nil, err
+	}
+
+	url, err := s.engine.WithContext(ctx).IngressUrlSize(ctx, checksum, expiresIn, asset.SizeBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordAccess(ctx, url); err != nil {
+		return nil, err
+	}
+
+	return url, nil
 }
 
-func (s *Service) ListAssets(ctx context.Context, opts ...registry.SearchAssetsOption) ([]*registry.Asset, error) {
-	slog.Debug("attempting to list assets")
-	return s.engine.ListAssetsRecords(opts...)
+// GetAssetEgressUrl presigns a download of a ready asset, a zero expiresIn uses the server default
+func (s *Service) GetAssetEgressUrl(ctx context.Context, checksum string, expiresIn time.Duration) (*registry.PresignedUrl, error) {
+	slog.Debug("attempting to get asset egress url", "checksum", checksum)
+
+	asset, err := s.GetAsset(ctx, checksum)
+	if err != nil {
+		return nil, err
+	}
+	if asset.State != registry.StatusReady {
+		return nil, fmt.Errorf("%w: %s is %s", ErrAssetNotReady, checksum, asset.State)
+	}
+
+	if err := s.AuthorizeEgress(ctx, asset); err != nil {
+		return nil, err
+	}
+
+	url, err := s.engine.WithContext(ctx).CuratedUrlExpire(ctx, asset.Checksum, expiresIn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordAccess(ctx, url); err != nil {
+		return nil, err
+	}
+
+	return url, nil
 }
 
-func (s *Service) CreateAssets(ctx context.Context, assets ...*registry.Asset) ([]*registry.PresignedUrl, error) {
-	slog.Debug("attempting to create new assets", "total", len(assets))
+type EgressUrlResult struct {
+	Index int
+	Url   *registry.PresignedUrl
+}
 
-	// Try to create
-	if err := s.engine.CreateAssetRecords(assets...); err != nil {
-		// duplicate error
-		if IsUniqueConstraintError(err) {
-			// fetch existing records
-			checksums := make([]string, len(assets))
-			for i, a := range assets {
-				checksums[i] = a.Checksum
-			}
+// GetAssetEgressUrls presigns downloads of many ready assets in one call, a zero expiresIn uses the server default.
+// Items are handled independently: unknown, unready and restricted assets are reported per item by index.
+func (s *Service) GetAssetEgressUrls(ctx context.Context, checksums []string, expiresIn time.Duration) ([]*EgressUrlResult, []*ItemError, error) {
+	slog.Debug("attempting to get asset egress urls", "total", len(checksums))
 
-			records, listErr := s.engine.ListAssetsRecords(registry.WithChecksums(checksums...))
-			if listErr != nil {
-				return nil, fmt.Errorf("failed to fetch existing assets: %w", listErr)
-			}
+	assets, _, err := s.AssetsExist(ctx, checksums)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byChecksum := make(map[string]*registry.Asset, len(assets))
+	for _, asset := range assets {
+		byChecksum[asset.Checksum] = asset
+	}
 
-			// return assets with ready status
-			readyAssets := filterAssetByStatus(registry.StatusReady, records...)
-			if len(readyAssets) > 0 {
-				return nil, AssetsExistsError{Checksums: readyAssets}
+	var failures []*ItemError
+	candidates := make([]string, 0, len(checksums))
+	indices := make([]int, 0, len(checksums))
+	seen := make(map[string]int, len(checksums))
+	for i, checksum := range checksums {
+		checksum = registry.NormalizeString(checksum)
+		if first, ok := seen[checksum]; ok {
+			failures = append(failures, NewItemError(i, checksum, CodeDuplicateItem, fmt.Errorf("duplicate of item %d", first)))
+			continue
+		}
+		seen[checksum] = i
+
+		asset, ok := byChecksum[checksum]
+		switch {
+		case !ok:
+			failures = append(failures, NewItemError(i, checksum, CodeAssetNotFound, ErrAssetNotFound))
+			continue
+		case asset.State != registry.StatusReady:
+			failures = append(failures, NewItemError(i, checksum, CodeAssetNotReady, fmt.Errorf("%w: %s", ErrAssetNotReady, asset.State)))
+			continue
+		}
+
+		if err := s.AuthorizeEgress(ctx, asset); err != nil {
+			if !errors.Is(err, registry.ErrClassificationRestricted) {
+				return nil, nil, err
 			}
+			failures = append(failures, NewItemError(i, checksum, CodeAccessDenied, err))
+			continue
 		}
-		// other errors
+
+		candidates = append(candidates, checksum)
+		indices = append(indices, i)
+	}
+
+	urls, errs := s.engine.WithContext(ctx).CuratedUrlsExpire(ctx, candidates, expiresIn)
+
+	results := make([]*EgressUrlResult, 0, len(urls))
+	granted := make([]*registry.PresignedUrl, 0, len(urls))
+	for j, url := range urls {
+		if errs[j] != nil {
+			failures = append(failures, NewItemError(indices[j], candidates[j], presignCode(errs[j]), errs[j]))
+			continue
+		}
+		results = append(results, &EgressUrlResult{Index: indices[j], Url: url})
+		granted = append(granted, url)
+	}
+
+	if err := s.recordAccess(ctx, granted...); err != nil {
+		return nil, nil, err
+	}
+
+	return results, failures, nil
+}
+
+// CheckUploadSize heads the uploaded ingress object of an asset and checks its
+// size against the caller project limit, a missing object returns nil info.
+func (s *Service) CheckUploadSize(ctx context.Context, checksum string) (*registry.ObjectInfo, error) {
+	slog.Debug("attempting to check upload size", "checksum", checksum)
+
+	policy, err := s.projectContentPolicy(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	// Generate ingress urls
-	urls, err := s.GenerateIngressUrls(
-		ctx,
-		registry.Assets2Checksums(assets...)...,
-	)
+	info, err := s.engine.WithContext(ctx).StatObject(ctx, s.engine.WithContext(ctx).IngressKey(checksum))
+	if err != nil || info == nil {
+		return info, err
+	}
 
+	if err := policy.CheckSize(info.Size); err != nil {
+		slog.Warn("uploaded object exceeds the project size limit", "checksum", checksum, "size", info.Size)
+		return info, err
+	}
+	return info, nil
+}
+
+// FinalizeAsset promotes an uploaded asset once its ingress object checks out:
+// the stored size must fit the caller project limit and match the asset checksum.
+func (s *Service) FinalizeAsset(ctx context.Context, checksum string) (*registry.Asset, error) {
+	slog.Debug("attempting to finalize asset", "checksum", checksum)
+
+	if _, err := s.CheckUploadSize(ctx, checksum); err != nil {
+		return nil, err
+	}
+
+	asset, err := s.engine.WithContext(ctx).PromoteAsset(ctx, checksum)
 	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrAssetNotFound, checksum)
+		}
 		return nil, err
 	}
 
-	return urls, err
+	s.runClassifiers(ctx, asset)
+	return asset, nil
 }
 
-func filterAssetByStatus(status registry.Status, assets ...*registry.Asset) []string {
-	// return assets with ready status
-	matches := make([]string, len(assets))
+// PatchAsset edits the display name, mime type or extra of an asset,
+// the resulting name and mime type must still pass the caller project content policy.
+func (s *Service) PatchAsset(ctx context.Context, checksum string, patch registry.AssetPatch) (*registry.Asset, error) {
+	slog.Debug("attempting to patch asset", "checksum", checksum)
+
+	asset, err := s.GetAsset(ctx, checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.Display != nil || patch.MimeType != nil {
+		display, mimeType := asset.Display, asset.MimeType
+		if patch.Display != nil {
+			display = *patch.Display
+		}
+		if patch.MimeType != nil {
+			mimeType = *patch.MimeType
+		}
+
+		policy, err := s.projectContentPolicy(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := policy.Check(mimeType, display); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.engine.WithContext(ctx).PatchAssetRecord(asset, patch); err != nil {
+		return nil, err
+	}
+
+	return asset, nil
+}
+
+func (s *Service) ListAssets(ctx context.Context, opts ...registry.SearchAssetsOption) ([]*registry.Asset, error) {
+	slog.Debug("attempting to list assets")
+	return s.engine.WithContext(ctx).ListAssetsRecords(opts...)
+}
+
+// CreateAssetResult is a successfully registered batch item and its upload url.
+// New assets carry their suggested tags, TagsApplied tells whether they were attached.
+type CreateAssetResult struct {
+	Index         int
+	Asset         *registry.Asset
+	Url           *registry.PresignedUrl
+	SuggestedTags []string
+	TagsApplied   bool
+}
+
+// CreateAssets registers a batch of assets and presigns their upload urls.
+// Items are handled independently: failures are reported per item by index,
+// only errors affecting the whole batch are returned as error.
+func (s *Service) CreateAssets(ctx context.Context, expiresIn time.Duration, assets ...*registry.Asset) ([]*CreateAssetResult, []*ItemError, error) {
+	slog.Debug("attempting to create new assets", "total", len(assets))
+
+	var failures []*ItemError
+
+	policy, err := s.projectContentPolicy(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Skip repeated checksums within the batch
+	seen := make(map[string]int, len(assets))
+	candidates := make([]int, 0, len(assets))
 	for i, a := range assets {
-		if a.State == status {
-			matches[i] = a.Checksum
+		a.Checksum = registry.NormalizeString(a.Checksum)
+
+		if first, ok := seen[a.Checksum]; ok {
+			failures = append(failures, NewItemError(i, a.Checksum, CodeDuplicateItem, fmt.Errorf("duplicate of item %d", first)))
+			continue
+		}
+
+		if err := policy.Check(a.MimeType, a.Display); err != nil {
+			failures = append(failures, NewItemError(i, a.Checksum, CodeContentRejected, err))
+			continue
+		}
+
+		if err := policy.CheckSize(a.SizeBytes); err != nil {
+			failures = append(failures, NewItemError(i, a.Checksum, CodeObjectTooLarge, err))
+			continue
+		}
+
+		seen[a.Checksum] = i
+		candidates = append(candidates, i)
+	}
+
+	// Fetch already registered assets
+	checksums := make([]string, len(candidates))
+	for i, idx := range candidates {
+		checksums[i] = assets[idx].Checksum
+	}
+
+	existing := make(map[string]*registry.Asset)
+	if len(checksums) > 0 {
+		records, err := s.engine.WithContext(ctx).ListAssetsRecords(
+			registry.WithChecksums(checksums...),
+			registry.WithLimit(registry.SearchMaxLimit),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch existing assets: %w", err)
+		}
+
+		for _, r := range records {
+			existing[r.Checksum] = r
 		}
 	}
-	return matches
+
+	var fresh []*registry.Asset
+	var freshIdx, presignIdx []int
+
+	// pending records are swapped in below, keep the declared sizes for signing
+	sizes := make(map[int]int64, len(candidates))
+	for _, i := range candidates {
+		sizes[i] = assets[i].SizeBytes
+	}
+
+	for _, i := range candidates {
+		a := assets[i]
+		record, ok := existing[a.Checksum]
+
+		switch {
+		case !ok:
+			fresh = append(fresh, a)
+			freshIdx = append(freshIdx, i)
+
+		case record.State == registry.StatusReady:
+			failures = append(failures, NewItemError(i, a.Checksum, CodeAssetExists, ErrAssetIsReady))
+
+		case record.State == registry.StatusDeleted:
+			failures = append(failures, NewItemError(i, a.Checksum, CodeAssetDeleted, fmt.Errorf("asset is in the trash, restore it instead")))
+
+		default:
+			// not uploaded yet, hand out a fresh upload url
+			assets[i] = record
+			presignIdx = append(presignIdx, i)
+		}
+	}
+
+	// Create new records in a single batch
+	suggested := make(map[int][]string)
+	if len(fresh) > 0 {
+		if err := s.engine.WithContext(ctx).CreateAssetRecords(fresh...); err != nil {
+			slog.Error("failed to create asset records", "total", len(fresh), "error", err)
+			for _, i := range freshIdx {
+				failures = append(failures, NewItemError(i, assets[i].Checksum, CodeInternal, err))
+			}
+		} else {
+			presignIdx = append(presignIdx, freshIdx...)
+			for _, i := range freshIdx {
+				suggested[i] = s.suggestTags(assets[i])
+			}
+		}
+	}
+
+	// Generate ingress urls, the items of the batch count towards the project quota together
+	results := make([]*CreateAssetResult, 0, len(presignIdx))
+	usage, err := s.engine.WithContext(ctx).GetUsage(PrincipalFrom(ctx).Project)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var quotaObjects, quotaBytes int64
+	for _, i := range presignIdx {
+		size := sizes[i]
+		if size == 0 {
+			size = assets[i].SizeBytes
+		}
+
+		if err := checkUsage(usage, quotaObjects+1, quotaBytes+size); err != nil {
+			failures = append(failures, NewItemError(i, assets[i].Checksum, CodeQuotaExceeded, err))
+			continue
+		}
+		quotaObjects, quotaBytes = quotaObjects+1, quotaBytes+size
+
+		url, err := s.engine.WithContext(ctx).IngressUrlSize(ctx, assets[i].Checksum, expiresIn, size)
+		if err != nil {
+			failures = append(failures, NewItemError(i, assets[i].Checksum, presignCode(err), fmt.Errorf("%w: %w", ErrCantGeneratePresignedUrl, err)))
+			continue
+		}
+
+		result := &CreateAssetResult{Index: i, Asset: assets[i], Url: url, SuggestedTags: suggested[i]}
+		if len(result.SuggestedTags) > 0 && policy.AutoTags() {
+			result.TagsApplied = s.applySuggestedTags(ctx, assets[i], result.SuggestedTags)
+		}
+		results = append(results, result)
+	}
+
+	urls := make([]*registry.PresignedUrl, len(results))
+	for i, r := range results {
+		urls[i] = r.Url
+	}
+	if err := s.recordAccess(ctx, urls...); err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(results, func(a, b int) bool { return results[a].Index < results[b].Index })
+	sort.Slice(failures, func(a, b int) bool { return failures[a].Index < failures[b].Index })
+	return results, failures, nil
 }
 
-func (s *Service) GenerateIngressUrls(ctx context.Context, checksums ...*string) ([]*registry.PresignedUrl, error) {
+func (s *Service) GenerateIngressUrls(ctx context.Context, expiresIn time.Duration, checksums ...*string) ([]*registry.PresignedUrl, error) {
 	slog.Debug("attempting to generate ingress urls", "total", len(checksums))
+
+	if err := s.checkQuota(ctx, int64(len(checksums)), 0); err != nil {
+		return nil, err
+	}
+
 	ingress := make([]*registry.PresignedUrl, len(checksums))
 
 	for i, c := range checksums {
-		url, err := s.engine.IngressURL(ctx, *c)
+		url, err := s.engine.WithContext(ctx).IngressUrlExpire(ctx, *c, expiresIn)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %w", ErrCantGeneratePresignedUrl, err)
 		}
 		ingress[i] = url
 	}
 
+	if err := s.recordAccess(ctx, ingress...); err != nil {
+		return nil, err
+	}
+
 	return ingress, nil
 }
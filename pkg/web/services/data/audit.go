@@ -0,0 +1,14 @@
+package data
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// ListAuditEntries returns the recorded writes, newest first
+func (s *Service) ListAuditEntries(ctx context.Context, opts ...registry.AuditOption) ([]*registry.AuditEntry, error) {
+	slog.Debug("attempting to list audit entries")
+	return s.engine.WithContext(ctx).ListAuditEntryRecords(opts...)
+}
@@ -0,0 +1,129 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+const (
+	EventClassification  = "asset.classification"
+	EventSensitiveAccess = "asset.sensitive_access"
+)
+
+// WithClassifiers runs scanners on every finalized asset, they can only raise its classification
+func WithClassifiers(classifiers ...registry.Classifier) ServiceOption {
+	return func(s *Service) {
+		s.classifiers = append(s.classifiers, classifiers...)
+	}
+}
+
+// WithEgressRoles sets the roles allowed to download assets of a sensitive class.
+// Without roles a sensitive class is only downloadable by principals holding a role named after it.
+func WithEgressRoles(class registry.Classification, roles ...string) ServiceOption {
+	return func(s *Service) {
+		if s.egressRoles == nil {
+			s.egressRoles = make(map[registry.Classification][]string)
+		}
+		s.egressRoles[class] = roles
+	}
+}
+
+// ClassifyAsset sets the classification of an asset on behalf of the caller
+func (s *Service) ClassifyAsset(ctx context.Context, checksum string, class registry.Classification) (*registry.Asset, error) {
+	slog.Debug("attempting to classify asset", "checksum", checksum, "classification", class)
+
+	asset, err := s.GetAsset(ctx, checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := asset.Classification
+	if err := s.engine.WithContext(ctx).SetAssetClassificationRecord(asset, class, PrincipalFrom(ctx).ID); err != nil {
+		return nil, err
+	}
+
+	s.recordClassificationEvent(ctx, asset, previous)
+	return asset, nil
+}
+
+// runClassifiers applies the configured scanners to an asset, keeping the most sensitive result.
+// Scanner failures are logged and never fail the caller.
+func (s *Service) runClassifiers(ctx context.Context, asset *registry.Asset) {
+	if len(s.classifiers) == 0 {
+		return
+	}
+
+	result, by := asset.Classification, ""
+	for _, classifier := range s.classifiers {
+		class, err := classifier.Classify(ctx, asset)
+		if err != nil {
+			slog.WarnContext(ctx, "asset classifier failed", "classifier", classifier.Name(), "checksum", asset.Checksum, "error", err)
+			continue
+		}
+		if class.Rank() > result.Rank() {
+			result, by = class, classifier.Name()
+		}
+	}
+
+	if by == "" {
+		return
+	}
+
+	previous := asset.Classification
+	if err := s.engine.WithContext(ctx).SetAssetClassificationRecord(asset, result, by); err != nil {
+		slog.ErrorContext(ctx, "failed to store asset classification", "classifier", by, "checksum", asset.Checksum, "error", err)
+		return
+	}
+	s.recordClassificationEvent(ctx, asset, previous)
+}
+
+// AuthorizeEgress checks the caller may download the assets, every presigned GET issuance must call it.
+// Sensitive assets need one of the class egress roles, and each granted download is recorded as an event.
+func (s *Service) AuthorizeEgress(ctx context.Context, assets ...*registry.Asset) error {
+	principal := PrincipalFrom(ctx)
+
+	var sensitive []*registry.Asset
+	for _, asset := range assets {
+		if !asset.Classification.Sensitive() {
+			continue
+		}
+
+		allowed := s.egressRolesFor(asset.Classification)
+		if !slices.ContainsFunc(principal.Roles, func(role string) bool { return slices.Contains(allowed, role) }) {
+			s.recordEvent(ctx, EventSensitiveAccess, registry.SeverityWarning,
+				fmt.Sprintf("denied download of %s asset %s", asset.Classification, asset.Checksum),
+				map[string]any{"checksum": asset.Checksum, "classification": asset.Classification, "granted": false})
+			return fmt.Errorf("%w: %s asset %s needs one of the roles %v", registry.ErrClassificationRestricted, asset.Classification, asset.Checksum, allowed)
+		}
+		sensitive = append(sensitive, asset)
+	}
+
+	for _, asset := range sensitive {
+		s.recordEvent(ctx, EventSensitiveAccess, registry.SeverityInfo,
+			fmt.Sprintf("granted download of %s asset %s", asset.Classification, asset.Checksum),
+			map[string]any{"checksum": asset.Checksum, "classification": asset.Classification, "granted": true})
+	}
+	return nil
+}
+
+func (s *Service) egressRolesFor(class registry.Classification) []string {
+	if roles, ok := s.egressRoles[class]; ok && len(roles) > 0 {
+		return roles
+	}
+	return []string{string(class)}
+}
+
+func (s *Service) recordClassificationEvent(ctx context.Context, asset *registry.Asset, previous registry.Classification) {
+	s.recordEvent(ctx, EventClassification, registry.SeverityInfo,
+		fmt.Sprintf("asset %s classified %s", asset.Checksum, asset.Classification),
+		map[string]any{
+			"checksum":       asset.Checksum,
+			"classification": asset.Classification,
+			"previous":       previous,
+			"by":             asset.ClassifiedBy,
+		})
+}
@@ -2,32 +2,34 @@ package data
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
 	"gorm.io/gorm"
 )
 
-func (s *Service) CreateDataset(ctx context.Context, name string, description string) (*registry.DatasetVersion, error) {
-	slog.Debug("attempting to create a new dataset", "name", name)
+func (s *Service) CreateDataset(ctx context.Context, ds *registry.Dataset) (*registry.DatasetVersion, error) {
+	slog.Debug("attempting to create a new dataset", "name", ds.Name)
 
 	var dsv *registry.DatasetVersion
-	err := s.engine.DatabaseClient.Transaction(func(tx *gorm.DB) error {
-		engine := s.engine.WithTx(tx)
+	err := s.engine.DatabaseClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		engine := s.engine.WithContext(ctx).WithTx(tx)
 
 		// create dataset
-		ds, err := engine.CreateDatasetRecord(name, description)
+		err := engine.CreateDatasetRecord(ds)
 		if err != nil {
-			if IsUniqueConstraintError(err) {
-				return fmt.Errorf("%w: %s", ErrDatasetAlreadyExists, name)
+			if errors.Is(err, registryerrors.ErrAlreadyExists) {
+				return fmt.Errorf("%w: %s", ErrDatasetAlreadyExists, ds.Name)
 			}
 
 			return err
 		}
 
 		// create first version
-		dsv, err = engine.CreateDatasetVersionRecord(ds.Name, description)
+		dsv, err = engine.CreateDatasetVersionRecord(ds.Name, ds.Description)
 		if err != nil {
 			return err
 		}
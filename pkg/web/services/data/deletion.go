@@ -0,0 +1,108 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+var ErrAssetDeletionNotFound = errors.New("asset deletion not found")
+
+// requestAssetDeletion opens a deletion of a referenced asset and notifies the referencing datasets
+func (s *Service) requestAssetDeletion(ctx context.Context, asset *registry.Asset, refs []registry.AssetReference, purge bool) (*registry.AssetDeletion, error) {
+	deletion, err := s.engine.WithContext(ctx).RequestAssetDeletion(asset, PrincipalFrom(ctx).ID, purge)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]map[string]any, len(refs))
+	for i, ref := range refs {
+		versions[i] = map[string]any{"dataset": ref.Dataset, "version": ref.Version, "status": ref.Status}
+	}
+
+	s.recordDeletionEvent(ctx, deletion, registry.SeverityWarning, "requested", map[string]any{
+		"purge":       purge,
+		"purge_after": deletion.PurgeAfter,
+		"versions":    versions,
+	})
+	return deletion, nil
+}
+
+// GetAssetDeletion returns the latest deletion request of an asset
+func (s *Service) GetAssetDeletion(ctx context.Context, checksum string) (*registry.AssetDeletion, error) {
+	slog.Debug("attempting to get asset deletion", "checksum", checksum)
+
+	asset, err := s.GetAsset(ctx, checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	deletion, err := s.engine.WithContext(ctx).GetAssetDeletionRecord(asset)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrAssetDeletionNotFound, checksum)
+		}
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// ListAssetDeletions returns a page of deletion requests, all statuses when status is empty
+func (s *Service) ListAssetDeletions(ctx context.Context, status registry.DeletionStatus, cursor uint, limit uint) ([]*registry.AssetDeletion, error) {
+	slog.Debug("attempting to list asset deletions", "status", status)
+	return s.engine.WithContext(ctx).ListAssetDeletionRecords(status, cursor, limit)
+}
+
+// ObjectToAssetDeletion stops the pending deletion of an asset, keeping it
+func (s *Service) ObjectToAssetDeletion(ctx context.Context, checksum string, reason string) (*registry.AssetDeletion, error) {
+	slog.Debug("attempting to object to asset deletion", "checksum", checksum)
+
+	deletion, err := s.GetAssetDeletion(ctx, checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	principal := PrincipalFrom(ctx)
+	if err := s.engine.WithContext(ctx).ObjectToAssetDeletion(deletion, principal.ID, reason); err != nil {
+		return nil, err
+	}
+
+	s.recordDeletionEvent(ctx, deletion, registry.SeverityInfo, "objected", map[string]any{"objection": reason})
+	return deletion, nil
+}
+
+// CancelAssetDeletion withdraws the pending deletion of an asset
+func (s *Service) CancelAssetDeletion(ctx context.Context, checksum string) (*registry.AssetDeletion, error) {
+	slog.Debug("attempting to cancel asset deletion", "checksum", checksum)
+
+	deletion, err := s.GetAssetDeletion(ctx, checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.engine.WithContext(ctx).CancelAssetDeletion(deletion); err != nil {
+		return nil, err
+	}
+
+	s.recordDeletionEvent(ctx, deletion, registry.SeverityInfo, "cancelled", nil)
+	return deletion, nil
+}
+
+func (s *Service) recordDeletionEvent(ctx context.Context, deletion *registry.AssetDeletion, severity registry.Severity, action string, extra map[string]any) {
+	data := map[string]any{
+		"deletion": deletion.ID,
+		"checksum": deletion.Asset.Checksum,
+		"status":   deletion.Status,
+		"action":   action,
+	}
+	for k, v := range extra {
+		data[k] = v
+	}
+
+	message := fmt.Sprintf("asset %s deletion %s", deletion.Asset.Checksum, action)
+	s.recordEvent(ctx, registry.EventAssetDeletion, severity, message, data)
+}
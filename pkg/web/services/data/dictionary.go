@@ -0,0 +1,55 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+var ErrDatasetSchemaNotFound = errors.New("dataset schema not found")
+
+// GetDatasetSchema returns a data dictionary revision, revision 0 returns the current one
+func (s *Service) GetDatasetSchema(ctx context.Context, name string, revision int) (*registry.DatasetSchema, error) {
+	slog.Debug("attempting to get dataset schema", "dataset", name, "revision", revision)
+
+	schema, err := s.engine.WithContext(ctx).GetDatasetSchemaRecord(name, revision)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrDatasetSchemaNotFound, name)
+		}
+		return nil, err
+	}
+	return schema, nil
+}
+
+// ListDatasetSchemas returns the data dictionary history of a dataset, newest first
+func (s *Service) ListDatasetSchemas(ctx context.Context, name string) ([]*registry.DatasetSchema, error) {
+	slog.Debug("attempting to list dataset schemas", "dataset", name)
+
+	schemas, err := s.engine.WithContext(ctx).ListDatasetSchemaRecords(name)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrDatasetNotFound, name)
+		}
+		return nil, err
+	}
+	return schemas, nil
+}
+
+// SaveDatasetSchema stores a new data dictionary revision, earlier revisions are kept as history
+func (s *Service) SaveDatasetSchema(ctx context.Context, name string, schema *registry.DatasetSchema) error {
+	slog.Debug("attempting to save dataset schema", "dataset", name, "columns", len(schema.Columns))
+
+	schema.CreatedBy = PrincipalFrom(ctx).ID
+	if err := s.engine.WithContext(ctx).CreateDatasetSchemaRecord(name, schema); err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return fmt.Errorf("%w: %s", ErrDatasetNotFound, name)
+		}
+		return err
+	}
+	return nil
+}
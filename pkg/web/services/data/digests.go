@@ -0,0 +1,42 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+// GetAssetDigests lists the digests computed for an asset under other algorithms than sha256
+func (s *Service) GetAssetDigests(ctx context.Context, checksum string) ([]*registry.AssetDigest, error) {
+	slog.Debug("attempting to get asset digests", "checksum", checksum)
+
+	asset, err := s.GetAsset(ctx, checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.engine.WithContext(ctx).ListAssetDigestRecords(asset)
+}
+
+// GetAssetByDigest finds an asset by its digest under another algorithm, for clients moving off sha256.
+// Unknown digests spend the caller probe quota like unknown checksums do.
+func (s *Service) GetAssetByDigest(ctx context.Context, algorithm string, digest string) (*registry.Asset, error) {
+	slog.Debug("attempting to get asset by digest", "algorithm", algorithm, "digest", digest)
+	if err := s.probes.Check(ctx); err != nil {
+		return nil, err
+	}
+
+	asset, err := s.engine.WithContext(ctx).GetAssetByDigestRecord(algorithm, digest)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, s.probes.Miss(ctx, fmt.Errorf("%w: %s digest %s", ErrAssetNotFound, algorithm, digest))
+		}
+		return nil, err
+	}
+
+	return asset, nil
+}
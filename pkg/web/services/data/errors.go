@@ -4,7 +4,8 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/web/api/apierrors"
 )
 
 var (
@@ -17,6 +18,57 @@ var (
 	ErrAssetIsReady             = errors.New("reuploading a ready asset is not allowed")
 )
 
+// ErrorCode is a machine readable reason attached to a failed batch item, see the apierrors catalog
+type ErrorCode = apierrors.Code
+
+const (
+	CodeValidation      = apierrors.Validation
+	CodeDuplicateItem   = apierrors.DuplicateItem
+	CodeAssetExists     = apierrors.AssetExists
+	CodeAssetDeleted    = apierrors.AssetDeleted
+	CodeAssetNotFound   = apierrors.AssetNotFound
+	CodeAssetNotReady   = apierrors.AssetNotReady
+	CodeAccessDenied    = apierrors.AccessDenied
+	CodeContentRejected = apierrors.ContentRejected
+	CodeObjectTooLarge  = apierrors.ObjectTooLarge
+	CodeQuotaExceeded   = apierrors.QuotaExceeded
+	CodePresignFailed   = apierrors.PresignFailed
+	CodeStorageDown     = apierrors.StorageUnavailable
+	CodeInternal        = apierrors.Internal
+)
+
+// presignCode is the item code of a failed presign, telling an unavailable store apart
+func presignCode(err error) ErrorCode {
+	if errors.Is(err, registry.ErrStorageUnavailable) {
+		return CodeStorageDown
+	}
+	return CodePresignFailed
+}
+
+// ItemError describes why a single item of a batch request failed
+type ItemError struct {
+	Index     int       `json:"index"`
+	Checksum  string    `json:"checksum,omitempty"`
+	Code      ErrorCode `json:"code"`
+	Field     string    `json:"field,omitempty"`
+	Message   string    `json:"message"`
+	Retryable bool      `json:"retryable"`
+}
+
+func NewItemError(index int, checksum string, code ErrorCode, err error) *ItemError {
+	return &ItemError{
+		Index:     index,
+		Checksum:  checksum,
+		Code:      code,
+		Message:   err.Error(),
+		Retryable: code.Retryable(),
+	}
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d (%s): %s: %s", e.Index, e.Checksum, e.Code, e.Message)
+}
+
 type MultiError struct {
 	Errors []*error
 }
@@ -32,11 +84,3 @@ type AssetsExistsError struct {
 func (e AssetsExistsError) Error() string {
 	return fmt.Sprintf("%d asset(s) already exist", len(e.Checksums))
 }
-
-func IsUniqueConstraintError(err error) bool {
-	var pgErr *pgconn.PgError
-	if errors.As(err, &pgErr) {
-		return pgErr.Code == "23505"
-	}
-	return false
-}
@@ -0,0 +1,30 @@
+package data
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// ListEvents returns registry events, newest first
+func (s *Service) ListEvents(ctx context.Context, opts ...registry.EventOption) ([]*registry.Event, error) {
+	slog.Debug("attempting to list events")
+	return s.engine.WithContext(ctx).ListEventRecords(opts...)
+}
+
+// recordEvent stores an event attributed to the request principal, failures are only logged
+func (s *Service) recordEvent(ctx context.Context, kind string, severity registry.Severity, message string, data map[string]any) {
+	event, err := registry.NewEvent(kind, severity, message, data)
+	if err != nil {
+		slog.Error("failed to build event", "kind", kind, "error", err)
+		return
+	}
+
+	principal := PrincipalFrom(ctx)
+	event.Principal = principal.ID
+	event.Project = principal.Project
+	if err := s.engine.WithContext(ctx).CreateEventRecord(event); err != nil {
+		slog.Error("failed to record event", "kind", kind, "error", err)
+	}
+}
@@ -0,0 +1,52 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+const EventGarbageCollection = "storage.gc"
+
+// StartGarbageCollection runs the ingress garbage collector in the background.
+// The run outlives the request, its outcome is recorded as a storage.gc event.
+func (s *Service) StartGarbageCollection(ctx context.Context) {
+	slog.Debug("attempting to start garbage collection", "principal", PrincipalFrom(ctx).ID)
+
+	go func() {
+		ctx := context.WithoutCancel(ctx)
+		report, err := s.engine.WithContext(ctx).NewGarbageCollector().Run(ctx)
+
+		severity, message := registry.SeverityInfo, "garbage collection completed"
+		data := map[string]any{}
+		if report != nil {
+			data = map[string]any{
+				"scanned":    report.Scanned,
+				"deleted":    report.Deleted,
+				"orphaned":   report.Orphaned,
+				"expired":    report.Expired,
+				"promoted":   report.Promoted,
+				"referenced": report.Referenced,
+				"bytes":      report.Bytes,
+				"duration":   report.Duration.String(),
+			}
+		}
+		switch {
+		case errors.Is(err, registry.ErrGarbageCollectionRunning):
+			severity, message = registry.SeverityWarning, err.Error()
+		case err != nil:
+			slog.Error("manual garbage collection failed", "error", err)
+			severity, message = registry.SeverityError, err.Error()
+		}
+
+		s.recordEvent(ctx, EventGarbageCollection, severity, message, data)
+	}()
+}
+
+// PlanGarbageCollection lists the ingress objects a garbage collection would delete, nothing is deleted
+func (s *Service) PlanGarbageCollection(ctx context.Context) (*registry.DryRunReport, error) {
+	slog.Debug("attempting to plan garbage collection", "principal", PrincipalFrom(ctx).ID)
+	return s.engine.WithContext(ctx).NewGarbageCollector().Plan(ctx)
+}
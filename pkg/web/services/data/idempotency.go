@@ -0,0 +1,34 @@
+package data
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// ClaimIdempotencyKey claims an idempotency key of the caller for a request with fingerprint.
+// It returns nil when the request may run, or the completed response of the same request to replay.
+func (s *Service) ClaimIdempotencyKey(ctx context.Context, key string, fingerprint string) (*registry.IdempotencyRecord, error) {
+	slog.Debug("attempting to claim idempotency key", "key", key)
+	return s.engine.ClaimIdempotencyKey(idempotencyScope(ctx), key, fingerprint)
+}
+
+// CompleteIdempotencyKey stores the response of a claimed request for its retries, for at most ttl when positive
+func (s *Service) CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, contentType string, body []byte, ttl time.Duration) error {
+	return s.engine.CompleteIdempotencyKey(idempotencyScope(ctx), key, statusCode, contentType, body, ttl)
+}
+
+// ReleaseIdempotencyKey drops the claim of a failed request so its retry runs again
+func (s *Service) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	return s.engine.ReleaseIdempotencyKey(idempotencyScope(ctx), key)
+}
+
+// idempotencyScope keys idempotency records by project and caller, so callers can't replay each other's responses.
+// Anonymous callers are scoped to the address of their connection, see probeKey, a forwarding header naming another
+// caller's address does not reach its records.
+func idempotencyScope(ctx context.Context) string {
+	principal := PrincipalFrom(ctx)
+	return principal.Project + "/" + probeKey(principal)
+}
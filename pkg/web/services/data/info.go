@@ -0,0 +1,26 @@
+package data
+
+import (
+	"math"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// ServerInfo holds the limits clients fit their requests to, they only change when the server restarts
+type ServerInfo struct {
+	PresignTTL     time.Duration
+	PresignMaxTTL  time.Duration
+	SearchMaxLimit uint
+	RequireAPIKeys bool
+}
+
+// GetServerInfo returns the limits of the server
+func (s *Service) GetServerInfo() *ServerInfo {
+	return &ServerInfo{
+		PresignTTL:     s.engine.PresignTTL(0),
+		PresignMaxTTL:  s.engine.PresignTTL(math.MaxInt64),
+		SearchMaxLimit: registry.SearchMaxLimit,
+		RequireAPIKeys: s.requireAPIKeys,
+	}
+}
@@ -0,0 +1,32 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrJobNoDryRun = errors.New("job does not support dry runs")
+)
+
+// PlanJob lists what a run of a maintenance job would remove without running it.
+// Only destructive jobs, e.g. gc, deletion and partitions, support dry runs.
+func (s *Service) PlanJob(ctx context.Context, name string) (*registry.DryRunReport, error) {
+	slog.Debug("attempting to plan job", "job", name)
+
+	for _, job := range s.engine.Jobs() {
+		if job.Name != name {
+			continue
+		}
+		if job.Plan == nil {
+			return nil, fmt.Errorf("%w: %s", ErrJobNoDryRun, name)
+		}
+		return job.Plan(ctx)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+}
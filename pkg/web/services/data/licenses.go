@@ -0,0 +1,63 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+var ErrLicenseRestrictionNotFound = errors.New("license restriction not found")
+
+func (s *Service) ListLicenseRestrictions(ctx context.Context) ([]*registry.LicenseRestriction, error) {
+	slog.Debug("attempting to list license restrictions")
+	return s.engine.WithContext(ctx).ListLicenseRestrictionRecords()
+}
+
+func (s *Service) SaveLicenseRestriction(ctx context.Context, restriction *registry.LicenseRestriction) error {
+	slog.Debug("attempting to save license restriction", "license", restriction.License)
+	return s.engine.WithContext(ctx).SaveLicenseRestrictionRecord(restriction)
+}
+
+func (s *Service) DeleteLicenseRestriction(ctx context.Context, license string) error {
+	slog.Debug("attempting to delete license restriction", "license", license)
+
+	restriction, err := s.engine.WithContext(ctx).GetLicenseRestrictionRecord(license)
+	if err != nil {
+		return err
+	}
+	if restriction == nil {
+		return fmt.Errorf("%w: %s", ErrLicenseRestrictionNotFound, license)
+	}
+	return s.engine.WithContext(ctx).DeleteLicenseRestrictionRecord(license)
+}
+
+// AuthorizeUsage checks the caller may download content under the given licenses.
+// Download paths must call it before handing out content, unknown licenses are never restricted.
+func (s *Service) AuthorizeUsage(ctx context.Context, licenses ...string) error {
+	var known []string
+	for _, license := range licenses {
+		if license != "" {
+			known = append(known, license)
+		}
+	}
+	if len(known) == 0 {
+		return nil
+	}
+
+	restrictions, err := s.engine.WithContext(ctx).ListLicenseRestrictionRecords(known...)
+	if err != nil {
+		return err
+	}
+
+	principal := PrincipalFrom(ctx)
+	for _, restriction := range restrictions {
+		if restriction.Blocks(principal.Roles) {
+			slog.WarnContext(ctx, "license restricts usage", "license", restriction.License, "principal", principal.ID)
+			return fmt.Errorf("%w: %s", registry.ErrLicenseRestricted, restriction.License)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// AddDatasetVersionAssets adds assets to a draft dataset version
+func (s *Service) AddDatasetVersionAssets(ctx context.Context, name string, number int, checksums ...string) (*registry.DatasetVersion, error) {
+	slog.Debug("attempting to add dataset version assets", "dataset", name, "version", number, "total", len(checksums))
+
+	dsv, err := s.getDatasetVersion(ctx, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := s.getMemberAssets(ctx, checksums...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.engine.WithContext(ctx).AddAssetsToVersion(dsv, assets...); err != nil {
+		return nil, err
+	}
+
+	s.recordVersionEvent(ctx, dsv, "assets added", map[string]any{"total": len(assets)})
+	return dsv, nil
+}
+
+// RemoveDatasetVersionAssets removes assets from a draft dataset version
+func (s *Service) RemoveDatasetVersionAssets(ctx context.Context, name string, number int, checksums ...string) (*registry.DatasetVersion, error) {
+	slog.Debug("attempting to remove dataset version assets", "dataset", name, "version", number, "total", len(checksums))
+
+	dsv, err := s.getDatasetVersion(ctx, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := s.getMemberAssets(ctx, checksums...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.engine.WithContext(ctx).RemoveAssetsFromVersion(dsv, assets...); err != nil {
+		return nil, err
+	}
+
+	s.recordVersionEvent(ctx, dsv, "assets removed", map[string]any{"total": len(assets)})
+	return dsv, nil
+}
+
+// ListDatasetVersionAssets returns a page of the assets in a dataset version
+func (s *Service) ListDatasetVersionAssets(ctx context.Context, name string, number int, cursor uint, limit uint) ([]*registry.Asset, error) {
+	slog.Debug("attempting to list dataset version assets", "dataset", name, "version", number)
+
+	dsv, err := s.getDatasetVersion(ctx, name, number)
+	if err != nil {
+		return nil, err
+	}
+	return s.engine.WithContext(ctx).ListVersionAssets(dsv, cursor, limit)
+}
+
+// getMemberAssets fetches the assets of a membership change, failing if any checksum is unknown or in the trash
+func (s *Service) getMemberAssets(ctx context.Context, checksums ...string) ([]*registry.Asset, error) {
+	normalized := make([]string, len(checksums))
+	for i, c := range checksums {
+		normalized[i] = registry.NormalizeString(c)
+	}
+
+	records, err := s.engine.WithContext(ctx).ListAssetsRecords(
+		registry.WithChecksums(normalized...),
+		registry.WithLimit(registry.SearchMaxLimit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool, len(records))
+	assets := make([]*registry.Asset, 0, len(records))
+	for _, r := range records {
+		if r.State == registry.StatusDeleted {
+			continue
+		}
+		found[r.Checksum] = true
+		assets = append(assets, r)
+	}
+
+	var missing []string
+	for _, c := range normalized {
+		if !found[c] {
+			missing = append(missing, c)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAssetNotFound, strings.Join(missing, ", "))
+	}
+
+	return assets, nil
+}
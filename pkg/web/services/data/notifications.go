@@ -0,0 +1,85 @@
+package data
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+var (
+	ErrStorageEventsDisabled = errors.New("storage event ingestion is not enabled")
+	ErrInvalidStorageToken   = errors.New("invalid storage event token")
+)
+
+// StorageEventReport counts what became of the records of bucket notifications
+type StorageEventReport struct {
+	Finalized int
+	Skipped   int
+	Rejected  int
+	Failed    int
+}
+
+// WithStorageEventToken accepts bucket notification webhooks authorized with token
+func WithStorageEventToken(token registry.Secret) ServiceOption {
+	return func(s *Service) {
+		s.storageEventToken = token
+	}
+}
+
+// VerifyStorageEventToken checks the Authorization header of a bucket notification webhook,
+// MinIO sends a single word auth_token as a bearer token
+func (s *Service) VerifyStorageEventToken(authorization string) error {
+	if s.storageEventToken.Value() == "" {
+		return ErrStorageEventsDisabled
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.storageEventToken.Value())) != 1 {
+		return ErrInvalidStorageToken
+	}
+	return nil
+}
+
+// IngestObjectNotifications finalizes the pending assets whose ingress objects were created.
+// Records of other keys or of assets that aren't pending are skipped, uploads that don't check out
+// stay pending for the client finalize to report. Only failures worth redelivering are returned.
+func (s *Service) IngestObjectNotifications(ctx context.Context, notifications []registry.ObjectNotification) (*StorageEventReport, error) {
+	slog.Debug("attempting to ingest object notifications", "records", len(notifications))
+
+	report := &StorageEventReport{}
+	var errs []error
+	for _, n := range notifications {
+		project, checksum, ok := s.engine.IngressChecksum(n.Bucket, n.Key)
+		if !ok {
+			report.Skipped++
+			continue
+		}
+
+		principal := PrincipalFrom(ctx)
+		principal.Project = project
+		_, err := s.FinalizeAsset(WithPrincipal(ctx, principal), checksum)
+		switch {
+		case err == nil:
+			report.Finalized++
+		case errors.Is(err, ErrAssetNotFound),
+			errors.Is(err, registry.ErrAssetNotPending),
+			errors.Is(err, registry.ErrUploadNotFound):
+			report.Skipped++
+		case errors.Is(err, registry.ErrChecksumMismatch),
+			errors.Is(err, registry.ErrSizeMismatch),
+			errors.Is(err, registry.ErrObjectTooLarge):
+			slog.Warn("uploaded object did not check out", "checksum", checksum, "key", n.Key, "error", err)
+			report.Rejected++
+		default:
+			slog.Error("failed to finalize uploaded asset", "checksum", checksum, "key", n.Key, "error", err)
+			report.Failed++
+			errs = append(errs, err)
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
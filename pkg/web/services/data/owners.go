@@ -0,0 +1,20 @@
+package data
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// GetOwnerlessReport totals the assets nobody owns, so data stewards can chase owners
+func (s *Service) GetOwnerlessReport(ctx context.Context) (*registry.OwnerlessReport, error) {
+	slog.Debug("attempting to report ownerless assets")
+	return s.engine.WithContext(ctx).ReportOwnerlessAssets(ctx)
+}
+
+// ListOwnerlessAssets returns a page of the assets nobody owns, oldest first
+func (s *Service) ListOwnerlessAssets(ctx context.Context, cursor uint, limit uint) ([]*registry.Asset, error) {
+	slog.Debug("attempting to list ownerless assets", "cursor", cursor)
+	return s.engine.WithContext(ctx).ListOwnerlessAssetRecords(cursor, limit)
+}
@@ -0,0 +1,118 @@
+package data
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// pageTokenVersion is bumped whenever the token payload changes, older tokens are then rejected
+const pageTokenVersion = 1
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// pageToken is the signed payload of a page token: the keyset cursor of the next page
+// and the query state of the listing it continues
+type pageToken struct {
+	Version int             `json:"v"`
+	Cursor  uint            `json:"c"`
+	State   json.RawMessage `json:"s"`
+}
+
+// WithPageTokenKey signs page tokens with key, so tokens are valid on every replica sharing it
+func WithPageTokenKey(key registry.Secret) ServiceOption {
+	return func(s *Service) {
+		s.pageTokenKey = key
+	}
+}
+
+// randomPageTokenKey is used when no page token key is configured
+func randomPageTokenKey() registry.Secret {
+	slog.Warn("No page token key configured, generated a random one; page tokens only work on this replica until restart")
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		panic(fmt.Errorf("generate page token key: %w", err))
+	}
+	return registry.Secret(hex.EncodeToString(raw))
+}
+
+// EncodePageToken returns the opaque token of the page after cursor, bound to the query state of the listing
+func (s *Service) EncodePageToken(cursor uint, state any) (string, error) {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("encode page token: %w", err)
+	}
+
+	payload, err := json.Marshal(pageToken{Version: pageTokenVersion, Cursor: cursor, State: raw})
+	if err != nil {
+		return "", fmt.Errorf("encode page token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(s.signPageToken(payload)), nil
+}
+
+// DecodePageToken verifies a page token and returns its cursor, an empty token starts at the first page.
+// state points at the query state of the request: left empty it is filled with the state of the token,
+// set it must match it, so a listing can't change its filters between pages.
+func (s *Service) DecodePageToken(token string, state any) (uint, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	encoded, signature, ok := strings.Cut(token, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if !ok || err != nil {
+		return 0, fmt.Errorf("%w: malformed", ErrInvalidPageToken)
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.signPageToken(payload)) {
+		return 0, fmt.Errorf("%w: bad signature", ErrInvalidPageToken)
+	}
+
+	var page pageToken
+	if err := json.Unmarshal(payload, &page); err != nil {
+		return 0, fmt.Errorf("%w: malformed", ErrInvalidPageToken)
+	}
+	if page.Version != pageTokenVersion {
+		return 0, fmt.Errorf("%w: issued by another server version", ErrInvalidPageToken)
+	}
+
+	current, err := json.Marshal(state)
+	if err != nil {
+		return 0, fmt.Errorf("decode page token: %w", err)
+	}
+	empty, err := json.Marshal(reflect.New(reflect.TypeOf(state).Elem()).Interface())
+	if err != nil {
+		return 0, fmt.Errorf("decode page token: %w", err)
+	}
+
+	switch {
+	case bytes.Equal(current, page.State):
+	case bytes.Equal(current, empty):
+		if err := json.Unmarshal(page.State, state); err != nil {
+			return 0, fmt.Errorf("%w: malformed", ErrInvalidPageToken)
+		}
+	default:
+		return 0, fmt.Errorf("%w: the query changed since the token was issued", ErrInvalidPageToken)
+	}
+
+	return page.Cursor, nil
+}
+
+func (s *Service) signPageToken(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(s.pageTokenKey.Value()))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
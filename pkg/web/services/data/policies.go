@@ -0,0 +1,44 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+var ErrContentPolicyNotFound = errors.New("content policy not found")
+
+func (s *Service) GetContentPolicy(ctx context.Context, project string) (*registry.ContentPolicy, error) {
+	slog.Debug("attempting to get content policy", "project", project)
+
+	policy, err := s.engine.WithContext(ctx).GetContentPolicyRecord(project)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("%w: %s", ErrContentPolicyNotFound, project)
+	}
+	return policy, nil
+}
+
+func (s *Service) SaveContentPolicy(ctx context.Context, policy *registry.ContentPolicy) error {
+	slog.Debug("attempting to save content policy", "project", policy.Project)
+	return s.engine.WithContext(ctx).SaveContentPolicyRecord(policy)
+}
+
+func (s *Service) DeleteContentPolicy(ctx context.Context, project string) error {
+	slog.Debug("attempting to delete content policy", "project", project)
+
+	if _, err := s.GetContentPolicy(ctx, project); err != nil {
+		return err
+	}
+	return s.engine.WithContext(ctx).DeleteContentPolicyRecord(project)
+}
+
+// projectContentPolicy returns the content policy of the caller project, nil accepts everything
+func (s *Service) projectContentPolicy(ctx context.Context) (*registry.ContentPolicy, error) {
+	return s.engine.WithContext(ctx).GetContentPolicyRecord(PrincipalFrom(ctx).Project)
+}
@@ -0,0 +1,154 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+const EventPresignRate = "presign.rate"
+
+var ErrPresignRateExceeded = errors.New("presign rate limit exceeded")
+
+// PresignLimits bounds how many urls a principal may be issued per operation and project within a window.
+// Crossing Warn emits a warning event, crossing Limit rejects further issuance. Zero disables either.
+type PresignLimits struct {
+	Warn   int
+	Limit  int
+	Window time.Duration
+}
+
+type presignRate struct {
+	limits PresignLimits
+
+	mu      sync.Mutex
+	windows map[string]*rateWindow
+	swept   time.Time
+}
+
+type rateWindow struct {
+	start     time.Time
+	count     int
+	warned    bool
+	throttled bool
+}
+
+// WithPresignLimits tracks presign issuance rates per principal
+func WithPresignLimits(limits PresignLimits) ServiceOption {
+	return func(s *Service) {
+		if limits.Window <= 0 {
+			limits.Window = time.Minute
+		}
+		s.presignRate = &presignRate{limits: limits, windows: make(map[string]*rateWindow)}
+	}
+}
+
+// checkPresignRate counts issued urls and enforces the soft and hard limits
+func (s *Service) checkPresignRate(ctx context.Context, urls ...*registry.PresignedUrl) error {
+	if s.presignRate == nil || len(urls) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, url := range urls {
+		counts[url.Operation]++
+	}
+
+	principal := PrincipalFrom(ctx)
+	for operation, n := range counts {
+		if err := s.presignRate.add(ctx, s.engine, principal, operation, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *presignRate) add(ctx context.Context, engine *registry.Engine, principal Principal, operation string, n int) error {
+	key := probeKey(principal) + "|" + principal.Project + "|" + operation
+	now := time.Now()
+
+	r.mu.Lock()
+	r.sweep(now)
+	w, ok := r.windows[key]
+	if !ok || now.Sub(w.start) >= r.limits.Window {
+		w = &rateWindow{start: now}
+		r.windows[key] = w
+	}
+
+	w.count += n
+	count := w.count
+
+	warn := r.limits.Warn > 0 && count > r.limits.Warn && !w.warned
+	if warn {
+		w.warned = true
+	}
+
+	throttle := r.limits.Limit > 0 && count > r.limits.Limit
+	firstThrottle := throttle && !w.throttled
+	if throttle {
+		// rejected urls are never handed out
+		w.count -= n
+		w.throttled = true
+	}
+	r.mu.Unlock()
+
+	switch {
+	case firstThrottle:
+		emitPresignRateEvent(engine, principal, operation, registry.SeverityError, "presign issuance throttled", count, r.limits)
+	case warn:
+		emitPresignRateEvent(engine, principal, operation, registry.SeverityWarning, "presign issuance above soft limit", count, r.limits)
+	}
+
+	if throttle {
+		return ErrPresignRateExceeded
+	}
+	return nil
+}
+
+// sweep drops expired windows, at most once per window
+func (r *presignRate) sweep(now time.Time) {
+	if now.Sub(r.swept) < r.limits.Window {
+		return
+	}
+	r.swept = now
+
+	for key, w := range r.windows {
+		if now.Sub(w.start) >= r.limits.Window {
+			delete(r.windows, key)
+		}
+	}
+}
+
+func emitPresignRateEvent(engine *registry.Engine, principal Principal, operation string, severity registry.Severity, message string, count int, limits PresignLimits) {
+	slog.Warn(message,
+		"principal", principal.ID,
+		"client_ip", principal.ClientIP,
+		"project", principal.Project,
+		"operation", operation,
+		"count", count,
+		"window", limits.Window,
+	)
+
+	event, err := registry.NewEvent(EventPresignRate, severity, message, map[string]any{
+		"operation": operation,
+		"count":     count,
+		"warn":      limits.Warn,
+		"limit":     limits.Limit,
+		"window":    limits.Window.String(),
+		"client_ip": principal.ClientIP,
+	})
+	if err != nil {
+		slog.Error("failed to build presign rate event", "error", err)
+		return
+	}
+
+	event.Principal = principal.ID
+	event.Project = principal.Project
+	if err := engine.CreateEventRecord(event); err != nil {
+		slog.Error("failed to record presign rate event", "error", err)
+	}
+}
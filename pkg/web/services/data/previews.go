@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// PreviewUrl is a presigned download of an asset preview
+type PreviewUrl struct {
+	Url     *registry.PresignedUrl
+	Preview *registry.Preview
+}
+
+// GetAssetPreviewUrls presigns the previews of ready assets by checksum, a zero expiresIn uses the server default.
+// Assets without a preview are skipped, so are sensitive ones: their previews show the content,
+// so they are only reachable through a recorded download.
+func (s *Service) GetAssetPreviewUrls(ctx context.Context, assets []*registry.Asset, expiresIn time.Duration) map[string]*PreviewUrl {
+	slog.Debug("attempting to get asset preview urls", "total", len(assets))
+
+	var checksums []string
+	previews := make(map[string]*registry.Preview, len(assets))
+	for _, asset := range assets {
+		if asset.State != registry.StatusReady || asset.Classification.Sensitive() {
+			continue
+		}
+		if preview := registry.AssetPreview(asset); preview != nil {
+			checksums = append(checksums, asset.Checksum)
+			previews[asset.Checksum] = preview
+		}
+	}
+
+	urls, errs := s.engine.WithContext(ctx).PreviewUrlsExpire(ctx, checksums, expiresIn)
+
+	results := make(map[string]*PreviewUrl, len(urls))
+	degraded := false
+	for i, url := range urls {
+		if errs[i] != nil {
+			// a missing preview or an unavailable store shouldn't fail the listing it decorates
+			if errors.Is(errs[i], registry.ErrStorageUnavailable) {
+				if !degraded {
+					slog.WarnContext(ctx, "storage unavailable, listing assets without previews", "error", errs[i])
+				}
+				degraded = true
+				continue
+			}
+			slog.WarnContext(ctx, "failed to presign asset preview", "checksum", checksums[i], "error", errs[i])
+			continue
+		}
+		results[checksums[i]] = &PreviewUrl{Url: url, Preview: previews[checksums[i]]}
+	}
+	return results
+}
@@ -0,0 +1,48 @@
+package data
+
+import (
+	"context"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// AnonymousPrincipal identifies callers until authentication is configured
+const AnonymousPrincipal = "anonymous"
+
+// Principal describes who issued a request
+type Principal struct {
+	ID        string
+	Project   string
+	Roles     []string
+	ClientIP  string
+	UserAgent string
+	RequestID string
+}
+
+type principalKey struct{}
+
+// WithPrincipal attaches the request principal to ctx. Registry calls made with ctx only see
+// the records of its project and their writes are audited as its own.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey{}, principal)
+
+	actor := PrincipalFrom(ctx)
+	ctx = registry.WithProject(ctx, actor.Project)
+	return registry.WithAuditActor(ctx, registry.AuditActor{
+		Principal: actor.ID,
+		Project:   actor.Project,
+		RequestID: actor.RequestID,
+	})
+}
+
+// PrincipalFrom returns the request principal, anonymous in the default project when none was attached
+func PrincipalFrom(ctx context.Context) Principal {
+	principal, _ := ctx.Value(principalKey{}).(Principal)
+	if principal.ID == "" {
+		principal.ID = AnonymousPrincipal
+	}
+	if principal.Project == "" {
+		principal.Project = registry.DEFAULT_PROJECT
+	}
+	return principal
+}
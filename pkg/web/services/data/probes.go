@@ -0,0 +1,133 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"sync"
+	"time"
+)
+
+var (
+	ErrAssetNotAccessible = errors.New("asset not accessible")
+	ErrTooManyProbes      = errors.New("too many lookups of unknown assets")
+
+	// probeStats exposes guard counters, served with the other expvars
+	probeStats = expvar.NewMap("aether_probe_guard")
+)
+
+// ProbeGuard protects checksum lookups against catalog enumeration.
+// Every lookup of an unknown asset counts as a probe for the calling principal,
+// once the quota is spent within the window further lookups are rejected.
+// With hideMissing, unknown assets are reported as not accessible instead of not found.
+type ProbeGuard struct {
+	limit       int
+	window      time.Duration
+	hideMissing bool
+
+	mu     sync.Mutex
+	probes map[string]*probeWindow
+	swept  time.Time
+}
+
+type probeWindow struct {
+	start time.Time
+	count int
+}
+
+// NewProbeGuard creates a guard, a zero limit disables the quota
+func NewProbeGuard(limit int, window time.Duration, hideMissing bool) *ProbeGuard {
+	if window <= 0 {
+		window = time.Minute
+	}
+
+	return &ProbeGuard{
+		limit:       limit,
+		window:      window,
+		hideMissing: hideMissing,
+		probes:      make(map[string]*probeWindow),
+	}
+}
+
+// probeKey identifies a caller, anonymous callers are told apart by the address of their connection,
+// forwarding headers only count from trusted proxies so changing them does not reset a quota
+func probeKey(principal Principal) string {
+	if principal.ID == AnonymousPrincipal {
+		return principal.ID + "@" + principal.ClientIP
+	}
+	return principal.ID
+}
+
+// Check fails when the caller already spent its probe quota
+func (g *ProbeGuard) Check(ctx context.Context) error {
+	if g == nil || g.limit <= 0 {
+		return nil
+	}
+
+	key := probeKey(PrincipalFrom(ctx))
+	now := time.Now()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	w, ok := g.probes[key]
+	if !ok || now.Sub(w.start) >= g.window || w.count < g.limit {
+		return nil
+	}
+
+	probeStats.Add("rejected", 1)
+	return ErrTooManyProbes
+}
+
+// Miss records a lookup of an unknown asset and returns the error to report
+func (g *ProbeGuard) Miss(ctx context.Context, err error) error {
+	if g == nil {
+		return err
+	}
+	g.record(ctx, 1)
+
+	if g.hideMissing {
+		return ErrAssetNotAccessible
+	}
+	return err
+}
+
+// record counts n lookups of unknown assets against the caller quota
+func (g *ProbeGuard) record(ctx context.Context, n int) {
+	if g == nil || n <= 0 {
+		return
+	}
+	probeStats.Add("misses", int64(n))
+
+	if g.limit <= 0 {
+		return
+	}
+
+	key := probeKey(PrincipalFrom(ctx))
+	now := time.Now()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.sweep(now)
+	w, ok := g.probes[key]
+	if !ok || now.Sub(w.start) >= g.window {
+		w = &probeWindow{start: now}
+		g.probes[key] = w
+	}
+	w.count += n
+}
+
+// sweep drops expired windows, at most once per window
+func (g *ProbeGuard) sweep(now time.Time) {
+	if now.Sub(g.swept) < g.window {
+		return
+	}
+	g.swept = now
+
+	for key, w := range g.probes {
+		if now.Sub(w.start) >= g.window {
+			delete(g.probes, key)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+var (
+	ErrProjectNotFound      = errors.New("project not found")
+	ErrProjectAlreadyExists = errors.New("project already exists")
+	ErrProjectDenied        = errors.New("project denied")
+)
+
+// CreateProject registers a project, its records and storage keys are kept apart from the other projects
+func (s *Service) CreateProject(ctx context.Context, name string, description string) (*registry.Project, error) {
+	slog.Debug("attempting to create project", "name", name)
+
+	project := &registry.Project{
+		Name:        name,
+		Description: description,
+		CreatedBy:   PrincipalFrom(ctx).ID,
+	}
+	if err := s.engine.WithContext(ctx).CreateProjectRecord(project); err != nil {
+		if errors.Is(err, registryerrors.ErrAlreadyExists) {
+			return nil, fmt.Errorf("%w: %s", ErrProjectAlreadyExists, name)
+		}
+		return nil, err
+	}
+	return project, nil
+}
+
+// GetProject returns a project by name
+func (s *Service) GetProject(ctx context.Context, name string) (*registry.Project, error) {
+	slog.Debug("attempting to get project", "name", name)
+
+	project, err := s.engine.WithContext(ctx).GetProjectRecord(name)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, name)
+		}
+		return nil, err
+	}
+	return project, nil
+}
+
+// ListProjects returns every project
+func (s *Service) ListProjects(ctx context.Context) ([]*registry.Project, error) {
+	slog.Debug("attempting to list projects")
+	return s.engine.WithContext(ctx).ListProjectRecords()
+}
+
+// SetProjectDefaults replaces the tags and Extra template applied to every asset created in a project
+func (s *Service) SetProjectDefaults(ctx context.Context, name string, tags []string, extra map[string]any) (*registry.Project, error) {
+	slog.Debug("attempting to set project defaults", "name", name, "tags", tags)
+
+	project, err := s.GetProject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.engine.WithContext(ctx).SetProjectDefaultsRecord(project, tags, extra); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// SetProjectQuota limits the bytes and objects a project stores, zero removes a limit
+func (s *Service) SetProjectQuota(ctx context.Context, name string, bytes int64, objects int64) (*registry.Project, error) {
+	slog.Debug("attempting to set project quota", "name", name, "bytes", bytes, "objects", objects)
+
+	project, err := s.GetProject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.engine.WithContext(ctx).SetProjectQuotaRecord(project, bytes, objects); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// GetProjectUsage returns the stored bytes and objects of a project with its quota,
+// callers only see the usage of the project they work in
+func (s *Service) GetProjectUsage(ctx context.Context, name string) (*registry.ProjectUsage, error) {
+	slog.Debug("attempting to get project usage", "name", name)
+
+	if project := PrincipalFrom(ctx).Project; registry.NormalizeString(name) != project {
+		return nil, fmt.Errorf("%w: usage of %q requested from project %q", ErrProjectDenied, name, project)
+	}
+
+	usage, err := s.engine.WithContext(ctx).GetUsage(name)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, name)
+		}
+		return nil, err
+	}
+	return usage, nil
+}
+
+// checkQuota fails when uploading objects more objects of bytes more bytes would take the caller project over its quota
+func (s *Service) checkQuota(ctx context.Context, objects int64, bytes int64) error {
+	usage, err := s.engine.WithContext(ctx).GetUsage(PrincipalFrom(ctx).Project)
+	if err != nil {
+		return err
+	}
+	return checkUsage(usage, objects, bytes)
+}
+
+// checkUsage is checkQuota against a usage fetched once, e.g. for every item of a batch
+func checkUsage(usage *registry.ProjectUsage, objects int64, bytes int64) error {
+	err := usage.Check(objects, bytes)
+	if err != nil {
+		slog.Warn("upload rejected by project quota", "project", usage.Project, "objects", objects, "bytes", bytes)
+	}
+	return err
+}
+
+// CheckProject fails unless the project of the request principal is a valid name of an existing project
+func (s *Service) CheckProject(ctx context.Context) error {
+	project := &registry.Project{Name: PrincipalFrom(ctx).Project}
+	if err := registry.ValidateProject(project); err != nil {
+		return err
+	}
+	_, err := s.GetProject(ctx, project.Name)
+	return err
+}
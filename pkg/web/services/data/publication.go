@@ -0,0 +1,297 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+	"gorm.io/gorm"
+)
+
+const EventDatasetVersion = "dataset.version"
+
+var (
+	ErrDatasetNotFound        = errors.New("dataset not found")
+	ErrDatasetVersionNotFound = errors.New("dataset version not found")
+	ErrDatasetChannelNotFound = errors.New("dataset channel not found")
+	ErrManifestNotFound       = errors.New("dataset version manifest not found")
+)
+
+// CreateDatasetVersion opens a new draft version of a dataset
+func (s *Service) CreateDatasetVersion(ctx context.Context, name string, description string) (*registry.DatasetVersion, error) {
+	slog.Debug("attempting to create dataset version", "dataset", name)
+
+	dsv, err := s.engine.WithContext(ctx).CreateDatasetVersionRecord(name, description)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrDatasetNotFound, name)
+		}
+		return nil, err
+	}
+	return dsv, nil
+}
+
+// SetDatasetApprovers replaces the roles that must approve versions of a dataset
+func (s *Service) SetDatasetApprovers(ctx context.Context, name string, roles []string) (*registry.Dataset, error) {
+	slog.Debug("attempting to set dataset approvers", "dataset", name, "roles", roles)
+
+	ds, err := s.engine.WithContext(ctx).GetDatasetRecord(name)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrDatasetNotFound, name)
+		}
+		return nil, err
+	}
+
+	if err := s.engine.WithContext(ctx).SetDatasetApproverRolesRecord(ds, roles); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// SubmitDatasetVersion moves a draft version into review
+func (s *Service) SubmitDatasetVersion(ctx context.Context, name string, number int) (*registry.DatasetVersion, error) {
+	slog.Debug("attempting to submit dataset version", "dataset", name, "version", number)
+
+	dsv, err := s.getDatasetVersion(ctx, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.engine.DatabaseClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		engine := s.engine.WithContext(ctx).WithTx(tx)
+
+		now := time.Now()
+		updates := map[string]any{"submitted_by": PrincipalFrom(ctx).ID, "submitted_at": now}
+		if err := engine.TransitionDatasetVersionRecord(dsv, registry.VersionDraft, registry.VersionReview, updates); err != nil {
+			return err
+		}
+		return engine.DeleteDatasetApprovalRecords(dsv)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordVersionEvent(ctx, dsv, "submitted", nil)
+	return s.getDatasetVersion(ctx, name, number)
+}
+
+// ApproveDatasetVersion signs off a version in review for every required role the caller holds
+func (s *Service) ApproveDatasetVersion(ctx context.Context, name string, number int) (*registry.DatasetVersion, error) {
+	slog.Debug("attempting to approve dataset version", "dataset", name, "version", number)
+
+	dsv, err := s.getDatasetVersion(ctx, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if dsv.Status != registry.VersionReview {
+		return nil, fmt.Errorf("%w: version %d is %s, not in review", registry.ErrVersionStatus, dsv.Number, dsv.Status)
+	}
+
+	principal := PrincipalFrom(ctx)
+	roles := dsv.Dataset.ApproverRolesFor(principal.Roles)
+	if len(roles) == 0 {
+		return nil, fmt.Errorf("%w: one of %v is required", registry.ErrApproverRole, dsv.Dataset.RequiredRoles())
+	}
+
+	approvals := make([]*registry.DatasetVersionApproval, len(roles))
+	for i, role := range roles {
+		approvals[i] = &registry.DatasetVersionApproval{
+			DatasetVersionID: dsv.ID,
+			Approver:         principal.ID,
+			Role:             role,
+		}
+	}
+
+	if err := s.engine.WithContext(ctx).CreateDatasetApprovalRecords(approvals...); err != nil {
+		return nil, err
+	}
+
+	s.recordVersionEvent(ctx, dsv, "approved", map[string]any{"roles": roles})
+	return s.getDatasetVersion(ctx, name, number)
+}
+
+// RejectDatasetVersion sends a version in review back to draft, dropping its approvals
+func (s *Service) RejectDatasetVersion(ctx context.Context, name string, number int, reason string) (*registry.DatasetVersion, error) {
+	slog.Debug("attempting to reject dataset version", "dataset", name, "version", number)
+
+	dsv, err := s.getDatasetVersion(ctx, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.engine.DatabaseClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		engine := s.engine.WithContext(ctx).WithTx(tx)
+
+		updates := map[string]any{"submitted_by": "", "submitted_at": nil}
+		if err := engine.TransitionDatasetVersionRecord(dsv, registry.VersionReview, registry.VersionDraft, updates); err != nil {
+			return err
+		}
+		return engine.DeleteDatasetApprovalRecords(dsv)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordVersionEvent(ctx, dsv, "rejected", map[string]any{"reason": reason})
+	return s.getDatasetVersion(ctx, name, number)
+}
+
+// PublishDatasetVersion publishes a fully approved version with a frozen manifest of its assets
+// and moves the latest channel to it
+func (s *Service) PublishDatasetVersion(ctx context.Context, name string, number int) (*registry.DatasetVersion, error) {
+	slog.Debug("attempting to publish dataset version", "dataset", name, "version", number)
+
+	dsv, err := s.getDatasetVersion(ctx, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if dsv.Status != registry.VersionReview {
+		return nil, fmt.Errorf("%w: version %d is %s, not in review", registry.ErrVersionStatus, dsv.Number, dsv.Status)
+	}
+
+	if missing := dsv.MissingApprovals(dsv.Dataset.RequiredRoles()); len(missing) > 0 {
+		return nil, &registry.MissingApprovalsError{Roles: missing}
+	}
+
+	principal := PrincipalFrom(ctx)
+	err = s.engine.DatabaseClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		engine := s.engine.WithContext(ctx).WithTx(tx)
+
+		// freeze the contents, the published status locks the membership
+		manifest, err := engine.BuildVersionManifest(dsv)
+		if err != nil {
+			return err
+		}
+		updates, err := manifest.ManifestColumns()
+		if err != nil {
+			return err
+		}
+		updates["published_by"] = principal.ID
+		updates["published_at"] = time.Now()
+
+		if err := engine.TransitionDatasetVersionRecord(dsv, registry.VersionReview, registry.VersionPublished, updates); err != nil {
+			return err
+		}
+
+		// queued with the transition, subscribers never hear of a publication that rolled back
+		err = engine.EnqueueWebhookEvent(registry.WebhookVersionPublished, registry.WebhookDatasetVersion{
+			Dataset:        dsv.Dataset.Name,
+			Version:        dsv.Number,
+			Description:    dsv.Description,
+			AssetCount:     manifest.Count,
+			TotalBytes:     manifest.TotalBytes,
+			ManifestDigest: manifest.Digest,
+			PublishedBy:    principal.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// latest only ever moves forward, publishing an older version after a newer one leaves it alone
+		latest, err := engine.GetDatasetChannelRecord(name, registry.CHANNEL_LATEST)
+		if err != nil && !errors.Is(err, registryerrors.ErrNotFound) {
+			return err
+		}
+		if latest != nil && latest.DatasetVersion.Number > dsv.Number {
+			return nil
+		}
+
+		_, err = engine.SetDatasetChannelRecord(dsv, registry.CHANNEL_LATEST, principal.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordVersionEvent(ctx, dsv, "published", nil)
+	return s.getDatasetVersion(ctx, name, number)
+}
+
+// GetDatasetVersionManifest returns the frozen manifest of a published version, narrowed by filter when it is set
+func (s *Service) GetDatasetVersionManifest(ctx context.Context, name string, number int, filter *registry.ManifestFilter) (*registry.VersionManifest, error) {
+	slog.Debug("attempting to get dataset version manifest", "dataset", name, "version", number)
+
+	dsv, err := s.getDatasetVersion(ctx, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := dsv.GetManifest()
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("%w: %s version %d is %s", ErrManifestNotFound, name, number, dsv.Status)
+	}
+
+	if filter.IsEmpty() {
+		return manifest, nil
+	}
+	return s.engine.WithContext(ctx).FilterVersionManifest(dsv, manifest, filter)
+}
+
+// SetDatasetChannel points a dataset channel at a published version
+func (s *Service) SetDatasetChannel(ctx context.Context, name string, channel string, number int) (*registry.DatasetChannel, error) {
+	slog.Debug("attempting to set dataset channel", "dataset", name, "channel", channel, "version", number)
+
+	dsv, err := s.getDatasetVersion(ctx, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := s.engine.WithContext(ctx).SetDatasetChannelRecord(dsv, channel, PrincipalFrom(ctx).ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordVersionEvent(ctx, dsv, "set as "+ch.Name, map[string]any{"channel": ch.Name})
+	return ch, nil
+}
+
+// GetDatasetChannel returns the published version a dataset channel points at
+func (s *Service) GetDatasetChannel(ctx context.Context, name string, channel string) (*registry.DatasetChannel, error) {
+	slog.Debug("attempting to get dataset channel", "dataset", name, "channel", channel)
+
+	ch, err := s.engine.WithContext(ctx).GetDatasetChannelRecord(name, channel)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s/%s", ErrDatasetChannelNotFound, name, channel)
+		}
+		return nil, err
+	}
+	return ch, nil
+}
+
+func (s *Service) getDatasetVersion(ctx context.Context, name string, number int) (*registry.DatasetVersion, error) {
+	dsv, err := s.engine.WithContext(ctx).GetDatasetVersionRecord(name, number)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s version %d", ErrDatasetVersionNotFound, name, number)
+		}
+		return nil, err
+	}
+	return dsv, nil
+}
+
+// recordVersionEvent stores a publication workflow event for a dataset version
+func (s *Service) recordVersionEvent(ctx context.Context, dsv *registry.DatasetVersion, action string, extra map[string]any) {
+	data := map[string]any{
+		"dataset": dsv.Dataset.Name,
+		"version": dsv.Number,
+		"status":  dsv.Status,
+		"action":  action,
+	}
+	for k, v := range extra {
+		data[k] = v
+	}
+
+	message := fmt.Sprintf("dataset %s version %d %s", dsv.Dataset.Name, dsv.Number, action)
+	s.recordEvent(ctx, EventDatasetVersion, registry.SeverityInfo, message, data)
+}
@@ -0,0 +1,100 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	ErrRateLimited = errors.New("request rate limit exceeded")
+
+	// rateLimitStats exposes limiter counters, served with the other expvars
+	rateLimitStats = expvar.NewMap("aether_rate_limit")
+)
+
+// RateLimiter gives every caller a token bucket refilled at rps tokens per second and holding up to burst.
+// Callers with an api key get a bucket per key, anonymous callers one per client address.
+type RateLimiter struct {
+	rps   float64
+	burst float64
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	swept   time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter, a zero rps disables it. The burst is at least one request.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rps:     rps,
+		burst:   float64(max(burst, 1)),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// WithRateLimiter limits the request rate of every caller
+func WithRateLimiter(limiter *RateLimiter) ServiceOption {
+	return func(s *Service) {
+		s.rateLimiter = limiter
+	}
+}
+
+// AllowRequest takes a token from the caller bucket, when it is empty it returns how long until one is refilled
+func (s *Service) AllowRequest(ctx context.Context) (time.Duration, error) {
+	return s.rateLimiter.Allow(ctx)
+}
+
+// Allow takes a token from the caller bucket, when it is empty it returns how long until one is refilled
+func (l *RateLimiter) Allow(ctx context.Context) (time.Duration, error) {
+	if l == nil || l.rps <= 0 {
+		return 0, nil
+	}
+
+	key := probeKey(PrincipalFrom(ctx))
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rps)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, nil
+	}
+
+	rateLimitStats.Add("rejected", 1)
+	wait := time.Duration(math.Ceil((1 - b.tokens) / l.rps * float64(time.Second)))
+	return wait, ErrRateLimited
+}
+
+// sweep drops the buckets refilled to their burst, at most once per refill period
+func (l *RateLimiter) sweep(now time.Time) {
+	full := time.Duration(l.burst / l.rps * float64(time.Second))
+	if now.Sub(l.swept) < full {
+		return
+	}
+	l.swept = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= full {
+			delete(l.buckets, key)
+		}
+	}
+}
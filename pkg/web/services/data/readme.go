@@ -0,0 +1,40 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+// GetDatasetReadme returns the dataset the readme belongs to
+func (s *Service) GetDatasetReadme(ctx context.Context, name string) (*registry.Dataset, error) {
+	slog.Debug("attempting to get dataset readme", "dataset", name)
+
+	ds, err := s.engine.WithContext(ctx).GetDatasetRecord(name)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrDatasetNotFound, name)
+		}
+		return nil, err
+	}
+	return ds, nil
+}
+
+// SetDatasetReadme replaces the markdown readme of a dataset
+func (s *Service) SetDatasetReadme(ctx context.Context, name string, readme string) (*registry.Dataset, error) {
+	slog.Debug("attempting to set dataset readme", "dataset", name, "bytes", len(readme))
+
+	ds, err := s.GetDatasetReadme(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.engine.WithContext(ctx).SetDatasetReadmeRecord(ds, readme); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
@@ -0,0 +1,28 @@
+package data
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// GetAssetReferences lists what still needs the stored object of an asset, an empty list means it can be purged
+func (s *Service) GetAssetReferences(ctx context.Context, checksum string) ([]registry.AssetReference, error) {
+	slog.Debug("attempting to get asset references", "checksum", checksum)
+
+	asset, err := s.GetAsset(ctx, checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := s.engine.WithContext(ctx).AssetReferences(asset)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(refs[asset.ID]) == 0 {
+		return []registry.AssetReference{}, nil
+	}
+	return refs[asset.ID], nil
+}
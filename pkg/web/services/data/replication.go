@@ -0,0 +1,142 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+var (
+	ErrReplicationDisabled = errors.New("replication is not enabled")
+	ErrAssetNotReady       = errors.New("asset is not ready")
+)
+
+// ReplicationWatermarks is the watermark exchange of a peer: how far our feed goes and how far the peer pulled it
+type ReplicationWatermarks struct {
+	Latest string
+	Acked  string
+}
+
+// WithReplicationKey serves the replication api to peers signing their requests with key
+func WithReplicationKey(key registry.Secret) ServiceOption {
+	return func(s *Service) {
+		s.replicationKey = key
+	}
+}
+
+type peerKey struct{}
+
+// WithPeer attaches the verified replication peer to ctx
+func WithPeer(ctx context.Context, peer *registry.Peer) context.Context {
+	return context.WithValue(ctx, peerKey{}, peer)
+}
+
+// peerFrom returns the verified replication peer of the request
+func peerFrom(ctx context.Context) (*registry.Peer, error) {
+	peer, ok := ctx.Value(peerKey{}).(*registry.Peer)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsigned replication request", registry.ErrInvalidPeerSignature)
+	}
+	return peer, nil
+}
+
+// VerifyPeerRequest checks the signature of a replication request and returns the calling peer,
+// peers are registered on their first valid request
+func (s *Service) VerifyPeerRequest(ctx context.Context, method string, path string, peer string, timestamp string, signature string, body []byte) (*registry.Peer, error) {
+	if s.replicationKey.Value() == "" {
+		return nil, ErrReplicationDisabled
+	}
+
+	if err := registry.VerifyReplicationRequest(s.replicationKey, method, path, peer, timestamp, signature, body); err != nil {
+		slog.Warn("rejected replication request", "peer", peer, "path", path, "error", err)
+		return nil, err
+	}
+
+	return s.engine.WithContext(ctx).EnsurePeerRecord(peer, "", "")
+}
+
+// GetReplicationWatermarks returns the latest change of our feed and the last change the calling peer acknowledged
+func (s *Service) GetReplicationWatermarks(ctx context.Context) (*ReplicationWatermarks, error) {
+	slog.Debug("attempting to get replication watermarks")
+
+	peer, err := peerFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.replicationEngine(ctx).LatestWatermark()
+	if err != nil {
+		return nil, err
+	}
+	return &ReplicationWatermarks{Latest: latest.String(), Acked: peer.AckedWatermark}, nil
+}
+
+// AckReplicationWatermark records how far the calling peer applied our feed
+func (s *Service) AckReplicationWatermark(ctx context.Context, watermark string) error {
+	slog.Debug("attempting to ack replication watermark", "watermark", watermark)
+
+	peer, err := peerFrom(ctx)
+	if err != nil {
+		return err
+	}
+	return s.engine.WithContext(ctx).AckPeerWatermark(peer, watermark)
+}
+
+// ListReplicationChanges returns a page of the asset change feed after the since watermark
+func (s *Service) ListReplicationChanges(ctx context.Context, since string, limit uint) ([]*registry.ReplicationChange, error) {
+	slog.Debug("attempting to list replication changes", "since", since, "limit", limit)
+
+	if _, err := peerFrom(ctx); err != nil {
+		return nil, err
+	}
+
+	watermark, err := registry.ParseWatermark(since)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", registry.ErrValidation, err)
+	}
+	return s.replicationEngine(ctx).ListReplicationChanges(watermark, limit)
+}
+
+// replicationEngine is the registry of every project, the replication feed spans the whole instance
+// whatever the project of the request
+func (s *Service) replicationEngine(ctx context.Context) *registry.Engine {
+	return s.engine.WithContext(registry.WithProject(ctx, ""))
+}
+
+// GetReplicationObjectUrl presigns a download of a ready asset object of project for the calling peer,
+// the default project when empty
+func (s *Service) GetReplicationObjectUrl(ctx context.Context, project string, checksum string, expiresIn time.Duration) (*registry.PresignedUrl, error) {
+	slog.Debug("attempting to get replication object url", "project", project, "checksum", checksum)
+
+	if _, err := peerFrom(ctx); err != nil {
+		return nil, err
+	}
+
+	// the asset, its namespace and the access log entry belong to the project of the asset
+	principal := PrincipalFrom(ctx)
+	principal.Project = registry.NormalizeString(project)
+	ctx = WithPrincipal(ctx, principal)
+
+	asset, err := s.GetAsset(ctx, checksum)
+	if err != nil {
+		return nil, err
+	}
+	if asset.State != registry.StatusReady {
+		return nil, fmt.Errorf("%w: %s is %s", ErrAssetNotReady, checksum, asset.State)
+	}
+
+	url, err := s.engine.WithContext(ctx).CuratedUrlExpire(ctx, asset.Checksum, expiresIn)
+	if err != nil {
+		return nil, err
+	}
+
+	// peers are audited like any other download
+	if err := s.recordAccess(ctx, url); err != nil {
+		return nil, err
+	}
+	return url, nil
+}
@@ -0,0 +1,59 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+var ErrRetagJobNotFound = errors.New("retag job not found")
+
+// CreateRetagJob queues attaching or detaching a tag on every asset matching opts,
+// the retag worker applies it in batches
+func (s *Service) CreateRetagJob(ctx context.Context, tagName string, action registry.RetagAction, opts ...registry.SearchAssetsOption) (*registry.RetagJob, error) {
+	slog.Debug("attempting to create retag job", "tagName", tagName, "action", action)
+
+	tag, err := s.GetTag(ctx, tagName)
+	if err != nil {
+		return nil, err
+	}
+	return s.engine.WithContext(ctx).CreateRetagJobRecord(tag, action, PrincipalFrom(ctx).ID, opts...)
+}
+
+// GetRetagJob returns a retag job and its progress
+func (s *Service) GetRetagJob(ctx context.Context, id uint) (*registry.RetagJob, error) {
+	slog.Debug("attempting to get retag job", "id", id)
+
+	job, err := s.engine.WithContext(ctx).GetRetagJobRecord(id)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrRetagJobNotFound, id)
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// ListRetagJobs returns a page of retag jobs, all statuses when status is empty
+func (s *Service) ListRetagJobs(ctx context.Context, status registry.RetagStatus, cursor uint, limit uint) ([]*registry.RetagJob, error) {
+	slog.Debug("attempting to list retag jobs", "status", status)
+	return s.engine.WithContext(ctx).ListRetagJobRecords(status, cursor, limit)
+}
+
+// CancelRetagJob stops a pending or running retag job, batches already applied are kept
+func (s *Service) CancelRetagJob(ctx context.Context, id uint) (*registry.RetagJob, error) {
+	slog.Debug("attempting to cancel retag job", "id", id)
+
+	job, err := s.GetRetagJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.engine.WithContext(ctx).CancelRetagJob(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
@@ -0,0 +1,111 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+var ErrRoleAssignmentNotFound = errors.New("role assignment not found")
+
+// WithAuthorization checks every request against the roles of its principal.
+// Principals without an authorization role get defaultRole, none at all when it is empty.
+func WithAuthorization(defaultRole string) ServiceOption {
+	return func(s *Service) {
+		s.authorize = true
+		s.defaultRole = defaultRole
+	}
+}
+
+// Authorize fails with registry.ErrPermissionDenied unless the request principal may perform action.
+// The principal roles are those of its api key, its role assignment and the default role.
+func (s *Service) Authorize(ctx context.Context, action registry.Action) error {
+	if !s.authorize {
+		return nil
+	}
+
+	principal := PrincipalFrom(ctx)
+	roles, err := s.principalRoles(principal)
+	if err != nil {
+		return err
+	}
+
+	if !registry.Allowed(roles, action) {
+		return fmt.Errorf("%w: %s may not %s", registry.ErrPermissionDenied, principal.ID, action)
+	}
+	return nil
+}
+
+// principalRoles adds the assigned role of a principal to its own roles, the default role without either
+func (s *Service) principalRoles(principal Principal) ([]string, error) {
+	roles := slices.Clone(principal.Roles)
+
+	assignment, err := s.engine.GetRoleAssignmentRecord(principal.ID)
+	switch {
+	case err == nil:
+		roles = append(roles, assignment.Role)
+	case !errors.Is(err, registryerrors.ErrNotFound):
+		return nil, err
+	}
+
+	if s.defaultRole != "" && !slices.ContainsFunc(roles, func(role string) bool {
+		return slices.Contains(registry.AuthorizationRoles(), role)
+	}) {
+		roles = append(roles, s.defaultRole)
+	}
+	return roles, nil
+}
+
+// AssignRole grants a role to a principal, e.g. key:<name> for api keys, replacing its previous role
+func (s *Service) AssignRole(ctx context.Context, principal string, role string) (*registry.RoleAssignment, error) {
+	slog.Debug("attempting to assign role", "principal", principal, "role", role)
+
+	assignment := &registry.RoleAssignment{
+		Principal:  principal,
+		Role:       role,
+		AssignedBy: PrincipalFrom(ctx).ID,
+	}
+	if err := s.engine.WithContext(ctx).AssignRoleRecord(assignment); err != nil {
+		return nil, err
+	}
+	return s.GetRoleAssignment(ctx, principal)
+}
+
+// GetRoleAssignment returns the role assigned to a principal
+func (s *Service) GetRoleAssignment(ctx context.Context, principal string) (*registry.RoleAssignment, error) {
+	slog.Debug("attempting to get role assignment", "principal", principal)
+
+	assignment, err := s.engine.WithContext(ctx).GetRoleAssignmentRecord(principal)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrRoleAssignmentNotFound, principal)
+		}
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// ListRoleAssignments returns every role assignment
+func (s *Service) ListRoleAssignments(ctx context.Context) ([]*registry.RoleAssignment, error) {
+	slog.Debug("attempting to list role assignments")
+	return s.engine.WithContext(ctx).ListRoleAssignmentRecords()
+}
+
+// RevokeRole removes the role assigned to a principal, it falls back to its api key roles or the default role
+func (s *Service) RevokeRole(ctx context.Context, principal string) (*registry.RoleAssignment, error) {
+	slog.Debug("attempting to revoke role", "principal", principal)
+
+	assignment, err := s.GetRoleAssignment(ctx, principal)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.engine.WithContext(ctx).DeleteRoleAssignmentRecord(assignment); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
@@ -0,0 +1,20 @@
+package data
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// SearchAssets finds assets by display name and tag names, best match first
+func (s *Service) SearchAssets(ctx context.Context, query string, limit uint) ([]*registry.AssetMatch, error) {
+	slog.Debug("attempting to search assets", "query", query)
+	return s.engine.WithContext(ctx).Search(ctx, query, limit)
+}
+
+// SearchContents finds document assets by their indexed text, best match first
+func (s *Service) SearchContents(ctx context.Context, query string, limit uint) ([]*registry.AssetMatch, error) {
+	slog.Debug("attempting to search asset contents", "query", query)
+	return s.engine.WithContext(ctx).SearchContent(ctx, query, limit)
+}
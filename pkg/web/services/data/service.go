@@ -6,10 +6,56 @@ import (
 
 type Service struct {
 	engine *registry.Engine
+	probes *ProbeGuard
+
+	presignRate *presignRate
+	rateLimiter *RateLimiter
+
+	// classification
+	classifiers []registry.Classifier
+	egressRoles map[registry.Classification][]string
+
+	// enrichment
+	suggesters []registry.TagSuggester
+
+	// replication
+	replicationKey registry.Secret
+
+	// bucket notification webhooks
+	storageEventToken registry.Secret
+
+	// pagination
+	pageTokenKey registry.Secret
+
+	// authentication
+	requireAPIKeys bool
+
+	// authorization
+	authorize   bool
+	defaultRole string
 }
 
-func NewService(engine *registry.Engine) *Service {
-	return &Service{
+type ServiceOption func(*Service)
+
+// WithProbeGuard protects asset lookups against enumeration
+func WithProbeGuard(guard *ProbeGuard) ServiceOption {
+	return func(s *Service) {
+		s.probes = guard
+	}
+}
+
+func NewService(engine *registry.Engine, opts ...ServiceOption) *Service {
+	s := &Service{
 		engine: engine,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.pageTokenKey.Value() == "" {
+		s.pageTokenKey = randomPageTokenKey()
+	}
+
+	return s
 }
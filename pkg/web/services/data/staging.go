@@ -0,0 +1,88 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+// StagedUpload is a presigned upload of content whose checksum is not known yet
+type StagedUpload struct {
+	ID  string
+	Url *registry.PresignedUrl
+}
+
+// CreateStagedUpload presigns an upload to a fresh staging key, a zero expiresIn uses the server default
+func (s *Service) CreateStagedUpload(ctx context.Context, expiresIn time.Duration) (*StagedUpload, error) {
+	slog.Debug("attempting to create staged upload")
+
+	id, err := registry.NewStagingID()
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := s.engine.WithContext(ctx).StagingUrlExpire(ctx, id, expiresIn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordAccess(ctx, url); err != nil {
+		return nil, err
+	}
+
+	return &StagedUpload{ID: id, Url: url}, nil
+}
+
+// CommitStagedUpload registers the asset of a staged upload under the checksum computed by the client and promotes the staged object.
+// The content must match the checksum and size, an asset that is already ready only drops the staged copy.
+func (s *Service) CommitStagedUpload(ctx context.Context, id string, asset *registry.Asset) (*registry.Asset, error) {
+	slog.Debug("attempting to commit staged upload", "staging", id, "checksum", asset.Checksum)
+
+	asset.Checksum = registry.NormalizeString(asset.Checksum)
+
+	policy, err := s.projectContentPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.engine.WithContext(ctx).StatStagedUpload(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.CheckSize(info.Size); err != nil {
+		return nil, err
+	}
+
+	record, err := s.engine.WithContext(ctx).GetAssetRecord(asset.Checksum)
+	switch {
+	case errors.Is(err, registryerrors.ErrNotFound):
+		if err := policy.Check(asset.MimeType, asset.Display); err != nil {
+			return nil, err
+		}
+		if err := s.engine.WithContext(ctx).CreateAssetRecords(asset); err != nil {
+			return nil, err
+		}
+		if tags := s.suggestTags(asset); len(tags) > 0 && policy.AutoTags() {
+			s.applySuggestedTags(ctx, asset, tags)
+		}
+		record = asset
+
+	case err != nil:
+		return nil, err
+	}
+
+	ready := record.State == registry.StatusReady
+	promoted, err := s.engine.WithContext(ctx).PromoteStagedAsset(ctx, id, asset.Checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ready {
+		s.runClassifiers(ctx, promoted)
+	}
+	return promoted, nil
+}
@@ -0,0 +1,14 @@
+package data
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// GetStats returns the aggregate asset counts and bytes of the registry
+func (s *Service) GetStats(ctx context.Context) (*registry.AssetStats, error) {
+	slog.Debug("attempting to get stats")
+	return s.engine.WithContext(ctx).Stats(ctx)
+}
@@ -0,0 +1,75 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// ErrStorageNotServed is returned when the storage backend serves its own urls, like S3
+var ErrStorageNotServed = errors.New("storage objects are not served by the api")
+
+// WriteStorageObject stores an upload sent to a signed filesystem storage url
+func (s *Service) WriteStorageObject(ctx context.Context, key string, query url.Values, body io.Reader) (*registry.ObjectInfo, error) {
+	slog.Debug("attempting to write storage object", "key", key)
+
+	store, err := s.filesystemStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := store.Verify("put", key, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Size != 0 {
+		return store.Write(ctx, key, body, req.Checksum, req.Size)
+	}
+
+	// unsigned sizes accept anything up to the size limit of the project, the upload fails once it is exceeded
+	project := s.engine.KeyProject(key)
+	policy, err := s.engine.WithContext(ctx).GetContentPolicyRecord(project)
+	if err != nil {
+		return nil, err
+	}
+	var limit int64
+	if policy != nil {
+		limit = policy.MaxSizeBytes
+	}
+
+	info, err := store.WriteAtMost(ctx, key, body, req.Checksum, limit)
+	var tooLarge *registry.ObjectTooLargeError
+	if errors.As(err, &tooLarge) {
+		tooLarge.Project = project
+	}
+	return info, err
+}
+
+// OpenStorageObject opens the object of a signed filesystem storage download url, the caller closes it
+func (s *Service) OpenStorageObject(ctx context.Context, key string, query url.Values) (*os.File, error) {
+	slog.Debug("attempting to open storage object", "key", key)
+
+	store, err := s.filesystemStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := store.Verify("get", key, query); err != nil {
+		return nil, err
+	}
+	return store.Open(key)
+}
+
+func (s *Service) filesystemStorage() (*registry.FilesystemStorage, error) {
+	store, ok := s.engine.Storage().(*registry.FilesystemStorage)
+	if !ok {
+		return nil, ErrStorageNotServed
+	}
+	return store, nil
+}
@@ -0,0 +1,47 @@
+package data
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// WithTagSuggesters proposes tags for every created asset, returned to the caller
+// or applied when the project content policy opts in
+func WithTagSuggesters(suggesters ...registry.TagSuggester) ServiceOption {
+	return func(s *Service) {
+		s.suggesters = append(s.suggesters, suggesters...)
+	}
+}
+
+// suggestTags collects the tag suggestions of every configured suggester
+func (s *Service) suggestTags(asset *registry.Asset) []string {
+	var tags []string
+	for _, suggester := range s.suggesters {
+		for _, tag := range suggester.Suggest(asset) {
+			if tag = registry.SuggestedTagName(tag); tag != "" && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// applySuggestedTags attaches suggested tags to a new asset, creating missing tags.
+// Failures are logged and never fail the caller, the tags stay suggestions.
+func (s *Service) applySuggestedTags(ctx context.Context, asset *registry.Asset, names []string) bool {
+	tags, err := s.engine.WithContext(ctx).EnsureTagRecords(names)
+	if err == nil {
+		_, err = s.attachGroupedTags(ctx, func(engine *registry.Engine) ([]*registry.TagSwap, error) {
+			return engine.AttachTags(asset, tags)
+		})
+	}
+
+	if err != nil {
+		slog.WarnContext(ctx, "failed to apply suggested tags", "checksum", asset.Checksum, "tags", names, "error", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTagGroupNotFound      = errors.New("tag group not found")
+	ErrTagGroupAlreadyExists = errors.New("tag group already exists")
+)
+
+// CreateTagGroup makes the named tags mutually exclusive, missing tags are created
+func (s *Service) CreateTagGroup(ctx context.Context, group *registry.TagGroup, tags []string) error {
+	slog.Debug("attempting to create tag group", "name", group.Name, "tags", tags)
+
+	group.CreatedBy = PrincipalFrom(ctx).ID
+	if err := s.engine.WithContext(ctx).CreateTagGroupRecord(group, tags); err != nil {
+		if errors.Is(err, registryerrors.ErrAlreadyExists) {
+			return fmt.Errorf("%w: %s", ErrTagGroupAlreadyExists, group.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetTagGroup returns the group named name with its tags
+func (s *Service) GetTagGroup(ctx context.Context, name string) (*registry.TagGroup, error) {
+	slog.Debug("attempting to get tag group", "name", name)
+
+	group, err := s.engine.WithContext(ctx).GetTagGroupRecord(name)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrTagGroupNotFound, name)
+		}
+		return nil, err
+	}
+	return group, nil
+}
+
+// ListTagGroups returns every group with its tags
+func (s *Service) ListTagGroups(ctx context.Context) ([]*registry.TagGroup, error) {
+	slog.Debug("attempting to list tag groups")
+	return s.engine.WithContext(ctx).ListTagGroupRecords()
+}
+
+// UpdateTagGroup replaces the description and tags of a group
+func (s *Service) UpdateTagGroup(ctx context.Context, name string, description string, tags []string) (*registry.TagGroup, error) {
+	slog.Debug("attempting to update tag group", "name", name, "tags", tags)
+
+	group, err := s.GetTagGroup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Description = description
+	if err := s.engine.WithContext(ctx).UpdateTagGroupRecord(group, tags); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// DeleteTagGroup removes a group, its tags stay attached to their assets
+func (s *Service) DeleteTagGroup(ctx context.Context, name string) error {
+	slog.Debug("attempting to delete tag group", "name", name)
+
+	group, err := s.GetTagGroup(ctx, name)
+	if err != nil {
+		return err
+	}
+	return s.engine.WithContext(ctx).DeleteTagGroupRecord(group)
+}
+
+// attachGroupedTags runs attach in a transaction together with an audit event for every tag
+// it detached, so a swap is never left unrecorded
+func (s *Service) attachGroupedTags(ctx context.Context, attach func(engine *registry.Engine) ([]*registry.TagSwap, error)) ([]*registry.TagSwap, error) {
+	var swaps []*registry.TagSwap
+	err := s.engine.DatabaseClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		engine := s.engine.WithContext(ctx).WithTx(tx)
+
+		var err error
+		if swaps, err = attach(engine); err != nil {
+			return err
+		}
+
+		for _, swap := range swaps {
+			event, err := registry.NewTagSwapEvent(swap)
+			if err != nil {
+				return err
+			}
+			principal := PrincipalFrom(ctx)
+			event.Principal, event.Project = principal.ID, principal.Project
+			if err := engine.CreateEventRecord(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return swaps, nil
+}
@@ -8,7 +8,7 @@ import (
 	"strings"
 
 	"github.com/UnivocalX/aether/internal/registry"
-	"gorm.io/gorm"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
 )
 
 const (
@@ -54,13 +54,25 @@ type GetTagAssetsResult struct {
 	Assets []*registry.Asset
 }
 
-func (s *Service) CreateTag(ctx context.Context, name string) (*registry.Tag, error) {
+// TagPatch edits tag metadata, nil fields are kept and empty strings clear them
+type TagPatch struct {
+	Description *string
+	Color       *string
+}
+
+func (s *Service) CreateTag(ctx context.Context, name string, description string, color string) (*registry.Tag, error) {
 	slog.Debug("attempting to create tag", "name", name)
 
+	tag := &registry.Tag{
+		Name:        name,
+		Description: description,
+		Color:       color,
+		CreatedBy:   PrincipalFrom(ctx).ID,
+	}
+
 	// Try to create the tag
-	tag, err := s.engine.CreateTagRecord(name)
-	if err != nil {
-		if IsUniqueConstraintError(err) {
+	if err := s.engine.WithContext(ctx).CreateTagRecord(tag); err != nil {
+		if errors.Is(err, registryerrors.ErrAlreadyExists) {
 			return nil, fmt.Errorf("%w: %s", ErrTagAlreadyExists, name)
 		}
 		return nil, err // Created successfully
@@ -69,13 +81,35 @@ func (s *Service) CreateTag(ctx context.Context, name string) (*registry.Tag, er
 	return tag, nil
 }
 
+// UpdateTag edits the description and color of a tag
+func (s *Service) UpdateTag(ctx context.Context, name string, patch TagPatch) (*registry.Tag, error) {
+	slog.Debug("attempting to update tag", "name", name)
+
+	tag, err := s.GetTag(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.Description != nil {
+		tag.Description = *patch.Description
+	}
+	if patch.Color != nil {
+		tag.Color = *patch.Color
+	}
+
+	if err := s.engine.WithContext(ctx).UpdateTagRecord(tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
 func (s *Service) GetTag(ctx context.Context, name string) (*registry.Tag, error) {
 	slog.Debug("attempting to get tag", "name", name)
 
-	tag, err := s.engine.GetTagRecord(name)
+	tag, err := s.engine.WithContext(ctx).GetTagRecord(name)
 
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, registryerrors.ErrNotFound) {
 			return nil, fmt.Errorf("%w: %s", ErrTagNotFound, name)
 		}
 
@@ -97,9 +131,9 @@ func (s *Service) GetTagAssets(ctx context.Context, params GetTagAssetsParams) (
 		"offset", params.Offset,
 	)
 
-	assets, err := s.engine.GetTagRecordAssets(params.Name, int(params.Limit), int(params.Offset))
+	assets, err := s.engine.WithContext(ctx).GetTagRecordAssets(params.Name, int(params.Limit), int(params.Offset))
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, registryerrors.ErrNotFound) {
 			return nil, fmt.Errorf("%w: %s", ErrTagNotFound, params.Name)
 		}
 
@@ -108,3 +142,78 @@ func (s *Service) GetTagAssets(ctx context.Context, params GetTagAssetsParams) (
 
 	return assets, nil
 }
+
+// TagBatchResult reports a bulk tag change, Missing lists unknown checksums and assets in the trash.
+// Swaps lists the tags of the same group detached by an attach.
+type TagBatchResult struct {
+	Tag     *registry.Tag
+	Changed int64
+	Missing []string
+	Swaps   []*registry.TagSwap
+}
+
+// TagAssets attaches a tag to many assets at once, detaching the other tags of its group
+func (s *Service) TagAssets(ctx context.Context, name string, checksums ...string) (*TagBatchResult, error) {
+	slog.Debug("attempting to tag assets", "tagName", name, "total", len(checksums))
+
+	return s.changeTagAssets(ctx, name, checksums, func(tag *registry.Tag, active []string) (int64, []*registry.TagSwap, error) {
+		var attached int64
+		swaps, err := s.attachGroupedTags(ctx, func(engine *registry.Engine) ([]*registry.TagSwap, error) {
+			var swaps []*registry.TagSwap
+			var err error
+			attached, swaps, err = engine.AttachTagToChecksums(tag, active)
+			return swaps, err
+		})
+		return attached, swaps, err
+	})
+}
+
+// UntagAssets detaches a tag from many assets at once
+func (s *Service) UntagAssets(ctx context.Context, name string, checksums ...string) (*TagBatchResult, error) {
+	slog.Debug("attempting to untag assets", "tagName", name, "total", len(checksums))
+
+	return s.changeTagAssets(ctx, name, checksums, func(tag *registry.Tag, active []string) (int64, []*registry.TagSwap, error) {
+		detached, err := s.engine.WithContext(ctx).DetachTagFromChecksums(tag, active)
+		return detached, nil, err
+	})
+}
+
+func (s *Service) changeTagAssets(ctx context.Context, name string, checksums []string, change func(*registry.Tag, []string) (int64, []*registry.TagSwap, error)) (*TagBatchResult, error) {
+	tag, err := s.GetTag(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip repeated checksums
+	seen := make(map[string]bool, len(checksums))
+	normalized := make([]string, 0, len(checksums))
+	for _, c := range checksums {
+		c = registry.NormalizeString(c)
+		if !seen[c] {
+			seen[c] = true
+			normalized = append(normalized, c)
+		}
+	}
+
+	active, err := s.engine.WithContext(ctx).ActiveChecksums(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool, len(active))
+	for _, c := range active {
+		found[c] = true
+	}
+
+	result := &TagBatchResult{Tag: tag, Missing: []string{}}
+	for _, c := range normalized {
+		if !found[c] {
+			result.Missing = append(result.Missing, c)
+		}
+	}
+
+	if result.Changed, result.Swaps, err = change(tag, active); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
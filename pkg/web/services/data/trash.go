@@ -0,0 +1,204 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"gorm.io/gorm"
+)
+
+// DeleteAsset moves an asset to the trash and detaches its tags.
+// With purge the stored objects are removed as well, a restored asset then goes back to pending.
+// Assets referenced by dataset versions aren't deleted right away, a pending deletion is returned instead.
+func (s *Service) DeleteAsset(ctx context.Context, checksum string, purge bool) (*registry.Asset, *registry.AssetDeletion, error) {
+	slog.Debug("attempting to delete asset", "checksum", checksum, "purge", purge)
+
+	asset, err := s.GetAsset(ctx, checksum)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refs, err := s.engine.WithContext(ctx).AssetReferences(asset)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(refs[asset.ID]) > 0 {
+		deletion, err := s.requestAssetDeletion(ctx, asset, refs[asset.ID], purge)
+		if err != nil {
+			return nil, nil, err
+		}
+		return asset, deletion, nil
+	}
+
+	if asset.State != registry.StatusDeleted {
+		err = s.engine.DatabaseClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			engine := s.engine.WithContext(ctx).WithTx(tx)
+
+			if err := engine.ClearTags(asset); err != nil {
+				return err
+			}
+			return engine.UpdateAssetState(asset, registry.StatusDeleted)
+		})
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Objects go after the state change so a failed cleanup can simply be retried
+	if purge {
+		if err := s.engine.WithContext(ctx).DeleteObjects(ctx, s.engine.WithContext(ctx).AssetKeys(asset.Checksum)...); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return asset, nil, nil
+}
+
+func (s *Service) ListTrash(ctx context.Context, opts ...registry.SearchAssetsOption) ([]*registry.Asset, error) {
+	slog.Debug("attempting to list deleted assets")
+	return s.engine.WithContext(ctx).ListAssetsRecords(append(opts, registry.WithState(registry.StatusDeleted))...)
+}
+
+// RestoreAssets moves deleted assets out of the trash.
+// Assets whose curated object still exists become ready again, the rest go back to pending.
+func (s *Service) RestoreAssets(ctx context.Context, checksums ...string) ([]*registry.Asset, error) {
+	slog.Debug("attempting to restore assets", "total", len(checksums))
+
+	assets, err := s.getDeletedAssets(ctx, checksums...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Storage is checked before the transaction, it holds no rows locked while waiting on the object store.
+	// Only assets whose curated object was confirmed become ready.
+	curated := make(map[uint]bool, len(assets))
+	for _, asset := range assets {
+		exists, err := s.engine.WithContext(ctx).ObjectExists(ctx, s.engine.WithContext(ctx).CuratedKey(asset.Checksum))
+		if err != nil {
+			return nil, err
+		}
+		curated[asset.ID] = exists
+	}
+
+	err = s.engine.DatabaseClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		engine := s.engine.WithContext(ctx).WithTx(tx)
+
+		for _, asset := range assets {
+			state := registry.StatusPending
+			if curated[asset.ID] {
+				state = registry.StatusReady
+			}
+
+			if err := engine.UpdateAssetState(asset, state); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+// PurgeAssets permanently removes deleted assets, including their stored objects.
+// Nothing is purged while any of the assets is still referenced.
+func (s *Service) PurgeAssets(ctx context.Context, checksums ...string) ([]*registry.Asset, error) {
+	slog.Debug("attempting to purge assets", "total", len(checksums))
+
+	assets, err := s.getDeletedAssets(ctx, checksums...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.engine.WithContext(ctx).CheckUnreferenced(assets...); err != nil {
+		return nil, err
+	}
+
+	// Remove objects first, a failed record delete can simply be purged again
+	keys := make([]string, 0, len(assets)*3)
+	for _, asset := range assets {
+		keys = append(keys, s.engine.WithContext(ctx).AssetKeys(asset.Checksum)...)
+	}
+
+	if err := s.engine.WithContext(ctx).DeleteObjects(ctx, keys...); err != nil {
+		return nil, err
+	}
+
+	err = s.engine.DatabaseClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return s.engine.WithContext(ctx).WithTx(tx).DeleteAssetRecords(assets...)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+// PlanPurgeAssets lists the deleted assets PurgeAssets would remove with the bytes of their stored objects,
+// it fails like PurgeAssets while any of them is still referenced. Nothing is purged.
+func (s *Service) PlanPurgeAssets(ctx context.Context, checksums ...string) (*registry.DryRunReport, error) {
+	slog.Debug("attempting to plan asset purge", "total", len(checksums))
+
+	assets, err := s.getDeletedAssets(ctx, checksums...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.engine.WithContext(ctx).CheckUnreferenced(assets...); err != nil {
+		return nil, err
+	}
+
+	plan := &registry.DryRunReport{Operation: "purge"}
+	for _, asset := range assets {
+		bytes, err := s.engine.WithContext(ctx).StoredBytes(ctx, asset.Checksum)
+		if err != nil {
+			return nil, err
+		}
+		plan.Add(registry.Candidate{Kind: registry.CandidateAsset, Name: asset.Checksum, Bytes: bytes, Reason: "purge"})
+	}
+	return plan, nil
+}
+
+// getDeletedAssets fetches assets in the trash, failing if any checksum is not there
+func (s *Service) getDeletedAssets(ctx context.Context, checksums ...string) ([]*registry.Asset, error) {
+	normalized := make([]string, len(checksums))
+	for i, c := range checksums {
+		normalized[i] = registry.NormalizeString(c)
+	}
+
+	records, err := s.engine.WithContext(ctx).ListAssetsRecords(
+		registry.WithChecksums(normalized...),
+		registry.WithState(registry.StatusDeleted),
+		registry.WithLimit(registry.SearchMaxLimit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool, len(records))
+	for _, r := range records {
+		found[r.Checksum] = true
+	}
+
+	var missing []string
+	for _, c := range normalized {
+		if !found[c] {
+			missing = append(missing, c)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w in trash: %s", ErrAssetNotFound, strings.Join(missing, ", "))
+	}
+
+	return records, nil
+}
@@ -0,0 +1,32 @@
+package data
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/UnivocalX/aether/internal/registry"
+)
+
+// ListUsage returns metered project usage per month, oldest first
+func (s *Service) ListUsage(ctx context.Context, opts ...registry.UsageOption) ([]*registry.UsageRecord, error) {
+	slog.Debug("attempting to list usage")
+	return s.engine.WithContext(ctx).ListUsageRecords(opts...)
+}
+
+// ListQuotaUsage returns the current usage and quota status of the projects with a quota,
+// only the one of project when it is set
+func (s *Service) ListQuotaUsage(ctx context.Context, project string) ([]*registry.ProjectUsage, error) {
+	slog.Debug("attempting to list quota usage", "project", project)
+
+	usages, err := s.engine.WithContext(ctx).ListQuotaUsage()
+	if err != nil {
+		return nil, err
+	}
+	if project != "" {
+		usages = slices.DeleteFunc(usages, func(u *registry.ProjectUsage) bool {
+			return u.Project != registry.NormalizeString(project)
+		})
+	}
+	return usages, nil
+}
@@ -0,0 +1,108 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/internal/registry/registryerrors"
+)
+
+var (
+	ErrWebhookNotFound      = errors.New("webhook not found")
+	ErrWebhookAlreadyExists = errors.New("webhook already exists")
+)
+
+// WebhookPatch edits a webhook, nil fields are kept
+type WebhookPatch struct {
+	URL      *string
+	Events   []string
+	Secret   *registry.Secret
+	Disabled *bool
+}
+
+// CreateWebhook registers a webhook attributed to the request principal
+func (s *Service) CreateWebhook(ctx context.Context, webhook *registry.Webhook) error {
+	slog.Debug("attempting to create webhook", "name", webhook.Name, "events", webhook.Events)
+
+	webhook.CreatedBy = PrincipalFrom(ctx).ID
+	if err := s.engine.WithContext(ctx).CreateWebhookRecord(webhook); err != nil {
+		if errors.Is(err, registryerrors.ErrAlreadyExists) {
+			return fmt.Errorf("%w: %s", ErrWebhookAlreadyExists, webhook.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetWebhook returns the webhook named name
+func (s *Service) GetWebhook(ctx context.Context, name string) (*registry.Webhook, error) {
+	slog.Debug("attempting to get webhook", "name", name)
+
+	webhook, err := s.engine.WithContext(ctx).GetWebhookRecord(name)
+	if err != nil {
+		if errors.Is(err, registryerrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrWebhookNotFound, name)
+		}
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// ListWebhooks returns every webhook
+func (s *Service) ListWebhooks(ctx context.Context) ([]*registry.Webhook, error) {
+	slog.Debug("attempting to list webhooks")
+	return s.engine.WithContext(ctx).ListWebhookRecords()
+}
+
+// UpdateWebhook applies a patch to a webhook
+func (s *Service) UpdateWebhook(ctx context.Context, name string, patch WebhookPatch) (*registry.Webhook, error) {
+	slog.Debug("attempting to update webhook", "name", name)
+
+	webhook, err := s.GetWebhook(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.URL != nil {
+		webhook.URL = *patch.URL
+	}
+	if patch.Events != nil {
+		webhook.Events = patch.Events
+	}
+	if patch.Secret != nil {
+		webhook.Secret = *patch.Secret
+	}
+	if patch.Disabled != nil {
+		webhook.Disabled = *patch.Disabled
+	}
+
+	if err := s.engine.WithContext(ctx).UpdateWebhookRecord(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook removes a webhook, its pending deliveries are dropped
+func (s *Service) DeleteWebhook(ctx context.Context, name string) error {
+	slog.Debug("attempting to delete webhook", "name", name)
+
+	webhook, err := s.GetWebhook(ctx, name)
+	if err != nil {
+		return err
+	}
+	return s.engine.WithContext(ctx).DeleteWebhookRecord(webhook)
+}
+
+// ListWebhookDeliveries returns the latest deliveries of a webhook, newest first
+func (s *Service) ListWebhookDeliveries(ctx context.Context, name string, status registry.DeliveryStatus, limit uint) ([]*registry.WebhookDelivery, error) {
+	slog.Debug("attempting to list webhook deliveries", "name", name, "status", status)
+
+	webhook, err := s.GetWebhook(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.engine.WithContext(ctx).ListWebhookDeliveryRecords(webhook, status, limit)
+}
@@ -0,0 +1,61 @@
+package web
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/UnivocalX/aether/pkg/web/api/dto"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// NewTLSConfig loads the server certificate and key, both PEM encoded. With a client CA bundle
+// clients must present a certificate it signed, see requireClientCert.
+func NewTLSConfig(certFile string, keyFile string, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load tls certificate: %w", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAFile == "" {
+		return config, nil
+	}
+
+	pem, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("read tls client ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("tls client ca %q holds no PEM certificate", clientCAFile)
+	}
+
+	// verified when given, requests are refused without one except for the probes
+	config.ClientCAs = pool
+	config.ClientAuth = tls.VerifyClientCertIfGiven
+	return config, nil
+}
+
+// requireClientCert refuses requests without a verified client certificate once a client CA is configured.
+// The handshake only verifies given certificates, so load balancer and kubelet probes still reach HealthPaths.
+func (s *Server) requireClientCert(c *gin.Context) {
+	if s.TLS == nil || s.TLS.ClientCAs == nil || slices.Contains(HealthPaths, c.Request.URL.Path) {
+		c.Next()
+		return
+	}
+
+	if c.Request.TLS == nil || len(c.Request.TLS.VerifiedChains) == 0 {
+		dto.HandleErrorResponse(c, "failed to authenticate", data.ErrClientCertRequired)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
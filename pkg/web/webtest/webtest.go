@@ -0,0 +1,417 @@
+// Package webtest boots a registry and its api on a local listener for end-to-end tests of the handlers,
+// e.g. create, upload, finalize and download of an asset through the same urls a client gets.
+//
+// Tests run against Postgres and MinIO, each harness in a database and bucket prefix of its own.
+// AETHER_TEST_DB_ENDPOINT and AETHER_TEST_S3_ENDPOINT point the harness at running services, e.g. those of
+// compose.yaml, otherwise it starts them in containers shared by the tests of the binary.
+// With AETHER_TEST_LOCAL set, tests run against sqlite and the filesystem storage backend in a temporary directory.
+// Without it and without a reachable Docker daemon they are skipped, so a missing daemon never passes for the real stack.
+package webtest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/UnivocalX/aether/internal/registry"
+	"github.com/UnivocalX/aether/pkg/client"
+	"github.com/UnivocalX/aether/pkg/web"
+	v1 "github.com/UnivocalX/aether/pkg/web/api/handlers/v1"
+	"github.com/UnivocalX/aether/pkg/web/services/data"
+)
+
+// Environment selecting the Postgres and MinIO the harness runs against, AWS_* variables hold the MinIO credentials
+const (
+	EnvDatabaseEndpoint = "AETHER_TEST_DB_ENDPOINT"
+	EnvDatabaseUser     = "AETHER_TEST_DB_USER"
+	EnvDatabasePassword = "AETHER_TEST_DB_PASSWORD"
+	EnvStorageEndpoint  = "AETHER_TEST_S3_ENDPOINT"
+	EnvBucket           = "AETHER_TEST_S3_BUCKET"
+	EnvLocal            = "AETHER_TEST_LOCAL"
+
+	DEFAULT_BUCKET = "aether-test"
+
+	// images of the services started when no endpoint is set, the same as compose.yaml
+	POSTGRES_IMAGE = "postgres:15-alpine"
+	MINIO_IMAGE    = "quay.io/minio/minio:RELEASE.2025-02-28T09-55-16Z"
+)
+
+// services are the Postgres and MinIO containers shared by every harness of the test binary,
+// the testcontainers reaper removes them when the binary exits
+var services struct {
+	once     sync.Once
+	database string
+	storage  string
+
+	// unavailable is set when no Docker daemon answers, err when one does but the containers failed to start
+	unavailable error
+	err         error
+}
+
+// Harness serves a fresh registry until the test ends
+type Harness struct {
+	t testing.TB
+
+	Engine *registry.Engine
+	Server *web.Server
+
+	// URL is the base url of the api, e.g. http://127.0.0.1:41234/api/v1
+	URL string
+
+	listener *httptest.Server
+}
+
+// New boots a registry with its api, opts are applied after the harness picked the database and storage.
+// Everything it created is removed when the test ends.
+func New(t testing.TB, opts []registry.Option, svcOpts ...data.ServiceOption) *Harness {
+	t.Helper()
+
+	// the listener is bound first, filesystem storage urls point at it
+	listener := httptest.NewUnstartedServer(nil)
+	t.Cleanup(listener.Close)
+	base := "http://" + listener.Listener.Addr().String()
+
+	database, storage := endpoints(t)
+	engineOpts := append(databaseOptions(t, database), storageOptions(t, storage, base)...)
+	engine, err := registry.New(append(engineOpts, opts...)...)
+	if err != nil {
+		t.Fatalf("create registry: %v", err)
+	}
+	t.Cleanup(func() { engine.Close() })
+	if engine.S3Client != nil {
+		createBucket(t, engine)
+	}
+
+	server := web.NewServer(false, engine, svcOpts...)
+	listener.Config.Handler = server.Router
+	listener.Start()
+
+	return &Harness{
+		t:        t,
+		Engine:   engine,
+		Server:   server,
+		URL:      base + client.DefaultPath,
+		listener: listener,
+	}
+}
+
+// endpoints returns the Postgres and MinIO endpoints of the environment, starting containers when none is set.
+// Empty endpoints select sqlite and the filesystem storage backend.
+func endpoints(t testing.TB) (string, string) {
+	database, storage := os.Getenv(EnvDatabaseEndpoint), os.Getenv(EnvStorageEndpoint)
+	if database != "" || storage != "" || os.Getenv(EnvLocal) != "" {
+		return database, storage
+	}
+
+	services.once.Do(startServices)
+	switch {
+	case services.err != nil:
+		t.Fatalf("start test services: %v", services.err)
+	case services.unavailable != nil:
+		t.Skipf("docker is unavailable, set %s=1 to run against sqlite and the filesystem instead: %v", EnvLocal, services.unavailable)
+	}
+	return services.database, services.storage
+}
+
+// startServices starts Postgres and MinIO containers with the credentials the harness defaults to
+func startServices() {
+	ctx := context.Background()
+	if services.unavailable = dockerHealth(ctx); services.unavailable != nil {
+		return
+	}
+
+	user, password := cmpEnv(EnvDatabaseUser, "postgres"), cmpEnv(EnvDatabasePassword, "changeme")
+	database, err := testcontainers.Run(ctx, POSTGRES_IMAGE,
+		testcontainers.WithExposedPorts("5432/tcp"),
+		testcontainers.WithEnv(map[string]string{"POSTGRES_USER": user, "POSTGRES_PASSWORD": password}),
+		// the server restarts once after the init scripts
+		testcontainers.WithWaitStrategy(wait.ForLog("database system is ready to accept connections").WithOccurrence(2)),
+	)
+	if err != nil {
+		services.err = fmt.Errorf("start postgres: %w", err)
+		return
+	}
+	if services.database, err = database.PortEndpoint(ctx, "5432/tcp", ""); err != nil {
+		services.err = fmt.Errorf("postgres endpoint: %w", err)
+		return
+	}
+
+	accessKey, secretKey := cmpEnv("AWS_ACCESS_KEY_ID", "minioadmin"), cmpEnv("AWS_SECRET_ACCESS_KEY", "minioadmin")
+	storage, err := testcontainers.Run(ctx, MINIO_IMAGE,
+		testcontainers.WithExposedPorts("9000/tcp"),
+		testcontainers.WithEnv(map[string]string{"MINIO_ROOT_USER": accessKey, "MINIO_ROOT_PASSWORD": secretKey}),
+		testcontainers.WithCmd("server", "/data"),
+		testcontainers.WithWaitStrategy(wait.ForHTTP("/minio/health/live").WithPort("9000/tcp")),
+	)
+	if err != nil {
+		services.err = fmt.Errorf("start minio: %w", err)
+		return
+	}
+	if services.storage, err = storage.PortEndpoint(ctx, "9000/tcp", "http"); err != nil {
+		services.err = fmt.Errorf("minio endpoint: %w", err)
+		return
+	}
+
+	// the engine reads the S3 credentials of the process environment
+	for key, value := range map[string]string{
+		"AWS_ACCESS_KEY_ID":     accessKey,
+		"AWS_SECRET_ACCESS_KEY": secretKey,
+		"AWS_REGION":            cmpEnv("AWS_REGION", "us-east-1"),
+	} {
+		os.Setenv(key, value)
+	}
+}
+
+// dockerHealth fails when no Docker daemon answers, the provider panics on some broken setups
+func dockerHealth(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("docker provider: %v", r)
+		}
+	}()
+
+	provider, err := testcontainers.ProviderDocker.GetProvider()
+	if err != nil {
+		return err
+	}
+	return provider.Health(ctx)
+}
+
+// databaseOptions creates a database of its own on the Postgres at endpoint, or a sqlite file without one
+func databaseOptions(t testing.TB, endpoint string) []registry.Option {
+	if endpoint == "" {
+		return []registry.Option{
+			registry.WithDatabaseDriver(registry.DRIVER_SQLITE),
+			registry.WithDatabaseName(t.TempDir() + "/aether.db"),
+		}
+	}
+
+	user, password := cmpEnv(EnvDatabaseUser, "postgres"), cmpEnv(EnvDatabasePassword, "changeme")
+	admin, err := gorm.Open(postgres.Open(postgresDSN(endpoint, user, password, "postgres")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("connect to %s: %v", endpoint, err)
+	}
+
+	name := "aether_test_" + randomHex(t, 8)
+	if err := admin.Exec("CREATE DATABASE " + name).Error; err != nil {
+		t.Fatalf("create database %s: %v", name, err)
+	}
+	// registered first, so it runs after the engine closed its connections
+	t.Cleanup(func() {
+		if err := admin.Exec("DROP DATABASE IF EXISTS " + name + " WITH (FORCE)").Error; err != nil {
+			t.Logf("drop database %s: %v", name, err)
+		}
+		if db, err := admin.DB(); err == nil {
+			db.Close()
+		}
+	})
+
+	return []registry.Option{
+		registry.WithDatabaseDriver(registry.DRIVER_POSTGRES),
+		registry.WithDatabaseEndpoint(endpoint),
+		registry.WithDatabaseUser(user),
+		registry.WithDatabasePassword(password),
+		registry.WithDatabaseName(name),
+	}
+}
+
+// storageOptions stores objects under a prefix of their own in the MinIO at endpoint, or in a directory without one
+func storageOptions(t testing.TB, endpoint string, base string) []registry.Option {
+	if endpoint == "" {
+		return []registry.Option{
+			registry.WithStorageBackend("filesystem"),
+			registry.WithStoragePath(t.TempDir()),
+			registry.WithStorageURL(base + "/api"),
+			registry.WithStorageSigningKey(randomHex(t, 16)),
+		}
+	}
+
+	return []registry.Option{
+		registry.WithStorageEndpoint(endpoint),
+		registry.WithBucket(cmpEnv(EnvBucket, DEFAULT_BUCKET)),
+		registry.WithBucketPrefix("test-" + randomHex(t, 8)),
+	}
+}
+
+// createBucket creates the test bucket unless it exists, objects under the harness prefix are left to the bucket lifecycle
+func createBucket(t testing.TB, engine *registry.Engine) {
+	bucket := cmpEnv(EnvBucket, DEFAULT_BUCKET)
+	_, err := engine.S3Client.CreateBucket(context.Background(), &s3.CreateBucketInput{Bucket: aws.String(bucket)})
+
+	var owned *types.BucketAlreadyOwnedByYou
+	var exists *types.BucketAlreadyExists
+	if err != nil && !errors.As(err, &owned) && !errors.As(err, &exists) {
+		t.Fatalf("create bucket %s: %v", bucket, err)
+	}
+}
+
+// Client returns an api client of the harness
+func (h *Harness) Client(opts ...client.Option) *client.Client {
+	h.t.Helper()
+
+	c, err := client.New(append([]client.Option{client.WithBaseURL(strings.TrimSuffix(h.URL, "/v1"))}, opts...)...)
+	if err != nil {
+		h.t.Fatalf("create client: %v", err)
+	}
+	return c
+}
+
+// Do sends a request to an api path, e.g. /assets, body is encoded as JSON unless it is an io.Reader.
+// The response body is closed when the test ends.
+func (h *Harness) Do(method string, path string, body any) *http.Response {
+	h.t.Helper()
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		reader = b
+	default:
+		raw, err := json.Marshal(b)
+		if err != nil {
+			h.t.Fatalf("encode %s %s: %v", method, path, err)
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	target := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		target = h.URL + path
+	}
+	req, err := http.NewRequest(method, target, reader)
+	if err != nil {
+		h.t.Fatalf("build %s %s: %v", method, path, err)
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return h.Send(req)
+}
+
+// Send sends a request built by the test, e.g. one with headers of its own.
+// The response body is closed when the test ends.
+func (h *Harness) Send(req *http.Request) *http.Response {
+	h.t.Helper()
+
+	resp, err := h.listener.Client().Do(req)
+	if err != nil {
+		h.t.Fatalf("%s %s: %v", req.Method, req.URL.Path, err)
+	}
+	h.t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// DoJSON sends a request like Do, fails the test unless the response has status want and decodes it into out
+func (h *Harness) DoJSON(method string, path string, body any, want int, out any) {
+	h.t.Helper()
+
+	resp := h.Do(method, path, body)
+	if resp.StatusCode != want {
+		raw, _ := io.ReadAll(resp.Body)
+		h.t.Fatalf("%s %s: status %d, want %d: %s", method, path, resp.StatusCode, want, raw)
+	}
+	if out == nil {
+		return
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		h.t.Fatalf("decode %s %s: %v", method, path, err)
+	}
+}
+
+// CreateAsset registers an asset, uploads content to its ingress url and finalizes it, returning its checksum
+func (h *Harness) CreateAsset(content []byte, display string) string {
+	h.t.Helper()
+
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	var created v1.AssetsBatchResponse
+	request := v1.CreateAssetsBatchRequest{Assets: []v1.AssetPayload{{Checksum: checksum, Display: display, SizeBytes: int64(len(content))}}}
+	h.DoJSON(http.MethodPost, "/batch/assets", request, http.StatusCreated, &created)
+	if len(created.Assets) != 1 || created.Assets[0].IngressUrl.Value() == "" {
+		h.t.Fatalf("create asset %s: no ingress url", checksum)
+	}
+
+	h.Upload(created.Assets[0].IngressUrl.Value(), checksum, content)
+	h.DoJSON(http.MethodPost, "/assets/"+checksum+"/finalize", nil, http.StatusOK, nil)
+	return checksum
+}
+
+// Upload puts content to a presigned upload url
+func (h *Harness) Upload(url string, checksum string, content []byte) {
+	h.t.Helper()
+
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(content))
+	if err != nil {
+		h.t.Fatalf("build upload: %v", err)
+	}
+	resp, err := h.listener.Client().Do(req)
+	if err != nil {
+		h.t.Fatalf("upload %s: %v", checksum, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(resp.Body)
+		h.t.Fatalf("upload %s: status %d: %s", checksum, resp.StatusCode, body)
+	}
+}
+
+// Download fetches the content of a ready asset through its presigned download url
+func (h *Harness) Download(checksum string) []byte {
+	h.t.Helper()
+
+	var egress v1.AssetEgressResponse
+	h.DoJSON(http.MethodGet, "/assets/"+checksum+"/egress", nil, http.StatusOK, &egress)
+
+	resp := h.Do(http.MethodGet, egress.DownloadURL, nil)
+	if resp.StatusCode != http.StatusOK {
+		h.t.Fatalf("download %s: status %d", checksum, resp.StatusCode)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		h.t.Fatalf("download %s: %v", checksum, err)
+	}
+	return content
+}
+
+func postgresDSN(endpoint string, user string, password string, name string) string {
+	e := registry.Endpoint(endpoint)
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		e.GetHost("localhost"), e.GetPort(5432), user, password, name)
+}
+
+func cmpEnv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func randomHex(t testing.TB, n int) string {
+	raw := make([]byte, n)
+	if _, err := rand.Read(raw); err != nil {
+		t.Fatalf("random: %v", err)
+	}
+	return hex.EncodeToString(raw)
+}